@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/plan"
+	"github.com/carlosarraes/subs-cli/internal/subformat"
+)
+
+type ApplyCmd struct {
+	Plan      string        `arg:"" type:"existingfile" help:"Plan file written by 'subs --dry-run --plan FILE'."`
+	Config    string        `short:"c" long:"config" type:"existingfile" help:"Path to custom YAML configuration file. Default location: ~/.subs-cli/config.yaml"`
+	Proxy     string        `long:"proxy" placeholder:"URL" help:"Proxy for provider requests, e.g. http://host:3128 or socks5://host:1080."`
+	DebugHTTP bool          `long:"debug-http" help:"Log provider HTTP requests and responses to stderr, with credentials redacted."`
+	WaitLock  time.Duration `long:"wait-lock" placeholder:"10m" help:"When another subs-cli run is downloading, wait up to this long for it to finish instead of exiting."`
+	Backup    bool          `long:"backup" help:"Keep a .bak copy of any subtitle file that gets replaced."`
+	Overwrite string        `long:"overwrite" placeholder:"always|never|if-better" help:"What to do when a target already exists: always replace it (default), never replace it, or replace it only if the planned subtitle scores higher than the one subs saved there (if-better)."`
+	NoEmoji   bool          `long:"no-emoji" help:"Replace emoji status symbols with plain ASCII markers."`
+	Quiet     bool          `short:"q" long:"quiet" help:"Hide progress bars."`
+}
+
+func (a *ApplyCmd) Run(ctx context.Context) error {
+	p, err := plan.Load(a.Plan)
+	if err != nil {
+		return err
+	}
+
+	cli := &CLI{Config: a.Config, Proxy: a.Proxy, DebugHTTP: a.DebugHTTP, Backup: a.Backup, Overwrite: a.Overwrite, WaitLock: a.WaitLock, NoEmoji: a.NoEmoji, Quiet: a.Quiet, ctx: ctx}
+	if err := cli.loadConfig(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	if cli.Overwrite == config.OverwriteIfBetter {
+		cli.loadFeedback()
+	}
+
+	l, err := cli.acquireLock()
+	if err != nil {
+		return err
+	}
+	defer l.Release()
+
+	cli.loadPlugins()
+	defer cli.closeProviders()
+	cli.loadArchive()
+
+	return cli.applyPlan(cli.newClient(cli.loadedConfig()), p)
+}
+
+func (c *CLI) applyPlan(client *api.OpenSubtitlesClient, p *plan.Plan) error {
+	ui := c.ui()
+	ui.Println(ui.Bold(fmt.Sprintf("Applying plan: %d download(s)", len(p.Downloads))))
+
+	failed := 0
+	for _, download := range p.Downloads {
+		if c.interrupted() {
+			return fmt.Errorf("interrupted before all planned downloads were applied")
+		}
+		if reason := c.keepReason(download.Target, download.MediaPath, download.Subtitle); reason != "" {
+			ui.Printf("  %s Keeping the existing %s: %s\n", ui.Info(ui.Icon(output.IconInfo)), download.Target, reason)
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(c.context(), fileTimeout(c.loadedConfig()))
+		err := c.applyDownload(ctx, client, download)
+		cancel()
+
+		if err != nil {
+			failed++
+			ui.Printf("  %s %s %v\n", ui.Icon(output.IconFailure), ui.Error(fmt.Sprintf("Failed to download %s:", download.Target)), err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d planned downloads failed", failed, len(p.Downloads))
+	}
+	return nil
+}
+
+func (c *CLI) applyDownload(ctx context.Context, client *api.OpenSubtitlesClient, download plan.Download) error {
+	if _, err := os.Stat(filepath.Dir(download.Target)); err != nil {
+		return fmt.Errorf("target directory: %w", err)
+	}
+
+	subtitle := download.Subtitle
+	var content []byte
+	if download.MergeCDs && subtitle.IsMultiPart() {
+		durations, err := download.Durations()
+		if err != nil {
+			return err
+		}
+
+		parts := make([][]byte, len(subtitle.Parts))
+		for i := range subtitle.Parts {
+			part, err := c.fetchSubtitle(ctx, client, subtitle.Part(i), download.Target)
+			if err != nil {
+				return fmt.Errorf("CD %d: %w", subtitle.Parts[i].CD, err)
+			}
+			parts[i] = part
+		}
+
+		if content, err = subformat.MergeSRT(parts, durations); err != nil {
+			return fmt.Errorf("cannot merge CD parts: %w", err)
+		}
+	} else {
+		var err error
+		if content, err = c.fetchSubtitle(ctx, client, subtitle, download.Target); err != nil {
+			return err
+		}
+	}
+
+	// The planned target keeps its name; only the extension follows the
+	// format the subtitle ends up in, e.g. after a MicroDVD conversion.
+	_, err := c.store(content, subtitle, download.MediaPath, func(format string) string {
+		return strings.TrimSuffix(download.Target, filepath.Ext(download.Target)) + "." + format
+	})
+	return err
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/plan"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanDownloads(t *testing.T) {
+	t.Parallel()
+
+	multiPart := &models.Subtitle{
+		FileID:    "1",
+		SubFormat: "srt",
+		Parts: []models.SubtitlePart{
+			{FileID: "1", FileName: "Movie.CD1.srt", CD: 1},
+			{FileID: "2", FileName: "Movie.CD2.srt", CD: 2},
+		},
+	}
+	outcome := &searchOutcome{candidates: map[string][]*models.Subtitle{
+		"en": {{FileID: "10", SubFormat: "srt"}, {FileID: "11", SubFormat: "ass"}},
+		"pt": {multiPart},
+	}}
+	settings := &fileSettings{config: config.Default(), languages: []string{"en", "pt"}}
+
+	t.Run("separate_parts", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		cli := &CLI{DryRun: true, MaxPerLanguage: 2, planned: plan.New(), out: output.New(&buf, output.Options{NoColor: true})}
+		cli.planDownloads("/media/Movie.mkv", settings, outcome)
+
+		var targets []string
+		for _, download := range cli.planned.Downloads {
+			targets = append(targets, download.Target)
+			assert.Equal(t, "/media/Movie.mkv", download.MediaPath)
+		}
+		assert.Equal(t, []string{"/media/Movie.en.srt", "/media/Movie.en.2.ass", "/media/Movie.pt.cd1.srt", "/media/Movie.pt.cd2.srt"}, targets)
+		assert.Equal(t, "2", cli.planned.Downloads[3].Subtitle.FileID)
+		assert.Contains(t, buf.String(), "Would save /media/Movie.pt.cd2.srt")
+	})
+
+	t.Run("merged_parts", func(t *testing.T) {
+		t.Parallel()
+
+		cli := &CLI{DryRun: true, MergeCDs: true, planned: plan.New(), out: output.New(&bytes.Buffer{}, output.Options{NoColor: true})}
+		cli.planDownloads("/media/Movie.mkv", settings, outcome)
+
+		require.Len(t, cli.planned.Downloads, 2)
+		merged := cli.planned.Downloads[1]
+		assert.Equal(t, "/media/Movie.pt.srt", merged.Target)
+		assert.True(t, merged.MergeCDs)
+		assert.Len(t, merged.Subtitle.Parts, 2)
+	})
+}
+
+func TestApplyPlan(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/login":
+			json.NewEncoder(w).Encode(map[string]interface{}{"token": "test-token", "status": 200})
+		case "/download":
+			var req api.DownloadRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			json.NewEncoder(w).Encode(map[string]interface{}{"link": fmt.Sprintf("http://%s/file/%d", r.Host, req.FileID)})
+		case "/file/1":
+			w.Write([]byte("1\n00:00:01,000 --> 00:00:02,000\nPart one\n"))
+		case "/file/2":
+			w.Write([]byte("1\n00:00:03,000 --> 00:00:04,000\nPart two\n"))
+		case "/file/4":
+			w.Write([]byte("{25}{50}Hola\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	p := plan.New()
+	p.Add(plan.Download{
+		MediaPath: filepath.Join(dir, "Movie.mkv"),
+		Target:    filepath.Join(dir, "custom", "Reviewed.srt"),
+		Language:  "en",
+		Subtitle:  &models.Subtitle{FileID: "1"},
+	})
+	p.Add(plan.Download{
+		MediaPath: filepath.Join(dir, "Movie.mkv"),
+		Target:    filepath.Join(dir, "Movie.pt.srt"),
+		Language:  "pt",
+		Subtitle: &models.Subtitle{FileID: "1", Parts: []models.SubtitlePart{
+			{FileID: "1", CD: 1},
+			{FileID: "2", CD: 2},
+		}},
+		MergeCDs:    true,
+		CDDurations: []string{"1h"},
+	})
+	p.Add(plan.Download{
+		MediaPath: filepath.Join(dir, "Movie.mkv"),
+		Target:    filepath.Join(dir, "Movie.fr.srt"),
+		Language:  "fr",
+		Subtitle:  &models.Subtitle{FileID: "3"},
+	})
+
+	var buf bytes.Buffer
+	cli := &CLI{Quiet: true, out: output.New(&buf, output.Options{NoColor: true})}
+	client := api.NewOpenSubtitlesClient(&api.Config{BaseURL: server.URL, Username: "u", Password: "p"})
+
+	err := cli.applyPlan(client, p)
+	assert.EqualError(t, err, "2 of 3 planned downloads failed")
+	assert.Contains(t, buf.String(), "target directory")
+	assert.NoFileExists(t, filepath.Join(dir, "custom", "Reviewed.srt"))
+	assert.NoFileExists(t, filepath.Join(dir, "Movie.fr.srt"))
+
+	content, err := os.ReadFile(filepath.Join(dir, "Movie.pt.srt"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "2\n01:00:03,000 --> 01:00:04,000\nPart two\n")
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "custom"), 0755))
+	p.Downloads = p.Downloads[:1]
+	require.NoError(t, cli.applyPlan(client, p))
+	content, err = os.ReadFile(filepath.Join(dir, "custom", "Reviewed.srt"))
+	require.NoError(t, err)
+	assert.Equal(t, "1\n00:00:01,000 --> 00:00:02,000\nPart one\n", string(content))
+	assert.Contains(t, buf.String(), "Saved "+filepath.Join(dir, "custom", "Reviewed.srt"))
+
+	p.Downloads = []plan.Download{{
+		MediaPath: filepath.Join(dir, "Movie.mkv"),
+		Target:    filepath.Join(dir, "Movie.es.sub"),
+		Language:  "es",
+		Subtitle:  &models.Subtitle{FileID: "4", FPS: 25},
+	}}
+	require.NoError(t, cli.applyPlan(client, p))
+	assert.NoFileExists(t, filepath.Join(dir, "Movie.es.sub"))
+	content, err = os.ReadFile(filepath.Join(dir, "Movie.es.srt"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "00:00:01,000 --> 00:00:02,000\nHola", "MicroDVD downloads are converted like regular ones")
+}
+
+func TestApplyPlan_Providers(t *testing.T) {
+	t.Parallel()
+
+	cli := &CLI{cfg: config.Default()}
+	bsplayer := cli.provider(api.ProviderBSPlayer)
+	require.NotNil(t, bsplayer, "providers not searched in this run are still available to a saved plan")
+	assert.Same(t, bsplayer, cli.provider(api.ProviderBSPlayer))
+	assert.Nil(t, cli.provider("unknown"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var buf bytes.Buffer
+	cli = &CLI{Quiet: true, ctx: ctx, out: output.New(&buf, output.Options{NoColor: true})}
+	p := plan.New()
+	p.Add(plan.Download{MediaPath: "/media/Movie.mkv", Target: "/media/Movie.en.srt", Language: "en", Subtitle: &models.Subtitle{FileID: "1"}})
+
+	err := cli.applyPlan(api.NewOpenSubtitlesClient(&api.Config{BaseURL: "http://127.0.0.1:0", APIKey: "key"}), p)
+	assert.EqualError(t, err, "interrupted before all planned downloads were applied")
+	assert.NotContains(t, buf.String(), "Failed to download")
+}
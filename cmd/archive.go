@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/moviehash"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+const onlineProviders = "online"
+
+func (c *CLI) loadArchive() {
+	dir := c.Archive
+	if dir == "" {
+		var err error
+		if dir, err = c.loadedConfig().Archive.Dir(); err != nil || dir == "" {
+			return
+		}
+	}
+
+	ui := c.ui()
+	info, err := os.Stat(dir)
+	if err == nil && !info.IsDir() {
+		err = fmt.Errorf("%s is not a directory", dir)
+	}
+	if err != nil {
+		ui.Printf("%s Skipping subtitle archive: %v\n", ui.Warning(ui.Icon(output.IconWarning)), err)
+		return
+	}
+	c.archive = api.NewLocalClient(dir)
+}
+
+func (c *CLI) searcher(client *api.OpenSubtitlesClient, cfg *config.Config) api.Client {
+	providers := c.providers(client)
+	if cfg.Anime || c.Anime {
+		providers = append(providers, c.animeProviders(cfg)...)
+	}
+	providers = append(providers, c.extraProviders(cfg.Providers, cfg)...)
+
+	online := api.NewMultiClient(providerTimeout(cfg), providers...)
+	if c.archive == nil {
+		return online
+	}
+	return api.NewChainClient(
+		api.Provider{Name: api.ProviderLocal, Client: c.archive},
+		api.Provider{Name: onlineProviders, Client: online},
+	)
+}
+
+func (c *CLI) hashMedia(params *models.SearchParams, filePath string) {
+	if c.Search != "" {
+		return
+	}
+	params.MediaPath = filePath
+	if source := c.mediaSource(filePath); source != filePath {
+		hash, size, err := c.hashRemote(source)
+		if err != nil {
+			ui := c.ui()
+			ui.Printf("  %s Could not hash the remote file, searching by name only: %v\n", ui.Warning(ui.Icon(output.IconWarning)), err)
+			return
+		}
+		params.MovieHash, params.MovieByteSize = hash, size
+		return
+	}
+	if f, ok := c.rars[filePath]; ok {
+		hash, size, err := hashRar(f)
+		if err != nil {
+			ui := c.ui()
+			ui.Printf("  %s Could not hash the video inside the RAR set, searching by name only: %v\n", ui.Warning(ui.Icon(output.IconWarning)), err)
+			return
+		}
+		params.MovieHash, params.MovieByteSize = hash, size
+		return
+	}
+	if hash, size, err := moviehash.Compute(filePath); err == nil {
+		params.MovieHash, params.MovieByteSize = hash, size
+	}
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func TestLoadArchive(t *testing.T) {
+	t.Parallel()
+
+	t.Run("searches the archive before online providers", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		name := "Inception.2010.1080p.BluRay.x264-SPARKS.en.srt"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("from the archive"), 0o644))
+
+		cfg := config.Default()
+		cfg.Archive.Path = dir
+		cli := &CLI{cfg: cfg, out: output.New(&bytes.Buffer{}, output.Options{NoColor: true})}
+		cli.loadArchive()
+		require.NotNil(t, cli.archive)
+
+		searcher := cli.searcher(api.NewOpenSubtitlesClient(&api.Config{APIKey: "key", BaseURL: "http://127.0.0.1:0"}), cfg)
+		subtitles, err := searcher.Search(context.Background(), &models.SearchParams{Query: "Inception", Year: 2010, Language: "en"})
+		require.NoError(t, err)
+		require.Len(t, subtitles, 1)
+		assert.Equal(t, api.ProviderLocal, subtitles[0].Provider)
+
+		content, err := cli.fetchSubtitle(context.Background(), nil, subtitles[0], filepath.Join(t.TempDir(), "movie.en.srt"))
+		require.NoError(t, err)
+		assert.Equal(t, "from the archive", string(content))
+	})
+
+	t.Run("flag overrides the config and missing folders are skipped", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.Default()
+		cfg.Archive.Path = t.TempDir()
+		var buf bytes.Buffer
+		cli := &CLI{cfg: cfg, Archive: filepath.Join(t.TempDir(), "missing"), out: output.New(&buf, output.Options{NoColor: true})}
+		cli.loadArchive()
+
+		assert.Nil(t, cli.archive)
+		assert.Contains(t, buf.String(), "Skipping subtitle archive:")
+	})
+
+	t.Run("no archive keeps the online search", func(t *testing.T) {
+		t.Parallel()
+
+		cli := &CLI{cfg: config.Default()}
+		cli.loadArchive()
+		assert.Nil(t, cli.archive)
+		assert.IsType(t, &api.MultiClient{}, cli.searcher(nil, cli.cfg))
+	})
+}
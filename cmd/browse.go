@@ -0,0 +1,300 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/filter"
+	"github.com/carlosarraes/subs-cli/internal/language"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+type pageFetcher func(page int, languages []string) ([]*models.Subtitle, int, error)
+
+type searchBrowser struct {
+	ui        *output.Renderer
+	prompt    *prompter
+	picker    *subtitlePicker
+	fetchPage pageFetcher
+	languages []string
+
+	language string
+	format   string
+	page     int
+	total    int
+	pages    map[int][]*models.Subtitle
+	selected []*models.Subtitle
+}
+
+func newSearchBrowser(ui *output.Renderer, prompt *prompter, languages []string, fetchPage pageFetcher, fetch subtitleFetcher) *searchBrowser {
+	return &searchBrowser{
+		ui:        ui,
+		prompt:    prompt,
+		picker:    newSubtitlePicker(ui, prompt, fetch),
+		fetchPage: fetchPage,
+		languages: languages,
+		pages:     make(map[int][]*models.Subtitle),
+	}
+}
+
+func (b *searchBrowser) browse() []*models.Subtitle {
+	if !b.load(1) {
+		return nil
+	}
+	b.render()
+
+	for {
+		answer := b.prompt.ask("  Browse", "")
+		command, argument, _ := strings.Cut(answer, " ")
+		argument = strings.TrimSpace(argument)
+
+		switch strings.ToLower(command) {
+		case "d", "download":
+			if len(b.selected) == 0 {
+				b.warn("Nothing selected yet: enter row numbers such as 1,3-5 first")
+				continue
+			}
+			return b.selected
+		case "":
+			if b.prompt.eof {
+				return nil
+			}
+		case "q", "quit":
+			b.ui.Printf("  Nothing downloaded.\n")
+			return nil
+		case "n", "next":
+			if b.page >= b.total {
+				b.warn("Already on the last page")
+				continue
+			}
+			if b.load(b.page + 1) {
+				b.render()
+			}
+		case "p", "prev":
+			if b.page <= 1 {
+				b.warn("Already on the first page")
+				continue
+			}
+			if b.load(b.page - 1) {
+				b.render()
+			}
+		case "l", "lang":
+			if argument != "" {
+				code, err := language.Normalize(argument)
+				if err != nil {
+					b.warn(fmt.Sprintf("Unknown language '%s'", argument))
+					continue
+				}
+				argument = code
+			}
+			previous := b.language
+			b.language, b.pages = argument, make(map[int][]*models.Subtitle)
+			if !b.load(1) {
+				b.language, b.pages = previous, make(map[int][]*models.Subtitle)
+				continue
+			}
+			b.render()
+		case "f", "format":
+			b.format = strings.ToLower(strings.TrimPrefix(argument, "."))
+			b.render()
+		case "a", "all":
+			for _, subtitle := range b.visible() {
+				if !b.isSelected(subtitle) {
+					b.selected = append(b.selected, subtitle)
+				}
+			}
+			b.render()
+		case "v", "view":
+			rows := b.visible()
+			index, err := strconv.Atoi(argument)
+			if err != nil || index < 1 || index > len(rows) {
+				b.warn(fmt.Sprintf("Preview needs a row number between 1 and %d, e.g. v 2", len(rows)))
+				continue
+			}
+			b.picker.preview(rows[index-1])
+		default:
+			indexes, err := parseRows(answer, len(b.visible()))
+			if err != nil {
+				b.warn(fmt.Sprintf("Invalid selection: %v", err))
+				continue
+			}
+			rows := b.visible()
+			for _, index := range indexes {
+				b.toggle(rows[index-1])
+			}
+			b.render()
+		}
+	}
+}
+
+func (b *searchBrowser) load(page int) bool {
+	if _, ok := b.pages[page]; ok {
+		b.page = page
+		return true
+	}
+
+	languages := b.languages
+	if b.language != "" {
+		languages = []string{b.language}
+	}
+	subtitles, total, err := b.fetchPage(page, languages)
+	if err != nil {
+		b.warn(fmt.Sprintf("Could not load page %d: %v", page, err))
+		return false
+	}
+
+	var usable []*models.Subtitle
+	for _, subtitle := range subtitles {
+		if subtitle.FileID != "" {
+			usable = append(usable, subtitle)
+		}
+	}
+	b.pages[page], b.page, b.total = usable, page, max(total, page)
+	return true
+}
+
+func (b *searchBrowser) visible() []*models.Subtitle {
+	var rows []*models.Subtitle
+	for _, subtitle := range b.pages[b.page] {
+		if b.format == "" || strings.EqualFold(subtitle.SubFormat, b.format) {
+			rows = append(rows, subtitle)
+		}
+	}
+	return rows
+}
+
+func (b *searchBrowser) isSelected(subtitle *models.Subtitle) bool {
+	for _, selected := range b.selected {
+		if selected == subtitle {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *searchBrowser) toggle(subtitle *models.Subtitle) {
+	for i, selected := range b.selected {
+		if selected == subtitle {
+			b.selected = append(b.selected[:i], b.selected[i+1:]...)
+			return
+		}
+	}
+	b.selected = append(b.selected, subtitle)
+}
+
+func (b *searchBrowser) render() {
+	ui := b.ui
+	languages := strings.Join(b.languages, ", ")
+	if b.language != "" {
+		languages = b.language
+	}
+	title := fmt.Sprintf("Results page %d of %d (%s", b.page, b.total, languages)
+	if b.format != "" {
+		title += ", " + b.format + " only"
+	}
+	ui.Printf("\n  %s %s\n", ui.Icon(output.IconList), ui.Bold(title+"):"))
+
+	rows := b.visible()
+	if len(rows) == 0 {
+		ui.Printf("  No subtitles on this page match the filters.\n")
+	}
+	for i, subtitle := range rows {
+		marker := " "
+		if b.isSelected(subtitle) {
+			marker = "*"
+		}
+		line := fmt.Sprintf("  %s %-3d %-8s %-5s %-50s %-15s %5.1f %7d", marker, i+1, subtitle.Language, subtitle.SubFormat, truncate(subtitle.ReleaseName, 50), truncate(subtitle.Uploader, 15), subtitle.Rating, subtitle.Downloads)
+		if marker == "*" {
+			line = ui.Bold(line)
+		}
+		ui.Println(line)
+	}
+
+	ui.Printf("  Selected: %d subtitle(s)\n", len(b.selected))
+	ui.Printf("  1,3-5: select/unselect  a: select page  n/p: next/previous page  l CODE: language (l: all)  f FORMAT: format (f: all)  v N: preview  d: download selected  q: quit\n")
+}
+
+func (b *searchBrowser) warn(message string) {
+	b.ui.Printf("  %s\n", b.ui.Warning(fmt.Sprintf("%s %s", b.ui.Icon(output.IconWarning), message)))
+}
+
+func parseRows(input string, count int) ([]int, error) {
+	var rows []int
+	for _, part := range strings.Split(input, ",") {
+		part = strings.TrimSpace(part)
+		first, last, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(strings.TrimSpace(first))
+		if err != nil {
+			return nil, fmt.Errorf("unknown choice '%s'", input)
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(strings.TrimSpace(last)); err != nil || end < start {
+				return nil, fmt.Errorf("invalid range '%s'", part)
+			}
+		}
+		if start < 1 || end > count {
+			return nil, fmt.Errorf("rows go from 1 to %d, got '%s'", count, part)
+		}
+		for row := start; row <= end; row++ {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (c *CLI) browseSearch(ctx context.Context, client *api.OpenSubtitlesClient, params *models.SearchParams, languages []string, dir string) error {
+	filters := c.filterOptions()
+	fetchPage := func(page int, languages []string) ([]*models.Subtitle, int, error) {
+		query := *params
+		query.Page, query.Language, query.Languages = page, strings.Join(languages, ","), nil
+		subtitles, total, err := client.SearchPage(ctx, &query)
+		if err != nil {
+			return nil, 0, err
+		}
+		return filter.Apply(filter.Dedupe(subtitles), filters), total, nil
+	}
+	fetch := func(subtitle *models.Subtitle) ([]byte, error) {
+		return client.Download(ctx, subtitle)
+	}
+
+	browser := newSearchBrowser(c.ui(), c.prompt(), languages, fetchPage, fetch)
+	selection := browser.browse()
+	if len(selection) == 0 {
+		return nil
+	}
+	if !c.DryRun {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("cannot create output directory: %w", err)
+		}
+	}
+
+	ui := c.ui()
+	ui.Printf("\n  %s Saving %d subtitle(s) to %s\n", ui.Icon(output.IconDownload), len(selection), dir)
+	for _, subtitle := range selection {
+		if c.quotaExceeded || c.interrupted() {
+			break
+		}
+		mediaPath := searchMediaPath(dir, subtitle, params.Query)
+		c.saveMatch(ctx, client, subtitle, browser.picker.fetched[subtitle], mediaPath, requestedLanguage(subtitle.Language, languages))
+	}
+	return nil
+}
+
+func requestedLanguage(code string, languages []string) string {
+	normalized, err := language.Normalize(code)
+	if err != nil {
+		return code
+	}
+	for _, requested := range languages {
+		if other, err := language.Normalize(requested); err == nil && other == normalized {
+			return requested
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func TestSearchBrowser(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		page      int
+		languages string
+	}
+
+	newBrowser := func(input string) (*searchBrowser, *[]request, *bytes.Buffer) {
+		var requests []request
+		fetchPage := func(page int, languages []string) ([]*models.Subtitle, int, error) {
+			requests = append(requests, request{page, strings.Join(languages, ",")})
+			if page == 3 {
+				return nil, 0, errors.New("server error")
+			}
+			var subtitles []*models.Subtitle
+			for _, language := range languages {
+				for i, format := range []string{"srt", "ass"} {
+					subtitles = append(subtitles, &models.Subtitle{
+						FileID:      fmt.Sprintf("%d-%s-%d", page, language, i),
+						Language:    language,
+						SubFormat:   format,
+						ReleaseName: fmt.Sprintf("Show.S01E%02d.720p", page*10+i),
+					})
+				}
+			}
+			subtitles = append(subtitles, &models.Subtitle{ReleaseName: "no file"})
+			return subtitles, 3, nil
+		}
+		fetch := func(subtitle *models.Subtitle) ([]byte, error) {
+			return []byte("1\n00:00:01,000 --> 00:00:02,000\nHello\n"), nil
+		}
+
+		var buf bytes.Buffer
+		ui := output.New(&buf, output.Options{NoColor: true, NoEmoji: true})
+		return newSearchBrowser(ui, newPrompter(strings.NewReader(input), &buf), []string{"en", "pt-BR"}, fetchPage, fetch), &requests, &buf
+	}
+	ids := func(subtitles []*models.Subtitle) []string {
+		var ids []string
+		for _, subtitle := range subtitles {
+			ids = append(ids, subtitle.FileID)
+		}
+		return ids
+	}
+
+	t.Run("selects across pages", func(t *testing.T) {
+		t.Parallel()
+
+		browser, requests, out := newBrowser("1,3-4\n3\nn\n2\np\nd\n")
+		selection := browser.browse()
+
+		assert.Equal(t, []string{"1-en-0", "1-pt-BR-1", "2-en-1"}, ids(selection))
+		assert.Equal(t, []request{{1, "en,pt-BR"}, {2, "en,pt-BR"}}, *requests, "visited pages are not fetched again")
+		assert.Contains(t, out.String(), "Results page 2 of 3 (en, pt-BR):")
+		assert.Contains(t, out.String(), "Selected: 3 subtitle(s)")
+		assert.NotContains(t, out.String(), "no file", "results without files are not offered")
+	})
+
+	t.Run("filters by language and format", func(t *testing.T) {
+		t.Parallel()
+
+		browser, requests, out := newBrowser("l pt-br\nf .ASS\na\nf\nl\nd\n")
+		selection := browser.browse()
+
+		assert.Equal(t, []string{"1-pt-BR-1"}, ids(selection))
+		assert.Equal(t, []request{{1, "en,pt-BR"}, {1, "pt-BR"}, {1, "en,pt-BR"}}, *requests)
+		assert.Contains(t, out.String(), "Results page 1 of 3 (pt-BR, ass only):")
+	})
+
+	t.Run("keeps the page when loading fails", func(t *testing.T) {
+		t.Parallel()
+
+		browser, _, out := newBrowser("p\nn\nn\n1\nd\n")
+		selection := browser.browse()
+
+		assert.Contains(t, out.String(), "Already on the first page")
+		assert.Contains(t, out.String(), "Could not load page 3: server error")
+		assert.Equal(t, []string{"2-en-0"}, ids(selection))
+	})
+
+	t.Run("previews and rejects bad input", func(t *testing.T) {
+		t.Parallel()
+
+		browser, _, out := newBrowser("v 2\nv 9\n0\n2-1\nl xx-yy\nd\nq\n")
+		assert.Empty(t, browser.browse())
+
+		assert.Contains(t, out.String(), "--- Preview: Show.S01E11.720p (srt) ---")
+		assert.Contains(t, out.String(), "Preview needs a row number between 1 and 4")
+		assert.Contains(t, out.String(), "Invalid selection: rows go from 1 to 4, got '0'")
+		assert.Contains(t, out.String(), "Invalid selection: invalid range '2-1'")
+		assert.Contains(t, out.String(), "Unknown language 'xx-yy'")
+		assert.Contains(t, out.String(), "Nothing selected yet")
+		assert.Contains(t, out.String(), "Nothing downloaded.")
+		require.Len(t, browser.picker.fetched, 1, "previewed subtitles are kept for the download")
+	})
+
+	t.Run("stops at end of input", func(t *testing.T) {
+		t.Parallel()
+
+		browser, _, _ := newBrowser("1")
+		assert.Empty(t, browser.browse())
+	})
+}
+
+func TestRequestedLanguage(t *testing.T) {
+	t.Parallel()
+
+	languages := []string{"en", "pt-BR"}
+	assert.Equal(t, "pt-BR", requestedLanguage("pt-br", languages))
+	assert.Equal(t, "en", requestedLanguage("EN", languages))
+	assert.Equal(t, "fr", requestedLanguage("fr", languages))
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import "github.com/carlosarraes/subs-cli/internal/api"
+
+func (c *CLI) requestBudget() *api.Budget {
+	if c.MaxRequests <= 0 {
+		return nil
+	}
+	if c.budget == nil {
+		c.budget = api.NewBudget(c.MaxRequests)
+	}
+	return c.budget
+}
+
+func (c *CLI) budgetSpent(current bool) bool {
+	if c.budget == nil {
+		return false
+	}
+	if current {
+		return c.budget.Denied()
+	}
+	return c.budget.Exhausted()
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/parser"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func TestRequestBudget(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, (&CLI{}).requestBudget())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total_pages":1,"page":1,"data":[]}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	cli := &CLI{MaxRequests: 1, cfg: config.Default(), out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+	budget := cli.requestBudget()
+	require.NotNil(t, budget)
+	assert.Same(t, budget, cli.requestBudget())
+
+	client := api.NewOpenSubtitlesClient(&api.Config{BaseURL: server.URL, APIKey: "key", Budget: budget})
+	_, err := client.Search(context.Background(), &models.SearchParams{Query: "Movie", Language: "en"})
+	require.NoError(t, err)
+
+	cli.processFiles(parser.New(), []string{"/media/Movie.One.mkv", "/media/Movie.Two.mkv"})
+	assert.Contains(t, buf.String(), "Request budget of 1 used up; 2 file(s) left for the next run:\n  /media/Movie.One.mkv\n  /media/Movie.Two.mkv\n")
+}
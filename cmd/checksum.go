@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func (c *CLI) checkChecksum(filePath string, info *models.MediaInfo) error {
+	if !c.VerifyChecksum || info.Checksum == "" || c.mediaSource(filePath) != filePath {
+		return nil
+	}
+	if _, ok := c.rars[filePath]; ok {
+		return nil
+	}
+
+	ui := c.ui()
+	ui.Printf("  %s Verifying CRC32 %s...\n", ui.Icon(output.IconSearch), info.Checksum)
+	if err := verifyChecksum(filePath, info.Checksum); err != nil {
+		return err
+	}
+	ui.Printf("  %s Checksum matches\n", ui.Success(ui.Icon(output.IconSuccess)))
+	return nil
+}
+
+func verifyChecksum(path, expected string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("cannot verify checksum: %w", err)
+	}
+	defer file.Close()
+
+	hash := crc32.NewIEEE()
+	if _, err := io.Copy(hash, file); err != nil {
+		return fmt.Errorf("cannot verify checksum: %w", err)
+	}
+	if actual := fmt.Sprintf("%08X", hash.Sum32()); actual != expected {
+		return fmt.Errorf("checksum mismatch: the file's CRC32 is %s but its name says %s; it may be damaged or incomplete", actual, expected)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func TestCheckChecksum(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "[Group] Show - 01 [CBF43926].mkv")
+	require.NoError(t, os.WriteFile(path, []byte("123456789"), 0644))
+
+	var buf bytes.Buffer
+	cli := &CLI{out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+	info := &models.MediaInfo{Checksum: "CBF43926"}
+	require.NoError(t, cli.checkChecksum(path, info))
+	assert.Empty(t, buf.String(), "verification is opt-in")
+
+	cli.VerifyChecksum = true
+	require.NoError(t, cli.checkChecksum(path, info))
+	assert.Contains(t, buf.String(), "Checksum matches")
+
+	info.Checksum = "DEADBEEF"
+	assert.EqualError(t, cli.checkChecksum(path, info), "checksum mismatch: the file's CRC32 is CBF43926 but its name says DEADBEEF; it may be damaged or incomplete")
+	assert.NoError(t, cli.checkChecksum(path, &models.MediaInfo{}))
+}
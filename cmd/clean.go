@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/internal/fsutil"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/rar"
+	"github.com/carlosarraes/subs-cli/internal/scan"
+	"github.com/carlosarraes/subs-cli/internal/subformat"
+)
+
+type CleanCmd struct {
+	Dir         string   `arg:"" type:"existingdir" help:"Directory to clean up."`
+	Recursive   bool     `short:"r" long:"recursive" help:"Also clean up subdirectories."`
+	MoveTo      string   `long:"move-to" placeholder:"DIR" help:"Move the files into DIR, keeping their paths relative to the cleaned directory, instead of deleting them."`
+	Prefer      []string `long:"prefer" sep:"," default:"srt,ass,ssa,vtt,smi,sub" help:"Format order used to pick the copy that is kept when a subtitle exists in several formats (comma-separated). Ties keep the newest file."`
+	NoDuplicate bool     `long:"no-duplicates" help:"Only clean up orphaned subtitles, leaving subtitles saved in several formats alone."`
+	DryRun      bool     `long:"dry-run" help:"List what would be removed or moved without touching any file."`
+	Interactive bool     `short:"i" long:"interactive" help:"Ask before removing or moving each file."`
+	Yes         bool     `short:"y" long:"yes" help:"Remove or move the files without asking."`
+	NoEmoji     bool     `long:"no-emoji" help:"Replace emoji status symbols with plain ASCII markers."`
+}
+
+type cleanup struct {
+	Path   string
+	Reason string
+}
+
+func (c *CleanCmd) Run(ctx context.Context) error {
+	return c.run(ctx, output.NewStdout(c.NoEmoji), os.Stdin)
+}
+
+func (c *CleanCmd) run(ctx context.Context, ui *output.Renderer, in io.Reader) error {
+	found, err := c.find()
+	if err != nil {
+		return err
+	}
+	if len(found) == 0 {
+		ui.Printf("%s No orphaned or duplicate subtitles in %s\n", ui.Success(ui.Icon(output.IconSuccess)), c.Dir)
+		return nil
+	}
+
+	ui.Printf("%s Found %d subtitle(s) to clean up in %s:\n", ui.Icon(output.IconSearch), len(found), c.Dir)
+	for _, item := range found {
+		ui.Printf("  %s (%s)\n", c.relative(item.Path), item.Reason)
+	}
+
+	verb := "Remove"
+	if c.MoveTo != "" {
+		verb = "Move"
+	}
+	if c.DryRun {
+		ui.Printf("%s Dry run: nothing was changed\n", ui.Info(ui.Icon(output.IconInfo)))
+		return nil
+	}
+
+	p := newPrompter(in, ui.Writer())
+	if !c.Yes && !c.Interactive && !p.askBool(fmt.Sprintf("%s %d file(s)?", verb, len(found)), false) {
+		ui.Println("Aborted: no files were changed.")
+		return nil
+	}
+
+	done, failed := 0, 0
+	for _, item := range found {
+		if ctx.Err() != nil {
+			ui.Printf("%s Interrupted: the remaining files were left alone\n", ui.Warning(ui.Icon(output.IconWarning)))
+			break
+		}
+		if c.Interactive && !c.Yes && !p.askBool(fmt.Sprintf("%s %s?", verb, c.relative(item.Path)), false) {
+			continue
+		}
+		if err := c.clean(item.Path); err != nil {
+			failed++
+			ui.Printf("  %s %v\n", ui.Warning(ui.Icon(output.IconWarning)), err)
+			continue
+		}
+		done++
+	}
+
+	if c.MoveTo != "" {
+		ui.Printf("%s Moved %d file(s) to %s\n", ui.Success(ui.Icon(output.IconSuccess)), done, c.MoveTo)
+	} else {
+		ui.Printf("%s Removed %d file(s)\n", ui.Success(ui.Icon(output.IconSuccess)), done)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d file(s) could not be cleaned up", failed)
+	}
+	return nil
+}
+
+func (c *CleanCmd) find() ([]cleanup, error) {
+	files, err := scan.Find(c.Dir, scan.Options{Recursive: c.Recursive})
+	if err != nil {
+		return nil, err
+	}
+
+	byDir := make(map[string][]string)
+	for _, file := range files {
+		dir := filepath.Dir(file)
+		byDir[dir] = append(byDir[dir], file)
+	}
+	videos := make(map[string][]string, len(byDir))
+	for dir, files := range byDir {
+		videos[dir] = videoStems(files)
+	}
+
+	var found []cleanup
+	for _, dir := range slices.Sorted(maps.Keys(byDir)) {
+		// Subtitles are often kept in a folder such as Subs/ next to the
+		// video, so the parent directory's videos count as well.
+		matches := append(slices.Clone(videos[dir]), videos[filepath.Dir(dir)]...)
+		found = append(found, c.findIn(byDir[dir], matches)...)
+	}
+	return found, nil
+}
+
+func videoStems(files []string) []string {
+	var videos []string
+	for _, file := range files {
+		name := filepath.Base(file)
+		switch {
+		case isVideoName(name):
+			videos = append(videos, stem(name))
+		case rar.IsArchive(file):
+			videos = append(videos, rarVideos(file)...)
+		}
+	}
+	return videos
+}
+
+func (c *CleanCmd) findIn(files, videos []string) []cleanup {
+	var subtitles []string
+	idx := make(map[string]bool)
+	for _, file := range files {
+		name := filepath.Base(file)
+		switch {
+		case isVideoName(name), rar.IsArchive(file):
+			continue
+		case strings.EqualFold(filepath.Ext(name), ".idx"):
+			idx[strings.ToLower(stem(file))] = true
+		case subformat.FromFileName(name) != "":
+			subtitles = append(subtitles, file)
+		}
+	}
+
+	var found []cleanup
+	copies := make(map[string][]string)
+	for _, subtitle := range subtitles {
+		if !hasVideo(filepath.Base(subtitle), videos) {
+			found = append(found, cleanup{Path: subtitle, Reason: "no matching video"})
+			continue
+		}
+		key := strings.ToLower(stem(subtitle))
+		if strings.EqualFold(filepath.Ext(subtitle), ".sub") && idx[key] {
+			continue
+		}
+		copies[key] = append(copies[key], subtitle)
+	}
+
+	if !c.NoDuplicate {
+		for _, group := range copies {
+			if len(group) < 2 {
+				continue
+			}
+			c.rank(group)
+			for _, duplicate := range group[1:] {
+				found = append(found, cleanup{Path: duplicate, Reason: "duplicate of " + filepath.Base(group[0])})
+			}
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool { return found[i].Path < found[j].Path })
+	return found
+}
+
+func (c *CleanCmd) rank(group []string) {
+	preference := func(path string) int {
+		format := subformat.FromFileName(path)
+		if i := slices.Index(c.Prefer, format); i >= 0 {
+			return i
+		}
+		return len(c.Prefer)
+	}
+	modified := func(path string) int64 {
+		if info, err := os.Stat(path); err == nil {
+			return info.ModTime().UnixNano()
+		}
+		return 0
+	}
+
+	sort.SliceStable(group, func(i, j int) bool {
+		if pi, pj := preference(group[i]), preference(group[j]); pi != pj {
+			return pi < pj
+		}
+		return modified(group[i]) > modified(group[j])
+	})
+}
+
+func (c *CleanCmd) clean(path string) error {
+	if c.MoveTo == "" {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		return nil
+	}
+
+	target := filepath.Join(c.MoveTo, c.relative(path))
+	if _, err := os.Stat(target); err == nil {
+		return fmt.Errorf("not moving %s: %s already exists", path, target)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(target), err)
+	}
+	if err := os.Rename(path, target); err == nil {
+		return nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err == nil {
+		err = fsutil.WriteFile(target, content, fsutil.WriteOptions{Perm: 0644})
+	}
+	if err == nil {
+		err = os.Remove(path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to move %s: %w", path, err)
+	}
+	return nil
+}
+
+func (c *CleanCmd) relative(path string) string {
+	if rel, err := filepath.Rel(c.Dir, path); err == nil {
+		return rel
+	}
+	return path
+}
+
+func stem(name string) string {
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+func hasVideo(name string, videos []string) bool {
+	lower := strings.ToLower(name)
+	for _, video := range videos {
+		if strings.HasPrefix(lower, strings.ToLower(video)+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func rarVideos(archive string) []string {
+	names := []string{stem(filepath.Base(archive))}
+	if !rar.IsFirstVolume(archive) {
+		return names
+	}
+
+	file, err := rar.Open(archive, isVideoName)
+	if err != nil {
+		return names
+	}
+	return append(names, stem(filepath.Base(file.Name)))
+}
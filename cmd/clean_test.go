@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/output"
+)
+
+func writeCleanFixture(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for _, name := range []string{
+		"Movie.2020.mkv",
+		"Movie.2020.en.srt",
+		"Movie.2020.pt-BR.srt",
+		"Movie.2020.pt-BR.vtt",
+		"Movie.2020.es.sub",
+		"Movie.2020.es.idx",
+		"Movie.2020.es.srt",
+		"Deleted.Movie.en.srt",
+		"notes.txt",
+		"Show/Show.S01E01.mkv",
+		"Show/Show.S01E01.en.ass",
+		"Show/Show.S01E02.en.srt",
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(name), 0644))
+	}
+	return dir
+}
+
+func TestCleanFind(t *testing.T) {
+	t.Parallel()
+
+	dir := writeCleanFixture(t)
+
+	found, err := (&CleanCmd{Dir: dir, Prefer: []string{"srt", "vtt"}}).find()
+	require.NoError(t, err)
+	assert.Equal(t, []cleanup{
+		{Path: filepath.Join(dir, "Deleted.Movie.en.srt"), Reason: "no matching video"},
+		{Path: filepath.Join(dir, "Movie.2020.pt-BR.vtt"), Reason: "duplicate of Movie.2020.pt-BR.srt"},
+	}, found, "a VobSub .sub with its .idx is not a duplicate")
+
+	found, err = (&CleanCmd{Dir: dir, Recursive: true, NoDuplicate: true}).find()
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, filepath.Join(dir, "Show", "Show.S01E02.en.srt"), found[1].Path)
+}
+
+func TestCleanFindSubsFolder(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, name := range []string{
+		"Movie/Movie.mkv",
+		"Movie/Subs/Movie.en.srt",
+		"Movie/Subs/Other.en.srt",
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(name), 0644))
+	}
+
+	found, err := (&CleanCmd{Dir: dir, Recursive: true}).find()
+	require.NoError(t, err)
+	assert.Equal(t, []cleanup{
+		{Path: filepath.Join(dir, "Movie", "Subs", "Other.en.srt"), Reason: "no matching video"},
+	}, found, "a subtitle in a folder next to its video is kept")
+}
+
+func TestCleanRank(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	older, newer := filepath.Join(dir, "a.srt"), filepath.Join(dir, "b.srt")
+	require.NoError(t, os.WriteFile(older, nil, 0644))
+	require.NoError(t, os.WriteFile(newer, nil, 0644))
+	require.NoError(t, os.Chtimes(older, time.Now().Add(-time.Hour), time.Now().Add(-time.Hour)))
+	vtt := filepath.Join(dir, "c.vtt")
+
+	group := []string{vtt, older, newer}
+	(&CleanCmd{Prefer: []string{"srt"}}).rank(group)
+	assert.Equal(t, []string{newer, older, vtt}, group)
+}
+
+func TestCleanRun(t *testing.T) {
+	t.Parallel()
+
+	t.Run("dry run changes nothing", func(t *testing.T) {
+		t.Parallel()
+
+		dir := writeCleanFixture(t)
+		var buf bytes.Buffer
+		require.NoError(t, (&CleanCmd{Dir: dir, DryRun: true}).run(context.Background(), output.New(&buf, output.Options{NoColor: true, NoEmoji: true}), strings.NewReader("")))
+		assert.Contains(t, buf.String(), "Found 2 subtitle(s) to clean up")
+		assert.Contains(t, buf.String(), "Deleted.Movie.en.srt (no matching video)")
+		assert.FileExists(t, filepath.Join(dir, "Deleted.Movie.en.srt"))
+	})
+
+	t.Run("asks before removing", func(t *testing.T) {
+		t.Parallel()
+
+		dir := writeCleanFixture(t)
+		ui := output.New(&bytes.Buffer{}, output.Options{NoColor: true, NoEmoji: true})
+		require.NoError(t, (&CleanCmd{Dir: dir, Prefer: []string{"srt"}}).run(context.Background(), ui, strings.NewReader("\n")))
+		assert.FileExists(t, filepath.Join(dir, "Deleted.Movie.en.srt"))
+
+		require.NoError(t, (&CleanCmd{Dir: dir, Prefer: []string{"srt"}}).run(context.Background(), ui, strings.NewReader("y\n")))
+		assert.NoFileExists(t, filepath.Join(dir, "Deleted.Movie.en.srt"))
+		assert.NoFileExists(t, filepath.Join(dir, "Movie.2020.pt-BR.vtt"))
+		assert.FileExists(t, filepath.Join(dir, "Movie.2020.pt-BR.srt"))
+	})
+
+	t.Run("interactive mode asks for each file", func(t *testing.T) {
+		t.Parallel()
+
+		dir := writeCleanFixture(t)
+		ui := output.New(&bytes.Buffer{}, output.Options{NoColor: true, NoEmoji: true})
+		require.NoError(t, (&CleanCmd{Dir: dir, Interactive: true, Prefer: []string{"srt"}}).run(context.Background(), ui, strings.NewReader("n\ny\n")))
+		assert.FileExists(t, filepath.Join(dir, "Deleted.Movie.en.srt"))
+		assert.NoFileExists(t, filepath.Join(dir, "Movie.2020.pt-BR.vtt"))
+	})
+
+	t.Run("stops when interrupted", func(t *testing.T) {
+		t.Parallel()
+
+		dir := writeCleanFixture(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var buf bytes.Buffer
+		require.NoError(t, (&CleanCmd{Dir: dir, Yes: true}).run(ctx, output.New(&buf, output.Options{NoColor: true, NoEmoji: true}), strings.NewReader("")))
+		assert.FileExists(t, filepath.Join(dir, "Deleted.Movie.en.srt"))
+		assert.Contains(t, buf.String(), "Interrupted")
+	})
+
+	t.Run("moves files keeping their relative path", func(t *testing.T) {
+		t.Parallel()
+
+		dir := writeCleanFixture(t)
+		trash := filepath.Join(t.TempDir(), "trash")
+		var buf bytes.Buffer
+		cmd := &CleanCmd{Dir: dir, Recursive: true, NoDuplicate: true, MoveTo: trash, Yes: true}
+		require.NoError(t, cmd.run(context.Background(), output.New(&buf, output.Options{NoColor: true, NoEmoji: true}), strings.NewReader("")))
+
+		assert.FileExists(t, filepath.Join(trash, "Deleted.Movie.en.srt"))
+		assert.FileExists(t, filepath.Join(trash, "Show", "Show.S01E02.en.srt"))
+		assert.NoFileExists(t, filepath.Join(dir, "Show", "Show.S01E02.en.srt"))
+		assert.Contains(t, buf.String(), "Moved 2 file(s) to "+trash)
+	})
+}
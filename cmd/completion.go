@@ -0,0 +1,375 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/language"
+)
+
+type CompletionCmd struct {
+	Shell string `arg:"" enum:"bash,zsh,fish,powershell" help:"Target shell: bash, zsh, fish or powershell."`
+}
+
+type CompleteCmd struct {
+	Kind string `arg:"" enum:"languages,providers" help:"Kind of values to list."`
+}
+
+func (c *CompletionCmd) Run(ctx *kong.Context) error {
+	return writeCompletion(os.Stdout, c.Shell, buildCompletionSpec(ctx.Model))
+}
+
+func (c *CompleteCmd) Run() error {
+	for _, value := range completionValues(c.Kind) {
+		fmt.Println(value)
+	}
+	return nil
+}
+
+func completionValues(kind string) []string {
+	switch kind {
+	case "languages":
+		return language.Codes()
+	case "providers":
+		return api.ProviderNames()
+	}
+	return nil
+}
+
+type completionFlag struct {
+	Long    string
+	Short   rune
+	Help    string
+	Values  []string
+	Dynamic string
+	Files   bool
+	Bool    bool
+}
+
+type completionCommand struct {
+	Name  string
+	Help  string
+	Flags []completionFlag
+	Args  []string
+	Files bool
+}
+
+type completionSpec struct {
+	Program  string
+	Root     completionCommand
+	Commands []completionCommand
+}
+
+func buildCompletionSpec(app *kong.Application) completionSpec {
+	spec := completionSpec{Program: app.Name}
+
+	for _, child := range app.Children {
+		if child.Hidden {
+			continue
+		}
+		command := completionCommandFromNode(child)
+		if child == app.DefaultCmd {
+			spec.Root = command
+			spec.Root.Name = ""
+			continue
+		}
+		spec.Commands = append(spec.Commands, command)
+	}
+
+	spec.Root.Flags = append(completionFlags(app.Node), spec.Root.Flags...)
+
+	return spec
+}
+
+func completionCommandFromNode(node *kong.Node) completionCommand {
+	command := completionCommand{
+		Name:  node.Name,
+		Help:  node.Help,
+		Flags: completionFlags(node),
+	}
+
+	for _, child := range node.Children {
+		if !child.Hidden {
+			command.Args = append(command.Args, child.Name)
+			command.Flags = append(command.Flags, completionFlags(child)...)
+		}
+	}
+
+	if len(node.Positional) > 0 {
+		first := node.Positional[0]
+		if first.Enum != "" {
+			command.Args = first.EnumSlice()
+		} else {
+			command.Files = true
+		}
+	}
+
+	return command
+}
+
+func completionFlags(node *kong.Node) []completionFlag {
+	var flags []completionFlag
+	for _, flag := range node.Flags {
+		if flag.Hidden {
+			continue
+		}
+		cf := completionFlag{
+			Long:    flag.Name,
+			Short:   flag.Short,
+			Help:    firstSentence(flag.Help),
+			Dynamic: flag.Tag.Get("completion"),
+			Bool:    flag.IsBool(),
+		}
+		if flag.Enum != "" {
+			cf.Values = flag.EnumSlice()
+		}
+		if flag.Tag.Type == "existingfile" || flag.Tag.Type == "path" || flag.Tag.Type == "existingdir" {
+			cf.Files = true
+		}
+		flags = append(flags, cf)
+	}
+	return flags
+}
+
+func firstSentence(help string) string {
+	if idx := strings.Index(help, ". "); idx >= 0 {
+		help = help[:idx]
+	}
+	return strings.TrimSuffix(help, ".")
+}
+
+func (s completionSpec) commandNames() []string {
+	names := make([]string, 0, len(s.Commands))
+	for _, command := range s.Commands {
+		names = append(names, command.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s completionSpec) allFlags() []completionFlag {
+	flags := append([]completionFlag{}, s.Root.Flags...)
+	for _, command := range s.Commands {
+		flags = append(flags, command.Flags...)
+	}
+	return flags
+}
+
+func writeCompletion(w io.Writer, shell string, spec completionSpec) error {
+	switch shell {
+	case "bash":
+		writeBashCompletion(w, spec)
+	case "zsh":
+		writeZshCompletion(w, spec)
+	case "fish":
+		writeFishCompletion(w, spec)
+	case "powershell":
+		writePowerShellCompletion(w, spec)
+	default:
+		return fmt.Errorf("unsupported shell '%s': expected bash, zsh, fish or powershell", shell)
+	}
+	return nil
+}
+
+func flagWords(flags []completionFlag) string {
+	words := make([]string, 0, len(flags)*2)
+	for _, flag := range flags {
+		words = append(words, "--"+flag.Long)
+		if flag.Short != 0 {
+			words = append(words, "-"+string(flag.Short))
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func flagPattern(flag completionFlag) string {
+	if flag.Short != 0 {
+		return fmt.Sprintf("-%c|--%s", flag.Short, flag.Long)
+	}
+	return "--" + flag.Long
+}
+
+func writeBashCompletion(w io.Writer, spec completionSpec) {
+	fn := "_" + strings.ReplaceAll(spec.Program, "-", "_") + "_completions"
+
+	fmt.Fprintf(w, "# bash completion for %s\n", spec.Program)
+	fmt.Fprintf(w, "%s() {\n", fn)
+	fmt.Fprintf(w, "    local cur prev\n")
+	fmt.Fprintf(w, "    cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	fmt.Fprintf(w, "    prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n\n")
+
+	fmt.Fprintf(w, "    case \"$prev\" in\n")
+	for _, flag := range spec.allFlags() {
+		switch {
+		case flag.Dynamic != "":
+			fmt.Fprintf(w, "        %s)\n", flagPattern(flag))
+			fmt.Fprintf(w, "            COMPREPLY=($(compgen -W \"$(%s __complete %s 2>/dev/null)\" -- \"${cur##*,}\"))\n", spec.Program, flag.Dynamic)
+			fmt.Fprintf(w, "            return ;;\n")
+		case len(flag.Values) > 0:
+			fmt.Fprintf(w, "        %s)\n", flagPattern(flag))
+			fmt.Fprintf(w, "            COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", strings.Join(flag.Values, " "))
+			fmt.Fprintf(w, "            return ;;\n")
+		case flag.Files:
+			fmt.Fprintf(w, "        %s)\n", flagPattern(flag))
+			fmt.Fprintf(w, "            COMPREPLY=($(compgen -f -- \"$cur\"))\n")
+			fmt.Fprintf(w, "            return ;;\n")
+		}
+	}
+	fmt.Fprintf(w, "    esac\n\n")
+
+	fmt.Fprintf(w, "    local command=\"\"\n")
+	fmt.Fprintf(w, "    if [[ ${COMP_CWORD} -gt 1 ]]; then\n")
+	fmt.Fprintf(w, "        command=\"${COMP_WORDS[1]}\"\n")
+	fmt.Fprintf(w, "    fi\n\n")
+
+	fmt.Fprintf(w, "    case \"$command\" in\n")
+	for _, command := range spec.Commands {
+		fmt.Fprintf(w, "        %s)\n", command.Name)
+		fmt.Fprintf(w, "            if [[ \"$cur\" == -* ]]; then\n")
+		fmt.Fprintf(w, "                COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", flagWords(command.Flags))
+		if len(command.Args) > 0 {
+			fmt.Fprintf(w, "            else\n")
+			fmt.Fprintf(w, "                COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", strings.Join(command.Args, " "))
+		} else if command.Files {
+			fmt.Fprintf(w, "            else\n")
+			fmt.Fprintf(w, "                COMPREPLY=($(compgen -f -- \"$cur\"))\n")
+		}
+		fmt.Fprintf(w, "            fi\n")
+		fmt.Fprintf(w, "            return ;;\n")
+	}
+	fmt.Fprintf(w, "    esac\n\n")
+
+	fmt.Fprintf(w, "    if [[ \"$cur\" == -* ]]; then\n")
+	fmt.Fprintf(w, "        COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", flagWords(spec.Root.Flags))
+	fmt.Fprintf(w, "    elif [[ ${COMP_CWORD} -eq 1 ]]; then\n")
+	fmt.Fprintf(w, "        COMPREPLY=($(compgen -W \"%s\" -- \"$cur\") $(compgen -f -- \"$cur\"))\n", strings.Join(spec.commandNames(), " "))
+	fmt.Fprintf(w, "    else\n")
+	fmt.Fprintf(w, "        COMPREPLY=($(compgen -f -- \"$cur\"))\n")
+	fmt.Fprintf(w, "    fi\n")
+	fmt.Fprintf(w, "}\n\n")
+	fmt.Fprintf(w, "complete -o default -F %s %s\n", fn, spec.Program)
+}
+
+func writeZshCompletion(w io.Writer, spec completionSpec) {
+	fmt.Fprintf(w, "#compdef %s\n\n", spec.Program)
+	fmt.Fprintf(w, "autoload -U +X bashcompinit && bashcompinit\n\n")
+	writeBashCompletion(w, spec)
+}
+
+func fishEscape(s string) string {
+	return strings.ReplaceAll(s, "'", "\\'")
+}
+
+func writeFishCompletion(w io.Writer, spec completionSpec) {
+	program := spec.Program
+	names := strings.Join(spec.commandNames(), " ")
+
+	fmt.Fprintf(w, "# fish completion for %s\n", program)
+	fmt.Fprintf(w, "complete -c %s -f\n", program)
+
+	for _, command := range spec.Commands {
+		fmt.Fprintf(w, "complete -c %s -n 'not __fish_seen_subcommand_from %s' -a '%s' -d '%s'\n",
+			program, names, command.Name, fishEscape(firstSentence(command.Help)))
+	}
+	fmt.Fprintf(w, "complete -c %s -n 'not __fish_seen_subcommand_from %s' -F\n", program, names)
+
+	writeFish := func(condition string, flag completionFlag) {
+		fmt.Fprintf(w, "complete -c %s -n '%s' -l %s", program, condition, flag.Long)
+		if flag.Short != 0 {
+			fmt.Fprintf(w, " -s %c", flag.Short)
+		}
+		switch {
+		case flag.Dynamic != "":
+			fmt.Fprintf(w, " -x -a '(%s __complete %s)'", program, flag.Dynamic)
+		case len(flag.Values) > 0:
+			fmt.Fprintf(w, " -x -a '%s'", strings.Join(flag.Values, " "))
+		case flag.Files:
+			fmt.Fprintf(w, " -r -F")
+		case !flag.Bool:
+			fmt.Fprintf(w, " -x")
+		}
+		fmt.Fprintf(w, " -d '%s'\n", fishEscape(flag.Help))
+	}
+
+	for _, flag := range spec.Root.Flags {
+		writeFish("not __fish_seen_subcommand_from "+names, flag)
+	}
+
+	for _, command := range spec.Commands {
+		condition := "__fish_seen_subcommand_from " + command.Name
+		for _, flag := range command.Flags {
+			writeFish(condition, flag)
+		}
+		if len(command.Args) > 0 {
+			fmt.Fprintf(w, "complete -c %s -n '%s' -a '%s'\n", program, condition, strings.Join(command.Args, " "))
+		}
+	}
+}
+
+func powerShellList(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, "'"+strings.ReplaceAll(value, "'", "''")+"'")
+	}
+	return "@(" + strings.Join(quoted, ", ") + ")"
+}
+
+func writePowerShellCompletion(w io.Writer, spec completionSpec) {
+	program := spec.Program
+
+	fmt.Fprintf(w, "# powershell completion for %s\n", program)
+	fmt.Fprintf(w, "Register-ArgumentCompleter -Native -CommandName '%s' -ScriptBlock {\n", program)
+	fmt.Fprintf(w, "    param($wordToComplete, $commandAst, $cursorPosition)\n\n")
+	fmt.Fprintf(w, "    $words = @($commandAst.CommandElements | ForEach-Object { $_.ToString() })\n")
+	fmt.Fprintf(w, "    $prev = if ($wordToComplete) { $words[-2] } else { $words[-1] }\n")
+	fmt.Fprintf(w, "    $command = if ($words.Count -gt 1) { $words[1] } else { '' }\n")
+	fmt.Fprintf(w, "    $candidates = @()\n\n")
+
+	fmt.Fprintf(w, "    switch ($prev) {\n")
+	for _, flag := range spec.allFlags() {
+		var source string
+		switch {
+		case flag.Dynamic != "":
+			source = fmt.Sprintf("@(& '%s' __complete %s)", program, flag.Dynamic)
+		case len(flag.Values) > 0:
+			source = powerShellList(flag.Values)
+		default:
+			continue
+		}
+		patterns := []string{"'--" + flag.Long + "'"}
+		if flag.Short != 0 {
+			patterns = append(patterns, fmt.Sprintf("'-%c'", flag.Short))
+		}
+		fmt.Fprintf(w, "        { $_ -in %s } { $candidates = %s }\n", strings.Join(patterns, ", "), source)
+	}
+	fmt.Fprintf(w, "    }\n\n")
+
+	fmt.Fprintf(w, "    if ($candidates.Count -eq 0) {\n")
+	fmt.Fprintf(w, "        switch ($command) {\n")
+	for _, command := range spec.Commands {
+		values := append(flagList(command.Flags), command.Args...)
+		fmt.Fprintf(w, "            '%s' { $candidates = %s }\n", command.Name, powerShellList(values))
+	}
+	rootValues := append(flagList(spec.Root.Flags), spec.commandNames()...)
+	fmt.Fprintf(w, "            default { $candidates = %s }\n", powerShellList(rootValues))
+	fmt.Fprintf(w, "        }\n")
+	fmt.Fprintf(w, "    }\n\n")
+
+	fmt.Fprintf(w, "    $candidates | Where-Object { $_ -like \"$wordToComplete*\" } | ForEach-Object {\n")
+	fmt.Fprintf(w, "        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)\n")
+	fmt.Fprintf(w, "    }\n")
+	fmt.Fprintf(w, "}\n")
+}
+
+func flagList(flags []completionFlag) []string {
+	values := make([]string, 0, len(flags))
+	for _, flag := range flags {
+		values = append(values, "--"+flag.Long)
+	}
+	return values
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestParser(t *testing.T) (*kong.Kong, *App) {
+	t.Helper()
+
+	app := &App{}
+	parser, err := kong.New(app, kong.Name("subs"), kong.Exit(func(int) {}))
+	require.NoError(t, err)
+	return parser, app
+}
+
+func TestAppParsing(t *testing.T) {
+	t.Parallel()
+
+	t.Run("path without command uses default get command", func(t *testing.T) {
+		t.Parallel()
+
+		parser, app := newTestParser(t)
+		ctx, err := parser.Parse([]string{t.TempDir(), "-l", "en,pt-BR", "--dry-run"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "get <path>", ctx.Command())
+		assert.Equal(t, []string{"en", "pt-BR"}, app.Get.Language)
+		assert.True(t, app.Get.DryRun)
+	})
+
+	t.Run("completion command", func(t *testing.T) {
+		t.Parallel()
+
+		parser, app := newTestParser(t)
+		ctx, err := parser.Parse([]string{"completion", "fish"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "completion <shell>", ctx.Command())
+		assert.Equal(t, "fish", app.Completion.Shell)
+	})
+
+	t.Run("rejects unknown shell", func(t *testing.T) {
+		t.Parallel()
+
+		parser, _ := newTestParser(t)
+		_, err := parser.Parse([]string{"completion", "tcsh"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestBuildCompletionSpec(t *testing.T) {
+	t.Parallel()
+
+	parser, _ := newTestParser(t)
+	spec := buildCompletionSpec(parser.Model)
+
+	assert.Equal(t, "subs", spec.Program)
+	assert.Contains(t, spec.commandNames(), "completion")
+	assert.NotContains(t, spec.commandNames(), "__complete")
+	assert.NotContains(t, spec.commandNames(), "get")
+
+	var language, config *completionFlag
+	for i := range spec.Root.Flags {
+		switch spec.Root.Flags[i].Long {
+		case "language":
+			language = &spec.Root.Flags[i]
+		case "config":
+			config = &spec.Root.Flags[i]
+		}
+	}
+
+	require.NotNil(t, language)
+	assert.Equal(t, 'l', language.Short)
+	assert.Equal(t, "languages", language.Dynamic)
+
+	require.NotNil(t, config)
+	assert.True(t, config.Files)
+}
+
+func TestWriteCompletion(t *testing.T) {
+	t.Parallel()
+
+	parser, _ := newTestParser(t)
+	spec := buildCompletionSpec(parser.Model)
+
+	tests := []struct {
+		shell    string
+		contains []string
+	}{
+		{
+			shell: "bash",
+			contains: []string{
+				"complete -o default -F _subs_completions subs",
+				"-l|--language)",
+				"subs __complete languages",
+				"bash zsh fish powershell",
+			},
+		},
+		{
+			shell: "zsh",
+			contains: []string{
+				"#compdef subs",
+				"bashcompinit",
+				"complete -o default -F _subs_completions subs",
+			},
+		},
+		{
+			shell: "fish",
+			contains: []string{
+				"complete -c subs",
+				"-l language -s l -x -a '(subs __complete languages)'",
+				"-a 'bash zsh fish powershell'",
+			},
+		},
+		{
+			shell: "powershell",
+			contains: []string{
+				"Register-ArgumentCompleter -Native -CommandName 'subs'",
+				"@(& 'subs' __complete languages)",
+				"'completion' { $candidates = @('bash', 'zsh', 'fish', 'powershell') }",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.shell, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			require.NoError(t, writeCompletion(&buf, tt.shell, spec))
+
+			for _, expected := range tt.contains {
+				assert.Contains(t, buf.String(), expected)
+			}
+		})
+	}
+
+	t.Run("unsupported shell", func(t *testing.T) {
+		t.Parallel()
+
+		err := writeCompletion(&bytes.Buffer{}, "tcsh", spec)
+		assert.Error(t, err)
+	})
+}
+
+func TestCompletionValues(t *testing.T) {
+	t.Parallel()
+
+	assert.Contains(t, completionValues("languages"), "pt-BR")
+	assert.Equal(t, []string{"opensubtitles", "opensubtitles-xmlrpc", "jimaku", "kitsunekko", "napiprojekt", "napisy24", "bsplayer"}, completionValues("providers"))
+	assert.Nil(t, completionValues("unknown"))
+}
@@ -0,0 +1,415 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/language"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/tui"
+)
+
+type ConfigCmd struct {
+	Init     ConfigInitCmd     `cmd:"" help:"Run the interactive setup wizard and write the configuration file."`
+	Edit     ConfigEditCmd     `cmd:"" help:"Change single settings from a menu, or with --set key=value, validating them before the file is written."`
+	Validate ConfigValidateCmd `cmd:"" help:"Check the configuration file for unknown keys, wrong types and invalid values, reporting each with its line and column."`
+}
+
+type ConfigInitCmd struct {
+	Output string `short:"o" long:"output" type:"path" help:"Where to write the configuration file. Default location: ~/.subs-cli/config.yaml"`
+	Force  bool   `short:"f" long:"force" help:"Overwrite an existing configuration file without asking."`
+}
+
+func (c *ConfigInitCmd) Run() error {
+	return c.run(os.Stdin, os.Stdout)
+}
+
+func (c *ConfigInitCmd) run(in io.Reader, out io.Writer) error {
+	path := c.Output
+	if path == "" {
+		defaultPath, err := config.DefaultPath()
+		if err != nil {
+			return err
+		}
+		path = defaultPath
+	}
+
+	p := newPrompter(in, out)
+	cfg := config.Default()
+
+	if _, err := os.Stat(path); err == nil {
+		existing, err := config.Load(path)
+		if err == nil {
+			cfg = existing
+		}
+		if !c.Force && !p.askBool(fmt.Sprintf("Config file %s already exists. Overwrite?", path), false) {
+			fmt.Fprintln(out, "Aborted: existing configuration left unchanged.")
+			return nil
+		}
+	}
+
+	fmt.Fprintln(out, "subs-cli setup")
+	fmt.Fprintln(out, "Press Enter to keep the value shown in brackets.")
+	fmt.Fprintln(out)
+
+	fmt.Fprintln(out, "OpenSubtitles account (https://www.opensubtitles.com/consumers)")
+	cfg.OpenSubtitles.APIKey = p.ask("API key", cfg.OpenSubtitles.APIKey)
+	cfg.OpenSubtitles.Username = p.ask("Username (leave empty to use the API key only)", cfg.OpenSubtitles.Username)
+	if password := p.askSecret("Password"); password != "" {
+		cfg.OpenSubtitles.Password = password
+	}
+	fmt.Fprintln(out)
+
+	for {
+		answer := p.ask("Default languages (comma-separated)", strings.Join(defaultLanguages(cfg), ","))
+		languages, err := parseLanguageList(answer)
+		if err == nil {
+			cfg.Defaults.Languages = languages
+			break
+		}
+		fmt.Fprintf(out, "  %v\n", err)
+	}
+
+	for {
+		naming := p.ask("Subtitle naming style (language: movie.en.srt, plain: movie.srt)", cfg.Output.Naming)
+		if naming == config.NamingLanguage || naming == config.NamingPlain {
+			cfg.Output.Naming = naming
+			break
+		}
+		fmt.Fprintf(out, "  naming style must be '%s' or '%s'\n", config.NamingLanguage, config.NamingPlain)
+	}
+
+	cfg.Defaults.Interactive = p.askBool("Use interactive selection by default?", cfg.Defaults.Interactive)
+	cfg.Output.NoEmoji = p.askBool("Use plain ASCII instead of emoji in output?", cfg.Output.NoEmoji)
+
+	if err := cfg.Save(path); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nConfiguration written to %s (permissions 0600)\n", path)
+	return nil
+}
+
+type ConfigEditCmd struct {
+	Set    []string `long:"set" placeholder:"KEY=VALUE" help:"Set a value without prompting, e.g. --set output.naming=plain. Lists are comma-separated. Repeatable."`
+	Config string   `short:"c" long:"config" type:"path" help:"Configuration file to edit. Default location: ~/.subs-cli/config.yaml"`
+}
+
+func (c *ConfigEditCmd) Run() error {
+	return c.run(os.Stdin, os.Stdout)
+}
+
+func (c *ConfigEditCmd) run(in io.Reader, out io.Writer) error {
+	path := c.Config
+	if path == "" {
+		defaultPath, err := config.DefaultPath()
+		if err != nil {
+			return err
+		}
+		path = defaultPath
+	}
+
+	cfg := config.Default()
+	if _, err := os.Stat(path); err == nil {
+		existing, err := config.Load(path)
+		if err != nil {
+			return fmt.Errorf("%w; fix it by hand or move it away and run 'subs config init'", err)
+		}
+		cfg = existing
+	}
+
+	if len(c.Set) > 0 {
+		for _, assignment := range c.Set {
+			key, value, ok := strings.Cut(assignment, "=")
+			if !ok {
+				return fmt.Errorf("--set expects KEY=VALUE, got '%s'", assignment)
+			}
+			if err := setConfigValue(cfg, strings.TrimSpace(key), value); err != nil {
+				return err
+			}
+		}
+		if err := cfg.Save(path); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Configuration written to %s\n", path)
+		return nil
+	}
+
+	return editConfig(newPrompter(in, out), out, cfg, path)
+}
+
+func editConfig(p *prompter, out io.Writer, cfg *config.Config, path string) error {
+	changed := false
+	for {
+		fields := cfg.Fields()
+		fmt.Fprintf(out, "\nSettings in %s:\n", path)
+		for i, field := range fields {
+			fmt.Fprintf(out, "  %2d. %s = %s\n", i+1, field.Key, displayValue(field))
+		}
+
+		answer := p.ask("\nSetting to change (number or key; Enter to save, q to quit without saving)", "")
+		switch answer {
+		case "":
+			if !changed {
+				fmt.Fprintln(out, "No changes.")
+				return nil
+			}
+			if err := cfg.Save(path); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Configuration written to %s\n", path)
+			return nil
+		case "q", "quit":
+			fmt.Fprintln(out, "Aborted: configuration left unchanged.")
+			return nil
+		}
+
+		field, ok := pickField(fields, answer)
+		if !ok {
+			fmt.Fprintf(out, "  unknown setting '%s'\n", answer)
+			continue
+		}
+
+		label := fmt.Sprintf("%s (%s)", field.Key, field.Kind)
+		if field.Kind == config.KindList {
+			label = fmt.Sprintf("%s (comma-separated)", field.Key)
+		}
+		var value string
+		if field.Secret {
+			value = p.askSecret(label)
+			if value == "" {
+				continue
+			}
+		} else {
+			value = p.ask(label, field.Value)
+		}
+
+		if err := setConfigValue(cfg, field.Key, value); err != nil {
+			fmt.Fprintf(out, "  %v\n", err)
+			continue
+		}
+		changed = true
+	}
+}
+
+func pickField(fields []config.Field, answer string) (config.Field, bool) {
+	if n, err := strconv.Atoi(answer); err == nil {
+		if n < 1 || n > len(fields) {
+			return config.Field{}, false
+		}
+		return fields[n-1], true
+	}
+	for _, field := range fields {
+		if field.Key == answer {
+			return field, true
+		}
+	}
+	return config.Field{}, false
+}
+
+func displayValue(field config.Field) string {
+	switch {
+	case field.Secret && field.Value != "":
+		return "(set)"
+	case field.Value == "":
+		return "(empty)"
+	}
+	return field.Value
+}
+
+func setConfigValue(cfg *config.Config, key, value string) error {
+	if key == "defaults.languages" && strings.TrimSpace(value) != "" {
+		languages, err := parseLanguageList(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		value = strings.Join(languages, ",")
+	}
+	return cfg.Set(key, value)
+}
+
+func defaultLanguages(cfg *config.Config) []string {
+	if len(cfg.Defaults.Languages) > 0 {
+		return cfg.Defaults.Languages
+	}
+	return []string{"en"}
+}
+
+func parseLanguageList(value string) ([]string, error) {
+	var languages []string
+	for _, lang := range strings.Split(value, ",") {
+		lang = strings.TrimSpace(lang)
+		if lang == "" {
+			continue
+		}
+		if !isValidLanguageCode(lang) {
+			return nil, fmt.Errorf("invalid language code format '%s': expected format like 'en' or 'pt-BR'", lang)
+		}
+		code, err := language.Normalize(lang)
+		if err != nil {
+			return nil, fmt.Errorf("%w; run 'subs languages' to list supported codes", err)
+		}
+		languages = append(languages, code)
+	}
+
+	if len(languages) == 0 {
+		return nil, fmt.Errorf("at least one language must be specified")
+	}
+
+	return languages, nil
+}
+
+type prompter struct {
+	in       *bufio.Reader
+	out      io.Writer
+	file     *os.File
+	terminal bool
+	eof      bool
+}
+
+func newPrompter(in io.Reader, out io.Writer) *prompter {
+	p := &prompter{
+		in:  bufio.NewReader(in),
+		out: out,
+	}
+	if f, ok := in.(*os.File); ok {
+		p.file = f
+		p.terminal = output.IsTerminal(f)
+	}
+	return p
+}
+
+func (p *prompter) readLine() string {
+	line, err := p.in.ReadString('\n')
+	p.eof = err != nil
+	return strings.TrimSpace(line)
+}
+
+func (p *prompter) ask(label, current string) string {
+	if current != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", label, current)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", label)
+	}
+
+	if answer := p.readLine(); answer != "" {
+		return answer
+	}
+	return current
+}
+
+func (p *prompter) edit(label, initial string) (string, bool) {
+	if p.terminal {
+		value, ok, err := tui.EditLine(p.file, p.out, label+": ", initial)
+		if err == nil {
+			return strings.TrimSpace(value), ok
+		}
+	}
+
+	return p.ask(label, initial), true
+}
+
+func (p *prompter) askBool(label string, current bool) bool {
+	hint := "y/N"
+	if current {
+		hint = "Y/n"
+	}
+	fmt.Fprintf(p.out, "%s [%s]: ", label, hint)
+
+	switch strings.ToLower(p.readLine()) {
+	case "y", "yes":
+		return true
+	case "n", "no":
+		return false
+	}
+	return current
+}
+
+func (p *prompter) askSecret(label string) string {
+	fmt.Fprintf(p.out, "%s (input hidden, Enter to keep current): ", label)
+
+	if p.terminal && setEcho(false) {
+		defer func() {
+			setEcho(true)
+			fmt.Fprintln(p.out)
+		}()
+	}
+
+	return p.readLine()
+}
+
+func setEcho(enabled bool) bool {
+	mode := "-echo"
+	if enabled {
+		mode = "echo"
+	}
+	cmd := exec.Command("stty", mode)
+	cmd.Stdin = os.Stdin
+	return cmd.Run() == nil
+}
+
+type ConfigValidateCmd struct {
+	Path string `arg:"" optional:"" type:"path" help:"Configuration file to check. Default location: ~/.subs-cli/config.yaml"`
+}
+
+func (c *ConfigValidateCmd) Run() error {
+	return c.run(os.Stdout)
+}
+
+func (c *ConfigValidateCmd) run(out io.Writer) error {
+	path := c.Path
+	if path == "" {
+		defaultPath, err := config.DefaultPath()
+		if err != nil {
+			return err
+		}
+		path = defaultPath
+	}
+
+	problems, err := config.CheckFile(path)
+	if err != nil {
+		return err
+	}
+	if len(problems) == 0 {
+		fmt.Fprintf(out, "%s is valid\n", path)
+		return nil
+	}
+
+	for _, problem := range problems {
+		fmt.Fprintf(out, "%s:%s\n", path, problem)
+	}
+	return fmt.Errorf("found %d problem(s) in %s", len(problems), path)
+}
+
+// configLoadError adds the positioned problems from config.CheckFile to a
+// failed load, which otherwise only names the first bad setting.
+func configLoadError(path string, err error) error {
+	problems, checkErr := config.CheckFile(path)
+	if checkErr != nil || len(problems) == 0 {
+		return err
+	}
+	lines := make([]string, len(problems))
+	for i, problem := range problems {
+		lines[i] = fmt.Sprintf("  %s:%s", path, problem)
+	}
+	return fmt.Errorf("%w\n%s", err, strings.Join(lines, "\n"))
+}
+
+func (c *CLI) warnConfigProblems(path string) {
+	problems, err := config.CheckFile(path)
+	if err != nil || len(problems) == 0 {
+		return
+	}
+
+	ui := c.ui()
+	ui.Printf("%s Found %d problem(s) in %s (run 'subs config validate' for details):\n", ui.Warning(ui.Icon(output.IconWarning)), len(problems), path)
+	for _, problem := range problems {
+		ui.Printf("  %s:%s\n", path, problem)
+	}
+}
@@ -0,0 +1,464 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigInit(t *testing.T) {
+	t.Parallel()
+
+	t.Run("writes answers to config file", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		answers := strings.Join([]string{
+			"my-api-key",
+			"myuser",
+			"mypassword",
+			"pt-BR, en",
+			"plain",
+			"y",
+			"n",
+		}, "\n") + "\n"
+
+		var out bytes.Buffer
+		cmd := &ConfigInitCmd{Output: path}
+		require.NoError(t, cmd.run(strings.NewReader(answers), &out))
+
+		cfg, err := config.Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, "my-api-key", cfg.OpenSubtitles.APIKey)
+		assert.Equal(t, "myuser", cfg.OpenSubtitles.Username)
+		assert.Equal(t, "mypassword", cfg.OpenSubtitles.Password)
+		assert.Equal(t, []string{"pt-BR", "en"}, cfg.Defaults.Languages)
+		assert.Equal(t, config.NamingPlain, cfg.Output.Naming)
+		assert.True(t, cfg.Defaults.Interactive)
+		assert.False(t, cfg.Output.NoEmoji)
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		assert.Contains(t, out.String(), "Configuration written to")
+	})
+
+	t.Run("re-prompts on invalid answers and keeps defaults", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		answers := strings.Join([]string{
+			"", "", "",
+			"english!",
+			"",
+			"fancy",
+			"",
+			"", "",
+		}, "\n") + "\n"
+
+		var out bytes.Buffer
+		cmd := &ConfigInitCmd{Output: path}
+		require.NoError(t, cmd.run(strings.NewReader(answers), &out))
+
+		cfg, err := config.Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"en"}, cfg.Defaults.Languages)
+		assert.Equal(t, config.NamingLanguage, cfg.Output.Naming)
+		assert.Contains(t, out.String(), "invalid language code format 'english!'")
+		assert.Contains(t, out.String(), "naming style must be")
+	})
+
+	t.Run("does not overwrite existing file without confirmation", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("opensubtitles:\n  username: original\n"), 0600))
+
+		var out bytes.Buffer
+		cmd := &ConfigInitCmd{Output: path}
+		require.NoError(t, cmd.run(strings.NewReader("n\n"), &out))
+
+		cfg, err := config.Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, "original", cfg.OpenSubtitles.Username)
+		assert.Contains(t, out.String(), "Aborted")
+	})
+
+	t.Run("force overwrites and keeps existing values as defaults", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("opensubtitles:\n  username: original\n  password: pw\n"), 0600))
+
+		cmd := &ConfigInitCmd{Output: path, Force: true}
+		require.NoError(t, cmd.run(strings.NewReader(strings.Repeat("\n", 7)), &bytes.Buffer{}))
+
+		cfg, err := config.Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, "original", cfg.OpenSubtitles.Username)
+		assert.Equal(t, "pw", cfg.OpenSubtitles.Password)
+	})
+}
+
+func TestConfigEdit(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sets values non-interactively", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("opensubtitles:\n  username: original\n"), 0600))
+
+		var out bytes.Buffer
+		cmd := &ConfigEditCmd{Config: path, Set: []string{"output.naming=plain", "defaults.languages=pt-br, EN", "providers=napisy24"}}
+		require.NoError(t, cmd.run(strings.NewReader(""), &out))
+
+		cfg, err := config.Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, "original", cfg.OpenSubtitles.Username)
+		assert.Equal(t, config.NamingPlain, cfg.Output.Naming)
+		assert.Equal(t, []string{"pt-BR", "en"}, cfg.Defaults.Languages)
+		assert.Equal(t, []string{"napisy24"}, cfg.Providers)
+		assert.Contains(t, out.String(), "Configuration written to "+path)
+	})
+
+	t.Run("rejects invalid values without writing", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		for _, set := range []string{"output.naming=fancy", "cache.ttl=forever", "defaults.languages=english!", "nope=1", "output.naming"} {
+			cmd := &ConfigEditCmd{Config: path, Set: []string{"cache.enabled=false", set}}
+			assert.Error(t, cmd.run(strings.NewReader(""), &bytes.Buffer{}), set)
+		}
+		assert.NoFileExists(t, path)
+	})
+
+	t.Run("edits from the menu", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		answers := strings.Join([]string{
+			"output.naming", "fancy",
+			"output.naming", "plain",
+			"999",
+			"opensubtitles.password", "hunter2",
+			"",
+		}, "\n") + "\n"
+
+		var out bytes.Buffer
+		cmd := &ConfigEditCmd{Config: path}
+		require.NoError(t, cmd.run(strings.NewReader(answers), &out))
+
+		cfg, err := config.Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, config.NamingPlain, cfg.Output.Naming)
+		assert.Equal(t, "hunter2", cfg.OpenSubtitles.Password)
+		assert.Contains(t, out.String(), "output.naming must be")
+		assert.Contains(t, out.String(), "unknown setting '999'")
+		assert.Contains(t, out.String(), "opensubtitles.password = (set)")
+		assert.NotContains(t, out.String(), "= hunter2")
+	})
+
+	t.Run("quits without saving", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		var out bytes.Buffer
+		cmd := &ConfigEditCmd{Config: path}
+		require.NoError(t, cmd.run(strings.NewReader("output.naming\nplain\nq\n"), &out))
+		assert.NoFileExists(t, path)
+		assert.Contains(t, out.String(), "Aborted")
+	})
+}
+
+func TestApplyConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("config defaults fill unset flags", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.Default()
+		cfg.Defaults.Languages = []string{"pt-BR"}
+		cfg.Defaults.Interactive = true
+		cfg.Defaults.Confirm = true
+		cfg.Defaults.AutoSelect = true
+		cfg.Output.NoEmoji = true
+		cfg.Output.Backup = true
+
+		cli := &CLI{}
+		cli.applyConfig(cfg)
+
+		assert.Equal(t, []string{"pt-BR"}, cli.Language)
+		assert.True(t, cli.Interactive)
+		assert.True(t, cli.Confirm)
+		assert.True(t, cli.Yes)
+		assert.True(t, cli.NoEmoji)
+		assert.True(t, cli.Backup)
+	})
+
+	t.Run("flags take precedence over config", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.Default()
+		cfg.Defaults.Languages = []string{"pt-BR"}
+
+		cli := &CLI{Language: []string{"es"}}
+		cli.applyConfig(cfg)
+
+		assert.Equal(t, []string{"es"}, cli.Language)
+	})
+
+	t.Run("falls back to english", func(t *testing.T) {
+		t.Parallel()
+
+		cli := &CLI{getenv: func(string) string { return "" }}
+		cli.applyConfig(config.Default())
+
+		assert.Equal(t, []string{"en"}, cli.Language)
+		assert.Contains(t, cli.langSource, "default")
+	})
+
+	t.Run("uses system locale without flag or config", func(t *testing.T) {
+		t.Parallel()
+
+		env := map[string]string{"LANG": "pt_BR.UTF-8"}
+		cli := &CLI{getenv: func(name string) string { return env[name] }}
+		cli.applyConfig(config.Default())
+
+		assert.Equal(t, []string{"pt-BR"}, cli.Language)
+		assert.Equal(t, "from system locale LANG=pt_BR.UTF-8", cli.langSource)
+
+		result, err := cli.validateLanguages()
+		require.NoError(t, err)
+		assert.Equal(t, "Language codes validated: pt-BR (Portuguese (Brazil)) - from system locale LANG=pt_BR.UTF-8", result.Message)
+	})
+
+	t.Run("unknown locale falls back to english", func(t *testing.T) {
+		t.Parallel()
+
+		env := map[string]string{"LC_ALL": "C", "LANG": "pt_BR.UTF-8"}
+		cli := &CLI{getenv: func(name string) string { return env[name] }}
+		cli.applyConfig(config.Default())
+
+		assert.Equal(t, []string{"en"}, cli.Language)
+		assert.Contains(t, cli.langSource, "LC_ALL=C")
+	})
+
+	t.Run("config languages win over locale", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.Default()
+		cfg.Defaults.Languages = []string{"es"}
+
+		cli := &CLI{getenv: func(string) string { return "pt_BR.UTF-8" }}
+		cli.applyConfig(cfg)
+
+		assert.Equal(t, []string{"es"}, cli.Language)
+		assert.Empty(t, cli.langSource)
+	})
+
+	t.Run("credentials reach the api config", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.Default()
+		cfg.OpenSubtitles = config.OpenSubtitlesConfig{APIKey: "key", Username: "user", Password: "pass"}
+
+		cli := &CLI{}
+		cli.applyConfig(cfg)
+		credentials := apiConfig(cli.loadedConfig())
+
+		assert.Equal(t, "key", credentials.APIKey)
+		assert.Equal(t, "user", credentials.Username)
+		assert.Equal(t, "pass", credentials.Password)
+	})
+
+	t.Run("network settings reach the api config", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.Default()
+		assert.Equal(t, config.DefaultTimeout, apiConfig(cfg).Timeout)
+		assert.Equal(t, config.DefaultFileTimeout, fileTimeout(cfg))
+
+		cfg.Network = config.NetworkConfig{Timeout: "10s", DialTimeout: "2s", FileTimeout: "3m", MaxIdleConns: 8}
+		apiCfg := apiConfig(cfg)
+		assert.Equal(t, 10*time.Second, apiCfg.Timeout)
+		assert.Equal(t, 2*time.Second, apiCfg.DialTimeout)
+		assert.Equal(t, 8, apiCfg.MaxIdleConns)
+		assert.Equal(t, 3*time.Minute, fileTimeout(cfg))
+	})
+
+	t.Run("timeout flags override the config file independently", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("network:\n  timeout: 20s\n  file_timeout: 3m\n"), 0600))
+
+		cli := &CLI{Config: path, FileTimeout: 5 * time.Minute, Quiet: true}
+		require.NoError(t, cli.loadConfig())
+		assert.Equal(t, 5*time.Minute, fileTimeout(cli.loadedConfig()))
+		assert.Equal(t, 20*time.Second, apiConfig(cli.loadedConfig()).Timeout)
+
+		cli = &CLI{Config: path, Timeout: time.Minute, Quiet: true}
+		require.NoError(t, cli.loadConfig())
+		assert.Equal(t, 3*time.Minute, fileTimeout(cli.loadedConfig()))
+		assert.Equal(t, time.Minute, apiConfig(cli.loadedConfig()).Timeout)
+
+		cli = &CLI{Config: path, FileTimeout: -time.Second, Quiet: true}
+		assert.EqualError(t, cli.loadConfig(), "--file-timeout must be a positive duration, got -1s")
+	})
+
+	t.Run("breaker is shared across files", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.Default()
+		cfg.Network.FailureThreshold = 1
+		cli := &CLI{}
+
+		first := cli.breaker(api.ProviderOpenSubtitles, cfg)
+		first.Record(errors.New("timeout"))
+		assert.Same(t, first, cli.breaker(api.ProviderOpenSubtitles, cfg))
+		assert.True(t, cli.breaker(api.ProviderOpenSubtitles, cfg).Open())
+	})
+
+	t.Run("proxy reaches the api config", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.Default()
+		cfg.Proxy = "http://config:3128"
+		cfg.OpenSubtitles.Proxy = "http://opensubtitles:3128"
+		require.NoError(t, overrideProxy(cfg, "socks5://flag:1080"))
+		assert.Equal(t, "socks5://flag:1080", apiConfig(cfg).Proxy, "the flag also replaces the OpenSubtitles proxy")
+
+		cfg.OpenSubtitles.Proxy = config.ProxyDirect
+		assert.True(t, apiConfig(cfg).NoProxy)
+		assert.Empty(t, apiConfig(cfg).Proxy)
+
+		err := overrideProxy(cfg, "localhost:8080")
+		assert.ErrorContains(t, err, "--proxy: invalid proxy")
+	})
+}
+
+func TestSettingsFor(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T) (string, string) {
+		root := t.TempDir()
+		novelas := filepath.Join(root, "Telenovelas")
+		require.NoError(t, os.MkdirAll(novelas, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(novelas, ".subsrc"), []byte("defaults:\n  languages: [es]\n"), 0644))
+		return root, novelas
+	}
+
+	t.Run("directory override replaces default languages", func(t *testing.T) {
+		t.Parallel()
+
+		root, novelas := setup(t)
+		cli := &CLI{}
+		cli.applyConfig(config.Default())
+
+		settings, err := cli.settingsFor(filepath.Join(novelas, "Show.S01E01.mkv"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"es"}, settings.languages)
+		assert.Len(t, settings.sources, 1)
+
+		settings, err = cli.settingsFor(filepath.Join(root, "Movie.2020.mkv"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"en"}, settings.languages)
+		assert.Empty(t, settings.sources)
+	})
+
+	t.Run("explicit language flag wins over override", func(t *testing.T) {
+		t.Parallel()
+
+		_, novelas := setup(t)
+		cli := &CLI{Language: []string{"fr"}}
+		cli.applyConfig(config.Default())
+
+		settings, err := cli.settingsFor(filepath.Join(novelas, "Show.S01E01.mkv"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"fr"}, settings.languages)
+	})
+
+	t.Run("invalid override languages are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".subs.yaml"), []byte("defaults:\n  languages: [spanish!]\n"), 0644))
+		cli := &CLI{}
+		cli.applyConfig(config.Default())
+
+		_, err := cli.settingsFor(filepath.Join(dir, "Show.S01E01.mkv"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid languages in")
+	})
+}
+
+func TestConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.yaml")
+	require.NoError(t, os.WriteFile(valid, []byte("output:\n  naming: plain\n"), 0600))
+
+	var out bytes.Buffer
+	require.NoError(t, (&ConfigValidateCmd{Path: valid}).run(&out))
+	assert.Equal(t, valid+" is valid\n", out.String())
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte("output:\n  naming: plain\n  colour: true\nproviders: [subscene]\n"), 0600))
+
+	out.Reset()
+	err := (&ConfigValidateCmd{Path: invalid}).run(&out)
+	assert.ErrorContains(t, err, "found 2 problem(s)")
+	assert.Contains(t, out.String(), invalid+":3:3: unknown key 'colour' in output")
+	assert.Contains(t, out.String(), invalid+":4:13: providers: unknown provider 'subscene'")
+
+	var warnings bytes.Buffer
+	c := &CLI{out: output.New(&warnings, output.Options{NoColor: true, NoEmoji: true})}
+	c.warnConfigProblems(invalid)
+	assert.Contains(t, warnings.String(), "Found 2 problem(s) in "+invalid)
+
+	warnings.Reset()
+	c.warnConfigProblems(valid)
+	assert.Empty(t, warnings.String())
+}
+
+func TestLoadConfigReportsPositions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   string
+		expected string
+	}{
+		{"bad_language", "output:\n  naming: plain\ndefaults:\n  languages: [en, \"\"]\n", ":4:19: defaults.languages: unknown language code ''"},
+		{"wrong_type", "defaults:\n  interactive: sometimes\n", ":2:16: defaults.interactive: expected true or false, got 'sometimes'"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.config), 0600))
+
+			c := &CLI{Config: path, out: output.New(io.Discard, output.Options{NoColor: true, NoEmoji: true})}
+			err := c.loadConfig()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "config file '"+path+"'")
+			assert.Contains(t, err.Error(), path+tt.expected)
+		})
+	}
+}
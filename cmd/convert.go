@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/subformat"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func (c *CLI) normalizeText(content []byte) []byte {
+	out := c.loadedConfig().Output
+	return subformat.Normalize(content, out.LineEndings, out.BOM)
+}
+
+func (c *CLI) postProcess(content []byte) []byte {
+	out := c.loadedConfig().Output
+	out.StripTags = c.StripTags
+	out.Reflow.Enabled = c.Reflow
+	return postProcess(content, out)
+}
+
+func postProcess(content []byte, out config.OutputConfig) []byte {
+	if out.StripTags {
+		content = subformat.StripTags(content, subformat.Detect(content))
+	}
+	if out.Reflow.Enabled {
+		content = subformat.Reflow(content, subformat.ReflowOptions{MaxLineLength: out.Reflow.MaxLineLength, MaxCPS: out.Reflow.MaxCPS})
+	}
+	return subformat.Normalize(content, out.LineEndings, out.BOM)
+}
+
+func (c *CLI) convertMicroDVD(content []byte, subtitle *models.Subtitle) []byte {
+	if c.KeepFormat || !subformat.IsMicroDVD(content) {
+		return content
+	}
+
+	fps := subtitle.FPS
+	if fps == 0 && c.video != nil {
+		fps = c.video.FPS
+	}
+	if header := subformat.MicroDVDFPS(content); header > 0 {
+		fps = header
+	}
+
+	ui := c.ui()
+	converted, err := subformat.MicroDVDToSRT(content, fps)
+	if err != nil {
+		ui.Printf("    %s Keeping the MicroDVD subtitle as .sub: %v (run with --probe to read it from the video)\n", ui.Warning(ui.Icon(output.IconWarning)), err)
+		return content
+	}
+	ui.Printf("    %s Converted the frame-based MicroDVD subtitle to SRT at %.3f fps\n", ui.Info(ui.Icon(output.IconInfo)), fps)
+	subtitle.SubFormat = subformat.SRT
+	return converted
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func TestSaveSubtitleConvertsMicroDVD(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	media := filepath.Join(dir, "Movie.mkv")
+	content := []byte("{25}{50}Hello\n{75}{100}{y:i}World\n")
+
+	var buf bytes.Buffer
+	c := &CLI{cfg: config.Default(), out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+	require.NoError(t, c.saveSubtitle(content, &models.Subtitle{ID: "1", Language: "en", FPS: 25}, media, "en", true))
+
+	saved, err := os.ReadFile(filepath.Join(dir, "Movie.en.srt"))
+	require.NoError(t, err)
+	assert.Equal(t, "1\n00:00:01,000 --> 00:00:02,000\nHello\n\n2\n00:00:03,000 --> 00:00:04,000\n<i>World</i>\n\n", string(saved))
+	assert.Contains(t, buf.String(), "Converted the frame-based MicroDVD subtitle to SRT at 25.000 fps")
+}
+
+func TestSaveSubtitleKeepsMicroDVDWithoutFPS(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	media := filepath.Join(dir, "Movie.mkv")
+	content := []byte("{25}{50}Hello\n")
+
+	var buf bytes.Buffer
+	c := &CLI{cfg: config.Default(), out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+	require.NoError(t, c.saveSubtitle(content, &models.Subtitle{ID: "1", Language: "en"}, media, "en", true))
+
+	saved, err := os.ReadFile(filepath.Join(dir, "Movie.en.sub"))
+	require.NoError(t, err)
+	assert.Equal(t, content, saved)
+	assert.Contains(t, buf.String(), "Keeping the MicroDVD subtitle as .sub")
+}
+
+func TestSaveSubtitleVobSubPair(t *testing.T) {
+	t.Parallel()
+
+	idx := []byte("# VobSub index file, v7 (do not modify this line!)\nsize: 720x480\n")
+	sub := []byte{0x00, 0x00, 0x01, 0xBA, 0x44, 0x00}
+	var archive bytes.Buffer
+	w := zip.NewWriter(&archive)
+	for name, data := range map[string][]byte{"movie.idx": idx, "movie.sub": sub} {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	dir := t.TempDir()
+	media := filepath.Join(dir, "Movie.mkv")
+	var buf bytes.Buffer
+	c := &CLI{cfg: config.Default(), out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+	require.NoError(t, c.saveSubtitle(archive.Bytes(), &models.Subtitle{ID: "1", Language: "en"}, media, "en", true))
+
+	savedIdx, err := os.ReadFile(filepath.Join(dir, "Movie.en.idx"))
+	require.NoError(t, err)
+	assert.Equal(t, idx, savedIdx)
+	savedSub, err := os.ReadFile(filepath.Join(dir, "Movie.en.sub"))
+	require.NoError(t, err)
+	assert.Equal(t, sub, savedSub)
+}
+
+func TestSaveSubtitleKeepFormat(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	media := filepath.Join(dir, "Movie.mkv")
+	microDVD := []byte("{1}{1}25\n{25}{50}Hello\n")
+	ass := []byte("[Script Info]\nScriptType: v4.00+\n\n[Events]\nFormat: Layer, Start, End, Style, Text\nDialogue: 0,0:00:01.00,0:00:02.00,Default,{\\k20}Hello\n")
+
+	var buf bytes.Buffer
+	c := &CLI{KeepFormat: true, cfg: config.Default(), out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+	require.NoError(t, c.saveSubtitle(microDVD, &models.Subtitle{ID: "1", Language: "en"}, media, "en", true))
+	require.NoError(t, c.saveSubtitle(ass, &models.Subtitle{ID: "2", Language: "pt-BR", SubFormat: "srt"}, media, "pt-BR", false))
+
+	saved, err := os.ReadFile(filepath.Join(dir, "Movie.en.sub"))
+	require.NoError(t, err)
+	assert.Equal(t, microDVD, saved)
+	saved, err = os.ReadFile(filepath.Join(dir, "Movie.ass"))
+	require.NoError(t, err)
+	assert.Equal(t, ass, saved)
+	assert.NotContains(t, buf.String(), "Converted")
+}
+
+func TestSaveSubtitleNormalizesText(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	media := filepath.Join(dir, "Movie.mkv")
+	cfg := config.Default()
+	cfg.Output.LineEndings = config.LineEndingsCRLF
+	cfg.Output.BOM = config.BOMAdd
+
+	var buf bytes.Buffer
+	c := &CLI{cfg: cfg, out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+	require.NoError(t, c.saveSubtitle([]byte("1\n00:00:01,000 --> 00:00:02,000\nHello\n"), &models.Subtitle{ID: "1", Language: "en"}, media, "en", true))
+
+	saved, err := os.ReadFile(filepath.Join(dir, "Movie.en.srt"))
+	require.NoError(t, err)
+	assert.Equal(t, "\ufeff1\r\n00:00:01,000 --> 00:00:02,000\r\nHello\r\n", string(saved))
+}
+
+func TestSaveSubtitleReflow(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	media := filepath.Join(dir, "Movie.mkv")
+	content := []byte("1\n00:00:01,000 --> 00:00:05,000\nI never said she stole my money, I only said it was missing\n")
+
+	var buf bytes.Buffer
+	c := &CLI{Reflow: true, cfg: config.Default(), out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+	require.NoError(t, c.saveSubtitle(content, &models.Subtitle{ID: "1", Language: "en"}, media, "en", true))
+
+	saved, err := os.ReadFile(filepath.Join(dir, "Movie.en.srt"))
+	require.NoError(t, err)
+	assert.Equal(t, "1\n00:00:01,000 --> 00:00:05,000\nI never said she stole my money,\nI only said it was missing\n\n", string(saved))
+}
+
+func TestSaveSubtitleStripTags(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	media := filepath.Join(dir, "Movie.mkv")
+	cfg := config.Default()
+	cfg.Output.LineEndings = config.LineEndingsCRLF
+
+	var buf bytes.Buffer
+	c := &CLI{StripTags: true, cfg: cfg, out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+	require.NoError(t, c.saveSubtitle([]byte("1\n00:00:01,000 --> 00:00:02,000\n{\\an8}<i>Hello</i>\n"), &models.Subtitle{ID: "1", Language: "en"}, media, "en", true))
+
+	saved, err := os.ReadFile(filepath.Join(dir, "Movie.en.srt"))
+	require.NoError(t, err)
+	assert.Equal(t, "1\r\n00:00:01,000 --> 00:00:02,000\r\nHello\r\n", string(saved))
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/internal/fsutil"
+	"github.com/carlosarraes/subs-cli/internal/moviehash"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/rar"
+)
+
+func (c *CLI) findDuplicates(files []string) map[string]string {
+	if len(files) < 2 || c.Stdout {
+		return nil
+	}
+
+	duplicates := make(map[string]string)
+	first := make(map[string]string)
+	for _, file := range files {
+		if rar.IsArchive(file) || c.mediaSource(file) != file {
+			continue
+		}
+		hash, size, err := moviehash.Compute(file)
+		if err != nil {
+			continue
+		}
+		key := fmt.Sprintf("%s/%d", hash, size)
+		if original, ok := first[key]; ok {
+			duplicates[file] = original
+			continue
+		}
+		first[key] = file
+	}
+	return duplicates
+}
+
+func (c *CLI) rememberSaved(mediaPath, target string) {
+	if c.saved == nil {
+		c.saved = make(map[string][]string)
+	}
+	c.saved[mediaPath] = append(c.saved[mediaPath], target)
+}
+
+func (c *CLI) linkDuplicate(original, duplicate string) bool {
+	ui := c.ui()
+	targets := c.saved[original]
+	if len(targets) == 0 {
+		ui.Printf("  %s Same video as %s, which got no subtitles; searching for this copy by its own name\n", ui.Info(ui.Icon(output.IconInfo)), original)
+		return false
+	}
+	ui.Printf("  %s Same video as %s (identical hash), reusing its subtitles instead of downloading again\n", ui.Info(ui.Icon(output.IconInfo)), original)
+
+	originalStem, duplicateStem := stem(original), stem(duplicate)
+	for _, target := range targets {
+		link := duplicateStem + strings.TrimPrefix(target, originalStem)
+		if c.keepExisting(link, duplicate, nil) {
+			continue
+		}
+		if c.DryRun {
+			ui.Printf("    %s Would link %s to %s\n", ui.Icon(output.IconDownload), c.previewTarget(link), filepath.Base(target))
+			continue
+		}
+		kind, err := c.linkSubtitle(target, link)
+		if err != nil {
+			ui.Printf("    %s %v\n", ui.Warning(fmt.Sprintf("%s Failed to link subtitle:", ui.Icon(output.IconWarning))), err)
+			continue
+		}
+		ui.Printf("    %s %s %s (%s to %s)\n", ui.Icon(output.IconSaved), ui.Success("Linked"), link, kind, filepath.Base(target))
+		c.summary.Linked++
+		c.rememberSaved(duplicate, link)
+	}
+	return true
+}
+
+func (c *CLI) linkSubtitle(target, link string) (string, error) {
+	if _, err := os.Lstat(link); err == nil {
+		if c.Backup {
+			err = os.Rename(link, fsutil.BackupPath(link))
+		} else {
+			err = os.Remove(link)
+		}
+		if err != nil {
+			return "", fmt.Errorf("cannot replace %s: %w", link, err)
+		}
+	}
+
+	if err := os.Link(target, link); err == nil {
+		return "hard link", nil
+	}
+	abs, err := filepath.Abs(target)
+	if err != nil {
+		return "", err
+	}
+	if err := os.Symlink(abs, link); err != nil {
+		return "", fmt.Errorf("cannot link %s to %s: %w", link, target, err)
+	}
+	return "symlink", nil
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	video := bytes.Repeat([]byte("frame"), 20000)
+	paths := map[string][]byte{
+		"a/Movie.mkv":      video,
+		"b/Movie.Copy.mkv": video,
+		"c/Other.mkv":      bytes.Repeat([]byte("other"), 20000),
+		"d/Tiny.mkv":       []byte("too small to hash"),
+		"e/Tiny.mkv":       []byte("too small to hash"),
+	}
+	for name, content := range paths {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, content, 0644))
+	}
+	linked := filepath.Join(dir, "c", "Movie.Link.mkv")
+	require.NoError(t, os.Link(filepath.Join(dir, "a/Movie.mkv"), linked))
+
+	files := []string{
+		filepath.Join(dir, "a/Movie.mkv"),
+		filepath.Join(dir, "b/Movie.Copy.mkv"),
+		filepath.Join(dir, "c/Other.mkv"),
+		linked,
+		filepath.Join(dir, "d/Tiny.mkv"),
+		filepath.Join(dir, "e/Tiny.mkv"),
+	}
+	duplicates := (&CLI{}).findDuplicates(files)
+	assert.Equal(t, map[string]string{
+		files[1]: files[0],
+		linked:   files[0],
+	}, duplicates)
+
+	assert.Nil(t, (&CLI{}).findDuplicates(files[:1]))
+}
+
+func TestLinkDuplicate(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	original := filepath.Join(dir, "a", "Movie.mkv")
+	duplicate := filepath.Join(dir, "b", "Movie.Copy.mkv")
+	require.NoError(t, os.MkdirAll(filepath.Dir(original), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Dir(duplicate), 0755))
+	subtitle := filepath.Join(dir, "a", "Movie.en.srt")
+	require.NoError(t, os.WriteFile(subtitle, []byte("1\n"), 0644))
+
+	var buf bytes.Buffer
+	c := &CLI{cfg: config.Default(), out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+	assert.False(t, c.linkDuplicate(original, duplicate), "nothing saved for the original means the copy is searched normally")
+
+	c.rememberSaved(original, subtitle)
+	require.True(t, c.linkDuplicate(original, duplicate))
+
+	link := filepath.Join(dir, "b", "Movie.Copy.en.srt")
+	a, err := os.Stat(subtitle)
+	require.NoError(t, err)
+	b, err := os.Stat(link)
+	require.NoError(t, err)
+	assert.True(t, os.SameFile(a, b))
+	assert.Contains(t, buf.String(), "Linked "+link+" (hard link to Movie.en.srt)")
+	assert.Equal(t, []string{link}, c.saved[duplicate])
+
+	c.Overwrite = config.OverwriteNever
+	buf.Reset()
+	require.True(t, c.linkDuplicate(original, duplicate))
+	assert.Contains(t, buf.String(), "Keeping the existing "+link)
+}
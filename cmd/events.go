@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/progress"
+	"github.com/carlosarraes/subs-cli/pkg/subs"
+)
+
+func (c *CLI) Observe(event subs.Event) {
+	ui := c.ui()
+	switch e := event.(type) {
+	case subs.FileStarted:
+		ui.Printf("\nProcessing: %s\n", ui.Bold(filepath.Base(e.Path)))
+	case subs.MediaParsed:
+		c.displayMediaInfo(e.Media)
+	case subs.SearchCompleted:
+		switch {
+		case e.Err != nil && e.Found == 0 && e.Filtered == 0:
+			ui.Printf("    %s %v\n", ui.Warning(fmt.Sprintf("%s Failed to search for %s subtitles:", ui.Icon(output.IconWarning), e.Language)), e.Err)
+			return
+		case e.Err != nil:
+			ui.Printf("    %s %v\n", ui.Warning(fmt.Sprintf("%s Showing %s results from the providers that answered:", ui.Icon(output.IconWarning), e.Language)), e.Err)
+		}
+		if e.Filtered > 0 {
+			ui.Printf("    %s Found %d %s subtitle(s), %d filtered out\n", ui.Icon(output.IconSuccess), e.Found, e.Language, e.Filtered)
+		} else {
+			ui.Printf("    %s Found %d %s subtitle(s)\n", ui.Icon(output.IconSuccess), e.Found, e.Language)
+		}
+	case subs.DownloadProgress:
+		if c.download == nil {
+			c.download = progress.NewBytes(ui.Writer(), "    "+ui.Icon(output.IconDownload)+" "+filepath.Base(e.Target), 0, c.progressEnabled())
+		}
+		if e.Done {
+			c.download.Finish()
+			c.download = nil
+			return
+		}
+		c.download.Set(e.Downloaded, e.Total)
+	case subs.FallbackStarted:
+		ui.Printf("    %s %v\n", ui.Warning(fmt.Sprintf("%s Likely made for a different cut, trying the next candidate:", ui.Icon(output.IconWarning))), e.Err)
+	case subs.FallbackFailed:
+		ui.Printf("    %s %v\n", ui.Warning(fmt.Sprintf("%s Failed to download candidate:", ui.Icon(output.IconWarning))), e.Err)
+	case subs.FallbackExhausted:
+		ui.Printf("    %s No other candidate fits the video length, keeping the best match\n", ui.Warning(ui.Icon(output.IconWarning)))
+	case subs.LengthMismatch:
+		ui.Printf("    %s %v\n", ui.Warning(fmt.Sprintf("%s Possibly out of sync:", ui.Icon(output.IconWarning))), e.Err)
+	case subs.MergeFailed:
+		ui.Printf("    %s %v\n", ui.Warning(fmt.Sprintf("%s Cannot merge CD parts, saving them separately:", ui.Icon(output.IconWarning))), e.Err)
+	case subs.DownloadFailed:
+		c.downloadFailed(e.Language, e.Err)
+	case subs.FileFinished:
+		if e.Err != nil {
+			ui.Printf("  %s %s\n", ui.Icon(output.IconFailure), ui.Error(sentence(e.Err.Error())))
+		}
+	}
+}
+
+func sentence(message string) string {
+	first, size := utf8.DecodeRuneInString(message)
+	if first == utf8.RuneError {
+		return message
+	}
+	return string(unicode.ToUpper(first)) + message[size:]
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/carlosarraes/subs-cli/pkg/subs"
+)
+
+func TestObserve(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    subs.Event
+		contains []string
+		empty    bool
+	}{
+		{name: "file_started", event: subs.FileStarted{Path: "/movies/Inception.2010.mkv"}, contains: []string{"Processing: Inception.2010.mkv"}},
+		{name: "media_parsed", event: subs.MediaParsed{Media: &models.MediaInfo{Title: "Inception", Year: "2010", Type: "movie"}}, contains: []string{"Parsed successfully:", "Title: Inception", "Year: 2010"}},
+		{name: "found", event: subs.SearchCompleted{Language: "en", Found: 3}, contains: []string{"Found 3 en subtitle(s)\n"}},
+		{name: "filtered", event: subs.SearchCompleted{Language: "en", Found: 1, Filtered: 2}, contains: []string{"Found 1 en subtitle(s), 2 filtered out"}},
+		{name: "failed", event: subs.SearchCompleted{Language: "pt-BR", Err: errors.New("timeout")}, contains: []string{"Failed to search for pt-BR subtitles: timeout"}},
+		{name: "partial", event: subs.SearchCompleted{Language: "en", Found: 2, Err: errors.New("other provider down")}, contains: []string{"Showing en results from the providers that answered: other provider down", "Found 2 en subtitle(s)"}},
+		{name: "file_failed", event: subs.FileFinished{Err: errors.New("failed to parse filename: no title")}, contains: []string{"Failed to parse filename: no title"}},
+		{name: "file_ok", event: subs.FileFinished{}, empty: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			cli := &CLI{out: output.New(&buf, output.Options{NoColor: true})}
+			cli.Observe(tt.event)
+
+			if tt.empty {
+				assert.Empty(t, buf.String())
+			}
+			for _, want := range tt.contains {
+				assert.Contains(t, buf.String(), want)
+			}
+		})
+	}
+}
+
+func TestObserveDownloadProgress(t *testing.T) {
+	var buf bytes.Buffer
+	cli := &CLI{out: output.New(&buf, output.Options{NoColor: true})}
+
+	cli.Observe(subs.DownloadProgress{Target: "/movies/movie.en.srt"})
+	assert.NotNil(t, cli.download)
+	cli.Observe(subs.DownloadProgress{Target: "/movies/movie.en.srt", Downloaded: 10, Total: 20})
+	assert.Equal(t, int64(10), cli.download.Current())
+	cli.Observe(subs.DownloadProgress{Target: "/movies/movie.en.srt", Done: true})
+	assert.Nil(t, cli.download)
+}
+
+func TestSentence(t *testing.T) {
+	assert.Equal(t, "Failed to parse", sentence("failed to parse"))
+	assert.Equal(t, "Élan", sentence("élan"))
+	assert.Equal(t, "", sentence(""))
+}
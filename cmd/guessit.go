@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+type filenameGuesser interface {
+	Guessit(ctx context.Context, filename string) (*models.MediaInfo, error)
+}
+
+func (c *CLI) filenameGuesser(cfg *config.Config) filenameGuesser {
+	if c.guesser == nil {
+		c.guesser = c.newClient(cfg)
+	}
+	return c.guesser
+}
+
+func (c *CLI) guessFilename(name string, parseErr error) (*models.MediaInfo, error) {
+	if !c.Guessit {
+		return nil, parseErr
+	}
+
+	ui := c.ui()
+	ui.Printf("  %s Could not parse the file name, asking the OpenSubtitles guessit service...\n", ui.Icon(output.IconSearch))
+
+	cfg := c.loadedConfig()
+	ctx, cancel := context.WithTimeout(c.context(), fileTimeout(cfg))
+	defer cancel()
+	info, err := c.filenameGuesser(cfg).Guessit(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("guessit lookup failed: %w", err)
+	}
+	if info == nil {
+		return nil, parseErr
+	}
+
+	title := info.GetDisplayTitle()
+	if info.IsEpisode() {
+		title += fmt.Sprintf(" S%02dE%02d", info.Season, info.Episode)
+	}
+	ui.Printf("  %s Guessed %s\n", ui.Success(ui.Icon(output.IconSuccess)), title)
+	return info, nil
+}
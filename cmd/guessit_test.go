@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/parser"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func TestGuessFilename(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("filename") == "Show Season Two Episode Five.mkv" {
+			w.Write([]byte(`{"title":"Show","season":2,"episode":5,"type":"episode"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	name := "Show Season Two Episode Five.mkv"
+	_, parseErr := parser.New().Parse(name)
+	require.ErrorIs(t, parseErr, parser.ErrUnparseableFilename)
+
+	var buf bytes.Buffer
+	cli := &CLI{cfg: config.Default(), out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+	cli.guesser = api.NewOpenSubtitlesClient(&api.Config{BaseURL: server.URL, APIKey: "key"})
+	_, err := cli.guessFilename(name, parseErr)
+	assert.ErrorIs(t, err, parser.ErrUnparseableFilename, "guessit is opt-in")
+	assert.Empty(t, buf.String())
+
+	cli.Guessit = true
+	info, err := cli.guessFilename(name, parseErr)
+	require.NoError(t, err)
+	assert.Equal(t, &models.MediaInfo{Title: "Show", Season: 2, Episode: 5, Type: "episode"}, info)
+	assert.Contains(t, buf.String(), "Guessed Show S02E05")
+
+	_, err = cli.guessFilename("noise.mkv", parseErr)
+	assert.ErrorIs(t, err, parser.ErrUnparseableFilename)
+}
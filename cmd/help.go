@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/alecthomas/kong"
+)
+
+func printHelp(options kong.HelpOptions, ctx *kong.Context) error {
+	if err := kong.DefaultHelpPrinter(options, ctx); err != nil {
+		return err
+	}
+	if ctx.Selected() != nil {
+		return nil
+	}
+
+	get, err := kong.Trace(ctx.Kong, []string{"get"})
+	if err != nil {
+		return nil
+	}
+	fmt.Fprintf(ctx.Stdout, "\nWithout a command, subs runs \"get\" with the flags below. To search a folder\nnamed like a command, write it as a path (subs ./apply) or use subs get apply.\n")
+	options.NoAppSummary = true
+	return kong.DefaultHelpPrinter(options, get)
+}
+
+func shadowedPath(model *kong.Application, args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	for _, node := range model.Children {
+		if node.Hidden || node.Name != args[0] && !slices.Contains(node.Aliases, args[0]) {
+			continue
+		}
+		if _, err := os.Stat(args[0]); err != nil {
+			return ""
+		}
+		return fmt.Sprintf("Running the %s command. To search the folder or file named %s instead, use: subs ./%s", node.Name, args[0], args[0])
+	}
+	return ""
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/alecthomas/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintHelp(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	parser, err := kong.New(&App{}, kong.Name("subs"), kong.Help(printHelp), kong.Writers(&buf, &buf), kong.Exit(func(int) {}))
+	require.NoError(t, err)
+
+	parser.Parse([]string{"--help"})
+	assert.Contains(t, buf.String(), "Usage: subs <command>")
+	assert.Contains(t, buf.String(), "subs ./apply")
+	assert.Contains(t, buf.String(), "--dry-run", "the default command's flags are listed at the top level")
+
+	buf.Reset()
+	parser.Parse([]string{"apply", "--help"})
+	assert.Contains(t, buf.String(), "Usage: subs apply <plan>")
+	assert.NotContains(t, buf.String(), "--explain-score")
+}
+
+func TestShadowedPath(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(dir+"/apply", 0755))
+	t.Chdir(dir)
+
+	parser, _ := newTestParser(t)
+	assert.Contains(t, shadowedPath(parser.Model, []string{"apply", "plan.json"}), "use: subs ./apply")
+	assert.Empty(t, shadowedPath(parser.Model, []string{"clean"}), "no folder named clean")
+	assert.Empty(t, shadowedPath(parser.Model, []string{"./apply"}))
+	assert.Empty(t, shadowedPath(parser.Model, nil))
+}
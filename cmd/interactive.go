@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/score"
+	"github.com/carlosarraes/subs-cli/internal/subformat"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+const previewCueLimit = 20
+
+type subtitleFetcher func(subtitle *models.Subtitle) ([]byte, error)
+
+type subtitlePicker struct {
+	ui      *output.Renderer
+	prompt  *prompter
+	fetch   subtitleFetcher
+	explain func(subtitle *models.Subtitle) score.Breakdown
+	fetched map[*models.Subtitle][]byte
+}
+
+func newSubtitlePicker(ui *output.Renderer, prompt *prompter, fetch subtitleFetcher) *subtitlePicker {
+	return &subtitlePicker{
+		ui:      ui,
+		prompt:  prompt,
+		fetch:   fetch,
+		fetched: make(map[*models.Subtitle][]byte),
+	}
+}
+
+func (p *subtitlePicker) pick(language string, subtitles []*models.Subtitle) (*models.Subtitle, []byte) {
+	var candidates []*models.Subtitle
+	for _, subtitle := range subtitles {
+		if subtitle.FileID != "" {
+			candidates = append(candidates, subtitle)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	current := 0
+	p.render(language, candidates, current)
+
+	for {
+		answer := strings.ToLower(p.prompt.ask("  Choice", ""))
+		switch answer {
+		case "":
+			chosen := candidates[current]
+			return chosen, p.fetched[chosen]
+		case "s", "skip", "q":
+			p.ui.Printf("  Skipped %s subtitles.\n", language)
+			return nil, nil
+		case "n", "j":
+			current = (current + 1) % len(candidates)
+			p.render(language, candidates, current)
+		case "k":
+			current = (current + len(candidates) - 1) % len(candidates)
+			p.render(language, candidates, current)
+		case "v", "p":
+			p.preview(candidates[current])
+		default:
+			index, err := strconv.Atoi(answer)
+			if err != nil || index < 1 || index > len(candidates) {
+				p.ui.Printf("  %s\n", p.ui.Warning(fmt.Sprintf("%s Unknown choice '%s'", p.ui.Icon(output.IconWarning), answer)))
+				continue
+			}
+			current = index - 1
+			p.render(language, candidates, current)
+		}
+	}
+}
+
+func (p *subtitlePicker) render(language string, candidates []*models.Subtitle, current int) {
+	ui := p.ui
+	ui.Printf("\n  %s %s\n", ui.Icon(output.IconList), ui.Bold(fmt.Sprintf("Select %s subtitle:", language)))
+	for i, subtitle := range candidates {
+		marker := " "
+		if i == current {
+			marker = ">"
+		}
+		line := fmt.Sprintf("  %s %-3d %-50s %-15s %5.1f %7d", marker, i+1, truncate(subtitle.ReleaseName, 50), truncate(subtitle.Uploader, 15), subtitle.Rating, subtitle.Downloads)
+		if i == current {
+			line = ui.Bold(line)
+		}
+		ui.Println(line)
+		if i == current && p.explain != nil {
+			ui.Printf("        %s\n", ui.Info(formatBreakdown(p.explain(subtitle), true)))
+		}
+	}
+	ui.Printf("  Enter: download highlighted  number: highlight  n/k: next/previous  v: preview  s: skip\n")
+}
+
+func (p *subtitlePicker) preview(subtitle *models.Subtitle) {
+	ui := p.ui
+	content, ok := p.fetched[subtitle]
+	if !ok {
+		var err error
+		content, err = p.fetch(subtitle)
+		if err != nil {
+			ui.Printf("  %s %v\n", ui.Warning(fmt.Sprintf("%s Preview failed:", ui.Icon(output.IconWarning))), err)
+			return
+		}
+		p.fetched[subtitle] = content
+	}
+
+	format := subformat.Resolve(content, subtitle.FileName, subtitle.SubFormat)
+	ui.Printf("\n  %s\n", ui.Bold(fmt.Sprintf("--- Preview: %s (%s) ---", truncate(subtitle.ReleaseName, 50), format)))
+
+	cues := subformat.Cues(content, format, previewCueLimit)
+	if len(cues) > 0 {
+		for _, cue := range cues {
+			ui.Printf("  %-14s %s\n", cue.Start, strings.ReplaceAll(cue.Text, "\n", " / "))
+		}
+	} else {
+		shown := 0
+		for _, line := range strings.Split(string(content), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			ui.Printf("  %s\n", line)
+			shown++
+			if shown == previewCueLimit {
+				break
+			}
+		}
+	}
+
+	ui.Printf("  %s\n", strings.Repeat("-", 60))
+	ui.Printf("  %s This file was fetched for the preview; choosing it will not download it again.\n", ui.Icon(output.IconTip))
+}
+
+func truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
+
+type confirmAnswer int
+
+const (
+	confirmYes confirmAnswer = iota
+	confirmNo
+	confirmSkip
+)
+
+var errSkipFile = errors.New("file skipped")
+
+// confirmCandidate adapts confirmDownload to the subs pipeline's Confirm hook.
+func (c *CLI) confirmCandidate(language string, subtitle *models.Subtitle) (bool, error) {
+	switch c.confirmDownload(language, subtitle) {
+	case confirmSkip:
+		return false, errSkipFile
+	case confirmNo:
+		return false, nil
+	}
+	return true, nil
+}
+
+func (c *CLI) confirmDownload(language string, subtitle *models.Subtitle) confirmAnswer {
+	ui := c.ui()
+	ui.Printf("  %s Best %s match: %s (%s, %d downloads)\n", ui.Icon(output.IconTip), language, subtitle.ReleaseName, subtitle.Uploader, subtitle.Downloads)
+
+	if c.Yes || c.acceptAll {
+		return confirmYes
+	}
+
+	for {
+		switch strings.ToLower(c.prompt().ask("  Download? [Y/n/s/a]", "")) {
+		case "", "y", "yes":
+			return confirmYes
+		case "n", "no":
+			return confirmNo
+		case "s", "skip":
+			return confirmSkip
+		case "a", "all":
+			c.acceptAll = true
+			return confirmYes
+		}
+		ui.Printf("  Answer y (download), n (not this one), s (skip this file) or a (download all remaining).\n")
+	}
+}
@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubtitlePicker(t *testing.T) {
+	t.Parallel()
+
+	srt := []byte("1\n00:00:01,000 --> 00:00:02,000\nHello there\n\n2\n00:00:03,000 --> 00:00:04,000\nGeneral Kenobi\n")
+
+	candidates := func() []*models.Subtitle {
+		return []*models.Subtitle{
+			{ID: "a", FileID: "1", ReleaseName: "Movie.2020.1080p", Uploader: "alice"},
+			{ID: "b", FileID: "2", ReleaseName: "Movie.2020.720p", Uploader: "bob"},
+			{ID: "no-file", ReleaseName: "Movie.2020.CAM"},
+		}
+	}
+
+	newPicker := func(input string, fetch subtitleFetcher) (*subtitlePicker, *bytes.Buffer) {
+		var buf bytes.Buffer
+		ui := output.New(&buf, output.Options{NoColor: true, NoEmoji: true})
+		return newSubtitlePicker(ui, newPrompter(strings.NewReader(input), &buf), fetch), &buf
+	}
+
+	t.Run("enter selects highlighted", func(t *testing.T) {
+		t.Parallel()
+
+		picker, out := newPicker("\n", nil)
+
+		chosen, content := picker.pick("en", candidates())
+
+		require.NotNil(t, chosen)
+		assert.Equal(t, "a", chosen.ID)
+		assert.Nil(t, content)
+		assert.NotContains(t, out.String(), "Movie.2020.CAM", "results without files are not offered")
+	})
+
+	t.Run("explains the highlighted score", func(t *testing.T) {
+		t.Parallel()
+
+		picker, out := newPicker("n\n\n", nil)
+		picker.explain = (&CLI{}).scorer("/movies/Movie.2020.720p.mkv").Explain
+		chosen, _ := picker.pick("en", candidates())
+		assert.Equal(t, "b", chosen.ID)
+		assert.Contains(t, out.String(), "score 27 (release +27 [2/3 tokens: movie 2020])")
+		assert.Contains(t, out.String(), "score 40 (release +40 [3/3 tokens: movie 2020 720p])")
+	})
+
+	t.Run("navigate and select", func(t *testing.T) {
+		t.Parallel()
+
+		picker, _ := newPicker("n\n\n", nil)
+		chosen, _ := picker.pick("en", candidates())
+		assert.Equal(t, "b", chosen.ID)
+
+		picker, _ = newPicker("k\n\n", nil)
+		chosen, _ = picker.pick("en", candidates())
+		assert.Equal(t, "b", chosen.ID, "moving up from the first result wraps around")
+
+		picker, _ = newPicker("2\n\n", nil)
+		chosen, _ = picker.pick("en", candidates())
+		assert.Equal(t, "b", chosen.ID)
+	})
+
+	t.Run("preview fetches once and reuses content", func(t *testing.T) {
+		t.Parallel()
+
+		calls := 0
+		picker, out := newPicker("2\nv\nv\n\n", func(subtitle *models.Subtitle) ([]byte, error) {
+			calls++
+			assert.Equal(t, "b", subtitle.ID)
+			return srt, nil
+		})
+
+		chosen, content := picker.pick("en", candidates())
+
+		assert.Equal(t, "b", chosen.ID)
+		assert.Equal(t, srt, content)
+		assert.Equal(t, 1, calls)
+		assert.Contains(t, out.String(), "--- Preview: Movie.2020.720p (srt) ---")
+		assert.Contains(t, out.String(), "00:00:01,000   Hello there")
+		assert.Contains(t, out.String(), "General Kenobi")
+	})
+
+	t.Run("preview failure keeps prompting", func(t *testing.T) {
+		t.Parallel()
+
+		picker, out := newPicker("v\n\n", func(*models.Subtitle) ([]byte, error) {
+			return nil, errors.New("quota exceeded")
+		})
+
+		chosen, content := picker.pick("en", candidates())
+
+		assert.Equal(t, "a", chosen.ID)
+		assert.Nil(t, content)
+		assert.Contains(t, out.String(), "Preview failed: quota exceeded")
+	})
+
+	t.Run("skip and invalid choice", func(t *testing.T) {
+		t.Parallel()
+
+		picker, out := newPicker("9\ns\n", nil)
+
+		chosen, content := picker.pick("en", candidates())
+
+		assert.Nil(t, chosen)
+		assert.Nil(t, content)
+		assert.Contains(t, out.String(), "Unknown choice '9'")
+		assert.Contains(t, out.String(), "Skipped en subtitles.")
+	})
+
+	t.Run("nothing downloadable", func(t *testing.T) {
+		t.Parallel()
+
+		picker, _ := newPicker("", nil)
+
+		chosen, _ := picker.pick("en", []*models.Subtitle{{ID: "no-file"}})
+
+		assert.Nil(t, chosen)
+	})
+}
+
+func TestSearchWithRetry(t *testing.T) {
+	t.Parallel()
+
+	newClient := func() *fakeSubtitleClient {
+		return &fakeSubtitleClient{
+			results: map[string][]*models.Subtitle{
+				"Dark Matter 2024/en": {{ID: "1", FileID: "1", ReleaseName: "Dark.Matter.2024.S01E01"}},
+			},
+		}
+	}
+
+	newCLI := func(input string, interactive bool) (*CLI, *bytes.Buffer) {
+		var buf bytes.Buffer
+		cli := &CLI{Interactive: interactive}
+		cli.out = output.New(&buf, output.Options{NoColor: true, NoEmoji: true})
+		cli.prompter = newPrompter(strings.NewReader(input), &buf)
+		return cli, &buf
+	}
+
+	t.Run("edited query is retried", func(t *testing.T) {
+		t.Parallel()
+
+		client := newClient()
+		cli, out := newCLI("Dark Matter 2024\n", true)
+		params := &models.SearchParams{Query: "Dark Mater"}
+
+		outcome := cli.searchWithRetry(context.Background(), client, params, []string{"en"})
+
+		require.Len(t, outcome.all, 1)
+		assert.Equal(t, "1", outcome.candidates["en"][0].ID)
+		assert.Equal(t, "Dark Matter 2024", params.Query)
+		require.Len(t, client.searches, 2)
+		assert.Contains(t, out.String(), "No results for 'Dark Mater'")
+		assert.Contains(t, out.String(), "Search query [Dark Mater]:")
+	})
+
+	t.Run("unchanged query gives up", func(t *testing.T) {
+		t.Parallel()
+
+		client := newClient()
+		cli, _ := newCLI("\n", true)
+
+		outcome := cli.searchWithRetry(context.Background(), client, &models.SearchParams{Query: "Dark Mater"}, []string{"en"})
+
+		assert.Empty(t, outcome.all)
+		assert.Len(t, client.searches, 1)
+	})
+
+	t.Run("non-interactive mode never prompts", func(t *testing.T) {
+		t.Parallel()
+
+		client := newClient()
+		cli, out := newCLI("Dark Matter 2024\n", false)
+
+		outcome := cli.searchWithRetry(context.Background(), client, &models.SearchParams{Query: "Dark Mater"}, []string{"en"})
+
+		assert.Empty(t, outcome.all)
+		assert.Len(t, client.searches, 1)
+		assert.NotContains(t, out.String(), "Search query")
+	})
+}
+
+func TestConfirmDownload(t *testing.T) {
+	t.Parallel()
+
+	subtitle := &models.Subtitle{ReleaseName: "Inception.2010.1080p", Uploader: "alice", Downloads: 900}
+
+	newCLI := func(input string) (*CLI, *bytes.Buffer) {
+		var buf bytes.Buffer
+		cli := &CLI{Confirm: true}
+		cli.out = output.New(&buf, output.Options{NoColor: true, NoEmoji: true})
+		cli.prompter = newPrompter(strings.NewReader(input), &buf)
+		return cli, &buf
+	}
+
+	tests := []struct {
+		input    string
+		expected confirmAnswer
+	}{
+		{"\n", confirmYes},
+		{"y\n", confirmYes},
+		{"N\n", confirmNo},
+		{"skip\n", confirmSkip},
+		{"maybe\nn\n", confirmNo},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.TrimSpace(strings.ReplaceAll(tt.input, "\n", " ")), func(t *testing.T) {
+			t.Parallel()
+
+			cli, out := newCLI(tt.input)
+
+			assert.Equal(t, tt.expected, cli.confirmDownload("en", subtitle))
+			assert.Contains(t, out.String(), "Best en match: Inception.2010.1080p (alice, 900 downloads)")
+		})
+	}
+
+	t.Run("all accepts the remaining matches", func(t *testing.T) {
+		t.Parallel()
+
+		cli, out := newCLI("a\n")
+
+		assert.Equal(t, confirmYes, cli.confirmDownload("en", subtitle))
+		assert.Equal(t, confirmYes, cli.confirmDownload("pt-BR", subtitle))
+		assert.Equal(t, 1, strings.Count(out.String(), "Download?"))
+	})
+
+	t.Run("yes never prompts", func(t *testing.T) {
+		t.Parallel()
+
+		cli, out := newCLI("")
+		cli.Yes = true
+
+		assert.Equal(t, confirmYes, cli.confirmDownload("en", subtitle))
+		assert.NotContains(t, out.String(), "Download?")
+	})
+
+	t.Run("unknown answer is asked again", func(t *testing.T) {
+		t.Parallel()
+
+		cli, out := newCLI("maybe\ny\n")
+
+		assert.Equal(t, confirmYes, cli.confirmDownload("en", subtitle))
+		assert.Contains(t, out.String(), "Answer y (download)")
+	})
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/cache"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/language"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+const languagesCacheKey = "languages-" + api.ProviderOpenSubtitles
+
+type LanguagesCmd struct {
+	Filter    string `arg:"" optional:"" help:"Only show languages whose code or name contains this text (case-insensitive)."`
+	Config    string `short:"c" long:"config" type:"existingfile" help:"Path to custom YAML configuration file. Default location: ~/.subs-cli/config.yaml"`
+	Refresh   bool   `long:"refresh" help:"Ignore the cached list and fetch supported languages from the provider again."`
+	NoEmoji   bool   `long:"no-emoji" help:"Replace emoji status symbols with plain ASCII markers."`
+	Proxy     string `long:"proxy" placeholder:"URL" help:"Proxy for provider requests, e.g. http://host:3128 or socks5://host:1080."`
+	DebugHTTP bool   `long:"debug-http" help:"Log provider HTTP requests and responses to stderr, with credentials redacted."`
+}
+
+type languageLister interface {
+	Languages(ctx context.Context) ([]*models.SupportedLanguage, error)
+}
+
+func (l *LanguagesCmd) Run(ctx context.Context) error {
+	var cfg *config.Config
+	var err error
+
+	if l.Config != "" {
+		cfg, err = config.Load(l.Config)
+	} else {
+		cfg, err = config.LoadDefault()
+	}
+	if err == nil {
+		err = overrideProxy(cfg, l.Proxy)
+	}
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	store, err := openCache(cfg)
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	ui := output.NewStdout(l.NoEmoji || cfg.Output.NoEmoji)
+	client := (&CLI{DebugHTTP: l.DebugHTTP}).newClient(cfg)
+
+	ctx, cancel := context.WithTimeout(ctx, fileTimeout(cfg))
+	defer cancel()
+
+	languages, err := loadSupportedLanguages(ctx, client, store, l.Refresh)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s Could not fetch provider languages (%v); showing built-in language table.\n", ui.Icon(output.IconWarning), err)
+		languages = builtinLanguages()
+	}
+
+	matches := filterLanguages(languages, l.Filter)
+	if len(matches) == 0 {
+		return fmt.Errorf("no languages match '%s'", l.Filter)
+	}
+
+	writeLanguages(ui, matches)
+	return nil
+}
+
+func openCache(cfg *config.Config) (*cache.Cache, error) {
+	if !cfg.Cache.Enabled {
+		return nil, nil
+	}
+
+	dir, err := cfg.Cache.Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	ttl, err := cfg.Cache.TTLDuration()
+	if err != nil {
+		return nil, err
+	}
+
+	return cache.New(dir, ttl), nil
+}
+
+func loadSupportedLanguages(ctx context.Context, lister languageLister, store *cache.Cache, refresh bool) ([]*models.SupportedLanguage, error) {
+	var languages []*models.SupportedLanguage
+	if store != nil && !refresh && store.Get(languagesCacheKey, &languages) && len(languages) > 0 {
+		return languages, nil
+	}
+
+	languages, err := lister.Languages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if store != nil {
+		_ = store.Set(languagesCacheKey, languages)
+	}
+
+	return languages, nil
+}
+
+func builtinLanguages() []*models.SupportedLanguage {
+	all := language.All()
+	languages := make([]*models.SupportedLanguage, 0, len(all))
+	for _, lang := range all {
+		languages = append(languages, &models.SupportedLanguage{
+			Code:   lang.Code,
+			Name:   lang.Name,
+			Native: lang.Native,
+		})
+	}
+	return languages
+}
+
+func filterLanguages(languages []*models.SupportedLanguage, filter string) []*models.SupportedLanguage {
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	if filter == "" {
+		return languages
+	}
+
+	var matches []*models.SupportedLanguage
+	for _, lang := range languages {
+		haystack := strings.ToLower(lang.Code + " " + lang.Name + " " + lang.Native)
+		if strings.Contains(haystack, filter) {
+			matches = append(matches, lang)
+		}
+	}
+	return matches
+}
+
+func writeLanguages(ui *output.Renderer, languages []*models.SupportedLanguage) {
+	ui.Printf("%-8s %-28s %s\n", "Code", "Name", "Native Name")
+	ui.Printf("%s\n", strings.Repeat("-", 60))
+	for _, lang := range languages {
+		ui.Printf("%-8s %-28s %s\n", lang.Code, lang.Name, lang.Native)
+	}
+	ui.Printf("\n%d languages\n", len(languages))
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/internal/cache"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLanguageLister struct {
+	languages []*models.SupportedLanguage
+	err       error
+	calls     int
+}
+
+func (f *fakeLanguageLister) Languages(ctx context.Context) ([]*models.SupportedLanguage, error) {
+	f.calls++
+	return f.languages, f.err
+}
+
+func TestLoadSupportedLanguages(t *testing.T) {
+	t.Parallel()
+
+	provider := []*models.SupportedLanguage{
+		{Code: "en", Name: "English", Native: "English"},
+		{Code: "pt-BR", Name: "Portuguese (BR)", Native: "Português (Brasil)"},
+	}
+
+	t.Run("fetches once and then uses cache", func(t *testing.T) {
+		t.Parallel()
+
+		lister := &fakeLanguageLister{languages: provider}
+		store := cache.New(t.TempDir(), time.Hour)
+
+		first, err := loadSupportedLanguages(context.Background(), lister, store, false)
+		require.NoError(t, err)
+		second, err := loadSupportedLanguages(context.Background(), lister, store, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, provider, first)
+		assert.Equal(t, provider, second)
+		assert.Equal(t, 1, lister.calls)
+	})
+
+	t.Run("refresh bypasses cache", func(t *testing.T) {
+		t.Parallel()
+
+		lister := &fakeLanguageLister{languages: provider}
+		store := cache.New(t.TempDir(), time.Hour)
+
+		_, err := loadSupportedLanguages(context.Background(), lister, store, false)
+		require.NoError(t, err)
+		_, err = loadSupportedLanguages(context.Background(), lister, store, true)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, lister.calls)
+	})
+
+	t.Run("without cache", func(t *testing.T) {
+		t.Parallel()
+
+		lister := &fakeLanguageLister{languages: provider}
+
+		languages, err := loadSupportedLanguages(context.Background(), lister, nil, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, provider, languages)
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		t.Parallel()
+
+		lister := &fakeLanguageLister{err: errors.New("boom")}
+
+		_, err := loadSupportedLanguages(context.Background(), lister, cache.New(t.TempDir(), time.Hour), false)
+
+		assert.EqualError(t, err, "boom")
+	})
+}
+
+func TestFilterLanguages(t *testing.T) {
+	t.Parallel()
+
+	languages := builtinLanguages()
+
+	tests := []struct {
+		name   string
+		filter string
+		want   []string
+	}{
+		{name: "empty filter", filter: "", want: nil},
+		{name: "by code", filter: "pt-", want: []string{"pt-BR", "pt-PT"}},
+		{name: "by name case-insensitive", filter: "PORTUGUESE", want: []string{"pt-BR", "pt-PT"}},
+		{name: "by native name", filter: "deutsch", want: []string{"de"}},
+		{name: "no match", filter: "klingon", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			matches := filterLanguages(languages, tt.filter)
+
+			if tt.want == nil {
+				assert.Len(t, matches, len(languages))
+				return
+			}
+
+			codes := []string{}
+			for _, lang := range matches {
+				codes = append(codes, lang.Code)
+			}
+			assert.Equal(t, tt.want, codes)
+		})
+	}
+}
+
+func TestWriteLanguages(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	ui := output.New(&buf, output.Options{NoColor: true})
+
+	writeLanguages(ui, []*models.SupportedLanguage{
+		{Code: "en", Name: "English", Native: "English"},
+		{Code: "ja", Name: "Japanese", Native: "日本語"},
+	})
+
+	out := buf.String()
+	assert.Contains(t, out, "Code")
+	assert.Contains(t, out, "Native Name")
+	assert.Contains(t, out, "ja       Japanese")
+	assert.Contains(t, out, "日本語")
+	assert.Contains(t, out, "2 languages")
+}
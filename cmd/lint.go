@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/carlosarraes/subs-cli/internal/fsutil"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/scan"
+	"github.com/carlosarraes/subs-cli/internal/subformat"
+)
+
+type LintCmd struct {
+	Path          string  `arg:"" type:"path" help:"SubRip subtitle, or directory of subtitles, to check."`
+	Recursive     bool    `short:"r" long:"recursive" help:"Also check subtitles in subdirectories."`
+	Fix           bool    `long:"fix" help:"Rewrite the files with the auto-correctable issues fixed: empty cues are removed, overlapping cues end when the next one starts, and cues are renumbered."`
+	MaxCPS        float64 `long:"max-cps" default:"21" help:"Highest reading speed, in characters per second, before a cue is reported as too fast. 0 turns the check off."`
+	MaxLineLength int     `long:"max-line-length" default:"42" help:"Longest line, in characters, before it is reported. 0 turns the check off."`
+	NoEmoji       bool    `long:"no-emoji" help:"Replace emoji status symbols with plain ASCII markers."`
+}
+
+func (c *LintCmd) Run() error {
+	return c.run(output.NewStdout(c.NoEmoji))
+}
+
+func (c *LintCmd) run(ui *output.Renderer) error {
+	files, err := c.files()
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		ui.Printf("%s No SubRip subtitles in %s\n", ui.Info(ui.Icon(output.IconInfo)), c.Path)
+		return nil
+	}
+
+	opts := subformat.LintOptions{MaxCPS: c.MaxCPS, MaxLineLength: c.MaxLineLength}
+	total, fixable, withIssues := 0, 0, 0
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		issues := subformat.Lint(content, opts)
+		if c.Fix && hasFixable(issues) {
+			fixed := subformat.FixSRT(content)
+			if err := fsutil.WriteFile(file, fixed, fsutil.WriteOptions{Perm: filePerm(file)}); err != nil {
+				return fmt.Errorf("failed to write %s: %w", file, err)
+			}
+			remaining := subformat.Lint(fixed, opts)
+			ui.Printf("%s Fixed %d issue(s) in %s\n", ui.Success(ui.Icon(output.IconSuccess)), len(issues)-len(remaining), file)
+			issues = remaining
+		}
+		if len(issues) == 0 {
+			continue
+		}
+
+		withIssues++
+		ui.Printf("%s %s\n", ui.Warning(ui.Icon(output.IconWarning)), file)
+		for _, issue := range issues {
+			suffix := ""
+			if issue.Fixable {
+				suffix = " [fixable]"
+				fixable++
+			}
+			ui.Printf("  cue %d (%s): %s%s\n", issue.Cue, issue.Start, issue.Message, suffix)
+		}
+		total += len(issues)
+	}
+
+	if total == 0 {
+		ui.Printf("%s No issues in %d subtitle(s)\n", ui.Success(ui.Icon(output.IconSuccess)), len(files))
+		return nil
+	}
+	if fixable > 0 {
+		ui.Printf("%s %d of them can be fixed with --fix\n", ui.Icon(output.IconTip), fixable)
+	}
+	return fmt.Errorf("found %d issue(s) in %d of %d subtitle(s)", total, withIssues, len(files))
+}
+
+func (c *LintCmd) files() ([]string, error) {
+	info, err := os.Stat(c.Path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		if subformat.FromFileName(c.Path) != subformat.SRT {
+			return nil, fmt.Errorf("%s: only SubRip (.srt) subtitles can be linted", c.Path)
+		}
+		return []string{c.Path}, nil
+	}
+
+	found, err := scan.Find(c.Path, scan.Options{Recursive: c.Recursive})
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, file := range found {
+		if subformat.FromFileName(file) == subformat.SRT {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+func hasFixable(issues []subformat.Issue) bool {
+	for _, issue := range issues {
+		if issue.Fixable {
+			return true
+		}
+	}
+	return false
+}
+
+func filePerm(path string) os.FileMode {
+	if info, err := os.Stat(path); err == nil {
+		return info.Mode().Perm()
+	}
+	return 0644
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/output"
+)
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	broken := filepath.Join(dir, "Movie.en.srt")
+	clean := filepath.Join(dir, "Show", "Show.S01E01.en.srt")
+	require.NoError(t, os.MkdirAll(filepath.Dir(clean), 0755))
+	require.NoError(t, os.WriteFile(broken, []byte("1\n00:00:01,000 --> 00:00:03,500\nHello\n\n2\n00:00:03,000 --> 00:00:05,000\nThere\n\n3\n00:00:06,000 --> 00:00:07,000\n\n"), 0600))
+	require.NoError(t, os.WriteFile(clean, []byte("1\n00:00:01,000 --> 00:00:03,000\nHi\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Movie.en.ass"), []byte("[Script Info]\n"), 0644))
+
+	var buf bytes.Buffer
+	ui := output.New(&buf, output.Options{NoColor: true, NoEmoji: true})
+	err := (&LintCmd{Path: dir, Recursive: true}).run(ui)
+	assert.EqualError(t, err, "found 2 issue(s) in 1 of 2 subtitle(s)")
+	assert.Contains(t, buf.String(), "  cue 1 (00:00:01,000): overlaps cue 2 by 500ms [fixable]\n  cue 3 (00:00:06,000): empty cue [fixable]\n")
+	assert.Contains(t, buf.String(), "2 of them can be fixed with --fix")
+	assert.NotContains(t, buf.String(), "Show.S01E01")
+
+	buf.Reset()
+	require.NoError(t, (&LintCmd{Path: broken, Fix: true}).run(ui))
+	assert.Contains(t, buf.String(), "Fixed 2 issue(s) in "+broken)
+	assert.Contains(t, buf.String(), "No issues in 1 subtitle(s)")
+
+	fixed, err := os.ReadFile(broken)
+	require.NoError(t, err)
+	assert.Equal(t, "1\n00:00:01,000 --> 00:00:03,000\nHello\n\n2\n00:00:03,000 --> 00:00:05,000\nThere\n\n", string(fixed))
+	info, err := os.Stat(broken)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	err = (&LintCmd{Path: filepath.Join(dir, "Movie.en.ass")}).run(ui)
+	assert.ErrorContains(t, err, "only SubRip (.srt) subtitles can be linted")
+}
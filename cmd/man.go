@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/alecthomas/kong"
+)
+
+type ManCmd struct{}
+
+func (m *ManCmd) Run(ctx *kong.Context) error {
+	writeManPage(os.Stdout, ctx.Model)
+	return nil
+}
+
+func roffEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\e`)
+	s = strings.ReplaceAll(s, "-", `\-`)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
+
+func manDate() string {
+	if BuildTime == "unknown" || len(BuildTime) < 10 {
+		return ""
+	}
+	return BuildTime[:10]
+}
+
+func writeManPage(w io.Writer, app *kong.Application) {
+	name := app.Name
+	summary, rest, _ := strings.Cut(app.Help, "\n\n")
+
+	fmt.Fprintf(w, ".TH %s 1 \"%s\" \"subs-cli %s\" \"User Commands\"\n", strings.ToUpper(name), manDate(), Version)
+
+	fmt.Fprintf(w, ".SH NAME\n")
+	fmt.Fprintf(w, "%s \\- %s\n", name, roffEscape(strings.TrimSuffix(strings.TrimSpace(summary), ".")))
+
+	commands := app.Leaves(true)
+
+	fmt.Fprintf(w, ".SH SYNOPSIS\n")
+	for _, child := range commands {
+		fmt.Fprintf(w, ".B %s\n", name)
+		if child != app.DefaultCmd {
+			fmt.Fprintf(w, "%s\n", roffEscape(child.Path()))
+		}
+		if len(child.Flags) > 0 {
+			fmt.Fprintf(w, "[\\fIOPTIONS\\fR]\n")
+		}
+		for _, arg := range child.Positional {
+			fmt.Fprintf(w, "%s\n", manPositional(arg))
+		}
+		fmt.Fprintf(w, ".br\n")
+	}
+
+	fmt.Fprintf(w, ".SH DESCRIPTION\n")
+	fmt.Fprintf(w, "%s\n", roffEscape(strings.TrimSpace(summary)))
+	writeManText(w, rest)
+
+	if app.DefaultCmd != nil {
+		fmt.Fprintf(w, ".SH OPTIONS\n")
+		writeManFlags(w, app.DefaultCmd.Flags)
+		writeManFlags(w, app.Flags)
+	}
+
+	fmt.Fprintf(w, ".SH COMMANDS\n")
+	for _, child := range commands {
+		fmt.Fprintf(w, ".SS %s\n", roffEscape(child.Summary()))
+		fmt.Fprintf(w, "%s\n", roffEscape(child.Help))
+		if child != app.DefaultCmd {
+			writeManFlags(w, child.Flags)
+		}
+	}
+
+	fmt.Fprintf(w, ".SH FILES\n")
+	fmt.Fprintf(w, ".TP\n.I ~/.subs\\-cli/config.yaml\n")
+	fmt.Fprintf(w, "Default configuration file with OpenSubtitles credentials and preferences.\n")
+
+	fmt.Fprintf(w, ".SH ENVIRONMENT\n")
+	fmt.Fprintf(w, ".TP\n.B NO_COLOR\n")
+	fmt.Fprintf(w, "When set to a non\\-empty value, disables colored output.\n")
+}
+
+func manPositional(arg *kong.Positional) string {
+	placeholder := "\\fI" + strings.ToUpper(roffEscape(arg.Name)) + "\\fR"
+	if arg.Required {
+		return placeholder
+	}
+	return "[" + placeholder + "]"
+}
+
+func writeManFlags(w io.Writer, flags []*kong.Flag) {
+	for _, flag := range flags {
+		if flag.Hidden {
+			continue
+		}
+		fmt.Fprintf(w, ".TP\n")
+
+		var names []string
+		if flag.Short != 0 {
+			names = append(names, fmt.Sprintf("\\fB\\-%c\\fR", flag.Short))
+		}
+		long := "\\fB\\-\\-" + roffEscape(flag.Name) + "\\fR"
+		if !flag.IsBool() && !flag.IsCounter() {
+			long += "=\\fI" + roffEscape(flag.FormatPlaceHolder()) + "\\fR"
+		}
+		names = append(names, long)
+		fmt.Fprintf(w, "%s\n", strings.Join(names, ", "))
+
+		help := flag.Help
+		if flag.HasDefault && flag.Default != "" {
+			help += fmt.Sprintf(" (default: %s)", flag.Default)
+		}
+		fmt.Fprintf(w, "%s\n", roffEscape(help))
+	}
+}
+
+func writeManText(w io.Writer, text string) {
+	for _, paragraph := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		if paragraph == "" {
+			continue
+		}
+		fmt.Fprintf(w, ".PP\n")
+		literal := false
+		for _, line := range strings.Split(paragraph, "\n") {
+			indented := strings.HasPrefix(line, "  ")
+			if indented && !literal {
+				fmt.Fprintf(w, ".RS\n.nf\n")
+			} else if !indented && literal {
+				fmt.Fprintf(w, ".fi\n.RE\n")
+			}
+			literal = indented
+			fmt.Fprintf(w, "%s\n", roffEscape(strings.TrimSpace(line)))
+		}
+		if literal {
+			fmt.Fprintf(w, ".fi\n.RE\n")
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteManPage(t *testing.T) {
+	t.Parallel()
+
+	parser, _ := newTestParser(t)
+
+	var buf bytes.Buffer
+	writeManPage(&buf, parser.Model)
+	page := buf.String()
+
+	assert.Contains(t, page, ".TH SUBS 1")
+	assert.Contains(t, page, ".SH NAME\nsubs \\- ")
+	assert.Contains(t, page, ".SH SYNOPSIS")
+	assert.Contains(t, page, ".SH OPTIONS")
+	assert.Contains(t, page, "\\fB\\-l\\fR, \\fB\\-\\-language\\fR=")
+	assert.Contains(t, page, "\\fB\\-\\-dry\\-run\\fR\n")
+	assert.Contains(t, page, ".SS completion <shell>")
+	assert.NotContains(t, page, "__complete")
+}
+
+func TestRoffEscape(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"plain text", "plain text"},
+		{"--dry-run", `\-\-dry\-run`},
+		{`C:\path`, `C:\epath`},
+		{".hidden", `\&.hidden`},
+		{"'quoted", `\&'quoted`},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, roffEscape(tt.input))
+	}
+}
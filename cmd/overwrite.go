@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func (c *CLI) keepReason(target, mediaPath string, subtitle *models.Subtitle) string {
+	if _, err := os.Stat(target); err != nil {
+		return ""
+	}
+
+	switch c.Overwrite {
+	case config.OverwriteNever:
+		return "--overwrite is never"
+	case config.OverwriteIfBetter:
+		return c.notBetter(target, mediaPath, subtitle)
+	}
+	return ""
+}
+
+func (c *CLI) notBetter(target, mediaPath string, subtitle *models.Subtitle) string {
+	if subtitle == nil {
+		return "generated files are only replaced with --overwrite always"
+	}
+	if c.feedback == nil {
+		return "there is no download history to compare it with"
+	}
+
+	saved, ok := c.feedback.Saved(mediaPath, target)
+	if !ok {
+		return "it was not saved by subs, so there is no score to compare with"
+	}
+	if c.feedback.Rejected(saved) {
+		return ""
+	}
+	if saved.Provider == subtitle.Provider && saved.SubtitleID == subtitle.ID {
+		return "it is already this subtitle"
+	}
+	if points := c.scorer(mediaPath).Score(subtitle); points <= saved.Score {
+		return fmt.Sprintf("this subtitle scores %d, not more than the %d of the saved one", points, saved.Score)
+	}
+	return ""
+}
+
+func (c *CLI) keepExisting(target, mediaPath string, subtitle *models.Subtitle) bool {
+	reason := c.keepReason(target, mediaPath, subtitle)
+	if reason == "" {
+		return false
+	}
+
+	c.summary.Kept++
+	ui := c.ui()
+	verb := "Keeping"
+	if c.DryRun {
+		verb = "Would keep"
+	}
+	ui.Printf("    %s %s the existing %s: %s\n", ui.Info(ui.Icon(output.IconInfo)), verb, target, reason)
+	return true
+}
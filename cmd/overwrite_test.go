@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/feedback"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func TestKeepReason(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	media := filepath.Join(dir, "Movie.2020.1080p.mkv")
+	existing := filepath.Join(dir, "Movie.2020.1080p.en.srt")
+	require.NoError(t, os.WriteFile(existing, []byte("old"), 0644))
+	missing := filepath.Join(dir, "Movie.2020.1080p.pt.srt")
+
+	saved := &models.Subtitle{ID: "1", Provider: "opensubtitles", Language: "en"}
+	candidate := &models.Subtitle{ID: "2", Provider: "opensubtitles", Language: "en", ReleaseName: "Movie.2020.1080p"}
+
+	newCLI := func(policy string, score int) *CLI {
+		store := &feedback.Store{Downloads: make(map[string][]feedback.Download), Votes: make(map[string]feedback.Vote)}
+		download := feedback.NewDownload(saved, existing)
+		download.Score = score
+		store.Record(media, download)
+		return &CLI{cfg: config.Default(), Overwrite: policy, feedback: store}
+	}
+
+	assert.Empty(t, newCLI(config.OverwriteAlways, 0).keepReason(existing, media, candidate))
+	assert.Empty(t, newCLI(config.OverwriteNever, 0).keepReason(missing, media, candidate), "missing targets are always written")
+	assert.Equal(t, "--overwrite is never", newCLI(config.OverwriteNever, 0).keepReason(existing, media, candidate))
+
+	assert.Empty(t, newCLI(config.OverwriteIfBetter, -1000).keepReason(existing, media, candidate))
+	assert.Contains(t, newCLI(config.OverwriteIfBetter, 100000).keepReason(existing, media, candidate), "not more than the 100000 of the saved one")
+	assert.Equal(t, "it is already this subtitle", newCLI(config.OverwriteIfBetter, -1000).keepReason(existing, media, saved))
+	assert.Contains(t, newCLI(config.OverwriteIfBetter, 0).keepReason(existing, filepath.Join(dir, "Other.mkv"), candidate), "not saved by subs")
+	assert.Contains(t, newCLI(config.OverwriteIfBetter, 0).keepReason(existing, media, nil), "generated files")
+
+	rejected := newCLI(config.OverwriteIfBetter, 100000)
+	download, _ := rejected.feedback.Saved(media, existing)
+	rejected.feedback.Vote(download, false)
+	assert.Empty(t, rejected.keepReason(existing, media, candidate), "a subtitle rated bad is always replaced")
+}
+
+func TestSaveSubtitleOverwriteNever(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	media := filepath.Join(dir, "Movie.mkv")
+	target := filepath.Join(dir, "Movie.en.srt")
+	require.NoError(t, os.WriteFile(target, []byte("old"), 0644))
+
+	var buf bytes.Buffer
+	c := &CLI{cfg: config.Default(), Overwrite: config.OverwriteNever, out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+	subtitle := &models.Subtitle{ID: "1", Language: "en", SubFormat: "srt"}
+	require.NoError(t, c.saveSubtitle([]byte("1\n00:00:01,000 --> 00:00:02,000\nHi\n"), subtitle, media, "en", true))
+
+	content, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(content))
+	assert.Contains(t, buf.String(), "Keeping the existing "+target+": --overwrite is never")
+}
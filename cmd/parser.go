@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/parser"
+)
+
+func (c *CLI) newParser(cfg *config.Config) *parser.Parser {
+	opts := parser.Options{ThreeDigitEpisodes: cfg.Parser.ThreeDigitEpisodes}
+	switch cfg.Parser.FourDigit() {
+	case config.FourDigitAlways:
+		opts.FourDigitEpisodes = true
+	case config.FourDigitAuto:
+		opts.FourDigitEpisodes = true
+		opts.HasSeason = c.hasSeason
+	}
+	return parser.NewWithOptions(opts)
+}
+
+func (c *CLI) hasSeason(title string, season int) bool {
+	if c.seasonHistory == nil {
+		c.seasonHistory = c.seasonsFromHistory()
+	}
+	if c.seasonHistory[strings.ToLower(title)] >= season {
+		return true
+	}
+	return c.tmdbSeasons(title) >= season
+}
+
+func (c *CLI) seasonsFromHistory() map[string]int {
+	seasons := make(map[string]int)
+	if c.feedback == nil {
+		return seasons
+	}
+	p := parser.New()
+	for media := range c.feedback.Downloads {
+		if info, err := p.Parse(filepath.Base(media)); err == nil && info.HasSeasonEpisode() {
+			key := strings.ToLower(info.Title)
+			seasons[key] = max(seasons[key], info.Season)
+		}
+	}
+	return seasons
+}
+
+func (c *CLI) tmdbSeasons(title string) int {
+	cfg := c.loadedConfig()
+	if cfg.Metadata.TMDBAPIKey == "" {
+		return 0
+	}
+	key := strings.ToLower(title)
+	if seasons, ok := c.showSeasons[key]; ok {
+		return seasons
+	}
+
+	ctx, cancel := context.WithTimeout(c.context(), fileTimeout(cfg))
+	defer cancel()
+	seasons, err := c.tmdbClient(cfg).SeasonCount(ctx, title)
+	if err != nil {
+		ui := c.ui()
+		ui.Printf("  %s Could not look up the seasons of %s on TMDB: %v\n", ui.Warning(ui.Icon(output.IconWarning)), title, err)
+	}
+	if c.showSeasons == nil {
+		c.showSeasons = make(map[string]int)
+	}
+	c.showSeasons[key] = seasons
+	return seasons
+}
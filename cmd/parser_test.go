@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/feedback"
+	"github.com/carlosarraes/subs-cli/internal/output"
+)
+
+func TestNewParser(t *testing.T) {
+	t.Parallel()
+
+	history := &feedback.Store{Downloads: map[string][]feedback.Download{
+		"/tv/Show.Name.S12E01.720p.mkv": {{Provider: "opensubtitles"}},
+	}}
+
+	t.Run("history", func(t *testing.T) {
+		t.Parallel()
+		cli := &CLI{cfg: config.Default(), feedback: history}
+		p := cli.newParser(cli.loadedConfig())
+
+		info, err := p.Parse("Show.Name.1015.720p.mkv")
+		require.NoError(t, err)
+		assert.Equal(t, 10, info.Season)
+		assert.Equal(t, 15, info.Episode)
+
+		_, err = p.Parse("Other.Show.1015.720p.mkv")
+		assert.Error(t, err, "titles without known seasons are not read as SSEE")
+		_, err = p.Parse("Show.Name.1315.720p.mkv")
+		assert.Error(t, err, "seasons past the last one downloaded are not read as SSEE")
+	})
+
+	t.Run("never", func(t *testing.T) {
+		t.Parallel()
+		cfg := config.Default()
+		cfg.Parser.FourDigitEpisodes = config.FourDigitNever
+		cli := &CLI{cfg: cfg, feedback: history}
+		_, err := cli.newParser(cfg).Parse("Show.Name.1015.720p.mkv")
+		assert.Error(t, err)
+	})
+
+	t.Run("three digits", func(t *testing.T) {
+		t.Parallel()
+		cli := &CLI{cfg: config.Default()}
+		_, err := cli.newParser(cli.loadedConfig()).Parse("Series.Name.101.720p.mkv")
+		assert.Error(t, err, "3-digit episodes are opt-in")
+
+		cfg := config.Default()
+		cfg.Parser.ThreeDigitEpisodes = true
+		info, err := (&CLI{cfg: cfg}).newParser(cfg).Parse("Series.Name.101.720p.mkv")
+		require.NoError(t, err)
+		assert.Equal(t, 1, info.Season)
+		assert.Equal(t, 1, info.Episode)
+	})
+
+	t.Run("tmdb", func(t *testing.T) {
+		t.Parallel()
+		var searches atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/search/tv":
+				searches.Add(1)
+				if r.URL.Query().Get("query") == "The Simpsons" {
+					w.Write([]byte(`{"results":[{"id":456,"name":"The Simpsons"}]}`))
+					return
+				}
+				w.Write([]byte(`{"results":[]}`))
+			case "/tv/456":
+				w.Write([]byte(`{"number_of_seasons":36}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+
+		cfg := config.Default()
+		cfg.Metadata.TMDBAPIKey = "key"
+		var buf bytes.Buffer
+		cli := &CLI{
+			cfg:  cfg,
+			out:  output.New(&buf, output.Options{NoColor: true, NoEmoji: true}),
+			tmdb: api.NewTMDBClient(&api.Config{BaseURL: server.URL, APIKey: "key"}),
+		}
+		p := cli.newParser(cfg)
+
+		info, err := p.Parse("The.Simpsons.1005.720p.HDTV.x264.mkv")
+		require.NoError(t, err)
+		assert.Equal(t, "episode", info.Type)
+		assert.Equal(t, 10, info.Season)
+		assert.Equal(t, 5, info.Episode)
+
+		info, err = p.Parse("The.Simpsons.1006.720p.HDTV.x264.mkv")
+		require.NoError(t, err)
+		assert.Equal(t, 6, info.Episode)
+
+		info, err = p.Parse("Inception.2010.1080p.BluRay.x264-SPARKS.mkv")
+		require.NoError(t, err)
+		assert.Equal(t, "movie", info.Type)
+		assert.Equal(t, int32(1), searches.Load(), "titles are looked up once and years never")
+		assert.Empty(t, buf.String())
+	})
+}
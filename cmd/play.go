@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/subformat"
+)
+
+var defaultPlayerArgs = []string{"--sub-file={subtitle}", "{video}"}
+
+type PlayCmd struct {
+	Video    string        `arg:"" type:"existingfile" help:"Video to play."`
+	Language []string      `short:"l" long:"language" completion:"languages" help:"Subtitle languages to try in order; the first one with a result is used. Defaults to the config file languages, then the system locale."`
+	Player   string        `long:"player" placeholder:"COMMAND" help:"Player to launch, e.g. mpv or vlc. Overrides player.command in the config file (default mpv)."`
+	Keep     bool          `long:"keep" help:"Keep the downloaded subtitle in the temporary directory after the player exits and print its path."`
+	Config   string        `short:"c" long:"config" type:"existingfile" help:"Path to custom YAML configuration file. Default location: ~/.subs-cli/config.yaml"`
+	Proxy    string        `long:"proxy" placeholder:"URL" help:"Proxy for provider requests, e.g. http://host:3128 or socks5://host:1080."`
+	NoEmoji  bool          `long:"no-emoji" help:"Replace emoji status symbols with plain ASCII markers."`
+	WaitLock time.Duration `long:"wait-lock" placeholder:"10m" help:"When another subs-cli run is downloading, wait up to this long for it to finish instead of exiting."`
+}
+
+func (p *PlayCmd) Run(ctx context.Context) error {
+	cli := &CLI{Config: p.Config, Proxy: p.Proxy, NoEmoji: p.NoEmoji, Language: p.Language, WaitLock: p.WaitLock, ctx: ctx}
+	if err := cli.loadConfig(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	// Hold the lock across every language tried, but not while the player runs.
+	l, err := cli.acquireLock()
+	if err != nil {
+		return err
+	}
+	ui := cli.ui()
+	ui.Printf("%s Looking for %s subtitles for %s...\n", ui.Icon(output.IconSearch), strings.Join(cli.Language, ", "), filepath.Base(p.Video))
+	content, language, err := p.fetch(ctx, cli.Language)
+	l.Release()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err != nil {
+		ui.Printf("%s %v; playing without subtitles\n", ui.Warning(ui.Icon(output.IconWarning)), err)
+	}
+	return p.play(ui, cli.loadedConfig().Player, content, language, runPlayer)
+}
+
+func (p *PlayCmd) fetch(ctx context.Context, languages []string) ([]byte, string, error) {
+	var err error
+	for _, language := range languages {
+		if ctx.Err() != nil {
+			return nil, "", ctx.Err()
+		}
+		var buf bytes.Buffer
+		get := &CLI{
+			Path:           p.Video,
+			Language:       []string{language},
+			Config:         p.Config,
+			Proxy:          p.Proxy,
+			MaxPerLanguage: 1,
+			Yes:            true,
+			Stdout:         true,
+			stdout:         &buf,
+			locked:         true,
+			ctx:            ctx,
+		}
+		if err = get.Run(); err == nil {
+			return buf.Bytes(), language, nil
+		}
+	}
+	if err == nil {
+		err = fmt.Errorf("no subtitle language to look for")
+	}
+	return nil, "", err
+}
+
+func (p *PlayCmd) play(ui *output.Renderer, player config.PlayerConfig, content []byte, language string, launch func(name string, args []string) error) error {
+	var subtitle string
+	if content != nil {
+		dir, err := os.MkdirTemp("", "subs-play-")
+		if err != nil {
+			return fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+
+		name := strings.TrimSuffix(filepath.Base(p.Video), filepath.Ext(p.Video))
+		subtitle = filepath.Join(dir, name+"."+language+"."+subformat.Resolve(content, "", subformat.SRT))
+		if err := os.WriteFile(subtitle, content, 0600); err != nil {
+			os.RemoveAll(dir)
+			return fmt.Errorf("failed to write subtitle: %w", err)
+		}
+
+		if p.Keep {
+			defer ui.Printf("%s Subtitle kept at %s\n", ui.Icon(output.IconSaved), subtitle)
+		} else {
+			defer os.RemoveAll(dir)
+		}
+	}
+
+	command := p.Player
+	if command == "" {
+		command = player.Binary()
+	}
+	args := player.Args
+	if len(args) == 0 {
+		args = defaultPlayerArgs
+	}
+
+	ui.Printf("%s Starting %s\n", ui.Icon(output.IconInfo), command)
+	err := launch(command, playerArgs(args, p.Video, subtitle))
+	if errors.Is(err, exec.ErrNotFound) {
+		return fmt.Errorf("%s not found; install it, pass --player or set player.command in the config file", command)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", command, err)
+	}
+	return nil
+}
+
+func playerArgs(args []string, video, subtitle string) []string {
+	replacer := strings.NewReplacer("{video}", video, "{subtitle}", subtitle)
+	result := make([]string, 0, len(args))
+	for _, arg := range args {
+		if subtitle == "" && strings.Contains(arg, "{subtitle}") {
+			continue
+		}
+		result = append(result, replacer.Replace(arg))
+	}
+	if !slices.ContainsFunc(args, func(arg string) bool { return strings.Contains(arg, "{video}") }) {
+		result = append(result, video)
+	}
+	return result
+}
+
+func runPlayer(name string, args []string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+)
+
+const playSRT = "1\n00:00:01,000 --> 00:00:02,000\nHello\n"
+
+func TestPlayerArgs(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"--sub-file=/tmp/m.en.srt", "movie.mkv"}, playerArgs(defaultPlayerArgs, "movie.mkv", "/tmp/m.en.srt"))
+	assert.Equal(t, []string{"movie.mkv"}, playerArgs(defaultPlayerArgs, "movie.mkv", ""))
+	assert.Equal(t, []string{"--fs", "movie.mkv"}, playerArgs([]string{"--fs"}, "movie.mkv", ""), "the video is appended when no argument mentions it")
+	assert.Equal(t, []string{"movie.mkv", ":sub-file=/tmp/s.srt"}, playerArgs([]string{"{video}", ":sub-file={subtitle}"}, "movie.mkv", "/tmp/s.srt"))
+}
+
+func TestPlay(t *testing.T) {
+	t.Parallel()
+
+	newUI := func() (*output.Renderer, *bytes.Buffer) {
+		var buf bytes.Buffer
+		return output.New(&buf, output.Options{NoColor: true, NoEmoji: true}), &buf
+	}
+
+	t.Run("cleans_up", func(t *testing.T) {
+		t.Parallel()
+
+		ui, _ := newUI()
+		var name string
+		var args []string
+		var during []byte
+		launch := func(n string, a []string) error {
+			name, args = n, a
+			during, _ = os.ReadFile(a[0][len("--sub-file="):])
+			return nil
+		}
+
+		cmd := &PlayCmd{Video: "/movies/Movie.2023.mkv"}
+		require.NoError(t, cmd.play(ui, config.PlayerConfig{}, []byte(playSRT), "en", launch))
+
+		assert.Equal(t, config.DefaultPlayer, name)
+		require.Len(t, args, 2)
+		subtitle := args[0][len("--sub-file="):]
+		assert.Equal(t, "Movie.2023.en.srt", filepath.Base(subtitle))
+		assert.Equal(t, playSRT, string(during))
+		assert.NoFileExists(t, subtitle)
+		assert.Equal(t, "/movies/Movie.2023.mkv", args[1])
+	})
+
+	t.Run("keep", func(t *testing.T) {
+		t.Parallel()
+
+		ui, buf := newUI()
+		var subtitle string
+		launch := func(n string, a []string) error {
+			assert.Equal(t, "vlc", n)
+			subtitle = a[0][len("--sub-file="):]
+			return nil
+		}
+
+		cmd := &PlayCmd{Video: "Movie.mkv", Keep: true, Player: "vlc"}
+		require.NoError(t, cmd.play(ui, config.PlayerConfig{Command: "mpv"}, []byte(playSRT), "pt-BR", launch))
+		t.Cleanup(func() { os.RemoveAll(filepath.Dir(subtitle)) })
+
+		assert.FileExists(t, subtitle)
+		assert.Contains(t, buf.String(), "Subtitle kept at "+subtitle)
+	})
+
+	t.Run("no_subtitle", func(t *testing.T) {
+		t.Parallel()
+
+		ui, _ := newUI()
+		var args []string
+		cmd := &PlayCmd{Video: "Movie.mkv"}
+		require.NoError(t, cmd.play(ui, config.PlayerConfig{}, nil, "", func(n string, a []string) error {
+			args = a
+			return nil
+		}))
+		assert.Equal(t, []string{"Movie.mkv"}, args)
+	})
+
+	t.Run("player_missing", func(t *testing.T) {
+		t.Parallel()
+
+		ui, _ := newUI()
+		cmd := &PlayCmd{Video: "Movie.mkv", Player: "nosuchplayer"}
+		err := cmd.play(ui, config.PlayerConfig{}, nil, "", func(n string, a []string) error {
+			return fmt.Errorf("exec: %q: %w", n, exec.ErrNotFound)
+		})
+		assert.ErrorContains(t, err, "nosuchplayer not found; install it, pass --player")
+	})
+}
+
+func TestPlayFetchInterrupted(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cmd := &PlayCmd{Video: "Movie.mkv"}
+	content, _, err := cmd.fetch(ctx, []string{"en", "es"})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, content)
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/parser"
+)
+
+var playlistExtensions = map[string]bool{
+	".m3u":  true,
+	".m3u8": true,
+}
+
+func isPlaylist(path string) bool {
+	return playlistExtensions[strings.ToLower(filepath.Ext(path))]
+}
+
+func (c *CLI) processPlaylist(p *parser.Parser) error {
+	file, err := os.Open(c.Path)
+	if err != nil {
+		return fmt.Errorf("cannot read playlist: %w", err)
+	}
+	defer file.Close()
+
+	paths, skipped, err := readPlaylist(file, filepath.Dir(c.Path))
+	if err != nil {
+		return fmt.Errorf("cannot read playlist %s: %w", c.Path, err)
+	}
+
+	ui := c.ui()
+	ui.Println(ui.Bold("\n--- Media File Processing ---"))
+	for _, entry := range skipped {
+		ui.Printf("%s Skipping %s: only local files are processed from playlists\n", ui.Warning(ui.Icon(output.IconWarning)), entry)
+	}
+	c.processPaths(p, paths, "playlist "+filepath.Base(c.Path))
+	return nil
+}
+
+func readPlaylist(r io.Reader, dir string) ([]string, []string, error) {
+	var paths, skipped []string
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		entry := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+
+		path, ok := playlistPath(entry, dir)
+		if !ok {
+			skipped = append(skipped, entry)
+			continue
+		}
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	return paths, skipped, scanner.Err()
+}
+
+func playlistPath(entry, dir string) (string, bool) {
+	if strings.HasPrefix(strings.ToLower(entry), "file://") {
+		u, err := url.Parse(entry)
+		if err != nil || (u.Host != "" && u.Host != "localhost") {
+			return "", false
+		}
+		return filepath.FromSlash(u.Path), true
+	}
+	if strings.Contains(entry, "://") {
+		return "", false
+	}
+
+	path := filepath.FromSlash(entry)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	return path, true
+}
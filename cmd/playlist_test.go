@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/output"
+)
+
+func TestReadPlaylist(t *testing.T) {
+	t.Parallel()
+
+	playlist := "\ufeff#EXTM3U\n" +
+		"#EXTINF:5400,Inception\n" +
+		"Movies/Inception.2010.1080p.mkv\r\n" +
+		"\n" +
+		"/media/Show.S01E01.mkv\n" +
+		"file:///media/The%20Matrix.1999.mkv\n" +
+		"https://stream.local/live.m3u8\n" +
+		"Movies/Inception.2010.1080p.mkv\n"
+
+	paths, skipped, err := readPlaylist(strings.NewReader(playlist), "/lists")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join("/lists", "Movies", "Inception.2010.1080p.mkv"),
+		filepath.FromSlash("/media/Show.S01E01.mkv"),
+		filepath.FromSlash("/media/The Matrix.1999.mkv"),
+	}, paths)
+	assert.Equal(t, []string{"https://stream.local/live.m3u8"}, skipped)
+}
+
+func TestProcessPlaylist(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("x"), 0644))
+	playlist := filepath.Join(dir, "watchlist.m3u8")
+	require.NoError(t, os.WriteFile(playlist, []byte("#EXTM3U\nmissing.mkv\nnotes.txt\nhttp://host/movie.mkv\n"), 0644))
+
+	var buf bytes.Buffer
+	cli := &CLI{Path: playlist, Quiet: true, out: output.New(&buf, output.Options{NoColor: true})}
+	require.NoError(t, cli.processMediaFiles(nil))
+
+	assert.Contains(t, buf.String(), "Skipping http://host/movie.mkv: only local files are processed from playlists")
+	assert.Contains(t, buf.String(), "Skipping "+filepath.Join(dir, "missing.mkv"))
+	assert.Contains(t, buf.String(), "Skipping "+filepath.Join(dir, "notes.txt")+": not a supported media file")
+	assert.Contains(t, buf.String(), "No media files found in playlist watchlist.m3u8")
+
+	cli = &CLI{Path: filepath.Join(dir, "absent.m3u"), out: output.New(&bytes.Buffer{}, output.Options{NoColor: true})}
+	assert.ErrorContains(t, cli.processMediaFiles(nil), "cannot read playlist")
+}
+
+func TestValidatePath_Playlist(t *testing.T) {
+	t.Parallel()
+
+	playlist := filepath.Join(t.TempDir(), "watchlist.M3U")
+	require.NoError(t, os.WriteFile(playlist, []byte("#EXTM3U\n"), 0644))
+
+	cli := &CLI{Path: playlist}
+	result, err := cli.validatePath()
+	require.NoError(t, err)
+	assert.Contains(t, result.Message, "Playlist validated:")
+	assert.Empty(t, result.Warning)
+
+	cli = &CLI{Path: playlist, Stdout: true, Language: []string{"en"}}
+	assert.ErrorContains(t, cli.validateStdout(), "got playlist")
+}
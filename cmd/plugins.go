@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/plugin"
+)
+
+func (c *CLI) loadPlugins() {
+	dir, err := config.PluginsDir()
+	if err != nil {
+		return
+	}
+	c.loadPluginsFrom(dir)
+}
+
+func (c *CLI) loadPluginsFrom(dir string) {
+	ui := c.ui()
+	paths, err := plugin.Discover(dir)
+	if err != nil {
+		ui.Printf("%s Skipping provider plugins: %v\n", ui.Warning(ui.Icon(output.IconWarning)), err)
+		return
+	}
+
+	seen := map[string]bool{
+		api.ProviderOpenSubtitles:       true,
+		api.ProviderOpenSubtitlesXMLRPC: true,
+		api.ProviderLocal:               true,
+		api.ProviderJimaku:              true,
+		api.ProviderKitsunekko:          true,
+		api.ProviderNapiprojekt:         true,
+		api.ProviderNapisy24:            true,
+		api.ProviderBSPlayer:            true,
+	}
+	for _, path := range paths {
+		ctx, cancel := context.WithTimeout(c.context(), providerTimeout(c.loadedConfig()))
+		client, err := plugin.Load(ctx, path)
+		cancel()
+		if err == nil && seen[client.Name] {
+			client.Close()
+			err = fmt.Errorf("plugin %s uses the provider name '%s', which is already taken", path, client.Name)
+		}
+		if err != nil {
+			ui.Printf("%s Skipping provider plugin: %v\n", ui.Warning(ui.Icon(output.IconWarning)), err)
+			continue
+		}
+
+		seen[client.Name] = true
+		c.plugins = append(c.plugins, client)
+		if !c.Quiet {
+			ui.Printf("%s Loaded provider plugin %s\n", ui.Info(ui.Icon(output.IconInfo)), client.Name)
+		}
+	}
+}
+
+func (c *CLI) closeProviders() {
+	for _, client := range c.plugins {
+		client.Close()
+	}
+	c.plugins = nil
+
+	for _, client := range c.extras {
+		if closer, ok := client.(io.Closer); ok {
+			closer.Close()
+		}
+	}
+	c.extras = nil
+}
+
+func (c *CLI) providers(client *api.OpenSubtitlesClient) []api.Provider {
+	providers := []api.Provider{{Name: api.ProviderOpenSubtitles, Client: c.seasonClient(client)}}
+	if cfg := c.loadedConfig(); cfg.OpenSubtitles.Backend == config.BackendXMLRPC {
+		providers[0] = api.Provider{Name: api.ProviderOpenSubtitlesXMLRPC, Client: c.xmlrpcClient(cfg)}
+	}
+	for _, p := range c.plugins {
+		providers = append(providers, api.Provider{Name: p.Name, Client: p})
+	}
+	return providers
+}
+
+func (c *CLI) provider(name string) api.Client {
+	switch {
+	case name == api.ProviderLocal && c.archive != nil:
+		return c.archive
+	case name == api.ProviderOpenSubtitlesXMLRPC:
+		return c.xmlrpcClient(c.loadedConfig())
+	case c.extras[name] != nil:
+		return c.extras[name]
+	case providerFactories[name] != nil:
+		return c.extraProviders([]string{name}, c.loadedConfig())[0].Client
+	}
+	if p := c.plugin(name); p != nil {
+		return p
+	}
+	return nil
+}
+
+func (c *CLI) plugin(name string) *plugin.Client {
+	for _, p := range c.plugins {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
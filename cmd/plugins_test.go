@@ -0,0 +1,86 @@
+//go:build unix
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/carlosarraes/subs-cli/pkg/plugin"
+)
+
+type testPluginProvider struct{}
+
+func (testPluginProvider) Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	return []*models.Subtitle{{ID: "p1", FileID: "p1", Language: params.Language, ReleaseName: "Plugin.Release", Downloads: 1}}, nil
+}
+
+func (testPluginProvider) Download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error) {
+	return []byte("1\n00:00:01,000 --> 00:00:02,000\nfrom the plugin\n"), nil
+}
+
+func TestMain(m *testing.M) {
+	if os.Getenv(plugin.MagicCookieKey) == plugin.MagicCookieValue {
+		plugin.Serve(os.Getenv("SUBS_CLI_TEST_PLUGIN_NAME"), testPluginProvider{})
+		return
+	}
+	os.Exit(m.Run())
+}
+
+func TestLoadPlugins(t *testing.T) {
+	executable, err := os.Executable()
+	require.NoError(t, err)
+	dir := t.TempDir()
+	require.NoError(t, os.Symlink(executable, filepath.Join(dir, "subs-provider-tracker")))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "subs-provider-broken"), []byte("#!/bin/sh\nexit 1\n"), 0755))
+
+	var buf bytes.Buffer
+	cli := &CLI{out: output.New(&buf, output.Options{NoColor: true})}
+	cli.loadPluginsFrom(dir)
+	t.Cleanup(cli.closeProviders)
+
+	require.Len(t, cli.plugins, 1)
+	assert.Equal(t, "tracker", cli.plugins[0].Name)
+	assert.Contains(t, buf.String(), "Loaded provider plugin tracker")
+	assert.Contains(t, buf.String(), "Skipping provider plugin:")
+
+	providers := cli.providers(api.NewOpenSubtitlesClient(&api.Config{APIKey: "key"}))
+	require.Len(t, providers, 2)
+	assert.Equal(t, api.ProviderOpenSubtitles, providers[0].Name)
+	assert.Equal(t, "tracker", providers[1].Name)
+
+	subtitles, err := providers[1].Client.Search(context.Background(), &models.SearchParams{Query: "Movie", Language: "en"})
+	require.NoError(t, err)
+	require.Len(t, subtitles, 1)
+	assert.Equal(t, "tracker", subtitles[0].Provider)
+
+	content, err := cli.fetchSubtitle(context.Background(), nil, subtitles[0], filepath.Join(dir, "movie.en.srt"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "from the plugin")
+	assert.Nil(t, cli.plugin(api.ProviderOpenSubtitles))
+}
+
+func TestLoadPluginsNameClash(t *testing.T) {
+	executable, err := os.Executable()
+	require.NoError(t, err)
+	dir := t.TempDir()
+	require.NoError(t, os.Symlink(executable, filepath.Join(dir, "subs-provider-clash")))
+	t.Setenv("SUBS_CLI_TEST_PLUGIN_NAME", api.ProviderOpenSubtitles)
+
+	var buf bytes.Buffer
+	cli := &CLI{out: output.New(&buf, output.Options{NoColor: true})}
+	cli.loadPluginsFrom(dir)
+	t.Cleanup(cli.closeProviders)
+
+	assert.Empty(t, cli.plugins)
+	assert.Contains(t, buf.String(), "which is already taken")
+}
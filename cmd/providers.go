@@ -0,0 +1,349 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+type ProvidersCmd struct {
+	List    ProvidersListCmd    `cmd:"" default:"withargs" help:"List providers in search order with their status and capabilities (default)."`
+	Enable  ProvidersEnableCmd  `cmd:"" help:"Enable a provider in the configuration file."`
+	Disable ProvidersDisableCmd `cmd:"" help:"Disable a provider in the configuration file."`
+	Test    ProvidersTestCmd    `cmd:"" help:"Check that providers are reachable, accept the configured credentials and have quota left."`
+}
+
+type ProvidersListCmd struct {
+	Config  string `short:"c" long:"config" type:"existingfile" help:"Path to custom YAML configuration file. Default location: ~/.subs-cli/config.yaml"`
+	NoEmoji bool   `long:"no-emoji" help:"Replace emoji status symbols with plain ASCII markers."`
+}
+
+type ProvidersEnableCmd struct {
+	Name   string `arg:"" completion:"providers" help:"Provider to enable."`
+	Config string `short:"c" long:"config" type:"path" help:"Configuration file to change. Default location: ~/.subs-cli/config.yaml"`
+}
+
+type ProvidersDisableCmd struct {
+	Name   string `arg:"" completion:"providers" help:"Provider to disable."`
+	Config string `short:"c" long:"config" type:"path" help:"Configuration file to change. Default location: ~/.subs-cli/config.yaml"`
+}
+
+type ProvidersTestCmd struct {
+	Names     []string `arg:"" optional:"" completion:"providers" help:"Providers to test. Defaults to every enabled provider."`
+	Config    string   `short:"c" long:"config" type:"existingfile" help:"Path to custom YAML configuration file. Default location: ~/.subs-cli/config.yaml"`
+	Proxy     string   `long:"proxy" placeholder:"URL" help:"Proxy for provider requests, e.g. http://host:3128 or socks5://host:1080."`
+	DebugHTTP bool     `long:"debug-http" help:"Log provider HTTP requests and responses to stderr, with credentials redacted."`
+	NoEmoji   bool     `long:"no-emoji" help:"Replace emoji status symbols with plain ASCII markers."`
+}
+
+type providerEntry struct {
+	name    string
+	client  api.Client
+	enabled bool
+	note    string
+}
+
+func (p *ProvidersListCmd) Run() error {
+	cli := &CLI{Config: p.Config, NoEmoji: p.NoEmoji}
+	if err := cli.loadConfig(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	cli.loadPlugins()
+	defer cli.closeProviders()
+	cli.loadArchive()
+
+	writeProviders(cli.ui(), cli.providerEntries(cli.loadedConfig()))
+	return nil
+}
+
+func (c *CLI) providerEntries(cfg *config.Config) []providerEntry {
+	var enabled, disabled []providerEntry
+	add := func(entry providerEntry) {
+		if entry.enabled {
+			enabled = append(enabled, entry)
+		} else {
+			disabled = append(disabled, entry)
+		}
+	}
+
+	local := providerEntry{name: api.ProviderLocal, client: api.NewLocalClient(""), note: "set archive.path or pass --archive"}
+	if c.archive != nil {
+		local = providerEntry{name: api.ProviderLocal, client: c.archive, enabled: true, note: "searched before online providers"}
+	}
+	add(local)
+
+	xmlrpc := cfg.OpenSubtitles.Backend == config.BackendXMLRPC
+	add(providerEntry{name: api.ProviderOpenSubtitles, client: c.newClient(cfg), enabled: !xmlrpc, note: backendNote(!xmlrpc, config.BackendREST)})
+	add(providerEntry{name: api.ProviderOpenSubtitlesXMLRPC, client: c.xmlrpcClient(cfg), enabled: xmlrpc, note: backendNote(xmlrpc, config.BackendXMLRPC)})
+
+	for _, p := range c.plugins {
+		add(providerEntry{name: p.Name, client: p, enabled: true, note: "plugin " + p.Path})
+	}
+
+	anime := cfg.Anime || c.Anime
+	for _, p := range c.extraProviders([]string{api.ProviderJimaku, api.ProviderKitsunekko}, cfg) {
+		entry := providerEntry{name: p.Name, client: p.Client, enabled: anime}
+		switch {
+		case !anime:
+			entry.note = "anime is off"
+		case p.Name == api.ProviderJimaku && cfg.Jimaku.APIKey == "":
+			entry.enabled, entry.note = false, "needs jimaku.api_key"
+		}
+		add(entry)
+	}
+
+	optional := slices.Clone(cfg.Providers)
+	for _, name := range config.OptionalProviders {
+		if !slices.Contains(optional, name) {
+			optional = append(optional, name)
+		}
+	}
+	for _, p := range c.extraProviders(optional, cfg) {
+		entry := providerEntry{name: p.Name, client: p.Client, enabled: slices.Contains(cfg.Providers, p.Name)}
+		if !entry.enabled {
+			entry.note = "not in providers"
+		}
+		add(entry)
+	}
+
+	return append(enabled, disabled...)
+}
+
+func backendNote(enabled bool, backend string) string {
+	if enabled {
+		return ""
+	}
+	return "opensubtitles.backend is not " + backend
+}
+
+func writeProviders(ui *output.Renderer, entries []providerEntry) {
+	ui.Printf("%-3s %-22s %-9s %-18s %-14s %s\n", "#", "Provider", "Status", "Searches by", "Languages", "Notes")
+	ui.Printf("%s\n", strings.Repeat("-", 90))
+
+	position := 0
+	for _, entry := range entries {
+		order, status := "-", "disabled"
+		if entry.enabled {
+			position++
+			order, status = fmt.Sprint(position), "enabled"
+		}
+
+		caps := api.CapabilitiesOf(entry.client)
+		languages := "any"
+		if len(caps.Languages) > 0 {
+			languages = strings.Join(caps.Languages, ", ")
+		}
+
+		var notes []string
+		if caps.NeedsAuth {
+			notes = append(notes, "needs an account or API key")
+		}
+		if caps.RateLimit != "" {
+			notes = append(notes, caps.RateLimit)
+		}
+		if entry.note != "" {
+			notes = append(notes, entry.note)
+		}
+
+		if entry.enabled {
+			status = ui.Success(fmt.Sprintf("%-9s", status))
+		} else {
+			status = ui.Warning(fmt.Sprintf("%-9s", status))
+		}
+		ui.Printf("%-3s %-22s %s %-18s %-14s %s\n", order, entry.name, status, searchKinds(caps), languages, strings.Join(notes, "; "))
+	}
+	ui.Printf("\nOnline providers are searched in parallel; a local archive is searched first.\n")
+}
+
+func searchKinds(caps models.Capabilities) string {
+	var kinds []string
+	if caps.TitleSearch {
+		kinds = append(kinds, "title")
+	}
+	if caps.HashSearch {
+		kinds = append(kinds, "hash")
+	}
+	if caps.IMDBSearch {
+		kinds = append(kinds, "imdb")
+	}
+	return strings.Join(kinds, ", ")
+}
+
+func (p *ProvidersEnableCmd) Run() error {
+	return toggleProvider(p.Config, p.Name, true, output.NewStdout(false))
+}
+
+func (p *ProvidersDisableCmd) Run() error {
+	return toggleProvider(p.Config, p.Name, false, output.NewStdout(false))
+}
+
+func toggleProvider(path, name string, enable bool, ui *output.Renderer) error {
+	if path == "" {
+		defaultPath, err := config.DefaultPath()
+		if err != nil {
+			return err
+		}
+		path = defaultPath
+	}
+
+	cfg := config.Default()
+	if _, err := os.Stat(path); err == nil {
+		if cfg, err = config.Load(path); err != nil {
+			return fmt.Errorf("configuration error: %w", err)
+		}
+	}
+
+	message, err := setProvider(cfg, name, enable)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	if err := cfg.Save(path); err != nil {
+		return err
+	}
+
+	ui.Printf("%s %s (saved to %s)\n", ui.Success(ui.Icon(output.IconSuccess)), message, path)
+	return nil
+}
+
+func setProvider(cfg *config.Config, name string, enable bool) (string, error) {
+	switch {
+	case name == api.ProviderOpenSubtitles && enable, name == api.ProviderOpenSubtitlesXMLRPC && !enable:
+		cfg.OpenSubtitles.Backend = config.BackendREST
+		return "OpenSubtitles is searched through the REST API", nil
+	case name == api.ProviderOpenSubtitlesXMLRPC:
+		cfg.OpenSubtitles.Backend = config.BackendXMLRPC
+		return "OpenSubtitles is searched through the XML-RPC API", nil
+	case name == api.ProviderOpenSubtitles:
+		return "", fmt.Errorf("OpenSubtitles is always searched; run 'subs providers enable %s' to use its XML-RPC API instead", api.ProviderOpenSubtitlesXMLRPC)
+	case name == api.ProviderLocal && enable:
+		return "", fmt.Errorf("set archive.path in the configuration file, or pass --archive, to search a local subtitle archive")
+	case name == api.ProviderLocal:
+		cfg.Archive.Path = ""
+		return "The local subtitle archive is no longer searched", nil
+	case name == api.ProviderJimaku || name == api.ProviderKitsunekko:
+		cfg.Anime = enable
+		if !enable {
+			return "Anime providers (Kitsunekko, Jimaku) disabled", nil
+		}
+		if name == api.ProviderJimaku && cfg.Jimaku.APIKey == "" {
+			return "Anime providers enabled; Jimaku is also searched once jimaku.api_key is set", nil
+		}
+		return "Anime providers (Kitsunekko, Jimaku) enabled", nil
+	case slices.Contains(config.OptionalProviders, name):
+		cfg.Providers = slices.DeleteFunc(cfg.Providers, func(p string) bool { return p == name })
+		if enable {
+			cfg.Providers = append(cfg.Providers, name)
+			return fmt.Sprintf("Enabled %s", name), nil
+		}
+		return fmt.Sprintf("Disabled %s", name), nil
+	}
+
+	dir, _ := config.PluginsDir()
+	return "", fmt.Errorf("unknown provider '%s'; plugins are enabled by adding them to %s and disabled by removing them", name, dir)
+}
+
+func (p *ProvidersTestCmd) Run(ctx context.Context) error {
+	cli := &CLI{Config: p.Config, Proxy: p.Proxy, DebugHTTP: p.DebugHTTP, NoEmoji: p.NoEmoji, ctx: ctx}
+	if err := cli.loadConfig(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	cli.loadPlugins()
+	defer cli.closeProviders()
+	cli.loadArchive()
+
+	cfg := cli.loadedConfig()
+	return p.test(ctx, cli.ui(), cli.providerEntries(cfg), providerTimeout(cfg))
+}
+
+func (p *ProvidersTestCmd) test(ctx context.Context, ui *output.Renderer, entries []providerEntry, timeout time.Duration) error {
+	var selected []providerEntry
+	for _, entry := range entries {
+		if len(p.Names) == 0 && entry.enabled || slices.Contains(p.Names, entry.name) {
+			selected = append(selected, entry)
+		}
+	}
+	for _, name := range p.Names {
+		if !slices.ContainsFunc(selected, func(e providerEntry) bool { return e.name == name }) {
+			return fmt.Errorf("unknown provider '%s'", name)
+		}
+	}
+
+	failed := 0
+	for _, entry := range selected {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		entryCtx, cancel := context.WithTimeout(ctx, timeout)
+		status, err := testProvider(entryCtx, entry.client)
+		cancel()
+
+		if err != nil {
+			failed++
+			ui.Printf("%s %s: %s: %v\n", ui.Error(ui.Icon(output.IconFailure)), entry.name, failureKind(err), err)
+			continue
+		}
+		ui.Printf("%s %s: %s\n", ui.Success(ui.Icon(output.IconSuccess)), entry.name, status)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d providers failed", failed, len(selected))
+	}
+	return nil
+}
+
+func testProvider(ctx context.Context, client api.Client) (string, error) {
+	if account, ok := client.(interface{ HasCredentials() bool }); !ok || account.HasCredentials() {
+		if err := client.Authenticate(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	caps := api.CapabilitiesOf(client)
+	status := "reachable"
+	if caps.TitleSearch {
+		params := &models.SearchParams{Query: "The Matrix", Year: 1999, Type: "movie", Language: "en"}
+		if len(caps.Languages) > 0 {
+			params.Language = caps.Languages[0]
+		}
+		if _, err := client.Search(ctx, params); err != nil {
+			return "", err
+		}
+	} else {
+		status = "search not checked, hash-only providers need a video"
+	}
+
+	if reporter, ok := client.(api.QuotaReporter); ok {
+		quota, err := reporter.Quota(ctx)
+		if err != nil {
+			return "", err
+		}
+		if quota != nil {
+			status += fmt.Sprintf(", %d of %d downloads left today", quota.Remaining, quota.Allowed)
+		}
+	}
+	return status, nil
+}
+
+func failureKind(err error) string {
+	switch {
+	case errors.Is(err, api.ErrAuthFailed):
+		return "authentication failed"
+	case errors.Is(err, api.ErrQuotaExceeded):
+		return "quota exceeded"
+	case errors.Is(err, api.ErrProviderUnavailable), errors.Is(err, context.DeadlineExceeded):
+		return "unreachable"
+	}
+	return "failed"
+}
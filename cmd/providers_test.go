@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+)
+
+func TestProviderEntries(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+	cfg.Anime = true
+	cfg.Providers = []string{api.ProviderBSPlayer, api.ProviderNapiprojekt}
+	cli := &CLI{cfg: cfg}
+
+	var enabled, disabled []string
+	for _, entry := range cli.providerEntries(cfg) {
+		if entry.enabled {
+			enabled = append(enabled, entry.name)
+		} else {
+			disabled = append(disabled, entry.name)
+		}
+	}
+	assert.Equal(t, []string{api.ProviderOpenSubtitles, api.ProviderKitsunekko, api.ProviderBSPlayer, api.ProviderNapiprojekt}, enabled)
+	assert.Equal(t, []string{api.ProviderLocal, api.ProviderOpenSubtitlesXMLRPC, api.ProviderJimaku, api.ProviderNapisy24}, disabled)
+
+	var buf bytes.Buffer
+	writeProviders(output.New(&buf, output.Options{NoColor: true}), cli.providerEntries(cfg))
+	assert.Contains(t, buf.String(), "1   opensubtitles          enabled   title, hash, imdb")
+	assert.Contains(t, buf.String(), "4   napiprojekt            enabled   hash               pl, en")
+	assert.Contains(t, buf.String(), "-   jimaku                 disabled  title              ja             needs an account or API key; needs jimaku.api_key")
+}
+
+func TestSetProvider(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+
+	_, err := setProvider(cfg, api.ProviderOpenSubtitlesXMLRPC, true)
+	require.NoError(t, err)
+	assert.Equal(t, config.BackendXMLRPC, cfg.OpenSubtitles.Backend)
+	_, err = setProvider(cfg, api.ProviderOpenSubtitlesXMLRPC, false)
+	require.NoError(t, err)
+	assert.Equal(t, config.BackendREST, cfg.OpenSubtitles.Backend)
+	_, err = setProvider(cfg, api.ProviderOpenSubtitles, false)
+	assert.ErrorContains(t, err, "OpenSubtitles is always searched")
+
+	message, err := setProvider(cfg, api.ProviderJimaku, true)
+	require.NoError(t, err)
+	assert.True(t, cfg.Anime)
+	assert.Contains(t, message, "once jimaku.api_key is set")
+
+	_, err = setProvider(cfg, api.ProviderNapisy24, true)
+	require.NoError(t, err)
+	_, err = setProvider(cfg, api.ProviderNapisy24, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{api.ProviderNapisy24}, cfg.Providers, "enabling twice keeps one entry")
+	_, err = setProvider(cfg, api.ProviderNapisy24, false)
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Providers)
+
+	cfg.Archive.Path = "/mnt/subs"
+	_, err = setProvider(cfg, api.ProviderLocal, false)
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Archive.Path)
+	_, err = setProvider(cfg, api.ProviderLocal, true)
+	assert.ErrorContains(t, err, "archive.path")
+
+	_, err = setProvider(cfg, "mytracker", true)
+	assert.ErrorContains(t, err, "unknown provider 'mytracker'")
+}
+
+func TestToggleProvider(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("opensubtitles:\n  api_key: key123\n"), 0600))
+
+	var buf bytes.Buffer
+	ui := output.New(&buf, output.Options{NoColor: true, NoEmoji: true})
+	require.NoError(t, toggleProvider(path, api.ProviderBSPlayer, true, ui))
+	assert.Contains(t, buf.String(), "Enabled bsplayer (saved to "+path+")")
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{api.ProviderBSPlayer}, cfg.Providers)
+	assert.Equal(t, "key123", cfg.OpenSubtitles.APIKey, "other settings are kept")
+}
+
+func TestProvidersTest(t *testing.T) {
+	t.Parallel()
+
+	entries := []providerEntry{
+		{name: "working", client: &fakeSubtitleClient{}, enabled: true},
+		{name: "locked", client: &failingSearchClient{err: fmt.Errorf("%w: invalid API key", api.ErrAuthFailed)}, enabled: true},
+		{name: "hashonly", client: api.NewNapisy24Client(&api.Config{}), enabled: false},
+	}
+
+	var buf bytes.Buffer
+	ui := output.New(&buf, output.Options{NoColor: true, NoEmoji: true})
+	err := (&ProvidersTestCmd{}).test(context.Background(), ui, entries, time.Second)
+	assert.EqualError(t, err, "1 of 2 providers failed")
+	assert.Contains(t, buf.String(), "[ok] working: reachable")
+	assert.Contains(t, buf.String(), "[x] locked: authentication failed: authentication failed: invalid API key")
+	assert.NotContains(t, buf.String(), "hashonly", "disabled providers are only tested by name")
+
+	buf.Reset()
+	require.NoError(t, (&ProvidersTestCmd{Names: []string{"hashonly"}}).test(context.Background(), ui, entries, time.Second))
+	assert.Contains(t, buf.String(), "[ok] hashonly: search not checked, hash-only providers need a video")
+
+	assert.EqualError(t, (&ProvidersTestCmd{Names: []string{"missing"}}).test(context.Background(), ui, entries, time.Second), "unknown provider 'missing'")
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/internal/moviehash"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/rar"
+)
+
+var scanExtensions = func() map[string]bool {
+	extensions := map[string]bool{".rar": true}
+	for ext := range mediaExtensions {
+		extensions[ext] = true
+	}
+	return extensions
+}()
+
+func isVideoName(name string) bool {
+	return mediaExtensions[strings.ToLower(filepath.Ext(name))]
+}
+
+func (c *CLI) openRar(archive string) (string, error) {
+	if !rar.IsFirstVolume(archive) {
+		return "", fmt.Errorf("%s is not the first volume of its RAR set", filepath.Base(archive))
+	}
+	f, err := rar.Open(archive, isVideoName)
+	if err != nil {
+		return "", fmt.Errorf("cannot read RAR set: %w", err)
+	}
+
+	inner := filepath.Join(filepath.Dir(archive), f.Name)
+	if c.rars == nil {
+		c.rars = make(map[string]*rar.File)
+	}
+	c.rars[inner] = f
+
+	ui := c.ui()
+	ui.Printf("  %s Reading %s from %d RAR volume(s) without extracting\n", ui.Info(ui.Icon(output.IconInfo)), f.Name, len(f.Volumes))
+	return inner, nil
+}
+
+func hashRar(f *rar.File) (string, int64, error) {
+	if !f.Complete() {
+		return "", 0, fmt.Errorf("%s is compressed or some volumes are missing", f.Name)
+	}
+	hash, err := moviehash.ComputeReaderAt(f, f.Size, f.Name)
+	return hash, f.Size, err
+}
+
+func withoutExtracted(files []string) []string {
+	present := make(map[string]bool, len(files))
+	for _, file := range files {
+		present[file] = true
+	}
+
+	kept := make([]string, 0, len(files))
+	for _, file := range files {
+		if rar.IsArchive(file) {
+			if !rar.IsFirstVolume(file) {
+				continue
+			}
+			f, err := rar.Open(file, isVideoName)
+			if err != nil || present[filepath.Join(filepath.Dir(file), f.Name)] {
+				continue
+			}
+		}
+		kept = append(kept, file)
+	}
+	return kept
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func writeStoredRar(t *testing.T, path, name string, data []byte, method byte) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	buf.WriteString("Rar!\x1a\x07\x00")
+	buf.Write([]byte{0, 0, 0x73, 0, 0, 13, 0, 0, 0, 0, 0, 0, 0})
+
+	header := make([]byte, 32)
+	header[2] = 0x74
+	binary.LittleEndian.PutUint16(header[3:], 0x8000)
+	binary.LittleEndian.PutUint16(header[5:], uint16(32+len(name)))
+	binary.LittleEndian.PutUint32(header[7:], uint32(len(data)))
+	binary.LittleEndian.PutUint32(header[11:], uint32(len(data)))
+	header[25] = method
+	binary.LittleEndian.PutUint16(header[26:], uint16(len(name)))
+	buf.Write(append(header, name...))
+	buf.Write(data)
+
+	buf.Write([]byte{0, 0, 0x7b, 0, 0x40, 7, 0})
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+}
+
+func TestOpenRar(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "show.s01e01.720p-grp.rar")
+	writeStoredRar(t, archive, "Show.S01E01.720p.HDTV.x264-GRP.mkv", make([]byte, 200000), 0x30)
+
+	var buf bytes.Buffer
+	cli := &CLI{cfg: config.Default(), out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+	inner, err := cli.openRar(archive)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "Show.S01E01.720p.HDTV.x264-GRP.mkv"), inner, "subtitles are written next to the RAR set with the inner basename")
+	assert.Contains(t, buf.String(), "Reading Show.S01E01.720p.HDTV.x264-GRP.mkv from 1 RAR volume(s)")
+
+	params := &models.SearchParams{}
+	cli.hashMedia(params, inner)
+	assert.Equal(t, inner, params.MediaPath)
+	assert.Equal(t, "0000000000030d40", params.MovieHash)
+	assert.Equal(t, int64(200000), params.MovieByteSize)
+
+	_, err = cli.openRar(filepath.Join(dir, "show.part02.rar"))
+	assert.ErrorContains(t, err, "not the first volume")
+}
+
+func TestHashMedia_CompressedRar(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "movie.rar")
+	writeStoredRar(t, archive, "Movie.2023.1080p.mkv", make([]byte, 200000), 0x33)
+
+	var buf bytes.Buffer
+	cli := &CLI{cfg: config.Default(), out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+	inner, err := cli.openRar(archive)
+	require.NoError(t, err)
+
+	params := &models.SearchParams{}
+	cli.hashMedia(params, inner)
+	assert.Empty(t, params.MovieHash)
+	assert.Contains(t, buf.String(), "searching by name only")
+}
+
+func TestFindMediaFiles_Rar(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeStoredRar(t, filepath.Join(dir, "packed.part01.rar"), "Packed.2020.mkv", []byte("video"), 0x30)
+	writeStoredRar(t, filepath.Join(dir, "packed.part02.rar"), "Packed.2020.mkv", []byte("video"), 0x30)
+	writeStoredRar(t, filepath.Join(dir, "extracted.rar"), "Extracted.2021.mkv", []byte("video"), 0x30)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Extracted.2021.mkv"), []byte("video"), 0644))
+	writeStoredRar(t, filepath.Join(dir, "subs.rar"), "Packed.2020.idx", []byte("idx"), 0x30)
+
+	files, err := findMediaFiles(dir, (&CLI{}).scanOptions())
+	require.NoError(t, err)
+
+	var names []string
+	for _, file := range files {
+		names = append(names, filepath.Base(file))
+	}
+	assert.ElementsMatch(t, []string{"packed.part01.rar", "Extracted.2021.mkv"}, names)
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/feedback"
+	"github.com/carlosarraes/subs-cli/internal/language"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+type RateCmd struct {
+	Video     string `arg:"" type:"existingfile" help:"Video whose downloaded subtitle you want to rate."`
+	Good      bool   `long:"good" xor:"vote" help:"The subtitle was good: prefer it and its uploader next time."`
+	Bad       bool   `long:"bad" xor:"vote" help:"The subtitle was bad: never pick it again and rank its uploader lower."`
+	Language  string `short:"l" long:"language" help:"Which subtitle to rate when several languages were downloaded for the video."`
+	Config    string `short:"c" long:"config" type:"existingfile" help:"Path to custom YAML configuration file. Default location: ~/.subs-cli/config.yaml"`
+	Proxy     string `long:"proxy" placeholder:"URL" help:"Proxy for provider requests, e.g. http://host:3128 or socks5://host:1080."`
+	DebugHTTP bool   `long:"debug-http" help:"Log provider HTTP requests and responses to stderr, with credentials redacted."`
+	NoEmoji   bool   `long:"no-emoji" help:"Replace emoji status symbols with plain ASCII markers."`
+}
+
+func (r *RateCmd) Run(ctx context.Context) error {
+	if !r.Good && !r.Bad {
+		return fmt.Errorf("pass --good or --bad")
+	}
+
+	cli := &CLI{Config: r.Config, Proxy: r.Proxy, DebugHTTP: r.DebugHTTP, NoEmoji: r.NoEmoji, ctx: ctx}
+	if err := cli.loadConfig(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	cfg := cli.loadedConfig()
+
+	path, err := config.FeedbackPath()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, fileTimeout(cfg))
+	defer cancel()
+
+	return r.rate(ctx, cli.ui(), path, map[string]any{
+		api.ProviderOpenSubtitles:       cli.newClient(cfg),
+		api.ProviderOpenSubtitlesXMLRPC: cli.xmlrpcClient(cfg),
+	})
+}
+
+func (r *RateCmd) rate(ctx context.Context, ui *output.Renderer, path string, providers map[string]any) error {
+	var rated feedback.Download
+	err := feedback.Update(path, func(store *feedback.Store) error {
+		download, err := r.pick(store.DownloadsFor(r.Video))
+		if err != nil {
+			return err
+		}
+		store.Vote(download, r.Good)
+		rated = download
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	name := rated.Release
+	if name == "" {
+		name = filepath.Base(rated.Target)
+	}
+	if r.Good {
+		ui.Printf("%s Rated %s as good; it and its uploader will be preferred next time\n", ui.Success(ui.Icon(output.IconSuccess)), name)
+	} else {
+		ui.Printf("%s Rated %s as bad; it will not be picked again\n", ui.Success(ui.Icon(output.IconSuccess)), name)
+	}
+
+	voter, ok := providers[rated.Provider].(api.Voter)
+	if !ok {
+		ui.Printf("%s %s does not take votes, so the rating is only kept locally\n", ui.Info(ui.Icon(output.IconInfo)), providerName(rated.Provider))
+		return nil
+	}
+
+	subtitle := &models.Subtitle{ID: rated.SubtitleID, FileID: rated.FileID, Provider: rated.Provider, Language: rated.Language, ReleaseName: rated.Release}
+	if err := voter.Vote(ctx, subtitle, r.Good); err != nil {
+		ui.Printf("%s Could not send the vote to %s: %v\n", ui.Warning(ui.Icon(output.IconWarning)), providerName(rated.Provider), err)
+		return nil
+	}
+	ui.Printf("%s Vote sent to %s\n", ui.Success(ui.Icon(output.IconSuccess)), providerName(rated.Provider))
+	return nil
+}
+
+func (r *RateCmd) pick(downloads []feedback.Download) (feedback.Download, error) {
+	if len(downloads) == 0 {
+		return feedback.Download{}, fmt.Errorf("no subtitle downloaded by subs-cli is recorded for %s", r.Video)
+	}
+
+	if r.Language != "" {
+		code, err := language.Normalize(r.Language)
+		if err != nil {
+			return feedback.Download{}, fmt.Errorf("--language: %w", err)
+		}
+		for i := len(downloads) - 1; i >= 0; i-- {
+			if sameLanguage(downloads[i].Language, code) {
+				return downloads[i], nil
+			}
+		}
+		return feedback.Download{}, fmt.Errorf("no %s subtitle downloaded by subs-cli is recorded for %s", code, r.Video)
+	}
+
+	if len(downloads) > 1 {
+		languages := make([]string, 0, len(downloads))
+		for _, download := range downloads {
+			languages = append(languages, download.Language)
+		}
+		return feedback.Download{}, fmt.Errorf("several subtitles were downloaded for %s (%s); choose one with --language", r.Video, strings.Join(languages, ", "))
+	}
+	return downloads[0], nil
+}
+
+func sameLanguage(recorded, code string) bool {
+	if normalized, err := language.Normalize(recorded); err == nil {
+		return normalized == code
+	}
+	return strings.EqualFold(recorded, code)
+}
+
+func providerName(provider string) string {
+	switch provider {
+	case api.ProviderOpenSubtitles:
+		return "OpenSubtitles"
+	case api.ProviderOpenSubtitlesXMLRPC:
+		return "OpenSubtitles (XML-RPC)"
+	}
+	return provider
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/feedback"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+type fakeVoter struct {
+	voted *models.Subtitle
+	good  bool
+	err   error
+}
+
+func (f *fakeVoter) Vote(ctx context.Context, subtitle *models.Subtitle, good bool) error {
+	f.voted, f.good = subtitle, good
+	return f.err
+}
+
+func TestRate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feedback.json")
+	video := filepath.Join(dir, "movie.mkv")
+
+	require.NoError(t, feedback.Update(path, func(store *feedback.Store) error {
+		store.Record(video, feedback.NewDownload(&models.Subtitle{ID: "1", Provider: "opensubtitles", Language: "en", ReleaseName: "Movie.2020.BluRay"}, filepath.Join(dir, "movie.en.srt")))
+		store.Record(video, feedback.NewDownload(&models.Subtitle{ID: "2", Provider: "legacy", FileID: "22", Language: "pt-br", ReleaseName: "Movie.2020.WEB"}, filepath.Join(dir, "movie.pt-BR.srt")))
+		return nil
+	}))
+
+	run := func(cmd *RateCmd, providers map[string]any) (string, error) {
+		var buf bytes.Buffer
+		err := cmd.rate(context.Background(), output.New(&buf, output.Options{NoColor: true}), path, providers)
+		return buf.String(), err
+	}
+	votes := func() map[string]feedback.Vote {
+		store, err := feedback.Load(path)
+		require.NoError(t, err)
+		return store.Votes
+	}
+
+	t.Run("needs_language", func(t *testing.T) {
+		_, err := run(&RateCmd{Video: video, Bad: true}, nil)
+		assert.ErrorContains(t, err, "several subtitles were downloaded")
+		assert.ErrorContains(t, err, "(en, pt-br)")
+		assert.Empty(t, votes())
+	})
+
+	t.Run("local_only", func(t *testing.T) {
+		out, err := run(&RateCmd{Video: video, Bad: true, Language: "en"}, map[string]any{"opensubtitles": struct{}{}})
+		require.NoError(t, err)
+		assert.Contains(t, out, "Rated Movie.2020.BluRay as bad")
+		assert.Contains(t, out, "OpenSubtitles does not take votes")
+
+		vote, ok := votes()["opensubtitles:1"]
+		require.True(t, ok)
+		assert.False(t, vote.Good)
+	})
+
+	t.Run("sent_to_provider", func(t *testing.T) {
+		voter := &fakeVoter{}
+		out, err := run(&RateCmd{Video: video, Good: true, Language: "pt-BR"}, map[string]any{"legacy": voter})
+		require.NoError(t, err)
+		assert.Contains(t, out, "Rated Movie.2020.WEB as good")
+		assert.Contains(t, out, "Vote sent to legacy")
+		require.NotNil(t, voter.voted)
+		assert.Equal(t, "22", voter.voted.FileID)
+		assert.True(t, voter.good)
+		assert.True(t, votes()["legacy:2"].Good)
+	})
+
+	t.Run("provider_error_keeps_local_vote", func(t *testing.T) {
+		voter := &fakeVoter{err: errors.New("boom")}
+		out, err := run(&RateCmd{Video: video, Bad: true, Language: "pt-BR"}, map[string]any{"legacy": voter})
+		require.NoError(t, err)
+		assert.Contains(t, out, "Could not send the vote to legacy: boom")
+		assert.False(t, votes()["legacy:2"].Good)
+	})
+
+	t.Run("unknown_video", func(t *testing.T) {
+		_, err := run(&RateCmd{Video: filepath.Join(dir, "other.mkv"), Good: true}, nil)
+		assert.ErrorContains(t, err, "no subtitle downloaded by subs-cli is recorded")
+	})
+
+	t.Run("missing_language", func(t *testing.T) {
+		_, err := run(&RateCmd{Video: video, Good: true, Language: "es"}, nil)
+		assert.ErrorContains(t, err, "no es subtitle downloaded")
+	})
+}
+
+func TestRecordDownload(t *testing.T) {
+	dir := t.TempDir()
+	video := filepath.Join(dir, "movie.mkv")
+	require.NoError(t, os.WriteFile(video, []byte("video"), 0644))
+
+	var buf bytes.Buffer
+	cli := &CLI{out: output.New(&buf, output.Options{NoColor: true}), feedbackPath: filepath.Join(dir, "feedback.json")}
+	subtitle := &models.Subtitle{ID: "7", Provider: "opensubtitles", Language: "en", FileName: "movie.srt", SubFormat: "srt"}
+	require.NoError(t, cli.saveSubtitle([]byte("1\n00:00:01,000 --> 00:00:02,000\nHi\n"), subtitle, video, "en", true))
+
+	store, err := feedback.Load(cli.feedbackPath)
+	require.NoError(t, err)
+	downloads := store.DownloadsFor(video)
+	require.Len(t, downloads, 1)
+	assert.Equal(t, "7", downloads[0].SubtitleID)
+	assert.Equal(t, filepath.Join(dir, "movie.en.srt"), downloads[0].Target)
+
+	cli.DryRun = true
+	cli.recordDownload(&models.Subtitle{ID: "8"}, video, filepath.Join(dir, "movie.es.srt"))
+	store, err = feedback.Load(cli.feedbackPath)
+	require.NoError(t, err)
+	assert.Len(t, store.DownloadsFor(video), 1)
+}
+
+func TestXMLRPCBackend(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+	cfg.OpenSubtitles.Backend = config.BackendXMLRPC
+	cli := &CLI{cfg: cfg}
+
+	providers := cli.providers(api.NewOpenSubtitlesClient(&api.Config{APIKey: "key"}))
+	require.Len(t, providers, 1)
+	assert.Equal(t, api.ProviderOpenSubtitlesXMLRPC, providers[0].Name)
+	assert.Same(t, cli.xmlrpcClient(cfg), providers[0].Client)
+	assert.Same(t, cli.xmlrpc, cli.provider(api.ProviderOpenSubtitlesXMLRPC))
+	assert.Implements(t, (*api.Voter)(nil), cli.provider(api.ProviderOpenSubtitlesXMLRPC))
+	assert.Equal(t, "OpenSubtitles (XML-RPC)", providerName(api.ProviderOpenSubtitlesXMLRPC))
+
+	dir := t.TempDir()
+	video := filepath.Join(dir, "movie.mkv")
+	require.NoError(t, os.WriteFile(video, make([]byte, 128*1024), 0o644))
+
+	params := &models.SearchParams{Query: "Movie"}
+	cli.hashMedia(params, video)
+	assert.Len(t, params.MovieHash, 16)
+	assert.Equal(t, int64(128*1024), params.MovieByteSize)
+
+	rest := &CLI{cfg: config.Default()}
+	params = &models.SearchParams{Query: "Movie"}
+	rest.hashMedia(params, video)
+	assert.Len(t, params.MovieHash, 16, "local files are hashed whatever the backend")
+	assert.Equal(t, api.ProviderOpenSubtitles, rest.providers(api.NewOpenSubtitlesClient(&api.Config{APIKey: "key"}))[0].Name)
+}
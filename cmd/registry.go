@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+)
+
+var providerFactories = map[string]func(apiCfg *api.Config, cfg *config.Config) api.Client{
+	api.ProviderJimaku: func(apiCfg *api.Config, cfg *config.Config) api.Client {
+		apiCfg.APIKey = cfg.Jimaku.APIKey
+		return api.NewJimakuClient(apiCfg)
+	},
+	api.ProviderKitsunekko: func(apiCfg *api.Config, cfg *config.Config) api.Client {
+		return api.NewKitsunekkoClient(apiCfg)
+	},
+	api.ProviderNapiprojekt: func(apiCfg *api.Config, cfg *config.Config) api.Client {
+		return api.NewNapiprojektClient(apiCfg)
+	},
+	api.ProviderBSPlayer: func(apiCfg *api.Config, cfg *config.Config) api.Client {
+		apiCfg.BaseURL = cfg.BSPlayer.Endpoint
+		return api.NewBSPlayerClient(apiCfg)
+	},
+	api.ProviderNapisy24: func(apiCfg *api.Config, cfg *config.Config) api.Client {
+		apiCfg.Username, apiCfg.Password = cfg.Napisy24.Username, cfg.Napisy24.Password
+		return api.NewNapisy24Client(apiCfg)
+	},
+}
+
+func (c *CLI) animeProviders(cfg *config.Config) []api.Provider {
+	names := []string{api.ProviderKitsunekko}
+	if cfg.Jimaku.APIKey != "" {
+		names = append([]string{api.ProviderJimaku}, names...)
+	}
+	return c.extraProviders(names, cfg)
+}
+
+func (c *CLI) extraProviders(names []string, cfg *config.Config) []api.Provider {
+	if c.extras == nil {
+		c.extras = make(map[string]api.Client)
+	}
+
+	providers := make([]api.Provider, 0, len(names))
+	for _, name := range names {
+		client, ok := c.extras[name]
+		if !ok {
+			client = c.newExtraClient(name, cfg)
+			c.extras[name] = client
+		}
+		providers = append(providers, api.Provider{Name: name, Client: client})
+	}
+	return providers
+}
+
+func (c *CLI) newExtraClient(name string, cfg *config.Config) api.Client {
+	apiCfg := apiConfig(cfg)
+	apiCfg.APIKey, apiCfg.Username, apiCfg.Password = "", "", ""
+	apiCfg.NoProxy, apiCfg.Proxy = cfg.Proxy == config.ProxyDirect, ""
+	if !apiCfg.NoProxy {
+		apiCfg.Proxy = cfg.Proxy
+	}
+	if c.DebugHTTP {
+		apiCfg.Debug = os.Stderr
+	}
+	apiCfg.Breaker = c.breaker(name, cfg)
+	apiCfg.Budget = c.requestBudget()
+	return providerFactories[name](apiCfg, cfg)
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func TestExtraProviders(t *testing.T) {
+	t.Parallel()
+
+	t.Run("kitsunekko without a jimaku key", func(t *testing.T) {
+		t.Parallel()
+
+		cli := &CLI{cfg: config.Default()}
+		providers := cli.animeProviders(cli.cfg)
+		require.Len(t, providers, 1)
+		assert.Equal(t, api.ProviderKitsunekko, providers[0].Name)
+		assert.Same(t, providers[0].Client, cli.provider(api.ProviderKitsunekko))
+		assert.NotContains(t, cli.extras, api.ProviderJimaku, "jimaku is not searched without a key")
+	})
+
+	t.Run("jimaku with a key", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.Default()
+		cfg.Jimaku.APIKey = "key"
+		cli := &CLI{cfg: cfg}
+		providers := cli.animeProviders(cfg)
+		require.Len(t, providers, 2)
+		assert.Equal(t, api.ProviderJimaku, providers[0].Name)
+		assert.Same(t, providers[0].Client, cli.animeProviders(cfg)[0].Client, "clients are reused across files")
+	})
+
+	t.Run("gated by the anime setting", func(t *testing.T) {
+		t.Parallel()
+
+		client := api.NewOpenSubtitlesClient(&api.Config{APIKey: "key"})
+
+		cli := &CLI{cfg: config.Default()}
+		cli.searcher(client, cli.cfg)
+		assert.Empty(t, cli.extras)
+
+		cfg := config.Default()
+		cfg.Anime = true
+		cli.searcher(client, cfg)
+		assert.Contains(t, cli.extras, api.ProviderKitsunekko)
+
+		flagged := &CLI{cfg: config.Default(), Anime: true}
+		flagged.searcher(client, flagged.cfg)
+		assert.Contains(t, flagged.extras, api.ProviderKitsunekko)
+	})
+
+	t.Run("optional providers from the config", func(t *testing.T) {
+		t.Parallel()
+
+		client := api.NewOpenSubtitlesClient(&api.Config{APIKey: "key"})
+
+		cli := &CLI{cfg: config.Default()}
+		cli.searcher(client, cli.cfg)
+		assert.NotContains(t, cli.extras, api.ProviderNapiprojekt, "optional providers are not searched unless enabled")
+
+		cfg := config.Default()
+		cfg.Providers = []string{api.ProviderNapiprojekt, api.ProviderNapisy24, api.ProviderBSPlayer}
+		cli = &CLI{cfg: cfg}
+		cli.searcher(client, cfg)
+		assert.IsType(t, &api.NapiprojektClient{}, cli.provider(api.ProviderNapiprojekt))
+		assert.IsType(t, &api.Napisy24Client{}, cli.provider(api.ProviderNapisy24))
+		assert.IsType(t, &api.BSPlayerClient{}, cli.provider(api.ProviderBSPlayer))
+	})
+
+	t.Run("media path only outside search mode", func(t *testing.T) {
+		t.Parallel()
+
+		cli := &CLI{cfg: config.Default()}
+		params := &models.SearchParams{}
+		cli.hashMedia(params, "/media/Psy.1992.avi")
+		assert.Equal(t, "/media/Psy.1992.avi", params.MediaPath)
+		assert.Empty(t, params.MovieHash, "missing files cannot be hashed")
+
+		cli.Search = "Psy"
+		params = &models.SearchParams{}
+		cli.hashMedia(params, "/media/Psy.1992.avi")
+		assert.Empty(t, params.MediaPath)
+	})
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/filter"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+var (
+	releaseTag      = regexp.MustCompile(`(?i)^(\d{3,4}p|4k|uhd|hdr|hdtv|pdtv|web|web-?dl|web-?rip|blu-?ray|bdrip|brrip|dvdrip|hdrip|remux|x26[45]|h\.?26[45]|hevc|xvid|proper|repack|extended|unrated)$`)
+	leadingArticle  = regexp.MustCompile(`(?i)^(the|a|an)\s+`)
+	trailingArticle = regexp.MustCompile(`(?i),\s*(the|a|an)$`)
+)
+
+type relaxStep struct {
+	name  string
+	apply func(params *models.SearchParams) bool
+}
+
+var relaxSteps = []relaxStep{
+	{"the year", func(params *models.SearchParams) bool {
+		if params.Year == 0 {
+			return false
+		}
+		params.Year = 0
+		return true
+	}},
+	{"release tags", func(params *models.SearchParams) bool {
+		var kept []string
+		for _, word := range strings.Fields(params.Query) {
+			if !releaseTag.MatchString(word) {
+				kept = append(kept, word)
+			}
+		}
+		return setQuery(params, strings.Join(kept, " "))
+	}},
+	{"articles", func(params *models.SearchParams) bool {
+		query := leadingArticle.ReplaceAllString(params.Query, "")
+		return setQuery(params, trailingArticle.ReplaceAllString(query, ""))
+	}},
+	{"the episode type", func(params *models.SearchParams) bool {
+		if params.Type != "episode" {
+			return false
+		}
+		params.Type = ""
+		return true
+	}},
+}
+
+func setQuery(params *models.SearchParams, query string) bool {
+	query = strings.TrimSpace(query)
+	if query == "" || query == params.Query {
+		return false
+	}
+	params.Query = query
+	return true
+}
+
+type yearSearcher struct {
+	api.Client
+	year int
+}
+
+func (s *yearSearcher) Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	subtitles, err := s.Client.Search(ctx, params)
+	return filter.Year(subtitles, s.year), err
+}
+
+func (c *CLI) relaxQuery(ctx context.Context, client api.Client, params *models.SearchParams, languages []string, outcome *searchOutcome) *searchOutcome {
+	ui := c.ui()
+	relaxed := *params
+	for _, step := range relaxSteps {
+		if !step.apply(&relaxed) {
+			continue
+		}
+
+		ui.Printf("  %s No results, retrying without %s: '%s'\n", ui.Icon(output.IconTip), step.name, relaxed.Query)
+		retryClient := client
+		if params.Year != 0 && relaxed.Year == 0 {
+			retryClient = &yearSearcher{Client: client, year: params.Year}
+		}
+		attempt := relaxed
+		retried := c.searchLanguages(ctx, retryClient, &attempt, languages)
+		if len(retried.all) > 0 {
+			*params = attempt
+			return retried
+		}
+		if len(retried.errs) > 0 {
+			return retried
+		}
+	}
+	return outcome
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func TestRelaxQuery(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeSubtitleClient{results: map[string][]*models.Subtitle{
+		"Office/en": {{ID: "1", Language: "en", ReleaseName: "Office.S01E01"}},
+	}}
+
+	var buf bytes.Buffer
+	cli := &CLI{out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+
+	params := &models.SearchParams{Query: "The Office 720p WEB-DL", Year: 2005, Type: "episode", Season: 1, Episode: 1}
+	outcome := cli.searchWithRetry(context.Background(), client, params, []string{"en"})
+	require.Len(t, outcome.all, 1)
+	assert.Equal(t, &models.SearchParams{Query: "Office", Language: "en", Type: "episode", Season: 1, Episode: 1}, params, "the query that found results is kept")
+
+	require.Len(t, client.searches, 4)
+	assert.Equal(t, 2005, client.searches[0].Year)
+	assert.Equal(t, "The Office 720p WEB-DL", client.searches[1].Query)
+	assert.Zero(t, client.searches[1].Year)
+	assert.Equal(t, "The Office", client.searches[2].Query)
+	assert.Equal(t, "Office", client.searches[3].Query)
+
+	log := buf.String()
+	assert.Contains(t, log, "No results, retrying without the year: 'The Office 720p WEB-DL'")
+	assert.Contains(t, log, "No results, retrying without release tags: 'The Office'")
+	assert.Contains(t, log, "No results, retrying without articles: 'Office'")
+
+	client.searches = nil
+	params = &models.SearchParams{Query: "Matrix, The", Type: "episode", Season: 1, Episode: 1}
+	outcome = cli.searchWithRetry(context.Background(), client, params, []string{"en"})
+	assert.Empty(t, outcome.all)
+	assert.Equal(t, "Matrix, The", params.Query, "the original query is kept when nothing is found")
+	require.Len(t, client.searches, 3)
+	assert.Equal(t, "Matrix", client.searches[1].Query)
+	assert.Empty(t, client.searches[2].Type)
+	assert.Contains(t, buf.String(), "No results, retrying without the episode type: 'Matrix'")
+}
+
+type exactYearClient struct {
+	fakeSubtitleClient
+}
+
+func (e *exactYearClient) Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	if params.Year != 0 {
+		return nil, nil
+	}
+	return e.fakeSubtitleClient.Search(ctx, params)
+}
+
+func TestRelaxQuery_YearTolerance(t *testing.T) {
+	t.Parallel()
+
+	client := &exactYearClient{fakeSubtitleClient{results: map[string][]*models.Subtitle{
+		"Dune/en": {
+			{ID: "1984", Language: "en", FeatureYear: 1984},
+			{ID: "2021", Language: "en", FeatureYear: 2021},
+			{ID: "unknown", Language: "en"},
+		},
+	}}}
+
+	var buf bytes.Buffer
+	cli := &CLI{out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+
+	outcome := cli.searchWithRetry(context.Background(), client, &models.SearchParams{Query: "Dune", Year: 2022, Type: "movie"}, []string{"en"})
+	var ids []string
+	for _, subtitle := range outcome.all {
+		ids = append(ids, subtitle.ID)
+	}
+	assert.Equal(t, []string{"2021", "unknown"}, ids, "a file name year off by one still finds the film, but not its remake")
+}
+
+type failingSubtitleClient struct {
+	fakeSubtitleClient
+	err error
+}
+
+func (f *failingSubtitleClient) Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	f.fakeSubtitleClient.Search(ctx, params)
+	return nil, f.err
+}
+
+func TestRelaxQuery_SkippedAfterErrors(t *testing.T) {
+	t.Parallel()
+
+	client := &failingSubtitleClient{err: errors.New("username and password are required for authentication")}
+
+	var buf bytes.Buffer
+	cli := &CLI{out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+
+	params := &models.SearchParams{Query: "The Office 720p", Year: 2005, Type: "episode", Season: 1, Episode: 1}
+	outcome := cli.searchWithRetry(context.Background(), client, params, []string{"en"})
+	assert.Empty(t, outcome.all)
+	assert.Len(t, outcome.errs, 1)
+	assert.Len(t, client.searches, 1, "a failed search is not retried with a relaxed query")
+	assert.NotContains(t, buf.String(), "retrying without")
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/moviehash"
+	"github.com/carlosarraes/subs-cli/internal/parser"
+)
+
+func isRemote(p string) bool {
+	lower := strings.ToLower(p)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+func remoteName(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL '%s': %w", raw, err)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("invalid URL '%s': missing host", raw)
+	}
+	name := path.Base(u.Path)
+	if name == "/" || name == "." {
+		return "", fmt.Errorf("URL '%s' does not name a video file", raw)
+	}
+	return name, nil
+}
+
+func (c *CLI) validateRemote() (*ValidationResult, error) {
+	name, err := remoteName(c.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ValidationResult{Success: true, Message: fmt.Sprintf("Remote file: %s (subtitles are saved to the current directory)", c.Path)}
+	if ext := strings.ToLower(path.Ext(name)); !mediaExtensions[ext] && ext != "" {
+		result.Warning = fmt.Sprintf("File extension '%s' may not be a supported media format", ext)
+	}
+	return result, nil
+}
+
+func (c *CLI) processRemote(p *parser.Parser) error {
+	name, err := remoteName(c.Path)
+	if err != nil {
+		return err
+	}
+	local, err := filepath.Abs(name)
+	if err != nil {
+		return err
+	}
+
+	if c.remotes == nil {
+		c.remotes = make(map[string]string)
+	}
+	c.remotes[local] = c.Path
+
+	c.ui().Println(c.ui().Bold("\n--- Media File Processing ---"))
+	return c.processFile(p, local)
+}
+
+func (c *CLI) mediaSource(filePath string) string {
+	if remote, ok := c.remotes[filePath]; ok {
+		return remote
+	}
+	return filePath
+}
+
+func (c *CLI) hashRemote(source string) (string, int64, error) {
+	cfg := c.loadedConfig()
+	timeout, _ := cfg.Network.RequestTimeout()
+	client, err := remoteClient(cfg.Proxy, timeout)
+	if err != nil {
+		return "", 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(c.context(), 2*timeout)
+	defer cancel()
+	return moviehash.ComputeURL(ctx, client, source)
+}
+
+// remoteClient reads remote media through the general proxy setting, like
+// the provider clients: empty uses the environment and "direct" none.
+func remoteClient(proxy string, timeout time.Duration) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	switch proxy {
+	case "":
+	case config.ProxyDirect:
+		transport.Proxy = nil
+	default:
+		u, err := url.Parse(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy '%s': %w", proxy, err)
+		}
+		transport.Proxy = http.ProxyURL(u)
+	}
+	return &http.Client{Timeout: timeout, Transport: transport}, nil
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func TestRemoteName(t *testing.T) {
+	t.Parallel()
+
+	name, err := remoteName("https://media.local/dav/Movies/The%20Matrix.1999.1080p.BluRay.mkv?token=abc")
+	require.NoError(t, err)
+	assert.Equal(t, "The Matrix.1999.1080p.BluRay.mkv", name)
+
+	_, err = remoteName("https://media.local/")
+	assert.ErrorContains(t, err, "does not name a video file")
+	_, err = remoteName("http:///movie.mkv")
+	assert.ErrorContains(t, err, "missing host")
+
+	assert.True(t, isRemote("HTTPS://host/movie.mkv"))
+	assert.False(t, isRemote("/movies/http.mkv"))
+}
+
+func TestValidatePath_Remote(t *testing.T) {
+	t.Parallel()
+
+	cli := &CLI{Path: "https://media.local/Movie.2023.mkv"}
+	result, err := cli.validatePath()
+	require.NoError(t, err)
+	assert.Contains(t, result.Message, "subtitles are saved to the current directory")
+	assert.Empty(t, result.Warning)
+	assert.Equal(t, "https://media.local/Movie.2023.mkv", cli.Path, "URLs are not turned into local paths")
+
+	cli = &CLI{Path: "https://media.local/notes.txt"}
+	result, err = cli.validatePath()
+	require.NoError(t, err)
+	assert.Contains(t, result.Warning, "'.txt' may not be a supported media format")
+}
+
+func TestHashMedia_Remote(t *testing.T) {
+	t.Parallel()
+
+	data := make([]byte, 200000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/norange.mkv" {
+			w.Write(data)
+			return
+		}
+		http.ServeContent(w, r, "movie.mkv", time.Time{}, bytes.NewReader(data))
+	}))
+	defer server.Close()
+
+	cfg := config.Default()
+	local, err := filepath.Abs("Movie.2023.mkv")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	cli := &CLI{cfg: cfg, out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+	cli.remotes = map[string]string{local: server.URL + "/Movie.2023.mkv"}
+
+	params := &models.SearchParams{}
+	cli.hashMedia(params, local)
+	assert.Equal(t, local, params.MediaPath)
+	assert.Equal(t, "0000000000030d40", params.MovieHash, "remote files are hashed through range requests")
+	assert.Equal(t, int64(200000), params.MovieByteSize)
+
+	cli.remotes[local] = server.URL + "/norange.mkv"
+	params = &models.SearchParams{}
+	cli.hashMedia(params, local)
+	assert.Empty(t, params.MovieHash)
+	assert.Contains(t, buf.String(), "searching by name only")
+}
+
+func TestHashRemoteProxy(t *testing.T) {
+	t.Parallel()
+
+	var hosts []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hosts = append(hosts, r.URL.Host)
+		http.ServeContent(w, r, "movie.mkv", time.Time{}, bytes.NewReader(make([]byte, 200000)))
+	}))
+	defer proxy.Close()
+
+	cfg := config.Default()
+	cfg.Proxy = proxy.URL
+	hash, size, err := (&CLI{cfg: cfg}).hashRemote("http://media.invalid/Movie.mkv")
+	require.NoError(t, err)
+	assert.Equal(t, "0000000000030d40", hash)
+	assert.Equal(t, int64(200000), size)
+	assert.Equal(t, []string{"media.invalid", "media.invalid"}, hosts, "both range requests go through the proxy")
+}
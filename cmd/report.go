@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/score"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+type resultsReport struct {
+	File    string                      `json:"file,omitempty"`
+	Query   string                      `json:"query"`
+	Results map[string][]scoredSubtitle `json:"results"`
+}
+
+type scoredSubtitle struct {
+	Rank     int              `json:"rank"`
+	Score    score.Breakdown  `json:"score"`
+	Subtitle *models.Subtitle `json:"subtitle"`
+}
+
+const (
+	eventResult   = "result"
+	eventDownload = "download"
+)
+
+type formatRecord struct {
+	Event     string
+	File      string
+	Language  string
+	Rank      int
+	Score     int
+	Provider  string
+	Release   string
+	Uploader  string
+	Downloads int
+	Rating    float64
+	Target    string
+	Subtitle  *models.Subtitle
+}
+
+func newFormatRecord(event, file string, rank, points int, subtitle *models.Subtitle) formatRecord {
+	return formatRecord{
+		Event:     event,
+		File:      file,
+		Language:  subtitle.Language,
+		Rank:      rank,
+		Score:     points,
+		Provider:  subtitle.Provider,
+		Release:   subtitle.ReleaseName,
+		Uploader:  subtitle.Uploader,
+		Downloads: subtitle.Downloads,
+		Rating:    subtitle.Rating,
+		Subtitle:  subtitle,
+	}
+}
+
+func parseFormat(text string) (*template.Template, error) {
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	format, err := template.New("format").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("--format: %w", err)
+	}
+	if err := format.Execute(io.Discard, formatRecord{Subtitle: &models.Subtitle{}}); err != nil {
+		return nil, fmt.Errorf("--format: %w", err)
+	}
+	return format, nil
+}
+
+func (c *CLI) resultsWriter() io.Writer {
+	if c.stdout == nil {
+		return os.Stdout
+	}
+	return c.stdout
+}
+
+func (c *CLI) resultsReport(params *models.SearchParams, outcome *searchOutcome, languages []string) resultsReport {
+	scorer := c.scorer(params.MediaPath)
+	report := resultsReport{
+		File:    params.MediaPath,
+		Query:   params.Query,
+		Results: make(map[string][]scoredSubtitle, len(languages)),
+	}
+	for _, language := range languages {
+		ranked := make([]scoredSubtitle, 0, len(outcome.candidates[language]))
+		for i, subtitle := range outcome.candidates[language] {
+			ranked = append(ranked, scoredSubtitle{Rank: i + 1, Score: scorer.Explain(subtitle), Subtitle: subtitle})
+		}
+		report.Results[language] = ranked
+	}
+	return report
+}
+
+var tableColumns = []string{
+	"file", "language", "rank", "score", "provider", "id", "file_id", "release_name", "file_name",
+	"uploader", "uploader_rank", "rating", "votes", "downloads", "upload_date", "movie_hash", "fps",
+	"duration", "format", "hd", "hearing_impaired", "from_trusted", "foreign_parts_only", "ai_translated",
+	"machine_translated", "moviehash_match", "cds", "feature_title", "feature_type", "feature_year",
+	"imdb_id", "tmdb_id", "url", "comments",
+}
+
+func tableRow(file, language string, rank, points int, s *models.Subtitle) []string {
+	var uploaded string
+	if !s.UploadDate.IsZero() {
+		uploaded = s.UploadDate.Format(time.RFC3339)
+	}
+	return []string{
+		file, language, strconv.Itoa(rank), strconv.Itoa(points), s.Provider, s.ID, s.FileID, s.ReleaseName, s.FileName,
+		s.Uploader, s.UploaderRank, strconv.FormatFloat(s.Rating, 'f', -1, 64), strconv.Itoa(s.Votes), strconv.Itoa(s.Downloads), uploaded, s.MovieHash, strconv.FormatFloat(s.FPS, 'f', -1, 64),
+		strconv.Itoa(s.Duration), s.SubFormat, strconv.FormatBool(s.HD), strconv.FormatBool(s.HearingImpaired), strconv.FormatBool(s.FromTrusted), strconv.FormatBool(s.ForeignPartsOnly), strconv.FormatBool(s.AITranslated),
+		strconv.FormatBool(s.MachineTranslated), strconv.FormatBool(s.MovieHashMatch), strconv.Itoa(len(s.Parts)), s.FeatureTitle, s.FeatureType, strconv.Itoa(s.FeatureYear),
+		strconv.Itoa(s.IMDBID), strconv.Itoa(s.TMDBID), s.URL, s.Comments,
+	}
+}
+
+func (c *CLI) tableOutput() bool {
+	return c.Output == "csv" || c.Output == "tsv"
+}
+
+func (c *CLI) writeResultsTable(params *models.SearchParams, outcome *searchOutcome, languages []string) {
+	if c.table == nil {
+		c.table = csv.NewWriter(c.resultsWriter())
+		if c.Output == "tsv" {
+			c.table.Comma = '\t'
+		}
+		c.table.Write(tableColumns)
+	}
+
+	scorer := c.scorer(params.MediaPath)
+	for _, language := range languages {
+		for i, subtitle := range outcome.candidates[language] {
+			c.table.Write(tableRow(params.MediaPath, language, i+1, scorer.Score(subtitle), subtitle))
+		}
+	}
+	c.table.Flush()
+	if err := c.table.Error(); err != nil {
+		ui := c.ui()
+		ui.Printf("  %s Could not write %s results: %v\n", ui.Warning(ui.Icon(output.IconWarning)), c.Output, err)
+	}
+}
+
+func (c *CLI) reportResults(params *models.SearchParams, outcome *searchOutcome, languages []string) {
+	switch {
+	case c.JSON:
+		c.writeResultsJSON(params, outcome, languages)
+	case c.tableOutput():
+		c.writeResultsTable(params, outcome, languages)
+	case c.format != nil:
+		scorer := c.scorer(params.MediaPath)
+		for _, language := range languages {
+			for i, subtitle := range outcome.candidates[language] {
+				record := newFormatRecord(eventResult, params.MediaPath, i+1, scorer.Score(subtitle), subtitle)
+				record.Language = language
+				c.writeFormat(record)
+			}
+		}
+	}
+}
+
+func (c *CLI) reportDownload(subtitle *models.Subtitle, mediaPath, target string) {
+	if c.format == nil {
+		return
+	}
+	record := newFormatRecord(eventDownload, mediaPath, 0, c.scorer(mediaPath).Score(subtitle), subtitle)
+	record.Target = target
+	c.writeFormat(record)
+}
+
+func (c *CLI) writeFormat(record formatRecord) {
+	if err := c.format.Execute(c.resultsWriter(), record); err != nil {
+		ui := c.ui()
+		ui.Printf("  %s Could not format result: %v\n", ui.Warning(ui.Icon(output.IconWarning)), err)
+	}
+}
+
+func (c *CLI) writeResultsJSON(params *models.SearchParams, outcome *searchOutcome, languages []string) {
+	if err := json.NewEncoder(c.resultsWriter()).Encode(c.resultsReport(params, outcome, languages)); err != nil {
+		ui := c.ui()
+		ui.Printf("  %s Could not write JSON results: %v\n", ui.Warning(ui.Icon(output.IconWarning)), err)
+	}
+}
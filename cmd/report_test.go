@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func TestWriteResultsJSON(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	cli := &CLI{stdout: &stdout, out: output.New(&stderr, output.Options{NoColor: true})}
+	outcome := &searchOutcome{candidates: map[string][]*models.Subtitle{
+		"en": {
+			{ID: "1", FileID: "10", ReleaseName: "Movie.2023.1080p.WEB-DL.x264-NTb", MovieHashMatch: true, Downloads: 99},
+			{ID: "2", FileID: "20", ReleaseName: "Movie.2023.720p.HDTV"},
+		},
+	}}
+	params := &models.SearchParams{Query: "Movie", MediaPath: "/movies/Movie.2023.1080p.WEB-DL.x264-NTb.mkv"}
+
+	cli.writeResultsJSON(params, outcome, []string{"en", "pt-BR"})
+	cli.writeResultsJSON(&models.SearchParams{Query: "Other"}, &searchOutcome{}, []string{"en"})
+	assert.Empty(t, stderr.String())
+
+	lines := bytes.Split(bytes.TrimSpace(stdout.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+
+	var report struct {
+		File    string `json:"file"`
+		Query   string `json:"query"`
+		Results map[string][]struct {
+			Rank  int `json:"rank"`
+			Score struct {
+				Total   int `json:"total"`
+				Factors []struct {
+					Name   string `json:"name"`
+					Points int    `json:"points"`
+					Detail string `json:"detail"`
+				} `json:"factors"`
+				MatchedTokens []string `json:"matched_tokens"`
+			} `json:"score"`
+			Subtitle models.Subtitle `json:"subtitle"`
+		} `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal(lines[0], &report))
+	assert.Equal(t, "/movies/Movie.2023.1080p.WEB-DL.x264-NTb.mkv", report.File)
+	assert.Equal(t, "Movie", report.Query)
+	require.Len(t, report.Results["en"], 2)
+	assert.Empty(t, report.Results["pt-BR"])
+
+	best := report.Results["en"][0]
+	assert.Equal(t, 1, best.Rank)
+	assert.Equal(t, "1", best.Subtitle.ID)
+	assert.Equal(t, 180, best.Score.Total)
+	assert.Equal(t, "hash", best.Score.Factors[0].Name)
+	assert.Equal(t, "release", best.Score.Factors[1].Name)
+	assert.Equal(t, "6/6 tokens", best.Score.Factors[1].Detail)
+	assert.Equal(t, []string{"movie", "2023", "1080p", "web", "dl", "x264"}, best.Score.MatchedTokens)
+	assert.Equal(t, 2, report.Results["en"][1].Rank)
+
+	assert.JSONEq(t, `{"query":"Other","results":{"en":[]}}`, string(lines[1]))
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	_, err := parseFormat("{{.File}} {{.Score}}")
+	require.NoError(t, err)
+
+	_, err = parseFormat("{{.File")
+	assert.ErrorContains(t, err, "--format: template: format:2: unclosed action")
+
+	_, err = parseFormat("{{.Unknown}}")
+	assert.ErrorContains(t, err, "--format: template: format:1:2: executing \"format\" at <.Unknown>: can't evaluate field Unknown")
+}
+
+func TestReportFormat(t *testing.T) {
+	t.Parallel()
+
+	format, err := parseFormat(`{{.Event}} {{.File}} {{.Language}} #{{.Rank}} {{.Provider}} {{.Score}} {{.Release}}{{with .Target}} -> {{.}}{{end}}`)
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	cli := &CLI{format: format, stdout: &stdout, out: output.New(&stderr, output.Options{NoColor: true})}
+	subtitle := &models.Subtitle{ID: "1", FileID: "10", Provider: "opensubtitles", Language: "pt-br", ReleaseName: "Movie.2023.1080p", MovieHashMatch: true}
+	outcome := &searchOutcome{candidates: map[string][]*models.Subtitle{
+		"pt-BR": {subtitle, {ID: "2", FileID: "20", Provider: "bsplayer", ReleaseName: "Movie.2023.CAM"}},
+	}}
+
+	cli.reportResults(&models.SearchParams{MediaPath: "/m/Movie.2023.1080p.mkv"}, outcome, []string{"pt-BR"})
+	cli.reportDownload(subtitle, "/m/Movie.2023.1080p.mkv", "/m/Movie.2023.1080p.pt-BR.srt")
+	assert.Empty(t, stderr.String())
+	assert.Equal(t, "result /m/Movie.2023.1080p.mkv pt-BR #1 opensubtitles 140 Movie.2023.1080p\n"+
+		"result /m/Movie.2023.1080p.mkv pt-BR #2 bsplayer -23 Movie.2023.CAM\n"+
+		"download /m/Movie.2023.1080p.mkv pt-br #0 opensubtitles 140 Movie.2023.1080p -> /m/Movie.2023.1080p.pt-BR.srt\n", stdout.String())
+
+	stdout.Reset()
+	(&CLI{stdout: &stdout}).reportDownload(subtitle, "movie.mkv", "movie.en.srt")
+	assert.Empty(t, stdout.String())
+}
+
+func TestWriteResultsTable(t *testing.T) {
+	t.Parallel()
+
+	outcome := &searchOutcome{candidates: map[string][]*models.Subtitle{
+		"en": {
+			{ID: "1", FileID: "10", Provider: "opensubtitles", ReleaseName: "Movie.2023.1080p", Uploader: "alice, bob", Rating: 8.5, Downloads: 99, UploadDate: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), FPS: 23.976, SubFormat: "srt", FromTrusted: true},
+			{ID: "2", FileID: "20", Provider: "bsplayer", ReleaseName: "Movie.2023.CAM", Parts: []models.SubtitlePart{{FileID: "21"}, {FileID: "22"}}},
+		},
+	}}
+
+	var stdout bytes.Buffer
+	cli := &CLI{Output: "csv", stdout: &stdout}
+	cli.writeResultsTable(&models.SearchParams{MediaPath: "/m/Movie.2023.1080p.mkv"}, outcome, []string{"en", "pt-BR"})
+	cli.writeResultsTable(&models.SearchParams{MediaPath: "/m/Other.mkv"}, &searchOutcome{}, []string{"en"})
+
+	rows, err := csv.NewReader(&stdout).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3, "the header is written once")
+	assert.Equal(t, tableColumns, rows[0])
+
+	row := func(i int) map[string]string {
+		fields := make(map[string]string)
+		for k, column := range tableColumns {
+			fields[column] = rows[i][k]
+		}
+		return fields
+	}
+	first := row(1)
+	assert.Equal(t, "/m/Movie.2023.1080p.mkv", first["file"])
+	assert.Equal(t, "en", first["language"])
+	assert.Equal(t, "1", first["rank"])
+	assert.Equal(t, "alice, bob", first["uploader"])
+	assert.Equal(t, "8.5", first["rating"])
+	assert.Equal(t, "2024-03-01T12:00:00Z", first["upload_date"])
+	assert.Equal(t, "23.976", first["fps"])
+	assert.Equal(t, "true", first["from_trusted"])
+	assert.Equal(t, "2", row(2)["rank"])
+	assert.Equal(t, "2", row(2)["cds"])
+	assert.Empty(t, row(2)["upload_date"])
+
+	stdout.Reset()
+	cli = &CLI{Output: "tsv", stdout: &stdout}
+	cli.writeResultsTable(&models.SearchParams{}, outcome, []string{"en"})
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "file\tlanguage\trank\tscore\tprovider\t"))
+	assert.True(t, strings.HasPrefix(lines[1], "\ten\t1\t"))
+}
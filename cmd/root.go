@@ -1,19 +1,43 @@
 package cmd
 
 import (
+	"bufio"
+	"cmp"
 	"context"
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"path/filepath"
 	"runtime"
-	"strconv"
+	"slices"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/alecthomas/kong"
 	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/feedback"
+	"github.com/carlosarraes/subs-cli/internal/filter"
+	"github.com/carlosarraes/subs-cli/internal/fsutil"
+	"github.com/carlosarraes/subs-cli/internal/language"
+	"github.com/carlosarraes/subs-cli/internal/lock"
+	"github.com/carlosarraes/subs-cli/internal/output"
 	"github.com/carlosarraes/subs-cli/internal/parser"
+	"github.com/carlosarraes/subs-cli/internal/plan"
+	"github.com/carlosarraes/subs-cli/internal/probe"
+	"github.com/carlosarraes/subs-cli/internal/progress"
+	"github.com/carlosarraes/subs-cli/internal/rar"
+	"github.com/carlosarraes/subs-cli/internal/scan"
+	"github.com/carlosarraes/subs-cli/internal/score"
+	"github.com/carlosarraes/subs-cli/internal/subformat"
+	"github.com/carlosarraes/subs-cli/internal/translate"
 	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/carlosarraes/subs-cli/pkg/plugin"
+	"github.com/carlosarraes/subs-cli/pkg/subs"
 )
 
 var (
@@ -24,13 +48,101 @@ var (
 )
 
 type CLI struct {
-	Path        string   `arg:"" default:"." help:"Path to media file or directory to search for subtitles. Supports files (.mp4, .mkv, etc.) and directories."`
-	Language    []string `short:"l" long:"language" default:"en" help:"Subtitle language codes (ISO 639-1/locale format). Examples: en, pt-BR, es, fr. Supports multiple comma-separated values."`
-	Interactive bool     `short:"i" long:"interactive" help:"Enable interactive fuzzy finder mode for subtitle selection. Allows browsing and previewing multiple subtitle options."`
-	Config      string   `short:"c" long:"config" type:"existingfile" help:"Path to custom YAML configuration file. Default location: ~/.subs-cli/config.yaml"`
-	DryRun      bool     `long:"dry-run" help:"Preview mode: displays what subtitles would be downloaded without actually downloading them. Useful for testing."`
-	Search      string   `short:"s" long:"search" help:"Manual search query mode. Use instead of filename parsing (e.g., 'Breaking Bad S01E01'). Overrides path-based search."`
-	Version     bool     `short:"v" long:"version" help:"Display detailed version information including build details, Git commit, and platform info."`
+	Path           string            `arg:"" default:"." help:"Path to media file or directory to search for subtitles. Supports files (.mp4, .mkv, etc.) and directories."`
+	Language       []string          `short:"l" long:"language" completion:"languages" help:"Subtitle language codes (ISO 639-1/locale format). Examples: en, pt-BR, es, fr. Supports multiple comma-separated values. Defaults to the config file languages, then the system locale (LC_ALL, LC_MESSAGES, LANG), then en."`
+	Interactive    bool              `short:"i" long:"interactive" help:"Enable interactive fuzzy finder mode for subtitle selection. Allows browsing and previewing multiple subtitle options."`
+	Config         string            `short:"c" long:"config" type:"existingfile" help:"Path to custom YAML configuration file. Default location: ~/.subs-cli/config.yaml"`
+	DryRun         bool              `long:"dry-run" help:"Preview mode: displays what subtitles would be downloaded and the exact path each would be written to, without actually downloading them. Useful for testing."`
+	Plan           string            `long:"plan" placeholder:"FILE" help:"With --dry-run, write every planned download and its target path as JSON to FILE. Run it later with 'subs apply FILE'."`
+	FilesFrom      string            `long:"files-from" placeholder:"FILE" help:"Read newline-separated media file paths from FILE, or from standard input with '-' (e.g. piped from find or fd). The path argument is ignored."`
+	Recursive      bool              `short:"r" long:"recursive" help:"Also search subdirectories for media files. Directories listed in .subsignore or --exclude-dir are skipped."`
+	FollowSymlinks bool              `long:"follow-symlinks" help:"Descend into symlinked directories when scanning recursively. Each physical file and directory is visited once, so symlink loops and duplicate links are skipped."`
+	NetworkShare   bool              `long:"network-share" help:"Treat the target as a slow network share even if it isn't detected as SMB/NFS: retry failed writes and directory reads, skip changing file owners and pace file lookups while scanning. See the 'shares' config section."`
+	Include        []string          `long:"include" placeholder:"GLOB" help:"Only process media files whose name matches one of these patterns, e.g. '*.mkv'. Patterns containing '/' match the path relative to the scanned directory."`
+	Exclude        []string          `long:"exclude" placeholder:"GLOB" help:"Skip media files whose name matches one of these patterns, e.g. '*sample*'."`
+	ExcludeDir     []string          `long:"exclude-dir" placeholder:"GLOB" help:"Skip directories whose name matches one of these patterns when scanning recursively, e.g. 'Extras'."`
+	MinSize        scan.Size         `long:"min-size" placeholder:"SIZE" help:"Skip media files smaller than this when scanning directories, e.g. 200MB or 1GiB. Useful to ignore samples and stubs."`
+	NewerThan      scan.Age          `long:"newer-than" placeholder:"AGE" help:"Only process media files modified within this period when scanning directories, e.g. 12h, 7d or 2w."`
+	Search         string            `short:"s" long:"search" help:"Manual search query mode. Use instead of filename parsing (e.g., 'Breaking Bad S01E01' or a whole season, 'Breaking Bad S01'). Overrides path-based search and lists the matches."`
+	OutputDir      string            `long:"output-dir" type:"path" placeholder:"DIR" help:"With --search, download every match into DIR, named after its release, e.g. Breaking.Bad.S01E01.720p.HDTV.x264-CTU.en.srt. No media files are needed."`
+	MinRating      float64           `long:"min-rating" help:"Only consider subtitles rated at least this value (0-10)."`
+	MinDownloads   int               `long:"min-downloads" help:"Only consider subtitles downloaded at least this many times."`
+	Uploader       string            `long:"uploader" help:"Only consider subtitles from this uploader (case-insensitive)."`
+	TrustedOnly    bool              `long:"trusted-only" help:"Only consider subtitles from trusted uploaders."`
+	OrderBy        string            `long:"order-by" placeholder:"downloads|rating|date" help:"Order results by downloads, rating or date (newest first). The first result is downloaded."`
+	MaxRequests    int               `long:"max-requests" placeholder:"N" help:"Stop after N requests to the subtitle providers, e.g. for cron jobs under a strict OpenSubtitles limit. Files not reached are listed for the next run. 0 means no limit."`
+	MaxPerLanguage int               `long:"max-per-language" default:"1" help:"Download up to this many of the best-ranked subtitles per language. Extra files get an index suffix, e.g. movie.en.2.srt."`
+	ExplainScore   bool              `long:"explain-score" help:"Show how each candidate was scored (hash, release and group match, trust, downloads, rating, frame rate and preferences) after the results list. Weights are set under scoring.weights in the config file."`
+	JSON           bool              `long:"json" help:"Write the ranked results for each file to stdout as one JSON object per line, including how every candidate was scored. All other messages go to stderr."`
+	Format         string            `long:"format" placeholder:"TEMPLATE" help:"Print one line per search result and per saved subtitle using a Go template, e.g. '{{.File}} {{.Language}} {{.Provider}} {{.Score}}'. Fields: Event (result or download), File, Language, Rank, Score, Provider, Release, Uploader, Downloads, Rating, Target and Subtitle. All other messages go to stderr."`
+	Output         string            `long:"output" enum:"text,csv,tsv" default:"text" help:"Also export every search result to stdout as csv or tsv, one row per subtitle with all of its metadata, for spreadsheets. All other messages go to stderr."`
+	Stdout         bool              `long:"stdout" help:"Write the downloaded subtitle to standard output instead of a file and print nothing else, for piping into a player: subs movie.mkv -l en --stdout | mpv --sub-file=- movie.mkv. Needs a single media file and one language."`
+	MinScore       int               `long:"min-score" placeholder:"SCORE" help:"Don't download subtitles automatically when none scores at least this much (see --explain-score). The file is added to the wanted list instead. Overrides scoring.min_score in the config file; 0 disables the check."`
+	MergeCDs       bool              `long:"merge-cds" help:"Merge multi-CD subtitles into a single SRT file instead of saving one file per CD (movie.en.cd1.srt, ...)."`
+	CDDurations    []time.Duration   `long:"cd-durations" sep:"," placeholder:"1h2m,58m" help:"Video length of each CD, used to offset merged parts. Defaults to the end of each part's last subtitle."`
+	Bilingual      string            `long:"bilingual" placeholder:"PRIMARY+SECONDARY" help:"Download two languages and combine them into one file with the secondary language shown below in another color, e.g. pt-BR+en. Replaces --language; the file is saved as movie.pt-BR+en.srt."`
+	BilingualAs    string            `long:"bilingual-format" enum:"srt,ass" default:"srt" help:"Format of the combined bilingual file: srt or ass (styled, secondary language in a smaller italic font)."`
+	Translate      map[string]string `long:"translate" mapsep:"," placeholder:"from=en,to=pt-BR" help:"When no subtitle exists in a requested language, download one in the 'from' language (default en) and machine-translate it with the backend set in the config file. 'to' limits this to one language. Saved as movie.pt-BR.translated.srt."`
+	Confirm        bool              `long:"confirm" help:"Show the best match for each file and ask before downloading: y (yes), n (not this one), s (skip the file), a (accept all remaining)."`
+	Yes            bool              `short:"y" long:"yes" help:"Accept every confirmation automatically. Useful in scripts."`
+	Probe          bool              `long:"probe" help:"Run ffprobe on each video to read its length and frame rate. Subtitles made for another frame rate are ranked last, and downloads that don't fit the video length trigger a warning."`
+	Proxy          string            `long:"proxy" placeholder:"URL" help:"Proxy for provider requests, e.g. http://host:3128 or socks5://host:1080. Overrides the 'proxy' config setting; HTTP_PROXY/HTTPS_PROXY are used otherwise."`
+	Archive        string            `long:"archive" type:"path" placeholder:"DIR" help:"Search this directory tree (or network share) of collected subtitles before any online provider. Overrides archive.path in the config file."`
+	Anime          bool              `long:"anime" help:"Also search anime subtitle providers (Kitsunekko, and Jimaku when jimaku.api_key is set), matching by romaji or English title and absolute episode number. Same as 'anime: true' in the config or a .subsrc file."`
+	Timeout        time.Duration     `long:"timeout" placeholder:"30s" help:"Timeout for each provider request. Overrides network.timeout in the config file."`
+	FileTimeout    time.Duration     `long:"file-timeout" placeholder:"2m" help:"Time allowed for all searches and downloads for one media file, across every provider and language. Overrides network.file_timeout in the config file."`
+	DebugHTTP      bool              `long:"debug-http" help:"Log provider HTTP requests and responses to stderr. Passwords, tokens and API keys are redacted."`
+	VerifyChecksum bool              `long:"verify-checksum" help:"Check files whose name carries a CRC32 like [1A2B3C4D] against it before searching, and skip damaged or incomplete ones. Reads each such file in full."`
+	Guessit        bool              `long:"guessit" help:"When a file name can't be parsed, ask OpenSubtitles' guessit service for the title, season and episode. Same as metadata.guessit in the config file."`
+	NoSeasonPack   bool              `long:"no-season-pack" help:"Search each episode on its own even when the directory is a season folder (Show.S01, Show/Season 1). By default the whole season's OpenSubtitles results are fetched once per language and matched to the episodes."`
+	Backup         bool              `long:"backup" help:"Keep a .bak copy of any subtitle file that gets replaced."`
+	KeepFormat     bool              `long:"keep-format" help:"Save subtitles exactly in the format they were downloaded in, e.g. styled ASS/SSA for anime or frame-based MicroDVD, instead of converting them to SRT. The file extension follows the format."`
+	StripTags      bool              `long:"strip-tags" help:"Remove styling and positioning from saved subtitles: <i>, <b> and <font> tags, ASS override codes such as {\\an8} and {\\pos(x,y)}, and WebVTT cue settings. For players that show them as text."`
+	Reflow         bool              `long:"reflow" help:"Rewrap SubRip subtitles so lines fit output.reflow.max_line_length (default 42): long lines are split, unbalanced two-line cues are rebalanced and text needing more than two lines becomes several cues. Cues read faster than output.reflow.max_cps (default 21) are shown longer when the next cue leaves room."`
+	Overwrite      string            `long:"overwrite" placeholder:"always|never|if-better" help:"What to do when the subtitle file already exists: always replace it (default), never replace it, or replace it only if the new subtitle scores higher than the one subs saved there (if-better)."`
+	WaitLock       time.Duration     `long:"wait-lock" placeholder:"10m" help:"When another subs-cli run is downloading, wait up to this long for it to finish instead of exiting."`
+	NoEmoji        bool              `long:"no-emoji" help:"Replace emoji status symbols with plain ASCII markers. Useful for log files and terminals without emoji support. Colors follow NO_COLOR and are disabled when output is not a terminal."`
+	Quiet          bool              `short:"q" long:"quiet" help:"Suppress validation details, configuration summary and progress bars. Progress bars are also hidden when output is not a terminal."`
+	Version        bool              `short:"v" long:"version" help:"Display detailed version information including build details, Git commit, and platform info."`
+
+	out           *output.Renderer        `kong:"-"`
+	cfg           *config.Config          `kong:"-"`
+	resolver      *config.Resolver        `kong:"-"`
+	explicitLang  bool                    `kong:"-"`
+	langSource    string                  `kong:"-"`
+	getenv        func(string) string     `kong:"-"`
+	prompter      *prompter               `kong:"-"`
+	acceptAll     bool                    `kong:"-"`
+	breakers      map[string]*api.Breaker `kong:"-"`
+	planned       *plan.Plan              `kong:"-"`
+	video         *probe.Info             `kong:"-"`
+	feedbackPath  string                  `kong:"-"`
+	feedback      *feedback.Store         `kong:"-"`
+	download      *progress.Bar           `kong:"-"`
+	quotaExceeded bool                    `kong:"-"`
+	plugins       []*plugin.Client        `kong:"-"`
+	archive       *api.LocalClient        `kong:"-"`
+	xmlrpc        *api.XMLRPCClient       `kong:"-"`
+	tmdb          *api.TMDBClient         `kong:"-"`
+	extras        map[string]api.Client   `kong:"-"`
+	stdout        io.Writer               `kong:"-"`
+	format        *template.Template      `kong:"-"`
+	table         *csv.Writer             `kong:"-"`
+	share         bool                    `kong:"-"`
+	piped         bool                    `kong:"-"`
+	locked        bool                    `kong:"-"`
+	remotes       map[string]string       `kong:"-"`
+	rars          map[string]*rar.File    `kong:"-"`
+	previewed     map[string]bool         `kong:"-"`
+	duplicates    map[string]string       `kong:"-"`
+	season        *seasonPack             `kong:"-"`
+	budget        *api.Budget             `kong:"-"`
+	ctx           context.Context         `kong:"-"`
+	guesser       filenameGuesser         `kong:"-"`
+	seasonHistory map[string]int          `kong:"-"`
+	showSeasons   map[string]int          `kong:"-"`
+	saved         map[string][]string     `kong:"-"`
+	summary       runSummary              `kong:"-"`
 }
 
 func (c *CLI) Run() error {
@@ -39,21 +151,266 @@ func (c *CLI) Run() error {
 		return nil
 	}
 
+	if err := c.loadConfig(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
 	if err := c.validateArguments(); err != nil {
 		return fmt.Errorf("validation error: %w", err)
 	}
+	c.detectShare()
+
+	if !c.Quiet {
+		c.displayConfiguration()
+	}
+	c.notifyUpdate()
+
+	if c.Plan != "" {
+		c.planned = plan.New()
+	}
+
+	if !c.DryRun && !c.locked {
+		l, err := c.acquireLock()
+		if err != nil {
+			return err
+		}
+		defer l.Release()
+	}
 
-	c.displayConfiguration()
+	c.loadFeedback()
+	c.loadPlugins()
+	defer c.closeProviders()
+	c.loadArchive()
 
-	parser := parser.New()
+	parser := c.newParser(c.loadedConfig())
 
 	if err := c.processMediaFiles(parser); err != nil {
 		return fmt.Errorf("failed to process media files: %w", err)
 	}
+	c.printSummary()
+	if c.interrupted() {
+		return fmt.Errorf("interrupted before all media files were processed")
+	}
+	if c.Stdout && !c.piped {
+		return fmt.Errorf("no %s subtitle was found for %s", c.Language[0], c.Path)
+	}
+
+	if c.planned != nil {
+		return c.savePlan()
+	}
+
+	return nil
+}
+
+func (c *CLI) acquireLock() (*lock.Lock, error) {
+	path, err := config.LockPath()
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := lock.Acquire(c.context(), path, 0)
+	if errors.Is(err, lock.ErrLocked) && c.WaitLock > 0 {
+		if !c.Quiet {
+			c.ui().Printf("Waiting up to %s for other subs-cli runs to finish...\n", c.WaitLock)
+		}
+		l, err = lock.Acquire(c.context(), path, c.WaitLock)
+	}
+	if errors.Is(err, lock.ErrLocked) {
+		return nil, fmt.Errorf("%w; wait for it to finish or retry with --wait-lock 10m", err)
+	}
+	return l, err
+}
+
+func (c *CLI) savePlan() error {
+	if err := c.planned.Save(c.Plan); err != nil {
+		return err
+	}
+
+	ui := c.ui()
+	ui.Printf("\n%s Plan with %d download(s) written to %s\n", ui.Icon(output.IconSaved), len(c.planned.Downloads), c.Plan)
+	ui.Printf("%s Review it, then run: subs apply %s\n", ui.Icon(output.IconTip), c.Plan)
+	return nil
+}
+
+func (c *CLI) loadConfig() error {
+	var cfg *config.Config
+	var err error
+
+	if c.Config != "" {
+		cfg, err = config.Load(c.Config)
+	} else {
+		cfg, err = config.LoadDefault()
+	}
+	if err != nil {
+		if path := c.configPath(); path != "" {
+			return configLoadError(path, err)
+		}
+		return err
+	}
+
+	if err := overrideProxy(cfg, c.Proxy); err != nil {
+		return err
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("--timeout must be a positive duration, got %s", c.Timeout)
+	}
+	if c.Timeout > 0 {
+		cfg.Network.Timeout = c.Timeout.String()
+	}
+	if c.FileTimeout < 0 {
+		return fmt.Errorf("--file-timeout must be a positive duration, got %s", c.FileTimeout)
+	}
+	if c.FileTimeout > 0 {
+		cfg.Network.FileTimeout = c.FileTimeout.String()
+	}
+	switch c.Overwrite {
+	case "", config.OverwriteAlways, config.OverwriteNever, config.OverwriteIfBetter:
+	default:
+		return fmt.Errorf("--overwrite must be '%s', '%s' or '%s', got '%s'", config.OverwriteAlways, config.OverwriteNever, config.OverwriteIfBetter, c.Overwrite)
+	}
+
+	c.applyConfig(cfg)
+	if !c.Quiet {
+		if path := c.configPath(); path != "" {
+			c.warnConfigProblems(path)
+		}
+	}
+	return nil
+}
+
+func (c *CLI) configPath() string {
+	if c.Config != "" {
+		return c.Config
+	}
+	path, err := config.DefaultPath()
+	if err != nil {
+		return ""
+	}
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+func providerTimeout(cfg *config.Config) time.Duration {
+	timeout, err := cfg.Network.ProviderTimeoutDuration()
+	if err != nil {
+		return config.DefaultProviderTimeout
+	}
+	return timeout
+}
+
+func (c *CLI) fileContext(cfg *config.Config) (context.Context, context.CancelFunc) {
+	if c.Interactive {
+		return context.WithCancel(c.context())
+	}
+	return context.WithTimeout(c.context(), fileTimeout(cfg))
+}
+
+func fileTimeout(cfg *config.Config) time.Duration {
+	timeout, err := cfg.Network.FileTimeoutDuration()
+	if err != nil {
+		return config.DefaultFileTimeout
+	}
+	return timeout
+}
 
+func overrideProxy(cfg *config.Config, proxy string) error {
+	if proxy == "" {
+		return nil
+	}
+	if err := config.ValidateProxy(proxy); err != nil {
+		return fmt.Errorf("--proxy: %w", err)
+	}
+	cfg.Proxy, cfg.OpenSubtitles.Proxy = proxy, ""
 	return nil
 }
 
+func (c *CLI) applyConfig(cfg *config.Config) {
+	c.cfg = cfg
+	c.resolver = config.NewResolver(cfg)
+	if languages := bilingualLanguages(c.Bilingual); languages != nil {
+		c.Language = languages
+	}
+	c.explicitLang = len(c.Language) > 0
+
+	if len(c.Language) == 0 {
+		c.Language = append([]string{}, cfg.Defaults.Languages...)
+	}
+	if len(c.Language) == 0 {
+		c.Language, c.langSource = c.localeLanguage()
+	}
+
+	c.Interactive = c.Interactive || cfg.Defaults.Interactive
+	c.Confirm = c.Confirm || cfg.Defaults.Confirm
+	c.Yes = c.Yes || cfg.Defaults.AutoSelect
+	c.NoEmoji = c.NoEmoji || cfg.Output.NoEmoji
+	c.Quiet = c.Quiet || cfg.Output.Quiet || c.Stdout
+	c.Backup = c.Backup || cfg.Output.Backup
+	c.KeepFormat = c.KeepFormat || cfg.Output.KeepFormat
+	c.Reflow = c.Reflow || cfg.Output.Reflow.Enabled
+	c.StripTags = c.StripTags || cfg.Output.StripTags
+	c.Overwrite = cmp.Or(c.Overwrite, cfg.Output.Overwrite, config.OverwriteAlways)
+	c.Probe = c.Probe || cfg.Probe.Enabled
+	c.Guessit = c.Guessit || cfg.Metadata.Guessit
+}
+
+type fileSettings struct {
+	config    *config.Config
+	languages []string
+	sources   []string
+}
+
+func (c *CLI) settingsFor(filePath string) (*fileSettings, error) {
+	if c.resolver == nil {
+		c.resolver = config.NewResolver(c.loadedConfig())
+	}
+
+	resolved, err := c.resolver.ForDir(filepath.Dir(filePath))
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &fileSettings{
+		config:    resolved.Config,
+		languages: c.Language,
+		sources:   resolved.Sources,
+	}
+
+	if !c.explicitLang && len(resolved.Sources) > 0 && len(resolved.Config.Defaults.Languages) > 0 {
+		languages, err := parseLanguageList(strings.Join(resolved.Config.Defaults.Languages, ","))
+		if err != nil {
+			return nil, fmt.Errorf("invalid languages in %s: %w", resolved.Sources[len(resolved.Sources)-1], err)
+		}
+		settings.languages = languages
+	}
+
+	return settings, nil
+}
+
+func (c *CLI) localeLanguage() ([]string, string) {
+	getenv := c.getenv
+	if getenv == nil {
+		getenv = os.Getenv
+	}
+
+	code, variable, ok := language.FromEnvironment(getenv)
+	if ok {
+		return []string{code}, fmt.Sprintf("from system locale %s=%s", variable, getenv(variable))
+	}
+	if variable != "" {
+		return []string{"en"}, fmt.Sprintf("default; system locale %s=%s has no matching language", variable, getenv(variable))
+	}
+	return []string{"en"}, "default; no -l flag, config languages or system locale"
+}
+
+func (c *CLI) loadedConfig() *config.Config {
+	if c.cfg == nil {
+		c.cfg = config.Default()
+	}
+	return c.cfg
+}
+
 func (c *CLI) printVersionInfo() {
 	fmt.Printf("subs-cli version %s\n", Version)
 	if BuildTime != "unknown" {
@@ -73,7 +430,7 @@ func (c *CLI) printVersionInfo() {
 func (c *CLI) validateArguments() error {
 	var results []*ValidationResult
 
-	if c.Search == "" {
+	if c.Search == "" && c.FilesFrom == "" {
 		result, err := c.validatePath()
 		if err != nil {
 			return err
@@ -95,31 +452,55 @@ func (c *CLI) validateArguments() error {
 		results = append(results, configResult)
 	}
 
+	filterResult, err := c.validateFilters()
+	if err != nil {
+		return err
+	}
+	if filterResult != nil {
+		results = append(results, filterResult)
+	}
+
 	modeResult, err := c.validateModeConsistency()
 	if err != nil {
 		return err
 	}
 	results = append(results, modeResult)
 
-	c.printValidationResults(results)
+	if !c.Quiet {
+		c.printValidationResults(results)
+	}
 
 	return nil
 }
 
 func (c *CLI) printValidationResults(results []*ValidationResult) {
+	ui := c.ui()
 	for _, result := range results {
 		if result.Success && result.Message != "" {
-			fmt.Printf("✓ %s\n", result.Message)
+			ui.Printf("%s %s\n", ui.Success(ui.Icon(output.IconCheck)), result.Message)
 		}
 		if result.Warning != "" {
-			fmt.Printf("⚠ Warning: %s\n", result.Warning)
+			ui.Printf("%s %s\n", ui.Warning(ui.Icon(output.IconWarning)+" Warning:"), result.Warning)
 		}
 		if result.Message != "" && !result.Success {
-			fmt.Printf("ℹ %s\n", result.Message)
+			ui.Printf("%s %s\n", ui.Info(ui.Icon(output.IconInfo)), result.Message)
 		}
 	}
 }
 
+func (c *CLI) ui() *output.Renderer {
+	if c.out == nil && c.Stdout {
+		c.out = output.New(io.Discard, output.Options{NoColor: true, NoEmoji: c.NoEmoji})
+	}
+	if c.out == nil && (c.JSON || c.Format != "" || c.tableOutput()) {
+		c.out = output.NewStderr(c.NoEmoji)
+	}
+	if c.out == nil {
+		c.out = output.NewStdout(c.NoEmoji)
+	}
+	return c.out
+}
+
 type ValidationResult struct {
 	Success bool
 	Message string
@@ -141,12 +522,19 @@ var mediaExtensions = map[string]bool{
 }
 
 func (c *CLI) validatePath() (*ValidationResult, error) {
+	if isRemote(c.Path) {
+		return c.validateRemote()
+	}
+
 	cleanPath := filepath.Clean(c.Path)
 
 	absPath, err := filepath.Abs(cleanPath)
 	if err != nil {
 		return nil, fmt.Errorf("invalid path '%s': %w", c.Path, err)
 	}
+	if err := fsutil.CheckName(absPath); err != nil {
+		return nil, fmt.Errorf("invalid path '%s': %w", c.Path, err)
+	}
 
 	info, err := os.Stat(absPath)
 	if err != nil {
@@ -162,11 +550,13 @@ func (c *CLI) validatePath() (*ValidationResult, error) {
 
 	if info.IsDir() {
 		result.Message = fmt.Sprintf("Directory path validated: %s", c.Path)
+	} else if isPlaylist(c.Path) {
+		result.Message = fmt.Sprintf("Playlist validated: %s", c.Path)
 	} else {
 		result.Message = fmt.Sprintf("File path validated: %s", c.Path)
 
 		ext := strings.ToLower(filepath.Ext(c.Path))
-		if !mediaExtensions[ext] && ext != "" {
+		if !mediaExtensions[ext] && !rar.IsArchive(c.Path) && ext != "" {
 			result.Warning = fmt.Sprintf("File extension '%s' may not be a supported media format", ext)
 		}
 	}
@@ -195,7 +585,14 @@ func (c *CLI) validateLanguages() (*ValidationResult, error) {
 			return nil, fmt.Errorf("invalid language code format '%s': expected format like 'en' or 'pt-BR'", lang)
 		}
 
-		validLanguages = append(validLanguages, lang)
+		code, err := language.Normalize(lang)
+		if err != nil {
+			return nil, fmt.Errorf("%w; run 'subs languages' to list supported codes", err)
+		}
+
+		if !slices.Contains(validLanguages, code) {
+			validLanguages = append(validLanguages, code)
+		}
 	}
 
 	if len(validLanguages) == 0 {
@@ -203,9 +600,20 @@ func (c *CLI) validateLanguages() (*ValidationResult, error) {
 	}
 
 	c.Language = validLanguages
+
+	names := make([]string, 0, len(validLanguages))
+	for _, code := range validLanguages {
+		names = append(names, fmt.Sprintf("%s (%s)", code, language.DisplayName(code)))
+	}
+
+	message := fmt.Sprintf("Language codes validated: %s", strings.Join(names, ", "))
+	if c.langSource != "" {
+		message += " - " + c.langSource
+	}
+
 	return &ValidationResult{
 		Success: true,
-		Message: fmt.Sprintf("Language codes validated: %v", c.Language),
+		Message: message,
 	}, nil
 }
 
@@ -229,6 +637,32 @@ func (c *CLI) validateConfigFile() (*ValidationResult, error) {
 	}, nil
 }
 
+func (c *CLI) filterOptions() filter.Options {
+	return filter.Options{
+		MinRating:    c.MinRating,
+		MinDownloads: c.MinDownloads,
+		Uploader:     strings.TrimSpace(c.Uploader),
+		TrustedOnly:  c.TrustedOnly,
+		OrderBy:      strings.ToLower(strings.TrimSpace(c.OrderBy)),
+	}
+}
+
+func (c *CLI) validateFilters() (*ValidationResult, error) {
+	opts := c.filterOptions()
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	if opts.IsZero() {
+		return nil, nil
+	}
+
+	return &ValidationResult{
+		Success: true,
+		Message: fmt.Sprintf("Result filters: %s", opts.Describe()),
+	}, nil
+}
+
 func (c *CLI) validateModeConsistency() (*ValidationResult, error) {
 	result := &ValidationResult{Success: true}
 	var messages []string
@@ -241,12 +675,130 @@ func (c *CLI) validateModeConsistency() (*ValidationResult, error) {
 		if strings.TrimSpace(c.Search) == "" {
 			return nil, fmt.Errorf("search query cannot be empty when using search mode")
 		}
+
+		if c.OutputDir != "" {
+			messages = append(messages, fmt.Sprintf("Matching subtitles will be saved to '%s', named after their release", c.OutputDir))
+		}
+	} else if c.OutputDir != "" {
+		return nil, fmt.Errorf("--output-dir is only used with --search; subtitles for media files are saved next to them")
+	}
+
+	if c.FilesFrom != "" {
+		if c.Search != "" {
+			return nil, fmt.Errorf("--files-from cannot be combined with --search")
+		}
+		if c.FilesFrom == "-" && (c.Interactive || (c.Confirm && !c.Yes)) {
+			return nil, fmt.Errorf("--files-from - reads paths from standard input, which is also needed to answer prompts; use --yes or pass the list as a file")
+		}
+		if c.Path != "." {
+			messages = append(messages, fmt.Sprintf("File list mode enabled: path argument '%s' will be ignored", c.Path))
+		}
 	}
 
 	if c.Interactive {
 		messages = append(messages, "Interactive mode enabled: you'll be able to select from multiple subtitle options")
 	}
 
+	if c.Confirm {
+		switch {
+		case c.Interactive:
+			messages = append(messages, "Confirmation mode ignored: interactive mode already asks for every download")
+		case c.Yes:
+			messages = append(messages, "Confirmation mode: best matches are accepted automatically (--yes)")
+		default:
+			messages = append(messages, "Confirmation mode: you'll be asked before each download")
+		}
+	}
+
+	if c.MaxRequests < 0 {
+		return nil, fmt.Errorf("--max-requests must not be negative, got %d", c.MaxRequests)
+	}
+	if c.MaxRequests > 0 {
+		messages = append(messages, fmt.Sprintf("Request budget: the run stops after %d API request(s) and lists the files left over", c.MaxRequests))
+	}
+
+	if c.MaxPerLanguage < 1 {
+		return nil, fmt.Errorf("--max-per-language must be at least 1, got %d", c.MaxPerLanguage)
+	}
+	if c.MaxPerLanguage > 1 {
+		if c.Interactive {
+			messages = append(messages, "Multiple downloads ignored: interactive mode downloads the subtitle you pick")
+		} else {
+			messages = append(messages, fmt.Sprintf("Multiple downloads: up to %d subtitles per language, extra files get an index suffix (e.g. .en.2.srt)", c.MaxPerLanguage))
+		}
+	}
+
+	if len(c.CDDurations) > 0 && !c.MergeCDs {
+		messages = append(messages, "CD durations ignored: they are only used with --merge-cds")
+	}
+
+	if c.Bilingual != "" {
+		switch {
+		case bilingualLanguages(c.Bilingual) == nil:
+			return nil, fmt.Errorf("--bilingual expects two languages joined by '+', e.g. pt-BR+en, got '%s'", c.Bilingual)
+		case len(c.Language) != 2:
+			return nil, fmt.Errorf("--bilingual needs two different languages, got '%s'", c.Bilingual)
+		case c.Interactive:
+			return nil, fmt.Errorf("--bilingual cannot be combined with --interactive")
+		case c.Plan != "":
+			return nil, fmt.Errorf("--plan does not support --bilingual")
+		}
+		messages = append(messages, fmt.Sprintf("Bilingual mode: the best %s and %s subtitles are combined into one %s file", c.Language[0], c.Language[1], c.bilingualFormat()))
+	}
+
+	if c.Translate != nil {
+		from, targets, err := c.translation()
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, fmt.Sprintf("Translation enabled: missing %s subtitles are translated from %s", strings.Join(targets, ", "), from))
+	}
+
+	if c.JSON && c.Interactive {
+		return nil, fmt.Errorf("--json cannot be combined with --interactive")
+	}
+	if c.tableOutput() {
+		switch {
+		case c.JSON:
+			return nil, fmt.Errorf("--output %s cannot be combined with --json", c.Output)
+		case c.Format != "":
+			return nil, fmt.Errorf("--output %s cannot be combined with --format", c.Output)
+		case c.Interactive:
+			return nil, fmt.Errorf("--output %s cannot be combined with --interactive", c.Output)
+		}
+	}
+	if c.Format != "" {
+		switch {
+		case c.JSON:
+			return nil, fmt.Errorf("--format cannot be combined with --json")
+		case c.Interactive:
+			return nil, fmt.Errorf("--format cannot be combined with --interactive")
+		}
+		format, err := parseFormat(c.Format)
+		if err != nil {
+			return nil, err
+		}
+		c.format = format
+	}
+
+	if c.Plan != "" && !c.DryRun {
+		return nil, fmt.Errorf("--plan can only be used together with --dry-run")
+	}
+
+	if c.Stdout {
+		if err := c.validateStdout(); err != nil {
+			return nil, err
+		}
+		c.MergeCDs = true
+	}
+
+	if err := c.scanOptions().Validate(); err != nil {
+		return nil, fmt.Errorf("--include/--exclude: %w", err)
+	}
+	if len(c.ExcludeDir) > 0 && !c.Recursive {
+		messages = append(messages, "Directory exclusions ignored: they are only used with --recursive")
+	}
+
 	if c.DryRun {
 		messages = append(messages, "Dry run mode: no files will be downloaded, only preview what would happen")
 	}
@@ -258,25 +810,80 @@ func (c *CLI) validateModeConsistency() (*ValidationResult, error) {
 	return result, nil
 }
 
+func (c *CLI) validateStdout() error {
+	if c.Search != "" || c.FilesFrom != "" {
+		return fmt.Errorf("--stdout needs a single media file, not --search or --files-from")
+	}
+	if info, err := os.Stat(c.Path); err == nil && info.IsDir() {
+		return fmt.Errorf("--stdout needs a single media file, got directory '%s'", c.Path)
+	}
+	if isPlaylist(c.Path) {
+		return fmt.Errorf("--stdout needs a single media file, got playlist '%s'", c.Path)
+	}
+	if len(c.Language) != 1 {
+		return fmt.Errorf("--stdout needs exactly one language, got %d", len(c.Language))
+	}
+
+	conflicts := []struct {
+		set  bool
+		flag string
+	}{
+		{c.Interactive, "--interactive"},
+		{c.Confirm && !c.Yes, "--confirm (add --yes)"},
+		{c.DryRun, "--dry-run"},
+		{c.JSON, "--json"},
+		{c.Format != "", "--format"},
+		{c.tableOutput(), "--output " + c.Output},
+		{c.Bilingual != "", "--bilingual"},
+		{c.Translate != nil, "--translate"},
+		{c.MaxPerLanguage > 1, "--max-per-language"},
+	}
+	for _, conflict := range conflicts {
+		if conflict.set {
+			return fmt.Errorf("--stdout cannot be combined with %s", conflict.flag)
+		}
+	}
+	return nil
+}
+
 func (c *CLI) displayConfiguration() {
-	fmt.Println("\n--- Configuration ---")
+	ui := c.ui()
+	ui.Println(ui.Bold("\n--- Configuration ---"))
 
 	if c.Search != "" {
-		fmt.Printf("Mode: Manual search\n")
-		fmt.Printf("Search query: %s\n", c.Search)
+		ui.Printf("Mode: Manual search\n")
+		ui.Printf("Search query: %s\n", c.Search)
+		if c.OutputDir != "" {
+			ui.Printf("Output directory: %s\n", c.OutputDir)
+		}
 	} else {
-		fmt.Printf("Mode: Path-based search\n")
-		fmt.Printf("Target path: %s\n", c.Path)
+		ui.Printf("Mode: Path-based search\n")
+		ui.Printf("Target path: %s\n", c.Path)
 	}
 
-	fmt.Printf("Languages: %v\n", c.Language)
-	fmt.Printf("Interactive: %t\n", c.Interactive)
-	fmt.Printf("Dry run: %t\n", c.DryRun)
+	ui.Printf("Languages: %v\n", c.Language)
+	ui.Printf("Interactive: %t\n", c.Interactive)
+	ui.Printf("Dry run: %t\n", c.DryRun)
 
 	if c.Config != "" {
-		fmt.Printf("Config file: %s\n", c.Config)
+		ui.Printf("Config file: %s\n", c.Config)
 	} else {
-		fmt.Printf("Config file: default (~/.subs-cli/config.yaml)\n")
+		ui.Printf("Config file: default (~/.subs-cli/config.yaml)\n")
+	}
+
+	if c.share {
+		ui.Printf("Network share: yes (writes and directory reads are retried up to %d times)\n", c.loadedConfig().Shares.Retries())
+	}
+}
+
+func (c *CLI) detectShare() {
+	switch c.loadedConfig().Shares.Detect {
+	case config.ShareAlways:
+		c.share = true
+	case config.ShareNever:
+		c.share = c.NetworkShare
+	default:
+		c.share = c.NetworkShare || (c.Search == "" && !isRemote(c.Path) && fsutil.IsNetworkPath(c.Path))
 	}
 }
 
@@ -315,12 +922,25 @@ func isValidLanguageCode(code string) bool {
 }
 
 func (c *CLI) processMediaFiles(p *parser.Parser) error {
+	if c.Search != "" {
+		return c.processSearch(p)
+	}
+	if c.FilesFrom != "" {
+		return c.processFileList(p)
+	}
+	if isRemote(c.Path) {
+		return c.processRemote(p)
+	}
+	if isPlaylist(c.Path) {
+		return c.processPlaylist(p)
+	}
+
 	info, err := os.Stat(c.Path)
 	if err != nil {
 		return fmt.Errorf("cannot access path: %w", err)
 	}
 
-	fmt.Println("\n--- Media File Processing ---")
+	c.ui().Println(c.ui().Bold("\n--- Media File Processing ---"))
 
 	if info.IsDir() {
 		return c.processDirectory(p)
@@ -330,151 +950,1159 @@ func (c *CLI) processMediaFiles(p *parser.Parser) error {
 }
 
 func (c *CLI) processDirectory(p *parser.Parser) error {
-	entries, err := os.ReadDir(c.Path)
+	mediaFiles, err := findMediaFiles(c.Path, c.scanOptions())
 	if err != nil {
-		return fmt.Errorf("failed to read directory: %w", err)
-	}
-
-	mediaFiles := []string{}
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-
-		filename := entry.Name()
-		ext := strings.ToLower(filepath.Ext(filename))
-		if mediaExtensions[ext] {
-			mediaFiles = append(mediaFiles, filepath.Join(c.Path, filename))
-		}
+		return err
 	}
 
 	if len(mediaFiles) == 0 {
-		fmt.Printf("No media files found in directory: %s\n", c.Path)
+		c.ui().Printf("No media files found in directory: %s\n", c.Path)
 		return nil
 	}
 
-	fmt.Printf("Found %d media file(s) in directory\n", len(mediaFiles))
-
-	for _, file := range mediaFiles {
-		if err := c.processFile(p, file); err != nil {
-			fmt.Printf("Error processing %s: %v\n", filepath.Base(file), err)
-			continue
-		}
+	c.ui().Printf("Found %d media file(s) in directory\n", len(mediaFiles))
+	if c.season = c.detectSeasonPack(p, mediaFiles); c.season != nil {
+		c.ui().Printf("Season pack: %s season %d (%d episodes), fetching the season's subtitles once per language\n", c.season.title, c.season.season, c.season.episodes)
 	}
-
+	c.processFiles(p, mediaFiles)
 	return nil
 }
 
-func (c *CLI) processFile(p *parser.Parser, filePath string) error {
-	filename := filepath.Base(filePath)
-	fmt.Printf("\nProcessing: %s\n", filename)
-
-	mediaInfo, err := p.Parse(filename)
-	if err != nil {
-		fmt.Printf("  ❌ Failed to parse filename: %v\n", err)
-		return nil
+func (c *CLI) processFiles(p *parser.Parser, mediaFiles []string) {
+	c.duplicates = c.findDuplicates(mediaFiles)
+	if len(c.duplicates) > 0 {
+		c.ui().Printf("Found %d duplicate video(s) by hash; their subtitles will be linked instead of downloaded again\n", len(c.duplicates))
 	}
 
-	c.displayMediaInfo(mediaInfo)
-
-	if err := c.searchAndDisplaySubtitles(mediaInfo); err != nil {
-		fmt.Printf("  ❌ Subtitle search failed: %v\n", err)
-		return nil
+	var unprocessed []string
+	bar := progress.New(c.ui().Writer(), "Library", int64(len(mediaFiles)), c.progressEnabled())
+	for i, file := range mediaFiles {
+		if c.budgetSpent(false) || c.interrupted() {
+			unprocessed = mediaFiles[i:]
+			break
+		}
+		if err := c.processFile(p, file); err != nil {
+			c.ui().Printf("%s %s: %v\n", c.ui().Error("Error processing"), filepath.Base(file), err)
+		}
+		bar.Add(1)
+		if c.budgetSpent(true) || c.interrupted() {
+			unprocessed = mediaFiles[i:]
+			break
+		}
+	}
+	bar.Finish()
+	if len(unprocessed) > 0 {
+		c.reportUnprocessed(unprocessed)
 	}
-
-	return nil
 }
 
-func (c *CLI) displayMediaInfo(info *models.MediaInfo) {
-	fmt.Printf("  ✅ Parsed successfully:\n")
-	fmt.Printf("     Title: %s\n", info.Title)
-
-	if info.Year != "" {
-		fmt.Printf("     Year: %s\n", info.Year)
+func (c *CLI) reportUnprocessed(files []string) {
+	ui := c.ui()
+	reason := "Interrupted"
+	if !c.interrupted() {
+		reason = fmt.Sprintf("Request budget of %d used up", c.budget.Limit())
 	}
+	ui.Printf("%s %s; %d file(s) left for the next run:\n", ui.Warning(ui.Icon(output.IconWarning)), reason, len(files))
+	for _, file := range files {
+		ui.Printf("  %s\n", file)
+	}
+}
 
-	if info.IsEpisode() {
-		fmt.Printf("     Season: %d, Episode: %d\n", info.Season, info.Episode)
+func (c *CLI) processFileList(p *parser.Parser) error {
+	source := "standard input"
+	var reader io.Reader = os.Stdin
+	if c.FilesFrom != "-" {
+		file, err := os.Open(c.FilesFrom)
+		if err != nil {
+			return fmt.Errorf("cannot read file list: %w", err)
+		}
+		defer file.Close()
+		reader, source = file, c.FilesFrom
 	}
 
-	if info.Quality != "" {
-		fmt.Printf("     Quality: %s\n", info.Quality)
+	paths, err := readFileList(reader)
+	if err != nil {
+		return fmt.Errorf("cannot read file list from %s: %w", source, err)
 	}
 
-	if info.Source != "" {
-		fmt.Printf("     Source: %s\n", info.Source)
+	c.ui().Println(c.ui().Bold("\n--- Media File Processing ---"))
+	c.processPaths(p, paths, "list from "+source)
+	return nil
+}
+
+func (c *CLI) processPaths(p *parser.Parser, paths []string, source string) {
+	ui := c.ui()
+	mediaFiles := []string{}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		switch {
+		case err != nil:
+			ui.Printf("%s Skipping %s: %v\n", ui.Warning(ui.Icon(output.IconWarning)), path, err)
+		case info.IsDir():
+			found, err := findMediaFiles(path, c.scanOptions())
+			if err != nil {
+				ui.Printf("%s Skipping %s: %v\n", ui.Warning(ui.Icon(output.IconWarning)), path, err)
+				continue
+			}
+			mediaFiles = append(mediaFiles, found...)
+		case !mediaExtensions[strings.ToLower(filepath.Ext(path))] && !rar.IsArchive(path):
+			ui.Printf("%s Skipping %s: not a supported media file\n", ui.Warning(ui.Icon(output.IconWarning)), path)
+		default:
+			mediaFiles = append(mediaFiles, path)
+		}
 	}
 
-	if info.Codec != "" {
-		fmt.Printf("     Codec: %s\n", info.Codec)
+	if len(mediaFiles) == 0 {
+		ui.Printf("No media files found in %s\n", source)
+		return
 	}
 
-	fmt.Printf("     Type: %s\n", info.Type)
+	ui.Printf("Found %d media file(s) in %s\n", len(mediaFiles), source)
+	c.processFiles(p, mediaFiles)
 }
 
-func (c *CLI) searchAndDisplaySubtitles(mediaInfo *models.MediaInfo) error {
-	config := &api.Config{
-		// TODO: Get credentials from config file or environment variables
-		Username: "demo",
-		Password: "demo",
+func readFileList(r io.Reader) ([]string, error) {
+	var paths []string
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		path := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(path) == "" || seen[path] {
+			continue
+		}
+		seen[path] = true
+		paths = append(paths, path)
 	}
-	
-	client := api.NewOpenSubtitlesClient(config)
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	return paths, scanner.Err()
+}
+
+func (c *CLI) scanOptions() scan.Options {
+	opts := scan.Options{
+		Recursive:      c.Recursive,
+		Include:        c.Include,
+		Exclude:        c.Exclude,
+		ExcludeDirs:    c.ExcludeDir,
+		MinSize:        c.MinSize,
+		FollowSymlinks: c.FollowSymlinks,
+	}
+	if c.NewerThan > 0 {
+		opts.NewerThan = time.Now().Add(-time.Duration(c.NewerThan))
+	}
+	if c.share {
+		shares := c.loadedConfig().Shares
+		opts.StatBatch = shares.Batch()
+		opts.StatPause, _ = shares.StatPauseDuration()
+		opts.Retries = shares.Retries()
+		opts.RetryDelay, _ = shares.RetryDelayDuration()
+	}
+	return opts
+}
+
+func findMediaFiles(dir string, opts scan.Options) ([]string, error) {
+	opts.Extensions = scanExtensions
+	files, err := scan.Find(dir, opts)
+	return withoutExtracted(files), err
+}
+
+func (c *CLI) processFile(p *parser.Parser, filePath string) error {
+	c.Observe(subs.FileStarted{Path: filePath})
+	c.Observe(subs.FileFinished{Path: filePath, Err: c.handleFile(p, filePath)})
+	return nil
+}
+
+func (c *CLI) handleFile(p *parser.Parser, filePath string) error {
+	if original, ok := c.duplicates[filePath]; ok && c.linkDuplicate(original, filePath) {
+		return nil
+	}
+	if rar.IsArchive(filePath) {
+		inner, err := c.openRar(filePath)
+		if err != nil {
+			return err
+		}
+		filePath = inner
+	}
+
+	mediaInfo, err := p.Parse(filepath.Base(filePath))
+	if errors.Is(err, parser.ErrUnparseableFilename) {
+		mediaInfo, err = c.lookupEpisodeTitle(filepath.Base(filePath), err)
+	}
+	if errors.Is(err, parser.ErrUnparseableFilename) {
+		mediaInfo, err = c.guessFilename(filepath.Base(filePath), err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse filename: %w", err)
+	}
+	c.Observe(subs.MediaParsed{Path: filePath, Media: mediaInfo})
+	if err := c.checkChecksum(filePath, mediaInfo); err != nil {
+		return err
+	}
+
+	settings, err := c.settingsFor(filePath)
+	if err != nil {
+		return fmt.Errorf("directory override failed: %w", err)
+	}
+	ui := c.ui()
+	for _, source := range settings.sources {
+		ui.Printf("  %s Using overrides from %s\n", ui.Info(ui.Icon(output.IconInfo)), source)
+	}
+
+	if err := c.searchAndDisplaySubtitles(mediaInfo, filePath, settings); err != nil {
+		return fmt.Errorf("subtitle search failed: %w", err)
+	}
+	return nil
+}
+
+func (c *CLI) displayMediaInfo(info *models.MediaInfo) {
+	ui := c.ui()
+	ui.Printf("  %s %s\n", ui.Icon(output.IconSuccess), ui.Success("Parsed successfully:"))
+	ui.Printf("     Title: %s\n", info.Title)
+
+	if info.Year != "" {
+		ui.Printf("     Year: %s\n", info.Year)
+	}
+
+	if info.IsEpisode() {
+		ui.Printf("     Season: %d, Episode: %d\n", info.Season, info.Episode)
+	}
+
+	if info.Quality != "" {
+		ui.Printf("     Quality: %s\n", info.Quality)
+	}
+
+	if info.Source != "" {
+		ui.Printf("     Source: %s\n", info.Source)
+	}
+
+	if info.Codec != "" {
+		ui.Printf("     Codec: %s\n", info.Codec)
+	}
+
+	if info.Audio != "" {
+		ui.Printf("     Audio: %s\n", strings.TrimSpace(info.Audio+" "+info.Channels))
+	}
+
+	if info.Checksum != "" {
+		ui.Printf("     Checksum: %s\n", info.Checksum)
+	}
+
+	ui.Printf("     Type: %s\n", info.Type)
+}
+
+func (c *CLI) searchAndDisplaySubtitles(mediaInfo *models.MediaInfo, filePath string, settings *fileSettings) error {
+	client := c.newClient(settings.config)
+	ctx, cancel := c.fileContext(settings.config)
 	defer cancel()
 
 	searchParams := c.createSearchParams(mediaInfo)
+	c.hashMedia(searchParams, filePath)
 	
-	fmt.Printf("  🔍 Searching for subtitles...\n")
-	
-	allSubtitles := make([]*models.Subtitle, 0)
-	for _, language := range c.Language {
-		searchParams.Language = language
-		subtitles, err := client.Search(ctx, searchParams)
-		if err != nil {
-			fmt.Printf("    ⚠ Failed to search for %s subtitles: %v\n", language, err)
-			continue
-		}
-		
-		fmt.Printf("    ✅ Found %d %s subtitle(s)\n", len(subtitles), language)
-		allSubtitles = append(allSubtitles, subtitles...)
+	ui := c.ui()
+	searcher := c.searcher(client, settings.config)
+	c.video = nil
+	if c.Probe && c.Search == "" {
+		c.video = c.probeVideo(ctx, filePath, settings.config)
 	}
+	outcome := c.searchWithRetry(ctx, searcher, searchParams, settings.languages)
+	allSubtitles, results := outcome.all, outcome.results
+	c.rankByVideo(outcome, settings.languages)
+	c.reportResults(searchParams, outcome, settings.languages)
 	
 	if len(allSubtitles) == 0 {
-		fmt.Printf("  ❌ No subtitles found for %s\n", mediaInfo.GetDisplayTitle())
+		ui.Printf("  %s %s %s\n", ui.Icon(output.IconFailure), ui.Error("No subtitles found for"), mediaInfo.GetDisplayTitle())
 		return nil
 	}
 	
 	c.displaySubtitleList(allSubtitles)
+	if c.ExplainScore {
+		c.explainScores(outcome, settings.languages, searchParams.MediaPath)
+	}
+	c.applyMinScore(outcome, settings.languages, searchParams.MediaPath)
+
+	if missing := c.missingTranslations(outcome, settings.languages); len(missing) > 0 {
+		c.translateMissing(ctx, client, searcher, searchParams, outcome, filePath, settings.config, missing)
+	}
+
+	if c.Bilingual != "" {
+		if err := c.downloadBilingual(ctx, client, outcome, filePath, settings.languages); err != nil {
+			ui.Printf("    %s %v\n", ui.Warning(fmt.Sprintf("%s Failed to create bilingual subtitle:", ui.Icon(output.IconWarning))), err)
+		}
+		return nil
+	}
+
+	if c.DryRun {
+		c.planDownloads(filePath, settings, outcome)
+		return nil
+	}
+
+	if c.quotaExceeded {
+		ui.Printf("  %s Skipping downloads: the download quota is used up\n", ui.Warning(ui.Icon(output.IconWarning)))
+		return nil
+	}
+
+	opts := c.engineOptions(searcher, client)
+	opts.PlainNames = settings.config.Output.Naming == config.NamingPlain
+	if c.Interactive {
+		picker := newSubtitlePicker(ui, c.prompt(), func(subtitle *models.Subtitle) ([]byte, error) {
+			return searcher.Download(ctx, subtitle)
+		})
+		picker.explain = c.scorer(searchParams.MediaPath).Explain
+		opts.Select = func(language string) (*models.Subtitle, []byte) {
+			return picker.pick(language, results[language])
+		}
+	} else if c.Confirm {
+		opts.Confirm = c.confirmCandidate
+	}
+
+	engine, err := subs.New(opts)
+	if err != nil {
+		return err
+	}
+	engine.Deliver(ctx, filePath, settings.languages, outcome.candidates)
 	return nil
 }
 
-func (c *CLI) createSearchParams(mediaInfo *models.MediaInfo) *models.SearchParams {
-	params := &models.SearchParams{
-		Query: mediaInfo.Title,
-		Type:  "movie",
+func downloadName(settings *fileSettings, language string, languageIndex, rank int) (string, bool) {
+	withLanguage := rank > 0 || languageIndex > 0 || settings.config.Output.Naming != config.NamingPlain
+	return indexedLanguage(language, rank), withLanguage
+}
+
+func (c *CLI) planDownloads(filePath string, settings *fileSettings, outcome *searchOutcome) {
+	ui := c.ui()
+	for i, language := range settings.languages {
+		candidates := outcome.candidates[language]
+		if limit := c.maxPerLanguage(); len(candidates) > limit {
+			candidates = candidates[:limit]
+		}
+
+		for k, subtitle := range candidates {
+			label, withLanguage := downloadName(settings, language, i, k)
+			for _, download := range c.plannedDownloads(subtitle, filePath, language, label, withLanguage) {
+				if c.keepExisting(download.Target, filePath, download.Subtitle) {
+					continue
+				}
+				ui.Printf("    %s Would save %s\n", ui.Icon(output.IconDownload), c.previewTarget(download.Target))
+				c.rememberSaved(filePath, download.Target)
+				if c.planned != nil {
+					c.planned.Add(download)
+				}
+			}
+		}
 	}
-	
-	if mediaInfo.IsEpisode() {
-		params.Type = "episode"
-		params.Season = mediaInfo.Season
-		params.Episode = mediaInfo.Episode
+}
+
+func (c *CLI) plannedDownloads(subtitle *models.Subtitle, mediaPath, language, label string, withLanguage bool) []plan.Download {
+	if !subtitle.IsMultiPart() || c.MergeCDs {
+		download := plan.Download{
+			MediaPath: mediaPath,
+			Target:    subtitlePath(mediaPath, label, subtitle.SubFormat, withLanguage),
+			Language:  language,
+			Subtitle:  subtitle,
+		}
+		if subtitle.IsMultiPart() {
+			download.MergeCDs = true
+			for _, duration := range c.CDDurations {
+				download.CDDurations = append(download.CDDurations, duration.String())
+			}
+		}
+		return []plan.Download{download}
 	}
-	
-	if mediaInfo.Year != "" {
-		if year, err := strconv.Atoi(mediaInfo.Year); err == nil {
-			params.Year = year
+
+	downloads := make([]plan.Download, 0, len(subtitle.Parts))
+	for i, part := range subtitle.Parts {
+		file := subtitle.Part(i)
+		downloads = append(downloads, plan.Download{
+			MediaPath: mediaPath,
+			Target:    subtitlePath(mediaPath, partLanguage(label, part.CD, withLanguage), file.SubFormat, true),
+			Language:  language,
+			Subtitle:  file,
+		})
+	}
+	return downloads
+}
+
+type searchOutcome struct {
+	all        []*models.Subtitle
+	results    map[string][]*models.Subtitle
+	candidates map[string][]*models.Subtitle
+	errs       []error
+}
+
+func (o *searchOutcome) emptyWithoutErrors() bool {
+	return len(o.all) == 0 && len(o.errs) == 0
+}
+
+func (c *CLI) searchLanguages(ctx context.Context, client api.Client, params *models.SearchParams, languages []string) *searchOutcome {
+	ui := c.ui()
+	ui.Printf("  %s Searching for subtitles...\n", ui.Icon(output.IconSearch))
+
+	engine, err := c.engine(client, nil)
+	if err != nil {
+		return &searchOutcome{results: map[string][]*models.Subtitle{}, candidates: map[string][]*models.Subtitle{}, errs: []error{err}}
+	}
+
+	found := engine.SearchLanguages(ctx, params.MediaPath, params, languages)
+	outcome := &searchOutcome{all: found.All, results: found.Results, candidates: found.Candidates}
+	for _, language := range languages {
+		if err := found.Errs[language]; err != nil {
+			outcome.errs = append(outcome.errs, err)
 		}
 	}
-	
-	return params
+	return outcome
+}
+
+func (c *CLI) searchWithRetry(ctx context.Context, client api.Client, params *models.SearchParams, languages []string) *searchOutcome {
+	outcome := c.searchLanguages(ctx, client, params, languages)
+	if c.interrupted() {
+		return outcome
+	}
+	if outcome.emptyWithoutErrors() {
+		outcome = c.searchAlternativeTitles(ctx, client, params, languages, outcome)
+	}
+	if outcome.emptyWithoutErrors() {
+		outcome = c.relaxQuery(ctx, client, params, languages, outcome)
+	}
+	for {
+		if len(outcome.all) > 0 || !c.Interactive {
+			return outcome
+		}
+
+		ui := c.ui()
+		ui.Printf("  %s No results for '%s'. Edit the query and press Enter to retry, or clear it to skip.\n", ui.Icon(output.IconTip), params.Query)
+		query, ok := c.prompt().edit("  Search query", params.Query)
+		query = strings.TrimSpace(query)
+		if !ok || query == "" || query == params.Query {
+			return outcome
+		}
+		params.Query = query
+		outcome = c.searchLanguages(ctx, client, params, languages)
+	}
+}
+
+func (c *CLI) prompt() *prompter {
+	if c.prompter == nil {
+		c.prompter = newPrompter(os.Stdin, c.ui().Writer())
+	}
+	return c.prompter
+}
+
+func (c *CLI) newClient(cfg *config.Config) *api.OpenSubtitlesClient {
+	apiCfg := apiConfig(cfg)
+	if c.DebugHTTP {
+		apiCfg.Debug = os.Stderr
+	}
+	apiCfg.Breaker = c.breaker(api.ProviderOpenSubtitles, cfg)
+	apiCfg.Budget = c.requestBudget()
+	return api.NewOpenSubtitlesClient(apiCfg)
+}
+
+func (c *CLI) xmlrpcClient(cfg *config.Config) *api.XMLRPCClient {
+	if c.xmlrpc == nil {
+		apiCfg := apiConfig(cfg)
+		if c.DebugHTTP {
+			apiCfg.Debug = os.Stderr
+		}
+		apiCfg.Breaker = c.breaker(api.ProviderOpenSubtitlesXMLRPC, cfg)
+		apiCfg.Budget = c.requestBudget()
+		c.xmlrpc = api.NewXMLRPCClient(apiCfg)
+	}
+	return c.xmlrpc
+}
+
+func (c *CLI) breaker(provider string, cfg *config.Config) *api.Breaker {
+	if c.breakers == nil {
+		c.breakers = make(map[string]*api.Breaker)
+	}
+	if b, ok := c.breakers[provider]; ok {
+		return b
+	}
+
+	cooldown, _ := cfg.Network.BreakerCooldownDuration()
+	b := api.NewBreaker(cfg.Network.FailureThreshold, cooldown)
+	c.breakers[provider] = b
+	return b
+}
+
+func apiConfig(cfg *config.Config) *api.Config {
+	credentials := cfg.OpenSubtitles
+	apiCfg := &api.Config{
+		APIKey:       credentials.APIKey,
+		Username:     credentials.Username,
+		Password:     credentials.Password,
+		MaxIdleConns: cfg.Network.MaxIdleConns,
+	}
+	apiCfg.Timeout, _ = cfg.Network.RequestTimeout()
+	apiCfg.DialTimeout, _ = cfg.Network.DialTimeoutDuration()
+	if store, err := openCache(cfg); err == nil && store != nil {
+		apiCfg.Responses = store
+	}
+
+	if proxy := cfg.OpenSubtitlesProxy(); proxy == config.ProxyDirect {
+		apiCfg.NoProxy = true
+	} else {
+		apiCfg.Proxy = proxy
+	}
+	return apiCfg
+}
+
+// engineOptions wires the CLI's ranking, download and save steps into the
+// subs pipeline. client answers searches; download fetches the files.
+func (c *CLI) engineOptions(client api.Client, download *api.OpenSubtitlesClient) subs.Options {
+	filters := c.filterOptions()
+	return subs.Options{
+		MinRating:      filters.MinRating,
+		MinDownloads:   filters.MinDownloads,
+		Uploader:       filters.Uploader,
+		TrustedOnly:    filters.TrustedOnly,
+		OrderBy:        filters.OrderBy,
+		MaxPerLanguage: c.maxPerLanguage(),
+		MergeCDs:       c.MergeCDs,
+		CDDurations:    c.CDDurations,
+		Fallback:       c.loadedConfig().Probe.Fallback(),
+		Observer:       c,
+		Client:         client,
+		Rank:           c.rankCandidates,
+		Fetch: func(ctx context.Context, subtitle *models.Subtitle, target string) ([]byte, error) {
+			return c.fetchSubtitle(ctx, download, subtitle, target)
+		},
+		Check: c.lengthProblem,
+		Keep:  c.keepExisting,
+		Save:  c.storeSubtitle,
+	}
+}
+
+func (c *CLI) engine(client api.Client, download *api.OpenSubtitlesClient) (*subs.Engine, error) {
+	return subs.New(c.engineOptions(client, download))
+}
+
+func (c *CLI) rankCandidates(mediaPath, language string, subtitles []*models.Subtitle) []*models.Subtitle {
+	ranked := c.rankSubtitles(subtitles, mediaPath)
+	if c.feedback == nil {
+		return ranked
+	}
+
+	ranked, rejected := c.feedback.Rank(ranked)
+	if rejected > 0 {
+		ui := c.ui()
+		ui.Printf("    %s Skipped %d %s subtitle(s) you rated bad\n", ui.Info(ui.Icon(output.IconInfo)), rejected, language)
+	}
+	return ranked
+}
+
+func (c *CLI) rankSubtitles(subtitles []*models.Subtitle, mediaPath string) []*models.Subtitle {
+	orderBy := c.filterOptions().OrderBy
+	ranked := subs.Rank(subtitles, orderBy)
+	if orderBy != "" {
+		return ranked
+	}
+
+	return c.scorer(mediaPath).Rank(ranked)
+}
+
+func (c *CLI) scorer(mediaPath string) *score.Scorer {
+	scoring := c.loadedConfig().Scoring
+	prefs := score.Preferences{
+		PreferGroups:  scoring.PreferGroups,
+		AvoidGroups:   scoring.AvoidGroups,
+		PreferSources: scoring.PreferSources,
+		AvoidSources:  scoring.AvoidSources,
+	}
+	var local score.Local
+	if mediaPath != "" {
+		local.Release = filepath.Base(mediaPath)
+	}
+	if c.video != nil {
+		local.FPS = c.video.FPS
+	}
+	return score.New(scoring.Weights, prefs, local)
+}
+
+func (c *CLI) explainScores(outcome *searchOutcome, languages []string, mediaPath string) {
+	ui := c.ui()
+	scorer := c.scorer(mediaPath)
+	for _, language := range languages {
+		candidates := outcome.candidates[language]
+		if len(candidates) == 0 {
+			continue
+		}
+
+		ui.Printf("\n  %s %s\n", ui.Icon(output.IconInfo), ui.Bold(fmt.Sprintf("Score breakdown (%s):", language)))
+		if c.filterOptions().OrderBy != "" {
+			ui.Printf("    Ranked by --order-by %s; scores are shown for reference only.\n", c.filterOptions().OrderBy)
+		}
+		for i, subtitle := range candidates {
+			name := subtitle.ReleaseName
+			if name == "" {
+				name = subtitle.FileName
+			}
+			ui.Printf("  %-4d %-40s %s\n", i+1, c.truncateString(name, 40), formatBreakdown(scorer.Explain(subtitle), false))
+		}
+	}
+}
+
+func (c *CLI) minScore() int {
+	if c.MinScore != 0 {
+		return c.MinScore
+	}
+	return c.loadedConfig().Scoring.MinScore
+}
+
+func (c *CLI) applyMinScore(outcome *searchOutcome, languages []string, mediaPath string) {
+	minimum := c.minScore()
+	if minimum == 0 || c.Interactive {
+		return
+	}
+
+	ui := c.ui()
+	scorer := c.scorer(mediaPath)
+	for _, language := range languages {
+		candidates := outcome.candidates[language]
+		if len(candidates) == 0 {
+			continue
+		}
+
+		best := math.MinInt
+		kept := make([]*models.Subtitle, 0, len(candidates))
+		for _, subtitle := range candidates {
+			points := scorer.Score(subtitle)
+			best = max(best, points)
+			if points >= minimum {
+				kept = append(kept, subtitle)
+			}
+		}
+		outcome.candidates[language] = kept
+		if len(kept) > 0 {
+			continue
+		}
+
+		switch {
+		case mediaPath == "":
+			ui.Printf("    %s No %s subtitle scored at least %d (best: %d); nothing will be downloaded\n", ui.Warning(ui.Icon(output.IconWarning)), language, minimum, best)
+		case c.DryRun:
+			ui.Printf("    %s No %s subtitle scored at least %d (best: %d); the file would be added to the wanted list\n", ui.Warning(ui.Icon(output.IconWarning)), language, minimum, best)
+		default:
+			ui.Printf("    %s No %s subtitle scored at least %d (best: %d); added the file to the wanted list\n", ui.Warning(ui.Icon(output.IconWarning)), language, minimum, best)
+			c.recordWanted(mediaPath, language, fmt.Sprintf("best score %d is below the minimum of %d", best, minimum))
+		}
+	}
+}
+
+func formatBreakdown(breakdown score.Breakdown, detailed bool) string {
+	if len(breakdown.Factors) == 0 {
+		return fmt.Sprintf("score %d", breakdown.Total)
+	}
+
+	parts := make([]string, 0, len(breakdown.Factors))
+	for _, factor := range breakdown.Factors {
+		part := fmt.Sprintf("%s %+d", factor.Name, factor.Points)
+		detail := factor.Detail
+		if factor.Name == score.FactorRelease && len(breakdown.MatchedTokens) > 0 {
+			detail += ": " + strings.Join(breakdown.MatchedTokens, " ")
+		}
+		if detailed && detail != "" {
+			part += " [" + detail + "]"
+		}
+		parts = append(parts, part)
+	}
+	return fmt.Sprintf("score %d (%s)", breakdown.Total, strings.Join(parts, ", "))
+}
+
+func (c *CLI) probeVideo(ctx context.Context, filePath string, cfg *config.Config) *probe.Info {
+	ui := c.ui()
+	info, err := probe.New(cfg.Probe.FFprobe).Probe(ctx, c.mediaSource(filePath))
+	if err != nil {
+		ui.Printf("  %s %v\n", ui.Warning(fmt.Sprintf("%s Could not probe video:", ui.Icon(output.IconWarning))), err)
+		return nil
+	}
+	ui.Printf("  %s Video length %s, %.3f fps\n", ui.Info(ui.Icon(output.IconInfo)), info.Duration.Round(time.Second), info.FPS)
+	return info
+}
+
+func (c *CLI) rankByVideo(outcome *searchOutcome, languages []string) {
+	if c.video == nil || c.video.FPS == 0 {
+		return
+	}
+
+	ui := c.ui()
+	for _, language := range languages {
+		ranked, mismatched := c.video.Rank(outcome.candidates[language])
+		outcome.candidates[language] = ranked
+		if mismatched > 0 {
+			ui.Printf("    %s %d %s subtitle(s) were made for a different frame rate than the video (%.3f fps) and may be out of sync; they are ranked last\n", ui.Warning(ui.Icon(output.IconWarning)), mismatched, language, c.video.FPS)
+		}
+	}
+}
+
+func (c *CLI) lengthProblem(content []byte) error {
+	if c.video == nil || subformat.Detect(content) != "srt" {
+		return nil
+	}
+
+	end, err := subformat.SRTEnd(content)
+	if err != nil {
+		return nil
+	}
+
+	cfg := c.loadedConfig().Probe
+	tolerance, err := cfg.ToleranceDuration()
+	if err != nil {
+		tolerance = config.DefaultLengthTolerance
+	}
+	return c.video.CheckLength(end, probe.Limits{Overrun: tolerance, Coverage: cfg.Coverage()})
+}
+
+func (c *CLI) checkLength(content []byte) {
+	if err := c.lengthProblem(content); err != nil {
+		ui := c.ui()
+		ui.Printf("    %s %v\n", ui.Warning(fmt.Sprintf("%s Possibly out of sync:", ui.Icon(output.IconWarning))), err)
+	}
+}
+
+func (c *CLI) translation() (string, []string, error) {
+	from, targets := "en", append([]string{}, c.Language...)
+	for key, value := range c.Translate {
+		switch key {
+		case "from":
+			from = value
+		case "to":
+			targets = []string{value}
+		default:
+			return "", nil, fmt.Errorf("--translate accepts from=LANG and to=LANG, got '%s'", key)
+		}
+	}
+
+	code, err := language.Normalize(from)
+	if err != nil {
+		return "", nil, fmt.Errorf("--translate from: %w", err)
+	}
+	for i, target := range targets {
+		normalized, err := language.Normalize(target)
+		if err != nil {
+			return "", nil, fmt.Errorf("--translate to: %w", err)
+		}
+		if !slices.Contains(c.Language, normalized) {
+			return "", nil, fmt.Errorf("--translate to=%s is not one of the requested languages (%s)", target, strings.Join(c.Language, ", "))
+		}
+		targets[i] = normalized
+	}
+	if _, explicit := c.Translate["to"]; explicit && targets[0] == code {
+		return "", nil, fmt.Errorf("--translate cannot translate %s into itself", code)
+	}
+	return code, slices.DeleteFunc(targets, func(target string) bool { return target == code }), nil
+}
+
+func (c *CLI) missingTranslations(outcome *searchOutcome, languages []string) []string {
+	if c.Translate == nil || c.Interactive {
+		return nil
+	}
+
+	_, targets, err := c.translation()
+	if err != nil {
+		return nil
+	}
+
+	var missing []string
+	for _, language := range languages {
+		if slices.Contains(targets, language) && len(outcome.candidates[language]) == 0 {
+			missing = append(missing, language)
+		}
+	}
+	return missing
+}
+
+func (c *CLI) translateMissing(ctx context.Context, client *api.OpenSubtitlesClient, searcher api.Client, params *models.SearchParams, outcome *searchOutcome, filePath string, cfg *config.Config, missing []string) {
+	ui := c.ui()
+	warn := func(format string, args ...interface{}) {
+		ui.Printf("    %s %s\n", ui.Warning(ui.Icon(output.IconWarning)), fmt.Sprintf(format, args...))
+	}
+
+	from, _, _ := c.translation()
+	candidates, ok := outcome.candidates[from]
+	if !ok {
+		source := *params
+		candidates = c.searchLanguages(ctx, searcher, &source, []string{from}).candidates[from]
+	}
+
+	var subtitle *models.Subtitle
+	for _, candidate := range candidates {
+		if !candidate.IsMultiPart() {
+			subtitle = candidate
+			break
+		}
+	}
+	if subtitle == nil {
+		warn("No %s subtitle to translate into %s", from, strings.Join(missing, ", "))
+		return
+	}
+
+	if c.DryRun {
+		for _, language := range missing {
+			target := subtitlePath(filePath, language+".translated", subformat.SRT, true)
+			if !c.keepExisting(target, filePath, nil) {
+				ui.Printf("    %s Would translate %s to %s and save %s\n", ui.Icon(output.IconDownload), from, language, c.previewTarget(target))
+			}
+		}
+		return
+	}
+
+	translator, err := translate.New(translate.Options{
+		Backend: cfg.Translate.Backend,
+		APIKey:  cfg.Translate.APIKey,
+		URL:     cfg.Translate.URL,
+		Model:   cfg.Translate.Model,
+	})
+	if err != nil {
+		warn("Cannot translate subtitles: %v", err)
+		return
+	}
+
+	content, err := c.fetchSubtitle(ctx, client, subtitle, subtitlePath(filePath, from, subtitle.SubFormat, true))
+	if err != nil {
+		warn("Failed to download %s subtitle for translation: %v", from, err)
+		return
+	}
+
+	for _, language := range missing {
+		target := subtitlePath(filePath, language+".translated", subformat.SRT, true)
+		if c.keepExisting(target, filePath, nil) {
+			continue
+		}
+
+		ui.Printf("    %s Translating %s subtitle to %s with %s...\n", ui.Icon(output.IconInfo), from, language, translator.Name())
+		translated, err := translate.SRT(c.context(), translator, content, from, language)
+		if err != nil {
+			warn("Failed to translate to %s: %v", language, err)
+			continue
+		}
+
+		if err := fsutil.WriteFile(target, c.postProcess(translated), c.writeOptions(filePath)); err != nil {
+			warn("Failed to write subtitle file: %v", err)
+			continue
+		}
+		ui.Printf("    %s %s %s (machine translated from %s)\n", ui.Icon(output.IconSaved), ui.Success("Saved"), target, from)
+		c.summary.Saved++
+		c.rememberSaved(filePath, target)
+	}
+}
+
+func bilingualLanguages(value string) []string {
+	primary, secondary, ok := strings.Cut(value, "+")
+	primary, secondary = strings.TrimSpace(primary), strings.TrimSpace(secondary)
+	if !ok || primary == "" || secondary == "" || strings.Contains(secondary, "+") {
+		return nil
+	}
+	return []string{primary, secondary}
+}
+
+func (c *CLI) bilingualFormat() string {
+	if c.BilingualAs == "" {
+		return subformat.SRT
+	}
+	return c.BilingualAs
+}
+
+func (c *CLI) downloadBilingual(ctx context.Context, client *api.OpenSubtitlesClient, outcome *searchOutcome, filePath string, languages []string) error {
+	ui := c.ui()
+	if len(languages) != 2 {
+		return fmt.Errorf("bilingual mode needs exactly two languages")
+	}
+
+	picks := make([]*models.Subtitle, 0, 2)
+	for _, language := range languages {
+		var pick *models.Subtitle
+		for _, candidate := range outcome.candidates[language] {
+			if !candidate.IsMultiPart() {
+				pick = candidate
+				break
+			}
+		}
+		if pick == nil {
+			ui.Printf("    %s No %s subtitle to combine, skipping bilingual file\n", ui.Warning(ui.Icon(output.IconWarning)), language)
+			return nil
+		}
+		picks = append(picks, pick)
+	}
+
+	target := subtitlePath(filePath, strings.Join(languages, "+"), c.bilingualFormat(), true)
+	if c.keepExisting(target, filePath, nil) {
+		return nil
+	}
+	if c.DryRun {
+		ui.Printf("    %s Would save %s\n", ui.Icon(output.IconDownload), c.previewTarget(target))
+		return nil
+	}
+
+	contents := make([][]byte, len(picks))
+	for i, pick := range picks {
+		content, err := c.fetchSubtitle(ctx, client, pick, subtitlePath(filePath, languages[i], pick.SubFormat, true))
+		if err != nil {
+			return fmt.Errorf("failed to download %s subtitle: %w", languages[i], err)
+		}
+		contents[i] = content
+	}
+
+	combined, err := subformat.Bilingual(contents[0], contents[1], c.bilingualFormat())
+	if err != nil {
+		return fmt.Errorf("cannot combine subtitles: %w", err)
+	}
+	if err := fsutil.WriteFile(target, c.normalizeText(combined), c.writeOptions(filePath)); err != nil {
+		return fmt.Errorf("failed to write subtitle file: %w", err)
+	}
+
+	ui.Printf("    %s %s %s\n", ui.Icon(output.IconSaved), ui.Success("Saved"), target)
+	c.summary.Saved++
+	c.rememberSaved(filePath, target)
+	c.checkLength(contents[0])
+	return nil
+}
+
+func (c *CLI) maxPerLanguage() int {
+	if c.MaxPerLanguage < 1 {
+		return 1
+	}
+	return c.MaxPerLanguage
+}
+
+func indexedLanguage(language string, index int) string {
+	return subs.IndexedLanguage(language, index)
+}
+
+func subtitlePath(mediaPath, language, format string, withLanguage bool) string {
+	return subs.SubtitlePath(mediaPath, language, format, withLanguage)
+}
+
+func (c *CLI) downloadSubtitle(ctx context.Context, client *api.OpenSubtitlesClient, subtitle *models.Subtitle, mediaPath, language string, withLanguage bool) error {
+	return c.downloadWithFallback(ctx, client, subtitle, nil, mediaPath, language, withLanguage)
+}
+
+func (c *CLI) downloadWithFallback(ctx context.Context, client *api.OpenSubtitlesClient, subtitle *models.Subtitle, spares *[]*models.Subtitle, mediaPath, language string, withLanguage bool) error {
+	return c.getSubtitle(ctx, client, subs.Request{
+		MediaPath:    mediaPath,
+		Language:     language,
+		Label:        language,
+		WithLanguage: withLanguage,
+		Subtitle:     subtitle,
+		Spares:       spares,
+	})
+}
+
+func (c *CLI) getSubtitle(ctx context.Context, client *api.OpenSubtitlesClient, request subs.Request) error {
+	engine, err := c.engine(client, client)
+	if err != nil {
+		return err
+	}
+	_, err = engine.Get(ctx, request)
+	return err
+}
+
+func (c *CLI) fetchSubtitle(ctx context.Context, client *api.OpenSubtitlesClient, subtitle *models.Subtitle, target string) ([]byte, error) {
+	if provider := c.provider(subtitle.Provider); provider != nil {
+		return provider.Download(ctx, subtitle)
+	}
+
+	c.Observe(subs.DownloadProgress{Target: target})
+	content, err := client.DownloadWithProgress(ctx, subtitle, func(downloaded, total int64) {
+		c.Observe(subs.DownloadProgress{Target: target, Downloaded: downloaded, Total: total})
+	})
+	c.Observe(subs.DownloadProgress{Target: target, Done: true})
+	return content, err
+}
+
+func (c *CLI) downloadFailed(language string, err error) {
+	ui := c.ui()
+	ui.Printf("    %s %v\n", ui.Warning(fmt.Sprintf("%s Failed to download %s subtitle:", ui.Icon(output.IconWarning), language)), err)
+
+	switch {
+	case errors.Is(err, api.ErrQuotaExceeded):
+		c.quotaExceeded = true
+		ui.Printf("    %s The download quota is used up; no more downloads will be attempted in this run\n", ui.Info(ui.Icon(output.IconInfo)))
+	case errors.Is(err, api.ErrAuthFailed):
+		ui.Printf("    %s Check the OpenSubtitles username and password in the config file\n", ui.Info(ui.Icon(output.IconInfo)))
+	}
+}
+
+func partLanguage(language string, cd int, withLanguage bool) string {
+	return subs.PartLabel(language, cd, withLanguage)
+}
+
+func (c *CLI) saveSubtitle(content []byte, subtitle *models.Subtitle, mediaPath, language string, withLanguage bool) error {
+	_, err := c.storeSubtitle(content, subtitle, mediaPath, language, withLanguage)
+	return err
+}
+
+// storeSubtitle is the save step of the pipeline. It returns the path
+// written, or "" when the subtitle went to stdout or was skipped.
+func (c *CLI) storeSubtitle(content []byte, subtitle *models.Subtitle, mediaPath, language string, withLanguage bool) (string, error) {
+	return c.store(content, subtitle, mediaPath, func(format string) string {
+		return subtitlePath(mediaPath, language, format, withLanguage)
+	})
+}
+
+// store converts and cleans up content, skips it when it duplicates a saved
+// subtitle or the existing target should be kept, writes it to the target
+// for its final format and records the download.
+func (c *CLI) store(content []byte, subtitle *models.Subtitle, mediaPath string, targetFor func(format string) string) (string, error) {
+	if c.Stdout {
+		if c.piped {
+			return "", fmt.Errorf("only one subtitle can be written to stdout")
+		}
+		if _, err := c.resultsWriter().Write(content); err != nil {
+			return "", fmt.Errorf("failed to write subtitle to stdout: %w", err)
+		}
+		c.piped = true
+		return "", nil
+	}
+
+	ui := c.ui()
+	content = c.postProcess(c.convertMicroDVD(content, subtitle))
+	if match := c.identicalSubtitle(content, mediaPath); match != "" {
+		ui.Printf("    %s Skipping %s subtitle: identical to %s\n", ui.Info(ui.Icon(output.IconInfo)), subtitle.Language, match)
+		c.summary.Identical++
+		return "", nil
+	}
+
+	format := subformat.Resolve(content, subtitle.FileName, subtitle.SubFormat)
+	target := targetFor(format)
+	if c.keepExisting(target, mediaPath, subtitle) {
+		return "", nil
+	}
+
+	subtitle.SubFormat = format
+	if err := writeSubtitleAt(content, format, target, c.writeOptions(mediaPath)); err != nil {
+		return "", err
+	}
+
+	ui.Printf("    %s %s %s\n", ui.Icon(output.IconSaved), ui.Success("Saved"), target)
+	c.summary.Saved++
+	c.rememberSaved(mediaPath, target)
+	c.recordDownload(subtitle, mediaPath, target)
+	c.reportDownload(subtitle, mediaPath, target)
+	return target, nil
+}
+
+func (c *CLI) loadFeedback() {
+	path, err := config.FeedbackPath()
+	if err == nil {
+		c.feedback, err = feedback.Load(path)
+	}
+	if err != nil {
+		ui := c.ui()
+		ui.Printf("%s Ignoring subtitle ratings: %v\n", ui.Warning(ui.Icon(output.IconWarning)), err)
+		return
+	}
+	c.feedbackPath = path
+}
+
+func (c *CLI) recordDownload(subtitle *models.Subtitle, mediaPath, target string) {
+	if c.feedbackPath == "" || c.DryRun {
+		return
+	}
+	err := feedback.Update(c.feedbackPath, func(store *feedback.Store) error {
+		download := feedback.NewDownload(subtitle, target)
+		download.Score = c.scorer(mediaPath).Score(subtitle)
+		store.Record(mediaPath, download)
+		return nil
+	})
+	if err != nil {
+		ui := c.ui()
+		ui.Printf("    %s Could not remember this download for 'subs rate': %v\n", ui.Warning(ui.Icon(output.IconWarning)), err)
+	}
+}
+
+func (c *CLI) recordWanted(mediaPath, language, reason string) {
+	if c.feedbackPath == "" || c.DryRun {
+		return
+	}
+	err := feedback.Update(c.feedbackPath, func(store *feedback.Store) error {
+		store.Want(mediaPath, language, reason)
+		return nil
+	})
+	if err != nil {
+		ui := c.ui()
+		ui.Printf("    %s Could not add the file to the wanted list: %v\n", ui.Warning(ui.Icon(output.IconWarning)), err)
+	}
+}
+
+func (c *CLI) writeOptions(mediaPath string) fsutil.WriteOptions {
+	opts := fsutil.WriteOptions{Perm: 0644, Backup: c.Backup}
+	perms := c.loadedConfig().Output.Permissions
+	if perms.MatchMedia {
+		opts = fsutil.MatchFile(mediaPath, opts)
+	}
+
+	if perms.UID != nil || perms.GID != nil {
+		if opts.Owner == nil {
+			opts.Owner = &fsutil.Owner{UID: -1, GID: -1}
+		}
+		opts.Owner.Required = true
+		if perms.UID != nil {
+			opts.Owner.UID = *perms.UID
+		}
+		if perms.GID != nil {
+			opts.Owner.GID = *perms.GID
+		}
+	}
+
+	if umask, err := perms.UmaskMode(); err == nil {
+		opts.Perm &^= umask
+	}
+	opts.Warn = func(err error) {
+		ui := c.ui()
+		ui.Printf("    %s %v\n", ui.Warning(ui.Icon(output.IconWarning)), err)
+	}
+
+	if c.share {
+		shares := c.loadedConfig().Shares
+		opts.Retries = shares.Retries()
+		opts.RetryDelay, _ = shares.RetryDelayDuration()
+		if !shares.Chown {
+			opts.Owner = nil
+		}
+	}
+	return opts
+}
+
+func writeSubtitleFile(content []byte, subtitle *models.Subtitle, mediaPath, language string, withLanguage bool, opts fsutil.WriteOptions) (string, error) {
+	subtitle.SubFormat = subformat.Resolve(content, subtitle.FileName, subtitle.SubFormat)
+	target := subtitlePath(mediaPath, language, subtitle.SubFormat, withLanguage)
+	if err := writeSubtitleAt(content, subtitle.SubFormat, target, opts); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+func writeSubtitleAt(content []byte, format, target string, opts fsutil.WriteOptions) error {
+	if format == subformat.IDX {
+		if idx, sub, ok := subformat.VobSubPair(content); ok {
+			stream := strings.TrimSuffix(target, filepath.Ext(target)) + "." + subformat.SUB
+			if err := fsutil.WriteFile(stream, sub, opts); err != nil {
+				return fmt.Errorf("failed to write subtitle file: %w", err)
+			}
+			content = idx
+		}
+	}
+
+	if err := fsutil.WriteFile(target, content, opts); err != nil {
+		return fmt.Errorf("failed to write subtitle file: %w", err)
+	}
+	return nil
+}
+
+func (c *CLI) progressEnabled() bool {
+	return !c.Quiet && !c.JSON && output.IsTerminal(os.Stdout)
+}
+
+func (c *CLI) createSearchParams(mediaInfo *models.MediaInfo) *models.SearchParams {
+	filters := c.filterOptions()
+	return subs.SearchParams(mediaInfo, filters.OrderBy, filters.TrustedOnly)
 }
 
 func (c *CLI) displaySubtitleList(subtitles []*models.Subtitle) {
-	fmt.Printf("\n  📺 Available Subtitles:\n")
-	fmt.Printf("  %-4s %-8s %-40s %-15s %-8s %-10s\n",
+	ui := c.ui()
+	ui.Printf("\n  %s %s\n", ui.Icon(output.IconList), ui.Bold("Available Subtitles:"))
+	ui.Printf("  %-4s %-8s %-40s %-15s %-8s %-10s\n",
 		"#", "Language", "Release Name", "Uploader", "Rating", "Downloads")
-	fmt.Printf("  %s\n", strings.Repeat("-", 85))
+	ui.Printf("  %s\n", strings.Repeat("-", 85))
 	
 	for i, subtitle := range subtitles {
 		releaseName := subtitle.ReleaseName
@@ -492,7 +2120,7 @@ func (c *CLI) displaySubtitleList(subtitles []*models.Subtitle) {
 			downloadsStr = fmt.Sprintf("%.1fk", float64(subtitle.Downloads)/1000)
 		}
 		
-		fmt.Printf("  %-4d %-8s %-40s %-15s %-8s %-10s\n",
+		ui.Printf("  %-4d %-8s %-40s %-15s %-8s %-10s\n",
 			i+1,
 			subtitle.Language,
 			releaseName,
@@ -502,22 +2130,37 @@ func (c *CLI) displaySubtitleList(subtitles []*models.Subtitle) {
 	}
 	
 	if c.DryRun {
-		fmt.Printf("\n  💡 Dry run mode: no files downloaded. Use without --dry-run to download subtitles.\n")
-	} else {
-		fmt.Printf("\n  💾 Ready to download. (Download functionality will be implemented next.)\n")
+		ui.Printf("\n  %s Dry run mode: no files downloaded. Use without --dry-run to download subtitles.\n", ui.Icon(output.IconTip))
 	}
 }
 
 func (c *CLI) truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen-3] + "..."
+	return truncate(s, maxLen)
+}
+
+type App struct {
+	Get        CLI           `cmd:"" default:"withargs" help:"Find and download subtitles for a media file or directory (default command)."`
+	Completion CompletionCmd `cmd:"" help:"Generate shell completion scripts for bash, zsh, fish or powershell."`
+	Man        ManCmd        `cmd:"" help:"Print the subs(1) manual page in roff format."`
+	Config     ConfigCmd     `cmd:"" help:"Manage the subs-cli configuration file."`
+	Languages  LanguagesCmd  `cmd:"" help:"List subtitle languages supported by the configured providers."`
+	TUI        TUICmd        `cmd:"" name:"tui" help:"Open the full-screen terminal interface for browsing, previewing and downloading subtitles."`
+	Apply      ApplyCmd      `cmd:"" help:"Download the subtitles listed in a plan file written by --dry-run --plan."`
+	Upload     UploadCmd     `cmd:"" help:"Upload a subtitle for a video to OpenSubtitles."`
+	Rate       RateCmd       `cmd:"" help:"Rate the subtitle downloaded for a video so future picks improve."`
+	Stats      StatsCmd      `cmd:"" help:"Show download statistics per provider, language and month, top shows, quota use and success rates."`
+	Wanted     WantedCmd     `cmd:"" help:"List, add and remove videos waiting for an acceptable subtitle."`
+	Clean      CleanCmd      `cmd:"" help:"Remove or move subtitles whose video is gone, and extra copies of a subtitle saved in several formats."`
+	Lint       LintCmd       `cmd:"" help:"Check SubRip subtitles for overlapping cues, fast reading speeds, long lines, empty cues and invalid timecodes."`
+	Sync       SyncCmd       `cmd:"" help:"Shift the timing of existing subtitle files or convert it to another frame rate."`
+	Play       PlayCmd       `cmd:"" help:"Fetch the best subtitle for a video to a temporary file and open the video with it in mpv, VLC or another player."`
+	Providers  ProvidersCmd  `cmd:"" help:"List, enable, disable and test subtitle providers."`
+	Complete   CompleteCmd   `cmd:"" name:"__complete" hidden:"" help:"Print dynamic completion values."`
 }
 
 func Execute() {
-	cli := CLI{}
-	ctx := kong.Parse(&cli,
+	app := App{}
+	ctx := kong.Parse(&app,
 		kong.Name("subs"),
 		kong.Description("A powerful CLI tool for automatically finding and downloading subtitles for your media files.\n\n"+
 			"Examples:\n"+
@@ -526,16 +2169,28 @@ func Execute() {
 			"  subs . -i -l es                           # Interactive mode with Spanish subtitles\n"+
 			"  subs --search \"Breaking Bad S01E01\"        # Manual search query\n"+
 			"  subs /path/to/series/ --dry-run           # Preview mode without downloading\n"+
-			"  subs -c ~/.config/subs.yaml /movies/      # Use custom config file\n\n"+
+			"  subs /series/ --dry-run --plan plan.json  # Save a reviewable plan, then: subs apply plan.json\n"+
+			"  subs -c ~/.config/subs.yaml /movies/      # Use custom config file\n"+
+			"  subs completion bash > /etc/bash_completion.d/subs  # Install shell completion\n\n"+
 			"Supported languages: en, es, pt-BR, fr, de, it, ru, ja, ko, zh, and many more.\n"+
 			"Use standard ISO 639-1 codes (en) or locale codes (pt-BR, zh-CN)."),
 		kong.UsageOnError(),
+		kong.Help(printHelp),
 		kong.ConfigureHelp(kong.HelpOptions{
 			Compact: false,
 			Summary: false,
 		}),
 	)
 
-	err := cli.Run()
+	if hint := shadowedPath(ctx.Model, os.Args[1:]); hint != "" {
+		fmt.Fprintln(os.Stderr, hint)
+	}
+
+	runCtx, stop := notifyContext()
+	defer stop()
+	app.Get.ctx = runCtx
+	ctx.BindTo(runCtx, (*context.Context)(nil))
+
+	err := ctx.Run()
 	ctx.FatalIfErrorf(err)
 }
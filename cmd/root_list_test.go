@@ -1,11 +1,30 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/feedback"
+	"github.com/carlosarraes/subs-cli/internal/fsutil"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/probe"
 	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/carlosarraes/subs-cli/pkg/subs"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDisplaySubtitleList(t *testing.T) {
@@ -139,6 +158,16 @@ func TestCreateSearchParams(t *testing.T) {
 		assert.Equal(t, "movie", params.Type)
 		assert.Equal(t, 0, params.Year)
 	})
+
+	t.Run("passes ordering and trusted filter", func(t *testing.T) {
+		t.Parallel()
+
+		cli := &CLI{OrderBy: "Rating", TrustedOnly: true}
+		params := cli.createSearchParams(&models.MediaInfo{Title: "Inception", Type: "movie"})
+
+		assert.Equal(t, "rating", params.OrderBy)
+		assert.True(t, params.TrustedOnly)
+	})
 }
 
 func TestTruncateString(t *testing.T) {
@@ -188,3 +217,804 @@ func TestTruncateString(t *testing.T) {
 		})
 	}
 }
+
+func TestSubtitlePath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		mediaPath string
+		language  string
+		format    string
+		plain     bool
+		expected  string
+	}{
+		{
+			name:      "replaces media extension",
+			mediaPath: "/media/The.Office.S03E07.mkv",
+			language:  "en",
+			format:    "srt",
+			expected:  "/media/The.Office.S03E07.en.srt",
+		},
+		{
+			name:      "defaults to srt",
+			mediaPath: "/media/Inception.2010.mp4",
+			language:  "pt-BR",
+			expected:  "/media/Inception.2010.pt-BR.srt",
+		},
+		{
+			name:      "plain naming omits language",
+			mediaPath: "/media/Inception.2010.mp4",
+			language:  "en",
+			format:    "srt",
+			plain:     true,
+			expected:  "/media/Inception.2010.srt",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, subtitlePath(tt.mediaPath, tt.language, tt.format, !tt.plain))
+		})
+	}
+}
+
+func TestProgressEnabled(t *testing.T) {
+	t.Parallel()
+
+	cli := &CLI{Quiet: true}
+	assert.False(t, cli.progressEnabled())
+	assert.False(t, (&CLI{JSON: true}).progressEnabled(), "JSON output never mixes with progress bars")
+}
+
+func TestNoEmojiOutput(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	cli := &CLI{DryRun: true, NoEmoji: true}
+	cli.out = output.New(&buf, output.Options{NoColor: true, NoEmoji: true})
+
+	cli.displaySubtitleList([]*models.Subtitle{
+		{ID: "1", Language: "en", ReleaseName: "Inception.2010.1080p", Uploader: "someone", Downloads: 10},
+	})
+	cli.displayMediaInfo(&models.MediaInfo{Title: "Inception", Year: "2010", Type: "movie"})
+
+	for _, ch := range buf.String() {
+		assert.Less(t, ch, rune(128), "unexpected non-ASCII character %q", ch)
+	}
+	assert.Contains(t, buf.String(), "[*] Available Subtitles:")
+	assert.Contains(t, buf.String(), "[ok] Parsed successfully:")
+}
+
+func TestDownloadSubtitleDetectsFormat(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/login":
+			json.NewEncoder(w).Encode(map[string]interface{}{"token": "test-token", "status": 200})
+		case "/download":
+			json.NewEncoder(w).Encode(map[string]interface{}{"link": "http://" + r.Host + "/file"})
+		case "/file":
+			w.Header().Set("Content-Type", "text/vtt")
+			w.Write([]byte("WEBVTT\n\n00:00:01.000 --> 00:00:02.000\nHello\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	mediaPath := filepath.Join(dir, "Movie.2020.mkv")
+	subtitle := &models.Subtitle{FileID: "1", FileName: "Movie.2020.srt", SubFormat: "srt"}
+
+	var buf bytes.Buffer
+	cli := &CLI{Quiet: true}
+	cli.out = output.New(&buf, output.Options{NoColor: true})
+
+	client := api.NewOpenSubtitlesClient(&api.Config{BaseURL: server.URL, Username: "u", Password: "p"})
+	err := cli.downloadSubtitle(context.Background(), client, subtitle, mediaPath, "en", true)
+
+	require.NoError(t, err)
+	assert.Equal(t, "vtt", subtitle.SubFormat)
+	assert.FileExists(t, filepath.Join(dir, "Movie.2020.en.vtt"))
+	assert.NoFileExists(t, filepath.Join(dir, "Movie.2020.en.srt"))
+}
+
+func TestWriteSubtitleFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	mediaPath := filepath.Join(dir, "Movie.mkv")
+	existing := filepath.Join(dir, "Movie.en.srt")
+	require.NoError(t, os.WriteFile(existing, []byte("old"), 0644))
+
+	content := []byte("1\n00:00:01,000 --> 00:00:02,000\nNew\n")
+	target, err := writeSubtitleFile(content, &models.Subtitle{}, mediaPath, "en", true, (&CLI{Backup: true}).writeOptions(mediaPath))
+	require.NoError(t, err)
+	assert.Equal(t, existing, target)
+
+	data, err := os.ReadFile(existing)
+	require.NoError(t, err)
+	assert.Equal(t, string(content), string(data))
+
+	backup, err := os.ReadFile(existing + ".bak")
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(backup))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "only the subtitle and its backup remain")
+}
+
+func TestWriteOptions(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	media := filepath.Join(dir, "Movie.mkv")
+	require.NoError(t, os.WriteFile(media, nil, 0660))
+	require.NoError(t, os.Chmod(media, 0660))
+
+	cli := &CLI{}
+	assert.Equal(t, os.FileMode(0644), cli.writeOptions(media).Perm)
+	assert.Nil(t, cli.writeOptions(media).Owner)
+
+	cfg := config.Default()
+	cfg.Output.Permissions = config.PermissionsConfig{MatchMedia: true, Umask: "027"}
+	cli = &CLI{cfg: cfg}
+	assert.Equal(t, os.FileMode(0640), cli.writeOptions(media).Perm)
+
+	gid := 1234
+	cfg = config.Default()
+	cfg.Output.Permissions = config.PermissionsConfig{GID: &gid}
+	cli = &CLI{cfg: cfg}
+	assert.Equal(t, &fsutil.Owner{UID: -1, GID: 1234, Required: true}, cli.writeOptions(media).Owner)
+}
+
+func TestSaveSubtitle_Stdout(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	media := filepath.Join(dir, "Movie.mkv")
+	var stdout bytes.Buffer
+	cli := &CLI{Stdout: true, stdout: &stdout}
+
+	require.NoError(t, cli.saveSubtitle([]byte("1\n00:00:01,000 --> 00:00:02,000\nHi\n"), &models.Subtitle{}, media, "en", true))
+	assert.Equal(t, "1\n00:00:01,000 --> 00:00:02,000\nHi\n", stdout.String())
+	assert.True(t, cli.piped)
+	assert.ErrorContains(t, cli.saveSubtitle([]byte("again"), &models.Subtitle{}, media, "en", true), "only one subtitle")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "nothing is written next to the video")
+
+	cli.ui().Printf("hidden\n")
+	assert.NotContains(t, stdout.String(), "hidden")
+}
+
+func TestShareOptions(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	media := filepath.Join(dir, "Movie.mkv")
+	require.NoError(t, os.WriteFile(media, nil, 0644))
+
+	gid := 1234
+	cfg := config.Default()
+	cfg.Output.Permissions = config.PermissionsConfig{GID: &gid}
+	cli := &CLI{cfg: cfg, Path: dir}
+	cli.detectShare()
+	assert.False(t, cli.share)
+	assert.Zero(t, cli.writeOptions(media).Retries)
+	assert.Zero(t, cli.scanOptions().StatBatch)
+
+	cli.NetworkShare = true
+	cli.detectShare()
+	require.True(t, cli.share)
+	opts := cli.writeOptions(media)
+	assert.Equal(t, config.DefaultWriteRetries, opts.Retries)
+	assert.Equal(t, config.DefaultRetryDelay, opts.RetryDelay)
+	assert.Nil(t, opts.Owner, "chown is skipped on shares unless enabled")
+	scanOpts := cli.scanOptions()
+	assert.Equal(t, config.DefaultStatBatch, scanOpts.StatBatch)
+	assert.Equal(t, config.DefaultStatPause, scanOpts.StatPause)
+	assert.Equal(t, config.DefaultWriteRetries, scanOpts.Retries)
+
+	cfg.Shares = config.SharesConfig{Detect: config.ShareAlways, Chown: true, WriteRetries: 1}
+	cli = &CLI{cfg: cfg, Path: dir}
+	cli.detectShare()
+	require.True(t, cli.share)
+	assert.Equal(t, 1, cli.writeOptions(media).Retries)
+	assert.Equal(t, &fsutil.Owner{UID: -1, GID: 1234, Required: true}, cli.writeOptions(media).Owner)
+}
+
+func TestDownloadSubtitleParts(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/login":
+			json.NewEncoder(w).Encode(map[string]interface{}{"token": "test-token", "status": 200})
+		case "/download":
+			var req api.DownloadRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			json.NewEncoder(w).Encode(map[string]interface{}{"link": fmt.Sprintf("http://%s/file/%d", r.Host, req.FileID)})
+		case "/file/1":
+			w.Write([]byte("1\n00:00:01,000 --> 00:00:02,000\nPart one\n"))
+		case "/file/2":
+			w.Write([]byte("1\n00:00:03,000 --> 00:00:04,000\nPart two\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	subtitle := func() *models.Subtitle {
+		return &models.Subtitle{
+			FileID:    "1",
+			SubFormat: "srt",
+			Parts: []models.SubtitlePart{
+				{FileID: "1", FileName: "Movie.CD1.srt", CD: 1},
+				{FileID: "2", FileName: "Movie.CD2.srt", CD: 2},
+			},
+		}
+	}
+
+	download := func(t *testing.T, cli *CLI, withLanguage bool) string {
+		dir := t.TempDir()
+		cli.Quiet = true
+		cli.out = output.New(&bytes.Buffer{}, output.Options{NoColor: true})
+
+		client := api.NewOpenSubtitlesClient(&api.Config{BaseURL: server.URL, Username: "u", Password: "p"})
+		err := cli.getSubtitle(context.Background(), client, subs.Request{MediaPath: filepath.Join(dir, "Movie.avi"), Language: "en", Label: "en", WithLanguage: withLanguage, Subtitle: subtitle()})
+		require.NoError(t, err)
+		return dir
+	}
+
+	t.Run("separate_files", func(t *testing.T) {
+		t.Parallel()
+
+		dir := download(t, &CLI{}, true)
+		assert.FileExists(t, filepath.Join(dir, "Movie.en.cd1.srt"))
+		assert.FileExists(t, filepath.Join(dir, "Movie.en.cd2.srt"))
+
+		dir = download(t, &CLI{}, false)
+		assert.FileExists(t, filepath.Join(dir, "Movie.cd1.srt"))
+		assert.FileExists(t, filepath.Join(dir, "Movie.cd2.srt"))
+	})
+
+	t.Run("merged", func(t *testing.T) {
+		t.Parallel()
+
+		dir := download(t, &CLI{MergeCDs: true, CDDurations: []time.Duration{time.Hour}}, true)
+		content, err := os.ReadFile(filepath.Join(dir, "Movie.en.srt"))
+		require.NoError(t, err)
+		assert.Equal(t, "1\n00:00:01,000 --> 00:00:02,000\nPart one\n\n2\n01:00:03,000 --> 01:00:04,000\nPart two\n\n", string(content))
+		assert.NoFileExists(t, filepath.Join(dir, "Movie.en.cd1.srt"))
+	})
+}
+
+func TestValidateFilters(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no filters", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := (&CLI{}).validateFilters()
+		require.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("describes active filters", func(t *testing.T) {
+		t.Parallel()
+
+		cli := &CLI{MinRating: 7.5, TrustedOnly: true, OrderBy: "date"}
+		result, err := cli.validateFilters()
+
+		require.NoError(t, err)
+		assert.Equal(t, "Result filters: rating >= 7.5, trusted uploaders only, ordered by date", result.Message)
+	})
+
+	t.Run("rejects unknown order", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := (&CLI{OrderBy: "size"}).validateFilters()
+		assert.ErrorContains(t, err, "--order-by must be one of downloads, rating, date")
+	})
+}
+
+func TestRankSubtitles(t *testing.T) {
+	t.Parallel()
+
+	subtitles := []*models.Subtitle{
+		{ID: "newest", FileID: "1", Downloads: 10},
+		{ID: "no-file", Downloads: 5000},
+		{ID: "popular", FileID: "2", Downloads: 900},
+		{ID: "middle", FileID: "3", Downloads: 300},
+	}
+
+	ids := func(subs []*models.Subtitle) []string {
+		var out []string
+		for _, sub := range subs {
+			out = append(out, sub.ID)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"popular", "middle", "newest"}, ids((&CLI{}).rankSubtitles(subtitles, "")))
+	assert.Equal(t, []string{"newest", "popular", "middle"}, ids((&CLI{OrderBy: "date"}).rankSubtitles(subtitles, "")))
+	assert.Equal(t, "newest", subtitles[0].ID, "input order is left untouched")
+}
+
+func TestRankSubtitles_Scoring(t *testing.T) {
+	t.Parallel()
+
+	subtitles := []*models.Subtitle{
+		{ID: "cam", FileID: "1", Downloads: 5000, ReleaseName: "Movie.2023.HDCAM.x264-FAST"},
+		{ID: "popular", FileID: "2", Downloads: 900, ReleaseName: "Movie.2023.1080p.WEB-DL.x264-OTHER"},
+		{ID: "same-group", FileID: "3", Downloads: 10, ReleaseName: "Movie.2023.1080p.BluRay.x264-SPARKS"},
+		{ID: "avoided", FileID: "4", Downloads: 800, ReleaseName: "Movie.2023.1080p.WEB-DL.x264-YIFY"},
+	}
+
+	ids := func(subs []*models.Subtitle) []string {
+		var out []string
+		for _, sub := range subs {
+			out = append(out, sub.ID)
+		}
+		return out
+	}
+
+	cfg := config.Default()
+	cfg.Scoring.AvoidGroups = []string{"yify"}
+	cli := &CLI{cfg: cfg}
+
+	assert.Equal(t, []string{"same-group", "popular", "avoided", "cam"},
+		ids(cli.rankSubtitles(subtitles, "/movies/Movie.2023.1080p.BluRay.x264-SPARKS.mkv")))
+	assert.Equal(t, []string{"popular", "same-group", "cam", "avoided"}, ids(cli.rankSubtitles(subtitles, "")),
+		"avoided groups and sources still apply without a local release")
+
+	cli = &CLI{cfg: cfg, OrderBy: "downloads"}
+	assert.Equal(t, []string{"cam", "popular", "same-group", "avoided"},
+		ids(cli.rankSubtitles(subtitles, "/movies/Movie.2023.1080p.BluRay.x264-SPARKS.mkv")), "explicit order skips scoring")
+}
+
+func TestMaxPerLanguage(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 1, (&CLI{}).maxPerLanguage())
+	assert.Equal(t, 3, (&CLI{MaxPerLanguage: 3}).maxPerLanguage())
+
+	assert.Equal(t, "en", indexedLanguage("en", 0))
+	assert.Equal(t, "en.2", indexedLanguage("en", 1))
+	assert.Equal(t, "pt-BR.3", indexedLanguage("pt-BR", 2))
+}
+
+type failingSearchClient struct {
+	fakeSubtitleClient
+	err error
+}
+
+func (f *failingSearchClient) Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	return nil, f.err
+}
+
+func TestSearchLanguagesPartialResults(t *testing.T) {
+	t.Parallel()
+
+	working := &fakeSubtitleClient{results: map[string][]*models.Subtitle{
+		"Movie/en": {{ID: "1", FileID: "1", Provider: "working", ReleaseName: "Movie.2020"}},
+	}}
+	searcher := api.NewMultiClient(time.Second,
+		api.Provider{Name: "working", Client: working},
+		api.Provider{Name: "slow", Client: &failingSearchClient{err: context.DeadlineExceeded}},
+	)
+
+	var buf bytes.Buffer
+	cli := &CLI{}
+	cli.out = output.New(&buf, output.Options{NoColor: true, NoEmoji: true})
+
+	outcome := cli.searchLanguages(context.Background(), searcher, &models.SearchParams{Query: "Movie"}, []string{"en"})
+
+	require.Len(t, outcome.all, 1)
+	assert.Contains(t, buf.String(), "Showing en results from the providers that answered: partial results: slow timed out")
+	assert.Contains(t, buf.String(), "Found 1 en subtitle(s)")
+}
+
+func TestSearchLanguagesKeepsCallerParams(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeSubtitleClient{results: map[string][]*models.Subtitle{
+		"Movie/en": {{ID: "1", FileID: "1", ReleaseName: "Movie.2020"}},
+	}}
+	cli := &CLI{}
+	cli.out = output.New(io.Discard, output.Options{NoColor: true, NoEmoji: true})
+	params := &models.SearchParams{Query: "Movie"}
+
+	outcome := cli.searchLanguages(context.Background(), client, params, []string{"en", "es"})
+
+	require.Len(t, outcome.all, 1)
+	require.Len(t, client.searches, 2)
+	assert.Equal(t, []string{"en", "es"}, client.searches[0].Languages)
+	assert.Nil(t, params.Languages, "retries and relaxed queries start from the caller's params")
+}
+
+func TestReadFileList(t *testing.T) {
+	t.Parallel()
+
+	paths, err := readFileList(strings.NewReader("/media/A.mkv\r\n\n  \n/media/B C.mp4\n/media/A.mkv\n/media/D.avi"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/media/A.mkv", "/media/B C.mp4", "/media/D.avi"}, paths)
+}
+
+func TestProcessFileList(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	notes := filepath.Join(dir, "notes.txt")
+	require.NoError(t, os.WriteFile(notes, []byte("x"), 0644))
+	list := filepath.Join(dir, "list.txt")
+	require.NoError(t, os.WriteFile(list, []byte(filepath.Join(dir, "missing.mkv")+"\n"+notes+"\n"+dir+"\n"), 0644))
+
+	var buf bytes.Buffer
+	cli := &CLI{FilesFrom: list, Quiet: true, out: output.New(&buf, output.Options{NoColor: true})}
+	require.NoError(t, cli.processFileList(nil))
+
+	assert.Contains(t, buf.String(), "Skipping "+filepath.Join(dir, "missing.mkv"))
+	assert.Contains(t, buf.String(), "Skipping "+notes+": not a supported media file")
+	assert.Contains(t, buf.String(), "No media files found in list from "+list)
+
+	cli = &CLI{FilesFrom: filepath.Join(dir, "absent.txt"), out: output.New(&bytes.Buffer{}, output.Options{NoColor: true})}
+	assert.ErrorContains(t, cli.processFileList(nil), "cannot read file list")
+}
+
+func TestRankByVideo(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	cli := &CLI{video: &probe.Info{FPS: 23.976}, out: output.New(&buf, output.Options{NoColor: true})}
+	outcome := &searchOutcome{candidates: map[string][]*models.Subtitle{
+		"en": {{ID: "pal", FPS: 25}, {ID: "film", FPS: 23.976}},
+		"pt": {{ID: "unknown"}},
+	}}
+
+	cli.rankByVideo(outcome, []string{"en", "pt"})
+	assert.Equal(t, "film", outcome.candidates["en"][0].ID)
+	assert.Equal(t, "pal", outcome.candidates["en"][1].ID)
+	assert.Contains(t, buf.String(), "1 en subtitle(s) were made for a different frame rate than the video (23.976 fps)")
+	assert.NotContains(t, buf.String(), " pt ")
+}
+
+func TestExplainScores(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	cli := &CLI{video: &probe.Info{FPS: 23.976}, out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+	outcome := &searchOutcome{candidates: map[string][]*models.Subtitle{
+		"en": {
+			{ID: "1", ReleaseName: "Movie.2023.1080p.BluRay.x264-SPARKS", MovieHashMatch: true, Downloads: 99, FPS: 23.976},
+			{ID: "2", FileName: "movie.srt"},
+		},
+	}}
+
+	cli.explainScores(outcome, []string{"en", "pt"}, "/movies/Movie.2023.1080p.BluRay.x264-SPARKS.mkv")
+	assert.Contains(t, buf.String(), "Score breakdown (en):")
+	assert.Contains(t, buf.String(), "Movie.2023.1080p.BluRay.x264-SPARKS      score 200 (hash +100, release +40, group +30, downloads +10, fps +20)")
+	assert.Contains(t, buf.String(), "movie.srt                                score 0")
+	assert.NotContains(t, buf.String(), "(pt)")
+	assert.NotContains(t, buf.String(), "--order-by")
+
+	buf.Reset()
+	cli.OrderBy = "date"
+	cli.explainScores(outcome, []string{"en"}, "")
+	assert.Contains(t, buf.String(), "Ranked by --order-by date; scores are shown for reference only.")
+}
+
+func TestApplyMinScore(t *testing.T) {
+	t.Parallel()
+
+	newOutcome := func() *searchOutcome {
+		return &searchOutcome{candidates: map[string][]*models.Subtitle{
+			"en": {
+				{ID: "hash", FileID: "1", MovieHashMatch: true},
+				{ID: "weak", FileID: "2", Downloads: 9},
+			},
+			"pt-BR": {{ID: "poor", FileID: "3", Downloads: 99}},
+		}}
+	}
+	ids := func(subs []*models.Subtitle) []string {
+		var out []string
+		for _, sub := range subs {
+			out = append(out, sub.ID)
+		}
+		return out
+	}
+
+	var buf bytes.Buffer
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feedback.json")
+	media := filepath.Join(dir, "movie.mkv")
+	cli := &CLI{MinScore: 50, feedbackPath: path, out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+
+	outcome := newOutcome()
+	cli.applyMinScore(outcome, []string{"en", "pt-BR"}, media)
+	assert.Equal(t, []string{"hash"}, ids(outcome.candidates["en"]))
+	assert.Empty(t, outcome.candidates["pt-BR"])
+	assert.Contains(t, buf.String(), "No pt-BR subtitle scored at least 50 (best: 10); added the file to the wanted list")
+	assert.NotContains(t, buf.String(), "No en subtitle")
+
+	store, err := feedback.Load(path)
+	require.NoError(t, err)
+	wanted := store.WantedFor(media)
+	require.Len(t, wanted, 1)
+	assert.Equal(t, "pt-BR", wanted[0].Language)
+	assert.Equal(t, "best score 10 is below the minimum of 50", wanted[0].Reason)
+
+	buf.Reset()
+	cli.DryRun = true
+	cli.applyMinScore(newOutcome(), []string{"pt-BR"}, media)
+	assert.Contains(t, buf.String(), "the file would be added to the wanted list")
+
+	buf.Reset()
+	cli.applyMinScore(newOutcome(), []string{"pt-BR"}, "")
+	assert.Contains(t, buf.String(), "nothing will be downloaded")
+
+	outcome = newOutcome()
+	(&CLI{MinScore: 50, Interactive: true}).applyMinScore(outcome, []string{"pt-BR"}, media)
+	assert.Len(t, outcome.candidates["pt-BR"], 1, "interactive mode lets the user judge")
+
+	cfg := config.Default()
+	cfg.Scoring.MinScore = 5
+	outcome = newOutcome()
+	(&CLI{cfg: cfg}).applyMinScore(outcome, []string{"en", "pt-BR"}, "")
+	assert.Equal(t, []string{"hash", "weak"}, ids(outcome.candidates["en"]))
+	assert.Equal(t, []string{"poor"}, ids(outcome.candidates["pt-BR"]))
+}
+
+func TestCheckLength(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	cli := &CLI{video: &probe.Info{Duration: time.Hour}, out: output.New(&buf, output.Options{NoColor: true})}
+
+	cli.checkLength([]byte("1\n00:55:00,000 --> 00:55:02,000\nBye\n"))
+	assert.Empty(t, buf.String())
+
+	cli.checkLength([]byte("1\n01:20:00,000 --> 01:20:02,000\nBye\n"))
+	assert.Contains(t, buf.String(), "Possibly out of sync: last subtitle at 1:20:02 is after the end of the 1:00:00 video")
+
+	buf.Reset()
+	cli.checkLength([]byte("WEBVTT\n\n00:00.000 --> 00:01.000\nHi\n"))
+	assert.Empty(t, buf.String())
+}
+
+func TestDownloadWithFallback(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/login":
+			json.NewEncoder(w).Encode(map[string]interface{}{"token": "test-token", "status": 200})
+		case "/download":
+			var req api.DownloadRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			json.NewEncoder(w).Encode(map[string]interface{}{"link": fmt.Sprintf("http://%s/file/%d", r.Host, req.FileID)})
+		case "/file/1":
+			w.Write([]byte("1\n01:50:00,000 --> 01:50:02,000\nExtended cut\n"))
+		case "/file/2":
+			w.Write([]byte("1\n00:20:00,000 --> 00:20:02,000\nIncomplete\n"))
+		case "/file/3":
+			w.Write([]byte("1\n00:58:00,000 --> 00:58:02,000\nTheatrical cut\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	download := func(t *testing.T, onMismatch string) (string, string, []*models.Subtitle) {
+		dir := t.TempDir()
+		var buf bytes.Buffer
+		cfg := config.Default()
+		cfg.Probe.OnMismatch = onMismatch
+		cli := &CLI{Quiet: true, cfg: cfg, video: &probe.Info{Duration: time.Hour}, out: output.New(&buf, output.Options{NoColor: true})}
+
+		client := api.NewOpenSubtitlesClient(&api.Config{BaseURL: server.URL, Username: "u", Password: "p"})
+		spares := []*models.Subtitle{{ID: "b", FileID: "2"}, {ID: "c", FileID: "3"}, {ID: "d", FileID: "4"}}
+		err := cli.downloadWithFallback(context.Background(), client, &models.Subtitle{ID: "a", FileID: "1"}, &spares, filepath.Join(dir, "Movie.mkv"), "en", true)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(dir, "Movie.en.srt"))
+		require.NoError(t, err)
+		return string(content), buf.String(), spares
+	}
+
+	t.Run("falls_back_to_fitting_candidate", func(t *testing.T) {
+		t.Parallel()
+
+		content, out, spares := download(t, "")
+		assert.Contains(t, content, "Theatrical cut")
+		assert.Contains(t, out, "Likely made for a different cut, trying the next candidate: last subtitle at 1:50:02 is after the end of the 1:00:00 video")
+		assert.NotContains(t, out, "Possibly out of sync")
+		assert.Len(t, spares, 1)
+	})
+
+	t.Run("warn_only", func(t *testing.T) {
+		t.Parallel()
+
+		content, out, spares := download(t, config.OnMismatchWarn)
+		assert.Contains(t, content, "Extended cut")
+		assert.Contains(t, out, "Possibly out of sync: last subtitle at 1:50:02")
+		assert.Len(t, spares, 3)
+	})
+}
+
+func TestDownloadBilingual(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/login":
+			json.NewEncoder(w).Encode(map[string]interface{}{"token": "test-token", "status": 200})
+		case "/download":
+			var req api.DownloadRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			json.NewEncoder(w).Encode(map[string]interface{}{"link": fmt.Sprintf("http://%s/file/%d", r.Host, req.FileID)})
+		case "/file/1":
+			w.Write([]byte("1\n00:00:01,000 --> 00:00:02,000\nOlá\n"))
+		case "/file/2":
+			w.Write([]byte("1\n00:00:01,000 --> 00:00:02,000\nHello\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	outcome := &searchOutcome{candidates: map[string][]*models.Subtitle{
+		"pt-BR": {{ID: "cd", FileID: "9", Parts: []models.SubtitlePart{{FileID: "9", CD: 1}, {FileID: "10", CD: 2}}}, {ID: "pt", FileID: "1"}},
+		"en":    {{ID: "en", FileID: "2"}},
+	}}
+	client := api.NewOpenSubtitlesClient(&api.Config{BaseURL: server.URL, Username: "u", Password: "p"})
+
+	t.Run("combines_best_matches", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		cli := &CLI{Quiet: true, out: output.New(&bytes.Buffer{}, output.Options{NoColor: true})}
+		require.NoError(t, cli.downloadBilingual(context.Background(), client, outcome, filepath.Join(dir, "Movie.mkv"), []string{"pt-BR", "en"}))
+
+		content, err := os.ReadFile(filepath.Join(dir, "Movie.pt-BR+en.srt"))
+		require.NoError(t, err)
+		assert.Equal(t, "1\n00:00:01,000 --> 00:00:02,000\nOlá\n<font color=\"#ffd75f\"><i>Hello</i></font>\n\n", string(content))
+	})
+
+	t.Run("missing_language", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		dir := t.TempDir()
+		cli := &CLI{Quiet: true, BilingualAs: "ass", out: output.New(&buf, output.Options{NoColor: true})}
+		require.NoError(t, cli.downloadBilingual(context.Background(), client, outcome, filepath.Join(dir, "Movie.mkv"), []string{"pt-BR", "fr"}))
+		assert.Contains(t, buf.String(), "No fr subtitle to combine")
+		assert.NoFileExists(t, filepath.Join(dir, "Movie.pt-BR+fr.ass"))
+	})
+}
+
+func TestBilingualLanguages(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"pt-BR", "en"}, bilingualLanguages("pt-BR+en"))
+	assert.Equal(t, []string{"pt-BR", "en"}, bilingualLanguages(" pt-BR + en "))
+	assert.Nil(t, bilingualLanguages("pt-BR"))
+	assert.Nil(t, bilingualLanguages("pt-BR+"))
+	assert.Nil(t, bilingualLanguages("pt+en+es"))
+}
+
+func newTranslateServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/login":
+			json.NewEncoder(w).Encode(map[string]interface{}{"token": "test-token", "status": 200})
+		case "/download":
+			json.NewEncoder(w).Encode(map[string]interface{}{"link": fmt.Sprintf("http://%s/file", r.Host)})
+		case "/file":
+			w.Write([]byte("1\n00:00:01,000 --> 00:00:02,000\nHello\n"))
+		case "/v2/translate":
+			json.NewEncoder(w).Encode(map[string]interface{}{"translations": []map[string]string{{"text": "Olá"}}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestTranslateMissing(t *testing.T) {
+	t.Parallel()
+
+	server := newTranslateServer(t)
+	dir := t.TempDir()
+
+	var buf bytes.Buffer
+	cfg := config.Default()
+	cfg.Translate = config.TranslateConfig{Backend: "deepl", APIKey: "key", URL: server.URL}
+	cli := &CLI{Quiet: true, Language: []string{"pt-BR", "en"}, Translate: map[string]string{"from": "en"}, cfg: cfg, out: output.New(&buf, output.Options{NoColor: true})}
+	outcome := &searchOutcome{candidates: map[string][]*models.Subtitle{
+		"en": {{ID: "en", FileID: "1", SubFormat: "srt"}},
+	}}
+
+	missing := cli.missingTranslations(outcome, []string{"pt-BR", "en"})
+	require.Equal(t, []string{"pt-BR"}, missing)
+
+	client := api.NewOpenSubtitlesClient(&api.Config{BaseURL: server.URL, Username: "u", Password: "p"})
+	cli.translateMissing(context.Background(), client, nil, &models.SearchParams{}, outcome, filepath.Join(dir, "Movie.mkv"), cfg, missing)
+
+	content, err := os.ReadFile(filepath.Join(dir, "Movie.pt-BR.translated.srt"))
+	require.NoError(t, err)
+	assert.Equal(t, "1\n00:00:01,000 --> 00:00:02,000\nOlá\n\n", string(content))
+	assert.Contains(t, buf.String(), "(machine translated from en)")
+
+	buf.Reset()
+	cli.cfg = config.Default()
+	cli.translateMissing(context.Background(), client, nil, &models.SearchParams{}, outcome, filepath.Join(dir, "Other.mkv"), cli.cfg, missing)
+	assert.Contains(t, buf.String(), "Cannot translate subtitles: no translation backend configured")
+}
+
+func TestTranslation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		translate map[string]string
+		from      string
+		targets   []string
+		errorMsg  string
+	}{
+		{name: "defaults", translate: map[string]string{}, from: "en", targets: []string{"pt-BR", "es"}},
+		{name: "explicit", translate: map[string]string{"from": "es", "to": "pt-br"}, from: "es", targets: []string{"pt-BR"}},
+		{name: "unknown_key", translate: map[string]string{"into": "fr"}, errorMsg: "--translate accepts from=LANG and to=LANG, got 'into'"},
+		{name: "not_requested", translate: map[string]string{"to": "fr"}, errorMsg: "--translate to=fr is not one of the requested languages (pt-BR, es)"},
+		{name: "skips_source", translate: map[string]string{"from": "es"}, from: "es", targets: []string{"pt-BR"}},
+		{name: "itself", translate: map[string]string{"from": "es", "to": "es"}, errorMsg: "--translate cannot translate es into itself"},
+	}
+
+	for _, tt := range tests {
+		cli := &CLI{Language: []string{"pt-BR", "es"}, Translate: tt.translate}
+		from, targets, err := cli.translation()
+		if tt.errorMsg != "" {
+			assert.EqualError(t, err, tt.errorMsg, tt.name)
+			continue
+		}
+		require.NoError(t, err, tt.name)
+		assert.Equal(t, tt.from, from, tt.name)
+		assert.Equal(t, tt.targets, targets, tt.name)
+		assert.Equal(t, []string{"pt-BR", "es"}, cli.Language, tt.name)
+	}
+}
+
+func TestDownloadFailed(t *testing.T) {
+	var buf bytes.Buffer
+	cli := &CLI{out: output.New(&buf, output.Options{NoColor: true})}
+
+	cli.downloadFailed("en", errors.New("connection reset"))
+	assert.False(t, cli.quotaExceeded)
+	assert.Contains(t, buf.String(), "Failed to download en subtitle: connection reset")
+
+	buf.Reset()
+	cli.downloadFailed("en", fmt.Errorf("wrapped: %w", api.ErrAuthFailed))
+	assert.False(t, cli.quotaExceeded)
+	assert.Contains(t, buf.String(), "Check the OpenSubtitles username and password")
+
+	buf.Reset()
+	cli.downloadFailed("pt-BR", fmt.Errorf("download limit exceeded: %w", api.ErrQuotaExceeded))
+	assert.True(t, cli.quotaExceeded)
+	assert.Contains(t, buf.String(), "no more downloads will be attempted")
+}
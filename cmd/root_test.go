@@ -1,13 +1,19 @@
 package cmd
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/lock"
+	"github.com/carlosarraes/subs-cli/internal/output"
 )
 
 func TestValidatePath(t *testing.T) {
@@ -194,7 +200,19 @@ func TestValidateLanguages(t *testing.T) {
 			name:        "three_letter_code",
 			languages:   []string{"eng", "spa"},
 			expectError: false,
-			expected:    []string{"eng", "spa"},
+			expected:    []string{"en", "es"},
+		},
+		{
+			name:        "provider_specific_codes",
+			languages:   []string{"pob", "ger", "pt-br"},
+			expectError: false,
+			expected:    []string{"pt-BR", "de"},
+		},
+		{
+			name:        "unknown_language",
+			languages:   []string{"xx"},
+			expectError: true,
+			errorMsg:    "unknown language code 'xx'",
 		},
 		{
 			name:        "language_with_spaces",
@@ -266,7 +284,7 @@ func TestValidateLanguages(t *testing.T) {
 			name:        "case_insensitive",
 			languages:   []string{"EN", "PT-br", "Es"},
 			expectError: false,
-			expected:    []string{"EN", "PT-br", "Es"},
+			expected:    []string{"en", "pt-BR", "es"},
 		},
 	}
 
@@ -395,6 +413,98 @@ func TestValidateModeConsistency(t *testing.T) {
 			expectError: false,
 			expectMsgs:  []string{},
 		},
+		{
+			name:       "search_mode_with_output_dir",
+			cli:        CLI{Search: "Breaking Bad S01", Path: ".", OutputDir: "subs"},
+			expectMsgs: []string{"Matching subtitles will be saved to 'subs', named after their release"},
+		},
+		{
+			name:        "output_dir_without_search",
+			cli:         CLI{Path: ".", OutputDir: "subs"},
+			expectError: true,
+			errorMsg:    "--output-dir is only used with --search",
+		},
+		{
+			name:        "files_from_stdin_with_confirm",
+			cli:         CLI{Path: ".", FilesFrom: "-", Confirm: true},
+			expectError: true,
+			errorMsg:    "--files-from - reads paths from standard input",
+		},
+		{
+			name:        "files_from_with_search",
+			cli:         CLI{Path: ".", FilesFrom: "list.txt", Search: "Dark"},
+			expectError: true,
+			errorMsg:    "--files-from cannot be combined with --search",
+		},
+		{
+			name:       "files_from_ignores_path",
+			cli:        CLI{Path: "/media", FilesFrom: "-", Confirm: true, Yes: true},
+			expectMsgs: []string{"File list mode enabled: path argument '/media' will be ignored"},
+		},
+		{
+			name:        "invalid_exclude_pattern",
+			cli:         CLI{Path: ".", Exclude: []string{"[sample"}},
+			expectError: true,
+			errorMsg:    "--include/--exclude: invalid pattern '[sample'",
+		},
+		{
+			name:       "exclude_dir_without_recursive",
+			cli:        CLI{Path: ".", ExcludeDir: []string{"Extras"}},
+			expectMsgs: []string{"Directory exclusions ignored: they are only used with --recursive"},
+		},
+		{
+			name:        "bilingual_malformed",
+			cli:         CLI{Path: ".", Bilingual: "pt-BR,en"},
+			expectError: true,
+			errorMsg:    "--bilingual expects two languages joined by '+'",
+		},
+		{
+			name:        "bilingual_same_language",
+			cli:         CLI{Path: ".", Bilingual: "en+en", Language: []string{"en"}},
+			expectError: true,
+			errorMsg:    "--bilingual needs two different languages",
+		},
+		{
+			name:        "bilingual_interactive",
+			cli:         CLI{Path: ".", Bilingual: "pt-BR+en", Language: []string{"pt-BR", "en"}, Interactive: true},
+			expectError: true,
+			errorMsg:    "--bilingual cannot be combined with --interactive",
+		},
+		{
+			name:       "bilingual",
+			cli:        CLI{Path: ".", Bilingual: "pt-BR+en", Language: []string{"pt-BR", "en"}},
+			expectMsgs: []string{"Bilingual mode: the best pt-BR and en subtitles are combined into one srt file"},
+		},
+		{
+			name:        "json_interactive",
+			cli:         CLI{Path: ".", JSON: true, Interactive: true},
+			expectError: true,
+			errorMsg:    "--json cannot be combined with --interactive",
+		},
+		{
+			name:        "format_json",
+			cli:         CLI{Path: ".", JSON: true, Format: "{{.File}}"},
+			expectError: true,
+			errorMsg:    "--format cannot be combined with --json",
+		},
+		{
+			name:        "output_csv_format",
+			cli:         CLI{Path: ".", Output: "csv", Format: "{{.File}}"},
+			expectError: true,
+			errorMsg:    "--output csv cannot be combined with --format",
+		},
+		{
+			name:        "format_invalid",
+			cli:         CLI{Path: ".", Format: "{{.Nope}}"},
+			expectError: true,
+			errorMsg:    "--format: template: format:1:2: executing",
+		},
+		{
+			name:        "plan_without_dry_run",
+			cli:         CLI{Path: ".", Plan: "plan.json"},
+			expectError: true,
+			errorMsg:    "--plan can only be used together with --dry-run",
+		},
 		{
 			name: "empty_search_query",
 			cli: CLI{
@@ -435,6 +545,61 @@ func TestValidateModeConsistency(t *testing.T) {
 				"Dry run mode: no files will be downloaded, only preview what would happen",
 			},
 		},
+		{
+			name:       "max_per_language",
+			cli:        CLI{MaxPerLanguage: 3},
+			expectMsgs: []string{"Multiple downloads: up to 3 subtitles per language"},
+		},
+		{
+			name:       "max_per_language_interactive",
+			cli:        CLI{MaxPerLanguage: 3, Interactive: true},
+			expectMsgs: []string{"Multiple downloads ignored: interactive mode downloads the subtitle you pick"},
+		},
+		{
+			name:        "negative_max_per_language",
+			cli:         CLI{MaxPerLanguage: -1},
+			expectError: true,
+			errorMsg:    "--max-per-language must be at least 1",
+		},
+		{
+			name:       "cd_durations_without_merge",
+			cli:        CLI{CDDurations: []time.Duration{time.Hour}},
+			expectMsgs: []string{"CD durations ignored: they are only used with --merge-cds"},
+		},
+		{
+			name: "stdout_single_file",
+			cli:  CLI{Path: "movie.mkv", Language: []string{"en"}, Stdout: true, MaxPerLanguage: 1},
+		},
+		{
+			name:        "stdout_directory",
+			cli:         CLI{Path: ".", Language: []string{"en"}, Stdout: true},
+			expectError: true,
+			errorMsg:    "--stdout needs a single media file, got directory",
+		},
+		{
+			name:        "stdout_search",
+			cli:         CLI{Search: "Dark", Path: ".", Language: []string{"en"}, Stdout: true},
+			expectError: true,
+			errorMsg:    "--stdout needs a single media file, not --search",
+		},
+		{
+			name:        "stdout_two_languages",
+			cli:         CLI{Path: "movie.mkv", Language: []string{"en", "es"}, Stdout: true},
+			expectError: true,
+			errorMsg:    "--stdout needs exactly one language, got 2",
+		},
+		{
+			name:        "stdout_interactive",
+			cli:         CLI{Path: "movie.mkv", Language: []string{"en"}, Stdout: true, Interactive: true},
+			expectError: true,
+			errorMsg:    "--stdout cannot be combined with --interactive",
+		},
+		{
+			name:        "stdout_confirm",
+			cli:         CLI{Path: "movie.mkv", Language: []string{"en"}, Stdout: true, Confirm: true},
+			expectError: true,
+			errorMsg:    "--stdout cannot be combined with --confirm (add --yes)",
+		},
 		{
 			name:        "normal_mode_no_flags",
 			cli:         CLI{},
@@ -449,6 +614,9 @@ func TestValidateModeConsistency(t *testing.T) {
 			t.Parallel()
 
 			cli := tt.cli
+			if cli.MaxPerLanguage == 0 {
+				cli.MaxPerLanguage = 1 // kong's default; an explicit 0 is covered by TestValidateMaxPerLanguage
+			}
 			result, err := cli.validateModeConsistency()
 
 			if tt.expectError {
@@ -471,6 +639,40 @@ func TestValidateModeConsistency(t *testing.T) {
 	}
 }
 
+func TestValidateMaxPerLanguage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		args     []string
+		errorMsg string
+	}{
+		{name: "default", args: nil},
+		{name: "zero", args: []string{"--max-per-language", "0"}, errorMsg: "--max-per-language must be at least 1, got 0"},
+		{name: "negative", args: []string{"--max-per-language=-2"}, errorMsg: "--max-per-language must be at least 1, got -2"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			parser, app := newTestParser(t)
+			_, err := parser.Parse(append([]string{t.TempDir()}, tt.args...))
+			require.NoError(t, err)
+
+			_, err = app.Get.validateModeConsistency()
+			if tt.errorMsg == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, app.Get.MaxPerLanguage)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
+
 func TestIsValidLanguageCode(t *testing.T) {
 	t.Parallel()
 
@@ -526,9 +728,10 @@ func TestValidateArguments(t *testing.T) {
 		require.NoError(t, os.WriteFile(configFile, []byte("test: value"), 0644))
 
 		cli := &CLI{
-			Path:     tmpFile,
-			Language: []string{"en", "pt-BR"},
-			Config:   configFile,
+			Path:           tmpFile,
+			Language:       []string{"en", "pt-BR"},
+			Config:         configFile,
+			MaxPerLanguage: 1,
 		}
 
 		err := cli.validateArguments()
@@ -596,9 +799,10 @@ func TestValidateArguments(t *testing.T) {
 		t.Parallel()
 
 		cli := &CLI{
-			Path:     "/nonexistent/path",
-			Language: []string{"en"},
-			Search:   "Breaking Bad S01E01",
+			Path:           "/nonexistent/path",
+			Language:       []string{"en"},
+			Search:         "Breaking Bad S01E01",
+			MaxPerLanguage: 1,
 		}
 
 		err := cli.validateArguments()
@@ -648,11 +852,46 @@ func TestCLIRun(t *testing.T) {
 
 		tmpDir := t.TempDir()
 		cli := &CLI{
-			Path:     tmpDir,
-			Language: []string{"en"},
+			Path:           tmpDir,
+			Language:       []string{"en"},
+			MaxPerLanguage: 1,
 		}
 
 		err := cli.Run()
 		assert.NoError(t, err)
 	})
 }
+
+func TestFileContext(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+	ctx, cancel := (&CLI{cfg: cfg}).fileContext(cfg)
+	defer cancel()
+	_, hasDeadline := ctx.Deadline()
+	assert.True(t, hasDeadline, "each file gets the file timeout")
+
+	ctx, cancel = (&CLI{cfg: cfg, Interactive: true}).fileContext(cfg)
+	defer cancel()
+	_, hasDeadline = ctx.Deadline()
+	assert.False(t, hasDeadline, "interactive prompts are not cut off by the file timeout")
+	cancel()
+	assert.Error(t, ctx.Err())
+}
+
+func TestAcquireLockWaitMessage(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var buf bytes.Buffer
+	cli := &CLI{WaitLock: 300 * time.Millisecond, out: output.New(&buf, output.Options{NoColor: true})}
+
+	l, err := cli.acquireLock()
+	require.NoError(t, err)
+	assert.Empty(t, buf.String(), "a free lock should be taken without waiting")
+
+	_, err = cli.acquireLock()
+	require.ErrorIs(t, err, lock.ErrLocked)
+	assert.Contains(t, buf.String(), "Waiting up to 300ms for other subs-cli runs to finish...")
+
+	require.NoError(t, l.Release())
+}
@@ -0,0 +1,76 @@
+//go:build windows
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/fsutil"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func TestValidatePath_Windows(t *testing.T) {
+	t.Run("reserved device name", func(t *testing.T) {
+		_, err := (&CLI{Path: `C:\Movies\CON`}).validatePath()
+		assert.ErrorContains(t, err, "'CON' is a reserved device name on Windows")
+
+		_, err = (&CLI{Path: `C:\Movies\nul.mkv`}).validatePath()
+		assert.ErrorContains(t, err, "reserved device name")
+	})
+
+	t.Run("long path", func(t *testing.T) {
+		dir := t.TempDir()
+		for len(dir) < 300 {
+			dir = filepath.Join(dir, strings.Repeat("d", 50))
+		}
+		require.NoError(t, os.MkdirAll(fsutil.LongPath(dir), 0755))
+		file := filepath.Join(dir, "movie.mkv")
+		require.NoError(t, os.WriteFile(fsutil.LongPath(file), []byte("test"), 0644))
+
+		cli := &CLI{Path: file}
+		result, err := cli.validatePath()
+		require.NoError(t, err)
+		assert.Contains(t, result.Message, "File path validated:")
+		assert.Equal(t, file, cli.Path)
+
+		cli = &CLI{Path: fsutil.LongPath(file)}
+		result, err = cli.validatePath()
+		require.NoError(t, err)
+		assert.Contains(t, result.Message, "File path validated:")
+	})
+
+	t.Run("drive-relative path", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "movie.mkv"), []byte("test"), 0644))
+		t.Chdir(dir)
+
+		cli := &CLI{Path: filepath.VolumeName(dir) + "movie.mkv"}
+		_, err := cli.validatePath()
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "movie.mkv"), cli.Path)
+	})
+}
+
+func TestWriteSubtitleFile_Windows(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for len(dir) < 300 {
+		dir = filepath.Join(dir, strings.Repeat("d", 50))
+	}
+	require.NoError(t, os.MkdirAll(fsutil.LongPath(dir), 0755))
+
+	media := filepath.Join(dir, "movie.mkv")
+	target, err := writeSubtitleFile([]byte("1\n00:00:01,000 --> 00:00:02,000\nHi\n"), &models.Subtitle{Language: "en"}, media, "en", true, fsutil.WriteOptions{Perm: 0644})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "movie.en.srt"), target)
+
+	_, err = writeSubtitleFile([]byte("x"), &models.Subtitle{Language: "en"}, filepath.Join(t.TempDir(), "aux"), "", false, fsutil.WriteOptions{Perm: 0644})
+	assert.ErrorContains(t, err, "reserved device name")
+}
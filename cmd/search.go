@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/parser"
+	"github.com/carlosarraes/subs-cli/internal/subformat"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/carlosarraes/subs-cli/pkg/subs"
+)
+
+const searchMediaExt = ".release"
+
+var unsafeNameChars = regexp.MustCompile(`[<>:"/\\|?*\x00-\x1f]+`)
+
+func (c *CLI) processSearch(p *parser.Parser) error {
+	c.ui().Println(c.ui().Bold("\n--- Manual Search ---"))
+	c.Observe(subs.FileStarted{Path: c.Search})
+	c.Observe(subs.FileFinished{Path: c.Search, Err: c.handleSearch(p)})
+	return nil
+}
+
+func (c *CLI) handleSearch(p *parser.Parser) error {
+	dir := c.OutputDir
+	if dir == "" {
+		dir = "."
+	}
+	settings, err := c.settingsFor(filepath.Join(dir, searchMediaExt))
+	if err != nil {
+		return fmt.Errorf("directory override failed: %w", err)
+	}
+
+	client := c.newClient(settings.config)
+	ctx, cancel := c.fileContext(settings.config)
+	defer cancel()
+
+	ui := c.ui()
+	params := c.searchQuery(p)
+	ui.Printf("  %s Query: %s\n", ui.Icon(output.IconInfo), describeQuery(params))
+	if c.Interactive {
+		return c.browseSearch(ctx, client, params, settings.languages, dir)
+	}
+
+	searcher := c.searcher(client, settings.config)
+	outcome := c.searchWithRetry(ctx, searcher, params, settings.languages)
+	c.reportResults(params, outcome, settings.languages)
+
+	if len(outcome.all) == 0 {
+		ui.Printf("  %s %s %s\n", ui.Icon(output.IconFailure), ui.Error("No subtitles found for"), c.Search)
+		return nil
+	}
+	c.displaySubtitleList(outcome.all)
+
+	if c.OutputDir == "" {
+		ui.Printf("\n  %s Add --output-dir DIR to download every match, named after its release.\n", ui.Icon(output.IconTip))
+		return nil
+	}
+	return c.saveMatches(ctx, client, outcome, params.Query, settings.languages)
+}
+
+func (c *CLI) saveMatches(ctx context.Context, client *api.OpenSubtitlesClient, outcome *searchOutcome, query string, languages []string) error {
+	if !c.DryRun {
+		if err := os.MkdirAll(c.OutputDir, 0755); err != nil {
+			return fmt.Errorf("cannot create output directory: %w", err)
+		}
+	}
+
+	for _, language := range languages {
+		seen := make(map[string]bool)
+		for _, subtitle := range outcome.candidates[language] {
+			if c.quotaExceeded || c.interrupted() {
+				return nil
+			}
+			mediaPath := searchMediaPath(c.OutputDir, subtitle, query)
+			if seen[mediaPath] {
+				continue
+			}
+			seen[mediaPath] = true
+			c.saveMatch(ctx, client, subtitle, nil, mediaPath, language)
+		}
+	}
+	return nil
+}
+
+func (c *CLI) saveMatch(ctx context.Context, client *api.OpenSubtitlesClient, subtitle *models.Subtitle, content []byte, mediaPath, language string) {
+	if c.DryRun {
+		if target := subtitlePath(mediaPath, language, subtitle.SubFormat, true); !c.keepExisting(target, mediaPath, subtitle) {
+			ui := c.ui()
+			ui.Printf("    %s Would save %s\n", ui.Icon(output.IconDownload), c.previewTarget(target))
+		}
+		return
+	}
+
+	err := c.getSubtitle(ctx, client, subs.Request{
+		MediaPath:    mediaPath,
+		Language:     language,
+		Label:        language,
+		WithLanguage: true,
+		Subtitle:     subtitle,
+		Content:      content,
+	})
+	if err != nil {
+		c.downloadFailed(language, err)
+	}
+}
+
+func (c *CLI) searchQuery(p *parser.Parser) *models.SearchParams {
+	query := strings.TrimSpace(c.Search)
+	if info, err := p.Parse(query); err == nil {
+		return c.createSearchParams(info)
+	}
+
+	filters := c.filterOptions()
+	params := &models.SearchParams{Query: query, OrderBy: filters.OrderBy, TrustedOnly: filters.TrustedOnly}
+	if title, season, ok := parser.ParseSeasonFolder(query); ok {
+		params.Query, params.Type, params.Season = title, "episode", season
+	}
+	return params
+}
+
+func describeQuery(params *models.SearchParams) string {
+	description := params.Query
+	if params.Year > 0 {
+		description += fmt.Sprintf(" (%d)", params.Year)
+	}
+	switch {
+	case params.Episode > 0:
+		description += fmt.Sprintf(" S%02dE%02d", params.Season, params.Episode)
+	case params.Season > 0:
+		description += fmt.Sprintf(" season %d", params.Season)
+	}
+	return description
+}
+
+func searchMediaPath(dir string, subtitle *models.Subtitle, query string) string {
+	name := subtitle.ReleaseName
+	if name == "" {
+		name = subtitle.FileName
+		if subformat.FromFileName(name) != "" {
+			name = strings.TrimSuffix(name, filepath.Ext(name))
+		}
+	}
+	name = strings.Trim(unsafeNameChars.ReplaceAllString(strings.TrimSpace(name), "."), ". ")
+	if name == "" {
+		name = strings.Trim(unsafeNameChars.ReplaceAllString(query, "."), ". ")
+	}
+	return filepath.Join(dir, name) + searchMediaExt
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/parser"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func TestSearchQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		search string
+		want   models.SearchParams
+		desc   string
+	}{
+		{"Breaking Bad S01E01", models.SearchParams{Query: "Breaking Bad", Type: "episode", Season: 1, Episode: 1}, "Breaking Bad S01E01"},
+		{"Breaking Bad S01", models.SearchParams{Query: "Breaking Bad", Type: "episode", Season: 1}, "Breaking Bad season 1"},
+		{"Breaking Bad Season 2", models.SearchParams{Query: "Breaking Bad", Type: "episode", Season: 2}, "Breaking Bad season 2"},
+		{"Inception 2010", models.SearchParams{Query: "Inception", Type: "movie", Year: 2010}, "Inception (2010)"},
+		{"  Dark  ", models.SearchParams{Query: "Dark"}, "Dark"},
+	}
+
+	for _, tt := range tests {
+		params := (&CLI{Search: tt.search}).searchQuery(parser.New())
+		assert.Equal(t, tt.want, *params, tt.search)
+		assert.Equal(t, tt.desc, describeQuery(params), tt.search)
+	}
+}
+
+func TestSearchMediaPath(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join("out", "subs")
+	tests := []struct {
+		subtitle *models.Subtitle
+		want     string
+	}{
+		{&models.Subtitle{ReleaseName: "Breaking.Bad.S01E01.720p.HDTV.x264-CTU"}, "Breaking.Bad.S01E01.720p.HDTV.x264-CTU"},
+		{&models.Subtitle{ReleaseName: "Breaking Bad: S01E02 / WEB"}, "Breaking Bad. S01E02 . WEB"},
+		{&models.Subtitle{FileName: "Breaking.Bad.S01E03.srt"}, "Breaking.Bad.S01E03"},
+		{&models.Subtitle{ReleaseName: " ... "}, "Breaking Bad S01"},
+	}
+
+	for _, tt := range tests {
+		path := searchMediaPath(dir, tt.subtitle, "Breaking Bad S01")
+		assert.Equal(t, filepath.Join(dir, tt.want)+searchMediaExt, path)
+		assert.Equal(t, filepath.Join(dir, tt.want)+".en.srt", subtitlePath(path, "en", "srt", true))
+	}
+}
+
+func TestSaveMatches(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/login":
+			json.NewEncoder(w).Encode(map[string]interface{}{"token": "test-token", "status": 200})
+		case "/download":
+			var req api.DownloadRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			json.NewEncoder(w).Encode(map[string]interface{}{"link": fmt.Sprintf("http://%s/file/%d", r.Host, req.FileID)})
+		default:
+			fmt.Fprintf(w, "1\n00:00:01,000 --> 00:00:02,000\n%s\n", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	outcome := &searchOutcome{candidates: map[string][]*models.Subtitle{
+		"en": {
+			{FileID: "1", ReleaseName: "Breaking.Bad.S01E01.720p.HDTV.x264-CTU", SubFormat: "srt"},
+			{FileID: "2", ReleaseName: "Breaking.Bad.S01E02.720p.HDTV.x264-CTU", SubFormat: "srt"},
+			{FileID: "3", ReleaseName: "Breaking.Bad.S01E01.720p.HDTV.x264-CTU", SubFormat: "srt"},
+		},
+		"pt-BR": {
+			{FileID: "4", ReleaseName: "Breaking.Bad.S01E01.720p.HDTV.x264-CTU", SubFormat: "srt"},
+		},
+	}}
+	client := api.NewOpenSubtitlesClient(&api.Config{BaseURL: server.URL, Username: "u", Password: "p"})
+
+	t.Run("dry_run", func(t *testing.T) {
+		t.Parallel()
+
+		dir := filepath.Join(t.TempDir(), "subs")
+		var buf bytes.Buffer
+		cli := &CLI{Quiet: true, DryRun: true, OutputDir: dir, out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+
+		require.NoError(t, cli.saveMatches(context.Background(), client, outcome, "Breaking Bad S01", []string{"en", "pt-BR"}))
+		assert.NoDirExists(t, dir)
+		assert.Contains(t, buf.String(), "Would save "+filepath.Join(dir, "Breaking.Bad.S01E02.720p.HDTV.x264-CTU.en.srt"))
+		assert.Contains(t, buf.String(), "Would save "+filepath.Join(dir, "Breaking.Bad.S01E01.720p.HDTV.x264-CTU.pt-BR.srt"))
+	})
+
+	t.Run("download", func(t *testing.T) {
+		t.Parallel()
+
+		dir := filepath.Join(t.TempDir(), "subs")
+		var buf bytes.Buffer
+		cli := &CLI{Quiet: true, OutputDir: dir, out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+
+		require.NoError(t, cli.saveMatches(context.Background(), client, outcome, "Breaking Bad S01", []string{"en", "pt-BR"}))
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		assert.ElementsMatch(t, []string{
+			"Breaking.Bad.S01E01.720p.HDTV.x264-CTU.en.srt",
+			"Breaking.Bad.S01E02.720p.HDTV.x264-CTU.en.srt",
+			"Breaking.Bad.S01E01.720p.HDTV.x264-CTU.pt-BR.srt",
+		}, names)
+
+		content, err := os.ReadFile(filepath.Join(dir, "Breaking.Bad.S01E01.720p.HDTV.x264-CTU.en.srt"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "/file/1", "the best-ranked subtitle for a release is kept")
+	})
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/parser"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+const maxSeasonPages = 10
+
+type seasonPack struct {
+	dir      string
+	title    string
+	season   int
+	episodes int
+	parser   *parser.Parser
+
+	mu      sync.Mutex
+	results map[string][]*models.Subtitle
+	failed  map[string]bool
+}
+
+func (c *CLI) detectSeasonPack(p *parser.Parser, files []string) *seasonPack {
+	if c.NoSeasonPack || c.Search != "" || c.loadedConfig().OpenSubtitles.Backend == config.BackendXMLRPC {
+		return nil
+	}
+	title, season, ok := parser.ParseSeasonFolder(c.Path)
+	if !ok {
+		return nil
+	}
+
+	dir := filepath.Clean(c.Path)
+	episodes := 0
+	for _, file := range files {
+		if filepath.Dir(file) != dir {
+			continue
+		}
+		if info, err := p.Parse(filepath.Base(file)); err == nil && info.HasSeasonEpisode() && info.Season == season {
+			episodes++
+		}
+	}
+	if episodes < 2 {
+		return nil
+	}
+
+	return &seasonPack{
+		dir:      dir,
+		title:    title,
+		season:   season,
+		episodes: episodes,
+		parser:   p,
+		results:  make(map[string][]*models.Subtitle),
+		failed:   make(map[string]bool),
+	}
+}
+
+func (p *seasonPack) covers(params *models.SearchParams) bool {
+	return params.Season == p.season && params.Episode > 0 && params.MediaPath != "" && filepath.Dir(params.MediaPath) == p.dir
+}
+
+func (p *seasonPack) list(ctx context.Context, client *api.OpenSubtitlesClient, params *models.SearchParams, ui *output.Renderer) ([]*models.Subtitle, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if subtitles, ok := p.results[params.Language]; ok {
+		return subtitles, true
+	}
+	if p.failed[params.Language] {
+		return nil, false
+	}
+
+	query := *params
+	query.Query, query.Episode, query.MovieHash, query.MovieByteSize, query.MediaPath = p.title, 0, "", 0, ""
+	query.Languages = nil
+	subtitles, pages, err := client.SearchPages(ctx, &query, maxSeasonPages)
+	if err != nil {
+		p.failed[params.Language] = true
+		ui.Printf("    %s Could not fetch the season's %s subtitles, searching each episode instead: %v\n", ui.Warning(ui.Icon(output.IconWarning)), params.Language, err)
+		return nil, false
+	}
+	ui.Printf("    %s Fetched %d %s subtitle(s) for %s season %d in %d request(s)\n", ui.Info(ui.Icon(output.IconInfo)), len(subtitles), params.Language, p.title, p.season, pages)
+	p.results[params.Language] = subtitles
+	return subtitles, true
+}
+
+func (p *seasonPack) episode(subtitles []*models.Subtitle, episode int) []*models.Subtitle {
+	var matches []*models.Subtitle
+	for _, subtitle := range subtitles {
+		season, number := subtitle.Season, subtitle.Episode
+		if number == 0 {
+			if info, err := p.parser.Parse(subtitle.ReleaseName); err == nil && info.HasSeasonEpisode() {
+				season, number = info.Season, info.Episode
+			}
+		}
+		if number == episode && (season == 0 || season == p.season) {
+			matches = append(matches, subtitle)
+		}
+	}
+	return matches
+}
+
+type seasonSearcher struct {
+	*api.OpenSubtitlesClient
+	pack *seasonPack
+	ui   *output.Renderer
+}
+
+func (c *CLI) seasonClient(client *api.OpenSubtitlesClient) api.Client {
+	if c.season == nil {
+		return client
+	}
+	return &seasonSearcher{OpenSubtitlesClient: client, pack: c.season, ui: c.ui()}
+}
+
+func (s *seasonSearcher) Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	if !s.pack.covers(params) {
+		return s.OpenSubtitlesClient.Search(ctx, params)
+	}
+	season, ok := s.pack.list(ctx, s.OpenSubtitlesClient, params, s.ui)
+	if !ok {
+		return s.OpenSubtitlesClient.Search(ctx, params)
+	}
+	if matches := s.pack.episode(season, params.Episode); len(matches) > 0 {
+		return matches, nil
+	}
+	return s.OpenSubtitlesClient.Search(ctx, params)
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/parser"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func TestDetectSeasonPack(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "The.Office.S03")
+	files := []string{
+		filepath.Join(dir, "The.Office.S03E01.720p.mkv"),
+		filepath.Join(dir, "The.Office.S03E02.720p.mkv"),
+	}
+
+	cli := &CLI{Path: dir, cfg: config.Default()}
+	pack := cli.detectSeasonPack(parser.New(), files)
+	require.NotNil(t, pack)
+	assert.Equal(t, "The Office", pack.title)
+	assert.Equal(t, 3, pack.season)
+	assert.Equal(t, 2, pack.episodes)
+
+	assert.Nil(t, cli.detectSeasonPack(parser.New(), files[:1]), "a single episode is searched on its own")
+	assert.Nil(t, (&CLI{Path: dir, cfg: config.Default(), NoSeasonPack: true}).detectSeasonPack(parser.New(), files))
+	assert.Nil(t, (&CLI{Path: filepath.Dir(dir), cfg: config.Default()}).detectSeasonPack(parser.New(), files))
+
+	xmlrpc := config.Default()
+	xmlrpc.OpenSubtitles.Backend = config.BackendXMLRPC
+	assert.Nil(t, (&CLI{Path: dir, cfg: xmlrpc}).detectSeasonPack(parser.New(), files))
+}
+
+func TestSeasonSearcher(t *testing.T) {
+	t.Parallel()
+
+	var seasonRequests, episodeRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query()
+		var data []interface{}
+		if query.Get("episode_number") == "" {
+			seasonRequests.Add(1)
+			assert.Equal(t, "The Office", query.Get("query"))
+			assert.Empty(t, query.Get("moviehash"))
+			for _, episode := range []int{1, 2} {
+				data = append(data, map[string]interface{}{
+					"id": "season",
+					"attributes": map[string]interface{}{
+						"language":        "en",
+						"release":         "The.Office.S03.720p",
+						"feature_details": map[string]interface{}{"season_number": 3, "episode_number": episode},
+						"files":           []interface{}{map[string]interface{}{"file_id": episode, "file_name": "a.srt"}},
+					},
+				})
+			}
+		} else {
+			episodeRequests.Add(1)
+			data = append(data, map[string]interface{}{
+				"id": "episode",
+				"attributes": map[string]interface{}{
+					"language": "en",
+					"release":  "The.Office.S03E09.720p",
+					"files":    []interface{}{map[string]interface{}{"file_id": 9, "file_name": "a.srt"}},
+				},
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"total_pages": 1, "page": 1, "data": data})
+	}))
+	defer server.Close()
+
+	dir := filepath.Join(t.TempDir(), "The.Office.S03")
+	var buf bytes.Buffer
+	cli := &CLI{
+		cfg: config.Default(),
+		out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true}),
+		season: &seasonPack{
+			dir: dir, title: "The Office", season: 3, parser: parser.New(),
+			results: make(map[string][]*models.Subtitle), failed: make(map[string]bool),
+		},
+	}
+	client := cli.seasonClient(api.NewOpenSubtitlesClient(&api.Config{BaseURL: server.URL, APIKey: "key"}))
+
+	search := func(episode int) []*models.Subtitle {
+		subtitles, err := client.Search(context.Background(), &models.SearchParams{
+			Query: "The Office", Type: "episode", Season: 3, Episode: episode, Language: "en",
+			MovieHash: "abc", MediaPath: filepath.Join(dir, fmt.Sprintf("The.Office.S03E%02d.mkv", episode)),
+		})
+		require.NoError(t, err)
+		return subtitles
+	}
+
+	require.Len(t, search(1), 1)
+	second := search(2)
+	require.Len(t, second, 1)
+	assert.Equal(t, 2, second[0].Episode)
+	assert.Equal(t, int32(1), seasonRequests.Load(), "the season is fetched once per language")
+	assert.Equal(t, int32(0), episodeRequests.Load())
+	assert.Contains(t, buf.String(), "Fetched 2 en subtitle(s) for The Office season 3 in 1 request(s)")
+
+	assert.Equal(t, "episode", search(9)[0].ID, "episodes missing from the season list are searched on their own")
+	assert.Equal(t, int32(1), seasonRequests.Load())
+	assert.Equal(t, int32(1), episodeRequests.Load())
+}
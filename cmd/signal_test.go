@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/parser"
+)
+
+func TestInterrupted(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, (&CLI{}).interrupted())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var buf bytes.Buffer
+	cli := &CLI{ctx: ctx, cfg: config.Default(), out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+	assert.False(t, cli.interrupted())
+
+	cancel()
+	assert.True(t, cli.interrupted())
+	cli.processFiles(parser.New(), []string{"/media/Movie.One.mkv", "/media/Movie.Two.mkv"})
+	assert.Contains(t, buf.String(), "Interrupted; 2 file(s) left for the next run:\n  /media/Movie.One.mkv\n  /media/Movie.Two.mkv\n")
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/feedback"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/parser"
+)
+
+var quotaProviders = []string{api.ProviderOpenSubtitles, api.ProviderOpenSubtitlesXMLRPC}
+
+type StatsCmd struct {
+	JSON    bool `long:"json" help:"Print the statistics as JSON instead of tables."`
+	Top     int  `long:"top" default:"10" help:"How many shows to list under top shows."`
+	NoEmoji bool `long:"no-emoji" help:"Replace emoji status symbols with plain ASCII markers."`
+}
+
+func (s *StatsCmd) Run() error {
+	path, err := config.FeedbackPath()
+	if err != nil {
+		return err
+	}
+	store, err := feedback.Load(path)
+	if err != nil {
+		return err
+	}
+
+	stats := store.Stats(time.Now(), showTitle(parser.New()), quotaProviders, s.Top)
+	ui := output.NewStdout(s.NoEmoji)
+	if s.JSON {
+		if err := json.NewEncoder(ui.Writer()).Encode(stats); err != nil {
+			return fmt.Errorf("failed to write statistics: %w", err)
+		}
+		return nil
+	}
+	writeStats(ui, stats)
+	return nil
+}
+
+func showTitle(p *parser.Parser) func(string) string {
+	return func(media string) string {
+		name := filepath.Base(media)
+		if info, err := p.Parse(name); err == nil && info.Title != "" {
+			return info.Title
+		}
+		return strings.TrimSuffix(name, filepath.Ext(name))
+	}
+}
+
+func writeStats(ui *output.Renderer, stats feedback.Stats) {
+	if stats.Files == 0 && stats.Wanted == 0 {
+		ui.Printf("%s No downloads recorded yet\n", ui.Info(ui.Icon(output.IconInfo)))
+		return
+	}
+
+	ui.Printf("%d subtitle(s) downloaded for %d file(s)", stats.Downloads, stats.Files)
+	if stats.Wanted > 0 {
+		ui.Printf(", %d file(s) still wanted", stats.Wanted)
+	}
+	ui.Printf(" (%.0f%% found)\n", stats.FoundRate*100)
+
+	providers := make([]feedback.Count, 0, len(stats.Providers))
+	for _, count := range stats.Providers {
+		providers = append(providers, feedback.Count{Name: providerName(count.Name), Downloads: count.Downloads})
+	}
+	writeCounts(ui, "Provider", providers)
+	writeCounts(ui, "Language", stats.Languages)
+	writeCounts(ui, "Month", stats.Months)
+	writeCounts(ui, "Top shows", stats.Shows)
+
+	used, peak := 0, 0
+	for _, day := range stats.Quota {
+		used += day.Downloads
+		peak = max(peak, day.Downloads)
+	}
+	ui.Printf("\nOpenSubtitles quota, last %d days: %d download(s), %.1f/day, busiest day %d\n", feedback.QuotaDays, used, float64(used)/float64(feedback.QuotaDays), peak)
+	for _, day := range stats.Quota {
+		ui.Printf("%-12s %4d %s\n", day.Name, day.Downloads, strings.Repeat("#", day.Downloads))
+	}
+
+	if len(stats.Ratings) > 0 {
+		ui.Printf("\n%-28s %6s %6s %8s\n", "Rated", "Good", "Bad", "Success")
+		ui.Printf("%s\n", strings.Repeat("-", 51))
+		for _, rating := range stats.Ratings {
+			ui.Printf("%-28s %6d %6d %7.0f%%\n", providerName(rating.Provider), rating.Good, rating.Bad, rating.Rate()*100)
+		}
+	}
+}
+
+func writeCounts(ui *output.Renderer, title string, counts []feedback.Count) {
+	if len(counts) == 0 {
+		return
+	}
+	ui.Printf("\n%-40s %10s\n", title, "Downloads")
+	ui.Printf("%s\n", strings.Repeat("-", 51))
+	for _, count := range counts {
+		ui.Printf("%-40s %10d\n", count.Name, count.Downloads)
+	}
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/feedback"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/parser"
+)
+
+func TestShowTitle(t *testing.T) {
+	t.Parallel()
+
+	title := showTitle(parser.New())
+	assert.Equal(t, "Breaking Bad", title("/tv/Breaking.Bad.S01E01.720p.x264-GROUP.mkv"))
+	assert.Equal(t, "home video", title("/videos/home video.mp4"))
+}
+
+func TestWriteStats(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	ui := output.New(&buf, output.Options{NoColor: true, NoEmoji: true})
+	writeStats(ui, feedback.Stats{})
+	assert.Contains(t, buf.String(), "No downloads recorded yet")
+
+	buf.Reset()
+	writeStats(ui, feedback.Stats{
+		Downloads: 3,
+		Files:     2,
+		Wanted:    1,
+		FoundRate: 2.0 / 3,
+		Providers: []feedback.Count{{Name: api.ProviderOpenSubtitles, Downloads: 3}},
+		Languages: []feedback.Count{{Name: "en", Downloads: 3}},
+		Quota:     []feedback.Count{{Name: "2026-10-15", Downloads: 1}, {Name: "2026-10-16", Downloads: 2}},
+		Ratings:   []feedback.Rating{{Provider: api.ProviderOpenSubtitles, Good: 1, Bad: 1}},
+	})
+
+	out := buf.String()
+	assert.Contains(t, out, "3 subtitle(s) downloaded for 2 file(s), 1 file(s) still wanted (67% found)")
+	assert.Contains(t, out, "OpenSubtitles                                     3")
+	assert.Contains(t, out, "last 14 days: 3 download(s), 0.2/day, busiest day 2")
+	assert.Contains(t, out, "2026-10-16      2 ##")
+	assert.Contains(t, out, "50%")
+	assert.NotContains(t, out, "Top shows")
+}
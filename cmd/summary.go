@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/internal/subformat"
+)
+
+type runSummary struct {
+	Saved     int
+	Linked    int
+	Kept      int
+	Identical int
+}
+
+func (c *CLI) printSummary() {
+	s := c.summary
+	if c.Quiet || c.DryRun || s == (runSummary{}) {
+		return
+	}
+
+	ui := c.ui()
+	ui.Println(ui.Bold("\n--- Summary ---"))
+	ui.Printf("Saved: %d subtitle(s)\n", s.Saved)
+	if s.Linked > 0 {
+		ui.Printf("Linked to duplicate videos: %d subtitle(s)\n", s.Linked)
+	}
+	if s.Kept > 0 {
+		ui.Printf("Existing files kept: %d\n", s.Kept)
+	}
+	if s.Identical > 0 {
+		ui.Printf("Skipped as identical to a saved subtitle: %d\n", s.Identical)
+	}
+}
+
+func (c *CLI) identicalSubtitle(content []byte, mediaPath string) string {
+	dir, prefix := filepath.Dir(mediaPath), strings.ToLower(stem(filepath.Base(mediaPath))+".")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+
+	var longer []string
+	for _, entry := range entries {
+		name := strings.ToLower(entry.Name())
+		if other := stem(name) + "."; isVideoName(name) && other != prefix && strings.HasPrefix(other, prefix) {
+			longer = append(longer, other)
+		}
+	}
+
+	sum := contentHash(content)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(strings.ToLower(name), prefix) || subformat.FromFileName(name) == "" {
+			continue
+		}
+		if slices.ContainsFunc(longer, func(other string) bool { return strings.HasPrefix(strings.ToLower(name), other) }) {
+			continue
+		}
+		existing, err := os.ReadFile(filepath.Join(dir, name))
+		if err == nil && contentHash(existing) == sum {
+			return filepath.Join(dir, name)
+		}
+	}
+	return ""
+}
+
+func contentHash(content []byte) [sha256.Size]byte {
+	content = bytes.TrimPrefix(content, []byte{0xEF, 0xBB, 0xBF})
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	return sha256.Sum256(bytes.TrimSpace(content))
+}
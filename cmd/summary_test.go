@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func TestIdenticalSubtitle(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	media := filepath.Join(dir, "Movie.mkv")
+	files := map[string]string{
+		"Movie.mkv":             "",
+		"Movie.Extended.mkv":    "",
+		"Movie.en.srt":          "1\r\n00:00:01,000 --> 00:00:02,000\r\nHello\r\n",
+		"Movie.Extended.pt.srt": "1\n00:00:01,000 --> 00:00:02,000\nOla\n",
+		"Movie.notes.txt":       "1\n00:00:01,000 --> 00:00:02,000\nOla\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	c := &CLI{}
+	assert.Equal(t, filepath.Join(dir, "Movie.en.srt"), c.identicalSubtitle([]byte("\ufeff1\n00:00:01,000 --> 00:00:02,000\nHello\n"), media), "BOM and line endings are ignored")
+	assert.Empty(t, c.identicalSubtitle([]byte("1\n00:00:01,000 --> 00:00:02,000\nOla\n"), media), "subtitles of other videos and non-subtitle files are ignored")
+	assert.Empty(t, c.identicalSubtitle([]byte("1\n00:00:01,000 --> 00:00:02,000\nBye\n"), media))
+}
+
+func TestSaveSubtitleSkipsIdentical(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	media := filepath.Join(dir, "Movie.mkv")
+	content := []byte("1\n00:00:01,000 --> 00:00:02,000\nHello\n")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Movie.en.srt"), content, 0644))
+
+	var buf bytes.Buffer
+	c := &CLI{cfg: config.Default(), out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+	require.NoError(t, c.saveSubtitle(content, &models.Subtitle{ID: "2", Language: "en", SubFormat: "srt"}, media, "en.2", true))
+
+	assert.NoFileExists(t, filepath.Join(dir, "Movie.en.2.srt"))
+	assert.Contains(t, buf.String(), "Skipping en subtitle: identical to "+filepath.Join(dir, "Movie.en.srt"))
+	assert.Equal(t, runSummary{Identical: 1}, c.summary)
+
+	require.NoError(t, c.saveSubtitle([]byte("1\n00:00:01,000 --> 00:00:02,000\nHi\n"), &models.Subtitle{ID: "3", Language: "en", SubFormat: "srt"}, media, "en.2", true))
+	assert.FileExists(t, filepath.Join(dir, "Movie.en.2.srt"))
+
+	buf.Reset()
+	c.printSummary()
+	assert.Equal(t, "\n--- Summary ---\nSaved: 1 subtitle(s)\nSkipped as identical to a saved subtitle: 1\n", buf.String())
+}
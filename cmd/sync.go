@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/internal/fsutil"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/scan"
+	"github.com/carlosarraes/subs-cli/internal/subformat"
+)
+
+var timedFormats = []string{subformat.SRT, subformat.VTT, subformat.ASS, subformat.SSA}
+
+type SyncCmd struct {
+	Paths     []string      `arg:"" type:"path" help:"Subtitle files or directories of subtitles to resync."`
+	Shift     time.Duration `long:"shift" placeholder:"2s" help:"Move every cue later by this much, or earlier when negative (--shift=-1.5s)."`
+	FPS       string        `long:"fps" placeholder:"FROM->TO" help:"Convert the timing from one frame rate to another, e.g. 25->23.976 for a subtitle made for a PAL release. Applied before --shift."`
+	Recursive bool          `short:"r" long:"recursive" help:"Also resync subtitles in subdirectories."`
+	Backup    bool          `long:"backup" help:"Keep a .bak copy of each file before changing it."`
+	DryRun    bool          `long:"dry-run" help:"List the subtitles that would be changed without writing them."`
+	NoEmoji   bool          `long:"no-emoji" help:"Replace emoji status symbols with plain ASCII markers."`
+}
+
+func (c *SyncCmd) Run() error {
+	return c.run(output.NewStdout(c.NoEmoji))
+}
+
+func (c *SyncCmd) run(ui *output.Renderer) error {
+	timing := subformat.Timing{Shift: c.Shift}
+	if c.FPS != "" {
+		ratio, err := subformat.ParseFPSChange(c.FPS)
+		if err != nil {
+			return fmt.Errorf("--fps: %w", err)
+		}
+		timing.Ratio = ratio
+	}
+	if timing.Shift == 0 && timing.Ratio == 0 {
+		return fmt.Errorf("nothing to do: pass --shift, --fps or both")
+	}
+
+	files, err := c.files()
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		ui.Printf("%s No SubRip, WebVTT or ASS/SSA subtitles found\n", ui.Info(ui.Icon(output.IconInfo)))
+		return nil
+	}
+
+	done, failed := 0, 0
+	for _, file := range files {
+		if c.DryRun {
+			ui.Printf("  Would resync %s\n", file)
+			continue
+		}
+		if err := c.sync(file, timing); err != nil {
+			failed++
+			ui.Printf("  %s %v\n", ui.Warning(ui.Icon(output.IconWarning)), err)
+			continue
+		}
+		done++
+		ui.Printf("  %s %s %s\n", ui.Icon(output.IconSaved), ui.Success("Resynced"), file)
+	}
+
+	if c.DryRun {
+		ui.Printf("%s Dry run: %d subtitle(s) would be resynced\n", ui.Info(ui.Icon(output.IconInfo)), len(files))
+		return nil
+	}
+	ui.Printf("%s Resynced %d subtitle(s)\n", ui.Success(ui.Icon(output.IconSuccess)), done)
+	if failed > 0 {
+		return fmt.Errorf("%d subtitle(s) could not be resynced", failed)
+	}
+	return nil
+}
+
+func (c *SyncCmd) sync(file string, timing subformat.Timing) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	retimed, err := subformat.Retime(content, subformat.Resolve(content, file, ""), timing)
+	if err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+	if err := fsutil.WriteFile(file, retimed, fsutil.WriteOptions{Perm: filePerm(file), Backup: c.Backup}); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	return nil
+}
+
+func (c *SyncCmd) files() ([]string, error) {
+	var files []string
+	for _, path := range c.Paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			if !slices.Contains(timedFormats, subformat.FromFileName(path)) {
+				return nil, fmt.Errorf("%s: only SubRip, WebVTT and ASS/SSA subtitles can be resynced", path)
+			}
+			files = append(files, path)
+			continue
+		}
+
+		found, err := scan.Find(path, scan.Options{Recursive: c.Recursive})
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range found {
+			if slices.Contains(timedFormats, subformat.FromFileName(file)) {
+				files = append(files, file)
+			}
+		}
+	}
+	return files, nil
+}
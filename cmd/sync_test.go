@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/output"
+)
+
+func TestSync(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	srt := filepath.Join(dir, "Movie.en.srt")
+	ass := filepath.Join(dir, "Show", "Show.S01E01.en.ass")
+	require.NoError(t, os.MkdirAll(filepath.Dir(ass), 0755))
+	require.NoError(t, os.WriteFile(srt, []byte("1\n00:00:01,000 --> 00:00:02,000\nHi\n"), 0644))
+	require.NoError(t, os.WriteFile(ass, []byte("[Events]\nDialogue: 0,0:00:01.00,0:00:02.00,Default,,0,0,0,,Hi\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Movie.mkv"), nil, 0644))
+
+	var buf bytes.Buffer
+	ui := output.New(&buf, output.Options{NoColor: true, NoEmoji: true})
+
+	require.NoError(t, (&SyncCmd{Paths: []string{dir}, Recursive: true, Shift: 2 * time.Second, DryRun: true}).run(ui))
+	assert.Contains(t, buf.String(), "Dry run: 2 subtitle(s) would be resynced")
+	content, err := os.ReadFile(srt)
+	require.NoError(t, err)
+	assert.Equal(t, "1\n00:00:01,000 --> 00:00:02,000\nHi\n", string(content))
+
+	buf.Reset()
+	require.NoError(t, (&SyncCmd{Paths: []string{dir}, Recursive: true, Shift: 2 * time.Second, Backup: true}).run(ui))
+	assert.Contains(t, buf.String(), "Resynced 2 subtitle(s)")
+
+	content, err = os.ReadFile(srt)
+	require.NoError(t, err)
+	assert.Equal(t, "1\n00:00:03,000 --> 00:00:04,000\nHi\n", string(content))
+	content, err = os.ReadFile(ass)
+	require.NoError(t, err)
+	assert.Equal(t, "[Events]\nDialogue: 0,0:00:03.00,0:00:04.00,Default,,0,0,0,,Hi\n", string(content))
+	assert.FileExists(t, srt+".bak")
+
+	buf.Reset()
+	require.NoError(t, (&SyncCmd{Paths: []string{srt}, FPS: "25->24"}).run(ui))
+	content, err = os.ReadFile(srt)
+	require.NoError(t, err)
+	assert.Equal(t, "1\n00:00:03,125 --> 00:00:04,166\nHi\n", string(content))
+}
+
+func TestSyncErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "Movie.en.sub")
+	require.NoError(t, os.WriteFile(sub, []byte("{1}{2}Hi\n"), 0644))
+	ui := output.New(&bytes.Buffer{}, output.Options{NoColor: true, NoEmoji: true})
+
+	assert.EqualError(t, (&SyncCmd{Paths: []string{dir}}).run(ui), "nothing to do: pass --shift, --fps or both")
+	assert.ErrorContains(t, (&SyncCmd{Paths: []string{dir}, FPS: "fast"}).run(ui), "--fps: expected FROM->TO")
+	assert.ErrorContains(t, (&SyncCmd{Paths: []string{sub}, Shift: time.Second}).run(ui), "only SubRip, WebVTT and ASS/SSA subtitles can be resynced")
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/carlosarraes/subs-cli/internal/fsutil"
+)
+
+func (c *CLI) previewTarget(target string) string {
+	if abs, err := filepath.Abs(target); err == nil {
+		target = abs
+	}
+
+	var note string
+	_, err := os.Stat(target)
+	switch {
+	case c.previewed[target]:
+		note = "replaces the subtitle listed above"
+	case err == nil && c.Backup:
+		note = fmt.Sprintf("replaces the existing file, backed up to %s", fsutil.BackupPath(target))
+	case err == nil:
+		note = "replaces the existing file"
+	default:
+		note = "new file"
+	}
+
+	if c.previewed == nil {
+		c.previewed = make(map[string]bool)
+	}
+	c.previewed[target] = true
+	return fmt.Sprintf("%s (%s)", target, note)
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreviewTarget(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "Movie.en.srt")
+	require.NoError(t, os.WriteFile(existing, []byte("1\n"), 0644))
+	fresh := filepath.Join(dir, "Movie.pt.srt")
+
+	c := &CLI{}
+	assert.Equal(t, fresh+" (new file)", c.previewTarget(fresh))
+	assert.Equal(t, existing+" (replaces the existing file)", c.previewTarget(existing))
+	assert.Equal(t, fresh+" (replaces the subtitle listed above)", c.previewTarget(fresh))
+
+	c = &CLI{Backup: true}
+	assert.Equal(t, existing+" (replaces the existing file, backed up to "+existing+".bak)", c.previewTarget(existing))
+
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(cwd, "Movie.srt")+" (new file)", c.previewTarget("Movie.srt"), "relative targets are shown as absolute paths")
+}
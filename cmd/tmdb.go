@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+const maxAlternativeTitles = 5
+
+func (c *CLI) tmdbClient(cfg *config.Config) *api.TMDBClient {
+	if c.tmdb == nil {
+		apiCfg := apiConfig(cfg)
+		apiCfg.APIKey = cfg.Metadata.TMDBAPIKey
+		c.tmdb = api.NewTMDBClient(apiCfg)
+	}
+	return c.tmdb
+}
+
+func (c *CLI) lookupEpisodeTitle(name string, parseErr error) (*models.MediaInfo, error) {
+	cfg := c.loadedConfig()
+	if cfg.Metadata.TMDBAPIKey == "" {
+		return nil, parseErr
+	}
+
+	ui := c.ui()
+	ui.Printf("  %s No season or episode number in the file name, looking up the episode title on TMDB...\n", ui.Icon(output.IconSearch))
+
+	ctx, cancel := context.WithTimeout(c.context(), fileTimeout(cfg))
+	defer cancel()
+	match, err := c.tmdbClient(cfg).FindEpisode(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("episode title lookup failed: %w", err)
+	}
+	if match == nil {
+		return nil, parseErr
+	}
+
+	ui.Printf("  %s Matched %s S%02dE%02d \"%s\"\n", ui.Success(ui.Icon(output.IconSuccess)), match.Show, match.Season, match.Episode, match.Title)
+	return &models.MediaInfo{Title: match.Show, Season: match.Season, Episode: match.Episode, Type: "episode"}, nil
+}
+
+func (c *CLI) searchAlternativeTitles(ctx context.Context, client api.Client, params *models.SearchParams, languages []string, outcome *searchOutcome) *searchOutcome {
+	cfg := c.loadedConfig()
+	if cfg.Metadata.TMDBAPIKey == "" || params.Query == "" {
+		return outcome
+	}
+
+	ui := c.ui()
+	titles, err := c.tmdbClient(cfg).AlternativeTitles(ctx, params.Query, params.Year, params.Type == "episode")
+	if err != nil {
+		ui.Printf("  %s Could not fetch alternative titles: %v\n", ui.Warning(ui.Icon(output.IconWarning)), err)
+		return outcome
+	}
+	if len(titles) > maxAlternativeTitles {
+		titles = titles[:maxAlternativeTitles]
+	}
+
+	original := params.Query
+	for _, title := range titles {
+		ui.Printf("  %s No results for '%s', trying the alternative title '%s'\n", ui.Icon(output.IconTip), params.Query, title)
+		params.Query = title
+		retried := c.searchLanguages(ctx, client, params, languages)
+		if len(retried.all) > 0 {
+			return retried
+		}
+		if len(retried.errs) > 0 {
+			params.Query = original
+			return retried
+		}
+	}
+	params.Query = original
+	return outcome
+}
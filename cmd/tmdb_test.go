@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/parser"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func TestLookupEpisodeTitle(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/search/tv":
+			if r.URL.Query().Get("query") == "Show Name" {
+				w.Write([]byte(`{"results":[{"id":1,"name":"Show Name"}]}`))
+				return
+			}
+			w.Write([]byte(`{"results":[]}`))
+		case "/tv/1":
+			w.Write([]byte(`{"seasons":[{"season_number":1}]}`))
+		case "/tv/1/season/1":
+			w.Write([]byte(`{"episodes":[{"season_number":1,"episode_number":1,"name":"Pilot"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	name := "Show.Name.The.Pilot.720p.mkv"
+	_, parseErr := parser.New().Parse(name)
+	require.ErrorIs(t, parseErr, parser.ErrUnparseableFilename)
+
+	var buf bytes.Buffer
+	cfg := config.Default()
+	cli := &CLI{cfg: cfg, out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+	_, err := cli.lookupEpisodeTitle(name, parseErr)
+	assert.ErrorIs(t, err, parser.ErrUnparseableFilename, "without a TMDB key the parse error is returned")
+	assert.Empty(t, buf.String())
+
+	cfg.Metadata.TMDBAPIKey = "key"
+	cli.tmdb = api.NewTMDBClient(&api.Config{BaseURL: server.URL, APIKey: "key"})
+	info, err := cli.lookupEpisodeTitle(name, parseErr)
+	require.NoError(t, err)
+	assert.Equal(t, &models.MediaInfo{Title: "Show Name", Season: 1, Episode: 1, Type: "episode"}, info)
+	assert.Contains(t, buf.String(), `Matched Show Name S01E01 "Pilot"`)
+
+	_, err = cli.lookupEpisodeTitle("Other.Show.Finale.mkv", parseErr)
+	assert.ErrorIs(t, err, parser.ErrUnparseableFilename)
+}
+
+func TestSearchWithRetry_AlternativeTitles(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/search/tv":
+			w.Write([]byte(`{"results":[{"id":71446,"name":"Money Heist","original_name":"La casa de papel"}]}`))
+		case "/tv/71446/alternative_titles":
+			w.Write([]byte(`{"results":[{"title":"Haus des Geldes"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &fakeSubtitleClient{results: map[string][]*models.Subtitle{
+		"La casa de papel/es": {{ID: "1", Language: "es", ReleaseName: "La.casa.de.papel.S01E01"}},
+	}}
+
+	var buf bytes.Buffer
+	cfg := config.Default()
+	cfg.Metadata.TMDBAPIKey = "key"
+	cli := &CLI{cfg: cfg, out: output.New(&buf, output.Options{NoColor: true, NoEmoji: true})}
+	cli.tmdb = api.NewTMDBClient(&api.Config{BaseURL: server.URL, APIKey: "key"})
+
+	params := &models.SearchParams{Query: "Money Heist", Type: "episode", Season: 1, Episode: 1}
+	outcome := cli.searchWithRetry(context.Background(), client, params, []string{"es"})
+	require.Len(t, outcome.all, 1)
+	assert.Equal(t, "La casa de papel", params.Query)
+	assert.Contains(t, buf.String(), "No results for 'Money Heist', trying the alternative title 'La casa de papel'")
+
+	params = &models.SearchParams{Query: "Money Heist", Type: "episode", Season: 1, Episode: 1}
+	outcome = cli.searchWithRetry(context.Background(), client, params, []string{"fr"})
+	assert.Empty(t, outcome.all)
+	assert.Equal(t, "Money Heist", params.Query, "the original query is kept when no alternative title helps")
+	assert.Len(t, client.searches, 6, "the query, two alternative titles and the relaxed query")
+
+	failing := &failingSubtitleClient{err: errors.New("search failed with status 503")}
+	outcome = cli.searchWithRetry(context.Background(), failing, &models.SearchParams{Query: "Money Heist"}, []string{"es"})
+	assert.Len(t, outcome.errs, 1)
+	assert.Len(t, failing.searches, 1, "no alternative titles are tried after a failed search")
+
+	cfg.Metadata.TMDBAPIKey = ""
+	client.searches = nil
+	cli.searchWithRetry(context.Background(), client, &models.SearchParams{Query: "Money Heist"}, []string{"fr"})
+	assert.Len(t, client.searches, 1, "without a TMDB key no alternative titles are tried")
+}
@@ -0,0 +1,558 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/fsutil"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/parser"
+	"github.com/carlosarraes/subs-cli/internal/scan"
+	"github.com/carlosarraes/subs-cli/internal/subformat"
+	"github.com/carlosarraes/subs-cli/internal/tui"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+const tuiLogLines = 4
+
+type TUICmd struct {
+	Path     string        `arg:"" default:"." type:"path" help:"Media file or directory to open."`
+	Language []string      `short:"l" long:"language" completion:"languages" help:"Subtitle language codes to cycle through. Defaults to the config file languages, then the system locale, then en."`
+	Config   string        `short:"c" long:"config" type:"existingfile" help:"Path to custom YAML configuration file. Default location: ~/.subs-cli/config.yaml"`
+	Proxy    string        `long:"proxy" placeholder:"URL" help:"Proxy for provider requests, e.g. http://host:3128 or socks5://host:1080."`
+	WaitLock time.Duration `long:"wait-lock" placeholder:"10m" help:"When another subs-cli run is downloading, wait up to this long for it to finish instead of exiting."`
+}
+
+func (t *TUICmd) Run(ctx context.Context) error {
+	cli := &CLI{Path: t.Path, Language: t.Language, Config: t.Config, Proxy: t.Proxy, WaitLock: t.WaitLock, ctx: ctx}
+	if err := cli.loadConfig(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	if _, err := cli.validateLanguages(); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+
+	paths := []string{t.Path}
+	if info, err := os.Stat(t.Path); err != nil {
+		return fmt.Errorf("cannot access path: %w", err)
+	} else if info.IsDir() {
+		found, err := findMediaFiles(t.Path, scan.Options{})
+		if err != nil {
+			return err
+		}
+		paths = found
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no media files found in directory: %s", t.Path)
+	}
+
+	files, err := newTUIFiles(cli, cli.newParser(cli.loadedConfig()), paths)
+	if err != nil {
+		return err
+	}
+
+	l, err := cli.acquireLock()
+	if err != nil {
+		return err
+	}
+	defer l.Release()
+
+	client := api.NewOpenSubtitlesClient(apiConfig(cli.loadedConfig()))
+	model := newTUIModel(client, files)
+	model.ctx, model.timeout = ctx, fileTimeout(cli.loadedConfig())
+	_, err = tui.NewProgram(model).Run()
+	if errors.Is(err, tui.ErrNoTerminal) {
+		// Without a terminal the TUI cannot draw; the regular interactive
+		// mode asks the same questions one line at a time.
+		ui := cli.ui()
+		ui.Printf("%s %v; falling back to line-by-line prompts\n", ui.Info(ui.Icon(output.IconInfo)), err)
+		cli.Interactive = true
+		cli.processFiles(cli.newParser(cli.loadedConfig()), paths)
+		return nil
+	}
+	return err
+}
+
+type tuiPane int
+
+const (
+	paneFiles tuiPane = iota
+	paneResults
+)
+
+type tuiFile struct {
+	path      string
+	info      *models.MediaInfo
+	query     string
+	languages []string
+	langIndex int
+	naming    string
+	output    config.OutputConfig
+	write     fsutil.WriteOptions
+	results   []*models.Subtitle
+	searching bool
+	searched  bool
+	err       error
+}
+
+func (f *tuiFile) language() string {
+	return f.languages[f.langIndex]
+}
+
+func newTUIFiles(cli *CLI, p *parser.Parser, paths []string) ([]*tuiFile, error) {
+	files := make([]*tuiFile, 0, len(paths))
+	for _, path := range paths {
+		settings, err := cli.settingsFor(path)
+		if err != nil {
+			return nil, err
+		}
+
+		file := &tuiFile{
+			path:      path,
+			languages: settings.languages,
+			naming:    settings.config.Output.Naming,
+			output:    settings.config.Output,
+			write:     cli.writeOptions(path),
+		}
+		file.write.Backup = file.write.Backup || settings.config.Output.Backup
+		if info, err := p.Parse(filepath.Base(path)); err == nil {
+			file.info = info
+			file.query = info.Title
+		} else {
+			file.query = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+type tuiSearchMsg struct {
+	file      *tuiFile
+	language  string
+	query     string
+	subtitles []*models.Subtitle
+	err       error
+}
+
+type tuiPreviewMsg struct {
+	subtitle *models.Subtitle
+	content  []byte
+	err      error
+}
+
+type tuiDownloadMsg struct {
+	subtitle *models.Subtitle
+	content  []byte
+	path     string
+	err      error
+}
+
+type tuiModel struct {
+	client       api.Client
+	files        []*tuiFile
+	fileCursor   int
+	resultCursor int
+	focus        tuiPane
+	selected     map[*models.Subtitle]bool
+	fetched      map[*models.Subtitle][]byte
+	previewFor   *models.Subtitle
+	preview      []string
+	log          []string
+	editing      bool
+	input        string
+	ctx          context.Context
+	timeout      time.Duration
+}
+
+func newTUIModel(client api.Client, files []*tuiFile) *tuiModel {
+	return &tuiModel{
+		client:   client,
+		files:    files,
+		selected: make(map[*models.Subtitle]bool),
+		fetched:  make(map[*models.Subtitle][]byte),
+		ctx:      context.Background(),
+		timeout:  config.DefaultFileTimeout,
+	}
+}
+
+func (m *tuiModel) Init() tui.Cmd {
+	if len(m.files) == 0 {
+		return nil
+	}
+	return m.search(m.files[0])
+}
+
+func (m *tuiModel) current() *tuiFile {
+	return m.files[m.fileCursor]
+}
+
+func (m *tuiModel) highlighted() *models.Subtitle {
+	results := m.current().results
+	if m.resultCursor < 0 || m.resultCursor >= len(results) {
+		return nil
+	}
+	return results[m.resultCursor]
+}
+
+func (m *tuiModel) logf(format string, args ...any) {
+	stamp := time.Now().Format("15:04:05")
+	m.log = append(m.log, stamp+" "+fmt.Sprintf(format, args...))
+	if len(m.log) > 100 {
+		m.log = m.log[len(m.log)-100:]
+	}
+}
+
+func (m *tuiModel) search(file *tuiFile) tui.Cmd {
+	file.searching = true
+	file.err = nil
+	language, query := file.language(), file.query
+	m.logf("Searching %s subtitles for '%s'", language, query)
+
+	params := &models.SearchParams{Query: query, Language: language, Type: "movie"}
+	if file.info != nil && file.query == file.info.Title {
+		params = (&CLI{}).createSearchParams(file.info)
+		params.Language = language
+	}
+
+	client, parent, timeout := m.client, m.ctx, m.timeout
+	return func() tui.Msg {
+		ctx, cancel := context.WithTimeout(parent, timeout)
+		defer cancel()
+		subtitles, err := client.Search(ctx, params)
+		return tuiSearchMsg{file: file, language: language, query: query, subtitles: subtitles, err: err}
+	}
+}
+
+func (m *tuiModel) fetch(subtitle *models.Subtitle) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(m.ctx, 2*m.timeout)
+	defer cancel()
+	return m.client.Download(ctx, subtitle)
+}
+
+func (m *tuiModel) previewCmd(subtitle *models.Subtitle) tui.Cmd {
+	if content, ok := m.fetched[subtitle]; ok {
+		return func() tui.Msg { return tuiPreviewMsg{subtitle: subtitle, content: content} }
+	}
+	m.logf("Fetching preview of %s (uses one download)", subtitle.ReleaseName)
+	return func() tui.Msg {
+		content, err := m.fetch(subtitle)
+		return tuiPreviewMsg{subtitle: subtitle, content: content, err: err}
+	}
+}
+
+func (m *tuiModel) downloadCmds() tui.Cmd {
+	file := m.current()
+
+	var chosen []*models.Subtitle
+	for _, subtitle := range file.results {
+		if m.selected[subtitle] {
+			chosen = append(chosen, subtitle)
+		}
+	}
+	if len(chosen) == 0 {
+		if subtitle := m.highlighted(); subtitle != nil {
+			chosen = append(chosen, subtitle)
+		}
+	}
+
+	var cmds []tui.Cmd
+	for i, subtitle := range chosen {
+		if subtitle.FileID == "" {
+			m.logf("Skipping %s: no downloadable file", subtitle.ReleaseName)
+			continue
+		}
+
+		language := indexedLanguage(file.language(), i)
+		withLanguage := i > 0 || file.langIndex > 0 || file.naming != config.NamingPlain
+		cached, hasCached := m.fetched[subtitle]
+		subtitle, mediaPath, opts, out := subtitle, file.path, file.write, file.output
+
+		m.logf("Downloading %s", subtitle.ReleaseName)
+		cmds = append(cmds, func() tui.Msg {
+			content := cached
+			if !hasCached {
+				var err error
+				if content, err = m.fetch(subtitle); err != nil {
+					return tuiDownloadMsg{subtitle: subtitle, err: err}
+				}
+			}
+			path, err := writeSubtitleFile(postProcess(content, out), subtitle, mediaPath, language, withLanguage, opts)
+			return tuiDownloadMsg{subtitle: subtitle, content: content, path: path, err: err}
+		})
+	}
+
+	return tui.Batch(cmds...)
+}
+
+func (m *tuiModel) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	switch msg := msg.(type) {
+	case tui.KeyMsg:
+		if m.editing {
+			return m, m.updateEditing(msg.Key)
+		}
+		return m, m.updateKey(msg.Key)
+
+	case tuiSearchMsg:
+		file := msg.file
+		if msg.language != file.language() || msg.query != file.query {
+			return m, nil
+		}
+		file.searching = false
+		file.searched = true
+		if msg.err != nil {
+			file.err = msg.err
+			m.logf("Search failed for '%s': %v", msg.query, msg.err)
+			return m, nil
+		}
+		file.results = msg.subtitles
+		m.logf("Found %d %s subtitle(s) for '%s'", len(msg.subtitles), msg.language, msg.query)
+		if file == m.current() {
+			m.resultCursor = 0
+		}
+
+	case tuiPreviewMsg:
+		if msg.err != nil {
+			m.logf("Preview failed: %v", msg.err)
+			return m, nil
+		}
+		m.fetched[msg.subtitle] = msg.content
+		m.previewFor = msg.subtitle
+		m.preview = previewLines(msg.subtitle, msg.content)
+
+	case tuiDownloadMsg:
+		if msg.err != nil {
+			m.logf("Download failed for %s: %v", msg.subtitle.ReleaseName, msg.err)
+			return m, nil
+		}
+		m.fetched[msg.subtitle] = msg.content
+		delete(m.selected, msg.subtitle)
+		m.logf("Saved %s", msg.path)
+	}
+
+	return m, nil
+}
+
+func (m *tuiModel) updateEditing(key string) tui.Cmd {
+	switch key {
+	case "esc", "ctrl+c":
+		m.editing = false
+	case "enter":
+		m.editing = false
+		query := strings.TrimSpace(m.input)
+		if query == "" {
+			return nil
+		}
+		file := m.current()
+		file.query = query
+		file.results = nil
+		m.resultCursor = 0
+		return m.search(file)
+	case "backspace":
+		if runes := []rune(m.input); len(runes) > 0 {
+			m.input = string(runes[:len(runes)-1])
+		}
+	case "space":
+		m.input += " "
+	default:
+		if len([]rune(key)) == 1 {
+			m.input += key
+		}
+	}
+	return nil
+}
+
+func (m *tuiModel) updateKey(key string) tui.Cmd {
+	file := m.current()
+
+	switch key {
+	case "q", "ctrl+c":
+		return tui.Quit
+	case "tab", "shift+tab":
+		if m.focus == paneFiles {
+			m.focus = paneResults
+		} else {
+			m.focus = paneFiles
+		}
+	case "/", "e":
+		m.editing = true
+		m.input = file.query
+	case "l":
+		file.langIndex = (file.langIndex + 1) % len(file.languages)
+		file.results = nil
+		m.resultCursor = 0
+		return m.search(file)
+	case "r":
+		return m.search(file)
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "enter":
+		if m.focus == paneFiles {
+			m.focus = paneResults
+			if !file.searched && !file.searching {
+				return m.search(file)
+			}
+			return nil
+		}
+		return m.downloadCmds()
+	case "space":
+		if subtitle := m.highlighted(); subtitle != nil && m.focus == paneResults {
+			if m.selected[subtitle] {
+				delete(m.selected, subtitle)
+			} else {
+				m.selected[subtitle] = true
+			}
+			m.move(1)
+		}
+	case "v", "p":
+		if subtitle := m.highlighted(); subtitle != nil {
+			return m.previewCmd(subtitle)
+		}
+	case "d":
+		return m.downloadCmds()
+	}
+	return nil
+}
+
+func (m *tuiModel) move(delta int) {
+	if m.focus == paneFiles {
+		m.fileCursor = clamp(m.fileCursor+delta, 0, len(m.files)-1)
+		m.resultCursor = 0
+		return
+	}
+	m.resultCursor = clamp(m.resultCursor+delta, 0, len(m.current().results)-1)
+}
+
+func clamp(value, low, high int) int {
+	if value > high {
+		value = high
+	}
+	if value < low {
+		value = low
+	}
+	return value
+}
+
+func previewLines(subtitle *models.Subtitle, content []byte) []string {
+	format := subformat.Resolve(content, subtitle.FileName, subtitle.SubFormat)
+	var lines []string
+	for _, cue := range subformat.Cues(content, format, previewCueLimit) {
+		lines = append(lines, fmt.Sprintf("%-13s %s", cue.Start, strings.ReplaceAll(cue.Text, "\n", " / ")))
+	}
+	if len(lines) > 0 {
+		return lines
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+		if len(lines) == previewCueLimit {
+			break
+		}
+	}
+	return lines
+}
+
+func (m *tuiModel) View(width, height int) string {
+	if width < 40 || height < 12 {
+		return "Terminal too small for subs tui (need at least 40x12)."
+	}
+
+	file := m.current()
+	logHeight := tuiLogLines + 2
+	mainHeight := height - logHeight - 1
+	leftWidth := width / 3
+	rightWidth := width - leftWidth
+	resultsHeight := mainHeight / 2
+	previewHeight := mainHeight - resultsHeight
+
+	left := tui.Box(fmt.Sprintf("Files (%d)", len(m.files)), m.fileLines(mainHeight-2), leftWidth, mainHeight, m.focus == paneFiles)
+
+	resultsTitle := fmt.Sprintf("Results: %s [%s]", file.query, file.language())
+	results := tui.Box(resultsTitle, m.resultLines(resultsHeight-2), rightWidth, resultsHeight, m.focus == paneResults)
+
+	previewTitle := "Preview"
+	if m.previewFor != nil {
+		previewTitle = "Preview: " + m.previewFor.ReleaseName
+	}
+	preview := tui.Box(previewTitle, m.preview, rightWidth, previewHeight, false)
+
+	logStart := len(m.log) - tuiLogLines
+	if logStart < 0 {
+		logStart = 0
+	}
+	logBox := tui.Box("Log", m.log[logStart:], width, logHeight, false)
+
+	lines := tui.JoinHorizontal(left, append(results, preview...))
+	lines = append(lines, logBox...)
+	lines = append(lines, tui.Fit(m.statusLine(), width))
+	return strings.Join(lines, "\n")
+}
+
+func (m *tuiModel) fileLines(height int) []string {
+	start, end := tui.Window(len(m.files), m.fileCursor, height)
+	lines := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		file := m.files[i]
+		marker := "  "
+		if i == m.fileCursor {
+			marker = "> "
+		}
+		status := ""
+		switch {
+		case file.searching:
+			status = " …"
+		case file.err != nil:
+			status = " !"
+		case file.searched:
+			status = fmt.Sprintf(" (%d)", len(file.results))
+		}
+		lines = append(lines, marker+filepath.Base(file.path)+status)
+	}
+	return lines
+}
+
+func (m *tuiModel) resultLines(height int) []string {
+	file := m.current()
+	switch {
+	case file.searching:
+		return []string{"Searching..."}
+	case file.err != nil:
+		return []string{"Search failed: " + file.err.Error()}
+	case file.searched && len(file.results) == 0:
+		return []string{"No subtitles found. Press / to edit the query."}
+	case !file.searched:
+		return []string{"Press Enter to search."}
+	}
+
+	start, end := tui.Window(len(file.results), m.resultCursor, height)
+	lines := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		subtitle := file.results[i]
+		marker := "  "
+		if i == m.resultCursor && m.focus == paneResults {
+			marker = "> "
+		}
+		check := "[ ]"
+		if m.selected[subtitle] {
+			check = "[x]"
+		}
+		lines = append(lines, fmt.Sprintf("%s%s %4.1f %6d  %-15s %s", marker, check, subtitle.Rating, subtitle.Downloads, truncate(subtitle.Uploader, 15), subtitle.ReleaseName))
+	}
+	return lines
+}
+
+func (m *tuiModel) statusLine() string {
+	if m.editing {
+		return "Search query: " + m.input + "▏  (Enter: search, Esc: cancel)"
+	}
+	return "Tab: switch pane  ↑/↓: move  Enter: search/download  Space: select  v: preview  d: download  /: edit query  l: language  r: retry  q: quit"
+}
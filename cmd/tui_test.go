@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/tui"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSubtitleClient struct {
+	mu        sync.Mutex
+	results   map[string][]*models.Subtitle
+	content   []byte
+	searches  []*models.SearchParams
+	downloads int
+}
+
+func (f *fakeSubtitleClient) Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	copied := *params
+	f.searches = append(f.searches, &copied)
+	return f.results[params.Query+"/"+params.Language], nil
+}
+
+func (f *fakeSubtitleClient) Download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.downloads++
+	return f.content, nil
+}
+
+func (f *fakeSubtitleClient) Authenticate(ctx context.Context) error {
+	return nil
+}
+
+func runTUICmd(t *testing.T, m *tuiModel, cmd tui.Cmd) {
+	t.Helper()
+	if cmd == nil {
+		return
+	}
+
+	switch msg := cmd().(type) {
+	case nil:
+	case tui.BatchMsg:
+		for _, next := range msg {
+			runTUICmd(t, m, next)
+		}
+	default:
+		_, next := m.Update(msg)
+		runTUICmd(t, m, next)
+	}
+}
+
+func pressKeys(t *testing.T, m *tuiModel, keys ...string) {
+	t.Helper()
+	for _, key := range keys {
+		_, cmd := m.Update(tui.KeyMsg{Key: key})
+		runTUICmd(t, m, cmd)
+	}
+}
+
+func TestTUIModel(t *testing.T) {
+	t.Parallel()
+
+	srt := []byte("1\n00:00:01,000 --> 00:00:02,000\nHello there\n")
+
+	setup := func(t *testing.T) (*tuiModel, *fakeSubtitleClient, string) {
+		dir := t.TempDir()
+		client := &fakeSubtitleClient{
+			content: srt,
+			results: map[string][]*models.Subtitle{
+				"Inception/en": {
+					{ID: "1", FileID: "1", ReleaseName: "Inception.2010.1080p", Uploader: "alice", Downloads: 900},
+					{ID: "2", FileID: "2", ReleaseName: "Inception.2010.720p", Uploader: "bob", Downloads: 10},
+				},
+				"Inception/pt-BR": {
+					{ID: "3", FileID: "3", ReleaseName: "Inception.2010.PTBR", Uploader: "carol"},
+				},
+				"Dark Matter/en": {},
+				"Dark Matter Fixed/en": {
+					{ID: "4", FileID: "4", ReleaseName: "Dark.Matter.S01E01", Uploader: "dan"},
+				},
+			},
+		}
+		files := []*tuiFile{
+			{path: filepath.Join(dir, "Inception.2010.1080p.mkv"), query: "Inception", languages: []string{"en", "pt-BR"}, naming: config.NamingLanguage},
+			{path: filepath.Join(dir, "Dark.Matter.mkv"), query: "Dark Matter", languages: []string{"en"}, naming: config.NamingPlain},
+		}
+		m := newTUIModel(client, files)
+		runTUICmd(t, m, m.Init())
+		return m, client, dir
+	}
+
+	t.Run("initial search fills results", func(t *testing.T) {
+		t.Parallel()
+
+		m, client, _ := setup(t)
+
+		require.Len(t, client.searches, 1)
+		assert.Equal(t, "en", client.searches[0].Language)
+		assert.Len(t, m.files[0].results, 2)
+
+		view := m.View(120, 30)
+		assert.Contains(t, view, "Results: Inception [en]")
+		assert.Contains(t, view, "Inception.2010.1080p.mkv (2)")
+		assert.Contains(t, view, "Found 2 en subtitle(s) for 'Inception'")
+	})
+
+	t.Run("language switching re-searches", func(t *testing.T) {
+		t.Parallel()
+
+		m, client, _ := setup(t)
+		pressKeys(t, m, "l")
+
+		assert.Equal(t, "pt-BR", m.files[0].language())
+		assert.Equal(t, "pt-BR", client.searches[len(client.searches)-1].Language)
+		require.Len(t, m.files[0].results, 1)
+		assert.Equal(t, "3", m.files[0].results[0].ID)
+	})
+
+	t.Run("editing the query re-searches", func(t *testing.T) {
+		t.Parallel()
+
+		m, _, _ := setup(t)
+		pressKeys(t, m, "down", "enter")
+		assert.Empty(t, m.files[1].results)
+		assert.Contains(t, m.View(120, 30), "No subtitles found")
+
+		pressKeys(t, m, "/", "space", "F", "i", "x", "e", "d", "enter")
+
+		assert.Equal(t, "Dark Matter Fixed", m.files[1].query)
+		require.Len(t, m.files[1].results, 1)
+		assert.False(t, m.editing)
+	})
+
+	t.Run("escape cancels editing", func(t *testing.T) {
+		t.Parallel()
+
+		m, _, _ := setup(t)
+		pressKeys(t, m, "/", "x", "backspace", "backspace", "esc")
+
+		assert.Equal(t, "Inception", m.files[0].query)
+		assert.False(t, m.editing)
+	})
+
+	t.Run("preview then download reuses content", func(t *testing.T) {
+		t.Parallel()
+
+		m, client, dir := setup(t)
+		pressKeys(t, m, "tab", "v")
+
+		assert.Equal(t, 1, client.downloads)
+		assert.Contains(t, m.View(120, 30), "Hello there")
+
+		pressKeys(t, m, "d")
+
+		assert.Equal(t, 1, client.downloads)
+		assert.FileExists(t, filepath.Join(dir, "Inception.2010.1080p.en.srt"))
+	})
+
+	t.Run("multi-select download", func(t *testing.T) {
+		t.Parallel()
+
+		m, client, dir := setup(t)
+		pressKeys(t, m, "tab", "space", "space", "enter")
+
+		assert.Equal(t, 2, client.downloads)
+		assert.FileExists(t, filepath.Join(dir, "Inception.2010.1080p.en.srt"))
+		assert.FileExists(t, filepath.Join(dir, "Inception.2010.1080p.en.2.srt"))
+		assert.Empty(t, m.selected)
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 2)
+	})
+
+	t.Run("quit", func(t *testing.T) {
+		t.Parallel()
+
+		m, _, _ := setup(t)
+		_, cmd := m.Update(tui.KeyMsg{Key: "q"})
+		require.NotNil(t, cmd)
+		assert.Equal(t, tui.Quit(), cmd())
+	})
+
+	t.Run("small terminal", func(t *testing.T) {
+		t.Parallel()
+
+		m, _, _ := setup(t)
+		assert.True(t, strings.HasPrefix(m.View(20, 5), "Terminal too small"))
+	})
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/update"
+)
+
+func (c *CLI) notifyUpdate() {
+	if c.Quiet || Version == "dev" || !c.loadedConfig().Updates.Check {
+		return
+	}
+	path, err := config.UpdatePath()
+	if err != nil {
+		return
+	}
+
+	release := latestRelease(c.context(), update.NewClient("", update.DefaultTimeout), path, time.Now())
+	if release == nil || !update.Newer(Version, release.Version) {
+		return
+	}
+
+	ui := c.ui()
+	ui.Printf("%s subs-cli %s is available (you have %s): %s\n", ui.Icon(output.IconTip), release.Version, Version, release.URL)
+}
+
+func latestRelease(ctx context.Context, client *update.Client, path string, now time.Time) *update.Release {
+	state, err := update.LoadState(path)
+	if err == nil && !state.Due(now) {
+		if state.Latest == "" {
+			return nil
+		}
+		return &update.Release{Version: state.Latest, URL: state.URL}
+	}
+
+	state = update.State{CheckedAt: now}
+	release, err := client.Latest(ctx)
+	if err == nil {
+		state.Latest, state.URL = release.Version, release.URL
+	}
+	update.SaveState(path, state)
+	return release
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/update"
+)
+
+func TestLatestRelease(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"tag_name": "v9.0.0", "html_url": "https://example.com/v9.0.0"}`))
+	}))
+	defer server.Close()
+
+	client := update.NewClient(server.URL, time.Second)
+	path := filepath.Join(t.TempDir(), "update.json")
+	now := time.Now()
+
+	release := latestRelease(context.Background(), client, path, now)
+	require.NotNil(t, release)
+	assert.Equal(t, "v9.0.0", release.Version)
+
+	release = latestRelease(context.Background(), client, path, now.Add(time.Hour))
+	require.NotNil(t, release)
+	assert.Equal(t, "https://example.com/v9.0.0", release.URL)
+	assert.Equal(t, int32(1), requests.Load(), "the release is checked at most once a day")
+
+	latestRelease(context.Background(), client, path, now.Add(25*time.Hour))
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestLatestRelease_Offline(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := update.NewClient(server.URL, time.Second)
+	path := filepath.Join(t.TempDir(), "update.json")
+	now := time.Now()
+
+	assert.Nil(t, latestRelease(context.Background(), client, path, now))
+
+	state, err := update.LoadState(path)
+	require.NoError(t, err)
+	assert.False(t, state.Due(now.Add(time.Hour)), "a failed check also waits a day")
+}
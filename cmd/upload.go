@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/language"
+	"github.com/carlosarraes/subs-cli/internal/moviehash"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/internal/probe"
+	"github.com/carlosarraes/subs-cli/internal/subformat"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+type UploadCmd struct {
+	Video           string  `arg:"" type:"existingfile" help:"Video file the subtitle belongs to."`
+	Subtitle        string  `arg:"" type:"existingfile" help:"Subtitle file to upload."`
+	Language        string  `short:"l" long:"language" help:"Subtitle language. Default: taken from the subtitle file name, e.g. movie.pt-BR.srt"`
+	Release         string  `long:"release" help:"Release name. Default: the video file name without its extension."`
+	FPS             float64 `long:"fps" help:"Video frame rate. Default: detected with ffprobe when available."`
+	HearingImpaired bool    `long:"hearing-impaired" help:"Mark the subtitle as made for the hearing impaired."`
+	Comment         string  `long:"comment" help:"Comment shown with the subtitle on OpenSubtitles."`
+	Force           bool    `long:"force" help:"Upload even when OpenSubtitles already has a subtitle in this language for the video."`
+	Config          string  `short:"c" long:"config" type:"existingfile" help:"Path to custom YAML configuration file. Default location: ~/.subs-cli/config.yaml"`
+	Proxy           string  `long:"proxy" placeholder:"URL" help:"Proxy for provider requests, e.g. http://host:3128 or socks5://host:1080."`
+	DebugHTTP       bool    `long:"debug-http" help:"Log provider HTTP requests and responses to stderr, with credentials redacted."`
+	NoEmoji         bool    `long:"no-emoji" help:"Replace emoji status symbols with plain ASCII markers."`
+}
+
+type subtitleUploader interface {
+	Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error)
+	Upload(ctx context.Context, upload *api.UploadRequest) (*api.UploadResult, error)
+}
+
+func (u *UploadCmd) Run(ctx context.Context) error {
+	cli := &CLI{Config: u.Config, Proxy: u.Proxy, DebugHTTP: u.DebugHTTP, NoEmoji: u.NoEmoji, ctx: ctx}
+	if err := cli.loadConfig(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	cfg := cli.loadedConfig()
+
+	ctx, cancel := context.WithTimeout(ctx, fileTimeout(cfg))
+	defer cancel()
+
+	return u.upload(ctx, cli.ui(), cli.newClient(cfg), cfg)
+}
+
+func (u *UploadCmd) upload(ctx context.Context, ui *output.Renderer, client subtitleUploader, cfg *config.Config) error {
+	content, err := os.ReadFile(u.Subtitle)
+	if err != nil {
+		return fmt.Errorf("failed to read subtitle: %w", err)
+	}
+	if subformat.Detect(content) == "" {
+		return fmt.Errorf("%s does not look like a subtitle file", u.Subtitle)
+	}
+
+	lang, err := u.language()
+	if err != nil {
+		return err
+	}
+
+	hash, size, err := moviehash.Compute(u.Video)
+	if err != nil {
+		return fmt.Errorf("cannot hash video: %w", err)
+	}
+
+	request := &api.UploadRequest{
+		MovieHash:       hash,
+		MovieByteSize:   size,
+		MovieFileName:   filepath.Base(u.Video),
+		FPS:             u.FPS,
+		Language:        lang,
+		ReleaseName:     u.Release,
+		HearingImpaired: u.HearingImpaired,
+		Comment:         u.Comment,
+		FileName:        filepath.Base(u.Subtitle),
+		Content:         content,
+	}
+	if request.ReleaseName == "" {
+		request.ReleaseName = strings.TrimSuffix(request.MovieFileName, filepath.Ext(request.MovieFileName))
+	}
+	if request.FPS == 0 {
+		if info, err := probe.New(cfg.Probe.FFprobe).Probe(ctx, u.Video); err == nil {
+			request.FPS = info.FPS
+		} else {
+			ui.Printf("%s Uploading without a frame rate: %v\n", ui.Warning(ui.Icon(output.IconWarning)), err)
+		}
+	}
+
+	ui.Println(ui.Bold(fmt.Sprintf("Uploading %s", request.FileName)))
+	ui.Printf("  Video:    %s (hash %s)\n", request.MovieFileName, hash)
+	ui.Printf("  Release:  %s\n", request.ReleaseName)
+	ui.Printf("  Language: %s\n", language.DisplayName(lang))
+	if request.FPS > 0 {
+		ui.Printf("  FPS:      %.3f\n", request.FPS)
+	}
+
+	existing, err := client.Search(ctx, &models.SearchParams{MovieHash: hash, Language: lang})
+	if err != nil {
+		ui.Printf("%s Could not check for existing subtitles: %v\n", ui.Warning(ui.Icon(output.IconWarning)), err)
+	}
+	if duplicates := uploadDuplicates(existing, request.ReleaseName); len(duplicates) > 0 {
+		ui.Printf("%s OpenSubtitles already has %d %s subtitle(s) for this video:\n", ui.Warning(ui.Icon(output.IconWarning)), len(duplicates), lang)
+		for _, subtitle := range duplicates {
+			ui.Printf("    %s (%s)\n", subtitle.ReleaseName, subtitle.URL)
+		}
+		if !u.Force {
+			return fmt.Errorf("not uploading a possible duplicate; use --force to upload anyway")
+		}
+	}
+
+	result, err := client.Upload(ctx, request)
+	if err != nil {
+		return err
+	}
+	if result.Duplicate {
+		ui.Printf("%s This exact subtitle is already on OpenSubtitles: %s\n", ui.Info(ui.Icon(output.IconInfo)), result.URL)
+		return nil
+	}
+	ui.Printf("%s %s %s\n", ui.Icon(output.IconSaved), ui.Success("Uploaded"), result.URL)
+	return nil
+}
+
+func (u *UploadCmd) language() (string, error) {
+	if u.Language != "" {
+		code, err := language.Normalize(u.Language)
+		if err != nil {
+			return "", fmt.Errorf("--language: %w", err)
+		}
+		return code, nil
+	}
+
+	name := strings.TrimSuffix(filepath.Base(u.Subtitle), filepath.Ext(u.Subtitle))
+	if dot := strings.LastIndex(name, "."); dot >= 0 {
+		if code, err := language.Normalize(name[dot+1:]); err == nil {
+			return code, nil
+		}
+	}
+	return "", fmt.Errorf("cannot tell the language of %s from its name; pass --language", u.Subtitle)
+}
+
+func uploadDuplicates(existing []*models.Subtitle, release string) []*models.Subtitle {
+	var duplicates []*models.Subtitle
+	for _, subtitle := range existing {
+		if subtitle.MovieHashMatch || strings.EqualFold(subtitle.ReleaseName, release) {
+			duplicates = append(duplicates, subtitle)
+		}
+	}
+	return duplicates
+}
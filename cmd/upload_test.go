@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/output"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+type fakeUploader struct {
+	existing []*models.Subtitle
+	searched *models.SearchParams
+	uploaded *api.UploadRequest
+	result   *api.UploadResult
+}
+
+func (f *fakeUploader) Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	f.searched = params
+	return f.existing, nil
+}
+
+func (f *fakeUploader) Upload(ctx context.Context, upload *api.UploadRequest) (*api.UploadResult, error) {
+	f.uploaded = upload
+	return f.result, nil
+}
+
+func TestUpload(t *testing.T) {
+	dir := t.TempDir()
+	video := filepath.Join(dir, "Movie.2020.1080p.WEB-DL.mkv")
+	require.NoError(t, os.WriteFile(video, make([]byte, 200000), 0644))
+	subtitle := filepath.Join(dir, "Movie.2020.1080p.WEB-DL.pt-BR.srt")
+	require.NoError(t, os.WriteFile(subtitle, []byte("1\n00:00:01,000 --> 00:00:02,000\nOlá\n"), 0644))
+	noLanguage := filepath.Join(dir, "Movie.srt")
+	require.NoError(t, os.WriteFile(noLanguage, []byte("1\n00:00:01,000 --> 00:00:02,000\nHello\n"), 0644))
+	notSubtitle := filepath.Join(dir, "notes.txt")
+	require.NoError(t, os.WriteFile(notSubtitle, []byte("just some notes"), 0644))
+
+	cfg := config.Default()
+	cfg.Probe.FFprobe = filepath.Join(dir, "missing-ffprobe")
+
+	run := func(cmd *UploadCmd, uploader *fakeUploader) (string, error) {
+		var buf bytes.Buffer
+		err := cmd.upload(context.Background(), output.New(&buf, output.Options{NoColor: true}), uploader, cfg)
+		return buf.String(), err
+	}
+
+	t.Run("uploads", func(t *testing.T) {
+		uploader := &fakeUploader{result: &api.UploadResult{SubtitleID: "42", URL: "https://www.opensubtitles.com/subtitles/42"}}
+
+		out, err := run(&UploadCmd{Video: video, Subtitle: subtitle, FPS: 23.976}, uploader)
+		require.NoError(t, err)
+
+		assert.Equal(t, &models.SearchParams{MovieHash: "0000000000030d40", Language: "pt-BR"}, uploader.searched)
+		require.NotNil(t, uploader.uploaded)
+		assert.Equal(t, "0000000000030d40", uploader.uploaded.MovieHash)
+		assert.Equal(t, int64(200000), uploader.uploaded.MovieByteSize)
+		assert.Equal(t, "Movie.2020.1080p.WEB-DL", uploader.uploaded.ReleaseName)
+		assert.Equal(t, "pt-BR", uploader.uploaded.Language)
+		assert.Equal(t, 23.976, uploader.uploaded.FPS)
+		assert.Equal(t, "Movie.2020.1080p.WEB-DL.pt-BR.srt", uploader.uploaded.FileName)
+		assert.Contains(t, out, "Uploaded https://www.opensubtitles.com/subtitles/42")
+	})
+
+	t.Run("without_fps", func(t *testing.T) {
+		uploader := &fakeUploader{result: &api.UploadResult{URL: "https://www.opensubtitles.com/subtitles/42"}}
+
+		out, err := run(&UploadCmd{Video: video, Subtitle: subtitle, Language: "en", Release: "Movie.2020.REPACK"}, uploader)
+		require.NoError(t, err)
+
+		assert.Equal(t, "en", uploader.uploaded.Language)
+		assert.Equal(t, "Movie.2020.REPACK", uploader.uploaded.ReleaseName)
+		assert.Zero(t, uploader.uploaded.FPS)
+		assert.Contains(t, out, "Uploading without a frame rate")
+	})
+
+	t.Run("duplicate_found", func(t *testing.T) {
+		uploader := &fakeUploader{existing: []*models.Subtitle{
+			{ReleaseName: "Other.Release", URL: "https://www.opensubtitles.com/subtitles/1"},
+			{ReleaseName: "Movie.2020.720p", MovieHashMatch: true, URL: "https://www.opensubtitles.com/subtitles/2"},
+		}}
+
+		out, err := run(&UploadCmd{Video: video, Subtitle: subtitle, FPS: 25}, uploader)
+		assert.EqualError(t, err, "not uploading a possible duplicate; use --force to upload anyway")
+		assert.Nil(t, uploader.uploaded)
+		assert.Contains(t, out, "already has 1 pt-BR subtitle(s)")
+		assert.Contains(t, out, "Movie.2020.720p (https://www.opensubtitles.com/subtitles/2)")
+		assert.NotContains(t, out, "Other.Release")
+	})
+
+	t.Run("forced", func(t *testing.T) {
+		uploader := &fakeUploader{
+			existing: []*models.Subtitle{{ReleaseName: "movie.2020.1080p.web-dl"}},
+			result:   &api.UploadResult{URL: "https://www.opensubtitles.com/subtitles/42"},
+		}
+
+		_, err := run(&UploadCmd{Video: video, Subtitle: subtitle, FPS: 25, Force: true}, uploader)
+		require.NoError(t, err)
+		assert.NotNil(t, uploader.uploaded)
+	})
+
+	t.Run("already_uploaded", func(t *testing.T) {
+		uploader := &fakeUploader{result: &api.UploadResult{Duplicate: true, URL: "https://www.opensubtitles.com/subtitles/7"}}
+
+		out, err := run(&UploadCmd{Video: video, Subtitle: subtitle, FPS: 25}, uploader)
+		require.NoError(t, err)
+		assert.Contains(t, out, "already on OpenSubtitles: https://www.opensubtitles.com/subtitles/7")
+	})
+
+	t.Run("unknown_language", func(t *testing.T) {
+		_, err := run(&UploadCmd{Video: video, Subtitle: noLanguage}, &fakeUploader{})
+		assert.ErrorContains(t, err, "pass --language")
+	})
+
+	t.Run("not_a_subtitle", func(t *testing.T) {
+		_, err := run(&UploadCmd{Video: video, Subtitle: notSubtitle, Language: "en"}, &fakeUploader{})
+		assert.ErrorContains(t, err, "does not look like a subtitle file")
+	})
+}
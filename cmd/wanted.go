@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/internal/config"
+	"github.com/carlosarraes/subs-cli/internal/feedback"
+	"github.com/carlosarraes/subs-cli/internal/language"
+	"github.com/carlosarraes/subs-cli/internal/output"
+)
+
+type WantedCmd struct {
+	List   WantedListCmd   `cmd:"" default:"withargs" help:"List files still waiting for an acceptable subtitle, with the reason and when they are retried (default)."`
+	Add    WantedAddCmd    `cmd:"" help:"Add videos to the wanted list so they are searched again."`
+	Remove WantedRemoveCmd `cmd:"" help:"Remove videos from the wanted list."`
+}
+
+type WantedListCmd struct {
+	Due     bool `long:"due" help:"Only list entries whose next retry time has passed."`
+	JSON    bool `long:"json" help:"Print the list as JSON."`
+	NoEmoji bool `long:"no-emoji" help:"Replace emoji status symbols with plain ASCII markers."`
+}
+
+type WantedAddCmd struct {
+	Videos    []string `arg:"" type:"existingfile" help:"Videos that need subtitles."`
+	Languages []string `short:"l" long:"languages" required:"" sep:"," help:"Subtitle languages wanted for the videos (comma-separated)."`
+	Reason    string   `long:"reason" default:"added by hand" help:"Why the subtitles are wanted, shown by 'subs wanted list'."`
+	NoEmoji   bool     `long:"no-emoji" help:"Replace emoji status symbols with plain ASCII markers."`
+}
+
+type WantedRemoveCmd struct {
+	Videos   []string `arg:"" help:"Videos to remove from the wanted list. They do not need to exist anymore."`
+	Language string   `short:"l" long:"language" help:"Only remove this language. Removes every language of the videos by default."`
+	NoEmoji  bool     `long:"no-emoji" help:"Replace emoji status symbols with plain ASCII markers."`
+}
+
+type wantedEntry struct {
+	feedback.Want
+	NextTry time.Time `json:"next_try"`
+}
+
+func (w *WantedListCmd) Run() error {
+	path, err := config.FeedbackPath()
+	if err != nil {
+		return err
+	}
+	store, err := feedback.Load(path)
+	if err != nil {
+		return err
+	}
+
+	ui := output.NewStdout(w.NoEmoji)
+	return w.list(ui, store, time.Now())
+}
+
+func (w *WantedListCmd) list(ui *output.Renderer, store *feedback.Store, now time.Time) error {
+	wanted := store.Wanted
+	if w.Due {
+		wanted = store.Due(now)
+	}
+
+	entries := make([]wantedEntry, 0, len(wanted))
+	for _, want := range wanted {
+		entries = append(entries, wantedEntry{Want: want, NextTry: want.NextTry()})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].NextTry.Before(entries[j].NextTry) })
+
+	if w.JSON {
+		return writeWantedJSON(ui.Writer(), entries)
+	}
+
+	if len(entries) == 0 {
+		ui.Printf("%s Nothing is waiting for subtitles\n", ui.Success(ui.Icon(output.IconSuccess)))
+		return nil
+	}
+	for _, entry := range entries {
+		next := "due now"
+		if entry.NextTry.After(now) {
+			next = "next try " + entry.NextTry.Local().Format("2006-01-02 15:04")
+		}
+		ui.Printf("%s [%s] (%s)\n", ui.Bold(filepath.Base(entry.Media)), entry.Language, next)
+		ui.Printf("    %s\n", entry.Media)
+		ui.Printf("    %s, %d attempt(s)\n", entry.Reason, entry.Attempts)
+	}
+	ui.Printf("\n%d wanted\n", len(entries))
+	return nil
+}
+
+func writeWantedJSON(w io.Writer, entries []wantedEntry) error {
+	if err := json.NewEncoder(w).Encode(entries); err != nil {
+		return fmt.Errorf("failed to write wanted list: %w", err)
+	}
+	return nil
+}
+
+func (w *WantedAddCmd) Run() error {
+	path, err := config.FeedbackPath()
+	if err != nil {
+		return err
+	}
+	return w.add(output.NewStdout(w.NoEmoji), path)
+}
+
+func (w *WantedAddCmd) add(ui *output.Renderer, path string) error {
+	codes := make([]string, 0, len(w.Languages))
+	for _, lang := range w.Languages {
+		code, err := language.Normalize(lang)
+		if err != nil {
+			return fmt.Errorf("--languages: %w", err)
+		}
+		codes = append(codes, code)
+	}
+
+	added := 0
+	err := feedback.Update(path, func(store *feedback.Store) error {
+		for _, video := range w.Videos {
+			for _, code := range codes {
+				if store.Request(video, code, w.Reason) {
+					added++
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	ui.Printf("%s Added %d video/language pair(s) to the wanted list\n", ui.Success(ui.Icon(output.IconSuccess)), added)
+	return nil
+}
+
+func (w *WantedRemoveCmd) Run() error {
+	path, err := config.FeedbackPath()
+	if err != nil {
+		return err
+	}
+	return w.remove(output.NewStdout(w.NoEmoji), path)
+}
+
+func (w *WantedRemoveCmd) remove(ui *output.Renderer, path string) error {
+	code := w.Language
+	if code != "" {
+		normalized, err := language.Normalize(code)
+		if err != nil {
+			return fmt.Errorf("--language: %w", err)
+		}
+		code = normalized
+	}
+
+	removed := 0
+	err := feedback.Update(path, func(store *feedback.Store) error {
+		for _, video := range w.Videos {
+			removed += store.Unwant(video, code)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if removed == 0 {
+		return fmt.Errorf("none of the given videos are on the wanted list")
+	}
+	ui.Printf("%s Removed %d video/language pair(s) from the wanted list\n", ui.Success(ui.Icon(output.IconSuccess)), removed)
+	return nil
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/feedback"
+	"github.com/carlosarraes/subs-cli/internal/output"
+)
+
+func TestWantedAddRemove(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feedback.json")
+	video := filepath.Join(dir, "Movie.2023.mkv")
+	require.NoError(t, os.WriteFile(video, nil, 0644))
+
+	var buf bytes.Buffer
+	ui := output.New(&buf, output.Options{NoColor: true, NoEmoji: true})
+
+	add := &WantedAddCmd{Videos: []string{video}, Languages: []string{"en", "pt-br"}, Reason: "added by hand"}
+	require.NoError(t, add.add(ui, path))
+	require.NoError(t, add.add(ui, path))
+	assert.Contains(t, buf.String(), "Added 2 video/language pair(s)")
+	assert.Contains(t, buf.String(), "Added 0 video/language pair(s)")
+
+	store, err := feedback.Load(path)
+	require.NoError(t, err)
+	wanted := store.WantedFor(video)
+	require.Len(t, wanted, 2)
+	assert.Equal(t, "pt-BR", wanted[1].Language)
+
+	assert.ErrorContains(t, (&WantedAddCmd{Videos: []string{video}, Languages: []string{"klingon"}}).add(ui, path), "--languages")
+
+	buf.Reset()
+	require.NoError(t, (&WantedRemoveCmd{Videos: []string{video}, Language: "pt-BR"}).remove(ui, path))
+	assert.Contains(t, buf.String(), "Removed 1 video/language pair(s)")
+	require.NoError(t, (&WantedRemoveCmd{Videos: []string{video}}).remove(ui, path))
+	assert.ErrorContains(t, (&WantedRemoveCmd{Videos: []string{video}}).remove(ui, path), "are on the wanted list")
+}
+
+func TestWantedList(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	store := &feedback.Store{Wanted: []feedback.Want{
+		{Media: "/tv/Show.S01E02.mkv", Language: "en", Reason: "best score 12 is below the minimum of 50", Attempts: 3, LastTry: now},
+		{Media: "/tv/Show.S01E01.mkv", Language: "fr", Reason: "added by hand", AddedAt: now.Add(-time.Hour)},
+	}}
+
+	var buf bytes.Buffer
+	ui := output.New(&buf, output.Options{NoColor: true, NoEmoji: true})
+	require.NoError(t, (&WantedListCmd{}).list(ui, store, now))
+
+	out := buf.String()
+	assert.Less(t, bytes.Index(buf.Bytes(), []byte("Show.S01E01.mkv [fr] (due now)")), bytes.Index(buf.Bytes(), []byte("Show.S01E02.mkv [en] (next try")))
+	assert.Contains(t, out, "best score 12 is below the minimum of 50, 3 attempt(s)")
+	assert.Contains(t, out, "2 wanted")
+
+	buf.Reset()
+	require.NoError(t, (&WantedListCmd{Due: true, JSON: true}).list(ui, store, now))
+	var entries []map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entries))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "/tv/Show.S01E01.mkv", entries[0]["media"])
+	assert.Equal(t, "2026-10-16T11:00:00Z", entries[0]["next_try"])
+
+	buf.Reset()
+	require.NoError(t, (&WantedListCmd{}).list(ui, &feedback.Store{}, now))
+	assert.Contains(t, buf.String(), "Nothing is waiting for subtitles")
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/internal/subformat"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+var (
+	animeBrackets      = regexp.MustCompile(`\[[^\]]*\]|\([^)]*\)|【[^】]*】`)
+	animeSeasonEpisode = regexp.MustCompile(`(?i)\bS(\d{1,2})\s*E(\d{1,4})\b`)
+	animeMarkedEpisode = regexp.MustCompile(`(?i)(?:\bEP?|#|第|\s-\s)\s*(\d{1,4})(?:v\d)?`)
+	animeLooseEpisode  = regexp.MustCompile(`(?:^|[^\p{L}\d])(\d{1,3})(?:v\d)?(?:[^\p{L}\d]|$)`)
+)
+
+func animeEpisode(name string) (season, episode int, ok bool) {
+	name = strings.TrimSuffix(name, path.Ext(name))
+	name = animeBrackets.ReplaceAllString(name, " ")
+	name = strings.NewReplacer("_", " ", ".", " ").Replace(name)
+
+	if m := animeSeasonEpisode.FindStringSubmatch(name); m != nil {
+		season, _ = strconv.Atoi(m[1])
+		episode, _ = strconv.Atoi(m[2])
+		return season, episode, true
+	}
+	if m := animeMarkedEpisode.FindStringSubmatch(name); m != nil {
+		episode, _ = strconv.Atoi(m[1])
+		return 0, episode, true
+	}
+	if matches := animeLooseEpisode.FindAllStringSubmatch(name, -1); len(matches) > 0 {
+		episode, _ = strconv.Atoi(matches[len(matches)-1][1])
+		return 0, episode, true
+	}
+	return 0, 0, false
+}
+
+func animeFileMatches(name string, params *models.SearchParams) bool {
+	if subformat.FromFileName(name) == "" {
+		return false
+	}
+	if params.Episode == 0 {
+		return true
+	}
+
+	season, episode, ok := animeEpisode(name)
+	if !ok || episode != params.Episode {
+		return false
+	}
+	return season == 0 || params.Season == 0 || season == params.Season
+}
+
+func animeTitleMatches(query string, titles ...string) bool {
+	folded := foldTitle(query)
+	if folded == "" {
+		return false
+	}
+	for _, title := range titles {
+		if title != "" && foldTitle(title) == folded {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnimeEpisode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		season  int
+		episode int
+		ok      bool
+	}{
+		{"[SubsPlease] Sousou no Frieren - 05 (1080p) [F02B9CEE].ass", 0, 5, true},
+		{"Sousou_no_Frieren_E12.srt", 0, 12, true},
+		{"Frieren.S02E03.WEB.srt", 2, 3, true},
+		{"Mob Psycho 100 - 07v2.ass", 0, 7, true},
+		{"Mob Psycho 100 #08.ass", 0, 8, true},
+		{"葬送のフリーレン 第13話.srt", 0, 13, true},
+		{"Kimi no Na wa.srt", 0, 0, false},
+	}
+	for _, tt := range tests {
+		season, episode, ok := animeEpisode(tt.name)
+		assert.Equal(t, tt.ok, ok, tt.name)
+		assert.Equal(t, tt.season, season, tt.name)
+		assert.Equal(t, tt.episode, episode, tt.name)
+	}
+}
+
+func TestAnimeFileMatches(t *testing.T) {
+	t.Parallel()
+
+	episode := &models.SearchParams{Season: 1, Episode: 5}
+	assert.True(t, animeFileMatches("Frieren - 05.ass", episode))
+	assert.False(t, animeFileMatches("Frieren - 06.ass", episode))
+	assert.False(t, animeFileMatches("Frieren.S02E05.srt", episode))
+	assert.False(t, animeFileMatches("Frieren - 05.zip", episode))
+	assert.True(t, animeFileMatches("Kimi no Na wa.srt", &models.SearchParams{Type: "movie"}))
+
+	assert.True(t, animeTitleMatches("Sousou no Frieren", "Sousou no Frieren", "Frieren: Beyond Journey's End"))
+	assert.True(t, animeTitleMatches("Frieren Beyond Journeys End", "Sousou no Frieren", "Frieren: Beyond Journey's End"))
+	assert.False(t, animeTitleMatches("Frieren", "Sousou no Frieren"))
+}
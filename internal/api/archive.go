@@ -0,0 +1,83 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/internal/subformat"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zipMagic  = []byte("PK\x03\x04")
+)
+
+func unpackSubtitle(content []byte, fileName string) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(content, gzipMagic):
+		reader, err := gzip.NewReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read gzipped subtitle: %w", err)
+		}
+		defer reader.Close()
+
+		unpacked, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read gzipped subtitle: %w", err)
+		}
+		return unpackSubtitle(unpacked, fileName)
+	case bytes.HasPrefix(content, zipMagic):
+		return extractSubtitle(content, fileName)
+	}
+	return content, nil
+}
+
+func extractSubtitle(content []byte, fileName string) ([]byte, error) {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open subtitle archive: %w", err)
+	}
+
+	var match, first *zip.File
+	for _, file := range archive.File {
+		format := subformat.FromFileName(file.Name)
+		if file.FileInfo().IsDir() || format == "" || format == subformat.IDX {
+			continue
+		}
+		if fileName != "" && strings.EqualFold(path.Base(file.Name), fileName) {
+			match = file
+			break
+		}
+		if first == nil {
+			first = file
+		}
+	}
+	if match == nil || strings.EqualFold(path.Ext(match.Name), ".sub") {
+		if _, _, ok := subformat.VobSubPair(content); ok {
+			return content, nil
+		}
+	}
+	if match == nil {
+		match = first
+	}
+	if match == nil {
+		return nil, withKind(ErrNotFound, fmt.Errorf("subtitle archive contains no subtitle file"))
+	}
+
+	reader, err := match.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract %s: %w", match.Name, err)
+	}
+	defer reader.Close()
+
+	extracted, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract %s: %w", match.Name, err)
+	}
+	return extracted, nil
+}
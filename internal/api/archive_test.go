@@ -0,0 +1,93 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const archiveSRT = "1\n00:00:01,000 --> 00:00:02,000\nHello\n"
+
+func gzipped(t *testing.T, content []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func zipped(t *testing.T, files map[string]string, order ...string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range order {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(files[name]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestUnpackSubtitle(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{
+		"readme.nfo":         "release notes",
+		"subs/Movie.CD1.srt": "cd1",
+		"subs/Movie.CD2.srt": archiveSRT,
+		"subs/":              "",
+	}
+	archive := zipped(t, files, "subs/", "readme.nfo", "subs/Movie.CD1.srt", "subs/Movie.CD2.srt")
+	vobsub := zipped(t, map[string]string{
+		"Movie.idx": "# VobSub index file, v7 (do not modify this line!)\n",
+		"Movie.sub": "\x00\x00\x01\xbapacket",
+		"Movie.srt": archiveSRT,
+	}, "Movie.idx", "Movie.sub", "Movie.srt")
+
+	tests := []struct {
+		name     string
+		content  []byte
+		fileName string
+		expected string
+		errorMsg string
+	}{
+		{name: "plain", content: []byte(archiveSRT), expected: archiveSRT},
+		{name: "gzip", content: gzipped(t, []byte(archiveSRT)), expected: archiveSRT},
+		{name: "zip_matching_name", content: archive, fileName: "movie.cd2.srt", expected: archiveSRT},
+		{name: "zip_first_subtitle", content: archive, fileName: "other.srt", expected: "cd1"},
+		{name: "gzipped_zip", content: gzipped(t, archive), fileName: "Movie.CD2.srt", expected: archiveSRT},
+		{
+			name:     "zip_without_subtitles",
+			content:  zipped(t, map[string]string{"readme.nfo": "x"}, "readme.nfo"),
+			errorMsg: "subtitle archive contains no subtitle file",
+		},
+		{name: "broken_gzip", content: []byte{0x1f, 0x8b, 0x00}, errorMsg: "failed to read gzipped subtitle"},
+		{name: "vobsub_pair", content: vobsub, fileName: "movie.sub", expected: string(vobsub)},
+		{name: "text_subtitle_next_to_vobsub", content: vobsub, fileName: "Movie.srt", expected: archiveSRT},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			content, err := unpackSubtitle(tt.content, tt.fileName)
+			if tt.errorMsg != "" {
+				assert.ErrorContains(t, err, tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(content))
+		})
+	}
+}
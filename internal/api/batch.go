@@ -0,0 +1,141 @@
+package api
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/internal/language"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+type languageBatch struct {
+	done    chan struct{}
+	results map[string][]*models.Subtitle
+	pages   int
+	err     error
+}
+
+// batchKey identifies one combined search: everything that is sent to the
+// provider except the single language being asked for.
+type batchKey struct {
+	query       string
+	languages   string
+	season      int
+	episode     int
+	year        int
+	kind        string
+	movieHash   string
+	orderBy     string
+	trustedOnly bool
+	page        int
+}
+
+func newBatchKey(params *models.SearchParams) batchKey {
+	return batchKey{
+		query:       params.Query,
+		languages:   strings.Join(params.Languages, ","),
+		season:      params.Season,
+		episode:     params.Episode,
+		year:        params.Year,
+		kind:        params.Type,
+		movieHash:   params.MovieHash,
+		orderBy:     params.OrderBy,
+		trustedOnly: params.TrustedOnly,
+		page:        params.Page,
+	}
+}
+
+func batchesLanguages(params *models.SearchParams) bool {
+	return len(params.Languages) > 1 && slices.Contains(params.Languages, params.Language)
+}
+
+func (c *OpenSubtitlesClient) searchBatch(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	batch, err := c.languageBatch(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	subtitles, ok := batch.results[params.Language]
+	if batch.pages <= 1 || ok {
+		return subtitles, nil
+	}
+
+	// The combined answer was cut off after one page and the other languages
+	// filled it, so this one may still have results; ask for it on its own.
+	single := *params
+	single.Languages = nil
+	return c.Search(ctx, &single)
+}
+
+// languageBatch runs the combined search for params once and shares it with
+// every language of the same file. Only the first caller goes to the network;
+// the others wait for it without holding the client lock. Failures are not
+// kept, so the next file (or retry) searches again.
+func (c *OpenSubtitlesClient) languageBatch(ctx context.Context, params *models.SearchParams) (*languageBatch, error) {
+	key := newBatchKey(params)
+
+	c.mu.Lock()
+	batch, ok := c.batches[key]
+	if !ok {
+		batch = &languageBatch{done: make(chan struct{})}
+		if c.batches == nil {
+			c.batches = make(map[batchKey]*languageBatch)
+		}
+		c.batches[key] = batch
+	}
+	c.mu.Unlock()
+
+	if ok {
+		select {
+		case <-batch.done:
+			return batch, batch.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	query := *params
+	query.Language = strings.Join(params.Languages, ",")
+	var subtitles []*models.Subtitle
+	batch.err = c.config.Breaker.guard(func() (err error) {
+		subtitles, batch.pages, err = c.searchPage(ctx, &query)
+		return err
+	})
+	if batch.err == nil {
+		batch.results = splitLanguages(subtitles, params.Languages)
+	} else {
+		c.mu.Lock()
+		delete(c.batches, key)
+		c.mu.Unlock()
+	}
+	close(batch.done)
+
+	return batch, batch.err
+}
+
+func splitLanguages(subtitles []*models.Subtitle, languages []string) map[string][]*models.Subtitle {
+	results := make(map[string][]*models.Subtitle, len(languages))
+	for _, subtitle := range subtitles {
+		code := normalizeLanguage(subtitle.Language)
+		for _, requested := range languages {
+			if strings.EqualFold(subtitle.Language, requested) || (code != "" && code == normalizeLanguage(requested)) {
+				results[requested] = append(results[requested], subtitle)
+				break
+			}
+		}
+	}
+	return results
+}
+
+func providerLanguages(value string) string {
+	codes := strings.Split(value, ",")
+	for i, code := range codes {
+		codes[i] = strings.ToLower(language.ProviderCode(ProviderOpenSubtitles, strings.TrimSpace(code)))
+	}
+	if len(codes) == 1 {
+		return language.ProviderCode(ProviderOpenSubtitles, value)
+	}
+	slices.Sort(codes)
+	return strings.Join(slices.Compact(codes), ",")
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func TestOpenSubtitlesClient_SearchBatch(t *testing.T) {
+	t.Parallel()
+
+	var queries []string
+	rows := []string{"en", "pt-BR", "en"}
+	pageSize, failures := len(rows), 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		languages := r.URL.Query().Get("languages")
+		queries = append(queries, languages)
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		requested := strings.Split(languages, ",")
+		var data []interface{}
+		for _, code := range rows {
+			if !slices.ContainsFunc(requested, func(s string) bool { return strings.EqualFold(s, code) }) {
+				continue
+			}
+			if len(data) == pageSize {
+				break
+			}
+			data = append(data, map[string]interface{}{
+				"id":         code,
+				"attributes": map[string]interface{}{"language": code, "release": "Movie.2024", "files": []interface{}{map[string]interface{}{"file_id": 1, "file_name": "a.srt"}}},
+			})
+		}
+		pages := (len(rows) + pageSize - 1) / pageSize
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"total_pages": pages, "page": 1, "data": data})
+	}))
+	defer server.Close()
+
+	client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, APIKey: "key"})
+	params := &models.SearchParams{Query: "Movie", Languages: []string{"pt-BR", "en", "es"}}
+	search := func(language string) ([]*models.Subtitle, error) {
+		params.Language = language
+		return client.Search(context.Background(), params)
+	}
+	found := func(language string) []*models.Subtitle {
+		subtitles, err := search(language)
+		require.NoError(t, err)
+		return subtitles
+	}
+
+	assert.Len(t, found("pt-BR"), 1)
+	assert.Len(t, found("en"), 2)
+	assert.Empty(t, found("es"), "a complete answer is trusted for languages without results")
+	assert.Equal(t, []string{"en,es,pt-br"}, queries)
+
+	rows, queries = []string{"en", "pt-BR", "en", "es", "en"}, nil
+	params.Query = "Other"
+	assert.Len(t, found("en"), 2, "a language on a truncated page keeps the rows it got")
+	assert.Len(t, found("es"), 1, "a language crowded out of a full page is searched on its own")
+	assert.Equal(t, []string{"en,es,pt-br", "es"}, queries)
+
+	failures, queries = 1, nil
+	params.Query = "Failing"
+	_, err := search("en")
+	require.Error(t, err)
+	assert.Len(t, found("en"), 2, "a failed combined search is not remembered")
+	assert.Equal(t, []string{"en,es,pt-br", "en,es,pt-br"}, queries)
+}
+
+func TestOpenSubtitlesClient_SearchBatchTruncated(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		var data []interface{}
+		for _, code := range strings.Split(r.URL.Query().Get("languages"), ",") {
+			if code == "es" {
+				continue
+			}
+			data = append(data, map[string]interface{}{
+				"id":         code,
+				"attributes": map[string]interface{}{"language": code, "files": []interface{}{map[string]interface{}{"file_id": 1}}},
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"total_pages": 2, "page": 1, "data": data})
+	}))
+	defer server.Close()
+
+	client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, APIKey: "key"})
+	search := func(languages ...string) {
+		for _, language := range languages {
+			subtitles, err := client.Search(context.Background(), &models.SearchParams{Query: strings.Join(languages, " "), Language: language, Languages: languages})
+			require.NoError(t, err)
+			assert.Len(t, subtitles, 1, language)
+		}
+	}
+
+	search("en", "fr", "de")
+	assert.Equal(t, int32(1), calls.Load(), "every language got rows on the first page")
+
+	calls.Store(0)
+	for _, language := range []string{"en", "es"} {
+		_, err := client.Search(context.Background(), &models.SearchParams{Query: "Movie", Language: language, Languages: []string{"en", "es"}})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), calls.Load(), "only the language missing from the truncated page is searched again")
+}
+
+func TestOpenSubtitlesClient_SearchBatchConcurrent(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"total_pages": 1, "page": 1, "data": []interface{}{}})
+	}))
+	defer server.Close()
+
+	client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, APIKey: "key"})
+	languages := []string{"en", "es"}
+
+	var wg sync.WaitGroup
+	for _, language := range languages {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Search(context.Background(), &models.SearchParams{Query: "Movie", Language: language, Languages: languages})
+			assert.NoError(t, err)
+		}()
+	}
+
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.Search(ctx, &models.SearchParams{Query: "Movie", Language: "en", Languages: languages})
+	assert.ErrorIs(t, err, context.Canceled, "a waiting caller gives up with its own context")
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), calls.Load())
+}
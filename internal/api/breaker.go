@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const DefaultFailureThreshold = 3
+
+var ErrCircuitOpen = withKind(ErrProviderUnavailable, errors.New("provider skipped after repeated failures"))
+
+type Breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openedAt  time.Time
+	probing   bool
+	now       func() time.Time
+}
+
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	if threshold <= 0 {
+		threshold = DefaultFailureThreshold
+	}
+	return &Breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+func (b *Breaker) Open() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failures >= b.threshold
+}
+
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return nil
+	}
+	if b.cooldown > 0 && !b.probing && b.now().Sub(b.openedAt) >= b.cooldown {
+		b.probing = true
+		return nil
+	}
+	return fmt.Errorf("%w (%d consecutive errors)", ErrCircuitOpen, b.failures)
+}
+
+func (b *Breaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasProbe := b.probing
+	b.probing = false
+
+	if err == nil {
+		b.failures = 0
+		return
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, ErrBudgetExhausted) {
+		return
+	}
+
+	b.failures++
+	if b.failures == b.threshold || wasProbe {
+		b.openedAt = b.now()
+	}
+}
+
+func (b *Breaker) guard(call func() error) error {
+	if b == nil {
+		return call()
+	}
+	if err := b.Allow(); err != nil {
+		return err
+	}
+	err := call()
+	b.Record(err)
+	return err
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreaker(t *testing.T) {
+	t.Parallel()
+
+	failure := errors.New("timeout")
+
+	t.Run("trips after threshold", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewBreaker(2, 0)
+		b.Record(failure)
+		require.NoError(t, b.Allow())
+		b.Record(failure)
+
+		assert.True(t, b.Open())
+		assert.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+	})
+
+	t.Run("success resets failures", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewBreaker(2, 0)
+		b.Record(failure)
+		b.Record(nil)
+		b.Record(failure)
+
+		assert.False(t, b.Open())
+	})
+
+	t.Run("cancellation is not a failure", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewBreaker(1, 0)
+		b.Record(context.Canceled)
+
+		assert.False(t, b.Open())
+	})
+
+	t.Run("half-open probe after cooldown", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+		b := NewBreaker(1, time.Minute)
+		b.now = func() time.Time { return now }
+		b.Record(failure)
+		assert.Error(t, b.Allow())
+
+		now = now.Add(time.Minute)
+		require.NoError(t, b.Allow(), "one probe is let through")
+		assert.Error(t, b.Allow(), "but only one at a time")
+		b.Record(failure)
+		assert.Error(t, b.Allow(), "a failed probe restarts the cooldown")
+
+		now = now.Add(time.Minute)
+		require.NoError(t, b.Allow())
+		b.Record(nil)
+		assert.False(t, b.Open())
+		assert.NoError(t, b.Allow())
+	})
+}
+
+func TestOpenSubtitlesClient_Breaker(t *testing.T) {
+	t.Parallel()
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	breaker := NewBreaker(2, 0)
+	for i := 0; i < 2; i++ {
+		client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, APIKey: "key", Breaker: breaker})
+		_, err := client.Search(context.Background(), &models.SearchParams{Query: "movie"})
+		assert.ErrorContains(t, err, "status 502")
+	}
+
+	client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, APIKey: "key", Breaker: breaker})
+	_, err := client.Search(context.Background(), &models.SearchParams{Query: "movie"})
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	_, err = client.Download(context.Background(), &models.Subtitle{FileID: "1"})
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 2, requests)
+}
@@ -0,0 +1,284 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/carlosarraes/subs-cli/internal/language"
+	"github.com/carlosarraes/subs-cli/internal/moviehash"
+	"github.com/carlosarraes/subs-cli/internal/subformat"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+const (
+	ProviderBSPlayer = "bsplayer"
+
+	bsplayerEndpoint  = "http://s%d.api.bsplayer-subtitles.com/v1.php"
+	bsplayerServers   = 8
+	bsplayerNamespace = "http://api.bsplayer-subtitles.com/v1.php"
+	bsplayerAppID     = "BSPlayer v2.67"
+)
+
+type BSPlayerClient struct {
+	client    *resty.Client
+	config    *Config
+	endpoints []string
+
+	mu       sync.Mutex
+	next     int
+	endpoint string
+	handle   string
+}
+
+type bsplayerParam struct {
+	name  string
+	value string
+}
+
+type bsplayerEnvelope struct {
+	Body struct {
+		Response struct {
+			Return bsplayerReturn `xml:"return"`
+		} `xml:",any"`
+	} `xml:"Body"`
+}
+
+type bsplayerReturn struct {
+	Result struct {
+		Code string `xml:"result"`
+	} `xml:"result"`
+	Data struct {
+		Text  string         `xml:",chardata"`
+		Items []bsplayerItem `xml:"item"`
+	} `xml:"data"`
+}
+
+type bsplayerItem struct {
+	ID           string  `xml:"subID"`
+	DownloadLink string  `xml:"subDownloadLink"`
+	Language     string  `xml:"subLang"`
+	Name         string  `xml:"subName"`
+	Format       string  `xml:"subFormat"`
+	Hash         string  `xml:"subHash"`
+	Rating       float64 `xml:"subRating"`
+	IMDBID       string  `xml:"movieIMDBID"`
+	MovieName    string  `xml:"movieName"`
+	MovieYear    int     `xml:"movieYear"`
+	MovieFPS     float64 `xml:"movieFPS"`
+}
+
+func NewBSPlayerClient(config *Config) *BSPlayerClient {
+	endpoints := []string{config.BaseURL}
+	next := 0
+	if config.BaseURL == "" {
+		endpoints = make([]string, bsplayerServers)
+		for i := range endpoints {
+			endpoints[i] = fmt.Sprintf(bsplayerEndpoint, i+1)
+		}
+		next = rand.IntN(len(endpoints))
+	}
+
+	client := newHTTPClient(config, endpoints[0])
+	client.SetHeader("Content-Type", "text/xml; charset=utf-8")
+	return &BSPlayerClient{client: client, config: config, endpoints: endpoints, next: next}
+}
+
+func (c *BSPlayerClient) call(ctx context.Context, endpoint, method string, params ...bsplayerParam) (*bsplayerReturn, error) {
+	var body bytes.Buffer
+	body.WriteString(`<?xml version="1.0" encoding="UTF-8"?>`)
+	body.WriteString(`<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://schemas.xmlsoap.org/soap/envelope/" xmlns:ns1="` + bsplayerNamespace + `">`)
+	body.WriteString(`<SOAP-ENV:Body><ns1:` + method + `>`)
+	for _, param := range params {
+		body.WriteString("<" + param.name + ">")
+		xml.EscapeText(&body, []byte(param.value))
+		body.WriteString("</" + param.name + ">")
+	}
+	body.WriteString(`</ns1:` + method + `></SOAP-ENV:Body></SOAP-ENV:Envelope>`)
+
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetHeader("SOAPAction", `"`+bsplayerNamespace+"#"+method+`"`).
+		SetBody(body.Bytes()).
+		Post(endpoint)
+	if err != nil {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("bsplayer %s request failed: %w", method, err))
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, statusError(resp.StatusCode(), fmt.Errorf("bsplayer %s failed with status %d", method, resp.StatusCode()))
+	}
+
+	var envelope bsplayerEnvelope
+	if err := xml.Unmarshal(resp.Body(), &envelope); err != nil {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("failed to parse bsplayer %s response: %w", method, err))
+	}
+	return &envelope.Body.Response.Return, nil
+}
+
+func (c *BSPlayerClient) Authenticate(ctx context.Context) error {
+	_, _, err := c.session(ctx)
+	return err
+}
+
+func (c *BSPlayerClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.handle == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+	defer cancel()
+	_, err := c.call(ctx, c.endpoint, "logOut", bsplayerParam{"handle", c.handle})
+	c.handle = ""
+	return err
+}
+
+func (c *BSPlayerClient) Capabilities() models.Capabilities {
+	return models.Capabilities{HashSearch: true}
+}
+
+func (c *BSPlayerClient) session(ctx context.Context) (string, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.handle != "" {
+		return c.handle, c.endpoint, nil
+	}
+
+	var err error
+	for range c.endpoints {
+		endpoint := c.endpoints[c.next]
+		var result *bsplayerReturn
+		result, err = c.call(ctx, endpoint, "logIn",
+			bsplayerParam{"username", ""},
+			bsplayerParam{"password", ""},
+			bsplayerParam{"AppID", bsplayerAppID},
+		)
+		if errors.Is(err, ErrProviderUnavailable) && ctx.Err() == nil {
+			c.next = (c.next + 1) % len(c.endpoints)
+			continue
+		}
+		if err != nil {
+			return "", "", err
+		}
+		if result.Result.Code != "200" || strings.TrimSpace(result.Data.Text) == "" {
+			return "", "", withKind(ErrAuthFailed, fmt.Errorf("bsplayer login failed with code %s", result.Result.Code))
+		}
+		c.handle, c.endpoint = strings.TrimSpace(result.Data.Text), endpoint
+		return c.handle, c.endpoint, nil
+	}
+	return "", "", err
+}
+
+func (c *BSPlayerClient) Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	var subtitles []*models.Subtitle
+	err := c.config.Breaker.guard(func() (err error) {
+		subtitles, err = c.search(ctx, params)
+		return err
+	})
+	return subtitles, err
+}
+
+func (c *BSPlayerClient) search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	if params.MediaPath == "" {
+		return nil, nil
+	}
+
+	hash, size := params.MovieHash, params.MovieByteSize
+	if hash == "" || size == 0 {
+		var err error
+		if hash, size, err = moviehash.Compute(params.MediaPath); err != nil {
+			return nil, fmt.Errorf("bsplayer cannot hash %s: %w", params.MediaPath, err)
+		}
+	}
+
+	lang := "all"
+	if params.Language != "" {
+		l, ok := language.Lookup(params.Language)
+		if !ok {
+			return nil, nil
+		}
+		lang = l.ISO6392
+	}
+
+	handle, endpoint, err := c.session(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.call(ctx, endpoint, "searchSubtitles",
+		bsplayerParam{"handle", handle},
+		bsplayerParam{"movieHash", hash},
+		bsplayerParam{"movieSize", strconv.FormatInt(size, 10)},
+		bsplayerParam{"languageId", lang},
+		bsplayerParam{"imdbId", "*"},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if result.Result.Code != "200" {
+		return nil, nil
+	}
+
+	subtitles := make([]*models.Subtitle, 0, len(result.Data.Items))
+	for _, item := range result.Data.Items {
+		if item.DownloadLink == "" {
+			continue
+		}
+		itemLang := item.Language
+		if l, ok := language.Lookup(item.Language); ok {
+			itemLang = l.Code
+		}
+		subtitle := &models.Subtitle{
+			ID:             item.ID,
+			Provider:       ProviderBSPlayer,
+			Language:       itemLang,
+			ReleaseName:    strings.TrimSuffix(item.Name, "."+item.Format),
+			FileName:       item.Name,
+			FileID:         item.DownloadLink,
+			Rating:         item.Rating,
+			MovieHash:      hash,
+			MovieHashMatch: true,
+			FPS:            item.MovieFPS,
+			SubFormat:      subformat.FromFileName(item.Name),
+			FeatureTitle:   item.MovieName,
+			FeatureType:    params.Type,
+			FeatureYear:    item.MovieYear,
+		}
+		if imdb, err := strconv.Atoi(strings.TrimPrefix(item.IMDBID, "tt")); err == nil {
+			subtitle.IMDBID = imdb
+		}
+		subtitles = append(subtitles, subtitle)
+	}
+	return subtitles, nil
+}
+
+func (c *BSPlayerClient) Download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error) {
+	var content []byte
+	err := c.config.Breaker.guard(func() (err error) {
+		content, err = c.download(ctx, subtitle)
+		return err
+	})
+	return content, err
+}
+
+func (c *BSPlayerClient) download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error) {
+	resp, err := c.client.R().SetContext(ctx).Get(subtitle.FileID)
+	if err != nil {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("bsplayer download failed: %w", err))
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, statusError(resp.StatusCode(), fmt.Errorf("bsplayer download failed with status %d", resp.StatusCode()))
+	}
+	return unpackSubtitle(resp.Body(), subtitle.FileName)
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bsplayerResponse(method, inner string) string {
+	return `<?xml version="1.0" encoding="UTF-8"?><SOAP-ENV:Envelope xmlns:SOAP-ENV="http://schemas.xmlsoap.org/soap/envelope/" xmlns:ns1="http://api.bsplayer-subtitles.com/v1.php"><SOAP-ENV:Body><ns1:` + method + `Response><return>` + inner + `</return></ns1:` + method + `Response></SOAP-ENV:Body></SOAP-ENV:Envelope>`
+}
+
+func TestBSPlayerClient(t *testing.T) {
+	t.Parallel()
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	gz.Write([]byte("1\n00:00:01,000 --> 00:00:02,000\nHello\n"))
+	require.NoError(t, gz.Close())
+
+	var logins, logouts atomic.Int32
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			assert.Equal(t, "/download/42", r.URL.Path)
+			w.Write(compressed.Bytes())
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		switch r.Header.Get("SOAPAction") {
+		case `"http://api.bsplayer-subtitles.com/v1.php#logIn"`:
+			logins.Add(1)
+			assert.Contains(t, string(body), "<AppID>BSPlayer v2.67</AppID>")
+			w.Write([]byte(bsplayerResponse("logIn", `<result><result>200</result></result><data>session-1</data>`)))
+		case `"http://api.bsplayer-subtitles.com/v1.php#logOut"`:
+			logouts.Add(1)
+			assert.Contains(t, string(body), "<handle>session-1</handle>")
+			w.Write([]byte(bsplayerResponse("logOut", `<result><result>200</result></result>`)))
+		case `"http://api.bsplayer-subtitles.com/v1.php#searchSubtitles"`:
+			assert.Contains(t, string(body), "<handle>session-1</handle>")
+			assert.Contains(t, string(body), "<movieSize>12909756</movieSize>")
+			if !strings.Contains(string(body), "<languageId>eng</languageId>") {
+				w.Write([]byte(bsplayerResponse("searchSubtitles", `<result><result>402</result></result><data></data>`)))
+				return
+			}
+			w.Write([]byte(bsplayerResponse("searchSubtitles", `<result><result>200</result></result><data>`+
+				`<item><subID>42</subID><subDownloadLink>`+server.URL+`/download/42</subDownloadLink><subLang>eng</subLang><subName>Breakdance.srt</subName><subFormat>srt</subFormat><subRating>4.5</subRating><movieIMDBID>tt0086998</movieIMDBID><movieName>Breakin'</movieName><movieYear>1984</movieYear></item>`+
+				`<item><subID>43</subID><subDownloadLink></subDownloadLink><subLang>eng</subLang><subName>broken.srt</subName></item>`+
+				`</data>`)))
+		default:
+			t.Errorf("unexpected action %s", r.Header.Get("SOAPAction"))
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewBSPlayerClient(&Config{BaseURL: server.URL})
+	ctx := context.Background()
+	params := &models.SearchParams{Language: "en", MediaPath: "/media/Breakdance.avi", MovieHash: "8e245d9679d31e12", MovieByteSize: 12909756, Type: "movie"}
+
+	subtitles, err := client.Search(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, subtitles, 1)
+	assert.Equal(t, ProviderBSPlayer, subtitles[0].Provider)
+	assert.Equal(t, "en", subtitles[0].Language)
+	assert.Equal(t, "Breakdance", subtitles[0].ReleaseName)
+	assert.Equal(t, "srt", subtitles[0].SubFormat)
+	assert.Equal(t, 86998, subtitles[0].IMDBID)
+	assert.Equal(t, 1984, subtitles[0].FeatureYear)
+	assert.True(t, subtitles[0].MovieHashMatch)
+
+	content, err := client.Download(ctx, subtitles[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "Hello")
+
+	subtitles, err = client.Search(ctx, &models.SearchParams{Language: "fr", MediaPath: "/media/Breakdance.avi", MovieHash: "8e245d9679d31e12", MovieByteSize: 12909756})
+	require.NoError(t, err)
+	assert.Empty(t, subtitles)
+	assert.Equal(t, int32(1), logins.Load(), "the session is reused")
+
+	subtitles, err = client.Search(ctx, &models.SearchParams{Query: "Breakdance", Language: "en"})
+	require.NoError(t, err)
+	assert.Empty(t, subtitles, "hash-only providers skip title searches")
+
+	_, err = client.Search(ctx, &models.SearchParams{Language: "en", MediaPath: "/media/missing.avi"})
+	assert.ErrorContains(t, err, "bsplayer cannot hash /media/missing.avi")
+
+	require.NoError(t, client.Close())
+	require.NoError(t, client.Close())
+	assert.Equal(t, int32(1), logouts.Load(), "the session is closed once")
+}
+
+func TestBSPlayerEndpoints(t *testing.T) {
+	t.Parallel()
+
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(down.Close)
+	var up atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		up.Add(1)
+		w.Write([]byte(bsplayerResponse("logIn", `<result><result>200</result></result><data>session-1</data>`)))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewBSPlayerClient(&Config{BaseURL: down.URL})
+	client.endpoints = []string{down.URL, server.URL}
+	require.NoError(t, client.Authenticate(context.Background()), "a server that is down is skipped")
+	assert.Equal(t, server.URL, client.endpoint)
+	assert.Equal(t, int32(1), up.Load())
+
+	defaults := NewBSPlayerClient(&Config{})
+	require.Len(t, defaults.endpoints, 8)
+	assert.Equal(t, "http://s1.api.bsplayer-subtitles.com/v1.php", defaults.endpoints[0])
+}
+
+func TestBSPlayerLoginFailure(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(bsplayerResponse("logIn", `<result><result>401</result></result><data></data>`)))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewBSPlayerClient(&Config{BaseURL: server.URL})
+	assert.ErrorIs(t, client.Authenticate(context.Background()), ErrAuthFailed)
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var ErrBudgetExhausted = errors.New("request budget used up")
+
+type Budget struct {
+	mu     sync.Mutex
+	limit  int
+	used   int
+	denied bool
+}
+
+func NewBudget(limit int) *Budget {
+	return &Budget{limit: limit}
+}
+
+func (b *Budget) Limit() int {
+	return b.limit
+}
+
+func (b *Budget) Used() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.used
+}
+
+func (b *Budget) Exhausted() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.used >= b.limit
+}
+
+func (b *Budget) Denied() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.denied
+}
+
+func (b *Budget) take() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.used >= b.limit {
+		b.denied = true
+		return fmt.Errorf("%w (%d of %d requests made)", ErrBudgetExhausted, b.used, b.limit)
+	}
+	b.used++
+	return nil
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func TestBudget(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total_pages":1,"page":1,"data":[]}`))
+	}))
+	defer server.Close()
+
+	budget := NewBudget(2)
+	breaker := NewBreaker(1, 0)
+	client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, APIKey: "key", Budget: budget, Breaker: breaker})
+	params := &models.SearchParams{Query: "Movie", Language: "en"}
+
+	for range 2 {
+		_, err := client.Search(context.Background(), params)
+		require.NoError(t, err)
+	}
+	assert.True(t, budget.Exhausted())
+	assert.False(t, budget.Denied())
+
+	_, err := client.Search(context.Background(), params)
+	assert.ErrorIs(t, err, ErrBudgetExhausted)
+	assert.ErrorContains(t, err, "2 of 2 requests made")
+	assert.True(t, budget.Denied())
+	assert.Equal(t, 2, budget.Used())
+	assert.Equal(t, 2, requests)
+	assert.False(t, breaker.Open(), "a refused request is not a provider failure")
+}
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"slices"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func CapabilitiesOf(client Client) models.Capabilities {
+	if capable, ok := client.(Capable); ok {
+		return capable.Capabilities()
+	}
+	return models.Capabilities{TitleSearch: true}
+}
+
+func Accepts(caps models.Capabilities, params *models.SearchParams) bool {
+	if !caps.TitleSearch && params.MediaPath == "" && params.MovieHash == "" {
+		return false
+	}
+	if len(caps.Languages) == 0 || params.Language == "" {
+		return true
+	}
+	return slices.Contains(caps.Languages, normalizeLanguage(params.Language))
+}
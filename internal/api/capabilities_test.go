@@ -0,0 +1,42 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapabilitiesOf(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, models.Capabilities{TitleSearch: true}, CapabilitiesOf(&stubClient{}), "clients without capabilities search by title")
+	assert.True(t, CapabilitiesOf(NewOpenSubtitlesClient(&Config{})).NeedsAuth)
+	assert.Equal(t, []string{"pl"}, CapabilitiesOf(NewNapisy24Client(&Config{})).Languages)
+}
+
+func TestAccepts(t *testing.T) {
+	t.Parallel()
+
+	hashOnly := models.Capabilities{HashSearch: true, Languages: []string{"pl", "pt-BR"}}
+	tests := []struct {
+		name   string
+		caps   models.Capabilities
+		params models.SearchParams
+		want   bool
+	}{
+		{"title provider", models.Capabilities{TitleSearch: true}, models.SearchParams{Query: "Psy", Language: "de"}, true},
+		{"hash provider without a file", hashOnly, models.SearchParams{Query: "Psy", Language: "pl"}, false},
+		{"hash provider with a file", hashOnly, models.SearchParams{MediaPath: "/media/Psy.avi", Language: "pl"}, true},
+		{"hash provider with a hash", hashOnly, models.SearchParams{MovieHash: "8e245d9679d31e12", Language: "pl"}, true},
+		{"language is normalized", hashOnly, models.SearchParams{MediaPath: "/media/Psy.avi", Language: "pob"}, true},
+		{"unsupported language", hashOnly, models.SearchParams{MediaPath: "/media/Psy.avi", Language: "en"}, false},
+		{"any language", hashOnly, models.SearchParams{MediaPath: "/media/Psy.avi"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, Accepts(tt.caps, &tt.params))
+		})
+	}
+}
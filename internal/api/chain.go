@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+type ChainClient struct {
+	tiers []Provider
+}
+
+func NewChainClient(tiers ...Provider) *ChainClient {
+	return &ChainClient{tiers: tiers}
+}
+
+func (c *ChainClient) Authenticate(ctx context.Context) error {
+	for _, tier := range c.tiers {
+		if err := tier.Client.Authenticate(ctx); err != nil {
+			return fmt.Errorf("%s: %w", tier.Name, err)
+		}
+	}
+	return nil
+}
+
+func (c *ChainClient) Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	failed := make(map[string]error)
+	var subtitles []*models.Subtitle
+	for _, tier := range c.tiers {
+		query := *params
+		found, err := tier.Client.Search(ctx, &query)
+
+		var partial *PartialResultsError
+		switch {
+		case errors.As(err, &partial):
+			for name, providerErr := range partial.Failed {
+				failed[name] = providerErr
+			}
+		case err != nil:
+			failed[tier.Name] = err
+		}
+
+		if len(found) > 0 {
+			subtitles = found
+			break
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	if len(failed) == 0 {
+		return subtitles, nil
+	}
+	if len(subtitles) == 0 && len(failed) == 1 {
+		for _, err := range failed {
+			return nil, err
+		}
+	}
+	return subtitles, &PartialResultsError{Failed: failed}
+}
+
+func (c *ChainClient) Download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error) {
+	if len(c.tiers) == 0 {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("unknown provider '%s'", subtitle.Provider))
+	}
+	for _, tier := range c.tiers {
+		if subtitle.Provider == tier.Name {
+			return tier.Client.Download(ctx, subtitle)
+		}
+	}
+	return c.tiers[len(c.tiers)-1].Client.Download(ctx, subtitle)
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainClient(t *testing.T) {
+	t.Parallel()
+
+	first := Provider{Name: "first", Client: &stubClient{name: "first"}}
+	second := Provider{Name: "second", Client: &stubClient{name: "second"}}
+	broken := Provider{Name: "broken", Client: &stubClient{name: "broken", err: errors.New("status 500")}}
+
+	t.Run("stops at the first tier with results", func(t *testing.T) {
+		t.Parallel()
+
+		subs, err := NewChainClient(first, second).Search(context.Background(), &models.SearchParams{Language: "en"})
+		require.NoError(t, err)
+		require.Len(t, subs, 1)
+		assert.Equal(t, "first-en", subs[0].ID)
+	})
+
+	t.Run("falls through empty tiers", func(t *testing.T) {
+		t.Parallel()
+
+		empty := Provider{Name: ProviderLocal, Client: NewLocalClient(t.TempDir())}
+		subs, err := NewChainClient(empty, second).Search(context.Background(), &models.SearchParams{Query: "Movie", Language: "en"})
+		require.NoError(t, err)
+		require.Len(t, subs, 1)
+		assert.Equal(t, "second-en", subs[0].ID)
+	})
+
+	t.Run("reports failed tiers alongside results", func(t *testing.T) {
+		t.Parallel()
+
+		subs, err := NewChainClient(broken, second).Search(context.Background(), &models.SearchParams{Language: "en"})
+		require.Len(t, subs, 1)
+		var partial *PartialResultsError
+		require.ErrorAs(t, err, &partial)
+		assert.Contains(t, partial.Failed, "broken")
+	})
+
+	t.Run("returns the only error when nothing is found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewChainClient(broken).Search(context.Background(), &models.SearchParams{Language: "en"})
+		assert.EqualError(t, err, "status 500")
+	})
+
+	t.Run("routes downloads by provider", func(t *testing.T) {
+		t.Parallel()
+
+		chain := NewChainClient(first, second)
+		content, err := chain.Download(context.Background(), &models.Subtitle{Provider: "first"})
+		require.NoError(t, err)
+		assert.Equal(t, "first", string(content))
+
+		content, err = chain.Download(context.Background(), &models.Subtitle{Provider: ProviderOpenSubtitles})
+		require.NoError(t, err)
+		assert.Equal(t, "second", string(content))
+	})
+}
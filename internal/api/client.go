@@ -2,6 +2,8 @@ package api
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/carlosarraes/subs-cli/pkg/models"
 )
@@ -12,10 +14,45 @@ type Client interface {
 	Authenticate(ctx context.Context) error
 }
 
+type Voter interface {
+	Vote(ctx context.Context, subtitle *models.Subtitle, good bool) error
+}
+
+type Capable interface {
+	Capabilities() models.Capabilities
+}
+
+type Quota struct {
+	Allowed   int
+	Remaining int
+}
+
+type QuotaReporter interface {
+	Quota(ctx context.Context) (*Quota, error)
+}
+
 type Config struct {
 	APIKey    string
 	UserAgent string
 	BaseURL   string
 	Username  string
 	Password  string
-}
\ No newline at end of file
+	Proxy     string
+	NoProxy   bool
+
+	Timeout      time.Duration
+	DialTimeout  time.Duration
+	MaxIdleConns int
+
+	Debug   io.Writer
+	Breaker *Breaker
+	Budget  *Budget
+
+	Responses ResponseCache
+}
+
+const ProviderOpenSubtitles = "opensubtitles"
+
+func ProviderNames() []string {
+	return []string{ProviderOpenSubtitles, ProviderOpenSubtitlesXMLRPC, ProviderJimaku, ProviderKitsunekko, ProviderNapiprojekt, ProviderNapisy24, ProviderBSPlayer}
+}
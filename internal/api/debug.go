@@ -0,0 +1,118 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	redacted       = "[REDACTED]"
+	debugBodyLimit = 4096
+)
+
+var (
+	sensitiveHeaders = map[string]bool{
+		"Authorization": true,
+		"Api-Key":       true,
+		"Cookie":        true,
+		"Set-Cookie":    true,
+	}
+	sensitiveJSON = regexp.MustCompile(`"(password|token|api_key|apikey|access_token|refresh_token)"(\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	sensitiveXML  = regexp.MustCompile(`(<name>(?:password|token)</name>\s*<value>(?:<string>)?)[^<]*`)
+	sensitiveSOAP = regexp.MustCompile(`(<(?:password|handle)>)[^<]*`)
+	sensitiveForm = regexp.MustCompile(`(^|&)(ap|password)=[^&]*`)
+	rpcParam      = regexp.MustCompile(`(<param>\s*<value>(?:<string>)?)[^<]*`)
+)
+
+func enableDebug(client *resty.Client, w io.Writer) {
+	client.SetPreRequestHook(func(_ *resty.Client, req *http.Request) error {
+		var body []byte
+		if req.GetBody != nil {
+			if reader, err := req.GetBody(); err == nil {
+				body, _ = io.ReadAll(reader)
+				reader.Close()
+			}
+		}
+
+		fmt.Fprintf(w, "--> %s %s\n", req.Method, req.URL)
+		writeHeaders(w, req.Header)
+		writeBody(w, body)
+		return nil
+	})
+
+	client.OnAfterResponse(func(_ *resty.Client, resp *resty.Response) error {
+		fmt.Fprintf(w, "<-- %s %s (%s)\n", resp.Status(), resp.Request.URL, resp.Time().Round(time.Millisecond))
+		writeHeaders(w, resp.Header())
+		writeBody(w, resp.Body())
+		return nil
+	})
+
+	client.OnError(func(req *resty.Request, err error) {
+		fmt.Fprintf(w, "<-- %s %s failed: %v\n", req.Method, req.URL, err)
+	})
+}
+
+func writeHeaders(w io.Writer, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			value = redacted
+		}
+		fmt.Fprintf(w, "    %s: %s\n", name, value)
+	}
+}
+
+func writeBody(w io.Writer, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+
+	body = redactBody(body)
+	suffix := ""
+	if len(body) > debugBodyLimit {
+		suffix = fmt.Sprintf("\n    [%d more bytes]", len(body)-debugBodyLimit)
+		body = body[:debugBodyLimit]
+	}
+	fmt.Fprintf(w, "    %s%s\n", bytes.ReplaceAll(body, []byte("\n"), []byte("\n    ")), suffix)
+}
+
+func redactBody(body []byte) []byte {
+	body = sensitiveJSON.ReplaceAll(body, []byte(`"$1"$2"`+redacted+`"`))
+	body = sensitiveXML.ReplaceAll(body, []byte(`${1}`+redacted))
+	body = sensitiveSOAP.ReplaceAll(body, []byte(`${1}`+redacted))
+	body = sensitiveForm.ReplaceAll(body, []byte(`${1}${2}=`+redacted))
+	return redactRPCParams(body)
+}
+
+func redactRPCParams(body []byte) []byte {
+	if !bytes.Contains(body, []byte("<methodCall>")) {
+		return body
+	}
+
+	secret := 0
+	if bytes.Contains(body, []byte("<methodName>LogIn</methodName>")) {
+		secret = 1
+	}
+	param := 0
+	return rpcParam.ReplaceAllFunc(body, func(match []byte) []byte {
+		defer func() { param++ }()
+		if param != secret {
+			return match
+		}
+		return rpcParam.ReplaceAll(match, []byte(`${1}`+redacted))
+	})
+}
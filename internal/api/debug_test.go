@@ -0,0 +1,70 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactBody(t *testing.T) {
+	t.Parallel()
+
+	body := `{"username":"alice","password":"s3cr\"et","token": "abc.def","status":200}`
+	assert.Equal(t, `{"username":"alice","password":"[REDACTED]","token": "[REDACTED]","status":200}`, string(redactBody([]byte(body))))
+
+	form := "ap=s3cret&fh=8e245d9679d31e12&ua=alice"
+	assert.Equal(t, "ap=[REDACTED]&fh=8e245d9679d31e12&ua=alice", string(redactBody([]byte(form))))
+
+	soap := "<ns1:searchSubtitles><handle>session-1</handle><movieHash>8e245d9679d31e12</movieHash></ns1:searchSubtitles>"
+	assert.Equal(t, "<ns1:searchSubtitles><handle>[REDACTED]</handle><movieHash>8e245d9679d31e12</movieHash></ns1:searchSubtitles>", string(redactBody([]byte(soap))))
+}
+
+func TestRedactXMLRPCBody(t *testing.T) {
+	t.Parallel()
+
+	login, err := encodeCall("LogIn", "alice", "s3cret", "en", "subs-cli")
+	require.NoError(t, err)
+	redactedLogin := string(redactBody(login))
+	assert.NotContains(t, redactedLogin, "s3cret")
+	assert.Contains(t, redactedLogin, "alice")
+
+	search, err := encodeCall("SearchSubtitles", "session-token", []any{map[string]any{"query": "Inception"}})
+	require.NoError(t, err)
+	redactedSearch := string(redactBody(search))
+	assert.NotContains(t, redactedSearch, "session-token")
+	assert.Contains(t, redactedSearch, "Inception")
+
+	response := `<methodResponse><params><param><value><struct><member><name>token</name><value><string>session-token</string></value></member></struct></value></param></params></methodResponse>`
+	assert.NotContains(t, string(redactBody([]byte(response))), "session-token")
+}
+
+func TestDebugHTTP(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		json.NewEncoder(w).Encode(LoginResponse{Token: "jwt-token-value", Status: 200})
+	}))
+	defer server.Close()
+
+	var log bytes.Buffer
+	client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, APIKey: "api-key-value", Username: "alice", Password: "hunter2", Debug: &log})
+	require.NoError(t, client.Authenticate(context.Background()))
+
+	out := log.String()
+	assert.Contains(t, out, "--> POST "+server.URL+"/login")
+	assert.Contains(t, out, "<-- 200 OK")
+	assert.Contains(t, out, `"username":"alice"`)
+	assert.Contains(t, out, "Api-Key: [REDACTED]")
+	assert.Contains(t, out, "Set-Cookie: [REDACTED]")
+	for _, secret := range []string{"hunter2", "jwt-token-value", "api-key-value", "session=secret"} {
+		assert.NotContains(t, out, secret)
+	}
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+var (
+	ErrNotFound            = models.ErrNotFound
+	ErrQuotaExceeded       = models.ErrQuotaExceeded
+	ErrAuthFailed          = models.ErrAuthFailed
+	ErrProviderUnavailable = models.ErrProviderUnavailable
+)
+
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+func withKind(kind, err error) error {
+	return &kindError{kind: kind, err: err}
+}
+
+func statusKind(status int) error {
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return ErrAuthFailed
+	case status == http.StatusNotFound || status == http.StatusGone:
+		return ErrNotFound
+	case status == http.StatusNotAcceptable || status == http.StatusTooManyRequests:
+		return ErrQuotaExceeded
+	case status >= http.StatusInternalServerError:
+		return ErrProviderUnavailable
+	}
+	return nil
+}
+
+func statusError(status int, err error) error {
+	if kind := statusKind(status); kind != nil {
+		return withKind(kind, err)
+	}
+	return err
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func TestErrorKinds(t *testing.T) {
+	t.Parallel()
+
+	newServer := func(t *testing.T, handler http.HandlerFunc) *OpenSubtitlesClient {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			handler(w, r)
+		}))
+		t.Cleanup(server.Close)
+		return NewOpenSubtitlesClient(&Config{BaseURL: server.URL, APIKey: "key"})
+	}
+	subtitle := &models.Subtitle{ID: "1", FileID: "11", FileName: "movie.srt"}
+
+	t.Run("quota", func(t *testing.T) {
+		t.Parallel()
+		client := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotAcceptable)
+			json.NewEncoder(w).Encode(DownloadResponse{Message: "limit reached"})
+		})
+
+		_, err := client.Download(context.Background(), subtitle)
+		assert.ErrorIs(t, err, ErrQuotaExceeded)
+		assert.ErrorContains(t, err, "download limit exceeded")
+	})
+
+	t.Run("auth", func(t *testing.T) {
+		t.Parallel()
+		client := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		})
+
+		_, err := client.Search(context.Background(), &models.SearchParams{Query: "movie"})
+		assert.ErrorIs(t, err, ErrAuthFailed)
+
+		err = NewOpenSubtitlesClient(&Config{}).Authenticate(context.Background())
+		assert.ErrorIs(t, err, ErrAuthFailed)
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		t.Parallel()
+		client := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		})
+
+		_, err := client.Search(context.Background(), &models.SearchParams{Query: "movie"})
+		assert.ErrorIs(t, err, ErrProviderUnavailable)
+		assert.NotErrorIs(t, err, ErrNotFound)
+
+		offline := NewOpenSubtitlesClient(&Config{BaseURL: "http://127.0.0.1:1", APIKey: "key", Timeout: time.Second})
+		_, err = offline.Search(context.Background(), &models.SearchParams{Query: "movie"})
+		assert.ErrorIs(t, err, ErrProviderUnavailable)
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		t.Parallel()
+		client := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/download":
+				json.NewEncoder(w).Encode(DownloadResponse{Link: "http://" + r.Host + "/file"})
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		})
+
+		_, err := client.Download(context.Background(), subtitle)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.EqualError(t, err, "subtitle file download failed with status 404")
+	})
+
+	t.Run("unclassified", func(t *testing.T) {
+		t.Parallel()
+		client := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		})
+
+		_, err := client.Search(context.Background(), &models.SearchParams{Query: "movie"})
+		require.Error(t, err)
+		for _, kind := range []error{ErrNotFound, ErrQuotaExceeded, ErrAuthFailed, ErrProviderUnavailable} {
+			assert.NotErrorIs(t, err, kind)
+		}
+	})
+}
+
+func TestCircuitOpenIsUnavailable(t *testing.T) {
+	breaker := NewBreaker(1, 0)
+	breaker.Record(errors.New("boom"))
+
+	err := breaker.Allow()
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.ErrorIs(t, err, ErrProviderUnavailable)
+	assert.EqualError(t, err, "provider skipped after repeated failures (1 consecutive errors)")
+}
+
+func TestPartialResultsUnwrap(t *testing.T) {
+	err := error(&PartialResultsError{Failed: map[string]error{
+		"one": withKind(ErrQuotaExceeded, errors.New("limit")),
+		"two": errors.New("other"),
+	}})
+
+	assert.ErrorIs(t, err, ErrQuotaExceeded)
+	assert.NotErrorIs(t, err, ErrAuthFailed)
+}
+
+func TestStatusKind(t *testing.T) {
+	assert.Equal(t, ErrAuthFailed, statusKind(http.StatusForbidden))
+	assert.Equal(t, ErrNotFound, statusKind(http.StatusGone))
+	assert.Equal(t, ErrQuotaExceeded, statusKind(http.StatusTooManyRequests))
+	assert.Equal(t, ErrProviderUnavailable, statusKind(http.StatusServiceUnavailable))
+	assert.Nil(t, statusKind(http.StatusBadRequest))
+	assert.Nil(t, statusKind(http.StatusOK))
+}
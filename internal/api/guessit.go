@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+type guessitNumber int
+
+func (n *guessitNumber) UnmarshalJSON(data []byte) error {
+	var many []int
+	if json.Unmarshal(data, &many) == nil {
+		if len(many) > 0 {
+			*n = guessitNumber(many[0])
+		}
+		return nil
+	}
+	var one int
+	if json.Unmarshal(data, &one) == nil {
+		*n = guessitNumber(one)
+	}
+	return nil
+}
+
+type GuessitResponse struct {
+	Title      string        `json:"title"`
+	Year       guessitNumber `json:"year"`
+	Season     guessitNumber `json:"season"`
+	Episode    guessitNumber `json:"episode"`
+	ScreenSize string        `json:"screen_size"`
+	VideoCodec string        `json:"video_codec"`
+	Type       string        `json:"type"`
+}
+
+func (c *OpenSubtitlesClient) Guessit(ctx context.Context, filename string) (*models.MediaInfo, error) {
+	var guess GuessitResponse
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetQueryParam("filename", filename).
+		SetResult(&guess).
+		Get("/utilities/guessit")
+
+	if err != nil {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("guessit request failed: %w", err))
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, statusError(resp.StatusCode(), fmt.Errorf("guessit request failed with status %d: %s", resp.StatusCode(), resp.String()))
+	}
+
+	if guess.Title == "" {
+		return nil, nil
+	}
+	info := &models.MediaInfo{
+		Title:   guess.Title,
+		Quality: guess.ScreenSize,
+		Codec:   guess.VideoCodec,
+		Type:    "movie",
+	}
+	if guess.Year > 0 {
+		info.Year = strconv.Itoa(int(guess.Year))
+	}
+	if guess.Type == "episode" {
+		if guess.Season <= 0 || guess.Episode <= 0 {
+			return nil, nil
+		}
+		info.Type, info.Season, info.Episode = "episode", int(guess.Season), int(guess.Episode)
+	}
+	return info, nil
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func TestOpenSubtitlesClient_Guessit(t *testing.T) {
+	t.Parallel()
+
+	responses := map[string]string{
+		"Show - 2x05 - Title [1080p].mkv": `{"title":"Show","season":2,"episode":[5,6],"screen_size":"1080p","video_codec":"H.265","type":"episode"}`,
+		"Film (2019) remux.mkv":           `{"title":"Film","year":2019,"type":"movie"}`,
+		"Show - Pilot.mkv":                `{"title":"Show","episode_title":"Pilot","type":"episode"}`,
+		"noise.mkv":                       `{"type":"movie"}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/utilities/guessit", r.URL.Path)
+		assert.Equal(t, "key", r.Header.Get("Api-Key"))
+		response, ok := responses[r.URL.Query().Get("filename")]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, APIKey: "key"})
+	info, err := client.Guessit(context.Background(), "Show - 2x05 - Title [1080p].mkv")
+	require.NoError(t, err)
+	assert.Equal(t, &models.MediaInfo{Title: "Show", Season: 2, Episode: 5, Quality: "1080p", Codec: "H.265", Type: "episode"}, info)
+
+	info, err = client.Guessit(context.Background(), "Film (2019) remux.mkv")
+	require.NoError(t, err)
+	assert.Equal(t, &models.MediaInfo{Title: "Film", Year: "2019", Type: "movie"}, info)
+
+	for _, name := range []string{"Show - Pilot.mkv", "noise.mkv"} {
+		info, err = client.Guessit(context.Background(), name)
+		require.NoError(t, err)
+		assert.Nil(t, info, name)
+	}
+
+	_, err = client.Guessit(context.Background(), "unknown.mkv")
+	assert.ErrorContains(t, err, "guessit request failed with status 400")
+}
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/carlosarraes/subs-cli/internal/subformat"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+const (
+	ProviderJimaku       = "jimaku"
+	DefaultJimakuBaseURL = "https://jimaku.cc/api"
+)
+
+type JimakuClient struct {
+	client *resty.Client
+	config *Config
+}
+
+type jimakuEntry struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	EnglishName  string `json:"english_name"`
+	JapaneseName string `json:"japanese_name"`
+}
+
+type jimakuFile struct {
+	URL          string    `json:"url"`
+	Name         string    `json:"name"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+func NewJimakuClient(config *Config) *JimakuClient {
+	client := newHTTPClient(config, DefaultJimakuBaseURL)
+	client.SetBaseURL(config.BaseURL)
+	if config.APIKey != "" {
+		client.SetHeader("Authorization", config.APIKey)
+	}
+	return &JimakuClient{client: client, config: config}
+}
+
+func (c *JimakuClient) Authenticate(ctx context.Context) error {
+	if c.config.APIKey == "" {
+		return withKind(ErrAuthFailed, fmt.Errorf("jimaku needs an API key"))
+	}
+	return nil
+}
+
+func (c *JimakuClient) Capabilities() models.Capabilities {
+	return models.Capabilities{TitleSearch: true, NeedsAuth: true, Languages: []string{"ja"}}
+}
+
+func (c *JimakuClient) Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	var subtitles []*models.Subtitle
+	err := c.config.Breaker.guard(func() (err error) {
+		subtitles, err = c.search(ctx, params)
+		return err
+	})
+	return subtitles, err
+}
+
+func (c *JimakuClient) search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	if params.Language != "" && normalizeLanguage(params.Language) != "ja" {
+		return nil, nil
+	}
+	if err := c.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	var entries []jimakuEntry
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetQueryParams(map[string]string{"query": params.Query, "anime": "true"}).
+		SetResult(&entries).
+		Get("/entries/search")
+	if err != nil {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("jimaku search failed: %w", err))
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, statusError(resp.StatusCode(), fmt.Errorf("jimaku search failed with status %d", resp.StatusCode()))
+	}
+
+	var subtitles []*models.Subtitle
+	for _, entry := range entries {
+		if !animeTitleMatches(params.Query, entry.Name, entry.EnglishName, entry.JapaneseName) {
+			continue
+		}
+		files, err := c.files(ctx, entry.ID, params.Episode)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if !animeFileMatches(file.Name, params) {
+				continue
+			}
+			subtitles = append(subtitles, &models.Subtitle{
+				ID:           strconv.Itoa(entry.ID) + "/" + file.Name,
+				Provider:     ProviderJimaku,
+				Language:     "ja",
+				ReleaseName:  strings.TrimSuffix(file.Name, path.Ext(file.Name)),
+				FileName:     file.Name,
+				FileID:       file.URL,
+				UploadDate:   file.LastModified,
+				SubFormat:    subformat.FromFileName(file.Name),
+				URL:          file.URL,
+				FeatureTitle: entry.Name,
+				FeatureType:  params.Type,
+			})
+		}
+	}
+	return subtitles, nil
+}
+
+func (c *JimakuClient) files(ctx context.Context, entryID, episode int) ([]jimakuFile, error) {
+	request := c.client.R().SetContext(ctx)
+	if episode > 0 {
+		request.SetQueryParam("episode", strconv.Itoa(episode))
+	}
+
+	var files []jimakuFile
+	resp, err := request.SetResult(&files).Get(fmt.Sprintf("/entries/%d/files", entryID))
+	if err != nil {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("jimaku file listing failed: %w", err))
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, statusError(resp.StatusCode(), fmt.Errorf("jimaku file listing failed with status %d", resp.StatusCode()))
+	}
+	return files, nil
+}
+
+func (c *JimakuClient) Download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error) {
+	var content []byte
+	err := c.config.Breaker.guard(func() (err error) {
+		content, err = c.download(ctx, subtitle)
+		return err
+	})
+	return content, err
+}
+
+func (c *JimakuClient) download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error) {
+	resp, err := c.client.R().SetContext(ctx).Get(subtitle.FileID)
+	if err != nil {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("jimaku download failed: %w", err))
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, statusError(resp.StatusCode(), fmt.Errorf("jimaku download failed with status %d", resp.StatusCode()))
+	}
+	return unpackSubtitle(resp.Body(), subtitle.FileName)
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJimakuClient(t *testing.T) {
+	t.Parallel()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "jimaku-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/entries/search":
+			assert.Equal(t, "Frieren Beyond Journeys End", r.URL.Query().Get("query"))
+			json.NewEncoder(w).Encode([]map[string]any{
+				{"id": 7, "name": "Sousou no Frieren", "english_name": "Frieren: Beyond Journey's End"},
+				{"id": 8, "name": "Something Else"},
+			})
+		case "/api/entries/7/files":
+			assert.Equal(t, "5", r.URL.Query().Get("episode"))
+			json.NewEncoder(w).Encode([]map[string]any{
+				{"url": server.URL + "/entry/7/download/Frieren%20-%2005.ass", "name": "Frieren - 05.ass", "last_modified": "2023-10-06T12:00:00Z"},
+				{"url": server.URL + "/entry/7/download/Frieren%2001-28.zip", "name": "Frieren 01-28.zip"},
+			})
+		case "/entry/7/download/Frieren - 05.ass":
+			w.Write([]byte("[Script Info]\n"))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewJimakuClient(&Config{BaseURL: server.URL + "/api", APIKey: "jimaku-key"})
+	ctx := context.Background()
+
+	subtitles, err := client.Search(ctx, &models.SearchParams{Query: "Frieren Beyond Journeys End", Season: 1, Episode: 5, Type: "episode", Language: "ja"})
+	require.NoError(t, err)
+	require.Len(t, subtitles, 1)
+	assert.Equal(t, ProviderJimaku, subtitles[0].Provider)
+	assert.Equal(t, "ja", subtitles[0].Language)
+	assert.Equal(t, "ass", subtitles[0].SubFormat)
+	assert.Equal(t, "Sousou no Frieren", subtitles[0].FeatureTitle)
+	assert.Equal(t, 2023, subtitles[0].UploadDate.Year())
+
+	content, err := client.Download(ctx, subtitles[0])
+	require.NoError(t, err)
+	assert.Equal(t, "[Script Info]\n", string(content))
+
+	subtitles, err = client.Search(ctx, &models.SearchParams{Query: "Frieren", Language: "en"})
+	require.NoError(t, err)
+	assert.Empty(t, subtitles, "jimaku only has Japanese subtitles")
+
+	unauthorized := NewJimakuClient(&Config{BaseURL: server.URL + "/api", APIKey: "wrong"})
+	_, err = unauthorized.Search(ctx, &models.SearchParams{Query: "Frieren", Language: "ja"})
+	assert.ErrorIs(t, err, ErrAuthFailed)
+
+	_, err = NewJimakuClient(&Config{BaseURL: server.URL + "/api"}).Search(ctx, &models.SearchParams{Query: "Frieren", Language: "ja"})
+	assert.ErrorIs(t, err, ErrAuthFailed)
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/carlosarraes/subs-cli/internal/subformat"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+const (
+	ProviderKitsunekko       = "kitsunekko"
+	DefaultKitsunekkoBaseURL = "https://kitsunekko.net"
+)
+
+var (
+	kitsunekkoDirs = map[string]string{
+		"ja":    "subtitles/japanese/",
+		"en":    "subtitles/",
+		"zh-CN": "subtitles/chinese/",
+	}
+	kitsunekkoFolder = regexp.MustCompile(`<a href="dirlist\.php\?dir=([^"]+)"[^>]*>\s*(?:<strong>)?([^<]+)`)
+	kitsunekkoFile   = regexp.MustCompile(`<a href="(subtitles/[^"]+)"`)
+)
+
+type KitsunekkoClient struct {
+	client *resty.Client
+	config *Config
+
+	mu      sync.Mutex
+	folders map[string][]kitsunekkoFolderEntry
+}
+
+type kitsunekkoFolderEntry struct {
+	title string
+	dir   string
+}
+
+func NewKitsunekkoClient(config *Config) *KitsunekkoClient {
+	client := newHTTPClient(config, DefaultKitsunekkoBaseURL)
+	client.SetBaseURL(config.BaseURL)
+	return &KitsunekkoClient{client: client, config: config, folders: make(map[string][]kitsunekkoFolderEntry)}
+}
+
+func (c *KitsunekkoClient) Authenticate(ctx context.Context) error {
+	return nil
+}
+
+func (c *KitsunekkoClient) Capabilities() models.Capabilities {
+	return models.Capabilities{TitleSearch: true, Languages: []string{"ja", "en", "zh-CN"}}
+}
+
+func (c *KitsunekkoClient) Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	var subtitles []*models.Subtitle
+	err := c.config.Breaker.guard(func() (err error) {
+		subtitles, err = c.search(ctx, params)
+		return err
+	})
+	return subtitles, err
+}
+
+func (c *KitsunekkoClient) search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	lang := normalizeLanguage(params.Language)
+	if lang == "" {
+		lang = "ja"
+	}
+	root, ok := kitsunekkoDirs[lang]
+	if !ok {
+		return nil, nil
+	}
+
+	folders, err := c.listFolders(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+
+	var subtitles []*models.Subtitle
+	for _, folder := range folders {
+		if !animeTitleMatches(params.Query, folder.title) {
+			continue
+		}
+		page, err := c.get(ctx, "/dirlist.php?dir="+url.QueryEscape(folder.dir))
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range kitsunekkoFile.FindAllStringSubmatch(string(page), -1) {
+			file := html.UnescapeString(m[1])
+			name := path.Base(file)
+			if !animeFileMatches(name, params) {
+				continue
+			}
+			subtitles = append(subtitles, &models.Subtitle{
+				ID:           file,
+				Provider:     ProviderKitsunekko,
+				Language:     lang,
+				ReleaseName:  strings.TrimSuffix(name, path.Ext(name)),
+				FileName:     name,
+				FileID:       file,
+				SubFormat:    subformat.FromFileName(name),
+				URL:          c.config.BaseURL + "/" + file,
+				FeatureTitle: folder.title,
+				FeatureType:  params.Type,
+			})
+		}
+	}
+	return subtitles, nil
+}
+
+func (c *KitsunekkoClient) listFolders(ctx context.Context, root string) ([]kitsunekkoFolderEntry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if folders, ok := c.folders[root]; ok {
+		return folders, nil
+	}
+
+	page, err := c.get(ctx, "/dirlist.php?dir="+url.QueryEscape(root))
+	if err != nil {
+		return nil, err
+	}
+
+	var folders []kitsunekkoFolderEntry
+	for _, m := range kitsunekkoFolder.FindAllStringSubmatch(string(page), -1) {
+		dir, err := url.QueryUnescape(html.UnescapeString(m[1]))
+		if err != nil || dir == root || !strings.HasPrefix(dir, root) {
+			continue
+		}
+		folders = append(folders, kitsunekkoFolderEntry{title: strings.TrimSpace(html.UnescapeString(m[2])), dir: dir})
+	}
+	c.folders[root] = folders
+	return folders, nil
+}
+
+func (c *KitsunekkoClient) get(ctx context.Context, target string) ([]byte, error) {
+	resp, err := c.client.R().SetContext(ctx).Get(target)
+	if err != nil {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("kitsunekko request failed: %w", err))
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, statusError(resp.StatusCode(), fmt.Errorf("kitsunekko request failed with status %d", resp.StatusCode()))
+	}
+	return resp.Body(), nil
+}
+
+func (c *KitsunekkoClient) Download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error) {
+	var content []byte
+	err := c.config.Breaker.guard(func() (err error) {
+		content, err = c.download(ctx, subtitle)
+		return err
+	})
+	return content, err
+}
+
+func (c *KitsunekkoClient) download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error) {
+	if !strings.HasPrefix(subtitle.FileID, "subtitles/") {
+		return nil, withKind(ErrNotFound, fmt.Errorf("invalid kitsunekko file '%s'", subtitle.FileID))
+	}
+
+	segments := strings.Split(subtitle.FileID, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	content, err := c.get(ctx, "/"+strings.Join(segments, "/"))
+	if err != nil {
+		return nil, err
+	}
+	return unpackSubtitle(content, subtitle.FileName)
+}
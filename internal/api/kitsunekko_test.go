@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKitsunekkoClient(t *testing.T) {
+	t.Parallel()
+
+	var listings atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/dirlist.php" && r.URL.Query().Get("dir") == "subtitles/japanese/":
+			listings.Add(1)
+			w.Write([]byte(`<table>
+				<tr><td><a href="dirlist.php?dir=subtitles%2Fjapanese%2FSousou+no+Frieren%2F" class=""><strong>Sousou no Frieren</strong></a></td></tr>
+				<tr><td><a href="dirlist.php?dir=subtitles%2Fjapanese%2FAkira%2F" class=""><strong>Akira</strong></a></td></tr>
+			</table>`))
+		case r.URL.Path == "/dirlist.php" && r.URL.Query().Get("dir") == "subtitles/japanese/Sousou no Frieren/":
+			w.Write([]byte(`<table>
+				<tr><td><a href="subtitles/japanese/Sousou no Frieren/[Sub] Sousou no Frieren - 05.ass" class="">x</a></td></tr>
+				<tr><td><a href="subtitles/japanese/Sousou no Frieren/[Sub] Sousou no Frieren - 06.ass" class="">x</a></td></tr>
+				<tr><td><a href="subtitles/japanese/Sousou no Frieren/Frieren.rar" class="">x</a></td></tr>
+			</table>`))
+		case r.URL.Path == "/subtitles/japanese/Sousou no Frieren/[Sub] Sousou no Frieren - 05.ass":
+			w.Write([]byte("[Script Info]\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewKitsunekkoClient(&Config{BaseURL: server.URL})
+	ctx := context.Background()
+
+	subtitles, err := client.Search(ctx, &models.SearchParams{Query: "Sousou no Frieren", Season: 1, Episode: 5, Language: "ja"})
+	require.NoError(t, err)
+	require.Len(t, subtitles, 1)
+	assert.Equal(t, ProviderKitsunekko, subtitles[0].Provider)
+	assert.Equal(t, "ja", subtitles[0].Language)
+	assert.Equal(t, "[Sub] Sousou no Frieren - 05.ass", subtitles[0].FileName)
+
+	content, err := client.Download(ctx, subtitles[0])
+	require.NoError(t, err)
+	assert.Equal(t, "[Script Info]\n", string(content))
+
+	subtitles, err = client.Search(ctx, &models.SearchParams{Query: "Sousou no Frieren", Season: 1, Episode: 6, Language: "ja"})
+	require.NoError(t, err)
+	assert.Len(t, subtitles, 1)
+	assert.Equal(t, int32(1), listings.Load(), "the folder listing is fetched once")
+
+	subtitles, err = client.Search(ctx, &models.SearchParams{Query: "Sousou no Frieren", Episode: 5, Language: "pt-BR"})
+	require.NoError(t, err)
+	assert.Empty(t, subtitles)
+
+	_, err = client.Download(ctx, &models.Subtitle{FileID: "../etc/passwd"})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
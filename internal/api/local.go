@@ -0,0 +1,228 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/carlosarraes/subs-cli/internal/filter"
+	"github.com/carlosarraes/subs-cli/internal/language"
+	"github.com/carlosarraes/subs-cli/internal/parser"
+	"github.com/carlosarraes/subs-cli/internal/subformat"
+	"github.com/carlosarraes/subs-cli/internal/textnorm"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+const ProviderLocal = "local"
+
+var movieHashToken = regexp.MustCompile(`^[0-9a-f]{16}$`)
+
+type LocalClient struct {
+	root string
+
+	once    sync.Once
+	entries []localEntry
+	err     error
+}
+
+type localEntry struct {
+	rel      string
+	release  string
+	language string
+	format   string
+	hash     string
+	media    *models.MediaInfo
+}
+
+func NewLocalClient(root string) *LocalClient {
+	return &LocalClient{root: root}
+}
+
+func (l *LocalClient) Authenticate(ctx context.Context) error {
+	return nil
+}
+
+func (l *LocalClient) Capabilities() models.Capabilities {
+	return models.Capabilities{TitleSearch: true, HashSearch: true}
+}
+
+func (l *LocalClient) Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	l.once.Do(func() {
+		l.entries, l.err = indexArchive(ctx, l.root)
+	})
+	if l.err != nil {
+		return nil, l.err
+	}
+
+	lang := normalizeLanguage(params.Language)
+	var subtitles []*models.Subtitle
+	for _, entry := range l.entries {
+		if lang != "" && entry.language != lang {
+			continue
+		}
+
+		hashMatch := params.MovieHash != "" && entry.hash == strings.ToLower(params.MovieHash)
+		if !hashMatch && !entry.matches(params) {
+			continue
+		}
+
+		subtitles = append(subtitles, &models.Subtitle{
+			ID:             entry.rel,
+			Provider:       ProviderLocal,
+			Language:       entry.language,
+			ReleaseName:    entry.release,
+			FileName:       filepath.Base(entry.rel),
+			FileID:         entry.rel,
+			SubFormat:      entry.format,
+			MovieHash:      entry.hash,
+			MovieHashMatch: hashMatch,
+		})
+	}
+	return subtitles, nil
+}
+
+func (l *LocalClient) Download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error) {
+	if !filepath.IsLocal(subtitle.FileID) {
+		return nil, withKind(ErrNotFound, fmt.Errorf("subtitle '%s' is outside the archive", subtitle.FileID))
+	}
+
+	content, err := os.ReadFile(filepath.Join(l.root, subtitle.FileID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, withKind(ErrNotFound, fmt.Errorf("subtitle '%s' is no longer in the archive", subtitle.FileID))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archived subtitle: %w", err)
+	}
+	return content, nil
+}
+
+func indexArchive(ctx context.Context, root string) ([]localEntry, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("cannot open subtitle archive: %w", err))
+	}
+	if !info.IsDir() {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("subtitle archive '%s' is not a directory", root))
+	}
+
+	p := parser.New()
+	var entries []localEntry
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if path != root && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		format := subformat.FromFileName(d.Name())
+		if format == "" {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil
+		}
+		if entry, ok := newLocalEntry(p, rel, format); ok {
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to index subtitle archive: %w", err)
+	}
+	return entries, nil
+}
+
+func newLocalEntry(p *parser.Parser, rel, format string) (localEntry, bool) {
+	base := filepath.Base(rel)
+	tokens := strings.Split(strings.TrimSuffix(base, filepath.Ext(base)), ".")
+
+	entry := localEntry{rel: rel, format: format}
+	if len(tokens) > 1 {
+		if lang := normalizeLanguage(tokens[len(tokens)-1]); lang != "" {
+			entry.language = lang
+			tokens = tokens[:len(tokens)-1]
+		}
+	}
+	if entry.language == "" {
+		entry.language = normalizeLanguage(filepath.Base(filepath.Dir(rel)))
+	}
+	if entry.language == "" {
+		return entry, false
+	}
+
+	release := tokens[:0:0]
+	for _, token := range tokens {
+		if movieHashToken.MatchString(strings.ToLower(token)) {
+			entry.hash = strings.ToLower(token)
+			continue
+		}
+		release = append(release, token)
+	}
+	entry.release = strings.Join(release, ".")
+
+	if media, err := p.Parse(entry.release + ".mkv"); err == nil {
+		entry.media = media
+	}
+	if entry.media == nil && entry.hash == "" {
+		return entry, false
+	}
+	return entry, true
+}
+
+func (e localEntry) matches(params *models.SearchParams) bool {
+	if e.media == nil || params.Query == "" {
+		return false
+	}
+	if foldTitle(e.media.Title) != foldTitle(params.Query) {
+		return false
+	}
+	if e.media.Season != params.Season || e.media.Episode != params.Episode {
+		return false
+	}
+	if year, _ := strconv.Atoi(e.media.Year); !filter.YearMatches(params.Year, year) {
+		return false
+	}
+	return true
+}
+
+func normalizeLanguage(code string) string {
+	if code == "" {
+		return ""
+	}
+	normalized, err := language.Normalize(code)
+	if err != nil {
+		return ""
+	}
+	return normalized
+}
+
+func foldTitle(title string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, textnorm.Compose(title))
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeArchive(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return root
+}
+
+func TestLocalClient(t *testing.T) {
+	t.Parallel()
+
+	root := writeArchive(t, map[string]string{
+		"Movies/Inception.2010.1080p.BluRay.x264-SPARKS.en.srt": "inception en",
+		"Movies/Inception.2010.720p.WEB.pt-BR.srt":              "inception pt",
+		"Movies/Heat.1995.BluRay.en.srt":                        "heat",
+		"TV/es/The.Office.S03E07.720p.BluRay.srt":               "office es",
+		"TV/The.Office.S03E08.720p.en.ass":                      "office next",
+		"Hashes/8e245d9679d31e12.en.srt":                        "hashed",
+		"Unsorted/notes.txt":                                    "not a subtitle",
+		"Unsorted/Inception.2010.1080p.srt":                     "no language",
+		".trash/Inception.2010.1080p.en.srt":                    "hidden",
+	})
+	client := NewLocalClient(root)
+	ctx := context.Background()
+
+	t.Run("matches movies by title and year", func(t *testing.T) {
+		subs, err := client.Search(ctx, &models.SearchParams{Query: "Inception", Year: 2010, Type: "movie", Language: "en"})
+		require.NoError(t, err)
+		require.Len(t, subs, 1)
+		assert.Equal(t, ProviderLocal, subs[0].Provider)
+		assert.Equal(t, "en", subs[0].Language)
+		assert.Equal(t, "Inception.2010.1080p.BluRay.x264-SPARKS", subs[0].ReleaseName)
+		assert.Equal(t, "srt", subs[0].SubFormat)
+		assert.Equal(t, filepath.Join("Movies", "Inception.2010.1080p.BluRay.x264-SPARKS.en.srt"), subs[0].FileID)
+
+		subs, err = client.Search(ctx, &models.SearchParams{Query: "Inception", Year: 2011, Language: "en"})
+		require.NoError(t, err)
+		assert.Len(t, subs, 1, "a year off by one still matches")
+
+		subs, err = client.Search(ctx, &models.SearchParams{Query: "Inception", Year: 2012, Language: "en"})
+		require.NoError(t, err)
+		assert.Empty(t, subs)
+	})
+
+	t.Run("normalizes languages", func(t *testing.T) {
+		subs, err := client.Search(ctx, &models.SearchParams{Query: "inception", Language: "pt-br"})
+		require.NoError(t, err)
+		require.Len(t, subs, 1)
+		assert.Equal(t, "pt-BR", subs[0].Language)
+	})
+
+	t.Run("matches episodes and reads the language from the folder", func(t *testing.T) {
+		subs, err := client.Search(ctx, &models.SearchParams{Query: "The Office", Season: 3, Episode: 7, Type: "episode", Language: "es"})
+		require.NoError(t, err)
+		require.Len(t, subs, 1)
+		assert.Equal(t, "es", subs[0].Language)
+
+		subs, err = client.Search(ctx, &models.SearchParams{Query: "The Office", Season: 3, Episode: 8, Language: "en"})
+		require.NoError(t, err)
+		require.Len(t, subs, 1)
+		assert.Equal(t, "ass", subs[0].SubFormat)
+	})
+
+	t.Run("matches by movie hash", func(t *testing.T) {
+		subs, err := client.Search(ctx, &models.SearchParams{Query: "Unknown", MovieHash: "8E245D9679D31E12", Language: "en"})
+		require.NoError(t, err)
+		require.Len(t, subs, 1)
+		assert.True(t, subs[0].MovieHashMatch)
+	})
+
+	t.Run("downloads archived files", func(t *testing.T) {
+		subs, err := client.Search(ctx, &models.SearchParams{Query: "Heat", Year: 1995, Language: "en"})
+		require.NoError(t, err)
+		require.Len(t, subs, 1)
+
+		content, err := client.Download(ctx, subs[0])
+		require.NoError(t, err)
+		assert.Equal(t, "heat", string(content))
+	})
+
+	t.Run("refuses paths outside the archive", func(t *testing.T) {
+		_, err := client.Download(ctx, &models.Subtitle{FileID: "../secret.srt"})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestLocalClientMissingArchive(t *testing.T) {
+	t.Parallel()
+
+	client := NewLocalClient(filepath.Join(t.TempDir(), "missing"))
+	_, err := client.Search(context.Background(), &models.SearchParams{Query: "Inception", Language: "en"})
+	assert.ErrorIs(t, err, ErrProviderUnavailable)
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/internal/textnorm"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+const DefaultProviderTimeout = 15 * time.Second
+
+type Provider struct {
+	Name   string
+	Client Client
+}
+
+type MultiClient struct {
+	providers []Provider
+	timeout   time.Duration
+}
+
+type PartialResultsError struct {
+	Failed map[string]error
+}
+
+func (e *PartialResultsError) Error() string {
+	names := make([]string, 0, len(e.Failed))
+	for name := range e.Failed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		err := e.Failed[name]
+		if errors.Is(err, context.DeadlineExceeded) {
+			parts = append(parts, name+" timed out")
+		} else {
+			parts = append(parts, fmt.Sprintf("%s failed: %v", name, err))
+		}
+	}
+	return "partial results: " + strings.Join(parts, "; ")
+}
+
+func (e *PartialResultsError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, err := range e.Failed {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+func NewMultiClient(timeout time.Duration, providers ...Provider) *MultiClient {
+	if timeout <= 0 {
+		timeout = DefaultProviderTimeout
+	}
+	return &MultiClient{providers: providers, timeout: timeout}
+}
+
+func (m *MultiClient) Authenticate(ctx context.Context) error {
+	for _, p := range m.providers {
+		if err := p.Client.Authenticate(ctx); err != nil {
+			return fmt.Errorf("%s: %w", p.Name, err)
+		}
+	}
+	return nil
+}
+
+func (m *MultiClient) Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	results := make([][]*models.Subtitle, len(m.providers))
+	errs := make([]error, len(m.providers))
+
+	var wg sync.WaitGroup
+	for i, p := range m.providers {
+		caps := CapabilitiesOf(p.Client)
+		if !Accepts(caps, params) {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			providerCtx, cancel := context.WithTimeout(ctx, m.timeout)
+			defer cancel()
+
+			query := *params
+			if caps.ASCIIQuery {
+				if ascii, ok := textnorm.ASCII(query.Query); ok {
+					query.Query = ascii
+				}
+			}
+			results[i], errs[i] = p.Client.Search(providerCtx, &query)
+			if errs[i] != nil && providerCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+				errs[i] = withKind(ErrProviderUnavailable, fmt.Errorf("%w after %s", context.DeadlineExceeded, m.timeout))
+			}
+		}()
+	}
+	wg.Wait()
+
+	var subtitles []*models.Subtitle
+	failed := make(map[string]error)
+	for i, p := range m.providers {
+		if errs[i] != nil {
+			failed[p.Name] = errs[i]
+			continue
+		}
+		subtitles = append(subtitles, results[i]...)
+	}
+
+	switch {
+	case len(failed) == 0:
+		return subtitles, nil
+	case len(failed) == len(m.providers) && len(m.providers) == 1:
+		return nil, errs[0]
+	case len(failed) == len(m.providers):
+		return nil, &PartialResultsError{Failed: failed}
+	}
+	return subtitles, &PartialResultsError{Failed: failed}
+}
+
+func (m *MultiClient) Download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error) {
+	for _, p := range m.providers {
+		if subtitle.Provider == "" || subtitle.Provider == p.Name {
+			return p.Client.Download(ctx, subtitle)
+		}
+	}
+	return nil, withKind(ErrProviderUnavailable, fmt.Errorf("unknown provider '%s'", subtitle.Provider))
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubClient struct {
+	name  string
+	delay time.Duration
+	err   error
+}
+
+func (s *stubClient) Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	select {
+	case <-time.After(s.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []*models.Subtitle{{ID: s.name + "-" + params.Language, Provider: s.name}}, nil
+}
+
+func (s *stubClient) Download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error) {
+	return []byte(s.name), nil
+}
+
+func (s *stubClient) Authenticate(ctx context.Context) error {
+	return s.err
+}
+
+type asciiClient struct {
+	stubClient
+	query string
+}
+
+func (a *asciiClient) Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	a.query = params.Query
+	return a.stubClient.Search(ctx, params)
+}
+
+func (a *asciiClient) Capabilities() models.Capabilities {
+	return models.Capabilities{TitleSearch: true, ASCIIQuery: true}
+}
+
+func TestMultiClient(t *testing.T) {
+	t.Parallel()
+
+	fast := Provider{Name: "fast", Client: &stubClient{name: "fast"}}
+	slow := Provider{Name: "slow", Client: &stubClient{name: "slow", delay: time.Minute}}
+	broken := Provider{Name: "broken", Client: &stubClient{name: "broken", err: errors.New("status 500")}}
+
+	t.Run("merges all providers", func(t *testing.T) {
+		t.Parallel()
+
+		other := Provider{Name: "other", Client: &stubClient{name: "other"}}
+		subs, err := NewMultiClient(time.Second, fast, other).Search(context.Background(), &models.SearchParams{Language: "en"})
+		require.NoError(t, err)
+		require.Len(t, subs, 2)
+		assert.Equal(t, "fast-en", subs[0].ID)
+		assert.Equal(t, "other-en", subs[1].ID)
+	})
+
+	t.Run("skips providers that cannot answer the query", func(t *testing.T) {
+		t.Parallel()
+
+		polish := Provider{Name: "polish", Client: NewNapisy24Client(&Config{BaseURL: "http://127.0.0.1:0"})}
+		subs, err := NewMultiClient(time.Second, fast, polish).Search(context.Background(), &models.SearchParams{Query: "Psy", Language: "pl"})
+		require.NoError(t, err, "a hash-only provider is not called for a title search")
+		require.Len(t, subs, 1)
+		assert.Equal(t, "fast-pl", subs[0].ID)
+	})
+
+	t.Run("ascii providers get a transliterated title", func(t *testing.T) {
+		t.Parallel()
+
+		ascii := &asciiClient{stubClient: stubClient{name: "ascii"}}
+		params := &models.SearchParams{Query: "Amélie", Language: "fr"}
+		_, err := NewMultiClient(time.Second, Provider{Name: "ascii", Client: ascii}).Search(context.Background(), params)
+		require.NoError(t, err)
+		assert.Equal(t, "Amelie", ascii.query)
+		assert.Equal(t, "Amélie", params.Query, "other providers keep the original title")
+
+		_, err = NewMultiClient(time.Second, Provider{Name: "ascii", Client: ascii}).Search(context.Background(), &models.SearchParams{Query: "千と千尋の神隠し", Language: "ja"})
+		require.NoError(t, err)
+		assert.Equal(t, "千と千尋の神隠し", ascii.query, "titles without a transliteration are sent as they are")
+	})
+
+	t.Run("slow provider times out on its own", func(t *testing.T) {
+		t.Parallel()
+
+		start := time.Now()
+		subs, err := NewMultiClient(50*time.Millisecond, slow, fast, broken).Search(context.Background(), &models.SearchParams{Language: "en"})
+
+		assert.Less(t, time.Since(start), 5*time.Second)
+		require.Len(t, subs, 1)
+		assert.Equal(t, "fast-en", subs[0].ID)
+
+		var partial *PartialResultsError
+		require.ErrorAs(t, err, &partial)
+		assert.ErrorIs(t, partial.Failed["slow"], context.DeadlineExceeded)
+		assert.Equal(t, "partial results: broken failed: status 500; slow timed out", err.Error())
+	})
+
+	t.Run("single provider error is passed through", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewMultiClient(time.Second, broken).Search(context.Background(), &models.SearchParams{})
+		assert.EqualError(t, err, "status 500")
+	})
+
+	t.Run("download goes to the subtitle's provider", func(t *testing.T) {
+		t.Parallel()
+
+		multi := NewMultiClient(time.Second, fast, slow)
+		content, err := multi.Download(context.Background(), &models.Subtitle{Provider: "slow"})
+		require.NoError(t, err)
+		assert.Equal(t, "slow", string(content))
+
+		_, err = multi.Download(context.Background(), &models.Subtitle{Provider: "missing"})
+		assert.ErrorContains(t, err, "unknown provider 'missing'")
+	})
+}
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/carlosarraes/subs-cli/internal/moviehash"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+const (
+	ProviderNapiprojekt       = "napiprojekt"
+	DefaultNapiprojektBaseURL = "https://napiprojekt.pl/api/api-napiprojekt3.php"
+)
+
+var napiprojektLanguages = map[string]string{"pl": "PL", "en": "ENG"}
+
+type NapiprojektClient struct {
+	client *resty.Client
+	config *Config
+
+	mu       sync.Mutex
+	hashes   map[string]string
+	contents map[string][]byte
+}
+
+type napiprojektResponse struct {
+	Status    string `xml:"status"`
+	Subtitles struct {
+		ID      string `xml:"id"`
+		Hash    string `xml:"hash"`
+		Content string `xml:"content"`
+	} `xml:"subtitles"`
+}
+
+func NewNapiprojektClient(config *Config) *NapiprojektClient {
+	return &NapiprojektClient{
+		client:   newHTTPClient(config, DefaultNapiprojektBaseURL),
+		config:   config,
+		hashes:   make(map[string]string),
+		contents: make(map[string][]byte),
+	}
+}
+
+func (c *NapiprojektClient) Authenticate(ctx context.Context) error {
+	return nil
+}
+
+func (c *NapiprojektClient) Capabilities() models.Capabilities {
+	return models.Capabilities{HashSearch: true, Languages: []string{"pl", "en"}}
+}
+
+func (c *NapiprojektClient) Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	var subtitles []*models.Subtitle
+	err := c.config.Breaker.guard(func() (err error) {
+		subtitles, err = c.search(ctx, params)
+		return err
+	})
+	return subtitles, err
+}
+
+func (c *NapiprojektClient) search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	lang := normalizeLanguage(params.Language)
+	if params.MediaPath == "" || napiprojektLanguages[lang] == "" {
+		return nil, nil
+	}
+
+	hash, err := c.hash(params.MediaPath)
+	if err != nil {
+		return nil, nil
+	}
+
+	content, err := c.fetch(ctx, hash, lang)
+	if err != nil || content == nil {
+		return nil, err
+	}
+
+	id := hash + ":" + lang
+	c.mu.Lock()
+	c.contents[id] = content
+	c.mu.Unlock()
+
+	release := strings.TrimSuffix(filepath.Base(params.MediaPath), filepath.Ext(params.MediaPath))
+	return []*models.Subtitle{{
+		ID:             id,
+		Provider:       ProviderNapiprojekt,
+		Language:       lang,
+		ReleaseName:    release,
+		FileName:       release + ".txt",
+		FileID:         id,
+		MovieHash:      hash,
+		MovieHashMatch: true,
+	}}, nil
+}
+
+func (c *NapiprojektClient) hash(path string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if hash, ok := c.hashes[path]; ok {
+		return hash, nil
+	}
+	hash, err := moviehash.Napiprojekt(path)
+	if err != nil {
+		return "", err
+	}
+	c.hashes[path] = hash
+	return hash, nil
+}
+
+func (c *NapiprojektClient) fetch(ctx context.Context, hash, lang string) ([]byte, error) {
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetFormData(map[string]string{
+			"mode":                      "1",
+			"client":                    "NapiProjekt",
+			"client_ver":                "2.2.0.2399",
+			"downloaded_subtitles_id":   hash,
+			"downloaded_subtitles_txt":  "1",
+			"downloaded_subtitles_lang": napiprojektLanguages[lang],
+		}).
+		Post(c.config.BaseURL)
+	if err != nil {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("napiprojekt request failed: %w", err))
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, statusError(resp.StatusCode(), fmt.Errorf("napiprojekt request failed with status %d", resp.StatusCode()))
+	}
+
+	var result napiprojektResponse
+	if err := xml.Unmarshal(resp.Body(), &result); err != nil {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("failed to parse napiprojekt response: %w", err))
+	}
+	if result.Status != "success" || strings.TrimSpace(result.Subtitles.Content) == "" {
+		return nil, nil
+	}
+
+	content, err := base64.StdEncoding.DecodeString(strings.TrimSpace(result.Subtitles.Content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode napiprojekt subtitle: %w", err)
+	}
+	return content, nil
+}
+
+func (c *NapiprojektClient) Download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error) {
+	c.mu.Lock()
+	content, ok := c.contents[subtitle.FileID]
+	c.mu.Unlock()
+	if ok {
+		return content, nil
+	}
+
+	hash, lang, found := strings.Cut(subtitle.FileID, ":")
+	if !found || napiprojektLanguages[lang] == "" {
+		return nil, withKind(ErrNotFound, fmt.Errorf("invalid napiprojekt subtitle '%s'", subtitle.FileID))
+	}
+
+	err := c.config.Breaker.guard(func() (err error) {
+		content, err = c.fetch(ctx, hash, lang)
+		return err
+	})
+	if err == nil && content == nil {
+		err = withKind(ErrNotFound, fmt.Errorf("napiprojekt no longer has subtitle '%s'", subtitle.FileID))
+	}
+	return content, err
+}
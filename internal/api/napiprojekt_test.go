@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/carlosarraes/subs-cli/internal/moviehash"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNapiprojektClient(t *testing.T) {
+	t.Parallel()
+
+	media := filepath.Join(t.TempDir(), "Psy.1992.DVDRip.avi")
+	require.NoError(t, os.WriteFile(media, []byte("not really a movie"), 0644))
+	hash, err := moviehash.Napiprojekt(media)
+	require.NoError(t, err)
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, hash, r.PostForm.Get("downloaded_subtitles_id"))
+		assert.Equal(t, "1", r.PostForm.Get("mode"))
+
+		if r.PostForm.Get("downloaded_subtitles_lang") != "PL" {
+			w.Write([]byte(`<?xml version="1.0"?><result><status>fail</status></result>`))
+			return
+		}
+		content := base64.StdEncoding.EncodeToString([]byte("{1}{50}Co jest, kurwa, grane?\n"))
+		w.Write([]byte(`<?xml version="1.0"?><result><status>success</status><subtitles><id>1</id><content><![CDATA[` + content + `]]></content></subtitles></result>`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewNapiprojektClient(&Config{BaseURL: server.URL})
+	ctx := context.Background()
+
+	subtitles, err := client.Search(ctx, &models.SearchParams{Query: "Psy", Language: "pl", MediaPath: media})
+	require.NoError(t, err)
+	require.Len(t, subtitles, 1)
+	assert.Equal(t, ProviderNapiprojekt, subtitles[0].Provider)
+	assert.Equal(t, "pl", subtitles[0].Language)
+	assert.Equal(t, "Psy.1992.DVDRip", subtitles[0].ReleaseName)
+	assert.True(t, subtitles[0].MovieHashMatch)
+
+	content, err := client.Download(ctx, subtitles[0])
+	require.NoError(t, err)
+	assert.Equal(t, "{1}{50}Co jest, kurwa, grane?\n", string(content))
+	assert.Equal(t, int32(1), requests.Load(), "download reuses the searched content")
+
+	subtitles, err = client.Search(ctx, &models.SearchParams{Language: "en", MediaPath: media})
+	require.NoError(t, err)
+	assert.Empty(t, subtitles)
+
+	subtitles, err = client.Search(ctx, &models.SearchParams{Query: "Psy", Language: "de", MediaPath: media})
+	require.NoError(t, err)
+	assert.Empty(t, subtitles)
+
+	subtitles, err = client.Search(ctx, &models.SearchParams{Query: "Psy", Language: "pl"})
+	require.NoError(t, err)
+	assert.Empty(t, subtitles, "hash-only providers skip title searches")
+	assert.Equal(t, int32(2), requests.Load())
+
+	fresh := NewNapiprojektClient(&Config{BaseURL: server.URL})
+	content, err = fresh.Download(ctx, &models.Subtitle{FileID: hash + ":pl"})
+	require.NoError(t, err)
+	assert.Equal(t, "{1}{50}Co jest, kurwa, grane?\n", string(content))
+
+	_, err = fresh.Download(ctx, &models.Subtitle{FileID: hash + ":en"})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
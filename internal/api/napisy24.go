@@ -0,0 +1,160 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/carlosarraes/subs-cli/internal/moviehash"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+const (
+	ProviderNapisy24       = "napisy24"
+	DefaultNapisy24BaseURL = "http://napisy24.pl/run/CheckSubAgent.php"
+
+	napisy24User     = "tantalosus"
+	napisy24Password = "susolatnat"
+)
+
+type Napisy24Client struct {
+	client *resty.Client
+	config *Config
+
+	mu       sync.Mutex
+	contents map[string][]byte
+}
+
+func NewNapisy24Client(config *Config) *Napisy24Client {
+	return &Napisy24Client{
+		client:   newHTTPClient(config, DefaultNapisy24BaseURL),
+		config:   config,
+		contents: make(map[string][]byte),
+	}
+}
+
+func (c *Napisy24Client) Authenticate(ctx context.Context) error {
+	return nil
+}
+
+func (c *Napisy24Client) Capabilities() models.Capabilities {
+	return models.Capabilities{HashSearch: true, Languages: []string{"pl"}}
+}
+
+func (c *Napisy24Client) Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	var subtitles []*models.Subtitle
+	err := c.config.Breaker.guard(func() (err error) {
+		subtitles, err = c.search(ctx, params)
+		return err
+	})
+	return subtitles, err
+}
+
+func (c *Napisy24Client) search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	if params.MediaPath == "" || normalizeLanguage(params.Language) != "pl" {
+		return nil, nil
+	}
+
+	hash, size := params.MovieHash, params.MovieByteSize
+	if hash == "" || size == 0 {
+		var err error
+		if hash, size, err = moviehash.Compute(params.MediaPath); err != nil {
+			return nil, nil
+		}
+	}
+
+	user, password := c.config.Username, c.config.Password
+	if user == "" || password == "" {
+		user, password = napisy24User, napisy24Password
+	}
+
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetFormData(map[string]string{
+			"postAction": "CheckSub",
+			"ua":         user,
+			"ap":         password,
+			"fh":         hash,
+			"fs":         strconv.FormatInt(size, 10),
+			"fn":         filepath.Base(params.MediaPath),
+			"n24pref":    "1",
+		}).
+		Post(c.config.BaseURL)
+	if err != nil {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("napisy24 request failed: %w", err))
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, statusError(resp.StatusCode(), fmt.Errorf("napisy24 request failed with status %d", resp.StatusCode()))
+	}
+
+	header, archive, _ := bytes.Cut(resp.Body(), []byte("||"))
+	fields := strings.Split(string(header), "|")
+	switch {
+	case fields[0] == "OK-0":
+		return nil, nil
+	case fields[0] == "OK-2" || fields[0] == "OK-3":
+	case strings.Contains(fields[0], "login"):
+		return nil, withKind(ErrAuthFailed, fmt.Errorf("napisy24 rejected the credentials: %s", fields[0]))
+	default:
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("napisy24 returned '%s'", fields[0]))
+	}
+
+	info := make(map[string]string, len(fields))
+	for _, field := range fields[1:] {
+		if key, value, ok := strings.Cut(field, ":"); ok {
+			info[key] = value
+		}
+	}
+
+	release := strings.TrimSuffix(filepath.Base(params.MediaPath), filepath.Ext(params.MediaPath))
+	id := info["napisId"]
+	if id == "" {
+		id = hash
+	}
+
+	content, err := unpackSubtitle(archive, release+".srt")
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.contents[id] = content
+	c.mu.Unlock()
+
+	subtitle := &models.Subtitle{
+		ID:             id,
+		Provider:       ProviderNapisy24,
+		Language:       "pl",
+		ReleaseName:    release,
+		FileName:       release + ".srt",
+		FileID:         id,
+		MovieHash:      hash,
+		MovieHashMatch: true,
+		FeatureTitle:   info["ftitle"],
+		FeatureType:    params.Type,
+	}
+	if year, err := strconv.Atoi(info["fyear"]); err == nil {
+		subtitle.FeatureYear = year
+	}
+	if imdb, err := strconv.Atoi(strings.TrimPrefix(info["fimdb"], "tt")); err == nil {
+		subtitle.IMDBID = imdb
+	}
+	return []*models.Subtitle{subtitle}, nil
+}
+
+func (c *Napisy24Client) Download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	content, ok := c.contents[subtitle.FileID]
+	if !ok {
+		return nil, withKind(ErrNotFound, fmt.Errorf("napisy24 subtitle '%s' must be found by a search first", subtitle.FileID))
+	}
+	return content, nil
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNapisy24Client(t *testing.T) {
+	t.Parallel()
+
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	file, err := writer.Create("Dom.Zly.2009.srt")
+	require.NoError(t, err)
+	file.Write([]byte("1\n00:00:01,000 --> 00:00:02,000\nDzień dobry\n"))
+	require.NoError(t, writer.Close())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "CheckSub", r.PostForm.Get("postAction"))
+		assert.Equal(t, "Dom.Zly.2009.mkv", r.PostForm.Get("fn"))
+
+		switch {
+		case r.PostForm.Get("ua") == "nobody":
+			w.Write([]byte("login error"))
+		case r.PostForm.Get("fh") == "0123456789abcdef":
+			assert.Equal(t, "1048576", r.PostForm.Get("fs"))
+			w.Write(append([]byte("OK-2|napisId:4242|ftitle:Dom zły|fimdb:tt1397295|fyear:2009||"), archive.Bytes()...))
+		default:
+			w.Write([]byte("OK-0"))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewNapisy24Client(&Config{BaseURL: server.URL})
+	ctx := context.Background()
+	params := &models.SearchParams{Language: "pl", MediaPath: "/media/Dom.Zly.2009.mkv", MovieHash: "0123456789abcdef", MovieByteSize: 1 << 20, Type: "movie"}
+
+	subtitles, err := client.Search(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, subtitles, 1)
+	assert.Equal(t, ProviderNapisy24, subtitles[0].Provider)
+	assert.Equal(t, "4242", subtitles[0].FileID)
+	assert.Equal(t, "Dom zły", subtitles[0].FeatureTitle)
+	assert.Equal(t, 2009, subtitles[0].FeatureYear)
+	assert.Equal(t, 1397295, subtitles[0].IMDBID)
+
+	content, err := client.Download(ctx, subtitles[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "Dzień dobry")
+
+	_, err = client.Download(ctx, &models.Subtitle{FileID: "1"})
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	media := filepath.Join(t.TempDir(), "Dom.Zly.2009.mkv")
+	require.NoError(t, os.WriteFile(media, make([]byte, 200000), 0644))
+	subtitles, err = client.Search(ctx, &models.SearchParams{Language: "pl", MediaPath: media})
+	require.NoError(t, err)
+	assert.Empty(t, subtitles, "hashes the file itself and handles OK-0")
+
+	subtitles, err = client.Search(ctx, &models.SearchParams{Language: "en", MediaPath: media})
+	require.NoError(t, err)
+	assert.Empty(t, subtitles)
+
+	denied := NewNapisy24Client(&Config{BaseURL: server.URL, Username: "nobody", Password: "x"})
+	_, err = denied.Search(ctx, params)
+	assert.ErrorIs(t, err, ErrAuthFailed)
+}
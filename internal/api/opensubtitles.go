@@ -1,25 +1,45 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 
+	"github.com/carlosarraes/subs-cli/internal/language"
+	"github.com/carlosarraes/subs-cli/internal/subformat"
 	"github.com/carlosarraes/subs-cli/pkg/models"
 )
 
 const (
-	DefaultBaseURL   = "https://api.opensubtitles.com/api/v1"
-	DefaultUserAgent = "subs-cli/1.0"
+	DefaultBaseURL      = "https://api.opensubtitles.com/api/v1"
+	DefaultUserAgent    = "subs-cli/1.0"
+	DefaultTimeout      = 30 * time.Second
+	DefaultDialTimeout  = 30 * time.Second
+	DefaultMaxIdleConns = 100
 )
 
+var searchOrderFields = map[string]string{
+	"downloads": "download_count",
+	"rating":    "ratings",
+	"date":      "upload_date",
+}
+
 type OpenSubtitlesClient struct {
 	client *resty.Client
 	config *Config
 	token  string
+
+	mu      sync.Mutex
+	batches map[batchKey]*languageBatch
 }
 
 type LoginRequest struct {
@@ -53,24 +73,25 @@ type SearchResponse struct {
 		ID         string `json:"id"`
 		Type       string `json:"type"`
 		Attributes struct {
-			SubtitleID   string    `json:"subtitle_id"`
-			Language     string    `json:"language"`
-			DownloadCount int      `json:"download_count"`
-			NewDownloadCount int  `json:"new_download_count"`
-			HearingImpaired bool  `json:"hearing_impaired"`
-			HD               bool  `json:"hd"`
-			FPS              float64 `json:"fps"`
-			Votes            int   `json:"votes"`
-			Ratings          float64 `json:"ratings"`
-			FromTrusted      bool  `json:"from_trusted"`
-			ForeignPartsOnly bool  `json:"foreign_parts_only"`
-			AITranslated     bool  `json:"ai_translated"`
-			MachineTranslated bool `json:"machine_translated"`
-			UploadDate       string `json:"upload_date"`
-			Release          string `json:"release"`
-			Comments         string `json:"comments"`
-			LegacySubtitleID int   `json:"legacy_subtitle_id"`
-			Uploader         struct {
+			SubtitleID        string  `json:"subtitle_id"`
+			Language          string  `json:"language"`
+			DownloadCount     int     `json:"download_count"`
+			NewDownloadCount  int     `json:"new_download_count"`
+			HearingImpaired   bool    `json:"hearing_impaired"`
+			HD                bool    `json:"hd"`
+			FPS               float64 `json:"fps"`
+			Votes             int     `json:"votes"`
+			Ratings           float64 `json:"ratings"`
+			FromTrusted       bool    `json:"from_trusted"`
+			ForeignPartsOnly  bool    `json:"foreign_parts_only"`
+			AITranslated      bool    `json:"ai_translated"`
+			MachineTranslated bool    `json:"machine_translated"`
+			MovieHashMatch    bool    `json:"moviehash_match"`
+			UploadDate        string  `json:"upload_date"`
+			Release           string  `json:"release"`
+			Comments          string  `json:"comments"`
+			LegacySubtitleID  int     `json:"legacy_subtitle_id"`
+			Uploader          struct {
 				UploaderID int    `json:"uploader_id"`
 				Name       string `json:"name"`
 				Rank       string `json:"rank"`
@@ -83,61 +104,127 @@ type SearchResponse struct {
 				MovieName   string `json:"movie_name"`
 				IMDBID      int    `json:"imdb_id"`
 				TMDBID      int    `json:"tmdb_id"`
+				Season      int    `json:"season_number"`
+				Episode     int    `json:"episode_number"`
 			} `json:"feature_details"`
-			URL       string `json:"url"`
+			URL          string `json:"url"`
 			RelatedLinks []struct {
-				Label string `json:"label"`
-				URL   string `json:"url"`
+				Label  string `json:"label"`
+				URL    string `json:"url"`
 				ImgURL string `json:"img_url"`
 			} `json:"related_links"`
 			Files []struct {
-				FileID int `json:"file_id"`
-				CDID   int `json:"cd_number"`
+				FileID   int    `json:"file_id"`
+				CDID     int    `json:"cd_number"`
 				FileName string `json:"file_name"`
 			} `json:"files"`
 		} `json:"attributes"`
 	} `json:"data"`
 }
 
+type LanguagesResponse struct {
+	Data []struct {
+		LanguageCode string `json:"language_code"`
+		LanguageName string `json:"language_name"`
+	} `json:"data"`
+}
+
+type UserInfoResponse struct {
+	Data struct {
+		AllowedDownloads   int `json:"allowed_downloads"`
+		RemainingDownloads int `json:"remaining_downloads"`
+	} `json:"data"`
+}
+
 type DownloadRequest struct {
 	FileID int `json:"file_id"`
 }
 
 type DownloadResponse struct {
-	Link       string `json:"link"`
-	FileName   string `json:"file_name"`
-	Requests   int    `json:"requests"`
-	Remaining  int    `json:"remaining"`
-	Message    string `json:"message"`
-	ResetTime  string `json:"reset_time"`
+	Link         string `json:"link"`
+	FileName     string `json:"file_name"`
+	Requests     int    `json:"requests"`
+	Remaining    int    `json:"remaining"`
+	Message      string `json:"message"`
+	ResetTime    string `json:"reset_time"`
 	ResetTimeUTC string `json:"reset_time_utc"`
 }
 
 func NewOpenSubtitlesClient(config *Config) *OpenSubtitlesClient {
+	client := newHTTPClient(config, DefaultBaseURL)
+	client.SetBaseURL(config.BaseURL)
+	if config.APIKey != "" {
+		client.SetHeader("Api-Key", config.APIKey)
+	}
+
+	return &OpenSubtitlesClient{
+		client: client,
+		config: config,
+	}
+}
+
+func newHTTPClient(config *Config, baseURL string) *resty.Client {
 	if config.BaseURL == "" {
-		config.BaseURL = DefaultBaseURL
+		config.BaseURL = baseURL
 	}
 	if config.UserAgent == "" {
 		config.UserAgent = DefaultUserAgent
 	}
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+	if config.DialTimeout <= 0 {
+		config.DialTimeout = DefaultDialTimeout
+	}
+	if config.MaxIdleConns <= 0 {
+		config.MaxIdleConns = DefaultMaxIdleConns
+	}
 
 	client := resty.New()
-	client.SetBaseURL(config.BaseURL)
+	client.SetTransport(newTransport(config))
 	client.SetHeader("User-Agent", config.UserAgent)
-	if config.APIKey != "" {
-		client.SetHeader("Api-Key", config.APIKey)
+	client.SetTimeout(config.Timeout)
+	if config.Debug != nil {
+		enableDebug(client, config.Debug)
+	}
+	if config.Budget != nil {
+		client.OnBeforeRequest(func(*resty.Client, *resty.Request) error {
+			return config.Budget.take()
+		})
+	}
+	switch {
+	case config.NoProxy:
+		client.RemoveProxy()
+	case config.Proxy != "":
+		client.SetProxy(config.Proxy)
+	}
+	if config.Responses != nil {
+		client.SetTransport(&revalidatingTransport{base: client.GetClient().Transport, store: config.Responses})
 	}
-	client.SetTimeout(30 * time.Second)
+	return client
+}
 
-	return &OpenSubtitlesClient{
-		client: client,
-		config: config,
+func newTransport(config *Config) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   config.DialTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          config.MaxIdleConns,
+		MaxIdleConnsPerHost:   config.MaxIdleConns,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
 	}
 }
 
 func (c *OpenSubtitlesClient) Authenticate(ctx context.Context) error {
 	if c.config.Username == "" || c.config.Password == "" {
-		return fmt.Errorf("username and password are required for authentication")
+		return withKind(ErrAuthFailed, fmt.Errorf("username and password are required for authentication"))
 	}
 
 	loginReq := LoginRequest{
@@ -153,15 +240,15 @@ func (c *OpenSubtitlesClient) Authenticate(ctx context.Context) error {
 		Post("/login")
 
 	if err != nil {
-		return fmt.Errorf("authentication request failed: %w", err)
+		return withKind(ErrProviderUnavailable, fmt.Errorf("authentication request failed: %w", err))
 	}
 
 	if resp.StatusCode() != 200 {
-		return fmt.Errorf("authentication failed with status %d: %s", resp.StatusCode(), resp.String())
+		return withKind(ErrAuthFailed, fmt.Errorf("authentication failed with status %d: %s", resp.StatusCode(), resp.String()))
 	}
 
 	if loginResp.Status != 200 {
-		return fmt.Errorf("authentication failed: invalid credentials")
+		return withKind(ErrAuthFailed, fmt.Errorf("authentication failed: invalid credentials"))
 	}
 
 	c.token = loginResp.Token
@@ -170,12 +257,105 @@ func (c *OpenSubtitlesClient) Authenticate(ctx context.Context) error {
 	return nil
 }
 
+func (c *OpenSubtitlesClient) Capabilities() models.Capabilities {
+	return models.Capabilities{
+		TitleSearch: true,
+		HashSearch:  true,
+		IMDBSearch:  true,
+		NeedsAuth:   true,
+		RateLimit:   "5 requests/second, 20 downloads/day with a free account",
+	}
+}
+
+func (c *OpenSubtitlesClient) HasCredentials() bool {
+	return c.config.Username != "" && c.config.Password != ""
+}
+
+func (c *OpenSubtitlesClient) Anonymous() bool {
+	return c.token == "" && !c.HasCredentials() && c.config.APIKey != ""
+}
+
+func (c *OpenSubtitlesClient) Quota(ctx context.Context) (*Quota, error) {
+	if !c.HasCredentials() {
+		return nil, nil
+	}
+	if err := c.ensureAuthenticated(ctx, true); err != nil {
+		return nil, err
+	}
+
+	var info UserInfoResponse
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetResult(&info).
+		Get("/infos/user")
+	if err != nil {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("user info request failed: %w", err))
+	}
+	if resp.StatusCode() != 200 {
+		return nil, statusError(resp.StatusCode(), fmt.Errorf("user info request failed with status %d", resp.StatusCode()))
+	}
+	return &Quota{Allowed: info.Data.AllowedDownloads, Remaining: info.Data.RemainingDownloads}, nil
+}
+
+func (c *OpenSubtitlesClient) ensureAuthenticated(ctx context.Context, needAccount bool) error {
+	if c.token != "" {
+		return nil
+	}
+	if c.config.APIKey != "" && (!needAccount || !c.HasCredentials()) {
+		return nil
+	}
+	if err := c.Authenticate(ctx); err != nil {
+		return fmt.Errorf("authentication required: %w", err)
+	}
+	return nil
+}
+
 func (c *OpenSubtitlesClient) Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
-	if c.token == "" {
-		if err := c.Authenticate(ctx); err != nil {
-			return nil, fmt.Errorf("authentication required: %w", err)
+	if batchesLanguages(params) {
+		return c.searchBatch(ctx, params)
+	}
+	var subtitles []*models.Subtitle
+	err := c.config.Breaker.guard(func() (err error) {
+		subtitles, err = c.search(ctx, params)
+		return err
+	})
+	return subtitles, err
+}
+
+func (c *OpenSubtitlesClient) SearchPages(ctx context.Context, params *models.SearchParams, maxPages int) ([]*models.Subtitle, int, error) {
+	var all []*models.Subtitle
+	page := *params
+	for page.Page = 1; ; page.Page++ {
+		subtitles, total, err := c.SearchPage(ctx, &page)
+		if err != nil {
+			return nil, page.Page - 1, err
+		}
+		all = append(all, subtitles...)
+		if page.Page >= total || page.Page >= maxPages {
+			return all, page.Page, nil
 		}
 	}
+}
+
+func (c *OpenSubtitlesClient) SearchPage(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, int, error) {
+	var subtitles []*models.Subtitle
+	var total int
+	err := c.config.Breaker.guard(func() (err error) {
+		subtitles, total, err = c.searchPage(ctx, params)
+		return err
+	})
+	return subtitles, total, err
+}
+
+func (c *OpenSubtitlesClient) search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	subtitles, _, err := c.searchPage(ctx, params)
+	return subtitles, err
+}
+
+func (c *OpenSubtitlesClient) searchPage(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, int, error) {
+	if err := c.ensureAuthenticated(ctx, false); err != nil {
+		return nil, 0, err
+	}
 
 	request := c.client.R().SetContext(ctx)
 	
@@ -184,7 +364,7 @@ func (c *OpenSubtitlesClient) Search(ctx context.Context, params *models.SearchP
 	}
 	
 	if params.Language != "" {
-		request = request.SetQueryParam("languages", params.Language)
+		request = request.SetQueryParam("languages", providerLanguages(params.Language))
 	}
 	
 	if params.Type != "" {
@@ -206,6 +386,19 @@ func (c *OpenSubtitlesClient) Search(ctx context.Context, params *models.SearchP
 	if params.MovieHash != "" {
 		request = request.SetQueryParam("moviehash", params.MovieHash)
 	}
+	
+	if orderBy, ok := searchOrderFields[params.OrderBy]; ok {
+		request = request.SetQueryParam("order_by", orderBy)
+		request = request.SetQueryParam("order_direction", "desc")
+	}
+	
+	if params.TrustedOnly {
+		request = request.SetQueryParam("trusted_sources", "only")
+	}
+
+	if params.Page > 1 {
+		request = request.SetQueryParam("page", strconv.Itoa(params.Page))
+	}
 
 	var searchResp SearchResponse
 	resp, err := request.
@@ -213,16 +406,16 @@ func (c *OpenSubtitlesClient) Search(ctx context.Context, params *models.SearchP
 		Get("/subtitles")
 
 	if err != nil {
-		return nil, fmt.Errorf("search request failed: %w", err)
+		return nil, 0, withKind(ErrProviderUnavailable, fmt.Errorf("search request failed: %w", err))
 	}
 
 	if resp.StatusCode() == 401 {
 		c.token = ""
-		return nil, fmt.Errorf("authentication expired, please retry")
+		return nil, 0, withKind(ErrAuthFailed, fmt.Errorf("authentication expired, please retry"))
 	}
 
 	if resp.StatusCode() != 200 {
-		return nil, fmt.Errorf("search failed with status %d: %s", resp.StatusCode(), resp.String())
+		return nil, 0, statusError(resp.StatusCode(), fmt.Errorf("search failed with status %d: %s", resp.StatusCode(), resp.String()))
 	}
 
 	subtitles := make([]*models.Subtitle, 0, len(searchResp.Data))
@@ -231,42 +424,122 @@ func (c *OpenSubtitlesClient) Search(ctx context.Context, params *models.SearchP
 		
 		uploadDate, _ := time.Parse("2006-01-02T15:04:05", attrs.UploadDate)
 		
+		parts := make([]models.SubtitlePart, 0, len(attrs.Files))
+		for _, file := range attrs.Files {
+			parts = append(parts, models.SubtitlePart{
+				FileID:   strconv.Itoa(file.FileID),
+				FileName: file.FileName,
+				CD:       file.CDID,
+			})
+		}
+		sort.SliceStable(parts, func(i, j int) bool { return parts[i].CD < parts[j].CD })
+		
 		var fileName, fileID string
-		if len(attrs.Files) > 0 {
-			fileName = attrs.Files[0].FileName
-			fileID = strconv.Itoa(attrs.Files[0].FileID)
+		if len(parts) > 0 {
+			fileName = parts[0].FileName
+			fileID = parts[0].FileID
+		}
+		
+		featureTitle := attrs.FeatureDetails.Title
+		if featureTitle == "" {
+			featureTitle = attrs.FeatureDetails.MovieName
 		}
 		
 		subtitle := &models.Subtitle{
-			ID:          item.ID,
-			Language:    attrs.Language,
-			ReleaseName: attrs.Release,
-			FileName:    fileName,
-			FileID:      fileID,
-			Uploader:    attrs.Uploader.Name,
-			Rating:      attrs.Ratings,
-			Downloads:   attrs.DownloadCount,
-			UploadDate:  uploadDate,
-			FPS:         attrs.FPS,
-			SubFormat:   "srt",
+			ID:                item.ID,
+			Provider:          ProviderOpenSubtitles,
+			Language:          attrs.Language,
+			ReleaseName:       attrs.Release,
+			FileName:          fileName,
+			FileID:            fileID,
+			Uploader:          attrs.Uploader.Name,
+			UploaderRank:      attrs.Uploader.Rank,
+			Rating:            attrs.Ratings,
+			Votes:             attrs.Votes,
+			Downloads:         attrs.DownloadCount,
+			UploadDate:        uploadDate,
+			FPS:               attrs.FPS,
+			SubFormat:         subformat.Resolve(nil, fileName, subformat.SRT),
+			HD:                attrs.HD,
+			HearingImpaired:   attrs.HearingImpaired,
+			FromTrusted:       attrs.FromTrusted,
+			ForeignPartsOnly:  attrs.ForeignPartsOnly,
+			AITranslated:      attrs.AITranslated,
+			MachineTranslated: attrs.MachineTranslated,
+			MovieHashMatch:    attrs.MovieHashMatch,
+			Comments:          attrs.Comments,
+			URL:               attrs.URL,
+			FeatureTitle:      featureTitle,
+			FeatureType:       attrs.FeatureDetails.FeatureType,
+			FeatureYear:       attrs.FeatureDetails.Year,
+			IMDBID:            attrs.FeatureDetails.IMDBID,
+			TMDBID:            attrs.FeatureDetails.TMDBID,
+			Season:            attrs.FeatureDetails.Season,
+			Episode:           attrs.FeatureDetails.Episode,
+		}
+		if len(parts) > 1 {
+			subtitle.Parts = parts
 		}
 		
 		subtitles = append(subtitles, subtitle)
 	}
 
-	return subtitles, nil
+	return subtitles, searchResp.TotalPages, nil
 }
 
-func (c *OpenSubtitlesClient) Download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error) {
-	if c.token == "" {
-		if err := c.Authenticate(ctx); err != nil {
-			return nil, fmt.Errorf("authentication required: %w", err)
+func (c *OpenSubtitlesClient) Languages(ctx context.Context) ([]*models.SupportedLanguage, error) {
+	var languagesResp LanguagesResponse
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetResult(&languagesResp).
+		Get("/infos/languages")
+
+	if err != nil {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("languages request failed: %w", err))
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, statusError(resp.StatusCode(), fmt.Errorf("languages request failed with status %d: %s", resp.StatusCode(), resp.String()))
+	}
+
+	languages := make([]*models.SupportedLanguage, 0, len(languagesResp.Data))
+	for _, item := range languagesResp.Data {
+		lang := &models.SupportedLanguage{
+			Code: item.LanguageCode,
+			Name: item.LanguageName,
+		}
+		if known, ok := language.Lookup(item.LanguageCode); ok {
+			lang.Native = known.Native
 		}
+		languages = append(languages, lang)
+	}
+
+	return languages, nil
+}
+
+type ProgressFunc func(downloaded, total int64)
+
+func (c *OpenSubtitlesClient) Download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error) {
+	return c.DownloadWithProgress(ctx, subtitle, nil)
+}
+
+func (c *OpenSubtitlesClient) DownloadWithProgress(ctx context.Context, subtitle *models.Subtitle, onProgress ProgressFunc) ([]byte, error) {
+	var content []byte
+	err := c.config.Breaker.guard(func() (err error) {
+		content, err = c.download(ctx, subtitle, onProgress)
+		return err
+	})
+	return content, err
+}
+
+func (c *OpenSubtitlesClient) download(ctx context.Context, subtitle *models.Subtitle, onProgress ProgressFunc) ([]byte, error) {
+	if err := c.ensureAuthenticated(ctx, true); err != nil {
+		return nil, err
 	}
 
 	fileID, err := strconv.Atoi(subtitle.FileID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid file ID: %s", subtitle.FileID)
+		return nil, withKind(ErrNotFound, fmt.Errorf("invalid file ID: %s", subtitle.FileID))
 	}
 
 	downloadReq := DownloadRequest{
@@ -281,37 +554,72 @@ func (c *OpenSubtitlesClient) Download(ctx context.Context, subtitle *models.Sub
 		Post("/download")
 
 	if err != nil {
-		return nil, fmt.Errorf("download request failed: %w", err)
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("download request failed: %w", err))
 	}
 
 	if resp.StatusCode() == 401 {
 		c.token = ""
-		return nil, fmt.Errorf("authentication expired, please retry")
+		return nil, withKind(ErrAuthFailed, fmt.Errorf("authentication expired, please retry"))
 	}
 
 	if resp.StatusCode() == 406 {
-		return nil, fmt.Errorf("download limit exceeded: %s", downloadResp.Message)
+		if c.Anonymous() {
+			return nil, withKind(ErrQuotaExceeded, fmt.Errorf("download limit exceeded: %s (anonymous API key quota; add an OpenSubtitles username and password for more downloads)", downloadResp.Message))
+		}
+		return nil, withKind(ErrQuotaExceeded, fmt.Errorf("download limit exceeded: %s", downloadResp.Message))
 	}
 
 	if resp.StatusCode() != 200 {
-		return nil, fmt.Errorf("download failed with status %d: %s", resp.StatusCode(), resp.String())
+		return nil, statusError(resp.StatusCode(), fmt.Errorf("download failed with status %d: %s", resp.StatusCode(), resp.String()))
 	}
 
 	if downloadResp.Link == "" {
-		return nil, fmt.Errorf("no download link provided")
+		return nil, withKind(ErrNotFound, fmt.Errorf("no download link provided"))
 	}
 
 	fileResp, err := c.client.R().
 		SetContext(ctx).
+		SetDoNotParseResponse(true).
 		Get(downloadResp.Link)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to download subtitle file: %w", err)
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("failed to download subtitle file: %w", err))
 	}
 
+	body := fileResp.RawBody()
+	defer body.Close()
+
 	if fileResp.StatusCode() != 200 {
-		return nil, fmt.Errorf("subtitle file download failed with status %d", fileResp.StatusCode())
+		return nil, statusError(fileResp.StatusCode(), fmt.Errorf("subtitle file download failed with status %d", fileResp.StatusCode()))
+	}
+
+	content, err := readWithProgress(body, fileResp.RawResponse.ContentLength, onProgress)
+	if err != nil {
+		return nil, err
+	}
+
+	return unpackSubtitle(content, subtitle.FileName)
+}
+
+func readWithProgress(r io.Reader, total int64, onProgress ProgressFunc) ([]byte, error) {
+	var buf bytes.Buffer
+	chunk := make([]byte, 32*1024)
+
+	for {
+		n, err := r.Read(chunk)
+		if n > 0 {
+			buf.Write(chunk[:n])
+			if onProgress != nil {
+				onProgress(int64(buf.Len()), total)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read subtitle file: %w", err)
+		}
 	}
 
-	return fileResp.Body(), nil
+	return buf.Bytes(), nil
 }
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -29,6 +31,19 @@ func TestNewOpenSubtitlesClient(t *testing.T) {
 		require.NotNil(t, client)
 		assert.Equal(t, DefaultBaseURL, client.config.BaseURL)
 		assert.Equal(t, DefaultUserAgent, client.config.UserAgent)
+		assert.Equal(t, DefaultTimeout, client.client.GetClient().Timeout)
+	})
+
+	t.Run("with network tuning", func(t *testing.T) {
+		t.Parallel()
+
+		client := NewOpenSubtitlesClient(&Config{Timeout: 5 * time.Second, DialTimeout: time.Second, MaxIdleConns: 4})
+
+		assert.Equal(t, 5*time.Second, client.client.GetClient().Timeout)
+		transport, ok := client.client.GetClient().Transport.(*http.Transport)
+		require.True(t, ok)
+		assert.Equal(t, 4, transport.MaxIdleConns)
+		assert.Equal(t, 4, transport.MaxIdleConnsPerHost)
 	})
 
 	t.Run("with custom values", func(t *testing.T) {
@@ -51,6 +66,103 @@ func TestNewOpenSubtitlesClient(t *testing.T) {
 	})
 }
 
+func TestOpenSubtitlesClient_Proxy(t *testing.T) {
+	t.Parallel()
+
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.Host+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(LanguagesResponse{})
+	}))
+	defer proxy.Close()
+
+	client := NewOpenSubtitlesClient(&Config{BaseURL: "http://api.example.invalid/api/v1", Proxy: proxy.URL})
+	_, err := client.Languages(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api.example.invalid/api/v1/infos/languages"}, proxied)
+}
+
+func TestOpenSubtitlesClient_APIKeyOnly(t *testing.T) {
+	t.Parallel()
+
+	newServer := func(t *testing.T, logins *int, downloadStatus int) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "key", r.Header.Get("Api-Key"))
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/login":
+				*logins++
+				json.NewEncoder(w).Encode(LoginResponse{Token: "test-token", Status: 200})
+			case "/subtitles":
+				json.NewEncoder(w).Encode(map[string]interface{}{"data": []interface{}{}})
+			case "/download":
+				w.WriteHeader(downloadStatus)
+				json.NewEncoder(w).Encode(DownloadResponse{Link: "http://" + r.Host + "/file", Message: "You have downloaded your allowed 5 subtitles for 24h"})
+			case "/file":
+				w.Write([]byte(archiveSRT))
+			}
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	t.Run("search and download without an account", func(t *testing.T) {
+		t.Parallel()
+
+		var logins int
+		server := newServer(t, &logins, http.StatusOK)
+		client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, APIKey: "key"})
+		assert.True(t, client.Anonymous())
+
+		_, err := client.Search(context.Background(), &models.SearchParams{Query: "movie"})
+		require.NoError(t, err)
+		content, err := client.Download(context.Background(), &models.Subtitle{FileID: "1"})
+		require.NoError(t, err)
+		assert.Equal(t, archiveSRT, string(content))
+		assert.Zero(t, logins)
+	})
+
+	t.Run("logs in only for downloads", func(t *testing.T) {
+		t.Parallel()
+
+		var logins int
+		server := newServer(t, &logins, http.StatusOK)
+		client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, APIKey: "key", Username: "u", Password: "p"})
+		assert.False(t, client.Anonymous())
+
+		_, err := client.Search(context.Background(), &models.SearchParams{Query: "movie"})
+		require.NoError(t, err)
+		assert.Zero(t, logins)
+
+		_, err = client.Download(context.Background(), &models.Subtitle{FileID: "1"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, logins)
+	})
+
+	t.Run("anonymous quota exceeded", func(t *testing.T) {
+		t.Parallel()
+
+		var logins int
+		server := newServer(t, &logins, http.StatusNotAcceptable)
+		client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, APIKey: "key"})
+
+		_, err := client.Download(context.Background(), &models.Subtitle{FileID: "1"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "download limit exceeded")
+		assert.Contains(t, err.Error(), "add an OpenSubtitles username and password")
+	})
+
+	t.Run("no api key and no account", func(t *testing.T) {
+		t.Parallel()
+
+		client := NewOpenSubtitlesClient(&Config{BaseURL: "http://127.0.0.1:0"})
+		_, err := client.Search(context.Background(), &models.SearchParams{Query: "movie"})
+		assert.ErrorContains(t, err, "username and password are required")
+	})
+}
+
 func TestOpenSubtitlesClient_Authenticate(t *testing.T) {
 	t.Parallel()
 
@@ -158,14 +270,29 @@ func TestOpenSubtitlesClient_Search(t *testing.T) {
 							"id":   "test-id-123",
 							"type": "subtitle",
 							"attributes": map[string]interface{}{
-								"language":       "en",
-								"download_count": 1500,
-								"fps":            23.976,
-								"ratings":        8.5,
-								"upload_date":    "2023-01-15T10:30:00",
-								"release":        "The.Office.S03E07.720p.BluRay.x264",
+								"language":           "en",
+								"download_count":     1500,
+								"fps":                23.976,
+								"ratings":            8.5,
+								"upload_date":        "2023-01-15T10:30:00",
+								"release":            "The.Office.S03E07.720p.BluRay.x264",
+								"votes":              12,
+								"hd":                 true,
+								"hearing_impaired":   true,
+								"from_trusted":       true,
+								"machine_translated": true,
+								"comments":           "Synced for BluRay",
+								"url":                "https://www.opensubtitles.com/en/subtitles/test",
 								"uploader": map[string]interface{}{
 									"name": "TestUploader",
+									"rank": "trusted",
+								},
+								"feature_details": map[string]interface{}{
+									"feature_type": "Episode",
+									"year":         2006,
+									"title":        "Diwali",
+									"imdb_id":      797993,
+									"tmdb_id":      62488,
 								},
 								"files": []map[string]interface{}{
 									{
@@ -220,6 +347,21 @@ func TestOpenSubtitlesClient_Search(t *testing.T) {
 		assert.Equal(t, 1500, subtitle.Downloads)
 		assert.Equal(t, 23.976, subtitle.FPS)
 		assert.Equal(t, "srt", subtitle.SubFormat)
+		assert.Equal(t, "trusted", subtitle.UploaderRank)
+		assert.Equal(t, 12, subtitle.Votes)
+		assert.True(t, subtitle.HD)
+		assert.True(t, subtitle.HearingImpaired)
+		assert.True(t, subtitle.FromTrusted)
+		assert.False(t, subtitle.ForeignPartsOnly)
+		assert.False(t, subtitle.AITranslated)
+		assert.True(t, subtitle.MachineTranslated)
+		assert.Equal(t, "Synced for BluRay", subtitle.Comments)
+		assert.Equal(t, "https://www.opensubtitles.com/en/subtitles/test", subtitle.URL)
+		assert.Equal(t, "Diwali", subtitle.FeatureTitle)
+		assert.Equal(t, "Episode", subtitle.FeatureType)
+		assert.Equal(t, 2006, subtitle.FeatureYear)
+		assert.Equal(t, 797993, subtitle.IMDBID)
+		assert.Equal(t, 62488, subtitle.TMDBID)
 
 		expectedDate, _ := time.Parse("2006-01-02T15:04:05", "2023-01-15T10:30:00")
 		assert.Equal(t, expectedDate, subtitle.UploadDate)
@@ -264,6 +406,120 @@ func TestOpenSubtitlesClient_Search(t *testing.T) {
 		assert.Empty(t, subtitles)
 	})
 
+	t.Run("keeps all CD parts in order", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/login" {
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(LoginResponse{Token: "test-token", Status: 200})
+				return
+			}
+
+			response := map[string]interface{}{
+				"data": []map[string]interface{}{
+					{
+						"id": "two-cds",
+						"attributes": map[string]interface{}{
+							"files": []map[string]interface{}{
+								{"file_id": 2, "cd_number": 2, "file_name": "Movie.CD2.srt"},
+								{"file_id": 1, "cd_number": 1, "file_name": "Movie.CD1.srt"},
+							},
+						},
+					},
+					{
+						"id": "single",
+						"attributes": map[string]interface{}{
+							"files": []map[string]interface{}{
+								{"file_id": 3, "cd_number": 1, "file_name": "Movie.srt"},
+							},
+						},
+					},
+				},
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(response)
+		}))
+		defer server.Close()
+
+		client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, Username: "test", Password: "test"})
+		subtitles, err := client.Search(context.Background(), &models.SearchParams{Query: "movie"})
+		require.NoError(t, err)
+		require.Len(t, subtitles, 2)
+
+		multi := subtitles[0]
+		assert.True(t, multi.IsMultiPart())
+		assert.Equal(t, "1", multi.FileID)
+		assert.Equal(t, "Movie.CD1.srt", multi.FileName)
+		assert.Equal(t, []models.SubtitlePart{
+			{FileID: "1", FileName: "Movie.CD1.srt", CD: 1},
+			{FileID: "2", FileName: "Movie.CD2.srt", CD: 2},
+		}, multi.Parts)
+		assert.Equal(t, "2", multi.Part(1).FileID)
+		assert.Nil(t, multi.Part(1).Parts)
+
+		assert.False(t, subtitles[1].IsMultiPart())
+		assert.Nil(t, subtitles[1].Parts)
+	})
+
+	t.Run("sends ordering and trusted filter", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/login" {
+				response := LoginResponse{Token: "test-token", Status: 200}
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(response)
+				return
+			}
+
+			if r.URL.Path == "/subtitles" {
+				assert.Equal(t, "ratings", r.URL.Query().Get("order_by"))
+				assert.Equal(t, "desc", r.URL.Query().Get("order_direction"))
+				assert.Equal(t, "only", r.URL.Query().Get("trusted_sources"))
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]interface{}{"data": []interface{}{}})
+				return
+			}
+
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, Username: "test", Password: "test"})
+
+		_, err := client.Search(context.Background(), &models.SearchParams{Query: "test", OrderBy: "rating", TrustedOnly: true})
+		require.NoError(t, err)
+	})
+
+	t.Run("maps language aliases to provider codes", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/login" {
+				response := LoginResponse{Token: "test-token", Status: 200}
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(response)
+				return
+			}
+
+			if r.URL.Path == "/subtitles" {
+				assert.Equal(t, "pt-BR", r.URL.Query().Get("languages"))
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]interface{}{"data": []interface{}{}})
+				return
+			}
+
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, Username: "test", Password: "test"})
+
+		_, err := client.Search(context.Background(), &models.SearchParams{Query: "test", Language: "pob"})
+		require.NoError(t, err)
+	})
+
 	t.Run("authentication error", func(t *testing.T) {
 		t.Parallel()
 
@@ -286,6 +542,150 @@ func TestOpenSubtitlesClient_Search(t *testing.T) {
 	})
 }
 
+func TestOpenSubtitlesClient_SearchPages(t *testing.T) {
+	t.Parallel()
+
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		assert.Equal(t, "3", r.URL.Query().Get("season_number"))
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		episode := map[string]int{"": 1, "2": 2, "3": 3}[page]
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"total_pages": 3,
+			"page":        episode,
+			"data": []interface{}{map[string]interface{}{
+				"id": strconv.Itoa(episode),
+				"attributes": map[string]interface{}{
+					"language":        "en",
+					"release":         "The.Office.S03E0" + strconv.Itoa(episode) + ".720p",
+					"feature_details": map[string]interface{}{"season_number": 3, "episode_number": episode},
+					"files":           []interface{}{map[string]interface{}{"file_id": episode, "file_name": "a.srt"}},
+				},
+			}},
+		})
+	}))
+	defer server.Close()
+
+	client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, APIKey: "key"})
+	subtitles, fetched, err := client.SearchPages(context.Background(), &models.SearchParams{Query: "The Office", Season: 3}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, fetched)
+	assert.Equal(t, []string{"", "2"}, pages)
+	require.Len(t, subtitles, 2)
+	assert.Equal(t, 3, subtitles[1].Season)
+	assert.Equal(t, 2, subtitles[1].Episode)
+
+	pages = nil
+	subtitles, fetched, err = client.SearchPages(context.Background(), &models.SearchParams{Query: "The Office", Season: 3}, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 3, fetched)
+	assert.Len(t, subtitles, 3)
+
+	pages = nil
+	subtitles, total, err := client.SearchPage(context.Background(), &models.SearchParams{Query: "The Office", Season: 3, Page: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, []string{"2"}, pages)
+	require.Len(t, subtitles, 1)
+	assert.Equal(t, 2, subtitles[0].Episode)
+}
+
+func TestOpenSubtitlesClient_Languages(t *testing.T) {
+	t.Parallel()
+
+	t.Run("lists provider languages", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/infos/languages", r.URL.Path)
+			assert.Equal(t, "GET", r.Method)
+			assert.Equal(t, "test-key", r.Header.Get("Api-Key"))
+
+			response := map[string]interface{}{
+				"data": []map[string]interface{}{
+					{"language_code": "en", "language_name": "English"},
+					{"language_code": "pt-BR", "language_name": "Portuguese (BR)"},
+					{"language_code": "xx", "language_name": "Unlisted"},
+				},
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(response)
+		}))
+		defer server.Close()
+
+		client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, APIKey: "test-key"})
+
+		languages, err := client.Languages(context.Background())
+
+		require.NoError(t, err)
+		require.Len(t, languages, 3)
+		assert.Equal(t, &models.SupportedLanguage{Code: "en", Name: "English", Native: "English"}, languages[0])
+		assert.Equal(t, "Português (Brasil)", languages[1].Native)
+		assert.Empty(t, languages[2].Native)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL})
+
+		_, err := client.Languages(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 403")
+	})
+}
+
+func TestOpenSubtitlesClient_Quota(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reads the account quota", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/login":
+				json.NewEncoder(w).Encode(map[string]interface{}{"token": "jwt", "status": 200})
+			case "/infos/user":
+				assert.Equal(t, "Bearer jwt", r.Header.Get("Authorization"))
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"data": map[string]interface{}{"allowed_downloads": 20, "remaining_downloads": 17},
+				})
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+
+		client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, APIKey: "key", Username: "user", Password: "pass"})
+
+		quota, err := client.Quota(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, &Quota{Allowed: 20, Remaining: 17}, quota)
+	})
+
+	t.Run("unknown without an account", func(t *testing.T) {
+		t.Parallel()
+
+		client := NewOpenSubtitlesClient(&Config{APIKey: "key"})
+
+		quota, err := client.Quota(context.Background())
+
+		require.NoError(t, err)
+		assert.Nil(t, quota)
+	})
+}
+
 func TestOpenSubtitlesClient_Download(t *testing.T) {
 	t.Parallel()
 
@@ -345,6 +745,31 @@ func TestOpenSubtitlesClient_Download(t *testing.T) {
 		assert.Equal(t, subtitleContent, string(content))
 	})
 
+	t.Run("zipped download", func(t *testing.T) {
+		t.Parallel()
+
+		archive := zipped(t, map[string]string{"notes.txt": "x", "Movie.srt": archiveSRT}, "notes.txt", "Movie.srt")
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/login":
+				json.NewEncoder(w).Encode(LoginResponse{Token: "test-token", Status: 200})
+			case "/download":
+				json.NewEncoder(w).Encode(DownloadResponse{Link: "http://" + r.Host + "/archive"})
+			case "/archive":
+				w.Header().Set("Content-Type", "application/zip")
+				w.Write(gzipped(t, archive))
+			}
+		}))
+		defer server.Close()
+
+		client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, Username: "test", Password: "test"})
+		content, err := client.Download(context.Background(), &models.Subtitle{FileID: "1", FileName: "Movie.srt"})
+
+		require.NoError(t, err)
+		assert.Equal(t, archiveSRT, string(content))
+	})
+
 	t.Run("invalid file ID", func(t *testing.T) {
 		t.Parallel()
 
@@ -399,4 +824,44 @@ func TestOpenSubtitlesClient_Download(t *testing.T) {
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "download limit exceeded")
 	})
-}
\ No newline at end of file
+}
+func TestOpenSubtitlesClient_DownloadWithProgress(t *testing.T) {
+	t.Parallel()
+
+	subtitleContent := strings.Repeat("1\n00:00:01,000 --> 00:00:05,000\nHello World\n\n", 100)
+	var serverURL string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(LoginResponse{Token: "test-token", Status: 200})
+		case "/download":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(DownloadResponse{Link: serverURL + "/subtitle-file"})
+		case "/subtitle-file":
+			w.Header().Set("Content-Length", strconv.Itoa(len(subtitleContent)))
+			w.Write([]byte(subtitleContent))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	serverURL = server.URL
+
+	client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, Username: "test", Password: "test"})
+
+	var lastDownloaded, lastTotal int64
+	calls := 0
+	content, err := client.DownloadWithProgress(context.Background(), &models.Subtitle{FileID: "1"}, func(downloaded, total int64) {
+		calls++
+		lastDownloaded = downloaded
+		lastTotal = total
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, subtitleContent, string(content))
+	assert.Greater(t, calls, 0)
+	assert.Equal(t, int64(len(subtitleContent)), lastDownloaded)
+	assert.Equal(t, int64(len(subtitleContent)), lastTotal)
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	responsePrefix = "http-"
+
+	// maxCachedResponses bounds how many API answers are kept on disk.
+	maxCachedResponses = 500
+)
+
+type ResponseCache interface {
+	Get(key string, v any) bool
+	Set(key string, v any) error
+}
+
+type cachedResponse struct {
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"last_modified,omitempty"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+}
+
+// responsePruner is implemented by stores that can drop expired and excess
+// entries; the transport prunes once before its first write.
+type responsePruner interface {
+	Prune(prefix string, keep int) error
+}
+
+type revalidatingTransport struct {
+	base   http.RoundTripper
+	store  ResponseCache
+	pruned sync.Once
+}
+
+// revalidates reports whether req is a search or info API call. Anything
+// else, such as the one-time links subtitle files are served from, is never
+// requested twice and is not worth keeping.
+func revalidates(req *http.Request) bool {
+	path := req.URL.Path
+	return strings.HasSuffix(path, "/subtitles") || strings.Contains(path, "/infos/")
+}
+
+func (t *revalidatingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" || !revalidates(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	key := responseKey(req)
+	var cached cachedResponse
+	if t.store.Get(key, &cached) {
+		req = req.Clone(req.Context())
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	} else {
+		cached = cachedResponse{}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached.Body != nil:
+		resp.Body.Close()
+		header := cached.Header.Clone()
+		for name, values := range resp.Header {
+			header[name] = values
+		}
+		resp.StatusCode, resp.Status = http.StatusOK, "200 OK"
+		resp.Header = header
+		resp.Body = io.NopCloser(bytes.NewReader(cached.Body))
+		resp.ContentLength = int64(len(cached.Body))
+	case resp.StatusCode == http.StatusOK && (resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != ""):
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		t.pruned.Do(func() {
+			if pruner, ok := t.store.(responsePruner); ok {
+				_ = pruner.Prune(responsePrefix, maxCachedResponses)
+			}
+		})
+		_ = t.store.Set(key, cachedResponse{
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			Header:       resp.Header.Clone(),
+			Body:         body,
+		})
+	}
+	return resp, nil
+}
+
+func responseKey(req *http.Request) string {
+	sum := sha256.New()
+	for _, part := range []string{req.URL.String(), req.Header.Get("Authorization"), req.Header.Get("Api-Key"), req.Header.Get("Accept-Language")} {
+		io.WriteString(sum, part)
+		sum.Write([]byte{0})
+	}
+	return responsePrefix + hex.EncodeToString(sum.Sum(nil))
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+type memoryResponses map[string][]byte
+
+func (m memoryResponses) Get(key string, v any) bool {
+	data, ok := m[key]
+	return ok && json.Unmarshal(data, v) == nil
+}
+
+func (m memoryResponses) Set(key string, v any) error {
+	data, err := json.Marshal(v)
+	m[key] = data
+	return err
+}
+
+func TestRevalidatingTransport(t *testing.T) {
+	t.Parallel()
+
+	var conditional []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conditional = append(conditional, r.Header.Get("If-None-Match")+"|"+r.Header.Get("If-Modified-Since"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 12 Oct 2026 10:00:00 GMT")
+		w.Write([]byte(`{"total_pages":1,"page":1,"data":[{"id":"1","attributes":{"language":"en","release":"Movie.2024.1080p","files":[{"file_id":7,"file_name":"a.srt"}]}}]}`))
+	}))
+	defer server.Close()
+
+	store := memoryResponses{}
+	client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, APIKey: "key", Responses: store})
+	params := &models.SearchParams{Query: "Movie", Language: "en"}
+
+	first, err := client.Search(context.Background(), params)
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+	assert.Len(t, store, 1)
+
+	second, err := client.Search(context.Background(), params)
+	require.NoError(t, err)
+	assert.Equal(t, first, second, "a 304 answer is served from the stored response")
+	assert.Equal(t, []string{"|", `"v1"|Mon, 12 Oct 2026 10:00:00 GMT`}, conditional)
+
+	other := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, APIKey: "other", Responses: store})
+	_, err = other.Search(context.Background(), params)
+	require.NoError(t, err)
+	assert.Equal(t, "|", conditional[2], "responses are not shared between API keys")
+}
+
+type prunedResponses struct {
+	memoryResponses
+	prunes []int
+}
+
+func (p *prunedResponses) Prune(prefix string, keep int) error {
+	p.prunes = append(p.prunes, keep)
+	return nil
+}
+
+func TestRevalidatingTransportSkipsDownloads(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"file"`)
+		w.Write([]byte("1\n00:00:01,000 --> 00:00:02,000\nHi\n"))
+	}))
+	defer server.Close()
+
+	store := &prunedResponses{memoryResponses: memoryResponses{}}
+	client := &http.Client{Transport: &revalidatingTransport{base: http.DefaultTransport, store: store}}
+	for _, path := range []string{"/download/abc/file.srt", "/infos/languages", "/infos/formats"} {
+		resp, err := client.Get(server.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	assert.Len(t, store.memoryResponses, 2, "only the info answers are kept")
+	assert.Equal(t, []int{maxCachedResponses}, store.prunes, "the store is pruned once per client")
+}
@@ -0,0 +1,270 @@
+package api
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const DefaultTMDBBaseURL = "https://api.themoviedb.org/3"
+
+var releaseToken = regexp.MustCompile(`(?i)^(\d{3,4}p|hdtv|pdtv|web|web-?dl|web-?rip|blu-?ray|bdrip|brrip|dvdrip|hdrip|x26[45](-.*)?|h26[45](-.*)?|hevc|xvid(-.*)?|proper|repack|internal|multi)$`)
+
+type EpisodeMatch struct {
+	Show    string
+	Season  int
+	Episode int
+	Title   string
+}
+
+type TMDBClient struct {
+	client *resty.Client
+	config *Config
+}
+
+type tmdbTitle struct {
+	ID            int    `json:"id"`
+	Title         string `json:"title"`
+	OriginalTitle string `json:"original_title"`
+	Name          string `json:"name"`
+	OriginalName  string `json:"original_name"`
+}
+
+func (t tmdbTitle) title() string {
+	return cmp.Or(t.Title, t.Name)
+}
+
+func (t tmdbTitle) original() string {
+	return cmp.Or(t.OriginalTitle, t.OriginalName)
+}
+
+type tmdbEpisode struct {
+	Season  int    `json:"season_number"`
+	Episode int    `json:"episode_number"`
+	Name    string `json:"name"`
+}
+
+func NewTMDBClient(config *Config) *TMDBClient {
+	client := newHTTPClient(config, DefaultTMDBBaseURL)
+	client.SetBaseURL(config.BaseURL)
+	if strings.HasPrefix(config.APIKey, "eyJ") {
+		client.SetAuthToken(config.APIKey)
+	} else if config.APIKey != "" {
+		client.SetQueryParam("api_key", config.APIKey)
+	}
+	return &TMDBClient{client: client, config: config}
+}
+
+func (c *TMDBClient) FindEpisode(ctx context.Context, name string) (*EpisodeMatch, error) {
+	if c.config.APIKey == "" {
+		return nil, withKind(ErrAuthFailed, fmt.Errorf("TMDB needs an API key"))
+	}
+
+	words := episodeWords(name)
+	for split := len(words) - 1; split > 0; split-- {
+		show, title := strings.Join(words[:split], " "), strings.Join(words[split:], " ")
+		shows, err := c.searchShows(ctx, show)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, candidate := range shows {
+			if foldTitle(candidate.Name) != foldTitle(show) && foldTitle(candidate.OriginalName) != foldTitle(show) {
+				continue
+			}
+			episode, err := c.findEpisode(ctx, candidate.ID, title)
+			if err != nil {
+				return nil, err
+			}
+			if episode != nil {
+				return &EpisodeMatch{
+					Show:    candidate.Name,
+					Season:  episode.Season,
+					Episode: episode.Episode,
+					Title:   episode.Name,
+				}, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (c *TMDBClient) SeasonCount(ctx context.Context, show string) (int, error) {
+	if c.config.APIKey == "" {
+		return 0, withKind(ErrAuthFailed, fmt.Errorf("TMDB needs an API key"))
+	}
+
+	shows, err := c.searchShows(ctx, show)
+	if err != nil {
+		return 0, err
+	}
+	for _, candidate := range shows {
+		if foldTitle(candidate.Name) != foldTitle(show) && foldTitle(candidate.OriginalName) != foldTitle(show) {
+			continue
+		}
+		var details struct {
+			Seasons int `json:"number_of_seasons"`
+		}
+		resp, err := c.client.R().SetContext(ctx).SetResult(&details).Get(fmt.Sprintf("/tv/%d", candidate.ID))
+		if err != nil {
+			return 0, withKind(ErrProviderUnavailable, fmt.Errorf("TMDB show lookup failed: %w", err))
+		}
+		if resp.StatusCode() != http.StatusOK {
+			return 0, statusError(resp.StatusCode(), fmt.Errorf("TMDB show lookup failed with status %d", resp.StatusCode()))
+		}
+		return details.Seasons, nil
+	}
+	return 0, nil
+}
+
+func (c *TMDBClient) searchShows(ctx context.Context, query string) ([]tmdbTitle, error) {
+	var result struct {
+		Results []tmdbTitle `json:"results"`
+	}
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetQueryParam("query", query).
+		SetResult(&result).
+		Get("/search/tv")
+	if err != nil {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("TMDB show search failed: %w", err))
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, statusError(resp.StatusCode(), fmt.Errorf("TMDB show search failed with status %d", resp.StatusCode()))
+	}
+	return result.Results, nil
+}
+
+func (c *TMDBClient) findEpisode(ctx context.Context, showID int, title string) (*tmdbEpisode, error) {
+	var details struct {
+		Seasons []struct {
+			Number int `json:"season_number"`
+		} `json:"seasons"`
+	}
+	resp, err := c.client.R().SetContext(ctx).SetResult(&details).Get(fmt.Sprintf("/tv/%d", showID))
+	if err != nil {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("TMDB show lookup failed: %w", err))
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, statusError(resp.StatusCode(), fmt.Errorf("TMDB show lookup failed with status %d", resp.StatusCode()))
+	}
+
+	wanted := episodeTitleKey(title)
+	for _, season := range details.Seasons {
+		if season.Number == 0 {
+			continue
+		}
+
+		var episodes struct {
+			Episodes []tmdbEpisode `json:"episodes"`
+		}
+		resp, err := c.client.R().SetContext(ctx).SetResult(&episodes).Get(fmt.Sprintf("/tv/%d/season/%d", showID, season.Number))
+		if err != nil {
+			return nil, withKind(ErrProviderUnavailable, fmt.Errorf("TMDB season lookup failed: %w", err))
+		}
+		if resp.StatusCode() != http.StatusOK {
+			return nil, statusError(resp.StatusCode(), fmt.Errorf("TMDB season lookup failed with status %d", resp.StatusCode()))
+		}
+
+		for _, episode := range episodes.Episodes {
+			if episode.Episode > 0 && episodeTitleKey(episode.Name) == wanted {
+				return &episode, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func episodeWords(name string) []string {
+	name = strings.TrimSuffix(name, path.Ext(name))
+	fields := strings.FieldsFunc(name, func(r rune) bool { return r == '.' || r == '_' || r == ' ' })
+
+	var words []string
+	for _, field := range fields {
+		if releaseToken.MatchString(field) {
+			break
+		}
+		words = append(words, field)
+	}
+	return words
+}
+
+func episodeTitleKey(title string) string {
+	words := strings.Fields(strings.ToLower(title))
+	if len(words) > 1 && (words[0] == "the" || words[0] == "a" || words[0] == "an") {
+		words = words[1:]
+	}
+	return foldTitle(strings.Join(words, " "))
+}
+
+func (c *TMDBClient) AlternativeTitles(ctx context.Context, query string, year int, episode bool) ([]string, error) {
+	if c.config.APIKey == "" {
+		return nil, withKind(ErrAuthFailed, fmt.Errorf("TMDB needs an API key"))
+	}
+
+	kind := "movie"
+	request := c.client.R().SetContext(ctx).SetQueryParam("query", query)
+	if episode {
+		kind = "tv"
+	} else if year > 0 {
+		request.SetQueryParam("year", strconv.Itoa(year))
+	}
+
+	var search struct {
+		Results []tmdbTitle `json:"results"`
+	}
+	resp, err := request.SetResult(&search).Get("/search/" + kind)
+	if err != nil {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("TMDB title search failed: %w", err))
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, statusError(resp.StatusCode(), fmt.Errorf("TMDB title search failed with status %d", resp.StatusCode()))
+	}
+	if len(search.Results) == 0 {
+		return nil, nil
+	}
+
+	found := search.Results[0]
+	for _, result := range search.Results {
+		if foldTitle(result.title()) == foldTitle(query) || foldTitle(result.original()) == foldTitle(query) {
+			found = result
+			break
+		}
+	}
+
+	var alternatives struct {
+		Titles  []struct{ Title string } `json:"titles"`
+		Results []struct{ Title string } `json:"results"`
+	}
+	resp, err = c.client.R().SetContext(ctx).SetResult(&alternatives).Get(fmt.Sprintf("/%s/%d/alternative_titles", kind, found.ID))
+	if err != nil {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("TMDB alternative titles lookup failed: %w", err))
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, statusError(resp.StatusCode(), fmt.Errorf("TMDB alternative titles lookup failed with status %d", resp.StatusCode()))
+	}
+
+	titles := []string{found.original(), found.title()}
+	for _, alternative := range append(alternatives.Titles, alternatives.Results...) {
+		titles = append(titles, alternative.Title)
+	}
+
+	seen := map[string]bool{foldTitle(query): true}
+	var unique []string
+	for _, title := range titles {
+		key := foldTitle(title)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, title)
+	}
+	return unique, nil
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTMDBClient_FindEpisode(t *testing.T) {
+	t.Parallel()
+
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("api_key") != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/search/tv":
+			query := r.URL.Query().Get("query")
+			queries = append(queries, query)
+			if query == "The Office" {
+				w.Write([]byte(`{"results":[{"id":7,"name":"The Office UK","first_air_date":"2001-07-09"},{"id":2316,"name":"The Office","first_air_date":"2005-03-24"}]}`))
+				return
+			}
+			w.Write([]byte(`{"results":[]}`))
+		case "/tv/2316":
+			w.Write([]byte(`{"seasons":[{"season_number":0},{"season_number":1},{"season_number":2}]}`))
+		case "/tv/2316/season/1":
+			w.Write([]byte(`{"episodes":[{"season_number":1,"episode_number":1,"name":"Pilot"}]}`))
+		case "/tv/2316/season/2":
+			w.Write([]byte(`{"episodes":[{"season_number":2,"episode_number":1,"name":"The Dundies"},{"season_number":2,"episode_number":2,"name":"Sexual Harassment"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewTMDBClient(&Config{BaseURL: server.URL, APIKey: "key"})
+
+	match, err := client.FindEpisode(context.Background(), "The.Office.The.Dundies.720p.WEB-DL.x264-GRP.mkv")
+	require.NoError(t, err)
+	require.NotNil(t, match)
+	assert.Equal(t, EpisodeMatch{Show: "The Office", Season: 2, Episode: 1, Title: "The Dundies"}, *match)
+	assert.Equal(t, []string{"The Office The", "The Office"}, queries, "the longest show name is tried first")
+
+	match, err = client.FindEpisode(context.Background(), "The Office - Dundies.mkv")
+	require.NoError(t, err)
+	require.NotNil(t, match, "leading articles and punctuation are ignored when comparing episode titles")
+	assert.Equal(t, 2, match.Season)
+
+	match, err = client.FindEpisode(context.Background(), "Unknown.Show.Some.Title.mkv")
+	require.NoError(t, err)
+	assert.Nil(t, match)
+
+	_, err = NewTMDBClient(&Config{BaseURL: server.URL, APIKey: "wrong"}).FindEpisode(context.Background(), "The.Office.Pilot.mkv")
+	assert.ErrorIs(t, err, ErrAuthFailed)
+
+	_, err = NewTMDBClient(&Config{BaseURL: server.URL}).FindEpisode(context.Background(), "The.Office.Pilot.mkv")
+	assert.ErrorContains(t, err, "needs an API key")
+}
+
+func TestTMDBClient_SeasonCount(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/search/tv":
+			if r.URL.Query().Get("query") == "The Simpsons" {
+				w.Write([]byte(`{"results":[{"id":1,"name":"The Simpsons Shorts"},{"id":456,"name":"The Simpsons"}]}`))
+				return
+			}
+			w.Write([]byte(`{"results":[{"id":2,"name":"Something Else"}]}`))
+		case "/tv/456":
+			w.Write([]byte(`{"number_of_seasons":36}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewTMDBClient(&Config{BaseURL: server.URL, APIKey: "key"})
+
+	seasons, err := client.SeasonCount(context.Background(), "The Simpsons")
+	require.NoError(t, err)
+	assert.Equal(t, 36, seasons)
+
+	seasons, err = client.SeasonCount(context.Background(), "Inception")
+	require.NoError(t, err)
+	assert.Zero(t, seasons, "only a show with the same name counts")
+
+	_, err = NewTMDBClient(&Config{BaseURL: server.URL}).SeasonCount(context.Background(), "The Simpsons")
+	assert.ErrorIs(t, err, ErrAuthFailed)
+}
+
+func TestEpisodeWords(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"Show", "Name", "The", "Pilot"}, episodeWords("Show.Name.The.Pilot.720p.mkv"))
+	assert.Equal(t, []string{"Show", "Name", "Pilot"}, episodeWords("Show_Name_Pilot_HDTV_x264-LOL.avi"))
+	assert.Equal(t, "pilot", episodeTitleKey("The Pilot"))
+	assert.Equal(t, "the", episodeTitleKey("The"))
+}
+
+func TestTMDBClient_AlternativeTitles(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/search/tv":
+			w.Write([]byte(`{"results":[{"id":9,"name":"Money Heist: Korea","original_name":"종이의 집"},{"id":71446,"name":"Money Heist","original_name":"La casa de papel"}]}`))
+		case "/tv/71446/alternative_titles":
+			w.Write([]byte(`{"results":[{"iso_3166_1":"DE","title":"Haus des Geldes"},{"iso_3166_1":"ES","title":"La Casa de Papel"},{"iso_3166_1":"US","title":"Money Heist"}]}`))
+		case "/search/movie":
+			assert.Equal(t, "2001", r.URL.Query().Get("year"))
+			w.Write([]byte(`{"results":[{"id":129,"title":"Spirited Away","original_title":"千と千尋の神隠し"}]}`))
+		case "/movie/129/alternative_titles":
+			w.Write([]byte(`{"titles":[{"title":"Sen to Chihiro no Kamikakushi"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewTMDBClient(&Config{BaseURL: server.URL, APIKey: "key"})
+
+	titles, err := client.AlternativeTitles(context.Background(), "Money Heist", 0, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"La casa de papel", "Haus des Geldes"}, titles, "the exact match is preferred and the query itself is left out")
+
+	titles, err = client.AlternativeTitles(context.Background(), "Spirited Away", 2001, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"千と千尋の神隠し", "Sen to Chihiro no Kamikakushi"}, titles)
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/carlosarraes/subs-cli/internal/language"
+)
+
+type UploadRequest struct {
+	MovieHash       string
+	MovieByteSize   int64
+	MovieFileName   string
+	FPS             float64
+	Language        string
+	ReleaseName     string
+	HearingImpaired bool
+	Comment         string
+	FileName        string
+	Content         []byte
+}
+
+type UploadResponse struct {
+	Data struct {
+		SubtitleID  string `json:"subtitle_id"`
+		URL         string `json:"url"`
+		AlreadyInDB bool   `json:"already_in_db"`
+	} `json:"data"`
+	Message string `json:"message"`
+}
+
+type UploadResult struct {
+	SubtitleID string
+	URL        string
+	Duplicate  bool
+}
+
+func SubtitleMD5(content []byte) string {
+	sum := md5.Sum(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *OpenSubtitlesClient) Upload(ctx context.Context, upload *UploadRequest) (*UploadResult, error) {
+	if !c.HasCredentials() && c.token == "" {
+		return nil, withKind(ErrAuthFailed, fmt.Errorf("uploading requires an OpenSubtitles username and password"))
+	}
+	if err := c.ensureAuthenticated(ctx, true); err != nil {
+		return nil, err
+	}
+
+	fields := map[string]string{
+		"moviehash":        upload.MovieHash,
+		"moviebytesize":    strconv.FormatInt(upload.MovieByteSize, 10),
+		"moviefilename":    upload.MovieFileName,
+		"language":         language.ProviderCode(ProviderOpenSubtitles, upload.Language),
+		"release_name":     upload.ReleaseName,
+		"hearing_impaired": strconv.FormatBool(upload.HearingImpaired),
+		"subfilename":      upload.FileName,
+		"submd5hash":       SubtitleMD5(upload.Content),
+	}
+	if upload.FPS > 0 {
+		fields["movie_fps"] = strconv.FormatFloat(upload.FPS, 'f', 3, 64)
+	}
+	if upload.Comment != "" {
+		fields["comment"] = upload.Comment
+	}
+
+	var uploadResp UploadResponse
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetFormData(fields).
+		SetFileReader("file", upload.FileName, bytes.NewReader(upload.Content)).
+		SetResult(&uploadResp).
+		SetError(&uploadResp).
+		Post("/upload")
+
+	if err != nil {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("upload request failed: %w", err))
+	}
+
+	if resp.StatusCode() == 401 {
+		c.token = ""
+		return nil, withKind(ErrAuthFailed, fmt.Errorf("authentication expired, please retry"))
+	}
+
+	if resp.StatusCode() == 409 {
+		return &UploadResult{SubtitleID: uploadResp.Data.SubtitleID, URL: uploadResp.Data.URL, Duplicate: true}, nil
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, statusError(resp.StatusCode(), fmt.Errorf("upload failed with status %d: %s", resp.StatusCode(), resp.String()))
+	}
+
+	return &UploadResult{
+		SubtitleID: uploadResp.Data.SubtitleID,
+		URL:        uploadResp.Data.URL,
+		Duplicate:  uploadResp.Data.AlreadyInDB,
+	}, nil
+}
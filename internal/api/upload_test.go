@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newUploadServer(t *testing.T, status int, alreadyInDB bool) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/login":
+			json.NewEncoder(w).Encode(LoginResponse{Token: "test-token", Status: 200})
+		case "/upload":
+			assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			assert.Equal(t, "8e245d9679d31e12", r.FormValue("moviehash"))
+			assert.Equal(t, "12909756", r.FormValue("moviebytesize"))
+			assert.Equal(t, "pt-BR", r.FormValue("language"))
+			assert.Equal(t, "Movie.2020.1080p.WEB-DL", r.FormValue("release_name"))
+			assert.Equal(t, "23.976", r.FormValue("movie_fps"))
+			assert.Equal(t, SubtitleMD5([]byte(archiveSRT)), r.FormValue("submd5hash"))
+
+			file, header, err := r.FormFile("file")
+			require.NoError(t, err)
+			defer file.Close()
+			content, err := io.ReadAll(file)
+			require.NoError(t, err)
+			assert.Equal(t, "movie.pt-BR.srt", header.Filename)
+			assert.Equal(t, archiveSRT, string(content))
+
+			w.WriteHeader(status)
+			response := UploadResponse{Message: "rejected"}
+			response.Data.SubtitleID = "42"
+			response.Data.URL = "https://www.opensubtitles.com/subtitles/42"
+			response.Data.AlreadyInDB = alreadyInDB
+			json.NewEncoder(w).Encode(response)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func uploadRequest() *UploadRequest {
+	return &UploadRequest{
+		MovieHash:     "8e245d9679d31e12",
+		MovieByteSize: 12909756,
+		MovieFileName: "Movie.2020.1080p.WEB-DL.mkv",
+		FPS:           23.976,
+		Language:      "pt-BR",
+		ReleaseName:   "Movie.2020.1080p.WEB-DL",
+		FileName:      "movie.pt-BR.srt",
+		Content:       []byte(archiveSRT),
+	}
+}
+
+func TestUpload(t *testing.T) {
+	t.Parallel()
+
+	t.Run("uploaded", func(t *testing.T) {
+		t.Parallel()
+
+		server := newUploadServer(t, http.StatusOK, false)
+		client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, Username: "user", Password: "pass"})
+
+		result, err := client.Upload(context.Background(), uploadRequest())
+		require.NoError(t, err)
+		assert.Equal(t, &UploadResult{SubtitleID: "42", URL: "https://www.opensubtitles.com/subtitles/42"}, result)
+	})
+
+	t.Run("already_in_db", func(t *testing.T) {
+		t.Parallel()
+
+		server := newUploadServer(t, http.StatusConflict, true)
+		client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, Username: "user", Password: "pass"})
+
+		result, err := client.Upload(context.Background(), uploadRequest())
+		require.NoError(t, err)
+		assert.True(t, result.Duplicate)
+		assert.Equal(t, "42", result.SubtitleID)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		t.Parallel()
+
+		server := newUploadServer(t, http.StatusBadRequest, false)
+		client := NewOpenSubtitlesClient(&Config{BaseURL: server.URL, Username: "user", Password: "pass"})
+
+		_, err := client.Upload(context.Background(), uploadRequest())
+		assert.ErrorContains(t, err, "upload failed with status 400")
+	})
+
+	t.Run("needs_account", func(t *testing.T) {
+		t.Parallel()
+
+		client := NewOpenSubtitlesClient(&Config{BaseURL: "http://127.0.0.1:1", APIKey: "key"})
+
+		_, err := client.Upload(context.Background(), uploadRequest())
+		assert.EqualError(t, err, "uploading requires an OpenSubtitles username and password")
+	})
+}
+
+func TestSubtitleMD5(t *testing.T) {
+	assert.Equal(t, "d41d8cd98f00b204e9800998ecf8427e", SubtitleMD5(nil))
+}
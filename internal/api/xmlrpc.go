@@ -0,0 +1,195 @@
+package api
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type rpcValue struct {
+	Text    string     `xml:",chardata"`
+	String  *string    `xml:"string"`
+	Int     *string    `xml:"int"`
+	I4      *string    `xml:"i4"`
+	Boolean *string    `xml:"boolean"`
+	Double  *string    `xml:"double"`
+	Base64  *string    `xml:"base64"`
+	Struct  *rpcStruct `xml:"struct"`
+	Array   *rpcArray  `xml:"array"`
+}
+
+type rpcStruct struct {
+	Members []rpcMember `xml:"member"`
+}
+
+type rpcMember struct {
+	Name  string   `xml:"name"`
+	Value rpcValue `xml:"value"`
+}
+
+type rpcArray struct {
+	Values []rpcValue `xml:"data>value"`
+}
+
+type rpcResponse struct {
+	Params []rpcValue `xml:"params>param>value"`
+	Fault  *rpcValue  `xml:"fault>value"`
+}
+
+func encodeCall(method string, params ...any) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString("<methodCall><methodName>")
+	xml.EscapeText(&buf, []byte(method))
+	buf.WriteString("</methodName><params>")
+	for _, param := range params {
+		buf.WriteString("<param>")
+		if err := encodeValue(&buf, param); err != nil {
+			return nil, fmt.Errorf("%s: %w", method, err)
+		}
+		buf.WriteString("</param>")
+	}
+	buf.WriteString("</params></methodCall>")
+	return buf.Bytes(), nil
+}
+
+func encodeValue(buf *bytes.Buffer, value any) error {
+	buf.WriteString("<value>")
+	switch v := value.(type) {
+	case string:
+		buf.WriteString("<string>")
+		xml.EscapeText(buf, []byte(v))
+		buf.WriteString("</string>")
+	case int:
+		fmt.Fprintf(buf, "<int>%d</int>", v)
+	case int64:
+		fmt.Fprintf(buf, "<double>%d</double>", v)
+	case float64:
+		fmt.Fprintf(buf, "<double>%s</double>", strconv.FormatFloat(v, 'f', -1, 64))
+	case bool:
+		if v {
+			buf.WriteString("<boolean>1</boolean>")
+		} else {
+			buf.WriteString("<boolean>0</boolean>")
+		}
+	case []any:
+		buf.WriteString("<array><data>")
+		for _, item := range v {
+			if err := encodeValue(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteString("</data></array>")
+	case map[string]any:
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		buf.WriteString("<struct>")
+		for _, name := range names {
+			buf.WriteString("<member><name>")
+			xml.EscapeText(buf, []byte(name))
+			buf.WriteString("</name>")
+			if err := encodeValue(buf, v[name]); err != nil {
+				return err
+			}
+			buf.WriteString("</member>")
+		}
+		buf.WriteString("</struct>")
+	default:
+		return fmt.Errorf("cannot encode %T as XML-RPC", value)
+	}
+	buf.WriteString("</value>")
+	return nil
+}
+
+func decodeResponse(body []byte) (any, error) {
+	var response rpcResponse
+	if err := xml.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse XML-RPC response: %w", err)
+	}
+	if response.Fault != nil {
+		fault, _ := response.Fault.decode().(map[string]any)
+		return nil, fmt.Errorf("XML-RPC fault %v: %v", fault["faultCode"], fault["faultString"])
+	}
+	if len(response.Params) == 0 {
+		return nil, fmt.Errorf("XML-RPC response has no value")
+	}
+	return response.Params[0].decode(), nil
+}
+
+func (v rpcValue) decode() any {
+	switch {
+	case v.String != nil:
+		return *v.String
+	case v.Int != nil:
+		n, _ := strconv.Atoi(strings.TrimSpace(*v.Int))
+		return n
+	case v.I4 != nil:
+		n, _ := strconv.Atoi(strings.TrimSpace(*v.I4))
+		return n
+	case v.Boolean != nil:
+		return strings.TrimSpace(*v.Boolean) == "1"
+	case v.Double != nil:
+		f, _ := strconv.ParseFloat(strings.TrimSpace(*v.Double), 64)
+		return f
+	case v.Base64 != nil:
+		return strings.TrimSpace(*v.Base64)
+	case v.Struct != nil:
+		members := make(map[string]any, len(v.Struct.Members))
+		for _, member := range v.Struct.Members {
+			members[member.Name] = member.Value.decode()
+		}
+		return members
+	case v.Array != nil:
+		values := make([]any, len(v.Array.Values))
+		for i, item := range v.Array.Values {
+			values[i] = item.decode()
+		}
+		return values
+	}
+	return v.Text
+}
+
+func rpcString(values map[string]any, name string) string {
+	switch v := values[name].(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return ""
+}
+
+func rpcInt(values map[string]any, name string) int {
+	switch v := values[name].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	case string:
+		n, _ := strconv.Atoi(strings.TrimSpace(v))
+		return n
+	}
+	return 0
+}
+
+func rpcFloat(values map[string]any, name string) float64 {
+	switch v := values[name].(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case string:
+		f, _ := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f
+	}
+	return 0
+}
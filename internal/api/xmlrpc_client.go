@@ -0,0 +1,278 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/carlosarraes/subs-cli/internal/language"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+const (
+	ProviderOpenSubtitlesXMLRPC = "opensubtitles-xmlrpc"
+	DefaultXMLRPCURL            = "https://api.opensubtitles.org/xml-rpc"
+
+	xmlrpcDateLayout = "2006-01-02 15:04:05"
+	xmlrpcLimit      = 100
+)
+
+type XMLRPCClient struct {
+	client *resty.Client
+	config *Config
+
+	mu    sync.Mutex
+	token string
+}
+
+func NewXMLRPCClient(config *Config) *XMLRPCClient {
+	client := newHTTPClient(config, DefaultXMLRPCURL)
+	client.SetHeader("Content-Type", "text/xml")
+	return &XMLRPCClient{client: client, config: config}
+}
+
+func (c *XMLRPCClient) call(ctx context.Context, method string, params ...any) (map[string]any, error) {
+	body, err := encodeCall(method, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetBody(body).
+		Post(c.config.BaseURL)
+	if err != nil {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("%s request failed: %w", method, err))
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, statusError(resp.StatusCode(), fmt.Errorf("%s failed with status %d", method, resp.StatusCode()))
+	}
+
+	value, err := decodeResponse(resp.Body())
+	if err != nil {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("%s: %w", method, err))
+	}
+	result, ok := value.(map[string]any)
+	if !ok {
+		return nil, withKind(ErrProviderUnavailable, fmt.Errorf("%s returned an unexpected response", method))
+	}
+	if err := xmlrpcStatus(method, rpcString(result, "status")); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func xmlrpcStatus(method, status string) error {
+	code, _ := strconv.Atoi(strings.Fields(status + " 0")[0])
+	switch {
+	case code >= 200 && code < 300:
+		return nil
+	case code == 407:
+		return withKind(ErrQuotaExceeded, fmt.Errorf("%s: %s", method, status))
+	case code == 411 || code == 414 || code == 415:
+		return withKind(ErrAuthFailed, fmt.Errorf("%s: %s (the XML-RPC API only accepts registered user agents)", method, status))
+	}
+	return statusError(code, fmt.Errorf("%s: %s", method, status))
+}
+
+func (c *XMLRPCClient) Authenticate(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.login(ctx)
+}
+
+func (c *XMLRPCClient) Capabilities() models.Capabilities {
+	return models.Capabilities{
+		TitleSearch: true,
+		HashSearch:  true,
+		IMDBSearch:  true,
+		RateLimit:   "40 requests/10 seconds",
+	}
+}
+
+func (c *XMLRPCClient) login(ctx context.Context) error {
+	result, err := c.call(ctx, "LogIn", c.config.Username, c.config.Password, "en", c.config.UserAgent)
+	if err != nil {
+		return fmt.Errorf("authentication failed: %w", err)
+	}
+	c.token = rpcString(result, "token")
+	return nil
+}
+
+func (c *XMLRPCClient) session(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token == "" {
+		if err := c.login(ctx); err != nil {
+			return "", err
+		}
+	}
+	return c.token, nil
+}
+
+func (c *XMLRPCClient) Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	var subtitles []*models.Subtitle
+	err := c.config.Breaker.guard(func() (err error) {
+		subtitles, err = c.search(ctx, params)
+		return err
+	})
+	return subtitles, err
+}
+
+func (c *XMLRPCClient) search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	token, err := c.session(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.call(ctx, "SearchSubtitles", token, searchCriteria(params), map[string]any{"limit": xmlrpcLimit})
+	if err != nil {
+		return nil, err
+	}
+
+	entries, _ := result["data"].([]any)
+	subtitles := make([]*models.Subtitle, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		values, ok := entry.(map[string]any)
+		if !ok {
+			continue
+		}
+		subtitle := xmlrpcSubtitle(values)
+		if subtitle.FileID == "" || seen[subtitle.FileID] {
+			continue
+		}
+		seen[subtitle.FileID] = true
+		subtitles = append(subtitles, subtitle)
+	}
+	return subtitles, nil
+}
+
+func searchCriteria(params *models.SearchParams) []any {
+	lang := "all"
+	if params.Language != "" {
+		if l, ok := language.Lookup(params.Language); ok {
+			lang = l.ISO6392
+		}
+	}
+
+	var criteria []any
+	if params.MovieHash != "" && params.MovieByteSize > 0 {
+		criteria = append(criteria, map[string]any{
+			"sublanguageid": lang,
+			"moviehash":     params.MovieHash,
+			"moviebytesize": strconv.FormatInt(params.MovieByteSize, 10),
+		})
+	}
+	if params.Query != "" {
+		query := map[string]any{"sublanguageid": lang, "query": params.Query}
+		if params.Season > 0 {
+			query["season"] = strconv.Itoa(params.Season)
+		}
+		if params.Episode > 0 {
+			query["episode"] = strconv.Itoa(params.Episode)
+		}
+		criteria = append(criteria, query)
+	}
+	return criteria
+}
+
+func xmlrpcSubtitle(values map[string]any) *models.Subtitle {
+	lang := rpcString(values, "ISO639")
+	if l, ok := language.Lookup(rpcString(values, "SubLanguageID")); ok {
+		lang = l.Code
+	}
+
+	subtitle := &models.Subtitle{
+		ID:                rpcString(values, "IDSubtitle"),
+		Provider:          ProviderOpenSubtitlesXMLRPC,
+		Language:          lang,
+		ReleaseName:       rpcString(values, "MovieReleaseName"),
+		FileName:          rpcString(values, "SubFileName"),
+		FileID:            rpcString(values, "IDSubtitleFile"),
+		Uploader:          rpcString(values, "UserNickName"),
+		UploaderRank:      rpcString(values, "UserRank"),
+		Rating:            rpcFloat(values, "SubRating"),
+		Votes:             rpcInt(values, "SubSumVotes"),
+		Downloads:         rpcInt(values, "SubDownloadsCnt"),
+		MovieHash:         rpcString(values, "MovieHash"),
+		FPS:               rpcFloat(values, "MovieFPS"),
+		SubFormat:         rpcString(values, "SubFormat"),
+		HearingImpaired:   rpcString(values, "SubHearingImpaired") == "1",
+		FromTrusted:       rpcString(values, "SubFromTrusted") == "1",
+		ForeignPartsOnly:  rpcString(values, "SubForeignPartsOnly") == "1",
+		MachineTranslated: rpcString(values, "SubAutoTranslation") == "1",
+		MovieHashMatch:    rpcString(values, "MatchedBy") == "moviehash",
+		Comments:          rpcString(values, "SubAuthorComment"),
+		URL:               rpcString(values, "SubtitlesLink"),
+		FeatureTitle:      rpcString(values, "MovieName"),
+		FeatureType:       rpcString(values, "MovieKind"),
+		FeatureYear:       rpcInt(values, "MovieYear"),
+		IMDBID:            rpcInt(values, "IDMovieImdb"),
+	}
+	if date, err := time.Parse(xmlrpcDateLayout, rpcString(values, "SubAddDate")); err == nil {
+		subtitle.UploadDate = date
+	}
+	return subtitle
+}
+
+func (c *XMLRPCClient) Download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error) {
+	var content []byte
+	err := c.config.Breaker.guard(func() (err error) {
+		content, err = c.download(ctx, subtitle)
+		return err
+	})
+	return content, err
+}
+
+func (c *XMLRPCClient) download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error) {
+	token, err := c.session(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.call(ctx, "DownloadSubtitles", token, []any{subtitle.FileID})
+	if err != nil {
+		return nil, err
+	}
+
+	files, _ := result["data"].([]any)
+	for _, file := range files {
+		values, ok := file.(map[string]any)
+		if !ok || rpcString(values, "idsubtitlefile") != subtitle.FileID {
+			continue
+		}
+		content, err := base64.StdEncoding.DecodeString(rpcString(values, "data"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode subtitle %s: %w", subtitle.FileID, err)
+		}
+		return unpackSubtitle(content, subtitle.FileName)
+	}
+	return nil, withKind(ErrNotFound, fmt.Errorf("subtitle file %s was not returned", subtitle.FileID))
+}
+
+func (c *XMLRPCClient) Vote(ctx context.Context, subtitle *models.Subtitle, good bool) error {
+	if c.config.Username == "" || c.config.Password == "" {
+		return withKind(ErrAuthFailed, fmt.Errorf("voting requires an OpenSubtitles account"))
+	}
+
+	token, err := c.session(ctx)
+	if err != nil {
+		return err
+	}
+
+	score := 1
+	if good {
+		score = 10
+	}
+	_, err = c.call(ctx, "SubtitlesVote", token, map[string]any{"idsubtitle": subtitle.ID, "score": score})
+	return err
+}
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var methodName = regexp.MustCompile(`<methodName>(\w+)</methodName>`)
+
+func rpcReply(members string) string {
+	return `<?xml version="1.0"?><methodResponse><params><param><value><struct>` + members + `</struct></value></param></params></methodResponse>`
+}
+
+func rpcMemberXML(name, value string) string {
+	return fmt.Sprintf("<member><name>%s</name><value><string>%s</string></value></member>", name, value)
+}
+
+func newXMLRPCServer(t *testing.T, replies map[string]string) (*XMLRPCClient, *[]string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		method := methodName.FindStringSubmatch(string(body))[1]
+
+		mu.Lock()
+		calls = append(calls, method+" "+string(body))
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "text/xml")
+		reply, ok := replies[method]
+		if !ok {
+			reply = rpcReply(rpcMemberXML("status", "200 OK"))
+		}
+		io.WriteString(w, reply)
+	}))
+	t.Cleanup(server.Close)
+
+	return NewXMLRPCClient(&Config{BaseURL: server.URL, Username: "alice", Password: "secret"}), &calls
+}
+
+func TestXMLRPCCodec(t *testing.T) {
+	t.Parallel()
+
+	body, err := encodeCall("Test", "a<b", 7, true, 1.5, []any{"x"}, map[string]any{"k": "v"})
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "<methodName>Test</methodName>")
+	assert.Contains(t, string(body), "<string>a&lt;b</string>")
+	assert.Contains(t, string(body), "<int>7</int>")
+	assert.Contains(t, string(body), "<boolean>1</boolean>")
+	assert.Contains(t, string(body), "<array><data><value><string>x</string></value></data></array>")
+	assert.Contains(t, string(body), "<struct><member><name>k</name><value><string>v</string></value></member></struct>")
+
+	_, err = encodeCall("Test", struct{}{})
+	assert.Error(t, err)
+
+	value, err := decodeResponse([]byte(`<methodResponse><params><param><value><struct>
+		<member><name>n</name><value><int>3</int></value></member>
+		<member><name>plain</name><value>text</value></member>
+		<member><name>list</name><value><array><data><value><double>2.5</double></value></data></array></value></member>
+	</struct></value></param></params></methodResponse>`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"n": 3, "plain": "text", "list": []any{2.5}}, value)
+
+	_, err = decodeResponse([]byte(`<methodResponse><fault><value><struct>
+		<member><name>faultCode</name><value><int>4</int></value></member>
+		<member><name>faultString</name><value><string>Too many parameters</string></value></member>
+	</struct></value></fault></methodResponse>`))
+	assert.EqualError(t, err, "XML-RPC fault 4: Too many parameters")
+}
+
+func TestXMLRPCClientSearch(t *testing.T) {
+	t.Parallel()
+
+	entry := func(fileID, matchedBy string) string {
+		return "<value><struct>" +
+			rpcMemberXML("IDSubtitle", "77") +
+			rpcMemberXML("IDSubtitleFile", fileID) +
+			rpcMemberXML("SubLanguageID", "pob") +
+			rpcMemberXML("ISO639", "pb") +
+			rpcMemberXML("MovieReleaseName", "Inception.2010.1080p.BluRay.x264-SPARKS") +
+			rpcMemberXML("SubFileName", "inception.srt") +
+			rpcMemberXML("SubFormat", "srt") +
+			rpcMemberXML("SubDownloadsCnt", "1234") +
+			rpcMemberXML("SubRating", "8.5") +
+			rpcMemberXML("SubFromTrusted", "1") +
+			rpcMemberXML("UserNickName", "bob") +
+			rpcMemberXML("MatchedBy", matchedBy) +
+			rpcMemberXML("SubAddDate", "2011-02-03 04:05:06") +
+			rpcMemberXML("MovieYear", "2010") +
+			"</struct></value>"
+	}
+	client, calls := newXMLRPCServer(t, map[string]string{
+		"LogIn": rpcReply(rpcMemberXML("status", "200 OK") + rpcMemberXML("token", "tok")),
+		"SearchSubtitles": rpcReply(rpcMemberXML("status", "200 OK") +
+			"<member><name>data</name><value><array><data>" + entry("1001", "moviehash") + entry("1001", "fulltext") + "</data></array></value></member>"),
+	})
+
+	subtitles, err := client.Search(context.Background(), &models.SearchParams{
+		Query: "Inception", Language: "pt-BR", MovieHash: "8e245d9679d31e12", MovieByteSize: 12909756,
+	})
+	require.NoError(t, err)
+	require.Len(t, subtitles, 1)
+
+	subtitle := subtitles[0]
+	assert.Equal(t, ProviderOpenSubtitlesXMLRPC, subtitle.Provider)
+	assert.Equal(t, "77", subtitle.ID)
+	assert.Equal(t, "1001", subtitle.FileID)
+	assert.Equal(t, "pt-BR", subtitle.Language)
+	assert.Equal(t, 1234, subtitle.Downloads)
+	assert.Equal(t, 8.5, subtitle.Rating)
+	assert.True(t, subtitle.FromTrusted)
+	assert.True(t, subtitle.MovieHashMatch)
+	assert.Equal(t, 2010, subtitle.FeatureYear)
+	assert.Equal(t, 2011, subtitle.UploadDate.Year())
+
+	require.Len(t, *calls, 2)
+	assert.True(t, strings.HasPrefix((*calls)[0], "LogIn "))
+	search := (*calls)[1]
+	assert.Contains(t, search, "<string>tok</string>")
+	assert.Contains(t, search, "<name>sublanguageid</name><value><string>pob</string>")
+	assert.Contains(t, search, "<name>moviehash</name><value><string>8e245d9679d31e12</string>")
+	assert.Contains(t, search, "<name>moviebytesize</name><value><string>12909756</string>")
+	assert.Contains(t, search, "<name>query</name><value><string>Inception</string>")
+}
+
+func TestXMLRPCClientDownloadAndVote(t *testing.T) {
+	t.Parallel()
+
+	var packed bytes.Buffer
+	writer := gzip.NewWriter(&packed)
+	writer.Write([]byte("1\n00:00:01,000 --> 00:00:02,000\nHello\n"))
+	writer.Close()
+
+	client, calls := newXMLRPCServer(t, map[string]string{
+		"LogIn": rpcReply(rpcMemberXML("status", "200 OK") + rpcMemberXML("token", "tok")),
+		"DownloadSubtitles": rpcReply(rpcMemberXML("status", "200 OK") +
+			"<member><name>data</name><value><array><data><value><struct>" +
+			rpcMemberXML("idsubtitlefile", "1001") +
+			"<member><name>data</name><value><base64>" + base64.StdEncoding.EncodeToString(packed.Bytes()) + "</base64></value></member>" +
+			"</struct></value></data></array></value></member>"),
+	})
+
+	subtitle := &models.Subtitle{ID: "77", FileID: "1001", FileName: "inception.srt"}
+	content, err := client.Download(context.Background(), subtitle)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "Hello")
+
+	require.NoError(t, client.Vote(context.Background(), subtitle, true))
+	vote := (*calls)[len(*calls)-1]
+	assert.True(t, strings.HasPrefix(vote, "SubtitlesVote "))
+	assert.Contains(t, vote, "<name>idsubtitle</name><value><string>77</string>")
+	assert.Contains(t, vote, "<name>score</name><value><int>10</int>")
+
+	_, err = client.Download(context.Background(), &models.Subtitle{FileID: "999"})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestXMLRPCClientStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		status string
+		kind   error
+	}{
+		{"401 Unauthorized", ErrAuthFailed},
+		{"414 Unknown User Agent", ErrAuthFailed},
+		{"407 Download limit reached", ErrQuotaExceeded},
+		{"503 Service Unavailable", ErrProviderUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			t.Parallel()
+
+			client, _ := newXMLRPCServer(t, map[string]string{"LogIn": rpcReply(rpcMemberXML("status", tt.status))})
+			_, err := client.Search(context.Background(), &models.SearchParams{Query: "Inception", Language: "en"})
+			assert.ErrorIs(t, err, tt.kind)
+			assert.ErrorContains(t, err, tt.status)
+		})
+	}
+
+	anonymous := NewXMLRPCClient(&Config{BaseURL: "http://127.0.0.1:0"})
+	assert.ErrorIs(t, anonymous.Vote(context.Background(), &models.Subtitle{ID: "1"}, false), ErrAuthFailed)
+}
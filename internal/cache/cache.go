@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/internal/fsutil"
+)
+
+type Cache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+type entry struct {
+	StoredAt time.Time       `json:"stored_at"`
+	Data     json.RawMessage `json:"data"`
+}
+
+func New(dir string, ttl time.Duration) *Cache {
+	return &Cache{
+		dir: dir,
+		ttl: ttl,
+		now: time.Now,
+	}
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+func (c *Cache) Get(key string, v any) bool {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return false
+	}
+
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return false
+	}
+
+	if c.ttl > 0 && c.now().Sub(e.StoredAt) > c.ttl {
+		return false
+	}
+
+	return json.Unmarshal(e.Data, v) == nil
+}
+
+func (c *Cache) Set(key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry '%s': %w", key, err)
+	}
+
+	encoded, err := json.Marshal(entry{StoredAt: c.now(), Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry '%s': %w", key, err)
+	}
+
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	if err := fsutil.WriteFile(c.path(key), encoded, fsutil.WriteOptions{Perm: 0600}); err != nil {
+		return fmt.Errorf("failed to write cache entry '%s': %w", key, err)
+	}
+
+	return nil
+}
+
+func (c *Cache) Delete(key string) error {
+	if err := os.Remove(c.path(key)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove cache entry '%s': %w", key, err)
+	}
+	return nil
+}
+
+// Prune removes the entries whose key starts with prefix once they have
+// outlived the TTL, then the oldest ones beyond the newest keep.
+func (c *Cache) Prune(prefix string, keep int) error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	type stored struct {
+		path string
+		at   time.Time
+	}
+	var kept []stored
+	var stale []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) || filepath.Ext(name) != ".json" {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(c.dir, name)
+		if c.ttl > 0 && c.now().Sub(info.ModTime()) > c.ttl {
+			stale = append(stale, path)
+			continue
+		}
+		kept = append(kept, stored{path: path, at: info.ModTime()})
+	}
+
+	if len(kept) > keep {
+		sort.Slice(kept, func(i, j int) bool { return kept[i].at.After(kept[j].at) })
+		for _, entry := range kept[keep:] {
+			stale = append(stale, entry.path)
+		}
+	}
+
+	for _, path := range stale {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove cache entry: %w", err)
+		}
+	}
+	return nil
+}
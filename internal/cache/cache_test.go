@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+
+		c := New(filepath.Join(t.TempDir(), "cache"), time.Hour)
+		require.NoError(t, c.Set("languages", []string{"en", "pt-BR"}))
+
+		var got []string
+		require.True(t, c.Get("languages", &got))
+		assert.Equal(t, []string{"en", "pt-BR"}, got)
+	})
+
+	t.Run("missing entry", func(t *testing.T) {
+		t.Parallel()
+
+		c := New(t.TempDir(), time.Hour)
+
+		var got []string
+		assert.False(t, c.Get("languages", &got))
+	})
+
+	t.Run("expired entry", func(t *testing.T) {
+		t.Parallel()
+
+		c := New(t.TempDir(), time.Hour)
+		require.NoError(t, c.Set("languages", []string{"en"}))
+
+		c.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+		var got []string
+		assert.False(t, c.Get("languages", &got))
+	})
+
+	t.Run("zero ttl never expires", func(t *testing.T) {
+		t.Parallel()
+
+		c := New(t.TempDir(), 0)
+		require.NoError(t, c.Set("languages", []string{"en"}))
+
+		c.now = func() time.Time { return time.Now().Add(24 * 365 * time.Hour) }
+
+		var got []string
+		assert.True(t, c.Get("languages", &got))
+	})
+
+	t.Run("corrupt entry", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "languages.json"), []byte("{not json"), 0600))
+
+		c := New(dir, time.Hour)
+
+		var got []string
+		assert.False(t, c.Get("languages", &got))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		t.Parallel()
+
+		c := New(t.TempDir(), time.Hour)
+		require.NoError(t, c.Set("languages", []string{"en"}))
+		require.NoError(t, c.Delete("languages"))
+		require.NoError(t, c.Delete("languages"))
+
+		var got []string
+		assert.False(t, c.Get("languages", &got))
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		c := New(dir, time.Hour)
+		now := time.Now()
+		for i, key := range []string{"http-old", "http-a", "http-b", "http-c", "languages"} {
+			require.NoError(t, c.Set(key, i))
+			at := now.Add(-time.Duration(i) * time.Minute)
+			if key == "http-old" {
+				at = now.Add(-2 * time.Hour)
+			}
+			require.NoError(t, os.Chtimes(filepath.Join(dir, key+".json"), at, at))
+		}
+
+		require.NoError(t, c.Prune("http-", 2))
+
+		var got int
+		assert.False(t, c.Get("http-old", &got), "expired")
+		assert.True(t, c.Get("http-a", &got))
+		assert.True(t, c.Get("http-b", &got))
+		assert.False(t, c.Get("http-c", &got), "oldest beyond the limit")
+		assert.True(t, c.Get("languages", &got), "other keys are left alone")
+
+		require.NoError(t, New(filepath.Join(dir, "missing"), time.Hour).Prune("http-", 2))
+	})
+}
@@ -0,0 +1,657 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/carlosarraes/subs-cli/internal/fsutil"
+	"github.com/carlosarraes/subs-cli/internal/score"
+	"github.com/carlosarraes/subs-cli/internal/subformat"
+)
+
+const (
+	DirName          = ".subs-cli"
+	FileName         = "config.yaml"
+	LockFileName     = "lock"
+	FeedbackFileName = "feedback.json"
+	UpdateFileName   = "update.json"
+	PluginsDirName   = "plugins"
+
+	NamingLanguage = "language"
+	NamingPlain    = "plain"
+
+	OverwriteAlways   = "always"
+	OverwriteNever    = "never"
+	OverwriteIfBetter = "if-better"
+
+	LineEndingsKeep = "keep"
+	LineEndingsLF   = "lf"
+	LineEndingsCRLF = "crlf"
+
+	BOMKeep   = "keep"
+	BOMAdd    = "add"
+	BOMRemove = "remove"
+
+	ProxyDirect = "direct"
+
+	BackendREST   = "rest"
+	BackendXMLRPC = "xmlrpc"
+
+	DefaultTimeout     = 30 * time.Second
+	DefaultDialTimeout = 30 * time.Second
+	DefaultFileTimeout = 2 * time.Minute
+
+	DefaultProviderTimeout = 15 * time.Second
+
+	FourDigitAuto   = "auto"
+	FourDigitAlways = "always"
+	FourDigitNever  = "never"
+
+	OnMismatchFallback = "fallback"
+	OnMismatchWarn     = "warn"
+
+	DefaultLengthTolerance = 30 * time.Second
+	DefaultMinCoverage     = 0.8
+
+	ShareAuto   = "auto"
+	ShareAlways = "always"
+	ShareNever  = "never"
+
+	DefaultPlayer = "mpv"
+
+	DefaultWriteRetries = 3
+	DefaultRetryDelay   = time.Second
+	DefaultStatBatch    = 100
+	DefaultStatPause    = 50 * time.Millisecond
+)
+
+var proxySchemes = []string{"http", "https", "socks5", "socks5h"}
+
+var OptionalProviders = []string{"napiprojekt", "napisy24", "bsplayer"}
+
+type Config struct {
+	OpenSubtitles OpenSubtitlesConfig `yaml:"opensubtitles"`
+	Defaults      DefaultsConfig      `yaml:"defaults"`
+	Output        OutputConfig        `yaml:"output"`
+	Cache         CacheConfig         `yaml:"cache"`
+	Network       NetworkConfig       `yaml:"network,omitempty"`
+	Shares        SharesConfig        `yaml:"shares,omitempty"`
+	Updates       UpdatesConfig       `yaml:"updates"`
+	Player        PlayerConfig        `yaml:"player,omitempty"`
+	Probe         ProbeConfig         `yaml:"probe,omitempty"`
+	Scoring       ScoringConfig       `yaml:"scoring,omitempty"`
+	Translate     TranslateConfig     `yaml:"translate,omitempty"`
+	Archive       ArchiveConfig       `yaml:"archive,omitempty"`
+	Anime         bool                `yaml:"anime,omitempty"`
+	Jimaku        JimakuConfig        `yaml:"jimaku,omitempty"`
+	Metadata      MetadataConfig      `yaml:"metadata,omitempty"`
+	Parser        ParserConfig        `yaml:"parser,omitempty"`
+	Providers     []string            `yaml:"providers,omitempty"`
+	Napisy24      Napisy24Config      `yaml:"napisy24,omitempty"`
+	BSPlayer      BSPlayerConfig      `yaml:"bsplayer,omitempty"`
+	Proxy         string              `yaml:"proxy,omitempty"`
+}
+
+type OpenSubtitlesConfig struct {
+	APIKey   string `yaml:"api_key,omitempty"`
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
+	Proxy    string `yaml:"proxy,omitempty"`
+	Backend  string `yaml:"backend,omitempty"`
+}
+
+type DefaultsConfig struct {
+	Languages   []string `yaml:"languages,omitempty"`
+	Interactive bool     `yaml:"interactive"`
+	Confirm     bool     `yaml:"confirm"`
+	AutoSelect  bool     `yaml:"auto_select"`
+}
+
+type OutputConfig struct {
+	Naming      string            `yaml:"naming,omitempty"`
+	Overwrite   string            `yaml:"overwrite,omitempty"`
+	NoEmoji     bool              `yaml:"no_emoji"`
+	Quiet       bool              `yaml:"quiet"`
+	Backup      bool              `yaml:"backup"`
+	KeepFormat  bool              `yaml:"keep_format"`
+	StripTags   bool              `yaml:"strip_tags"`
+	LineEndings string            `yaml:"line_endings,omitempty"`
+	BOM         string            `yaml:"bom,omitempty"`
+	Reflow      ReflowConfig      `yaml:"reflow,omitempty"`
+	Permissions PermissionsConfig `yaml:"permissions,omitempty"`
+}
+
+type ReflowConfig struct {
+	Enabled       bool    `yaml:"enabled"`
+	MaxLineLength int     `yaml:"max_line_length"`
+	MaxCPS        float64 `yaml:"max_cps"`
+}
+
+type PermissionsConfig struct {
+	MatchMedia bool   `yaml:"match_media"`
+	Umask      string `yaml:"umask,omitempty"`
+	UID        *int   `yaml:"uid,omitempty"`
+	GID        *int   `yaml:"gid,omitempty"`
+}
+
+type NetworkConfig struct {
+	Timeout          string `yaml:"timeout,omitempty"`
+	DialTimeout      string `yaml:"dial_timeout,omitempty"`
+	FileTimeout      string `yaml:"file_timeout,omitempty"`
+	ProviderTimeout  string `yaml:"provider_timeout,omitempty"`
+	MaxIdleConns     int    `yaml:"max_idle_conns,omitempty"`
+	FailureThreshold int    `yaml:"failure_threshold,omitempty"`
+	BreakerCooldown  string `yaml:"breaker_cooldown,omitempty"`
+}
+
+type SharesConfig struct {
+	Detect       string `yaml:"detect,omitempty"`
+	WriteRetries int    `yaml:"write_retries,omitempty"`
+	RetryDelay   string `yaml:"retry_delay,omitempty"`
+	Chown        bool   `yaml:"chown,omitempty"`
+	StatBatch    int    `yaml:"stat_batch,omitempty"`
+	StatPause    string `yaml:"stat_pause,omitempty"`
+}
+
+type UpdatesConfig struct {
+	Check bool `yaml:"check"`
+}
+
+type PlayerConfig struct {
+	Command string   `yaml:"command,omitempty"`
+	Args    []string `yaml:"args,omitempty"`
+}
+
+type ProbeConfig struct {
+	Enabled     bool    `yaml:"enabled"`
+	FFprobe     string  `yaml:"ffprobe,omitempty"`
+	Tolerance   string  `yaml:"tolerance,omitempty"`
+	MinCoverage float64 `yaml:"min_coverage,omitempty"`
+	OnMismatch  string  `yaml:"on_mismatch,omitempty"`
+}
+
+type ScoringConfig struct {
+	PreferGroups  []string      `yaml:"prefer_groups,omitempty"`
+	AvoidGroups   []string      `yaml:"avoid_groups,omitempty"`
+	PreferSources []string      `yaml:"prefer_sources,omitempty"`
+	AvoidSources  []string      `yaml:"avoid_sources,omitempty"`
+	MinScore      int           `yaml:"min_score,omitempty"`
+	Weights       score.Weights `yaml:"weights"`
+}
+
+type TranslateConfig struct {
+	Backend string `yaml:"backend,omitempty"`
+	APIKey  string `yaml:"api_key,omitempty"`
+	URL     string `yaml:"url,omitempty"`
+	Model   string `yaml:"model,omitempty"`
+}
+
+type ArchiveConfig struct {
+	Path string `yaml:"path,omitempty"`
+}
+
+type JimakuConfig struct {
+	APIKey string `yaml:"api_key,omitempty"`
+}
+
+type MetadataConfig struct {
+	TMDBAPIKey string `yaml:"tmdb_api_key,omitempty"`
+	Guessit    bool   `yaml:"guessit,omitempty"`
+}
+
+type ParserConfig struct {
+	ThreeDigitEpisodes bool   `yaml:"three_digit_episodes,omitempty"`
+	FourDigitEpisodes  string `yaml:"four_digit_episodes,omitempty"`
+}
+
+type Napisy24Config struct {
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
+}
+
+type BSPlayerConfig struct {
+	Endpoint string `yaml:"endpoint,omitempty"`
+}
+
+type CacheConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	TTL     string `yaml:"ttl,omitempty"`
+	Path    string `yaml:"path,omitempty"`
+}
+
+func Default() *Config {
+	return &Config{
+		Output: OutputConfig{
+			Naming:    NamingLanguage,
+			Overwrite: OverwriteAlways,
+			Reflow: ReflowConfig{
+				MaxLineLength: subformat.DefaultMaxLineLength,
+				MaxCPS:        subformat.DefaultMaxCPS,
+			},
+		},
+		Scoring: ScoringConfig{
+			AvoidSources: []string{score.SourceCam, score.SourceTelesync},
+			Weights:      score.DefaultWeights(),
+		},
+		Updates: UpdatesConfig{
+			Check: true,
+		},
+		Cache: CacheConfig{
+			Enabled: true,
+			TTL:     "24h",
+			Path:    "~/" + DirName + "/cache",
+		},
+	}
+}
+
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	return filepath.Join(home, DirName), nil
+}
+
+func DefaultPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, FileName), nil
+}
+
+func LockPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, LockFileName), nil
+}
+
+func PluginsDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, PluginsDirName), nil
+}
+
+func FeedbackPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, FeedbackFileName), nil
+}
+
+func UpdatePath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, UpdateFileName), nil
+}
+
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file '%s': %w", path, err)
+	}
+
+	cfg := Default()
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file '%s': %w", path, err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file '%s': %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+func LoadDefault() (*Config, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return Default(), nil
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return Default(), nil
+	}
+
+	return Load(path)
+}
+
+func (c *Config) Validate() error {
+	switch c.Output.Naming {
+	case "", NamingLanguage, NamingPlain:
+	default:
+		return fmt.Errorf("output.naming must be '%s' or '%s', got '%s'", NamingLanguage, NamingPlain, c.Output.Naming)
+	}
+
+	switch c.Output.Overwrite {
+	case "", OverwriteAlways, OverwriteNever, OverwriteIfBetter:
+	default:
+		return fmt.Errorf("output.overwrite must be '%s', '%s' or '%s', got '%s'", OverwriteAlways, OverwriteNever, OverwriteIfBetter, c.Output.Overwrite)
+	}
+
+	switch c.Output.LineEndings {
+	case "", LineEndingsKeep, LineEndingsLF, LineEndingsCRLF:
+	default:
+		return fmt.Errorf("output.line_endings must be '%s', '%s' or '%s', got '%s'", LineEndingsKeep, LineEndingsLF, LineEndingsCRLF, c.Output.LineEndings)
+	}
+
+	switch c.Output.BOM {
+	case "", BOMKeep, BOMAdd, BOMRemove:
+	default:
+		return fmt.Errorf("output.bom must be '%s', '%s' or '%s', got '%s'", BOMKeep, BOMAdd, BOMRemove, c.Output.BOM)
+	}
+
+	if _, err := c.Cache.TTLDuration(); err != nil {
+		return err
+	}
+
+	if _, err := c.Output.Permissions.UmaskMode(); err != nil {
+		return err
+	}
+
+	if c.Output.Reflow.MaxLineLength < 0 {
+		return fmt.Errorf("output.reflow.max_line_length must not be negative, got %d", c.Output.Reflow.MaxLineLength)
+	}
+	if c.Output.Reflow.MaxCPS < 0 {
+		return fmt.Errorf("output.reflow.max_cps must not be negative, got %g", c.Output.Reflow.MaxCPS)
+	}
+
+	for _, timeout := range []func() (time.Duration, error){c.Network.RequestTimeout, c.Network.DialTimeoutDuration, c.Network.FileTimeoutDuration, c.Network.ProviderTimeoutDuration} {
+		if _, err := timeout(); err != nil {
+			return err
+		}
+	}
+	if c.Network.MaxIdleConns < 0 {
+		return fmt.Errorf("network.max_idle_conns cannot be negative, got %d", c.Network.MaxIdleConns)
+	}
+	if c.Network.FailureThreshold < 0 {
+		return fmt.Errorf("network.failure_threshold cannot be negative, got %d", c.Network.FailureThreshold)
+	}
+	if _, err := c.Network.BreakerCooldownDuration(); err != nil {
+		return err
+	}
+
+	switch c.Shares.Detect {
+	case "", ShareAuto, ShareAlways, ShareNever:
+	default:
+		return fmt.Errorf("shares.detect must be '%s', '%s' or '%s', got '%s'", ShareAuto, ShareAlways, ShareNever, c.Shares.Detect)
+	}
+	if c.Shares.WriteRetries < 0 {
+		return fmt.Errorf("shares.write_retries cannot be negative, got %d", c.Shares.WriteRetries)
+	}
+	if c.Shares.StatBatch < 0 {
+		return fmt.Errorf("shares.stat_batch cannot be negative, got %d", c.Shares.StatBatch)
+	}
+	if _, err := c.Shares.RetryDelayDuration(); err != nil {
+		return err
+	}
+	if _, err := c.Shares.StatPauseDuration(); err != nil {
+		return err
+	}
+
+	if _, err := c.Probe.ToleranceDuration(); err != nil {
+		return err
+	}
+	if c.Probe.MinCoverage < 0 || c.Probe.MinCoverage > 1 {
+		return fmt.Errorf("probe.min_coverage must be between 0 and 1, got %g", c.Probe.MinCoverage)
+	}
+	switch c.Parser.FourDigitEpisodes {
+	case "", FourDigitAuto, FourDigitAlways, FourDigitNever:
+	default:
+		return fmt.Errorf("parser.four_digit_episodes must be '%s', '%s' or '%s', got '%s'", FourDigitAuto, FourDigitAlways, FourDigitNever, c.Parser.FourDigitEpisodes)
+	}
+
+	switch c.Probe.OnMismatch {
+	case "", OnMismatchFallback, OnMismatchWarn:
+	default:
+		return fmt.Errorf("probe.on_mismatch must be '%s' or '%s', got '%s'", OnMismatchFallback, OnMismatchWarn, c.Probe.OnMismatch)
+	}
+
+	if err := validateSources("scoring.prefer_sources", c.Scoring.PreferSources); err != nil {
+		return err
+	}
+	if err := validateSources("scoring.avoid_sources", c.Scoring.AvoidSources); err != nil {
+		return err
+	}
+	weights := c.Scoring.Weights.Named()
+	for _, name := range slices.Sorted(maps.Keys(weights)) {
+		if weights[name] < 0 {
+			return fmt.Errorf("scoring.weights.%s must not be negative, got %d", name, weights[name])
+		}
+	}
+
+	switch c.OpenSubtitles.Backend {
+	case "", BackendREST, BackendXMLRPC:
+	default:
+		return fmt.Errorf("opensubtitles.backend must be '%s' or '%s', got '%s'", BackendREST, BackendXMLRPC, c.OpenSubtitles.Backend)
+	}
+
+	for _, name := range c.Providers {
+		if !slices.Contains(OptionalProviders, name) {
+			return fmt.Errorf("providers: unknown provider '%s', expected one of %s", name, strings.Join(OptionalProviders, ", "))
+		}
+	}
+
+	switch c.Translate.Backend {
+	case "", "deepl", "google", "llm":
+	default:
+		return fmt.Errorf("translate.backend must be 'deepl', 'google' or 'llm', got '%s'", c.Translate.Backend)
+	}
+
+	if err := ValidateProxy(c.Proxy); err != nil {
+		return fmt.Errorf("proxy: %w", err)
+	}
+	if err := ValidateProxy(c.OpenSubtitles.Proxy); err != nil {
+		return fmt.Errorf("opensubtitles.proxy: %w", err)
+	}
+
+	for _, lang := range c.Defaults.Languages {
+		if strings.TrimSpace(lang) == "" {
+			return fmt.Errorf("defaults.languages cannot contain empty values")
+		}
+	}
+
+	return nil
+}
+
+func validateSources(field string, sources []string) error {
+	for _, source := range sources {
+		if !slices.Contains(score.Sources, source) {
+			return fmt.Errorf("%s: unknown source '%s', expected one of %s", field, source, strings.Join(score.Sources, ", "))
+		}
+	}
+	return nil
+}
+
+func (c *Config) OpenSubtitlesProxy() string {
+	if c.OpenSubtitles.Proxy != "" {
+		return c.OpenSubtitles.Proxy
+	}
+	return c.Proxy
+}
+
+func ValidateProxy(value string) error {
+	if value == "" || value == ProxyDirect {
+		return nil
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" || !slices.Contains(proxySchemes, u.Scheme) {
+		return fmt.Errorf("invalid proxy '%s': expected a URL like 'http://host:port' or 'socks5://host:port' (supported schemes: %s), or '%s'", value, strings.Join(proxySchemes, ", "), ProxyDirect)
+	}
+	return nil
+}
+
+func (n NetworkConfig) RequestTimeout() (time.Duration, error) {
+	return parseTimeout("network.timeout", n.Timeout, DefaultTimeout)
+}
+
+func (n NetworkConfig) DialTimeoutDuration() (time.Duration, error) {
+	return parseTimeout("network.dial_timeout", n.DialTimeout, DefaultDialTimeout)
+}
+
+func (n NetworkConfig) FileTimeoutDuration() (time.Duration, error) {
+	return parseTimeout("network.file_timeout", n.FileTimeout, DefaultFileTimeout)
+}
+
+func (n NetworkConfig) ProviderTimeoutDuration() (time.Duration, error) {
+	return parseTimeout("network.provider_timeout", n.ProviderTimeout, DefaultProviderTimeout)
+}
+
+func (n NetworkConfig) BreakerCooldownDuration() (time.Duration, error) {
+	return parseTimeout("network.breaker_cooldown", n.BreakerCooldown, 0)
+}
+
+func (s SharesConfig) Retries() int {
+	if s.WriteRetries == 0 {
+		return DefaultWriteRetries
+	}
+	return s.WriteRetries
+}
+
+func (s SharesConfig) Batch() int {
+	if s.StatBatch == 0 {
+		return DefaultStatBatch
+	}
+	return s.StatBatch
+}
+
+func (s SharesConfig) RetryDelayDuration() (time.Duration, error) {
+	return parseTimeout("shares.retry_delay", s.RetryDelay, DefaultRetryDelay)
+}
+
+func (s SharesConfig) StatPauseDuration() (time.Duration, error) {
+	return parseTimeout("shares.stat_pause", s.StatPause, DefaultStatPause)
+}
+
+func (p PlayerConfig) Binary() string {
+	if p.Command == "" {
+		return DefaultPlayer
+	}
+	return p.Command
+}
+
+func (p ProbeConfig) ToleranceDuration() (time.Duration, error) {
+	return parseTimeout("probe.tolerance", p.Tolerance, DefaultLengthTolerance)
+}
+
+func (p ProbeConfig) Coverage() float64 {
+	if p.MinCoverage == 0 {
+		return DefaultMinCoverage
+	}
+	return p.MinCoverage
+}
+
+func (p ProbeConfig) Fallback() bool {
+	return p.OnMismatch != OnMismatchWarn
+}
+
+func (p ParserConfig) FourDigit() string {
+	if p.FourDigitEpisodes == "" {
+		return FourDigitAuto
+	}
+	return p.FourDigitEpisodes
+}
+
+func parseTimeout(field, value string, fallback time.Duration) (time.Duration, error) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("%s must be a positive duration like '30s', got '%s'", field, value)
+	}
+	return timeout, nil
+}
+
+func (p PermissionsConfig) UmaskMode() (os.FileMode, error) {
+	if p.Umask == "" {
+		return 0, nil
+	}
+
+	umask, err := strconv.ParseUint(p.Umask, 8, 32)
+	if err != nil || umask > 0777 {
+		return 0, fmt.Errorf("output.permissions.umask must be an octal mask like '022', got '%s'", p.Umask)
+	}
+	return os.FileMode(umask), nil
+}
+
+func (c CacheConfig) TTLDuration() (time.Duration, error) {
+	if c.TTL == "" {
+		return 0, nil
+	}
+
+	ttl, err := time.ParseDuration(c.TTL)
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("cache.ttl must be a positive duration like '24h', got '%s'", c.TTL)
+	}
+	return ttl, nil
+}
+
+func (c CacheConfig) Dir() (string, error) {
+	path := c.Path
+	if path == "" {
+		dir, err := Dir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, "cache"), nil
+	}
+	return expandHome(path)
+}
+
+func (a ArchiveConfig) Dir() (string, error) {
+	if a.Path == "" {
+		return "", nil
+	}
+	return expandHome(a.Path)
+}
+
+func expandHome(path string) (string, error) {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("cannot determine home directory: %w", err)
+		}
+		path = filepath.Join(home, strings.TrimPrefix(path, "~"))
+	}
+	return path, nil
+}
+
+func (c *Config) Save(path string) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	if err := fsutil.WriteFile(path, data, fsutil.WriteOptions{Perm: 0600}); err != nil {
+		return fmt.Errorf("failed to write config file '%s': %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	KindString = "string"
+	KindBool   = "bool"
+	KindInt    = "int"
+	KindNumber = "number"
+	KindList   = "list"
+)
+
+type Field struct {
+	Key    string
+	Kind   string
+	Value  string
+	Secret bool
+}
+
+func (c *Config) Fields() []Field {
+	var fields []Field
+	walkFields(reflect.ValueOf(c).Elem(), "", func(key string, v reflect.Value) {
+		fields = append(fields, Field{Key: key, Kind: fieldKind(v.Type()), Value: formatField(v), Secret: isSecret(key)})
+	})
+	return fields
+}
+
+func (c *Config) Get(key string) (Field, error) {
+	for _, field := range c.Fields() {
+		if field.Key == key {
+			return field, nil
+		}
+	}
+	return Field{}, fmt.Errorf("unknown config key '%s'", key)
+}
+
+func (c *Config) Set(key, value string) error {
+	next := c.Clone()
+	found := false
+	var err error
+	walkFields(reflect.ValueOf(next).Elem(), "", func(name string, v reflect.Value) {
+		if name == key {
+			found = true
+			err = setField(v, strings.TrimSpace(value))
+		}
+	})
+	if !found {
+		return fmt.Errorf("unknown config key '%s'", key)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	if err := next.Validate(); err != nil {
+		return err
+	}
+
+	*c = *next
+	return nil
+}
+
+func walkFields(v reflect.Value, prefix string, visit func(key string, v reflect.Value)) {
+	t := v.Type()
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		key := prefix + name
+
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			walkFields(field, key+".", visit)
+			continue
+		}
+		visit(key, field)
+	}
+}
+
+func fieldKind(t reflect.Type) string {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return KindBool
+	case reflect.Int:
+		return KindInt
+	case reflect.Float64:
+		return KindNumber
+	case reflect.Slice:
+		return KindList
+	}
+	return KindString
+}
+
+func formatField(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return ""
+		}
+		return formatField(v.Elem())
+	case reflect.Slice:
+		return strings.Join(v.Interface().([]string), ",")
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func setField(v reflect.Value, value string) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if value == "" {
+			v.SetZero()
+			return nil
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("expected a whole number, got '%s'", value)
+		}
+		v.Set(reflect.ValueOf(&n))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("expected true or false, got '%s'", value)
+		}
+		v.SetBool(b)
+	case reflect.Int:
+		if value == "" {
+			value = "0"
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("expected a whole number, got '%s'", value)
+		}
+		v.SetInt(int64(n))
+	case reflect.Float64:
+		if value == "" {
+			value = "0"
+		}
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("expected a number, got '%s'", value)
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		v.SetString(value)
+	}
+	return nil
+}
+
+func isSecret(key string) bool {
+	return strings.HasSuffix(key, "password") || strings.HasSuffix(key, "api_key")
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFields(t *testing.T) {
+	t.Parallel()
+
+	cfg := Default()
+	cfg.OpenSubtitles.Password = "secret"
+	cfg.Defaults.Languages = []string{"en", "pt-BR"}
+
+	fields := map[string]Field{}
+	for _, field := range cfg.Fields() {
+		fields[field.Key] = field
+	}
+
+	assert.Equal(t, Field{Key: "defaults.languages", Kind: KindList, Value: "en,pt-BR"}, fields["defaults.languages"])
+	assert.Equal(t, Field{Key: "opensubtitles.password", Kind: KindString, Value: "secret", Secret: true}, fields["opensubtitles.password"])
+	assert.Equal(t, KindBool, fields["cache.enabled"].Kind)
+	assert.Equal(t, KindInt, fields["scoring.weights.hash"].Kind)
+	assert.Equal(t, KindNumber, fields["probe.min_coverage"].Kind)
+	assert.Equal(t, KindInt, fields["output.permissions.uid"].Kind)
+	assert.Empty(t, fields["output.permissions.uid"].Value)
+	assert.True(t, fields["metadata.tmdb_api_key"].Secret)
+}
+
+func TestSet(t *testing.T) {
+	t.Parallel()
+
+	cfg := Default()
+	require.NoError(t, cfg.Set("output.naming", "plain"))
+	require.NoError(t, cfg.Set("cache.enabled", "false"))
+	require.NoError(t, cfg.Set("network.max_idle_conns", "8"))
+	require.NoError(t, cfg.Set("probe.min_coverage", "0.5"))
+	require.NoError(t, cfg.Set("providers", "napisy24, bsplayer"))
+	require.NoError(t, cfg.Set("output.permissions.gid", "100"))
+
+	assert.Equal(t, NamingPlain, cfg.Output.Naming)
+	assert.False(t, cfg.Cache.Enabled)
+	assert.Equal(t, 8, cfg.Network.MaxIdleConns)
+	assert.Equal(t, 0.5, cfg.Probe.MinCoverage)
+	assert.Equal(t, []string{"napisy24", "bsplayer"}, cfg.Providers)
+	require.NotNil(t, cfg.Output.Permissions.GID)
+	assert.Equal(t, 100, *cfg.Output.Permissions.GID)
+
+	require.NoError(t, cfg.Set("output.permissions.gid", ""))
+	assert.Nil(t, cfg.Output.Permissions.GID)
+
+	field, err := cfg.Get("scoring.weights.hash")
+	require.NoError(t, err)
+	assert.Equal(t, "100", field.Value)
+
+	assert.ErrorContains(t, cfg.Set("output.naming", "fancy"), "output.naming must be")
+	assert.ErrorContains(t, cfg.Set("cache.enabled", "maybe"), "cache.enabled: expected true or false")
+	assert.ErrorContains(t, cfg.Set("network.max_idle_conns", "many"), "expected a whole number")
+	assert.ErrorContains(t, cfg.Set("network.timeout", "soon"), "network.timeout")
+	assert.ErrorContains(t, cfg.Set("output", "plain"), "unknown config key 'output'")
+	_, err = cfg.Get("nope")
+	assert.ErrorContains(t, err, "unknown config key 'nope'")
+
+	assert.Equal(t, NamingPlain, cfg.Output.Naming, "invalid values leave the config unchanged")
+	assert.Empty(t, cfg.Network.Timeout)
+}
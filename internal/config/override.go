@@ -0,0 +1,112 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+var OverrideFileNames = []string{".subsrc", ".subs.yaml"}
+
+type Resolved struct {
+	Config  *Config
+	Sources []string
+}
+
+type Resolver struct {
+	base  *Config
+	mu    sync.Mutex
+	cache map[string]*Resolved
+}
+
+func NewResolver(base *Config) *Resolver {
+	return &Resolver{
+		base:  base,
+		cache: make(map[string]*Resolved),
+	}
+}
+
+func (r *Resolver) ForDir(dir string) (*Resolved, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid directory '%s': %w", dir, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.resolve(abs)
+}
+
+func (r *Resolver) resolve(dir string) (*Resolved, error) {
+	if resolved, ok := r.cache[dir]; ok {
+		return resolved, nil
+	}
+
+	var inherited *Resolved
+	if parent := filepath.Dir(dir); parent != dir {
+		var err error
+		inherited, err = r.resolve(parent)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		inherited = &Resolved{Config: r.base}
+	}
+
+	resolved := inherited
+	for _, name := range OverrideFileNames {
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read override file '%s': %w", path, err)
+		}
+
+		cfg := resolved.Config.Clone()
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse override file '%s': %w", path, err)
+		}
+		if err := cfg.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid override file '%s': %w", path, err)
+		}
+
+		resolved = &Resolved{
+			Config:  cfg,
+			Sources: append(append([]string{}, resolved.Sources...), path),
+		}
+		break
+	}
+
+	r.cache[dir] = resolved
+	return resolved, nil
+}
+
+func (c *Config) Clone() *Config {
+	clone := *c
+	clone.Defaults.Languages = append([]string(nil), c.Defaults.Languages...)
+	clone.Providers = append([]string(nil), c.Providers...)
+	clone.Player.Args = append([]string(nil), c.Player.Args...)
+	clone.Scoring.PreferGroups = append([]string(nil), c.Scoring.PreferGroups...)
+	clone.Scoring.AvoidGroups = append([]string(nil), c.Scoring.AvoidGroups...)
+	clone.Scoring.PreferSources = append([]string(nil), c.Scoring.PreferSources...)
+	clone.Scoring.AvoidSources = append([]string(nil), c.Scoring.AvoidSources...)
+	// yaml decodes into existing pointees, so an override must not share
+	// them with the config it was cloned from.
+	clone.Output.Permissions.UID = cloneInt(c.Output.Permissions.UID)
+	clone.Output.Permissions.GID = cloneInt(c.Output.Permissions.GID)
+	return &clone
+}
+
+func cloneInt(v *int) *int {
+	if v == nil {
+		return nil
+	}
+	n := *v
+	return &n
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolver_ForDir(t *testing.T) {
+	t.Parallel()
+
+	newBase := func() *Config {
+		base := Default()
+		base.Defaults.Languages = []string{"en"}
+		base.OpenSubtitles.Username = "global-user"
+		return base
+	}
+
+	t.Run("without overrides returns base config", func(t *testing.T) {
+		t.Parallel()
+
+		base := newBase()
+		resolved, err := NewResolver(base).ForDir(t.TempDir())
+
+		require.NoError(t, err)
+		assert.Same(t, base, resolved.Config)
+		assert.Empty(t, resolved.Sources)
+	})
+
+	t.Run("override applies to subtree and merges on top of base", func(t *testing.T) {
+		t.Parallel()
+
+		root := t.TempDir()
+		novelas := filepath.Join(root, "Telenovelas")
+		season := filepath.Join(novelas, "Season 1")
+		require.NoError(t, os.MkdirAll(season, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(novelas, ".subsrc"), []byte("defaults:\n  languages: [es]\n"), 0644))
+
+		base := newBase()
+		resolver := NewResolver(base)
+
+		resolved, err := resolver.ForDir(season)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"es"}, resolved.Config.Defaults.Languages)
+		assert.Equal(t, "global-user", resolved.Config.OpenSubtitles.Username)
+		assert.Equal(t, []string{filepath.Join(novelas, ".subsrc")}, resolved.Sources)
+
+		sibling, err := resolver.ForDir(root)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"en"}, sibling.Config.Defaults.Languages)
+		assert.Equal(t, []string{"en"}, base.Defaults.Languages, "base config is not mutated")
+	})
+
+	t.Run("deeper overrides win", func(t *testing.T) {
+		t.Parallel()
+
+		root := t.TempDir()
+		child := filepath.Join(root, "anime")
+		require.NoError(t, os.MkdirAll(child, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, ".subs.yaml"), []byte("defaults:\n  languages: [pt-BR]\noutput:\n  naming: plain\n"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(child, ".subsrc"), []byte("defaults:\n  languages: [ja, en]\n"), 0644))
+
+		resolved, err := NewResolver(newBase()).ForDir(child)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ja", "en"}, resolved.Config.Defaults.Languages)
+		assert.Equal(t, NamingPlain, resolved.Config.Output.Naming)
+		assert.Len(t, resolved.Sources, 2)
+	})
+
+	t.Run("override of owner leaves base and siblings unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		root := t.TempDir()
+		a := filepath.Join(root, "a")
+		b := filepath.Join(root, "b")
+		require.NoError(t, os.MkdirAll(a, 0755))
+		require.NoError(t, os.MkdirAll(b, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(a, ".subsrc"), []byte("output:\n  permissions:\n    uid: 4242\n    gid: 4243\n"), 0644))
+
+		base := newBase()
+		uid, gid := 1000, 100
+		base.Output.Permissions.UID = &uid
+		base.Output.Permissions.GID = &gid
+		resolver := NewResolver(base)
+
+		resolved, err := resolver.ForDir(a)
+		require.NoError(t, err)
+		assert.Equal(t, 4242, *resolved.Config.Output.Permissions.UID)
+		assert.Equal(t, 4243, *resolved.Config.Output.Permissions.GID)
+
+		sibling, err := resolver.ForDir(b)
+		require.NoError(t, err)
+		assert.Equal(t, 1000, *sibling.Config.Output.Permissions.UID)
+		assert.Equal(t, 100, *sibling.Config.Output.Permissions.GID)
+		assert.Equal(t, 1000, *base.Output.Permissions.UID, "base config is not mutated")
+		assert.Equal(t, 100, *base.Output.Permissions.GID, "base config is not mutated")
+	})
+
+	t.Run("invalid override is reported", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".subsrc"), []byte("output:\n  naming: weird\n"), 0644))
+
+		_, err := NewResolver(newBase()).ForDir(dir)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid override file")
+	})
+}
+
+func TestConfig_Clone(t *testing.T) {
+	t.Parallel()
+
+	original := Default()
+	original.Defaults.Languages = []string{"en"}
+
+	clone := original.Clone()
+	clone.Defaults.Languages[0] = "fr"
+
+	assert.Equal(t, "en", original.Defaults.Languages[0])
+}
@@ -0,0 +1,238 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/carlosarraes/subs-cli/internal/language"
+	"github.com/carlosarraes/subs-cli/internal/score"
+)
+
+var yamlErrorLine = regexp.MustCompile(`line (\d+): (.*)`)
+
+type Problem struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.Line == 0 {
+		return p.Message
+	}
+	return fmt.Sprintf("%d:%d: %s", p.Line, p.Column, p.Message)
+}
+
+func CheckFile(path string) ([]Problem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file '%s': %w", path, err)
+	}
+	return Check(data), nil
+}
+
+func Check(data []byte) []Problem {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return yamlProblems(err)
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+
+	c := &checker{keys: make(map[string]*yaml.Node)}
+	c.check(doc.Content[0], reflect.TypeOf(Config{}), "")
+	if len(c.problems) == 0 {
+		cfg := Default()
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			c.problems = append(c.problems, yamlProblems(err)...)
+		} else if err := cfg.Validate(); err != nil {
+			c.problems = append(c.problems, c.locate(err.Error()))
+		}
+	}
+
+	sort.SliceStable(c.problems, func(i, j int) bool {
+		if c.problems[i].Line != c.problems[j].Line {
+			return c.problems[i].Line < c.problems[j].Line
+		}
+		return c.problems[i].Column < c.problems[j].Column
+	})
+	return c.problems
+}
+
+type checker struct {
+	problems []Problem
+	keys     map[string]*yaml.Node
+}
+
+func (c *checker) report(node *yaml.Node, format string, args ...any) {
+	c.problems = append(c.problems, Problem{Line: node.Line, Column: node.Column, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *checker) check(node *yaml.Node, t reflect.Type, path string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Tag == "!!null" {
+		return
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			c.report(node, "%s: expected a section of settings, got '%s'", path, node.Value)
+			return
+		}
+		fields := structFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			field, ok := fields[key.Value]
+			if !ok {
+				c.report(key, "unknown key '%s'%s%s", key.Value, inSection(path), suggest(key.Value, fields))
+				continue
+			}
+			c.keys[path+key.Value] = key
+			c.check(value, field.Type, path+key.Value+".")
+		}
+	case reflect.Slice:
+		name := strings.TrimSuffix(path, ".")
+		if node.Kind != yaml.SequenceNode {
+			c.report(node, "%s: expected a list like [a, b], got '%s'", name, node.Value)
+			return
+		}
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				c.report(item, "%s: expected a list of values", name)
+				continue
+			}
+			c.checkItem(item, name)
+		}
+	default:
+		name := strings.TrimSuffix(path, ".")
+		if node.Kind != yaml.ScalarNode {
+			c.report(node, "%s: expected a single value", name)
+			return
+		}
+		switch {
+		case t.Kind() == reflect.Bool && node.Tag != "!!bool":
+			c.report(node, "%s: expected true or false, got '%s'", name, node.Value)
+		case t.Kind() == reflect.Int && node.Tag != "!!int":
+			c.report(node, "%s: expected a whole number, got '%s'", name, node.Value)
+		case t.Kind() == reflect.Float64 && node.Tag != "!!int" && node.Tag != "!!float":
+			c.report(node, "%s: expected a number, got '%s'", name, node.Value)
+		}
+	}
+}
+
+func (c *checker) checkItem(item *yaml.Node, name string) {
+	switch name {
+	case "defaults.languages":
+		if _, err := language.Normalize(item.Value); err != nil {
+			c.report(item, "%s: %v", name, err)
+		}
+	case "providers":
+		if !slices.Contains(OptionalProviders, item.Value) {
+			c.report(item, "%s: unknown provider '%s', expected one of %s", name, item.Value, strings.Join(OptionalProviders, ", "))
+		}
+	case "scoring.prefer_sources", "scoring.avoid_sources":
+		if !slices.Contains(score.Sources, item.Value) {
+			c.report(item, "%s: unknown source '%s', expected one of %s", name, item.Value, strings.Join(score.Sources, ", "))
+		}
+	}
+}
+
+func (c *checker) locate(message string) Problem {
+	best := ""
+	for key := range c.keys {
+		if strings.HasPrefix(message, key) && len(key) > len(best) {
+			best = key
+		}
+	}
+	if best == "" {
+		return Problem{Message: message}
+	}
+	node := c.keys[best]
+	return Problem{Line: node.Line, Column: node.Column, Message: message}
+}
+
+func structFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if name != "" && name != "-" {
+			fields[name] = t.Field(i)
+		}
+	}
+	return fields
+}
+
+func inSection(path string) string {
+	if path == "" {
+		return ""
+	}
+	return " in " + strings.TrimSuffix(path, ".")
+}
+
+func suggest(key string, fields map[string]reflect.StructField) string {
+	best, bestDistance := "", 3
+	for name := range fields {
+		if d := editDistance(key, name); d < bestDistance || (d == bestDistance && name < best) {
+			best, bestDistance = name, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean '%s'?)", best)
+}
+
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+func yamlProblems(err error) []Problem {
+	messages := []string{err.Error()}
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		messages = typeErr.Errors
+	}
+
+	problems := make([]Problem, 0, len(messages))
+	for _, message := range messages {
+		if m := yamlErrorLine.FindStringSubmatch(message); m != nil {
+			line, _ := strconv.Atoi(m[1])
+			problems = append(problems, Problem{Line: line, Column: 1, Message: m[2]})
+			continue
+		}
+		problems = append(problems, Problem{Message: strings.TrimPrefix(message, "yaml: ")})
+	}
+	return problems
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	t.Run("accepts a valid file", func(t *testing.T) {
+		t.Parallel()
+
+		data := "defaults:\n  languages: [en, pt-br]\noutput:\n  naming: plain\nproviders: [napisy24]\ncache:\n  enabled: false\n"
+		assert.Empty(t, Check([]byte(data)))
+		assert.Empty(t, Check(nil))
+	})
+
+	t.Run("reports unknown keys and wrong types with positions", func(t *testing.T) {
+		t.Parallel()
+
+		data := "output:\n  namign: plain\ncache:\n  enabled: maybe\nnetwork:\n  max_idle_conns: many\ndefaults:\n  languages: english\n"
+		problems := Check([]byte(data))
+		assert.Equal(t, []Problem{
+			{Line: 2, Column: 3, Message: "unknown key 'namign' in output (did you mean 'naming'?)"},
+			{Line: 4, Column: 12, Message: "cache.enabled: expected true or false, got 'maybe'"},
+			{Line: 6, Column: 19, Message: "network.max_idle_conns: expected a whole number, got 'many'"},
+			{Line: 8, Column: 14, Message: "defaults.languages: expected a list like [a, b], got 'english'"},
+		}, problems)
+	})
+
+	t.Run("reports invalid list items at their position", func(t *testing.T) {
+		t.Parallel()
+
+		data := "defaults:\n  languages:\n    - en\n    - english!\nproviders: [napisy24, subscene]\n"
+		problems := Check([]byte(data))
+		require.Len(t, problems, 2)
+		assert.Equal(t, 4, problems[0].Line)
+		assert.Equal(t, 7, problems[0].Column)
+		assert.Contains(t, problems[0].Message, "defaults.languages:")
+		assert.Equal(t, Problem{Line: 5, Column: 23, Message: "providers: unknown provider 'subscene', expected one of napiprojekt, napisy24, bsplayer"}, problems[1])
+	})
+
+	t.Run("locates semantic errors at their key", func(t *testing.T) {
+		t.Parallel()
+
+		problems := Check([]byte("cache:\n  enabled: true\noutput:\n  naming: fancy\n"))
+		require.Len(t, problems, 1)
+		assert.Equal(t, 4, problems[0].Line)
+		assert.Equal(t, 3, problems[0].Column)
+		assert.Contains(t, problems[0].Message, "output.naming must be")
+	})
+
+	t.Run("reports syntax errors", func(t *testing.T) {
+		t.Parallel()
+
+		problems := Check([]byte("output:\n  naming: plain\n   quiet: true\n"))
+		require.Len(t, problems, 1)
+		assert.Equal(t, 3, problems[0].Line)
+		assert.Equal(t, "3:1: "+problems[0].Message, problems[0].String())
+	})
+}
+
+func TestCheckFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("colour: true\n"), 0600))
+
+	problems, err := CheckFile(path)
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	assert.Equal(t, "1:1: unknown key 'colour'", problems[0].String())
+
+	_, err = CheckFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read config file")
+}
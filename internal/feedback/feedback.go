@@ -0,0 +1,272 @@
+package feedback
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/internal/fsutil"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+const (
+	goodSubtitleScore = 1000
+
+	firstRetry = time.Hour
+	maxRetry   = 7 * 24 * time.Hour
+)
+
+type Download struct {
+	Provider   string    `json:"provider"`
+	SubtitleID string    `json:"subtitle_id"`
+	FileID     string    `json:"file_id"`
+	Language   string    `json:"language"`
+	Release    string    `json:"release"`
+	Uploader   string    `json:"uploader,omitempty"`
+	Target     string    `json:"target"`
+	Score      int       `json:"score,omitempty"`
+	SavedAt    time.Time `json:"saved_at"`
+}
+
+type Vote struct {
+	Download
+	Good    bool      `json:"good"`
+	VotedAt time.Time `json:"voted_at"`
+}
+
+type Want struct {
+	Media    string    `json:"media"`
+	Language string    `json:"language"`
+	Reason   string    `json:"reason"`
+	Attempts int       `json:"attempts"`
+	AddedAt  time.Time `json:"added_at"`
+	LastTry  time.Time `json:"last_try"`
+}
+
+func (w Want) NextTry() time.Time {
+	if w.Attempts == 0 {
+		return w.AddedAt
+	}
+	delay := firstRetry
+	for i := 1; i < w.Attempts && delay < maxRetry; i++ {
+		delay *= 2
+	}
+	return w.LastTry.Add(min(delay, maxRetry))
+}
+
+type Store struct {
+	Downloads map[string][]Download `json:"downloads"`
+	Votes     map[string]Vote       `json:"votes"`
+	Wanted    []Want                `json:"wanted,omitempty"`
+}
+
+func NewDownload(subtitle *models.Subtitle, target string) Download {
+	return Download{
+		Provider:   subtitle.Provider,
+		SubtitleID: subtitle.ID,
+		FileID:     subtitle.FileID,
+		Language:   subtitle.Language,
+		Release:    subtitle.ReleaseName,
+		Uploader:   subtitle.Uploader,
+		Target:     target,
+		SavedAt:    time.Now(),
+	}
+}
+
+func Load(path string) (*Store, error) {
+	store := &Store{Downloads: make(map[string][]Download), Votes: make(map[string]Vote)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feedback file: %w", err)
+	}
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("invalid feedback file %s: %w", path, err)
+	}
+	if store.Downloads == nil {
+		store.Downloads = make(map[string][]Download)
+	}
+	if store.Votes == nil {
+		store.Votes = make(map[string]Vote)
+	}
+	return store, nil
+}
+
+func (s *Store) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode feedback: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create feedback directory: %w", err)
+	}
+	if err := fsutil.WriteFile(path, data, fsutil.WriteOptions{Perm: 0600}); err != nil {
+		return fmt.Errorf("failed to write feedback file: %w", err)
+	}
+	return nil
+}
+
+func Update(path string, change func(*Store) error) error {
+	store, err := Load(path)
+	if err != nil {
+		return err
+	}
+	if err := change(store); err != nil {
+		return err
+	}
+	return store.Save(path)
+}
+
+func mediaKey(mediaPath string) string {
+	if abs, err := filepath.Abs(mediaPath); err == nil {
+		return abs
+	}
+	return mediaPath
+}
+
+func voteKey(provider, id string) string {
+	return provider + ":" + id
+}
+
+func (s *Store) Record(mediaPath string, download Download) {
+	key := mediaKey(mediaPath)
+	downloads := s.Downloads[key][:0:0]
+	for _, existing := range s.Downloads[key] {
+		if existing.Target != download.Target {
+			downloads = append(downloads, existing)
+		}
+	}
+	s.Downloads[key] = append(downloads, download)
+	s.Unwant(mediaPath, download.Language)
+}
+
+func (s *Store) DownloadsFor(mediaPath string) []Download {
+	return s.Downloads[mediaKey(mediaPath)]
+}
+
+func (s *Store) Saved(mediaPath, target string) (Download, bool) {
+	downloads := s.DownloadsFor(mediaPath)
+	for i := len(downloads) - 1; i >= 0; i-- {
+		if downloads[i].Target == target {
+			return downloads[i], true
+		}
+	}
+	return Download{}, false
+}
+
+func (s *Store) Rejected(download Download) bool {
+	vote, ok := s.Votes[voteKey(download.Provider, download.SubtitleID)]
+	return ok && !vote.Good
+}
+
+func (s *Store) Want(mediaPath, language, reason string) {
+	key, now := mediaKey(mediaPath), time.Now()
+	for i, want := range s.Wanted {
+		if want.Media == key && strings.EqualFold(want.Language, language) {
+			s.Wanted[i].Reason = reason
+			s.Wanted[i].Attempts++
+			s.Wanted[i].LastTry = now
+			return
+		}
+	}
+	s.Wanted = append(s.Wanted, Want{Media: key, Language: language, Reason: reason, Attempts: 1, AddedAt: now, LastTry: now})
+}
+
+func (s *Store) Request(mediaPath, language, reason string) bool {
+	key := mediaKey(mediaPath)
+	for _, want := range s.Wanted {
+		if want.Media == key && strings.EqualFold(want.Language, language) {
+			return false
+		}
+	}
+	s.Wanted = append(s.Wanted, Want{Media: key, Language: language, Reason: reason, AddedAt: time.Now()})
+	return true
+}
+
+func (s *Store) Due(now time.Time) []Want {
+	var due []Want
+	for _, want := range s.Wanted {
+		if !want.NextTry().After(now) {
+			due = append(due, want)
+		}
+	}
+	return due
+}
+
+func (s *Store) Unwant(mediaPath, language string) int {
+	key, before := mediaKey(mediaPath), len(s.Wanted)
+	s.Wanted = slices.DeleteFunc(s.Wanted, func(want Want) bool {
+		return want.Media == key && (language == "" || strings.EqualFold(want.Language, language))
+	})
+	return before - len(s.Wanted)
+}
+
+func (s *Store) WantedFor(mediaPath string) []Want {
+	key := mediaKey(mediaPath)
+	var wanted []Want
+	for _, want := range s.Wanted {
+		if want.Media == key {
+			wanted = append(wanted, want)
+		}
+	}
+	return wanted
+}
+
+func (s *Store) Vote(download Download, good bool) {
+	s.Votes[voteKey(download.Provider, download.SubtitleID)] = Vote{Download: download, Good: good, VotedAt: time.Now()}
+}
+
+func (s *Store) score(subtitle *models.Subtitle) (int, bool) {
+	if vote, ok := s.Votes[voteKey(subtitle.Provider, subtitle.ID)]; ok {
+		if !vote.Good {
+			return 0, false
+		}
+		return goodSubtitleScore, true
+	}
+
+	score := 0
+	if subtitle.Uploader == "" {
+		return score, true
+	}
+	for _, vote := range s.Votes {
+		if vote.Provider != subtitle.Provider || !strings.EqualFold(vote.Uploader, subtitle.Uploader) {
+			continue
+		}
+		if vote.Good {
+			score++
+		} else {
+			score--
+		}
+	}
+	return score, true
+}
+
+func (s *Store) Rank(subtitles []*models.Subtitle) ([]*models.Subtitle, int) {
+	if len(s.Votes) == 0 {
+		return subtitles, 0
+	}
+
+	ranked := make([]*models.Subtitle, 0, len(subtitles))
+	scores := make(map[*models.Subtitle]int, len(subtitles))
+	for _, subtitle := range subtitles {
+		score, keep := s.score(subtitle)
+		if !keep {
+			continue
+		}
+		scores[subtitle] = score
+		ranked = append(ranked, subtitle)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return scores[ranked[i]] > scores[ranked[j]]
+	})
+	return ranked, len(subtitles) - len(ranked)
+}
@@ -0,0 +1,158 @@
+package feedback
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "feedback.json")
+
+	store, err := Load(path)
+	require.NoError(t, err)
+	assert.Empty(t, store.Downloads)
+
+	subtitle := &models.Subtitle{ID: "1", Provider: "opensubtitles", FileID: "11", Language: "en", ReleaseName: "Movie.2020", Uploader: "alice"}
+	require.NoError(t, Update(path, func(s *Store) error {
+		s.Record("movie.mkv", NewDownload(subtitle, "movie.en.srt"))
+		s.Record("movie.mkv", NewDownload(&models.Subtitle{ID: "2", Provider: "opensubtitles", Language: "pt-BR"}, "movie.pt-BR.srt"))
+		s.Record("movie.mkv", NewDownload(&models.Subtitle{ID: "3", Provider: "opensubtitles", Language: "en"}, "movie.en.srt"))
+		return nil
+	}))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	store, err = Load(path)
+	require.NoError(t, err)
+	downloads := store.DownloadsFor("movie.mkv")
+	require.Len(t, downloads, 2)
+	assert.Equal(t, "2", downloads[0].SubtitleID)
+	assert.Equal(t, "3", downloads[1].SubtitleID)
+
+	abs, err := filepath.Abs("movie.mkv")
+	require.NoError(t, err)
+	assert.Len(t, store.DownloadsFor(abs), 2)
+	assert.Empty(t, store.DownloadsFor("other.mkv"))
+}
+
+func TestSaved(t *testing.T) {
+	t.Parallel()
+
+	store := &Store{Downloads: make(map[string][]Download), Votes: make(map[string]Vote)}
+	download := NewDownload(&models.Subtitle{ID: "1", Provider: "opensubtitles", Language: "en"}, "movie.en.srt")
+	download.Score = 120
+	store.Record("movie.mkv", download)
+
+	saved, ok := store.Saved("movie.mkv", "movie.en.srt")
+	require.True(t, ok)
+	assert.Equal(t, 120, saved.Score)
+	_, ok = store.Saved("movie.mkv", "movie.pt.srt")
+	assert.False(t, ok)
+
+	assert.False(t, store.Rejected(saved))
+	store.Vote(saved, false)
+	assert.True(t, store.Rejected(saved))
+}
+
+func TestWanted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feedback.json")
+
+	require.NoError(t, Update(path, func(s *Store) error {
+		s.Want("movie.mkv", "en", "best score 12 is below 50")
+		s.Want("movie.mkv", "pt-BR", "best score 3 is below 50")
+		s.Want("movie.mkv", "EN", "best score 20 is below 50")
+		s.Want("other.mkv", "en", "best score 0 is below 50")
+		return nil
+	}))
+
+	store, err := Load(path)
+	require.NoError(t, err)
+	wanted := store.WantedFor("movie.mkv")
+	require.Len(t, wanted, 2)
+	assert.Equal(t, "en", wanted[0].Language)
+	assert.Equal(t, "best score 20 is below 50", wanted[0].Reason)
+	assert.Equal(t, 2, wanted[0].Attempts)
+	assert.False(t, wanted[0].LastTry.Before(wanted[0].AddedAt))
+
+	store.Record("movie.mkv", NewDownload(&models.Subtitle{ID: "1", Language: "en"}, "movie.en.srt"))
+	wanted = store.WantedFor("movie.mkv")
+	require.Len(t, wanted, 1)
+	assert.Equal(t, "pt-BR", wanted[0].Language)
+
+	assert.Equal(t, 0, store.Unwant("movie.mkv", "es"))
+	assert.Equal(t, 1, store.Unwant("movie.mkv", ""))
+	assert.Empty(t, store.WantedFor("movie.mkv"))
+	assert.Len(t, store.WantedFor("other.mkv"), 1)
+}
+
+func TestWantedRetries(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, now, Want{AddedAt: now}.NextTry(), "files added by hand are due right away")
+	assert.Equal(t, now.Add(time.Hour), Want{Attempts: 1, LastTry: now}.NextTry())
+	assert.Equal(t, now.Add(4*time.Hour), Want{Attempts: 3, LastTry: now}.NextTry())
+	assert.Equal(t, now.Add(7*24*time.Hour), Want{Attempts: 30, LastTry: now}.NextTry())
+
+	store := &Store{Wanted: []Want{
+		{Media: "/a.mkv", Language: "en", Attempts: 1, LastTry: now.Add(-2 * time.Hour)},
+		{Media: "/b.mkv", Language: "en", Attempts: 2, LastTry: now.Add(-time.Hour)},
+	}}
+	due := store.Due(now)
+	require.Len(t, due, 1)
+	assert.Equal(t, "/a.mkv", due[0].Media)
+
+	assert.True(t, store.Request("c.mkv", "fr", "added by hand"))
+	assert.False(t, store.Request("c.mkv", "FR", "again"))
+	wanted := store.WantedFor("c.mkv")
+	require.Len(t, wanted, 1)
+	assert.Equal(t, "added by hand", wanted[0].Reason)
+	assert.Zero(t, wanted[0].Attempts)
+	assert.False(t, wanted[0].NextTry().After(time.Now()))
+}
+
+func TestLoadInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feedback.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0600))
+
+	_, err := Load(path)
+	assert.ErrorContains(t, err, "invalid feedback file")
+}
+
+func TestRank(t *testing.T) {
+	subtitle := func(id, uploader string) *models.Subtitle {
+		return &models.Subtitle{ID: id, Provider: "opensubtitles", Uploader: uploader}
+	}
+	ids := func(subtitles []*models.Subtitle) []string {
+		var out []string
+		for _, s := range subtitles {
+			out = append(out, s.ID)
+		}
+		return out
+	}
+
+	store := &Store{Downloads: map[string][]Download{}, Votes: map[string]Vote{}}
+	subtitles := []*models.Subtitle{subtitle("1", "bob"), subtitle("2", "alice"), subtitle("3", "carol"), subtitle("4", ""), subtitle("5", "alice")}
+
+	ranked, rejected := store.Rank(subtitles)
+	assert.Equal(t, []string{"1", "2", "3", "4", "5"}, ids(ranked))
+	assert.Zero(t, rejected)
+
+	store.Vote(NewDownload(subtitle("9", "alice"), ""), true)
+	store.Vote(NewDownload(subtitle("8", "bob"), ""), false)
+	store.Vote(NewDownload(subtitle("3", "carol"), ""), false)
+	store.Vote(NewDownload(subtitle("4", ""), ""), true)
+	store.Vote(NewDownload(&models.Subtitle{ID: "1", Provider: "other"}, ""), false)
+
+	ranked, rejected = store.Rank(subtitles)
+	assert.Equal(t, []string{"4", "2", "5", "1"}, ids(ranked))
+	assert.Equal(t, 1, rejected)
+}
@@ -0,0 +1,129 @@
+package feedback
+
+import (
+	"slices"
+	"sort"
+	"time"
+)
+
+const QuotaDays = 14
+
+type Count struct {
+	Name      string `json:"name"`
+	Downloads int    `json:"downloads"`
+}
+
+type Rating struct {
+	Provider string `json:"provider"`
+	Good     int    `json:"good"`
+	Bad      int    `json:"bad"`
+}
+
+func (r Rating) Rate() float64 {
+	if r.Good+r.Bad == 0 {
+		return 0
+	}
+	return float64(r.Good) / float64(r.Good+r.Bad)
+}
+
+type Stats struct {
+	Downloads int      `json:"downloads"`
+	Files     int      `json:"files"`
+	Wanted    int      `json:"wanted"`
+	FoundRate float64  `json:"found_rate"`
+	Providers []Count  `json:"providers"`
+	Languages []Count  `json:"languages"`
+	Months    []Count  `json:"months"`
+	Shows     []Count  `json:"shows"`
+	Quota     []Count  `json:"quota"`
+	Ratings   []Rating `json:"ratings"`
+}
+
+func (s *Store) Stats(now time.Time, show func(media string) string, quotaProviders []string, top int) Stats {
+	var stats Stats
+	providers := make(map[string]int)
+	languages := make(map[string]int)
+	months := make(map[string]int)
+	shows := make(map[string]int)
+
+	firstDay := now.AddDate(0, 0, -(QuotaDays - 1)).Format(time.DateOnly)
+	quota := make(map[string]int)
+
+	for media, downloads := range s.Downloads {
+		if len(downloads) == 0 {
+			continue
+		}
+		stats.Files++
+		stats.Downloads += len(downloads)
+		shows[show(media)] += len(downloads)
+
+		for _, download := range downloads {
+			providers[download.Provider]++
+			languages[download.Language]++
+			saved := download.SavedAt.In(now.Location())
+			months[saved.Format("2006-01")]++
+			if day := saved.Format(time.DateOnly); day >= firstDay && slices.Contains(quotaProviders, download.Provider) {
+				quota[day]++
+			}
+		}
+	}
+
+	wanted := make(map[string]bool)
+	for _, want := range s.Wanted {
+		if len(s.Downloads[want.Media]) == 0 {
+			wanted[want.Media] = true
+		}
+	}
+	stats.Wanted = len(wanted)
+	if stats.Files+stats.Wanted > 0 {
+		stats.FoundRate = float64(stats.Files) / float64(stats.Files+stats.Wanted)
+	}
+
+	stats.Providers = byDownloads(providers)
+	stats.Languages = byDownloads(languages)
+	stats.Shows = byDownloads(shows)
+	if top > 0 && len(stats.Shows) > top {
+		stats.Shows = stats.Shows[:top]
+	}
+
+	stats.Months = byName(months)
+	for i := QuotaDays - 1; i >= 0; i-- {
+		day := now.AddDate(0, 0, -i).Format(time.DateOnly)
+		stats.Quota = append(stats.Quota, Count{Name: day, Downloads: quota[day]})
+	}
+
+	ratings := make(map[string]*Rating)
+	for _, vote := range s.Votes {
+		rating, ok := ratings[vote.Provider]
+		if !ok {
+			rating = &Rating{Provider: vote.Provider}
+			ratings[vote.Provider] = rating
+		}
+		if vote.Good {
+			rating.Good++
+		} else {
+			rating.Bad++
+		}
+	}
+	for _, rating := range ratings {
+		stats.Ratings = append(stats.Ratings, *rating)
+	}
+	sort.Slice(stats.Ratings, func(i, j int) bool { return stats.Ratings[i].Provider < stats.Ratings[j].Provider })
+
+	return stats
+}
+
+func byDownloads(counts map[string]int) []Count {
+	result := byName(counts)
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Downloads > result[j].Downloads })
+	return result
+}
+
+func byName(counts map[string]int) []Count {
+	result := make([]Count, 0, len(counts))
+	for name, downloads := range counts {
+		result = append(result, Count{Name: name, Downloads: downloads})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
@@ -0,0 +1,69 @@
+package feedback
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 16, 20, 0, 0, 0, time.UTC)
+	store := &Store{
+		Downloads: map[string][]Download{
+			"/tv/Show.S01E01.mkv": {
+				{Provider: "opensubtitles", Language: "en", SavedAt: now},
+				{Provider: "podnapisi", Language: "pt-BR", SavedAt: now.AddDate(0, 0, -1)},
+			},
+			"/tv/Show.S01E02.mkv": {{Provider: "opensubtitles", Language: "en", SavedAt: now.AddDate(0, 0, -1)}},
+			"/movies/Movie.mkv":   {{Provider: "opensubtitles", Language: "en", SavedAt: now.AddDate(0, -2, 0)}},
+		},
+		Votes: map[string]Vote{
+			"opensubtitles:1": {Download: Download{Provider: "opensubtitles"}, Good: true},
+			"opensubtitles:2": {Download: Download{Provider: "opensubtitles"}, Good: true},
+			"opensubtitles:3": {Download: Download{Provider: "opensubtitles"}, Good: false},
+		},
+		Wanted: []Want{
+			{Media: "/tv/Show.S01E03.mkv", Language: "en"},
+			{Media: "/tv/Show.S01E03.mkv", Language: "pt-BR"},
+			{Media: "/tv/Show.S01E01.mkv", Language: "fr"},
+		},
+	}
+
+	show := func(media string) string {
+		return strings.SplitN(filepath.Base(media), ".", 2)[0]
+	}
+	stats := store.Stats(now, show, []string{"opensubtitles"}, 1)
+
+	assert.Equal(t, 4, stats.Downloads)
+	assert.Equal(t, 3, stats.Files)
+	assert.Equal(t, 1, stats.Wanted, "files with a subtitle in another language are not counted as wanted")
+	assert.InDelta(t, 0.75, stats.FoundRate, 0.001)
+	assert.Equal(t, []Count{{"opensubtitles", 3}, {"podnapisi", 1}}, stats.Providers)
+	assert.Equal(t, []Count{{"en", 3}, {"pt-BR", 1}}, stats.Languages)
+	assert.Equal(t, []Count{{"2026-08", 1}, {"2026-10", 3}}, stats.Months)
+	assert.Equal(t, []Count{{"Show", 3}}, stats.Shows)
+
+	require.Len(t, stats.Quota, QuotaDays)
+	assert.Equal(t, Count{"2026-10-03", 0}, stats.Quota[0])
+	assert.Equal(t, Count{"2026-10-15", 1}, stats.Quota[QuotaDays-2], "podnapisi downloads don't use the quota")
+	assert.Equal(t, Count{"2026-10-16", 1}, stats.Quota[QuotaDays-1])
+
+	require.Equal(t, []Rating{{Provider: "opensubtitles", Good: 2, Bad: 1}}, stats.Ratings)
+	assert.InDelta(t, 0.667, stats.Ratings[0].Rate(), 0.001)
+}
+
+func TestStats_Empty(t *testing.T) {
+	t.Parallel()
+
+	stats := (&Store{}).Stats(time.Now(), filepath.Base, nil, 10)
+	assert.Zero(t, stats.Files)
+	assert.Zero(t, stats.FoundRate)
+	assert.Len(t, stats.Quota, QuotaDays)
+	assert.Zero(t, Rating{}.Rate())
+}
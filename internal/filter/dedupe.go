@@ -0,0 +1,72 @@
+package filter
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/carlosarraes/subs-cli/internal/subformat"
+	"github.com/carlosarraes/subs-cli/internal/textnorm"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func Dedupe(subtitles []*models.Subtitle) []*models.Subtitle {
+	kept := make([]*models.Subtitle, 0, len(subtitles))
+	seen := make(map[string][]int)
+
+	for _, subtitle := range subtitles {
+		keys := dedupeKeys(subtitle)
+		duplicate := -1
+		for _, key := range keys {
+			for _, i := range seen[key] {
+				if kept[i].Provider != subtitle.Provider {
+					duplicate = i
+					break
+				}
+			}
+			if duplicate >= 0 {
+				break
+			}
+		}
+
+		if duplicate < 0 {
+			for _, key := range keys {
+				seen[key] = append(seen[key], len(kept))
+			}
+			kept = append(kept, subtitle)
+			continue
+		}
+
+		if subtitle.Downloads > kept[duplicate].Downloads {
+			kept[duplicate] = subtitle
+		}
+	}
+
+	return kept
+}
+
+func dedupeKeys(subtitle *models.Subtitle) []string {
+	var keys []string
+	if subtitle.MovieHash != "" {
+		keys = append(keys, "hash:"+strings.ToLower(subtitle.MovieHash)+":"+strings.ToLower(subtitle.Language))
+	}
+	if release := normalizeRelease(subtitle.ReleaseName); release != "" {
+		keys = append(keys, "release:"+release+":"+strings.ToLower(subtitle.Language))
+	}
+	return keys
+}
+
+func normalizeRelease(name string) string {
+	name = strings.ToLower(strings.TrimSpace(textnorm.Compose(name)))
+	if subformat.FromFileName(name) != "" {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+
+	var b strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
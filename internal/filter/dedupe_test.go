@@ -0,0 +1,55 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDedupe(t *testing.T) {
+	t.Parallel()
+
+	ids := func(subs []*models.Subtitle) []string {
+		var out []string
+		for _, sub := range subs {
+			out = append(out, sub.ID)
+		}
+		return out
+	}
+
+	t.Run("same provider keeps everything", func(t *testing.T) {
+		t.Parallel()
+
+		subs := []*models.Subtitle{
+			{ID: "1", Provider: "a", Language: "en", ReleaseName: "Movie.2010.1080p.BluRay"},
+			{ID: "2", Provider: "a", Language: "en", ReleaseName: "Movie.2010.1080p.BluRay"},
+		}
+		assert.Equal(t, []string{"1", "2"}, ids(Dedupe(subs)))
+	})
+
+	t.Run("near-identical release names across providers", func(t *testing.T) {
+		t.Parallel()
+
+		subs := []*models.Subtitle{
+			{ID: "a1", Provider: "a", Language: "en", ReleaseName: "Movie.2010.1080p.BluRay-GRP", Downloads: 10},
+			{ID: "a2", Provider: "a", Language: "en", ReleaseName: "Other.Release"},
+			{ID: "b1", Provider: "b", Language: "en", ReleaseName: "movie 2010 1080p bluray grp.srt", Downloads: 500},
+			{ID: "b2", Provider: "b", Language: "pt-BR", ReleaseName: "Movie.2010.1080p.BluRay-GRP"},
+		}
+		assert.Equal(t, []string{"b1", "a2", "b2"}, ids(Dedupe(subs)), "the more downloaded copy takes the first one's place")
+	})
+
+	t.Run("same file hash across providers", func(t *testing.T) {
+		t.Parallel()
+
+		subs := []*models.Subtitle{
+			{ID: "a1", Provider: "a", Language: "en", MovieHash: "ABCDEF", ReleaseName: "Movie"},
+			{ID: "b1", Provider: "b", Language: "en", MovieHash: "abcdef", ReleaseName: "Completely different name"},
+		}
+		assert.Equal(t, []string{"a1"}, ids(Dedupe(subs)))
+	})
+
+	assert.Empty(t, Dedupe(nil))
+	assert.Equal(t, "movie2010", normalizeRelease(" Movie (2010).SRT "))
+}
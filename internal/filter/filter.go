@@ -0,0 +1,113 @@
+package filter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+const (
+	OrderDownloads = "downloads"
+	OrderRating    = "rating"
+	OrderDate      = "date"
+)
+
+var Orders = []string{OrderDownloads, OrderRating, OrderDate}
+
+type Options struct {
+	MinRating    float64
+	MinDownloads int
+	Uploader     string
+	TrustedOnly  bool
+	OrderBy      string
+}
+
+func (o Options) Validate() error {
+	if o.MinRating < 0 || o.MinRating > 10 {
+		return fmt.Errorf("--min-rating must be between 0 and 10, got %g", o.MinRating)
+	}
+
+	if o.MinDownloads < 0 {
+		return fmt.Errorf("--min-downloads cannot be negative, got %d", o.MinDownloads)
+	}
+
+	switch o.OrderBy {
+	case "", OrderDownloads, OrderRating, OrderDate:
+	default:
+		return fmt.Errorf("--order-by must be one of %s, got '%s'", strings.Join(Orders, ", "), o.OrderBy)
+	}
+
+	return nil
+}
+
+func (o Options) IsZero() bool {
+	return o == Options{}
+}
+
+func (o Options) Describe() string {
+	var parts []string
+	if o.MinRating > 0 {
+		parts = append(parts, fmt.Sprintf("rating >= %.1f", o.MinRating))
+	}
+	if o.MinDownloads > 0 {
+		parts = append(parts, fmt.Sprintf("downloads >= %d", o.MinDownloads))
+	}
+	if o.Uploader != "" {
+		parts = append(parts, fmt.Sprintf("uploader '%s'", o.Uploader))
+	}
+	if o.TrustedOnly {
+		parts = append(parts, "trusted uploaders only")
+	}
+	if o.OrderBy != "" {
+		parts = append(parts, fmt.Sprintf("ordered by %s", o.OrderBy))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (o Options) Match(subtitle *models.Subtitle) bool {
+	if subtitle.Rating < o.MinRating {
+		return false
+	}
+	if subtitle.Downloads < o.MinDownloads {
+		return false
+	}
+	if o.Uploader != "" && !strings.EqualFold(subtitle.Uploader, o.Uploader) {
+		return false
+	}
+	if o.TrustedOnly && !subtitle.FromTrusted {
+		return false
+	}
+	return true
+}
+
+func Apply(subtitles []*models.Subtitle, opts Options) []*models.Subtitle {
+	matches := make([]*models.Subtitle, 0, len(subtitles))
+	for _, subtitle := range subtitles {
+		if opts.Match(subtitle) {
+			matches = append(matches, subtitle)
+		}
+	}
+
+	Sort(matches, opts.OrderBy)
+	return matches
+}
+
+func Sort(subtitles []*models.Subtitle, orderBy string) {
+	var less func(a, b *models.Subtitle) bool
+	switch orderBy {
+	case OrderDownloads:
+		less = func(a, b *models.Subtitle) bool { return a.Downloads > b.Downloads }
+	case OrderRating:
+		less = func(a, b *models.Subtitle) bool { return a.Rating > b.Rating }
+	case OrderDate:
+		less = func(a, b *models.Subtitle) bool { return a.UploadDate.After(b.UploadDate) }
+	default:
+		return
+	}
+
+	sort.SliceStable(subtitles, func(i, j int) bool {
+		return less(subtitles[i], subtitles[j])
+	})
+}
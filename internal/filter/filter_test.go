@@ -0,0 +1,81 @@
+package filter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func testSubtitles() []*models.Subtitle {
+	base := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []*models.Subtitle{
+		{ID: "a", Uploader: "alice", Rating: 8.0, Downloads: 100, UploadDate: base, FromTrusted: true},
+		{ID: "b", Uploader: "Bob", Rating: 9.5, Downloads: 50, UploadDate: base.AddDate(0, 2, 0)},
+		{ID: "c", Uploader: "carol", Rating: 0, Downloads: 5000, UploadDate: base.AddDate(0, 1, 0), FromTrusted: true},
+	}
+}
+
+func ids(subtitles []*models.Subtitle) []string {
+	out := []string{}
+	for _, subtitle := range subtitles {
+		out = append(out, subtitle.ID)
+	}
+	return out
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		opts     Options
+		expected []string
+	}{
+		{name: "no options keeps provider order", opts: Options{}, expected: []string{"a", "b", "c"}},
+		{name: "min rating", opts: Options{MinRating: 8.5}, expected: []string{"b"}},
+		{name: "min downloads", opts: Options{MinDownloads: 100}, expected: []string{"a", "c"}},
+		{name: "uploader is case-insensitive", opts: Options{Uploader: "bob"}, expected: []string{"b"}},
+		{name: "trusted only", opts: Options{TrustedOnly: true}, expected: []string{"a", "c"}},
+		{name: "order by downloads", opts: Options{OrderBy: OrderDownloads}, expected: []string{"c", "a", "b"}},
+		{name: "order by rating", opts: Options{OrderBy: OrderRating}, expected: []string{"b", "a", "c"}},
+		{name: "order by date", opts: Options{OrderBy: OrderDate}, expected: []string{"b", "c", "a"}},
+		{name: "combined", opts: Options{TrustedOnly: true, OrderBy: OrderDate}, expected: []string{"c", "a"}},
+		{name: "nothing matches", opts: Options{Uploader: "dave"}, expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, ids(Apply(testSubtitles(), tt.opts)))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, Options{}.Validate())
+	assert.NoError(t, Options{MinRating: 7, MinDownloads: 10, OrderBy: OrderRating}.Validate())
+
+	err := Options{MinRating: 11}.Validate()
+	assert.ErrorContains(t, err, "--min-rating")
+
+	err = Options{MinDownloads: -1}.Validate()
+	assert.ErrorContains(t, err, "--min-downloads")
+
+	err = Options{OrderBy: "size"}.Validate()
+	assert.ErrorContains(t, err, "--order-by must be one of downloads, rating, date")
+}
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, Options{}.IsZero())
+	assert.Empty(t, Options{}.Describe())
+
+	opts := Options{MinRating: 7, MinDownloads: 100, Uploader: "alice", TrustedOnly: true, OrderBy: OrderRating}
+	assert.False(t, opts.IsZero())
+	assert.Equal(t, "rating >= 7.0, downloads >= 100, uploader 'alice', trusted uploaders only, ordered by rating", opts.Describe())
+}
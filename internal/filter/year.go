@@ -0,0 +1,25 @@
+package filter
+
+import "github.com/carlosarraes/subs-cli/pkg/models"
+
+const YearTolerance = 1
+
+func YearMatches(want, got int) bool {
+	if want == 0 || got == 0 {
+		return true
+	}
+	return got >= want-YearTolerance && got <= want+YearTolerance
+}
+
+func Year(subtitles []*models.Subtitle, year int) []*models.Subtitle {
+	if year == 0 {
+		return subtitles
+	}
+	kept := make([]*models.Subtitle, 0, len(subtitles))
+	for _, subtitle := range subtitles {
+		if YearMatches(year, subtitle.FeatureYear) {
+			kept = append(kept, subtitle)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,38 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func TestYearMatches(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, YearMatches(2010, 2010))
+	assert.True(t, YearMatches(2010, 2009))
+	assert.True(t, YearMatches(2010, 2011))
+	assert.False(t, YearMatches(2010, 2012))
+	assert.True(t, YearMatches(0, 1984), "no year to compare with")
+	assert.True(t, YearMatches(2021, 0), "results without a year are kept")
+}
+
+func TestYear(t *testing.T) {
+	t.Parallel()
+
+	subtitles := []*models.Subtitle{
+		{ID: "1984", FeatureYear: 1984},
+		{ID: "2020", FeatureYear: 2020},
+		{ID: "2021", FeatureYear: 2021},
+		{ID: "unknown"},
+	}
+
+	var ids []string
+	for _, subtitle := range Year(subtitles, 2021) {
+		ids = append(ids, subtitle.ID)
+	}
+	assert.Equal(t, []string{"2020", "2021", "unknown"}, ids)
+	assert.Len(t, Year(subtitles, 0), 4)
+}
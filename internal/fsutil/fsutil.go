@@ -0,0 +1,158 @@
+package fsutil
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type WriteOptions struct {
+	Perm       os.FileMode
+	Backup     bool
+	Owner      *Owner
+	Retries    int
+	RetryDelay time.Duration
+	Warn       func(error)
+}
+
+type Owner struct {
+	UID      int
+	GID      int
+	Required bool
+}
+
+func MatchFile(path string, opts WriteOptions) WriteOptions {
+	info, err := os.Stat(path)
+	if err != nil {
+		return opts
+	}
+
+	opts.Perm = info.Mode().Perm() &^ 0111
+	if uid, gid, ok := fileOwner(info); ok {
+		opts.Owner = &Owner{UID: uid, GID: gid}
+	}
+	return opts
+}
+
+func BackupPath(path string) string {
+	return path + ".bak"
+}
+
+func Transient(err error) bool {
+	if err == nil || errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrPermission) || errors.Is(err, errInvalidName) {
+		return false
+	}
+	for _, permanent := range permanentErrors {
+		if errors.Is(err, permanent) {
+			return false
+		}
+	}
+	return true
+}
+
+func WriteFile(path string, data []byte, opts WriteOptions) error {
+	err := writeFile(path, data, opts)
+	for attempt := 0; attempt < opts.Retries && Transient(err); attempt++ {
+		time.Sleep(opts.RetryDelay)
+		err = writeFile(path, data, opts)
+	}
+	return err
+}
+
+func writeFile(path string, data []byte, opts WriteOptions) (err error) {
+	if err := CheckName(path); err != nil {
+		return fmt.Errorf("cannot write %s: %w", path, err)
+	}
+
+	path = LongPath(path)
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, tempPattern(filepath.Base(path)))
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err = tmp.Chmod(opts.Perm); err != nil {
+		return fmt.Errorf("failed to set file permissions: %w", err)
+	}
+	if opts.Owner != nil {
+		if chownErr := tmp.Chown(opts.Owner.UID, opts.Owner.GID); chownErr != nil {
+			if opts.Owner.Required {
+				return fmt.Errorf("failed to set file owner: %w", chownErr)
+			}
+			if opts.Warn != nil {
+				opts.Warn(fmt.Errorf("could not set the owner of %s: %w", path, chownErr))
+			}
+		}
+	}
+	if err = tmp.Sync(); err != nil {
+		return fmt.Errorf("failed to sync temporary file: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+
+	if opts.Backup {
+		if err = backup(path); err != nil {
+			return err
+		}
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+
+	syncDir(dir)
+	return nil
+}
+
+func backup(path string) error {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+
+	target := BackupPath(path)
+	if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to replace backup %s: %w", target, err)
+	}
+	if os.Link(path, target) == nil {
+		return nil
+	}
+
+	src, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	return WriteFile(target, data, WriteOptions{Perm: info.Mode().Perm()})
+}
+
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	d.Sync()
+	d.Close()
+}
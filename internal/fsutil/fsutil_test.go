@@ -0,0 +1,169 @@
+package fsutil
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("creates_file", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "movie.en.srt")
+		require.NoError(t, WriteFile(path, []byte("new"), WriteOptions{Perm: 0640}))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "new", string(data))
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+		assert.NoFileExists(t, BackupPath(path))
+	})
+
+	t.Run("replaces_without_leftovers", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		path := filepath.Join(dir, "movie.en.srt")
+		require.NoError(t, os.WriteFile(path, []byte("old"), 0644))
+		require.NoError(t, WriteFile(path, []byte("new"), WriteOptions{Perm: 0644}))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "new", string(data))
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1, "no temporary or backup files are left behind")
+	})
+
+	t.Run("backs_up_replaced_file", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "movie.en.srt")
+		require.NoError(t, os.WriteFile(path, []byte("old"), 0644))
+		require.NoError(t, os.WriteFile(BackupPath(path), []byte("older"), 0644))
+		require.NoError(t, WriteFile(path, []byte("new"), WriteOptions{Perm: 0644, Backup: true}))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "new", string(data))
+
+		backup, err := os.ReadFile(BackupPath(path))
+		require.NoError(t, err)
+		assert.Equal(t, "old", string(backup))
+	})
+
+	t.Run("missing_directory", func(t *testing.T) {
+		t.Parallel()
+
+		err := WriteFile(filepath.Join(t.TempDir(), "missing", "movie.srt"), []byte("x"), WriteOptions{Perm: 0644})
+		assert.ErrorContains(t, err, "failed to create temporary file")
+	})
+}
+
+func TestMatchFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	media := filepath.Join(dir, "movie.mkv")
+	require.NoError(t, os.WriteFile(media, []byte("video"), 0644))
+	require.NoError(t, os.Chmod(media, 0775))
+
+	opts := MatchFile(media, WriteOptions{Perm: 0600, Backup: true})
+	assert.Equal(t, os.FileMode(0664), opts.Perm, "execute bits are dropped")
+	assert.True(t, opts.Backup)
+
+	if uid, gid, ok := fileOwner(mustStat(t, media)); ok {
+		require.NotNil(t, opts.Owner)
+		assert.Equal(t, Owner{UID: uid, GID: gid}, *opts.Owner)
+
+		path := filepath.Join(dir, "movie.en.srt")
+		require.NoError(t, WriteFile(path, []byte("sub"), opts))
+		assert.Equal(t, os.FileMode(0664), mustStat(t, path).Mode().Perm())
+	}
+
+	missing := MatchFile(filepath.Join(dir, "missing.mkv"), WriteOptions{Perm: 0600})
+	assert.Equal(t, WriteOptions{Perm: 0600}, missing)
+}
+
+func mustStat(t *testing.T, path string) os.FileInfo {
+	t.Helper()
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	return info
+}
+
+func TestWriteFile_Owner(t *testing.T) {
+	t.Parallel()
+
+	if os.Geteuid() <= 0 {
+		t.Skip("needs an unprivileged unix user to make chown fail")
+	}
+
+	dir := t.TempDir()
+	var warnings []error
+	opts := WriteOptions{Perm: 0644, Owner: &Owner{UID: 0, GID: 0}, Warn: func(err error) { warnings = append(warnings, err) }}
+
+	path := filepath.Join(dir, "movie.en.srt")
+	require.NoError(t, WriteFile(path, []byte("sub"), opts), "owners copied from the video are best effort")
+	assert.FileExists(t, path)
+	require.Len(t, warnings, 1)
+	assert.ErrorIs(t, warnings[0], fs.ErrPermission)
+
+	opts.Owner.Required = true
+	err := WriteFile(filepath.Join(dir, "movie.pt.srt"), []byte("sub"), opts)
+	assert.ErrorContains(t, err, "failed to set file owner")
+	assert.NoFileExists(t, filepath.Join(dir, "movie.pt.srt"))
+}
+
+func TestTransient(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, Transient(nil))
+	assert.False(t, Transient(fs.ErrNotExist))
+	assert.False(t, Transient(fmt.Errorf("open: %w", fs.ErrPermission)))
+	assert.False(t, Transient(fmt.Errorf("cannot write: %w", errInvalidName)))
+	assert.True(t, Transient(errors.New("input/output error")))
+}
+
+func TestWriteFile_Retries(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "share")
+	path := filepath.Join(dir, "movie.en.srt")
+	require.NoError(t, os.WriteFile(dir, nil, 0644), "a file in place of the directory fails every attempt")
+
+	err := WriteFile(path, []byte("new"), WriteOptions{Perm: 0644, Retries: 2, RetryDelay: time.Millisecond})
+	require.Error(t, err)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		os.Remove(dir)
+		os.Mkdir(dir, 0755)
+	}()
+	require.NoError(t, WriteFile(path, []byte("new"), WriteOptions{Perm: 0644, Retries: 50, RetryDelay: 10 * time.Millisecond}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+}
+
+func TestIsNetworkPath(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, IsNetworkPath(filepath.Join(t.TempDir(), "missing")))
+}
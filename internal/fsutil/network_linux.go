@@ -0,0 +1,87 @@
+//go:build linux
+
+package fsutil
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+const fuseMagic = 0x65735546
+
+var networkFilesystems = map[uint32]bool{
+	0x6969:     true, // nfs
+	0x517b:     true, // smb
+	0xff534d42: true, // cifs
+	0xfe534d42: true, // smb2
+	0x564c:     true, // ncp
+	0x01021997: true, // 9p
+	0x013111a8: true, // ibrix
+	0x19830326: true, // fhgfs
+	0x0bd00bd0: true, // lustre
+	0x47504653: true, // gpfs
+	0x6b414653: true, // afs
+	0x00c36400: true, // ceph
+}
+
+var networkFuse = map[string]bool{
+	"fuse.sshfs": true, "fuse.rclone": true, "fuse.s3fs": true, "fuse.gcsfuse": true,
+	"fuse.glusterfs": true, "fuse.ceph-fuse": true, "fuse.smbnetfs": true, "fuse.curlftpfs": true,
+}
+
+func IsNetworkPath(path string) bool {
+	var fs syscall.Statfs_t
+	if err := syscall.Statfs(LongPath(path), &fs); err != nil {
+		return false
+	}
+	if uint32(fs.Type) != fuseMagic {
+		return networkFilesystems[uint32(fs.Type)]
+	}
+
+	resolved, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	if real, err := filepath.EvalSymlinks(resolved); err == nil {
+		resolved = real
+	}
+	mountinfo, err := os.ReadFile("/proc/self/mountinfo")
+	if err != nil {
+		return false
+	}
+	return networkFuse[mountType(mountinfo, resolved)]
+}
+
+func mountType(mountinfo []byte, path string) string {
+	var best, fstype string
+	for _, line := range bytes.Split(mountinfo, []byte("\n")) {
+		fields := strings.Fields(string(line))
+		separator := -1
+		for i, field := range fields {
+			if field == "-" {
+				separator = i
+				break
+			}
+		}
+		if len(fields) < 5 || separator < 0 || separator+1 >= len(fields) {
+			continue
+		}
+
+		mountPoint := strings.ReplaceAll(fields[4], `\040`, " ")
+		if !within(path, mountPoint) || len(mountPoint) < len(best) {
+			continue
+		}
+		best, fstype = mountPoint, fields[separator+1]
+	}
+	return fstype
+}
+
+func within(path, dir string) bool {
+	if dir == "/" || path == dir {
+		return true
+	}
+	return strings.HasPrefix(path, dir+"/")
+}
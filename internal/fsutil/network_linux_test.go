@@ -0,0 +1,28 @@
+//go:build linux
+
+package fsutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMountType(t *testing.T) {
+	t.Parallel()
+
+	mountinfo := []byte(`22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw
+40 22 0:35 / /mnt/nas rw,nosuid shared:20 - fuse.sshfs user@nas:/media rw,user_id=1000
+41 22 0:36 / /mnt/vault rw,nosuid shared:21 - fuse.gocryptfs /home/me/.vault rw
+42 22 0:37 / /mnt/My\040Movies rw shared:22 - cifs //nas/movies rw
+`)
+
+	assert.Equal(t, "ext4", mountType(mountinfo, "/home/me/Movies"))
+	assert.Equal(t, "fuse.sshfs", mountType(mountinfo, "/mnt/nas/Movies/Inception.mkv"))
+	assert.Equal(t, "fuse.sshfs", mountType(mountinfo, "/mnt/nas"))
+	assert.Equal(t, "ext4", mountType(mountinfo, "/mnt/nasty"))
+	assert.Equal(t, "cifs", mountType(mountinfo, "/mnt/My Movies/Heat.mkv"))
+
+	assert.True(t, networkFuse[mountType(mountinfo, "/mnt/nas/Movies")])
+	assert.False(t, networkFuse[mountType(mountinfo, "/mnt/vault/Movies")], "local FUSE filesystems are not shares")
+}
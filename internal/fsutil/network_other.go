@@ -0,0 +1,7 @@
+//go:build !linux && !darwin && !windows
+
+package fsutil
+
+func IsNetworkPath(path string) bool {
+	return false
+}
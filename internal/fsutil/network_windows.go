@@ -0,0 +1,22 @@
+//go:build windows
+
+package fsutil
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+func IsNetworkPath(path string) bool {
+	if strings.HasPrefix(path, longPathPrefix+`UNC\`) {
+		return true
+	}
+	if strings.HasPrefix(path, longPathPrefix) || strings.HasPrefix(path, devicePrefix) {
+		return false
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(abs, `\\`)
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package fsutil
+
+import "os"
+
+func fileOwner(info os.FileInfo) (uid, gid int, ok bool) {
+	return 0, 0, false
+}
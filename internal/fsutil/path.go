@@ -0,0 +1,32 @@
+package fsutil
+
+import (
+	"errors"
+	"strings"
+)
+
+var errInvalidName = errors.New("invalid file name")
+
+var reservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true, "CONIN$": true, "CONOUT$": true,
+	"COM0": true, "COM1": true, "COM2": true, "COM3": true, "COM4": true,
+	"COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT0": true, "LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true,
+	"LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+func IsReservedName(name string) bool {
+	stem := name
+	if i := strings.IndexByte(stem, '.'); i >= 0 {
+		stem = stem[:i]
+	}
+	return reservedNames[strings.ToUpper(strings.TrimRight(stem, " "))]
+}
+
+func tempPattern(name string) string {
+	const maxStem = 200
+	if len(name) > maxStem {
+		name = name[:maxStem]
+	}
+	return "." + name + ".*.tmp"
+}
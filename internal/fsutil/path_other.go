@@ -0,0 +1,11 @@
+//go:build !windows
+
+package fsutil
+
+func LongPath(path string) string {
+	return path
+}
+
+func CheckName(path string) error {
+	return nil
+}
@@ -0,0 +1,35 @@
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsReservedName(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"CON", "con", "Nul.srt", "aux.en.srt", "COM1", "lpt9.txt", "PRN ", "CONOUT$"} {
+		assert.True(t, IsReservedName(name), name)
+	}
+	for _, name := range []string{"Console.srt", "movie.con.srt", "COM10", "LPT", "nulls.mkv", ""} {
+		assert.False(t, IsReservedName(name), name)
+	}
+}
+
+func TestWriteFile_LongName(t *testing.T) {
+	t.Parallel()
+
+	name := strings.Repeat("a", 240) + ".en.srt"
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, WriteFile(path, []byte("data"), WriteOptions{Perm: 0644}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(data))
+	assert.Len(t, tempPattern(name), 207)
+}
@@ -0,0 +1,39 @@
+//go:build windows
+
+package fsutil
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	longPathPrefix = `\\?\`
+	devicePrefix   = `\\.\`
+)
+
+func LongPath(path string) string {
+	if strings.HasPrefix(path, longPathPrefix) || strings.HasPrefix(path, devicePrefix) {
+		return path
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	if strings.HasPrefix(abs, `\\`) {
+		return longPathPrefix + `UNC\` + abs[2:]
+	}
+	return longPathPrefix + abs
+}
+
+func CheckName(path string) error {
+	name := filepath.Base(path)
+	if IsReservedName(name) {
+		return fmt.Errorf("%w: '%s' is a reserved device name on Windows", errInvalidName, name)
+	}
+	if strings.HasSuffix(name, ".") || strings.HasSuffix(name, " ") {
+		return fmt.Errorf("%w: '%s' ends with a dot or a space, which Windows does not allow", errInvalidName, name)
+	}
+	return nil
+}
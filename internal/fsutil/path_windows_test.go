@@ -0,0 +1,54 @@
+//go:build windows
+
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLongPath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `\\?\C:\Movies\movie.mkv`, LongPath(`C:\Movies\movie.mkv`))
+	assert.Equal(t, `\\?\UNC\nas\media\movie.mkv`, LongPath(`\\nas\media\movie.mkv`))
+	assert.Equal(t, `\\?\C:\Movies\movie.mkv`, LongPath(`\\?\C:\Movies\movie.mkv`))
+	assert.Equal(t, `\\.\pipe\subs`, LongPath(`\\.\pipe\subs`))
+
+	abs, err := filepath.Abs("movie.mkv")
+	require.NoError(t, err)
+	assert.Equal(t, `\\?\`+abs, LongPath("movie.mkv"))
+}
+
+func TestCheckName(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, CheckName(`C:\Movies\movie.en.srt`))
+	assert.ErrorContains(t, CheckName(`C:\Movies\CON.en.srt`), "'CON.en.srt' is a reserved device name on Windows")
+	assert.ErrorContains(t, CheckName(`C:\Movies\movie.`), "ends with a dot or a space")
+}
+
+func TestWriteFile_Windows(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	deep := dir
+	for len(deep) < 300 {
+		deep = filepath.Join(deep, strings.Repeat("d", 50))
+	}
+	require.NoError(t, os.MkdirAll(LongPath(deep), 0755))
+
+	path := filepath.Join(deep, "movie.en.srt")
+	require.NoError(t, WriteFile(path, []byte("data"), WriteOptions{Perm: 0644}))
+	data, err := os.ReadFile(LongPath(path))
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(data))
+
+	err = WriteFile(filepath.Join(dir, "nul.en.srt"), []byte("data"), WriteOptions{Perm: 0644})
+	assert.ErrorContains(t, err, "reserved device name")
+}
@@ -0,0 +1,5 @@
+//go:build !unix && !windows
+
+package fsutil
+
+var permanentErrors []error
@@ -0,0 +1,7 @@
+//go:build unix
+
+package fsutil
+
+import "syscall"
+
+var permanentErrors = []error{syscall.ENOSPC, syscall.EDQUOT, syscall.EROFS}
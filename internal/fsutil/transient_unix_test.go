@@ -0,0 +1,19 @@
+//go:build unix
+
+package fsutil
+
+import (
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransient_Unix(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, Transient(&os.PathError{Op: "write", Path: "movie.srt", Err: syscall.ENOSPC}), "a full disk stays full")
+	assert.False(t, Transient(&os.PathError{Op: "open", Path: "movie.srt", Err: syscall.EROFS}))
+	assert.True(t, Transient(&os.PathError{Op: "write", Path: "movie.srt", Err: syscall.EIO}))
+}
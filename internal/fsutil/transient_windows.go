@@ -0,0 +1,13 @@
+//go:build windows
+
+package fsutil
+
+import "syscall"
+
+const (
+	errorWriteProtect   syscall.Errno = 19
+	errorHandleDiskFull syscall.Errno = 39
+	errorDiskFull       syscall.Errno = 112
+)
+
+var permanentErrors = []error{errorWriteProtect, errorHandleDiskFull, errorDiskFull}
@@ -0,0 +1,193 @@
+package language
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type Language struct {
+	Code    string
+	Name    string
+	Native  string
+	ISO6392 string
+	Aliases []string
+}
+
+var languages = []Language{
+	{Code: "af", Name: "Afrikaans", Native: "Afrikaans", ISO6392: "afr"},
+	{Code: "sq", Name: "Albanian", Native: "Shqip", ISO6392: "alb", Aliases: []string{"sqi"}},
+	{Code: "ar", Name: "Arabic", Native: "العربية", ISO6392: "ara"},
+	{Code: "hy", Name: "Armenian", Native: "Հայերեն", ISO6392: "arm", Aliases: []string{"hye"}},
+	{Code: "eu", Name: "Basque", Native: "Euskara", ISO6392: "baq", Aliases: []string{"eus"}},
+	{Code: "be", Name: "Belarusian", Native: "Беларуская", ISO6392: "bel"},
+	{Code: "bn", Name: "Bengali", Native: "বাংলা", ISO6392: "ben"},
+	{Code: "bs", Name: "Bosnian", Native: "Bosanski", ISO6392: "bos"},
+	{Code: "br", Name: "Breton", Native: "Brezhoneg", ISO6392: "bre"},
+	{Code: "bg", Name: "Bulgarian", Native: "Български", ISO6392: "bul"},
+	{Code: "my", Name: "Burmese", Native: "မြန်မာ", ISO6392: "bur", Aliases: []string{"mya"}},
+	{Code: "ca", Name: "Catalan", Native: "Català", ISO6392: "cat"},
+	{Code: "zh-CN", Name: "Chinese (Simplified)", Native: "简体中文", ISO6392: "chi", Aliases: []string{"zh", "zho", "zhs", "zh-hans"}},
+	{Code: "zh-TW", Name: "Chinese (Traditional)", Native: "繁體中文", ISO6392: "zht", Aliases: []string{"zh-hant", "zh-hk"}},
+	{Code: "hr", Name: "Croatian", Native: "Hrvatski", ISO6392: "hrv"},
+	{Code: "cs", Name: "Czech", Native: "Čeština", ISO6392: "cze", Aliases: []string{"ces"}},
+	{Code: "da", Name: "Danish", Native: "Dansk", ISO6392: "dan"},
+	{Code: "nl", Name: "Dutch", Native: "Nederlands", ISO6392: "dut", Aliases: []string{"nld"}},
+	{Code: "en", Name: "English", Native: "English", ISO6392: "eng", Aliases: []string{"en-us", "en-gb"}},
+	{Code: "eo", Name: "Esperanto", Native: "Esperanto", ISO6392: "epo"},
+	{Code: "et", Name: "Estonian", Native: "Eesti", ISO6392: "est"},
+	{Code: "fa", Name: "Persian", Native: "فارسی", ISO6392: "per", Aliases: []string{"fas"}},
+	{Code: "fi", Name: "Finnish", Native: "Suomi", ISO6392: "fin"},
+	{Code: "fr", Name: "French", Native: "Français", ISO6392: "fre", Aliases: []string{"fra", "fr-fr", "fr-ca"}},
+	{Code: "gl", Name: "Galician", Native: "Galego", ISO6392: "glg"},
+	{Code: "ka", Name: "Georgian", Native: "ქართული", ISO6392: "geo", Aliases: []string{"kat"}},
+	{Code: "de", Name: "German", Native: "Deutsch", ISO6392: "ger", Aliases: []string{"deu", "de-de", "de-at", "de-ch"}},
+	{Code: "el", Name: "Greek", Native: "Ελληνικά", ISO6392: "ell", Aliases: []string{"gre"}},
+	{Code: "he", Name: "Hebrew", Native: "עברית", ISO6392: "heb", Aliases: []string{"iw"}},
+	{Code: "hi", Name: "Hindi", Native: "हिन्दी", ISO6392: "hin"},
+	{Code: "hu", Name: "Hungarian", Native: "Magyar", ISO6392: "hun"},
+	{Code: "is", Name: "Icelandic", Native: "Íslenska", ISO6392: "ice", Aliases: []string{"isl"}},
+	{Code: "id", Name: "Indonesian", Native: "Bahasa Indonesia", ISO6392: "ind", Aliases: []string{"in"}},
+	{Code: "it", Name: "Italian", Native: "Italiano", ISO6392: "ita"},
+	{Code: "ja", Name: "Japanese", Native: "日本語", ISO6392: "jpn", Aliases: []string{"jp"}},
+	{Code: "kk", Name: "Kazakh", Native: "Қазақ", ISO6392: "kaz"},
+	{Code: "km", Name: "Khmer", Native: "ខ្មែរ", ISO6392: "khm"},
+	{Code: "ko", Name: "Korean", Native: "한국어", ISO6392: "kor"},
+	{Code: "lv", Name: "Latvian", Native: "Latviešu", ISO6392: "lav"},
+	{Code: "lt", Name: "Lithuanian", Native: "Lietuvių", ISO6392: "lit"},
+	{Code: "lb", Name: "Luxembourgish", Native: "Lëtzebuergesch", ISO6392: "ltz"},
+	{Code: "mk", Name: "Macedonian", Native: "Македонски", ISO6392: "mac", Aliases: []string{"mkd"}},
+	{Code: "ms", Name: "Malay", Native: "Bahasa Melayu", ISO6392: "may", Aliases: []string{"msa"}},
+	{Code: "ml", Name: "Malayalam", Native: "മലയാളം", ISO6392: "mal"},
+	{Code: "mn", Name: "Mongolian", Native: "Монгол", ISO6392: "mon"},
+	{Code: "no", Name: "Norwegian", Native: "Norsk", ISO6392: "nor", Aliases: []string{"nb", "nob", "nn", "nno"}},
+	{Code: "oc", Name: "Occitan", Native: "Occitan", ISO6392: "oci"},
+	{Code: "pl", Name: "Polish", Native: "Polski", ISO6392: "pol"},
+	{Code: "pt-PT", Name: "Portuguese", Native: "Português", ISO6392: "por", Aliases: []string{"pt"}},
+	{Code: "pt-BR", Name: "Portuguese (Brazil)", Native: "Português (Brasil)", ISO6392: "pob", Aliases: []string{"pb"}},
+	{Code: "ro", Name: "Romanian", Native: "Română", ISO6392: "rum", Aliases: []string{"ron"}},
+	{Code: "ru", Name: "Russian", Native: "Русский", ISO6392: "rus"},
+	{Code: "sr", Name: "Serbian", Native: "Српски", ISO6392: "scc", Aliases: []string{"srp"}},
+	{Code: "si", Name: "Sinhala", Native: "සිංහල", ISO6392: "sin"},
+	{Code: "sk", Name: "Slovak", Native: "Slovenčina", ISO6392: "slo", Aliases: []string{"slk"}},
+	{Code: "sl", Name: "Slovenian", Native: "Slovenščina", ISO6392: "slv"},
+	{Code: "es", Name: "Spanish", Native: "Español", ISO6392: "spa", Aliases: []string{"es-es"}},
+	{Code: "ea", Name: "Spanish (Latin America)", Native: "Español (Latinoamérica)", ISO6392: "spl", Aliases: []string{"es-419", "es-mx", "es-la"}},
+	{Code: "sw", Name: "Swahili", Native: "Kiswahili", ISO6392: "swa"},
+	{Code: "sv", Name: "Swedish", Native: "Svenska", ISO6392: "swe"},
+	{Code: "tl", Name: "Tagalog", Native: "Tagalog", ISO6392: "tgl"},
+	{Code: "ta", Name: "Tamil", Native: "தமிழ்", ISO6392: "tam"},
+	{Code: "te", Name: "Telugu", Native: "తెలుగు", ISO6392: "tel"},
+	{Code: "th", Name: "Thai", Native: "ไทย", ISO6392: "tha"},
+	{Code: "tr", Name: "Turkish", Native: "Türkçe", ISO6392: "tur"},
+	{Code: "uk", Name: "Ukrainian", Native: "Українська", ISO6392: "ukr"},
+	{Code: "ur", Name: "Urdu", Native: "اردو", ISO6392: "urd"},
+	{Code: "vi", Name: "Vietnamese", Native: "Tiếng Việt", ISO6392: "vie"},
+	{Code: "cy", Name: "Welsh", Native: "Cymraeg", ISO6392: "wel", Aliases: []string{"cym"}},
+}
+
+var index = buildIndex()
+
+func buildIndex() map[string]*Language {
+	idx := make(map[string]*Language, len(languages)*3)
+	for i := range languages {
+		lang := &languages[i]
+		keys := append([]string{lang.Code, lang.ISO6392, lang.Name}, lang.Aliases...)
+		for _, key := range keys {
+			if key == "" {
+				continue
+			}
+			idx[strings.ToLower(key)] = lang
+		}
+	}
+	return idx
+}
+
+func normalizeKey(input string) string {
+	key := strings.ToLower(strings.TrimSpace(input))
+	return strings.ReplaceAll(key, "_", "-")
+}
+
+func Lookup(input string) (Language, bool) {
+	lang, ok := index[normalizeKey(input)]
+	if !ok {
+		return Language{}, false
+	}
+	return *lang, true
+}
+
+func Normalize(input string) (string, error) {
+	lang, ok := Lookup(input)
+	if !ok {
+		return "", fmt.Errorf("unknown language code '%s'", strings.TrimSpace(input))
+	}
+	return lang.Code, nil
+}
+
+func DisplayName(code string) string {
+	lang, ok := Lookup(code)
+	if !ok {
+		return code
+	}
+	return lang.Name
+}
+
+func ProviderCode(provider, code string) string {
+	lang, ok := Lookup(code)
+	if !ok {
+		return code
+	}
+	return lang.Code
+}
+
+var LocaleVariables = []string{"LC_ALL", "LC_MESSAGES", "LANG"}
+
+func FromLocale(locale string) (string, bool) {
+	locale, _, _ = strings.Cut(locale, ".")
+	locale, _, _ = strings.Cut(locale, "@")
+	locale = strings.TrimSpace(locale)
+	if locale == "" || locale == "C" || locale == "POSIX" {
+		return "", false
+	}
+
+	if lang, ok := Lookup(locale); ok {
+		return lang.Code, true
+	}
+
+	base, _, _ := strings.Cut(normalizeKey(locale), "-")
+	if lang, ok := Lookup(base); ok {
+		return lang.Code, true
+	}
+
+	return "", false
+}
+
+func FromEnvironment(getenv func(string) string) (string, string, bool) {
+	for _, name := range LocaleVariables {
+		value := getenv(name)
+		if value == "" {
+			continue
+		}
+		code, ok := FromLocale(value)
+		return code, name, ok
+	}
+	return "", "", false
+}
+
+func All() []Language {
+	out := make([]Language, len(languages))
+	copy(out, languages)
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Code < out[j].Code
+	})
+	return out
+}
+
+func Codes() []string {
+	all := All()
+	codes := make([]string, 0, len(all))
+	for _, lang := range all {
+		codes = append(codes, lang.Code)
+	}
+	return codes
+}
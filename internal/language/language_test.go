@@ -0,0 +1,153 @@
+package language
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"en", "en"},
+		{"eng", "en"},
+		{"EN", "en"},
+		{"pob", "pt-BR"},
+		{"pt-br", "pt-BR"},
+		{"pt_BR", "pt-BR"},
+		{"pb", "pt-BR"},
+		{"pt", "pt-PT"},
+		{"por", "pt-PT"},
+		{"ger", "de"},
+		{"deu", "de"},
+		{"fre", "fr"},
+		{"zh", "zh-CN"},
+		{"zht", "zh-TW"},
+		{"es-419", "ea"},
+		{" spa ", "es"},
+		{"german", "de"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			code, err := Normalize(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, code)
+		})
+	}
+
+	t.Run("unknown code", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Normalize("xx")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown language code 'xx'")
+	})
+}
+
+func TestDisplayName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Portuguese (Brazil)", DisplayName("pob"))
+	assert.Equal(t, "German", DisplayName("de"))
+	assert.Equal(t, "xx", DisplayName("xx"))
+}
+
+func TestProviderCode(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "pt-BR", ProviderCode("opensubtitles", "pob"))
+	assert.Equal(t, "de", ProviderCode("opensubtitles", "ger"))
+	assert.Equal(t, "zz", ProviderCode("opensubtitles", "zz"))
+}
+
+func TestTableIntegrity(t *testing.T) {
+	t.Parallel()
+
+	seen := make(map[string]string)
+	for _, lang := range All() {
+		assert.NotEmpty(t, lang.Name, lang.Code)
+		assert.NotEmpty(t, lang.Native, lang.Code)
+		assert.Len(t, lang.ISO6392, 3, lang.Code)
+
+		keys := append([]string{lang.Code, lang.ISO6392}, lang.Aliases...)
+		for _, key := range keys {
+			key = normalizeKey(key)
+			if owner, ok := seen[key]; ok {
+				t.Errorf("key %q used by both %s and %s", key, owner, lang.Code)
+			}
+			seen[key] = lang.Code
+		}
+	}
+
+	codes := Codes()
+	assert.Contains(t, codes, "en")
+	assert.Contains(t, codes, "pt-BR")
+	assert.IsIncreasing(t, codes)
+}
+
+func TestFromLocale(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		locale   string
+		expected string
+		ok       bool
+	}{
+		{"pt_BR.UTF-8", "pt-BR", true},
+		{"en_US.UTF-8", "en", true},
+		{"de_DE@euro", "de", true},
+		{"fr_BE.UTF-8", "fr", true},
+		{"es_MX", "ea", true},
+		{"ja", "ja", true},
+		{"C", "", false},
+		{"POSIX", "", false},
+		{"C.UTF-8", "", false},
+		{"", "", false},
+		{"xx_YY", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			t.Parallel()
+
+			code, ok := FromLocale(tt.locale)
+
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, code)
+		})
+	}
+}
+
+func TestFromEnvironment(t *testing.T) {
+	t.Parallel()
+
+	env := func(values map[string]string) func(string) string {
+		return func(name string) string { return values[name] }
+	}
+
+	code, variable, ok := FromEnvironment(env(map[string]string{"LANG": "pt_BR.UTF-8"}))
+	assert.True(t, ok)
+	assert.Equal(t, "pt-BR", code)
+	assert.Equal(t, "LANG", variable)
+
+	code, variable, ok = FromEnvironment(env(map[string]string{"LC_ALL": "de_DE.UTF-8", "LANG": "pt_BR.UTF-8"}))
+	assert.True(t, ok)
+	assert.Equal(t, "de", code)
+	assert.Equal(t, "LC_ALL", variable)
+
+	_, variable, ok = FromEnvironment(env(map[string]string{"LC_ALL": "C", "LANG": "pt_BR.UTF-8"}))
+	assert.False(t, ok)
+	assert.Equal(t, "LC_ALL", variable)
+
+	_, _, ok = FromEnvironment(env(nil))
+	assert.False(t, ok)
+}
@@ -0,0 +1,53 @@
+//go:build !unix
+
+package lock
+
+import "os"
+
+func tryLock(path string) (*Lock, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+	if os.IsExist(err) {
+		if !reclaim(path) {
+			return nil, ErrLocked
+		}
+		file, err = os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+		if os.IsExist(err) {
+			return nil, ErrLocked
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writePID(file); err != nil {
+		file.Close()
+		os.Remove(path)
+		return nil, err
+	}
+	return &Lock{path: path, file: file}, nil
+}
+
+func reclaim(path string) bool {
+	pid := holder(path)
+	if pid <= 0 || running(pid) {
+		return false
+	}
+	return os.Remove(path) == nil
+}
+
+func running(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	process.Release()
+	return true
+}
+
+func unlock(l *Lock) error {
+	err := l.file.Close()
+	if removeErr := os.Remove(l.path); err == nil {
+		err = removeErr
+	}
+	return err
+}
@@ -0,0 +1,81 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquire(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "state", "lock")
+	first, err := Acquire(context.Background(), path, 0)
+	require.NoError(t, err)
+
+	_, err = Acquire(context.Background(), path, 50*time.Millisecond)
+	var held *HeldError
+	require.ErrorAs(t, err, &held)
+	assert.True(t, errors.Is(err, ErrLocked))
+	assert.Equal(t, os.Getpid(), held.PID)
+	assert.Contains(t, err.Error(), "held by another subs-cli run (pid")
+
+	require.NoError(t, first.Release())
+	require.NoError(t, first.Release())
+
+	second, err := Acquire(context.Background(), path, 0)
+	require.NoError(t, err)
+	require.NoError(t, second.Release())
+}
+
+func TestAcquireWaits(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "lock")
+	first, err := Acquire(context.Background(), path, 0)
+	require.NoError(t, err)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		first.Release()
+	}()
+
+	second, err := Acquire(context.Background(), path, 5*time.Second)
+	require.NoError(t, err)
+	require.NoError(t, second.Release())
+}
+
+func TestAcquireCanceled(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "lock")
+	first, err := Acquire(context.Background(), path, 0)
+	require.NoError(t, err)
+	defer first.Release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err = Acquire(ctx, path, time.Minute)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), 5*time.Second, "an interrupt ends the wait")
+}
+
+func TestAcquireStale(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "lock")
+	require.NoError(t, os.WriteFile(path, []byte("999999999\n"), 0600))
+
+	l, err := Acquire(context.Background(), path, 0)
+	require.NoError(t, err, "a lock left by a process that is gone is reclaimed")
+	assert.Equal(t, os.Getpid(), holder(path))
+	require.NoError(t, l.Release())
+}
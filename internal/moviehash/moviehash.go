@@ -0,0 +1,71 @@
+package moviehash
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	chunkSize     = 64 * 1024
+	napiChunkSize = 10 * 1024 * 1024
+)
+
+func Compute(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", 0, err
+	}
+	hash, err := ComputeReaderAt(f, info.Size(), path)
+	return hash, info.Size(), err
+}
+
+func ComputeReaderAt(r io.ReaderAt, size int64, name string) (string, error) {
+	if size < chunkSize {
+		return "", fmt.Errorf("%s is too small to hash (%d bytes, need at least %d)", name, size, chunkSize)
+	}
+
+	chunks := make([][]byte, 0, 2)
+	for _, offset := range []int64{0, size - chunkSize} {
+		chunk := make([]byte, chunkSize)
+		if _, err := r.ReadAt(chunk, offset); err != nil && err != io.EOF {
+			return "", fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		chunks = append(chunks, chunk)
+	}
+
+	return checksum(size, chunks...), nil
+}
+
+func checksum(size int64, chunks ...[]byte) string {
+	hash := uint64(size)
+	for _, chunk := range chunks {
+		for i := 0; i+8 <= len(chunk); i += 8 {
+			hash += binary.LittleEndian.Uint64(chunk[i:])
+		}
+	}
+	return fmt.Sprintf("%016x", hash)
+}
+
+func Napiprojekt(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	sum := md5.New()
+	if _, err := io.CopyN(sum, f, napiChunkSize); err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return hex.EncodeToString(sum.Sum(nil)), nil
+}
@@ -0,0 +1,97 @@
+package moviehash
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompute(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("zeros", func(t *testing.T) {
+		path := filepath.Join(dir, "zeros.mkv")
+		require.NoError(t, os.WriteFile(path, make([]byte, 200000), 0644))
+
+		hash, size, err := Compute(path)
+		require.NoError(t, err)
+		assert.Equal(t, int64(200000), size)
+		assert.Equal(t, "0000000000030d40", hash)
+	})
+
+	t.Run("head_and_tail", func(t *testing.T) {
+		data := make([]byte, 3*chunkSize)
+		binary.LittleEndian.PutUint64(data[0:], 1)
+		binary.LittleEndian.PutUint64(data[chunkSize:], 1000)
+		binary.LittleEndian.PutUint64(data[len(data)-8:], 2)
+		path := filepath.Join(dir, "marked.mkv")
+		require.NoError(t, os.WriteFile(path, data, 0644))
+
+		hash, _, err := Compute(path)
+		require.NoError(t, err)
+		assert.Equal(t, "0000000000030003", hash)
+	})
+
+	t.Run("overlapping_chunks", func(t *testing.T) {
+		data := make([]byte, chunkSize+8)
+		binary.LittleEndian.PutUint64(data[8:], 5)
+		path := filepath.Join(dir, "short.mkv")
+		require.NoError(t, os.WriteFile(path, data, 0644))
+
+		hash, _, err := Compute(path)
+		require.NoError(t, err)
+		assert.Equal(t, "0000000000010012", hash)
+	})
+
+	t.Run("too_small", func(t *testing.T) {
+		path := filepath.Join(dir, "tiny.mkv")
+		require.NoError(t, os.WriteFile(path, []byte("tiny"), 0644))
+
+		_, _, err := Compute(path)
+		assert.ErrorContains(t, err, "too small to hash")
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, _, err := Compute(filepath.Join(dir, "missing.mkv"))
+		assert.Error(t, err)
+	})
+}
+
+func TestComputeReaderAt(t *testing.T) {
+	data := make([]byte, 3*chunkSize)
+	binary.LittleEndian.PutUint64(data[0:], 1)
+	binary.LittleEndian.PutUint64(data[len(data)-8:], 2)
+
+	hash, err := ComputeReaderAt(bytes.NewReader(data), int64(len(data)), "inner.mkv")
+	require.NoError(t, err)
+	assert.Equal(t, "0000000000030003", hash)
+
+	_, err = ComputeReaderAt(bytes.NewReader(data[:10]), 10, "inner.mkv")
+	assert.ErrorContains(t, err, "inner.mkv is too small to hash")
+}
+
+func TestNapiprojekt(t *testing.T) {
+	dir := t.TempDir()
+
+	small := filepath.Join(dir, "small.mkv")
+	require.NoError(t, os.WriteFile(small, []byte("abc"), 0644))
+	hash, err := Napiprojekt(small)
+	require.NoError(t, err)
+	assert.Equal(t, "900150983cd24fb0d6963f7d28e17f72", hash)
+
+	data := make([]byte, napiChunkSize+100)
+	data[len(data)-1] = 1
+	large := filepath.Join(dir, "large.mkv")
+	require.NoError(t, os.WriteFile(large, data, 0644))
+	hash, err = Napiprojekt(large)
+	require.NoError(t, err)
+	assert.Equal(t, "f1c9645dbc14efddc7d8a322685f26eb", hash, "only the first 10MiB is hashed")
+
+	_, err = Napiprojekt(filepath.Join(dir, "missing.mkv"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,70 @@
+package moviehash
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func ComputeURL(ctx context.Context, client *http.Client, url string) (string, int64, error) {
+	head, size, err := fetchRange(ctx, client, url, 0, chunkSize-1)
+	if err != nil {
+		return "", 0, err
+	}
+	if size < chunkSize {
+		return "", size, fmt.Errorf("%s is too small to hash (%d bytes, need at least %d)", url, size, chunkSize)
+	}
+
+	tail, _, err := fetchRange(ctx, client, url, size-chunkSize, size-1)
+	if err != nil {
+		return "", size, err
+	}
+	return checksum(size, head, tail), size, nil
+}
+
+func fetchRange(ctx context.Context, client *http.Client, url string, first, last int64) ([]byte, int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid URL %s: %w", url, err)
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", first, last))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusPartialContent {
+		if resp.StatusCode == http.StatusOK {
+			return nil, 0, fmt.Errorf("%s: the server does not support range requests", url)
+		}
+		return nil, 0, fmt.Errorf("failed to read %s: %s", url, resp.Status)
+	}
+
+	size, err := rangeTotal(resp.Header.Get("Content-Range"))
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", url, err)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, last-first+1))
+	if err != nil {
+		return nil, size, fmt.Errorf("failed to read %s: %w", url, err)
+	}
+	return data, size, nil
+}
+
+func rangeTotal(contentRange string) (int64, error) {
+	_, total, ok := strings.Cut(contentRange, "/")
+	if !ok || !strings.HasPrefix(contentRange, "bytes ") || total == "*" {
+		return 0, fmt.Errorf("unusable Content-Range %q", contentRange)
+	}
+	size, err := strconv.ParseInt(total, 10, 64)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("unusable Content-Range %q", contentRange)
+	}
+	return size, nil
+}
@@ -0,0 +1,80 @@
+package moviehash
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeURL(t *testing.T) {
+	t.Parallel()
+
+	data := make([]byte, 3*chunkSize+100)
+	binary.LittleEndian.PutUint64(data[0:], 7)
+	binary.LittleEndian.PutUint64(data[len(data)-16:], 9)
+	path := filepath.Join(t.TempDir(), "movie.mkv")
+	require.NoError(t, os.WriteFile(path, data, 0644))
+	want, _, err := Compute(path)
+	require.NoError(t, err)
+
+	var requested atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/movie.mkv":
+			cw := &countingWriter{ResponseWriter: w}
+			http.ServeContent(cw, r, "movie.mkv", time.Time{}, bytes.NewReader(data))
+			requested.Add(cw.n)
+		case "/tiny.mkv":
+			http.ServeContent(w, r, "tiny.mkv", time.Time{}, bytes.NewReader([]byte("tiny")))
+		default:
+			w.Write(data)
+		}
+	}))
+	defer server.Close()
+
+	hash, size, err := ComputeURL(context.Background(), server.Client(), server.URL+"/movie.mkv")
+	require.NoError(t, err)
+	assert.Equal(t, want, hash)
+	assert.Equal(t, int64(len(data)), size)
+	assert.Equal(t, int64(2*chunkSize), requested.Load(), "only the first and last 64 KiB are downloaded")
+
+	_, _, err = ComputeURL(context.Background(), server.Client(), server.URL+"/tiny.mkv")
+	assert.ErrorContains(t, err, "too small to hash")
+
+	_, _, err = ComputeURL(context.Background(), server.Client(), server.URL+"/norange.mkv")
+	assert.ErrorContains(t, err, "does not support range requests")
+}
+
+func TestRangeTotal(t *testing.T) {
+	t.Parallel()
+
+	size, err := rangeTotal("bytes 0-65535/1048576")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1048576), size)
+
+	for _, header := range []string{"", "bytes 0-65535/*", "items 0-1/2", "bytes 0-1/x"} {
+		_, err := rangeTotal(header)
+		assert.Error(t, err, header)
+	}
+}
+
+type countingWriter struct {
+	http.ResponseWriter
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	w.n += int64(n)
+	return n, err
+}
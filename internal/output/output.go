@@ -0,0 +1,145 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+type Icon int
+
+const (
+	IconCheck Icon = iota
+	IconSuccess
+	IconFailure
+	IconWarning
+	IconInfo
+	IconSearch
+	IconList
+	IconTip
+	IconSaved
+	IconDownload
+)
+
+var icons = map[Icon][2]string{
+	IconCheck:    {"✓", "[ok]"},
+	IconSuccess:  {"✅", "[ok]"},
+	IconFailure:  {"❌", "[x]"},
+	IconWarning:  {"⚠", "[!]"},
+	IconInfo:     {"ℹ", "[i]"},
+	IconSearch:   {"🔍", "[?]"},
+	IconList:     {"📺", "[*]"},
+	IconTip:      {"💡", "[i]"},
+	IconSaved:    {"💾", "[+]"},
+	IconDownload: {"⬇", "[v]"},
+}
+
+const (
+	ansiReset  = "\033[0m"
+	ansiBold   = "\033[1m"
+	ansiRed    = "\033[31m"
+	ansiGreen  = "\033[32m"
+	ansiYellow = "\033[33m"
+	ansiCyan   = "\033[36m"
+)
+
+type Options struct {
+	NoColor bool
+	NoEmoji bool
+}
+
+type Renderer struct {
+	w     io.Writer
+	color bool
+	emoji bool
+}
+
+func New(w io.Writer, opts Options) *Renderer {
+	return &Renderer{
+		w:     w,
+		color: !opts.NoColor,
+		emoji: !opts.NoEmoji,
+	}
+}
+
+func NewStdout(noEmoji bool) *Renderer {
+	return New(os.Stdout, Options{
+		NoColor: !ColorEnabled(os.Stdout),
+		NoEmoji: noEmoji,
+	})
+}
+
+func NewStderr(noEmoji bool) *Renderer {
+	return New(os.Stderr, Options{
+		NoColor: !ColorEnabled(os.Stderr),
+		NoEmoji: noEmoji,
+	})
+}
+
+func ColorEnabled(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return IsTerminal(f)
+}
+
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+func (r *Renderer) Writer() io.Writer {
+	return r.w
+}
+
+func (r *Renderer) Printf(format string, a ...any) {
+	fmt.Fprintf(r.w, format, a...)
+}
+
+func (r *Renderer) Println(a ...any) {
+	fmt.Fprintln(r.w, a...)
+}
+
+func (r *Renderer) Icon(i Icon) string {
+	pair, ok := icons[i]
+	if !ok {
+		return ""
+	}
+	if r.emoji {
+		return pair[0]
+	}
+	return pair[1]
+}
+
+func (r *Renderer) Success(s string) string {
+	return r.paint(ansiGreen, s)
+}
+
+func (r *Renderer) Warning(s string) string {
+	return r.paint(ansiYellow, s)
+}
+
+func (r *Renderer) Error(s string) string {
+	return r.paint(ansiRed, s)
+}
+
+func (r *Renderer) Info(s string) string {
+	return r.paint(ansiCyan, s)
+}
+
+func (r *Renderer) Bold(s string) string {
+	return r.paint(ansiBold, s)
+}
+
+func (r *Renderer) paint(code, s string) string {
+	if !r.color || s == "" {
+		return s
+	}
+	return code + s + ansiReset
+}
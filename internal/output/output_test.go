@@ -0,0 +1,94 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderer_Icon(t *testing.T) {
+	t.Parallel()
+
+	t.Run("emoji enabled", func(t *testing.T) {
+		t.Parallel()
+
+		r := New(&bytes.Buffer{}, Options{})
+		assert.Equal(t, "✅", r.Icon(IconSuccess))
+		assert.Equal(t, "📺", r.Icon(IconList))
+	})
+
+	t.Run("ascii fallback", func(t *testing.T) {
+		t.Parallel()
+
+		r := New(&bytes.Buffer{}, Options{NoEmoji: true})
+		assert.Equal(t, "[ok]", r.Icon(IconSuccess))
+		assert.Equal(t, "[x]", r.Icon(IconFailure))
+		assert.Equal(t, "[!]", r.Icon(IconWarning))
+	})
+
+	t.Run("every icon has an ascii form", func(t *testing.T) {
+		t.Parallel()
+
+		r := New(&bytes.Buffer{}, Options{NoEmoji: true})
+		for icon := range icons {
+			for _, ch := range r.Icon(icon) {
+				assert.Less(t, ch, rune(128))
+			}
+		}
+	})
+}
+
+func TestRenderer_Colors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("colors wrap text", func(t *testing.T) {
+		t.Parallel()
+
+		r := New(&bytes.Buffer{}, Options{})
+		assert.Equal(t, "\033[32mdone\033[0m", r.Success("done"))
+		assert.Equal(t, "\033[33mcareful\033[0m", r.Warning("careful"))
+		assert.Equal(t, "\033[31mfailed\033[0m", r.Error("failed"))
+	})
+
+	t.Run("no color returns plain text", func(t *testing.T) {
+		t.Parallel()
+
+		r := New(&bytes.Buffer{}, Options{NoColor: true})
+		assert.Equal(t, "done", r.Success("done"))
+		assert.Equal(t, "failed", r.Error("failed"))
+		assert.Equal(t, "title", r.Bold("title"))
+	})
+
+	t.Run("empty text is not painted", func(t *testing.T) {
+		t.Parallel()
+
+		r := New(&bytes.Buffer{}, Options{})
+		assert.Equal(t, "", r.Info(""))
+	})
+}
+
+func TestRenderer_Printf(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	r := New(&buf, Options{NoColor: true, NoEmoji: true})
+	r.Printf("%s %s\n", r.Icon(IconSaved), r.Success("Saved"))
+
+	assert.Equal(t, "[+] Saved\n", buf.String())
+}
+
+func TestColorEnabled(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "out.log"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	t.Setenv("NO_COLOR", "")
+	assert.False(t, ColorEnabled(f), "regular files are not terminals")
+
+	t.Setenv("NO_COLOR", "1")
+	assert.False(t, ColorEnabled(os.Stdout))
+}
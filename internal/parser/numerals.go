@@ -0,0 +1,108 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const seasonNumeral = `\d{1,2}|[ivxl]+|one|two|three|four|five|six|seven|eight|nine|ten|eleven|twelve|thirteen|fourteen|fifteen|sixteen|seventeen|eighteen|nineteen|twenty`
+
+var (
+	wordedEpisode = regexp.MustCompile(`(?i)(^|\.)(?:season|series|part)\.?(` + seasonNumeral + `)\.?(?:episode|ep|e)\.?(\d{1,3})(\.|$)`)
+	wordedSeason  = regexp.MustCompile(`(?i)(^|\.)(?:season|series|part)\.?(` + seasonNumeral + `)(\.|$)`)
+	wordedPart    = regexp.MustCompile(`(?i)(^|\.)part\.?(` + seasonNumeral + `)(\.|$)`)
+
+	numberWords = []string{
+		"one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten",
+		"eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen", "twenty",
+	}
+)
+
+func normalizeEpisodeSeason(name string) string {
+	return wordedEpisode.ReplaceAllStringFunc(name, func(match string) string {
+		m := wordedEpisode.FindStringSubmatch(match)
+		season := parseSeasonNumeral(m[2])
+		if season == 0 {
+			return match
+		}
+		episode, _ := strconv.Atoi(m[3])
+		return fmt.Sprintf("%sS%02dE%02d%s", m[1], season, episode, m[4])
+	})
+}
+
+func normalizeSeason(name string) string {
+	return wordedSeason.ReplaceAllStringFunc(name, func(match string) string {
+		m := wordedSeason.FindStringSubmatch(match)
+		season := parseSeasonNumeral(m[2])
+		if season == 0 {
+			return match
+		}
+		return fmt.Sprintf("%sS%02d%s", m[1], season, m[3])
+	})
+}
+
+// normalizePart reads a lone "Part <numeral>" as an episode of a single-season
+// miniseries. It is only tried once nothing else matched, so movies such as
+// "Dune.Part.Two.2024" keep their title.
+func normalizePart(name string) string {
+	return wordedPart.ReplaceAllStringFunc(name, func(match string) string {
+		m := wordedPart.FindStringSubmatch(match)
+		part := parseSeasonNumeral(m[2])
+		if part == 0 {
+			return match
+		}
+		return fmt.Sprintf("%sS01E%02d%s", m[1], part, m[3])
+	})
+}
+
+func parseSeasonNumeral(s string) int {
+	lower := strings.ToLower(s)
+	if n, err := strconv.Atoi(lower); err == nil {
+		return n
+	}
+	for i, word := range numberWords {
+		if lower == word {
+			return i + 1
+		}
+	}
+	return parseRoman(lower)
+}
+
+func parseRoman(s string) int {
+	values := map[byte]int{'i': 1, 'v': 5, 'x': 10, 'l': 50}
+	total := 0
+	for i := 0; i < len(s); i++ {
+		value, ok := values[s[i]]
+		if !ok {
+			return 0
+		}
+		if i+1 < len(s) && values[s[i+1]] > value {
+			total -= value
+		} else {
+			total += value
+		}
+	}
+	if total < 1 || total > 99 || toRoman(total) != s {
+		return 0
+	}
+	return total
+}
+
+func toRoman(n int) string {
+	numerals := []struct {
+		value  int
+		symbol string
+	}{
+		{50, "l"}, {40, "xl"}, {10, "x"}, {9, "ix"}, {5, "v"}, {4, "iv"}, {1, "i"},
+	}
+	var b strings.Builder
+	for _, numeral := range numerals {
+		for n >= numeral.value {
+			b.WriteString(numeral.symbol)
+			n -= numeral.value
+		}
+	}
+	return b.String()
+}
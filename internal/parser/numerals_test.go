@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSeasonNumeral(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]int{
+		"4":          4,
+		"IV":         4,
+		"ix":         9,
+		"XII":        12,
+		"two":        2,
+		"Twenty":     20,
+		"IIII":       0,
+		"VX":         0,
+		"twenty-one": 0,
+	}
+
+	for in, want := range tests {
+		assert.Equal(t, want, parseSeasonNumeral(in), in)
+	}
+}
+
+func TestNormalizeEpisodeSeason(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"Show.Name.Season.IV.E05.mkv":        "Show.Name.S04E05.mkv",
+		"Show.Season.Two.Episode.7.720p.mkv": "Show.S02E07.720p.mkv",
+		"Money.Heist.Part.3.Ep.1.mkv":        "Money.Heist.S03E01.mkv",
+		"Dune.Part.Two.2024.mkv":             "Dune.Part.Two.2024.mkv",
+		"Show.Season.Two.Episode.Five.mkv":   "Show.Season.Two.Episode.Five.mkv",
+		"Show.Season.IIII.E05.mkv":           "Show.Season.IIII.E05.mkv",
+	}
+
+	for in, want := range tests {
+		assert.Equal(t, want, normalizeEpisodeSeason(in), in)
+	}
+}
+
+func TestNormalizePart(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"Series.Part.Two.mkv":      "Series.S01E02.mkv",
+		"Band.of.Brothers.Part.IV": "Band.of.Brothers.S01E04",
+		"Series.Part.Zero.mkv":     "Series.Part.Zero.mkv",
+	}
+
+	for in, want := range tests {
+		assert.Equal(t, want, normalizePart(in), in)
+	}
+}
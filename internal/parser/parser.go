@@ -1,17 +1,29 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
+	"github.com/carlosarraes/subs-cli/internal/textnorm"
 	"github.com/carlosarraes/subs-cli/pkg/models"
 )
 
 type Parser struct {
 	patterns []PatternMatcher
+	options  Options
+}
+
+type Options struct {
+	ThreeDigitEpisodes bool
+	FourDigitEpisodes  bool
+	HasSeason          func(title string, season int) bool
 }
 
 type PatternMatcher struct {
@@ -21,30 +33,74 @@ type PatternMatcher struct {
 	Example string
 }
 
+var ErrUnparseableFilename = errors.New("unable to parse filename")
+
+var (
+	checksumBlock = regexp.MustCompile(`[\[(]([0-9A-Fa-f]{8})[\])]`)
+	bracketedYear = regexp.MustCompile(`[\[(]((?:19|20)\d{2})[\])]`)
+	// trailingExt is a file extension swallowed by an optional source group
+	// when nothing follows the episode ("Show.Name.1015.mkv").
+	trailingExt = regexp.MustCompile(`(?i)(?:^|\.)(?:mp4|mkv|avi|mov|wmv|flv|webm|m4v|mpg|mpeg|3gp)$`)
+)
+
+var (
+	audioPart   = regexp.MustCompile(`(?i)^(dts-hd|dts-x|dtsx|dts|truehd|atmos|ddp|dd\+|e-?ac-?3|dd|ac-?3|aac|flac|opus|lpcm|pcm|mp3)(\d)?(?:-(.+))?$`)
+	channelPart = regexp.MustCompile(`^(\d)(?:-(.+))?$`)
+
+	audioNames = map[string]string{
+		"dts": "DTS", "dts-hd": "DTS-HD", "dts-x": "DTS:X", "dtsx": "DTS:X",
+		"truehd": "TrueHD", "atmos": "Atmos",
+		"ddp": "DDP", "dd+": "DDP", "eac3": "DDP", "e-ac3": "DDP", "eac-3": "DDP", "e-ac-3": "DDP",
+		"dd": "DD", "ac3": "DD", "ac-3": "DD",
+		"aac": "AAC", "flac": "FLAC", "opus": "Opus", "lpcm": "LPCM", "pcm": "PCM", "mp3": "MP3",
+	}
+)
+
 func New() *Parser {
+	return NewWithOptions(Options{})
+}
+
+func NewWithOptions(opts Options) *Parser {
 	return &Parser{
 		patterns: compilePatterns(),
+		options:  opts,
 	}
 }
 
 func (p *Parser) Parse(filename string) (*models.MediaInfo, error) {
-	cleanName := cleanFilename(filename)
+	name, checksum := stripChecksum(textnorm.Compose(filepath.Base(filename)))
+	name = bracketedYear.ReplaceAllString(name, " $1 ")
+	cleanName := normalizeEpisodeSeason(cleanFilename(name))
+
+	mediaInfo := p.match(cleanName)
+	if mediaInfo == nil {
+		if parted := normalizePart(cleanName); parted != cleanName {
+			mediaInfo = p.match(parted)
+		}
+	}
+	if mediaInfo != nil {
+		mediaInfo.Checksum = checksum
+		return mediaInfo, nil
+	}
+
+	return nil, fmt.Errorf("%w '%s': expected formats like:\n"+
+		"  TV Show: Series.Name.S01E01.720p.x264-GROUP.mkv\n"+
+		"  TV Show with Year: Series.Name.2024.S01E01.1080p.x265-GROUP.mkv\n"+
+		"  Alternative TV: Series.Name.1x01.720p.WEB-DL.mkv\n"+
+		"  Movie: Movie.Name.2023.1080p.BluRay.x264-GROUP.mp4", ErrUnparseableFilename, filename)
+}
 
+func (p *Parser) match(name string) *models.MediaInfo {
 	for _, pattern := range p.patterns {
-		if matches := pattern.Regex.FindStringSubmatch(cleanName); matches != nil {
+		if matches := pattern.Regex.FindStringSubmatch(name); matches != nil {
 			mediaInfo, err := p.extractMediaInfo(matches, pattern)
 			if err != nil {
 				continue
 			}
-			return mediaInfo, nil
+			return mediaInfo
 		}
 	}
-
-	return nil, fmt.Errorf("unable to parse filename '%s': expected formats like:\n"+
-		"  TV Show: Series.Name.S01E01.720p.x264-GROUP.mkv\n"+
-		"  TV Show with Year: Series.Name.2024.S01E01.1080p.x265-GROUP.mkv\n"+
-		"  Alternative TV: Series.Name.1x01.720p.WEB-DL.mkv\n"+
-		"  Movie: Movie.Name.2023.1080p.BluRay.x264-GROUP.mp4", filename)
+	return nil
 }
 
 func (p *Parser) extractMediaInfo(matches []string, pattern PatternMatcher) (*models.MediaInfo, error) {
@@ -56,6 +112,9 @@ func (p *Parser) extractMediaInfo(matches []string, pattern PatternMatcher) (*mo
 			matchMap[name] = strings.TrimSpace(matches[i])
 		}
 	}
+	if source, ok := matchMap["source"]; ok && matchMap["ext"] == "" {
+		matchMap["source"] = trailingExt.ReplaceAllString(source, "")
+	}
 
 	mediaInfo := &models.MediaInfo{
 		Type: pattern.Type,
@@ -70,7 +129,7 @@ func (p *Parser) extractMediaInfo(matches []string, pattern PatternMatcher) (*mo
 	}
 
 	if pattern.Type == "tv" {
-		season, episode, err := p.extractSeasonEpisode(matchMap)
+		season, episode, err := p.extractSeasonEpisode(matchMap, mediaInfo.Title)
 		if err != nil {
 			return nil, err
 		}
@@ -84,6 +143,7 @@ func (p *Parser) extractMediaInfo(matches []string, pattern PatternMatcher) (*mo
 	}
 
 	if source, ok := matchMap["source"]; ok && source != "" {
+		source, mediaInfo.Audio, mediaInfo.Channels = extractAudio(source)
 		mediaInfo.Source, mediaInfo.Codec = extractSourceAndCodec(source)
 	}
 
@@ -94,7 +154,7 @@ func (p *Parser) extractMediaInfo(matches []string, pattern PatternMatcher) (*mo
 	return mediaInfo, nil
 }
 
-func (p *Parser) extractSeasonEpisode(matchMap map[string]string) (int, int, error) {
+func (p *Parser) extractSeasonEpisode(matchMap map[string]string, title string) (int, int, error) {
 	var season, episode int
 	var err error
 
@@ -112,6 +172,14 @@ func (p *Parser) extractSeasonEpisode(matchMap map[string]string) (int, int, err
 		}
 	}
 
+	if absolute, ok := matchMap["absolute_episode"]; ok && absolute != "" {
+		episode, err = strconv.Atoi(absolute)
+		if err != nil || episode < 1 {
+			return 0, 0, fmt.Errorf("invalid episode number: %s", absolute)
+		}
+		season = 1
+	}
+
 	if alt, ok := matchMap["alt_episode"]; ok && alt != "" && season == 0 && episode == 0 {
 		if len(alt) == 3 {
 			season, err = strconv.Atoi(alt[:1])
@@ -127,6 +195,9 @@ func (p *Parser) extractSeasonEpisode(matchMap map[string]string) (int, int, err
 		if err != nil || season < 1 || episode < 1 {
 			return 0, 0, fmt.Errorf("invalid alternative episode format: %s", alt)
 		}
+		if !p.acceptAltEpisode(alt, matchMap["source"], title, season) {
+			return 0, 0, fmt.Errorf("%s is not read as an episode number for %s", alt, title)
+		}
 	}
 
 	if season == 0 || episode == 0 {
@@ -136,6 +207,37 @@ func (p *Parser) extractSeasonEpisode(matchMap map[string]string) (int, int, err
 	return season, episode, nil
 }
 
+func maxYear() int {
+	return time.Now().Year() + 2
+}
+
+func (p *Parser) acceptAltEpisode(alt, rest, title string, season int) bool {
+	switch len(alt) {
+	case 3:
+		return p.options.ThreeDigitEpisodes
+	case 4:
+		if !p.options.FourDigitEpisodes || isYear(alt) || hasYear(rest) {
+			return false
+		}
+		return p.options.HasSeason == nil || p.options.HasSeason(title, season)
+	}
+	return false
+}
+
+func isYear(s string) bool {
+	year, err := strconv.Atoi(s)
+	return err == nil && len(s) == 4 && year >= 1900 && year <= maxYear()
+}
+
+func hasYear(s string) bool {
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == '.' || r == '-' }) {
+		if isYear(part) {
+			return true
+		}
+	}
+	return false
+}
+
 func (p *Parser) validateMediaInfo(info *models.MediaInfo) error {
 	if info.Title == "" {
 		return fmt.Errorf("title cannot be empty")
@@ -147,7 +249,7 @@ func (p *Parser) validateMediaInfo(info *models.MediaInfo) error {
 
 	if info.Year != "" {
 		year, err := strconv.Atoi(info.Year)
-		if err != nil || year < 1900 || year > 2030 {
+		if err != nil || year < 1900 || year > maxYear() {
 			return fmt.Errorf("invalid year: %s", info.Year)
 		}
 	}
@@ -157,6 +259,15 @@ func (p *Parser) validateMediaInfo(info *models.MediaInfo) error {
 
 func compilePatterns() []PatternMatcher {
 	return []PatternMatcher{
+		{
+			Name:    "Anime (absolute episode)",
+			Type:    "tv",
+			Example: "[SubsPlease] Sousou no Frieren - 05 (1080p) [F02B9CEE].mkv",
+			Regex: regexp.MustCompile(
+				`^\[(?P<source>[^\]]+)\]\.?(?P<title>.+?)\.-\.(?P<absolute_episode>\d{1,3})(?:v\d)?(?:\.[\[(](?P<quality>\d{3,4}p)[\])])?(?:\..*)?$`,
+			),
+		},
+
 		{
 			Name:    "TV with Year (SxxExx)",
 			Type:    "tv",
@@ -211,12 +322,21 @@ func compilePatterns() []PatternMatcher {
 			),
 		},
 
+		{
+			Name:    "TV Alternative (4-digit format)",
+			Type:    "tv",
+			Example: "Series.Name.1015.720p.x264.mkv",
+			Regex: regexp.MustCompile(
+				`^(?P<title>.*?)\.(?P<alt_episode>\d{4})(?:\.(?P<quality>\d+p))?(?:\.(?P<source>.+?))?(?:\.(?P<ext>\w+))?$`,
+			),
+		},
+
 		{
 			Name:    "Movie",
 			Type:    "movie",
 			Example: "Inception.2010.1080p.BluRay.x264-SPARKS.mkv",
 			Regex: regexp.MustCompile(
-				`^(?P<title>.*?)\.(?P<year>\d{4})(?:\.(?P<quality>\d+p))?(?:\.(?P<source>.+?))\.(?P<ext>mp4|mkv|avi|mov|wmv|flv|webm|m4v|mpg|mpeg|3gp)$`,
+				`^(?P<title>.*)\.(?P<year>\d{4})(?:\.(?P<quality>\d+p))?(?:\.(?P<source>.+?))?\.(?P<ext>mp4|mkv|avi|mov|wmv|flv|webm|m4v|mpg|mpeg|3gp)$`,
 			),
 		},
 
@@ -225,7 +345,7 @@ func compilePatterns() []PatternMatcher {
 			Type:    "movie",
 			Example: "Movie.Name.2023.1080p.BluRay.x264",
 			Regex: regexp.MustCompile(
-				`^(?P<title>.*?)\.(?P<year>\d{4})(?:\.(?P<quality>\d+p))?(?:\.(?P<source>.+?))?$`,
+				`^(?P<title>.*)\.(?P<year>\d{4})(?:\.(?P<quality>\d+p))?(?:\.(?P<source>.+?))?$`,
 			),
 		},
 
@@ -234,16 +354,31 @@ func compilePatterns() []PatternMatcher {
 			Type:    "movie",
 			Example: "Movie.Name.2023.BluRay.x264-GROUP.mp4",
 			Regex: regexp.MustCompile(
-				`^(?P<title>.*?)\.(?P<year>\d{4})\.(?P<source>.+?)\.(?P<ext>mp4|mkv|avi|mov|wmv|flv|webm|m4v|mpg|mpeg|3gp)$`,
+				`^(?P<title>.*)\.(?P<year>\d{4})\.(?P<source>.+?)\.(?P<ext>mp4|mkv|avi|mov|wmv|flv|webm|m4v|mpg|mpeg|3gp)$`,
 			),
 		},
 	}
 }
 
+func stripChecksum(name string) (string, string) {
+	matches := checksumBlock.FindAllStringSubmatchIndex(name, -1)
+	if len(matches) == 0 {
+		return name, ""
+	}
+	last := matches[len(matches)-1]
+	checksum := strings.ToUpper(name[last[2]:last[3]])
+	return name[:last[0]] + " " + name[last[1]:], checksum
+}
+
 func cleanFilename(filename string) string {
 	base := filepath.Base(filename)
 
-	cleaned := strings.ReplaceAll(base, " ", ".")
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return '.'
+		}
+		return r
+	}, base)
 
 	for strings.Contains(cleaned, "..") {
 		cleaned = strings.ReplaceAll(cleaned, "..", ".")
@@ -260,6 +395,50 @@ func cleanTitle(title string) string {
 	return strings.TrimSpace(clean)
 }
 
+func extractAudio(combined string) (rest, audio, channels string) {
+	parts := strings.Split(combined, ".")
+	var kept, codecs []string
+
+	for i := 0; i < len(parts); i++ {
+		m := audioPart.FindStringSubmatch(parts[i])
+		if m == nil {
+			kept = append(kept, parts[i])
+			continue
+		}
+
+		name := audioNames[strings.ToLower(m[1])]
+		if name == "DTS-HD" && m[2] == "" && m[3] == "" && i+1 < len(parts) && strings.EqualFold(parts[i+1], "MA") {
+			name += " MA"
+			i++
+		}
+		if !slices.Contains(codecs, name) {
+			codecs = append(codecs, name)
+		}
+
+		first, group := m[2], m[3]
+		if first == "" && group == "" && i+1 < len(parts) {
+			if c := channelPart.FindStringSubmatch(parts[i+1]); c != nil && c[2] == "" && i+2 < len(parts) && channelPart.MatchString(parts[i+2]) {
+				first = c[1]
+				i++
+			}
+		}
+		if first != "" && group == "" && i+1 < len(parts) {
+			if c := channelPart.FindStringSubmatch(parts[i+1]); c != nil {
+				if channels == "" {
+					channels = first + "." + c[1]
+				}
+				group = c[2]
+				i++
+			}
+		}
+		if group != "" {
+			kept = append(kept, group)
+		}
+	}
+
+	return strings.Join(kept, "."), strings.Join(codecs, " "), channels
+}
+
 func extractSourceAndCodec(combined string) (source, codec string) {
 	if combined == "" {
 		return "", ""
@@ -1,7 +1,10 @@
 package parser
 
 import (
+	"fmt"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/carlosarraes/subs-cli/pkg/models"
 	"github.com/stretchr/testify/assert"
@@ -11,11 +14,16 @@ import (
 func TestParser_Parse(t *testing.T) {
 	t.Parallel()
 
-	parser := New()
+	knownSeasons := func(seasons int) func(string, int) bool {
+		return func(title string, season int) bool {
+			return title == "Show Name" && season <= seasons
+		}
+	}
 
 	tests := []struct {
 		name     string
 		filename string
+		options  Options
 		want     *models.MediaInfo
 		wantErr  bool
 	}{
@@ -72,6 +80,191 @@ func TestParser_Parse(t *testing.T) {
 			},
 		},
 
+		{
+			name:     "TV with roman numeral season",
+			filename: "Show.Name.Season.IV.E05.720p.mkv",
+			want: &models.MediaInfo{
+				Title:   "Show Name",
+				Season:  4,
+				Episode: 5,
+				Quality: "720p",
+				Type:    "episode",
+			},
+		},
+		{
+			name:     "TV with worded part",
+			filename: "Series Part Two Episode 3.mkv",
+			want: &models.MediaInfo{
+				Title:   "Series",
+				Season:  2,
+				Episode: 3,
+				Type:    "episode",
+			},
+		},
+		{
+			name:     "Miniseries with worded part",
+			filename: "Series Part Two.mkv",
+			want: &models.MediaInfo{
+				Title:   "Series",
+				Season:  1,
+				Episode: 2,
+				Type:    "episode",
+			},
+		},
+		{
+			name:     "Miniseries with worded part and longer title",
+			filename: "Band of Brothers Part Two.mkv",
+			want: &models.MediaInfo{
+				Title:   "Band of Brothers",
+				Season:  1,
+				Episode: 2,
+				Type:    "episode",
+			},
+		},
+		{
+			name:     "Movie with worded part",
+			filename: "Dune.Part.Two.2024.1080p.WEB-DL.x265-GRP.mkv",
+			want: &models.MediaInfo{
+				Title:   "Dune Part Two",
+				Year:    "2024",
+				Quality: "1080p",
+				Source:  "WEB-DL.GRP",
+				Codec:   "x265",
+				Type:    "movie",
+			},
+		},
+
+		{
+			name:     "Title that is a year",
+			filename: "2012.2009.1080p.BluRay.x264-GRP.mkv",
+			want: &models.MediaInfo{
+				Title:   "2012",
+				Year:    "2009",
+				Quality: "1080p",
+				Source:  "BluRay.GRP",
+				Codec:   "x264",
+				Type:    "movie",
+			},
+		},
+		{
+			name:     "Another title that is a year",
+			filename: "1917.2019.1080p.WEB-DL.x265.mkv",
+			want: &models.MediaInfo{
+				Title:   "1917",
+				Year:    "2019",
+				Quality: "1080p",
+				Source:  "WEB-DL",
+				Codec:   "x265",
+				Type:    "movie",
+			},
+		},
+		{
+			name:     "Title that is a year, without a source",
+			filename: "2012.2009.1080p.mkv",
+			want: &models.MediaInfo{
+				Title:   "2012",
+				Year:    "2009",
+				Quality: "1080p",
+				Type:    "movie",
+			},
+		},
+		{
+			name:     "Title that is a year, without anything else",
+			filename: "1917.2019.mkv",
+			want: &models.MediaInfo{
+				Title: "1917",
+				Year:  "2019",
+				Type:  "movie",
+			},
+		},
+		{
+			name:     "Year in the middle of the title",
+			filename: "Blade.Runner.2049.2017.1080p.WEB-DL.x264.mkv",
+			want: &models.MediaInfo{
+				Title:   "Blade Runner 2049",
+				Year:    "2017",
+				Quality: "1080p",
+				Source:  "WEB-DL",
+				Codec:   "x264",
+				Type:    "movie",
+			},
+		},
+		{
+			name:     "Year at the start of the title",
+			filename: "2001.A.Space.Odyssey.1968.1080p.BluRay.x264.mkv",
+			want: &models.MediaInfo{
+				Title:   "2001 A Space Odyssey",
+				Year:    "1968",
+				Quality: "1080p",
+				Source:  "BluRay",
+				Codec:   "x264",
+				Type:    "movie",
+			},
+		},
+		{
+			name:     "Year in parentheses",
+			filename: "1917 (2019) 1080p BluRay x264.mkv",
+			want: &models.MediaInfo{
+				Title:   "1917",
+				Year:    "2019",
+				Quality: "1080p",
+				Source:  "BluRay",
+				Codec:   "x264",
+				Type:    "movie",
+			},
+		},
+		{
+			name:     "Movie with a decomposed accent",
+			filename: "Ame\u0301lie.2001.1080p.BluRay.x264-GRP.mkv",
+			want: &models.MediaInfo{
+				Title:   "Amélie",
+				Year:    "2001",
+				Quality: "1080p",
+				Source:  "BluRay.GRP",
+				Codec:   "x264",
+				Type:    "movie",
+			},
+		},
+		{
+			name:     "Movie with a Japanese title",
+			filename: "千と千尋の神隠し 2001 1080p BluRay x264.mkv",
+			want: &models.MediaInfo{
+				Title:   "千と千尋の神隠し",
+				Year:    "2001",
+				Quality: "1080p",
+				Source:  "BluRay",
+				Codec:   "x264",
+				Type:    "movie",
+			},
+		},
+		{
+			name:     "Movie with an apostrophe",
+			filename: "Don't.Look.Up.2021.1080p.WEB-DL.x264.mkv",
+			want: &models.MediaInfo{
+				Title:   "Don't Look Up",
+				Year:    "2021",
+				Quality: "1080p",
+				Source:  "WEB-DL",
+				Codec:   "x264",
+				Type:    "movie",
+			},
+		},
+		{
+			name:     "TV with audio tokens",
+			filename: "Show.Name.S02E03.1080p.WEB-DL.DDP5.1.x264-NTb.mkv",
+			want: &models.MediaInfo{
+				Title:    "Show Name",
+				Season:   2,
+				Episode:  3,
+				Quality:  "1080p",
+				Source:   "WEB-DL.NTb",
+				Codec:    "x264",
+				Audio:    "DDP",
+				Channels: "5.1",
+				Type:     "episode",
+			},
+		},
+
 		{
 			name:     "TV alternative xXx format",
 			filename: "Series.Name.1x01.720p.WEB-DL.mkv",
@@ -102,6 +295,7 @@ func TestParser_Parse(t *testing.T) {
 		{
 			name:     "TV 3-digit episode format",
 			filename: "Series.Name.101.720p.x264.mkv",
+			options:  Options{ThreeDigitEpisodes: true},
 			want: &models.MediaInfo{
 				Title:   "Series Name",
 				Season:  1,
@@ -114,6 +308,7 @@ func TestParser_Parse(t *testing.T) {
 		{
 			name:     "TV 3-digit complex episode",
 			filename: "Game.of.Thrones.315.1080p.HDTV.x265-DIMENSION.mkv",
+			options:  Options{ThreeDigitEpisodes: true},
 			want: &models.MediaInfo{
 				Title:   "Game of Thrones",
 				Season:  3,
@@ -124,7 +319,163 @@ func TestParser_Parse(t *testing.T) {
 				Type:    "episode",
 			},
 		},
+		{
+			name:     "3-digit episodes are opt-in",
+			filename: "Series.Name.101.720p.x264.mkv",
+			wantErr:  true,
+		},
+		{
+			name:     "Movie with a numeric title",
+			filename: "Fahrenheit.451.1966.1080p.BluRay.x264-GRP.mkv",
+			want: &models.MediaInfo{
+				Title:   "Fahrenheit 451",
+				Year:    "1966",
+				Quality: "1080p",
+				Source:  "BluRay.GRP",
+				Codec:   "x264",
+				Type:    "movie",
+			},
+		},
+		{
+			name:     "TV 4-digit episode of a show with enough seasons",
+			filename: "Show.Name.1015.720p.x264.mkv",
+			options:  Options{FourDigitEpisodes: true, HasSeason: knownSeasons(12)},
+			want: &models.MediaInfo{
+				Title:   "Show Name",
+				Season:  10,
+				Episode: 15,
+				Quality: "720p",
+				Codec:   "x264",
+				Type:    "episode",
+			},
+		},
+		{
+			name:     "TV 4-digit episode without season heuristics",
+			filename: "Show.Name.1015.HDTV.mkv",
+			options:  Options{FourDigitEpisodes: true},
+			want: &models.MediaInfo{
+				Title:   "Show Name",
+				Season:  10,
+				Episode: 15,
+				Source:  "HDTV",
+				Type:    "episode",
+			},
+		},
+		{
+			name:     "TV 4-digit episode right before the extension",
+			filename: "Show.Name.1015.mkv",
+			options:  Options{FourDigitEpisodes: true},
+			want: &models.MediaInfo{
+				Title:   "Show Name",
+				Season:  10,
+				Episode: 15,
+				Type:    "episode",
+			},
+		},
+		{
+			name:     "TV xXx episode right before the extension",
+			filename: "Series.Name.1x01.mkv",
+			want: &models.MediaInfo{
+				Title:   "Series Name",
+				Season:  1,
+				Episode: 1,
+				Type:    "episode",
+			},
+		},
+		{
+			name:     "4-digit number beyond the known seasons",
+			filename: "Show.Name.1015.mkv",
+			options:  Options{FourDigitEpisodes: true, HasSeason: knownSeasons(5)},
+			wantErr:  true,
+		},
+		{
+			name:     "4-digit year stays a movie even when 4-digit episodes are always on",
+			filename: "Inception.2010.1080p.BluRay.x264.mkv",
+			options:  Options{FourDigitEpisodes: true},
+			want: &models.MediaInfo{
+				Title:   "Inception",
+				Year:    "2010",
+				Quality: "1080p",
+				Source:  "BluRay",
+				Codec:   "x264",
+				Type:    "movie",
+			},
+		},
+		{
+			name:     "4-digit number in a title followed by a year stays a movie",
+			filename: "Blade.Runner.2049.2017.mkv",
+			options:  Options{FourDigitEpisodes: true},
+			want: &models.MediaInfo{
+				Title: "Blade Runner 2049",
+				Year:  "2017",
+				Type:  "movie",
+			},
+		},
+		{
+			name:     "4-digit year of an unknown title stays a movie",
+			filename: "Inception.2010.1080p.BluRay.x264-SPARKS.mkv",
+			options:  Options{FourDigitEpisodes: true, HasSeason: knownSeasons(30)},
+			want: &models.MediaInfo{
+				Title:   "Inception",
+				Year:    "2010",
+				Quality: "1080p",
+				Source:  "BluRay.SPARKS",
+				Codec:   "x264",
+				Type:    "movie",
+			},
+		},
 
+		{
+			name:     "Anime fansub absolute episode",
+			filename: "[SubsPlease] Sousou no Frieren - 05 (1080p) [F02B9CEE].mkv",
+			want: &models.MediaInfo{
+				Title:    "Sousou no Frieren",
+				Season:   1,
+				Episode:  5,
+				Quality:  "1080p",
+				Source:   "SubsPlease",
+				Checksum: "F02B9CEE",
+				Type:     "episode",
+			},
+		},
+		{
+			name:     "Anime checksum after quality",
+			filename: "[Group] Show - 12 [720p][abcdef12].mkv",
+			want: &models.MediaInfo{
+				Title:    "Show",
+				Season:   1,
+				Episode:  12,
+				Quality:  "720p",
+				Source:   "Group",
+				Checksum: "ABCDEF12",
+				Type:     "episode",
+			},
+		},
+		{
+			name:     "Checksum is not part of the source",
+			filename: "Movie.2010.1080p.BluRay.x264-GRP.[DEADBEEF].mkv",
+			want: &models.MediaInfo{
+				Title:    "Movie",
+				Year:     "2010",
+				Quality:  "1080p",
+				Source:   "BluRay.GRP",
+				Codec:    "x264",
+				Checksum: "DEADBEEF",
+				Type:     "movie",
+			},
+		},
+		{
+			name:     "Anime fansub with version and number in title",
+			filename: "[Erai-raws] Mob Psycho 100 - 12v2 [1080p].mkv",
+			want: &models.MediaInfo{
+				Title:   "Mob Psycho 100",
+				Season:  1,
+				Episode: 12,
+				Quality: "1080p",
+				Source:  "Erai-raws",
+				Type:    "episode",
+			},
+		},
 		{
 			name:     "Movie with quality",
 			filename: "Inception.2010.1080p.BluRay.x264-SPARKS.mkv",
@@ -262,7 +613,7 @@ func TestParser_Parse(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			got, err := parser.Parse(tt.filename)
+			got, err := NewWithOptions(tt.options).Parse(tt.filename)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -280,6 +631,8 @@ func TestParser_Parse(t *testing.T) {
 			assert.Equal(t, tt.want.Quality, got.Quality, "Quality mismatch")
 			assert.Equal(t, tt.want.Source, got.Source, "Source mismatch")
 			assert.Equal(t, tt.want.Codec, got.Codec, "Codec mismatch")
+			assert.Equal(t, tt.want.Audio, got.Audio, "Audio mismatch")
+			assert.Equal(t, tt.want.Channels, got.Channels, "Channels mismatch")
 			assert.Equal(t, tt.want.Type, got.Type, "Type mismatch")
 		})
 	}
@@ -322,7 +675,7 @@ func TestParser_ValidationErrors(t *testing.T) {
 		},
 		{
 			name:     "Invalid year - future",
-			filename: "Movie.Name.2050.1080p.BluRay.x264.mkv",
+			filename: fmt.Sprintf("Movie.Name.%d.1080p.BluRay.x264.mkv", time.Now().Year()+3),
 			errorMsg: "unable to parse filename",
 		},
 	}
@@ -335,10 +688,21 @@ func TestParser_ValidationErrors(t *testing.T) {
 			_, err := parser.Parse(tt.filename)
 			require.Error(t, err)
 			assert.Contains(t, err.Error(), tt.errorMsg)
+			assert.ErrorIs(t, err, ErrUnparseableFilename)
 		})
 	}
 }
 
+func TestParser_UpcomingYears(t *testing.T) {
+	t.Parallel()
+
+	year := time.Now().Year() + 2
+	info, err := New().Parse(fmt.Sprintf("Movie.Name.%d.1080p.BluRay.x264.mkv", year))
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(year), info.Year, "announced releases two years out are accepted")
+	assert.Equal(t, year, maxYear())
+}
+
 func TestCleanFilename(t *testing.T) {
 	t.Parallel()
 
@@ -367,6 +731,11 @@ func TestCleanFilename(t *testing.T) {
 			filename: "Movie Name.2023 1080p.mkv",
 			want:     "Movie.Name.2023.1080p.mkv",
 		},
+		{
+			name:     "Ideographic and non-breaking spaces",
+			filename: "千と千尋の神隠し\u30002001\u00a01080p.mkv",
+			want:     "千と千尋の神隠し.2001.1080p.mkv",
+		},
 	}
 
 	for _, tt := range tests {
@@ -421,6 +790,32 @@ func TestCleanTitle(t *testing.T) {
 	}
 }
 
+func TestExtractAudio(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		combined     string
+		wantRest     string
+		wantAudio    string
+		wantChannels string
+	}{
+		{"WEB-DL.DDP5.1.H.264-NTb", "WEB-DL.H.264-NTb", "DDP", "5.1"},
+		{"BluRay.DTS-HD.MA.5.1.x264-GRP", "BluRay.x264-GRP", "DTS-HD MA", "5.1"},
+		{"BluRay.TrueHD.Atmos.7.1.x265-GRP", "BluRay.x265-GRP", "TrueHD Atmos", "7.1"},
+		{"WEBRip.AAC2.0-GRP", "WEBRip.GRP", "AAC", "2.0"},
+		{"BluRay.x264.DTS-GRP", "BluRay.x264.GRP", "DTS", ""},
+		{"HDTV.AC3.5.1.XviD", "HDTV.XviD", "DD", "5.1"},
+		{"BluRay.x264-SPARKS", "BluRay.x264-SPARKS", "", ""},
+	}
+
+	for _, tt := range tests {
+		rest, audio, channels := extractAudio(tt.combined)
+		assert.Equal(t, tt.wantRest, rest, tt.combined)
+		assert.Equal(t, tt.wantAudio, audio, tt.combined)
+		assert.Equal(t, tt.wantChannels, channels, tt.combined)
+	}
+}
+
 func TestExtractSourceAndCodec(t *testing.T) {
 	t.Parallel()
 
@@ -555,3 +950,25 @@ func TestMediaInfo_Methods(t *testing.T) {
 		assert.Equal(t, "Inception", withoutYear.GetDisplayTitle())
 	})
 }
+
+func TestParser_FourDigitYearsSkipSeasonLookup(t *testing.T) {
+	t.Parallel()
+
+	var lookups []string
+	p := NewWithOptions(Options{FourDigitEpisodes: true, HasSeason: func(title string, season int) bool {
+		lookups = append(lookups, title)
+		return true
+	}})
+
+	for _, name := range []string{"Inception.2010.1080p.BluRay.x264.mkv", "Blade.Runner.2049.2017.mkv", "Dune.Part.Two.2024.2160p.WEB-DL.mkv"} {
+		info, err := p.Parse(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, "movie", info.Type, name)
+	}
+	assert.Empty(t, lookups, "years are never looked up as seasons")
+
+	info, err := p.Parse("Show.Name.1015.720p.mkv")
+	require.NoError(t, err)
+	assert.Equal(t, 10, info.Season)
+	assert.Equal(t, []string{"Show Name"}, lookups)
+}
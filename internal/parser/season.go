@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/internal/textnorm"
+)
+
+var (
+	seasonOnlyFolder = regexp.MustCompile(`(?i)^(?:season\.?|s)(\d{1,2})$`)
+	seasonFolder     = regexp.MustCompile(`(?i)^(.+?)\.(?:\(?\d{4}\)?\.)?(?:season\.?|s)(\d{1,2})(?:\..*)?$`)
+	folderYear       = regexp.MustCompile(`\.?\(?\d{4}\)?$`)
+)
+
+func ParseSeasonFolder(dir string) (title string, season int, ok bool) {
+	name := normalizeSeason(cleanFilename(strings.ReplaceAll(textnorm.Compose(filepath.Base(dir)), "_", ".")))
+
+	if m := seasonOnlyFolder.FindStringSubmatch(name); m != nil {
+		parent := cleanFilename(strings.ReplaceAll(textnorm.Compose(filepath.Base(filepath.Dir(dir))), "_", "."))
+		title = folderTitle(parent)
+		season, _ = strconv.Atoi(m[1])
+		return title, season, title != "" && title != "." && season > 0
+	}
+
+	if m := seasonFolder.FindStringSubmatch(name); m != nil {
+		title = folderTitle(m[1])
+		season, _ = strconv.Atoi(m[2])
+		return title, season, title != "" && title != "." && season > 0
+	}
+	return "", 0, false
+}
+
+// folderTitle strips the separators and release year that commonly sit
+// between a show name and its season ("Show (2019) - Season 1").
+func folderTitle(name string) string {
+	name = strings.TrimRight(name, ".-_ ")
+	if trimmed := strings.TrimRight(folderYear.ReplaceAllString(name, ""), ".-_ "); trimmed != "" {
+		name = trimmed
+	}
+	return cleanTitle(name)
+}
@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSeasonFolder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dir    string
+		title  string
+		season int
+		ok     bool
+	}{
+		{"The.Office.S03", "The Office", 3, true},
+		{"The.Office.S03.720p.BluRay.x264-GRP", "The Office", 3, true},
+		{"The Office Season 3", "The Office", 3, true},
+		{"Dark.Matter.2024.S01.1080p", "Dark Matter", 1, true},
+		{"Breaking_Bad_Season_05", "Breaking Bad", 5, true},
+		{"Show Name - Season 2", "Show Name", 2, true},
+		{"Show Name (2019) - Season 1", "Show Name", 1, true},
+		{"Show.Name.2019.-.S01", "Show Name", 1, true},
+		{"Show Name - S02 - 1080p", "Show Name", 2, true},
+		{filepath.Join("Shows", "Show Name (2019) -", "Season 1"), "Show Name", 1, true},
+		{filepath.Join("Shows", "1917", "Season 1"), "1917", 1, true},
+		{filepath.Join("Shows", "Severance (2022)", "Season 02"), "Severance", 2, true},
+		{filepath.Join("Shows", "Severance", "S1"), "Severance", 1, true},
+		{"Show Name Season IV", "Show Name", 4, true},
+		{"Series Part Two", "Series", 2, true},
+		{filepath.Join("Shows", "Severance", "Season Two"), "Severance", 2, true},
+		{"The.Office.S03E07", "", 0, false},
+		{"Movies", "", 0, false},
+		{"Season 1", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			t.Parallel()
+			title, season, ok := ParseSeasonFolder(tt.dir)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.title, title)
+				assert.Equal(t, tt.season, season)
+			}
+		})
+	}
+}
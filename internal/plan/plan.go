@@ -0,0 +1,99 @@
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/internal/fsutil"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+const Version = 1
+
+type Plan struct {
+	Version   int        `json:"version"`
+	CreatedAt time.Time  `json:"created_at"`
+	Downloads []Download `json:"downloads"`
+}
+
+type Download struct {
+	MediaPath   string           `json:"media_path"`
+	Target      string           `json:"target"`
+	Language    string           `json:"language"`
+	Subtitle    *models.Subtitle `json:"subtitle"`
+	MergeCDs    bool             `json:"merge_cds,omitempty"`
+	CDDurations []string         `json:"cd_durations,omitempty"`
+}
+
+func New() *Plan {
+	return &Plan{
+		Version:   Version,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+		Downloads: []Download{},
+	}
+}
+
+func (p *Plan) Add(download Download) {
+	p.Downloads = append(p.Downloads, download)
+}
+
+func (d Download) Durations() ([]time.Duration, error) {
+	durations := make([]time.Duration, 0, len(d.CDDurations))
+	for _, value := range d.CDDurations {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CD duration '%s': %w", value, err)
+		}
+		durations = append(durations, duration)
+	}
+	return durations, nil
+}
+
+func (p *Plan) Save(path string) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode plan: %w", err)
+	}
+
+	if err := fsutil.WriteFile(path, append(data, '\n'), fsutil.WriteOptions{Perm: 0644}); err != nil {
+		return fmt.Errorf("failed to write plan '%s': %w", path, err)
+	}
+	return nil
+}
+
+func Load(path string) (*Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan '%s': %w", path, err)
+	}
+
+	var p Plan
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse plan '%s': %w", path, err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid plan '%s': %w", path, err)
+	}
+	return &p, nil
+}
+
+func (p *Plan) Validate() error {
+	if p.Version != Version {
+		return fmt.Errorf("unsupported plan version %d (expected %d)", p.Version, Version)
+	}
+
+	for i, download := range p.Downloads {
+		switch {
+		case download.Target == "":
+			return fmt.Errorf("download %d has no target path", i+1)
+		case download.Subtitle == nil || download.Subtitle.FileID == "":
+			return fmt.Errorf("download %d (%s) has no subtitle file", i+1, download.Target)
+		}
+		if _, err := download.Durations(); err != nil {
+			return fmt.Errorf("download %d (%s): %w", i+1, download.Target, err)
+		}
+	}
+	return nil
+}
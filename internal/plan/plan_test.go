@@ -0,0 +1,73 @@
+package plan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "plan.json")
+	p := New()
+	p.Add(Download{
+		MediaPath:   "/media/Movie.mkv",
+		Target:      "/media/Movie.en.srt",
+		Language:    "en",
+		Subtitle:    &models.Subtitle{ID: "1", FileID: "42", Provider: "opensubtitles", ReleaseName: "Movie.2020"},
+		MergeCDs:    true,
+		CDDurations: []string{"52m0s"},
+	})
+	require.NoError(t, p.Save(path))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, p.Downloads, loaded.Downloads)
+	assert.Equal(t, Version, loaded.Version)
+
+	durations, err := loaded.Downloads[0].Durations()
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{52 * time.Minute}, durations)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"target": "/media/Movie.en.srt"`)
+}
+
+func TestLoadInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		content  string
+		errorMsg string
+	}{
+		{name: "not_json", content: "downloads:", errorMsg: "failed to parse plan"},
+		{name: "wrong_version", content: `{"version": 7}`, errorMsg: "unsupported plan version 7"},
+		{name: "missing_target", content: `{"version": 1, "downloads": [{"subtitle": {"file_id": "1"}}]}`, errorMsg: "download 1 has no target path"},
+		{name: "missing_file", content: `{"version": 1, "downloads": [{"target": "a.srt", "subtitle": {}}]}`, errorMsg: "download 1 (a.srt) has no subtitle file"},
+		{name: "bad_duration", content: `{"version": 1, "downloads": [{"target": "a.srt", "subtitle": {"file_id": "1"}, "cd_durations": ["long"]}]}`, errorMsg: "invalid CD duration 'long'"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "plan.json")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+
+			_, err := Load(path)
+			assert.ErrorContains(t, err, tt.errorMsg)
+		})
+	}
+
+	_, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorContains(t, err, "failed to read plan")
+}
@@ -0,0 +1,162 @@
+package probe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+const (
+	DefaultBinary = "ffprobe"
+
+	FPSTolerance = 0.01
+
+	DefaultOverrun  = 30 * time.Second
+	DefaultCoverage = 0.8
+)
+
+type Limits struct {
+	Overrun  time.Duration
+	Coverage float64
+}
+
+var DefaultLimits = Limits{Overrun: DefaultOverrun, Coverage: DefaultCoverage}
+
+type Info struct {
+	Duration time.Duration
+	FPS      float64
+}
+
+type Prober struct {
+	Binary string
+
+	run func(ctx context.Context, name string, args ...string) ([]byte, error)
+}
+
+func New(binary string) *Prober {
+	if binary == "" {
+		binary = DefaultBinary
+	}
+	return &Prober{Binary: binary, run: runCommand}
+}
+
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	var stderr strings.Builder
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil && stderr.Len() > 0 {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return out, err
+}
+
+func (p *Prober) Probe(ctx context.Context, path string) (*Info, error) {
+	out, err := p.run(ctx, p.Binary,
+		"-v", "error",
+		"-select_streams", "v:0",
+		"-show_entries", "stream=avg_frame_rate,r_frame_rate:format=duration",
+		"-of", "json",
+		path)
+	if errors.Is(err, exec.ErrNotFound) {
+		return nil, fmt.Errorf("%s not found; install FFmpeg or set probe.ffprobe in the config file", p.Binary)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s failed: %w", p.Binary, err)
+	}
+	return parseOutput(out)
+}
+
+func parseOutput(out []byte) (*Info, error) {
+	var result struct {
+		Streams []struct {
+			AvgFrameRate string `json:"avg_frame_rate"`
+			RFrameRate   string `json:"r_frame_rate"`
+		} `json:"streams"`
+		Format struct {
+			Duration string `json:"duration"`
+		} `json:"format"`
+	}
+	if err := json.Unmarshal(out, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse ffprobe output: %w", err)
+	}
+
+	info := &Info{}
+	if seconds, err := strconv.ParseFloat(result.Format.Duration, 64); err == nil && seconds > 0 {
+		info.Duration = time.Duration(seconds * float64(time.Second))
+	}
+	if len(result.Streams) > 0 {
+		info.FPS = parseRate(result.Streams[0].AvgFrameRate)
+		if info.FPS == 0 {
+			info.FPS = parseRate(result.Streams[0].RFrameRate)
+		}
+	}
+
+	if info.Duration == 0 && info.FPS == 0 {
+		return nil, fmt.Errorf("ffprobe reported no duration or frame rate")
+	}
+	return info, nil
+}
+
+func parseRate(rate string) float64 {
+	num, den, found := strings.Cut(rate, "/")
+	n, err := strconv.ParseFloat(num, 64)
+	if err != nil || n <= 0 {
+		return 0
+	}
+	if !found {
+		return n
+	}
+	d, err := strconv.ParseFloat(den, 64)
+	if err != nil || d <= 0 {
+		return 0
+	}
+	return math.Round(n/d*1000) / 1000
+}
+
+func (i *Info) MatchesFPS(fps float64) bool {
+	if i == nil || i.FPS == 0 || fps == 0 {
+		return true
+	}
+	return math.Abs(i.FPS-fps) <= FPSTolerance
+}
+
+func (i *Info) Rank(subtitles []*models.Subtitle) ([]*models.Subtitle, int) {
+	ranked := make([]*models.Subtitle, 0, len(subtitles))
+	var mismatched []*models.Subtitle
+	for _, subtitle := range subtitles {
+		if i.MatchesFPS(subtitle.FPS) {
+			ranked = append(ranked, subtitle)
+		} else {
+			mismatched = append(mismatched, subtitle)
+		}
+	}
+	return append(ranked, mismatched...), len(mismatched)
+}
+
+func (i *Info) CheckLength(end time.Duration, limits Limits) error {
+	if i == nil || i.Duration == 0 || end == 0 {
+		return nil
+	}
+
+	switch {
+	case end > i.Duration+limits.Overrun:
+		return fmt.Errorf("last subtitle at %s is after the end of the %s video", formatDuration(end), formatDuration(i.Duration))
+	case float64(end) < float64(i.Duration)*limits.Coverage:
+		return fmt.Errorf("last subtitle at %s leaves the last %s of the %s video without subtitles", formatDuration(end), formatDuration(i.Duration-end), formatDuration(i.Duration))
+	}
+	return nil
+}
+
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
@@ -0,0 +1,101 @@
+package probe
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbe(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		output   string
+		err      error
+		expected *Info
+		errorMsg string
+	}{
+		{
+			name:     "movie",
+			output:   `{"streams": [{"r_frame_rate": "24000/1001", "avg_frame_rate": "24000/1001"}], "format": {"duration": "5423.500000"}}`,
+			expected: &Info{Duration: 5423500 * time.Millisecond, FPS: 23.976},
+		},
+		{
+			name:     "average_rate_missing",
+			output:   `{"streams": [{"r_frame_rate": "25/1", "avg_frame_rate": "0/0"}], "format": {"duration": "60"}}`,
+			expected: &Info{Duration: time.Minute, FPS: 25},
+		},
+		{name: "no_video", output: `{"streams": [], "format": {}}`, errorMsg: "no duration or frame rate"},
+		{name: "garbage", output: `not json`, errorMsg: "failed to parse ffprobe output"},
+		{name: "missing_binary", err: exec.ErrNotFound, errorMsg: "ffprobe not found"},
+		{name: "failure", err: errors.New("exit status 1: Invalid data"), errorMsg: "ffprobe failed: exit status 1: Invalid data"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var args []string
+			p := New("")
+			p.run = func(ctx context.Context, name string, a ...string) ([]byte, error) {
+				args = append([]string{name}, a...)
+				return []byte(tt.output), tt.err
+			}
+
+			info, err := p.Probe(context.Background(), "/media/Movie.mkv")
+			assert.Equal(t, "ffprobe", args[0])
+			assert.Equal(t, "/media/Movie.mkv", args[len(args)-1])
+			if tt.errorMsg != "" {
+				assert.ErrorContains(t, err, tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, info)
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	t.Parallel()
+
+	subtitles := []*models.Subtitle{
+		{ID: "pal", FPS: 25},
+		{ID: "film", FPS: 23.976},
+		{ID: "unknown"},
+		{ID: "rounded", FPS: 23.98},
+	}
+
+	info := &Info{FPS: 23.976}
+	ranked, mismatched := info.Rank(subtitles)
+	assert.Equal(t, 1, mismatched)
+	assert.Equal(t, []string{"film", "unknown", "rounded", "pal"}, []string{ranked[0].ID, ranked[1].ID, ranked[2].ID, ranked[3].ID})
+
+	var missing *Info
+	ranked, mismatched = missing.Rank(subtitles)
+	assert.Zero(t, mismatched)
+	assert.Equal(t, subtitles, ranked)
+}
+
+func TestCheckLength(t *testing.T) {
+	t.Parallel()
+
+	info := &Info{Duration: 100 * time.Minute}
+	assert.NoError(t, info.CheckLength(95*time.Minute, DefaultLimits))
+	assert.NoError(t, info.CheckLength(100*time.Minute+20*time.Second, DefaultLimits))
+	assert.NoError(t, info.CheckLength(0, DefaultLimits))
+	assert.EqualError(t, info.CheckLength(125*time.Minute, DefaultLimits), "last subtitle at 2:05:00 is after the end of the 1:40:00 video")
+	assert.EqualError(t, info.CheckLength(60*time.Minute, DefaultLimits), "last subtitle at 1:00:00 leaves the last 0:40:00 of the 1:40:00 video without subtitles")
+	assert.NoError(t, (&Info{FPS: 25}).CheckLength(time.Hour, DefaultLimits))
+
+	strict := Limits{Overrun: time.Second, Coverage: 0.97}
+	assert.Error(t, info.CheckLength(100*time.Minute+20*time.Second, strict))
+	assert.Error(t, info.CheckLength(95*time.Minute, strict))
+}
@@ -0,0 +1,140 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+const defaultWidth = 30
+
+type Bar struct {
+	mu      sync.Mutex
+	w       io.Writer
+	label   string
+	total   int64
+	current int64
+	width   int
+	bytes   bool
+	enabled bool
+	done    bool
+}
+
+func New(w io.Writer, label string, total int64, enabled bool) *Bar {
+	return &Bar{
+		w:       w,
+		label:   label,
+		total:   total,
+		width:   defaultWidth,
+		enabled: enabled,
+	}
+}
+
+func NewBytes(w io.Writer, label string, total int64, enabled bool) *Bar {
+	b := New(w, label, total, enabled)
+	b.bytes = true
+	return b
+}
+
+func (b *Bar) Add(n int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.current += n
+	b.render()
+}
+
+func (b *Bar) Set(current, total int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.current = current
+	if total > 0 {
+		b.total = total
+	}
+	b.render()
+}
+
+func (b *Bar) Finish() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.enabled || b.done {
+		return
+	}
+	b.done = true
+	fmt.Fprintln(b.w)
+}
+
+func (b *Bar) Current() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.current
+}
+
+func (b *Bar) render() {
+	if !b.enabled || b.done {
+		return
+	}
+	fmt.Fprintf(b.w, "\r\033[K%s", b.String())
+}
+
+func (b *Bar) String() string {
+	filled := 0
+	percent := 0.0
+	if b.total > 0 {
+		percent = float64(b.current) / float64(b.total)
+		if percent > 1 {
+			percent = 1
+		}
+		filled = int(percent * float64(b.width))
+	}
+
+	bar := strings.Repeat("#", filled) + strings.Repeat("-", b.width-filled)
+
+	var counts string
+	if b.bytes {
+		if b.total > 0 {
+			counts = fmt.Sprintf("%s/%s", FormatBytes(b.current), FormatBytes(b.total))
+		} else {
+			counts = FormatBytes(b.current)
+		}
+	} else {
+		counts = fmt.Sprintf("%d/%d", b.current, b.total)
+	}
+
+	return fmt.Sprintf("%s [%s] %3.0f%% %s", b.label, bar, percent*100, counts)
+}
+
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+type Reader struct {
+	r   io.Reader
+	bar *Bar
+}
+
+func NewReader(r io.Reader, bar *Bar) *Reader {
+	return &Reader{r: r, bar: bar}
+}
+
+func (r *Reader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.bar.Add(int64(n))
+	}
+	return n, err
+}
@@ -0,0 +1,100 @@
+package progress
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBar(t *testing.T) {
+	t.Parallel()
+
+	t.Run("renders count progress", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		bar := New(&buf, "Files", 4, true)
+		bar.Add(1)
+		bar.Add(1)
+
+		assert.Contains(t, buf.String(), "Files [###############---------------]  50% 2/4")
+	})
+
+	t.Run("renders byte progress", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		bar := NewBytes(&buf, "movie.srt", 2048, true)
+		bar.Set(1024, 0)
+
+		assert.Contains(t, buf.String(), "1.0 KiB/2.0 KiB")
+	})
+
+	t.Run("clamps overflow to 100 percent", func(t *testing.T) {
+		t.Parallel()
+
+		bar := New(io.Discard, "Files", 1, true)
+		bar.Add(5)
+
+		assert.Contains(t, bar.String(), "100%")
+	})
+
+	t.Run("disabled bar writes nothing", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		bar := New(&buf, "Files", 2, false)
+		bar.Add(1)
+		bar.Finish()
+
+		assert.Empty(t, buf.String())
+		assert.Equal(t, int64(1), bar.Current())
+	})
+
+	t.Run("finish ends line once", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		bar := New(&buf, "Files", 1, true)
+		bar.Add(1)
+		bar.Finish()
+		bar.Finish()
+		bar.Add(1)
+
+		assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+	})
+}
+
+func TestReader(t *testing.T) {
+	t.Parallel()
+
+	bar := NewBytes(io.Discard, "download", 11, true)
+	data, err := io.ReadAll(NewReader(strings.NewReader("hello world"), bar))
+
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(data))
+	assert.Equal(t, int64(11), bar.Current())
+}
+
+func TestFormatBytes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    int64
+		expected string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{1024, "1.0 KiB"},
+		{1536, "1.5 KiB"},
+		{5 * 1024 * 1024, "5.0 MiB"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, FormatBytes(tt.input))
+	}
+}
@@ -0,0 +1,394 @@
+package rar
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"strings"
+)
+
+var (
+	signature4 = []byte("Rar!\x1a\x07\x00")
+	signature5 = []byte("Rar!\x1a\x07\x01\x00")
+
+	ErrNotArchive = errors.New("not a RAR archive")
+	ErrEncrypted  = errors.New("RAR archive headers are encrypted")
+	ErrNoMatch    = errors.New("no matching file in RAR archive")
+
+	errCorrupt = errors.New("corrupt RAR header")
+)
+
+type entry struct {
+	name      string
+	size      int64
+	stored    bool
+	dir       bool
+	encrypted bool
+	continued bool
+	more      bool
+	offset    int64
+	packed    int64
+}
+
+type segment struct {
+	volume string
+	offset int64
+	size   int64
+}
+
+type File struct {
+	Name    string
+	Size    int64
+	Stored  bool
+	Volumes []string
+
+	segments []segment
+}
+
+func Open(first string, match func(name string) bool) (*File, error) {
+	entries, err := readEntries(first)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", first, err)
+	}
+
+	var found *entry
+	for i := range entries {
+		e := &entries[i]
+		if !e.dir && !e.continued && match(path.Base(e.name)) {
+			found = e
+			break
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%s: %w", first, ErrNoMatch)
+	}
+
+	f := &File{
+		Name:     path.Base(found.name),
+		Size:     found.size,
+		Stored:   found.stored && !found.encrypted,
+		Volumes:  []string{first},
+		segments: []segment{{volume: first, offset: found.offset, size: found.packed}},
+	}
+
+	more, volume := found.more, first
+	for more {
+		volume = nextVolume(volume)
+		if _, err := os.Stat(volume); err != nil {
+			break
+		}
+		entries, err := readEntries(volume)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", volume, err)
+		}
+
+		more = false
+		for _, e := range entries {
+			if e.continued && e.name == found.name {
+				f.Volumes = append(f.Volumes, volume)
+				f.segments = append(f.segments, segment{volume: volume, offset: e.offset, size: e.packed})
+				more = e.more
+				break
+			}
+		}
+	}
+	return f, nil
+}
+
+func (f *File) Complete() bool {
+	var total int64
+	for _, s := range f.segments {
+		total += s.size
+	}
+	return f.Stored && total == f.Size
+}
+
+func (f *File) ReadAt(p []byte, off int64) (int, error) {
+	if !f.Stored {
+		return 0, fmt.Errorf("%s is compressed inside the RAR archive and cannot be read without extracting", f.Name)
+	}
+
+	n := 0
+	for _, s := range f.segments {
+		if len(p) == 0 {
+			break
+		}
+		if off >= s.size {
+			off -= s.size
+			continue
+		}
+
+		chunk := p[:min(int64(len(p)), s.size-off)]
+		read, err := readVolume(s.volume, chunk, s.offset+off)
+		n += read
+		if err != nil {
+			return n, err
+		}
+		p, off = p[read:], 0
+	}
+	if len(p) > 0 {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func readVolume(volume string, p []byte, off int64) (int, error) {
+	v, err := os.Open(volume)
+	if err != nil {
+		return 0, err
+	}
+	defer v.Close()
+	return v.ReadAt(p, off)
+}
+
+func readEntries(name string) ([]entry, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	head := make([]byte, len(signature5))
+	if _, err := f.ReadAt(head, 0); err != nil && err != io.EOF {
+		return nil, err
+	}
+	switch {
+	case bytes.HasPrefix(head, signature5):
+		return readEntries5(f, info.Size())
+	case bytes.HasPrefix(head, signature4):
+		return readEntries4(f, info.Size())
+	}
+	return nil, ErrNotArchive
+}
+
+func readEntries4(f io.ReaderAt, size int64) ([]entry, error) {
+	const (
+		typeMain = 0x73
+		typeFile = 0x74
+		typeEnd  = 0x7b
+
+		flagSplitBefore = 0x01
+		flagSplitAfter  = 0x02
+		flagPassword    = 0x04
+		flagLarge       = 0x100
+		flagDirectory   = 0xe0
+		flagLongBlock   = 0x8000
+		mainPassword    = 0x80
+		methodStore     = 0x30
+	)
+
+	var entries []entry
+	pos := int64(len(signature4))
+	for pos+7 <= size {
+		base := make([]byte, 7)
+		if _, err := f.ReadAt(base, pos); err != nil {
+			return nil, err
+		}
+		kind, flags, headSize := base[2], binary.LittleEndian.Uint16(base[3:]), int64(binary.LittleEndian.Uint16(base[5:]))
+		if headSize < 7 {
+			return nil, errCorrupt
+		}
+
+		header := make([]byte, headSize)
+		if _, err := f.ReadAt(header, pos); err != nil {
+			return nil, errCorrupt
+		}
+		var extra int64
+		if flags&flagLongBlock != 0 && headSize >= 11 {
+			extra = int64(binary.LittleEndian.Uint32(header[7:]))
+		}
+
+		switch kind {
+		case typeMain:
+			if flags&mainPassword != 0 {
+				return nil, ErrEncrypted
+			}
+		case typeFile:
+			if headSize < 32 {
+				return nil, errCorrupt
+			}
+			packed := int64(binary.LittleEndian.Uint32(header[7:]))
+			unpacked := int64(binary.LittleEndian.Uint32(header[11:]))
+			method := header[25]
+			nameSize := int64(binary.LittleEndian.Uint16(header[26:]))
+			nameAt := int64(32)
+			if flags&flagLarge != 0 {
+				if headSize < 40 {
+					return nil, errCorrupt
+				}
+				packed |= int64(binary.LittleEndian.Uint32(header[32:])) << 32
+				unpacked |= int64(binary.LittleEndian.Uint32(header[36:])) << 32
+				nameAt = 40
+			}
+			if nameAt+nameSize > headSize {
+				return nil, errCorrupt
+			}
+
+			name := header[nameAt : nameAt+nameSize]
+			if i := bytes.IndexByte(name, 0); i >= 0 {
+				name = name[:i]
+			}
+			entries = append(entries, entry{
+				name:      strings.ReplaceAll(string(name), "\\", "/"),
+				size:      unpacked,
+				stored:    method == methodStore,
+				dir:       flags&flagDirectory == flagDirectory,
+				encrypted: flags&flagPassword != 0,
+				continued: flags&flagSplitBefore != 0,
+				more:      flags&flagSplitAfter != 0,
+				offset:    pos + headSize,
+				packed:    packed,
+			})
+			extra = packed
+		case typeEnd:
+			return entries, nil
+		}
+		pos += headSize + extra
+	}
+	return entries, nil
+}
+
+func readEntries5(f io.ReaderAt, size int64) ([]entry, error) {
+	const (
+		maxHeadSize = 2 << 20
+
+		typeFile       = 2
+		typeEncryption = 4
+		typeEnd        = 5
+
+		flagExtra       = 0x01
+		flagData        = 0x02
+		flagSplitBefore = 0x08
+		flagSplitAfter  = 0x10
+
+		fileDirectory = 0x01
+		fileTime      = 0x02
+		fileCRC       = 0x04
+
+		extraEncryption = 0x01
+	)
+
+	var entries []entry
+	pos := int64(len(signature5))
+	for pos+5 <= size {
+		prefix := make([]byte, 4+binary.MaxVarintLen64)
+		n, err := f.ReadAt(prefix, pos)
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		headSize, width := binary.Uvarint(prefix[4:n])
+		if width <= 0 || headSize == 0 || headSize > maxHeadSize {
+			return nil, errCorrupt
+		}
+
+		start := pos + 4 + int64(width)
+		if headSize > uint64(size-start) {
+			return nil, errCorrupt
+		}
+		next := start + int64(headSize)
+		header := make([]byte, headSize)
+		if _, err := f.ReadAt(header, start); err != nil {
+			return nil, errCorrupt
+		}
+
+		r := &vintReader{b: header}
+		kind, flags := r.vint(), r.vint()
+		var extraSize, dataSize uint64
+		if flags&flagExtra != 0 {
+			extraSize = r.vint()
+		}
+		if flags&flagData != 0 {
+			dataSize = r.vint()
+		}
+		if dataSize > uint64(math.MaxInt64-next) {
+			return nil, errCorrupt
+		}
+
+		switch kind {
+		case typeEncryption:
+			return nil, ErrEncrypted
+		case typeFile:
+			fileFlags, unpacked := r.vint(), r.vint()
+			r.vint()
+			if fileFlags&fileTime != 0 {
+				r.skip(4)
+			}
+			if fileFlags&fileCRC != 0 {
+				r.skip(4)
+			}
+			compression := r.vint()
+			r.vint()
+			name := r.bytes(r.vint())
+			if r.err || extraSize > uint64(len(header)) {
+				return nil, errCorrupt
+			}
+
+			entries = append(entries, entry{
+				name:      strings.ReplaceAll(string(name), "\\", "/"),
+				size:      int64(unpacked),
+				stored:    (compression>>7)&0x7 == 0,
+				dir:       fileFlags&fileDirectory != 0,
+				encrypted: hasRecord(header[uint64(len(header))-extraSize:], extraEncryption),
+				continued: flags&flagSplitBefore != 0,
+				more:      flags&flagSplitAfter != 0,
+				offset:    next,
+				packed:    int64(dataSize),
+			})
+		case typeEnd:
+			return entries, nil
+		}
+		pos = next + int64(dataSize)
+	}
+	return entries, nil
+}
+
+func hasRecord(extra []byte, kind uint64) bool {
+	r := &vintReader{b: extra}
+	for len(r.b) > 0 && !r.err {
+		size := r.vint()
+		record := &vintReader{b: r.bytes(size)}
+		if record.vint() == kind && !record.err {
+			return true
+		}
+	}
+	return false
+}
+
+type vintReader struct {
+	b   []byte
+	err bool
+}
+
+func (r *vintReader) vint() uint64 {
+	v, n := binary.Uvarint(r.b)
+	if n <= 0 {
+		r.err, r.b = true, nil
+		return 0
+	}
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *vintReader) bytes(n uint64) []byte {
+	if n > uint64(len(r.b)) {
+		r.err, r.b = true, nil
+		return nil
+	}
+	b := r.b[:n]
+	r.b = r.b[n:]
+	return b
+}
+
+func (r *vintReader) skip(n uint64) {
+	r.bytes(n)
+}
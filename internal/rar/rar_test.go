@@ -0,0 +1,279 @@
+package rar
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type member struct {
+	name       string
+	data       []byte
+	size       int
+	compressed bool
+	before     bool
+	after      bool
+}
+
+func rar4(members ...member) []byte {
+	var buf bytes.Buffer
+	buf.Write(signature4)
+	buf.Write([]byte{0, 0, 0x73, 0, 0, 13, 0, 0, 0, 0, 0, 0, 0})
+
+	for _, m := range members {
+		flags := uint16(0x8000)
+		if m.before {
+			flags |= 0x01
+		}
+		if m.after {
+			flags |= 0x02
+		}
+		method := byte(0x30)
+		if m.compressed {
+			method = 0x33
+		}
+		size := m.size
+		if size == 0 {
+			size = len(m.data)
+		}
+
+		header := make([]byte, 32, 32+len(m.name))
+		header[2] = 0x74
+		binary.LittleEndian.PutUint16(header[3:], flags)
+		binary.LittleEndian.PutUint16(header[5:], uint16(32+len(m.name)))
+		binary.LittleEndian.PutUint32(header[7:], uint32(len(m.data)))
+		binary.LittleEndian.PutUint32(header[11:], uint32(size))
+		header[25] = method
+		binary.LittleEndian.PutUint16(header[26:], uint16(len(m.name)))
+		buf.Write(append(header, m.name...))
+		buf.Write(m.data)
+	}
+
+	buf.Write([]byte{0, 0, 0x7b, 0, 0x40, 7, 0})
+	return buf.Bytes()
+}
+
+func rar5(members ...member) []byte {
+	var buf bytes.Buffer
+	buf.Write(signature5)
+	block5(&buf, []byte{1, 0, 0})
+
+	for _, m := range members {
+		flags := uint64(0x02)
+		if m.before {
+			flags |= 0x08
+		}
+		if m.after {
+			flags |= 0x10
+		}
+		var compression uint64
+		if m.compressed {
+			compression = 3 << 7
+		}
+		size := m.size
+		if size == 0 {
+			size = len(m.data)
+		}
+
+		var header []byte
+		for _, v := range []uint64{2, flags, uint64(len(m.data)), 0, uint64(size), 0, compression, 0, uint64(len(m.name))} {
+			header = binary.AppendUvarint(header, v)
+		}
+		block5(&buf, append(header, m.name...))
+		buf.Write(m.data)
+	}
+
+	block5(&buf, []byte{5, 0, 0})
+	return buf.Bytes()
+}
+
+func block5(buf *bytes.Buffer, header []byte) {
+	buf.Write([]byte{0, 0, 0, 0})
+	buf.Write(binary.AppendUvarint(nil, uint64(len(header))))
+	buf.Write(header)
+}
+
+func write(t *testing.T, dir, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, data, 0644))
+	return path
+}
+
+func isVideo(name string) bool {
+	return strings.HasSuffix(name, ".mkv")
+}
+
+func TestOpen(t *testing.T) {
+	video := bytes.Repeat([]byte("0123456789"), 100)
+
+	for name, build := range map[string]func(...member) []byte{"rar4": rar4, "rar5": rar5} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := write(t, dir, "release.rar", build(
+				member{name: "release.nfo", data: []byte("info")},
+				member{name: "Release\\Show.S01E01.mkv", data: video},
+			))
+
+			f, err := Open(path, isVideo)
+			require.NoError(t, err)
+			assert.Equal(t, "Show.S01E01.mkv", f.Name)
+			assert.Equal(t, int64(len(video)), f.Size)
+			assert.True(t, f.Complete())
+
+			buf := make([]byte, 20)
+			n, err := f.ReadAt(buf, 995)
+			assert.Equal(t, 5, n)
+			assert.Error(t, err)
+			assert.Equal(t, "56789", string(buf[:n]))
+
+			n, err = f.ReadAt(buf, 10)
+			require.NoError(t, err)
+			assert.Equal(t, string(video[10:30]), string(buf[:n]))
+		})
+	}
+}
+
+func TestOpen_MultiVolume(t *testing.T) {
+	video := bytes.Repeat([]byte("abcdefghij"), 30)
+
+	t.Run("old_naming", func(t *testing.T) {
+		dir := t.TempDir()
+		first := write(t, dir, "show.rar", rar4(member{name: "show.mkv", data: video[:100], size: len(video), after: true}))
+		write(t, dir, "show.r00", rar4(member{name: "show.mkv", data: video[100:200], size: len(video), before: true, after: true}))
+		write(t, dir, "show.r01", rar4(member{name: "show.mkv", data: video[200:], size: len(video), before: true}))
+
+		f, err := Open(first, isVideo)
+		require.NoError(t, err)
+		assert.Len(t, f.Volumes, 3)
+		assert.True(t, f.Complete())
+
+		buf := make([]byte, 150)
+		n, err := f.ReadAt(buf, 90)
+		require.NoError(t, err)
+		assert.Equal(t, string(video[90:240]), string(buf[:n]))
+	})
+
+	t.Run("part_naming", func(t *testing.T) {
+		dir := t.TempDir()
+		first := write(t, dir, "show.part01.rar", rar5(member{name: "show.mkv", data: video[:150], size: len(video), after: true}))
+		write(t, dir, "show.part02.rar", rar5(member{name: "show.mkv", data: video[150:], size: len(video), before: true}))
+
+		f, err := Open(first, isVideo)
+		require.NoError(t, err)
+		assert.Equal(t, []string{first, filepath.Join(dir, "show.part02.rar")}, f.Volumes)
+
+		buf := make([]byte, len(video))
+		_, err = f.ReadAt(buf, 0)
+		require.NoError(t, err)
+		assert.Equal(t, video, buf)
+	})
+
+	t.Run("missing_volume", func(t *testing.T) {
+		dir := t.TempDir()
+		first := write(t, dir, "show.rar", rar4(member{name: "show.mkv", data: video[:100], size: len(video), after: true}))
+
+		f, err := Open(first, isVideo)
+		require.NoError(t, err)
+		assert.False(t, f.Complete())
+	})
+}
+
+func TestOpen_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("compressed", func(t *testing.T) {
+		path := write(t, dir, "packed.rar", rar5(member{name: "show.mkv", data: []byte("packed"), size: 5000, compressed: true}))
+
+		f, err := Open(path, isVideo)
+		require.NoError(t, err)
+		assert.Equal(t, int64(5000), f.Size)
+		assert.False(t, f.Complete())
+		_, err = f.ReadAt(make([]byte, 4), 0)
+		assert.ErrorContains(t, err, "compressed")
+	})
+
+	t.Run("no_video", func(t *testing.T) {
+		path := write(t, dir, "subs.rar", rar4(member{name: "show.idx", data: []byte("idx")}))
+
+		_, err := Open(path, isVideo)
+		assert.ErrorIs(t, err, ErrNoMatch)
+	})
+
+	t.Run("not_rar", func(t *testing.T) {
+		path := write(t, dir, "fake.rar", []byte("PK\x03\x04 not a rar"))
+
+		_, err := Open(path, isVideo)
+		assert.ErrorIs(t, err, ErrNotArchive)
+	})
+
+	t.Run("encrypted_headers", func(t *testing.T) {
+		var buf bytes.Buffer
+		buf.Write(signature5)
+		block5(&buf, []byte{4, 0, 0, 0})
+		path := write(t, dir, "locked.rar", buf.Bytes())
+
+		_, err := Open(path, isVideo)
+		assert.ErrorIs(t, err, ErrEncrypted)
+	})
+
+	t.Run("corrupt_headers", func(t *testing.T) {
+		huge := binary.AppendUvarint(nil, 1<<63)
+		tests := map[string][]byte{
+			"header_size_out_of_range": binary.AppendUvarint([]byte{0, 0, 0, 0}, 1<<63),
+			"header_size_over_limit":   binary.AppendUvarint([]byte{0, 0, 0, 0}, 3<<20),
+			"truncated_header":         append(binary.AppendUvarint([]byte{0, 0, 0, 0}, 64), 2, 0),
+			"data_size_overflow":       append(binary.AppendUvarint([]byte{0, 0, 0, 0}, uint64(2+len(huge))), append([]byte{3, 0x02}, huge...)...),
+		}
+		for name, block := range tests {
+			t.Run(name, func(t *testing.T) {
+				var buf bytes.Buffer
+				buf.Write(signature5)
+				block5(&buf, []byte{1, 0, 0})
+				buf.Write(block)
+				buf.Write(binary.AppendUvarint([]byte{0, 0, 0, 0}, 3))
+				buf.Write([]byte{5, 0, 0})
+				path := write(t, dir, name+".rar", buf.Bytes())
+
+				_, err := Open(path, isVideo)
+				assert.ErrorIs(t, err, errCorrupt)
+			})
+		}
+	})
+}
+
+func TestIsFirstVolume(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"show.rar", true},
+		{"Show.RAR", true},
+		{"show.part1.rar", true},
+		{"show.part01.rar", true},
+		{"show.part02.rar", false},
+		{"show.r00", false},
+		{"show.mkv", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsFirstVolume(tt.name))
+		})
+	}
+}
+
+func TestNextVolume(t *testing.T) {
+	assert.Equal(t, "show.r00", nextVolume("show.rar"))
+	assert.Equal(t, "SHOW.R00", nextVolume("SHOW.RAR"))
+	assert.Equal(t, "show.r01", nextVolume("show.r00"))
+	assert.Equal(t, "show.s00", nextVolume("show.r99"))
+	assert.Equal(t, "show.part02.rar", nextVolume("show.part01.rar"))
+	assert.Equal(t, "show.part10.rar", nextVolume("show.part9.rar"))
+}
@@ -0,0 +1,52 @@
+package rar
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var partPattern = regexp.MustCompile(`(?i)^(.*\.part)(\d+)(\.rar)$`)
+
+func IsArchive(name string) bool {
+	return strings.EqualFold(filepath.Ext(name), ".rar")
+}
+
+func IsFirstVolume(name string) bool {
+	if !IsArchive(name) {
+		return false
+	}
+	if m := partPattern.FindStringSubmatch(name); m != nil {
+		n, err := strconv.Atoi(m[2])
+		return err == nil && n == 1
+	}
+	return true
+}
+
+func nextVolume(name string) string {
+	if m := partPattern.FindStringSubmatch(name); m != nil {
+		n, _ := strconv.Atoi(m[2])
+		return fmt.Sprintf("%s%0*d%s", m[1], len(m[2]), n+1, m[3])
+	}
+
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	if strings.EqualFold(ext, ".rar") {
+		return base + ext[:2] + "00"
+	}
+
+	if len(ext) != 4 {
+		return ""
+	}
+	n, err := strconv.Atoi(ext[2:])
+	if err != nil {
+		return ""
+	}
+	letter := ext[1]
+	if n == 99 {
+		letter, n = letter+1, -1
+	}
+	return fmt.Sprintf("%s.%c%02d", base, letter, n+1)
+}
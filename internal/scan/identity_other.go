@@ -0,0 +1,20 @@
+//go:build !unix
+
+package scan
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func fileIdentity(path string, info os.FileInfo) (string, bool) {
+	real, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", false
+	}
+	abs, err := filepath.Abs(real)
+	if err != nil {
+		return "", false
+	}
+	return abs, true
+}
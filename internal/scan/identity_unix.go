@@ -0,0 +1,17 @@
+//go:build unix
+
+package scan
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+func fileIdentity(path string, info os.FileInfo) (string, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("%d:%d", stat.Dev, stat.Ino), true
+}
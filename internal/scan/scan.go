@@ -0,0 +1,196 @@
+package scan
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/internal/fsutil"
+)
+
+type Options struct {
+	Extensions     map[string]bool
+	Recursive      bool
+	Include        []string
+	Exclude        []string
+	ExcludeDirs    []string
+	MinSize        Size
+	NewerThan      time.Time
+	FollowSymlinks bool
+	StatBatch      int
+	StatPause      time.Duration
+	Retries        int
+	RetryDelay     time.Duration
+}
+
+func (o Options) Validate() error {
+	for _, patterns := range [][]string{o.Include, o.Exclude, o.ExcludeDirs} {
+		for _, pattern := range patterns {
+			if _, err := path.Match(strings.ToLower(pattern), ""); err != nil {
+				return fmt.Errorf("invalid pattern '%s': %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+func Find(root string, opts Options) ([]string, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	w := &walker{opts: opts, files: []string{}, visited: map[string]bool{}}
+	if opts.FollowSymlinks {
+		w.visit(root)
+	}
+	if err := w.walk(root, "", nil); err != nil {
+		return nil, err
+	}
+	return w.files, nil
+}
+
+type walker struct {
+	opts    Options
+	files   []string
+	visited map[string]bool
+	stats   int
+}
+
+func (w *walker) throttle() {
+	if w.opts.StatBatch <= 0 {
+		return
+	}
+	w.stats++
+	if w.stats%w.opts.StatBatch == 0 {
+		time.Sleep(w.opts.StatPause)
+	}
+}
+
+func (w *walker) readDir(dir string) ([]os.DirEntry, error) {
+	w.throttle()
+	entries, err := os.ReadDir(fsutil.LongPath(dir))
+	for attempt := 0; attempt < w.opts.Retries && fsutil.Transient(err); attempt++ {
+		time.Sleep(w.opts.RetryDelay)
+		entries, err = os.ReadDir(fsutil.LongPath(dir))
+	}
+	return entries, err
+}
+
+func (w *walker) visit(path string) bool {
+	w.throttle()
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+
+	id, ok := fileIdentity(path, info)
+	if !ok {
+		return true
+	}
+	if w.visited[id] {
+		return false
+	}
+	w.visited[id] = true
+	return true
+}
+
+func (w *walker) walk(dir, rel string, ignores []*ignoreFile) error {
+	entries, err := w.readDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	ignore, err := loadIgnoreFile(filepath.Join(dir, IgnoreFileName), rel)
+	if err != nil {
+		return err
+	}
+	if ignore != nil {
+		ignores = append(ignores[:len(ignores):len(ignores)], ignore)
+	}
+
+	opts := w.opts
+	for _, entry := range entries {
+		name := entry.Name()
+		if fsutil.CheckName(name) != nil {
+			continue
+		}
+		full := filepath.Join(dir, name)
+		entryRel := path.Join(rel, name)
+
+		isDir := entry.IsDir()
+		if entry.Type()&os.ModeSymlink != 0 {
+			w.throttle()
+			info, err := os.Stat(full)
+			if err != nil || (info.IsDir() && !opts.FollowSymlinks) {
+				continue
+			}
+			isDir = info.IsDir()
+		}
+		if ignored(ignores, entryRel, isDir) {
+			continue
+		}
+
+		if isDir {
+			if !opts.Recursive || matchAny(opts.ExcludeDirs, entryRel) {
+				continue
+			}
+			if opts.FollowSymlinks && !w.visit(full) {
+				continue
+			}
+			if err := w.walk(full, entryRel, ignores); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if opts.Extensions != nil && !opts.Extensions[strings.ToLower(filepath.Ext(name))] {
+			continue
+		}
+		if len(opts.Include) > 0 && !matchAny(opts.Include, entryRel) {
+			continue
+		}
+		if matchAny(opts.Exclude, entryRel) {
+			continue
+		}
+		if !w.fresh(full) {
+			continue
+		}
+		if opts.FollowSymlinks && !w.visit(full) {
+			continue
+		}
+		w.files = append(w.files, full)
+	}
+	return nil
+}
+
+func (w *walker) fresh(path string) bool {
+	o := w.opts
+	if o.MinSize <= 0 && o.NewerThan.IsZero() {
+		return true
+	}
+
+	w.throttle()
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return info.Size() >= int64(o.MinSize) && !info.ModTime().Before(o.NewerThan)
+}
+
+func matchAny(patterns []string, rel string) bool {
+	rel = strings.ToLower(rel)
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		target := path.Base(rel)
+		if strings.Contains(pattern, "/") {
+			target = rel
+		}
+		if ok, _ := path.Match(pattern, target); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,223 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var videoExtensions = map[string]bool{".mkv": true, ".mp4": true, ".avi": true}
+
+func createTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return root
+}
+
+func relative(t *testing.T, root string, files []string) []string {
+	t.Helper()
+
+	rel := make([]string, 0, len(files))
+	for _, file := range files {
+		r, err := filepath.Rel(root, file)
+		require.NoError(t, err)
+		rel = append(rel, filepath.ToSlash(r))
+	}
+	return rel
+}
+
+func TestFind(t *testing.T) {
+	t.Parallel()
+
+	root := createTree(t, map[string]string{
+		"Movie.mkv":                    "",
+		"Movie.sample.mkv":             "",
+		"Movie.MP4":                    "",
+		"notes.txt":                    "",
+		"Show/S01E01.mkv":              "",
+		"Show/Extras/Interview.mkv":    "",
+		"Show/Season 2/S02E01.avi":     "",
+		"Show/Season 2/extras/BTS.mkv": "",
+	})
+
+	tests := []struct {
+		name     string
+		opts     Options
+		expected []string
+	}{
+		{
+			name:     "top_level_only",
+			opts:     Options{},
+			expected: []string{"Movie.MP4", "Movie.mkv", "Movie.sample.mkv"},
+		},
+		{
+			name: "recursive",
+			opts: Options{Recursive: true},
+			expected: []string{
+				"Movie.MP4", "Movie.mkv", "Movie.sample.mkv",
+				"Show/Extras/Interview.mkv", "Show/S01E01.mkv", "Show/Season 2/S02E01.avi", "Show/Season 2/extras/BTS.mkv",
+			},
+		},
+		{
+			name:     "include_and_exclude",
+			opts:     Options{Recursive: true, Include: []string{"*.mkv"}, Exclude: []string{"*sample*"}, ExcludeDirs: []string{"extras"}},
+			expected: []string{"Movie.mkv", "Show/S01E01.mkv"},
+		},
+		{
+			name:     "relative_path_patterns",
+			opts:     Options{Recursive: true, Exclude: []string{"Show/*.mkv"}, ExcludeDirs: []string{"Show/Season 2"}},
+			expected: []string{"Movie.MP4", "Movie.mkv", "Movie.sample.mkv", "Show/Extras/Interview.mkv"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.opts.Extensions = videoExtensions
+			files, err := Find(root, tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, relative(t, root, files))
+		})
+	}
+
+	_, err := Find(root, Options{Exclude: []string{"[abc"}})
+	assert.ErrorContains(t, err, "invalid pattern '[abc'")
+
+	_, err = Find(filepath.Join(root, "missing"), Options{})
+	assert.ErrorContains(t, err, "failed to read directory")
+}
+
+func TestFindSizeAndAge(t *testing.T) {
+	t.Parallel()
+
+	root := createTree(t, map[string]string{
+		"Movie.mkv":        "0123456789",
+		"Movie.sample.mkv": "01",
+		"Old.mkv":          "0123456789",
+	})
+	old := time.Now().Add(-30 * 24 * time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(root, "Old.mkv"), old, old))
+
+	files, err := Find(root, Options{Extensions: videoExtensions, MinSize: 5})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Movie.mkv", "Old.mkv"}, relative(t, root, files))
+
+	files, err = Find(root, Options{Extensions: videoExtensions, MinSize: 5, NewerThan: time.Now().Add(-7 * 24 * time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Movie.mkv"}, relative(t, root, files))
+}
+
+func TestFindThrottled(t *testing.T) {
+	t.Parallel()
+
+	root := createTree(t, map[string]string{
+		"A.mkv":     "0123456789",
+		"B.mkv":     "0123456789",
+		"Sub/C.mkv": "0123456789",
+	})
+
+	start := time.Now()
+	files, err := Find(root, Options{Extensions: videoExtensions, Recursive: true, MinSize: 5, StatBatch: 2, StatPause: 20 * time.Millisecond, Retries: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A.mkv", "B.mkv", "Sub/C.mkv"}, relative(t, root, files))
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond, "two readdirs and three stats pause twice")
+}
+
+func TestFindSymlinks(t *testing.T) {
+	t.Parallel()
+
+	library := createTree(t, map[string]string{
+		"Movies/Movie.mkv":    "",
+		"Shows/Show/E01.mkv":  "",
+		"Shows/Show/E02.mkv":  "",
+		"Farm/Favourite.mkv":  "",
+		"Farm/Show/Extra.txt": "",
+	})
+	farm := filepath.Join(library, "Farm")
+	require.NoError(t, os.Symlink(filepath.Join(library, "Movies"), filepath.Join(farm, "Movies")))
+	require.NoError(t, os.Symlink(filepath.Join(library, "Movies"), filepath.Join(farm, "Movies Again")))
+	require.NoError(t, os.Symlink(filepath.Join(library, "Shows", "Show", "E01.mkv"), filepath.Join(farm, "Show", "E01.mkv")))
+	require.NoError(t, os.Symlink(filepath.Join(library, "Shows", "Show", "E01.mkv"), filepath.Join(farm, "Show", "Pilot.mkv")))
+	require.NoError(t, os.Symlink(farm, filepath.Join(farm, "Show", "Loop")))
+	require.NoError(t, os.Symlink(filepath.Join(library, "missing.mkv"), filepath.Join(farm, "Dangling.mkv")))
+
+	files, err := Find(farm, Options{Recursive: true, Extensions: videoExtensions})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Favourite.mkv", "Show/E01.mkv", "Show/Pilot.mkv"}, relative(t, farm, files))
+
+	files, err = Find(farm, Options{Recursive: true, Extensions: videoExtensions, FollowSymlinks: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Favourite.mkv", "Movies/Movie.mkv", "Show/E01.mkv"}, relative(t, farm, files))
+}
+
+func TestFindIgnoreFile(t *testing.T) {
+	t.Parallel()
+
+	root := createTree(t, map[string]string{
+		IgnoreFileName:                "# skip samples and extras\n*sample*\nExtras/\n/Trailers\n!keep.sample.mkv\n",
+		"Movie.mkv":                   "",
+		"Movie.sample.mkv":            "",
+		"keep.sample.mkv":             "",
+		"Extras/Interview.mkv":        "",
+		"Trailers/Teaser.mkv":         "",
+		"Show/Trailers/Promo.mkv":     "",
+		"Show/Extras.mkv":             "",
+		"Show/" + IgnoreFileName:      "Season 1/**/*.avi\n",
+		"Show/Season 1/S01E01.avi":    "",
+		"Show/Season 1/S01E02.mkv":    "",
+		"Show/Season 1/cd/S01E03.avi": "",
+		"Other/Season 1/S01E01.avi":   "",
+	})
+
+	files, err := Find(root, Options{Recursive: true, Extensions: videoExtensions})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"Movie.mkv",
+		"Other/Season 1/S01E01.avi",
+		"Show/Extras.mkv",
+		"Show/Season 1/S01E02.mkv",
+		"Show/Trailers/Promo.mkv",
+		"keep.sample.mkv",
+	}, relative(t, root, files))
+}
+
+func TestCompileIgnorePattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{"*.mkv", "a/b/movie.mkv", true},
+		{"*.mkv", "movie.mkv.part", false},
+		{"/root.mkv", "root.mkv", true},
+		{"/root.mkv", "sub/root.mkv", false},
+		{"docs/*.mkv", "docs/a.mkv", true},
+		{"docs/*.mkv", "docs/x/a.mkv", false},
+		{"**/cd?/*.srt", "a/b/cd1/x.srt", true},
+		{"extras/**", "extras/a/b.mkv", true},
+		{"movie[0-9].mkv", "movie7.mkv", true},
+		{"movie[!0-9].mkv", "movie7.mkv", false},
+		{`\#hash.mkv`, "#hash.mkv", true},
+	}
+
+	for _, tt := range tests {
+		rules, err := parseIgnore([]byte(tt.pattern))
+		require.NoError(t, err)
+		require.Len(t, rules, 1)
+		assert.Equal(t, tt.match, rules[0].pattern.MatchString(tt.path), "%s vs %s", tt.pattern, tt.path)
+	}
+}
@@ -0,0 +1,119 @@
+package scan
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Size int64
+
+var (
+	sizePattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([a-z]*)$`)
+	agePattern  = regexp.MustCompile(`(\d+(?:\.\d+)?)([a-zµ]+)`)
+)
+
+var sizeUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"k":   1e3,
+	"kb":  1e3,
+	"m":   1e6,
+	"mb":  1e6,
+	"g":   1e9,
+	"gb":  1e9,
+	"t":   1e12,
+	"tb":  1e12,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+}
+
+func ParseSize(value string) (Size, error) {
+	match := sizePattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(value)))
+	if match == nil {
+		return 0, fmt.Errorf("invalid size '%s': expected a number with an optional unit, e.g. 200MB or 1.5GiB", value)
+	}
+
+	unit, ok := sizeUnits[match[2]]
+	if !ok {
+		return 0, fmt.Errorf("invalid size '%s': unknown unit '%s' (use B, KB, MB, GB, TB or KiB, MiB, GiB, TiB)", value, match[2])
+	}
+	number, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size '%s': %w", value, err)
+	}
+	return Size(number * unit), nil
+}
+
+func (s *Size) UnmarshalText(text []byte) error {
+	size, err := ParseSize(string(text))
+	if err != nil {
+		return err
+	}
+	*s = size
+	return nil
+}
+
+func (s Size) String() string {
+	units := []string{"B", "KB", "MB", "GB", "TB"}
+	value, unit := float64(s), 0
+	for value >= 1000 && unit < len(units)-1 {
+		value /= 1000
+		unit++
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64) + units[unit]
+}
+
+type Age time.Duration
+
+func ParseAge(value string) (Age, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	invalid := fmt.Errorf("invalid age '%s': expected a duration like 12h, 7d or 2w", value)
+
+	if agePattern.ReplaceAllString(value, "") != "" || value == "" {
+		return 0, invalid
+	}
+
+	var total time.Duration
+	for _, match := range agePattern.FindAllStringSubmatch(value, -1) {
+		switch match[2] {
+		case "d", "w":
+			number, err := strconv.ParseFloat(match[1], 64)
+			if err != nil {
+				return 0, invalid
+			}
+			day := 24 * time.Hour
+			if match[2] == "w" {
+				day *= 7
+			}
+			total += time.Duration(number * float64(day))
+		default:
+			duration, err := time.ParseDuration(match[0])
+			if err != nil {
+				return 0, invalid
+			}
+			total += duration
+		}
+	}
+	if total <= 0 {
+		return 0, invalid
+	}
+	return Age(total), nil
+}
+
+func (a *Age) UnmarshalText(text []byte) error {
+	age, err := ParseAge(string(text))
+	if err != nil {
+		return err
+	}
+	*a = age
+	return nil
+}
+
+func (a Age) String() string {
+	return time.Duration(a).String()
+}
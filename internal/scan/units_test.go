@@ -0,0 +1,74 @@
+package scan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value    string
+		expected Size
+		errorMsg string
+	}{
+		{value: "200MB", expected: 200_000_000},
+		{value: "1.5 GiB", expected: 1536 << 20},
+		{value: "700m", expected: 700_000_000},
+		{value: "512", expected: 512},
+		{value: "10XB", errorMsg: "unknown unit 'xb'"},
+		{value: "-1MB", errorMsg: "invalid size '-1MB'"},
+		{value: "", errorMsg: "invalid size"},
+	}
+
+	for _, tt := range tests {
+		size, err := ParseSize(tt.value)
+		if tt.errorMsg != "" {
+			assert.ErrorContains(t, err, tt.errorMsg, tt.value)
+			continue
+		}
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.expected, size, tt.value)
+	}
+
+	assert.Equal(t, "200MB", Size(200_000_000).String())
+	assert.Equal(t, "1.5GB", Size(1_500_000_000).String())
+}
+
+func TestParseAge(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value    string
+		expected time.Duration
+		errorMsg string
+	}{
+		{value: "7d", expected: 7 * 24 * time.Hour},
+		{value: "2w", expected: 14 * 24 * time.Hour},
+		{value: "1d12h", expected: 36 * time.Hour},
+		{value: "90m", expected: 90 * time.Minute},
+		{value: "0.5d", expected: 12 * time.Hour},
+		{value: "3q", errorMsg: "invalid age '3q'"},
+		{value: "7 days", errorMsg: "invalid age"},
+		{value: "0d", errorMsg: "invalid age"},
+		{value: "", errorMsg: "invalid age"},
+	}
+
+	for _, tt := range tests {
+		age, err := ParseAge(tt.value)
+		if tt.errorMsg != "" {
+			assert.ErrorContains(t, err, tt.errorMsg, tt.value)
+			continue
+		}
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.expected, time.Duration(age), tt.value)
+	}
+
+	var age Age
+	require.NoError(t, age.UnmarshalText([]byte("1w")))
+	assert.Equal(t, "168h0m0s", age.String())
+}
@@ -0,0 +1,110 @@
+package score
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	SourceCam      = "cam"
+	SourceTelesync = "telesync"
+	SourceTelecine = "telecine"
+	SourceScreener = "screener"
+	SourceDVD      = "dvd"
+	SourceHDTV     = "hdtv"
+	SourceWeb      = "web"
+	SourceBluRay   = "bluray"
+)
+
+var Sources = []string{SourceCam, SourceTelesync, SourceTelecine, SourceScreener, SourceDVD, SourceHDTV, SourceWeb, SourceBluRay}
+
+var (
+	sourceTokens = map[string]string{
+		"cam": SourceCam, "camrip": SourceCam, "hdcam": SourceCam,
+		"ts": SourceTelesync, "telesync": SourceTelesync, "hdts": SourceTelesync, "pdvd": SourceTelesync,
+		"tc": SourceTelecine, "telecine": SourceTelecine, "hdtc": SourceTelecine,
+		"scr": SourceScreener, "screener": SourceScreener, "dvdscr": SourceScreener, "bdscr": SourceScreener,
+		"dvd": SourceDVD, "dvdrip": SourceDVD, "dvdr": SourceDVD, "dvd5": SourceDVD, "dvd9": SourceDVD,
+		"hdtv": SourceHDTV, "pdtv": SourceHDTV, "sdtv": SourceHDTV,
+		"web": SourceWeb, "webrip": SourceWeb, "webdl": SourceWeb,
+		"bluray": SourceBluRay, "bdrip": SourceBluRay, "brrip": SourceBluRay, "bdremux": SourceBluRay, "remux": SourceBluRay,
+	}
+	fileExtensions = map[string]bool{
+		".mkv": true, ".mp4": true, ".avi": true, ".m4v": true, ".mov": true, ".wmv": true,
+		".srt": true, ".ass": true, ".ssa": true, ".sub": true, ".vtt": true, ".txt": true,
+	}
+	audioTokens = map[string]string{
+		"ddp": "ddp", "eac3": "ddp", "dd": "dd", "ac3": "dd",
+		"dts": "dts", "truehd": "truehd", "aac": "aac", "flac": "flac", "opus": "opus",
+	}
+	audioChannels = regexp.MustCompile(`^([a-z]+\d?)(\d)$`)
+	notGroups     = map[string]bool{"dl": true, "rip": true, "hd": true, "sd": true}
+	tokenPattern  = regexp.MustCompile(`[\p{L}\d]+`)
+	groupPrefix   = regexp.MustCompile(`^\[([^\]\s]+)\]`)
+	groupSuffix   = regexp.MustCompile(`-([\p{L}\d]+)(?:\[[^\]]*\])?$`)
+)
+
+type Release struct {
+	Group  string
+	Source string
+	Tokens []string
+}
+
+func ParseRelease(name string) Release {
+	name = strings.TrimSpace(name)
+	if ext := filepath.Ext(name); fileExtensions[strings.ToLower(ext)] {
+		name = strings.TrimSuffix(name, ext)
+	}
+
+	var release Release
+	tokens := tokenPattern.FindAllString(name, -1)
+	for i := 0; i < len(tokens); i++ {
+		token := strings.ToLower(tokens[i])
+		if audio, channels, next := audioToken(tokens, i); audio != "" {
+			release.Tokens = append(release.Tokens, audio)
+			if channels != "" {
+				release.Tokens = append(release.Tokens, channels)
+			}
+			i = next
+			continue
+		}
+		release.Tokens = append(release.Tokens, token)
+		if source, ok := sourceTokens[token]; ok && release.Source == "" {
+			release.Source = source
+		}
+	}
+	if release.Source == "" && strings.Contains(strings.ToLower(name), "web-dl") {
+		release.Source = SourceWeb
+	}
+
+	if m := groupSuffix.FindStringSubmatch(name); m != nil && !notGroups[strings.ToLower(m[1])] && sourceTokens[strings.ToLower(m[1])] == "" {
+		release.Group = m[1]
+	} else if m := groupPrefix.FindStringSubmatch(name); m != nil {
+		release.Group = m[1]
+	}
+	return release
+}
+
+func audioToken(tokens []string, i int) (audio, channels string, next int) {
+	token := strings.ToLower(tokens[i])
+	first := ""
+	if m := audioChannels.FindStringSubmatch(token); m != nil && audioTokens[m[1]] != "" {
+		token, first = m[1], m[2]
+	}
+	audio = audioTokens[token]
+	if audio == "" {
+		return "", "", i
+	}
+	if first == "" && i+2 < len(tokens) && isDigit(tokens[i+1]) && isDigit(tokens[i+2]) {
+		return audio, tokens[i+1] + "." + tokens[i+2], i + 2
+	}
+	if first != "" && i+1 < len(tokens) && isDigit(tokens[i+1]) {
+		return audio, first + "." + tokens[i+1], i + 1
+	}
+	return audio, "", i
+}
+
+func isDigit(token string) bool {
+	return len(token) == 1 && token[0] >= '0' && token[0] <= '9'
+}
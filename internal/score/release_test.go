@@ -0,0 +1,52 @@
+package score
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRelease(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		input  string
+		group  string
+		source string
+	}{
+		{"group suffix", "Inception.2010.1080p.BluRay.x264-SPARKS.mkv", "SPARKS", SourceBluRay},
+		{"no extension", "The.Office.S03E07.720p.HDTV.x264-LOL", "LOL", SourceHDTV},
+		{"web-dl without group", "Series.Name.1x01.720p.WEB-DL", "", SourceWeb},
+		{"bracket prefix", "[SubsPlease] Sousou no Frieren - 05 (1080p) [F02B9CEE].mkv", "SubsPlease", ""},
+		{"cam source", "Movie.2023.HDCAM.x264-GRP", "GRP", SourceCam},
+		{"telesync source", "Movie.2023.TS.XviD-NOGRP.avi", "NOGRP", SourceTelesync},
+		{"dotted name keeps tokens", "Movie.2023.WEBRip", "", SourceWeb},
+		{"empty", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			release := ParseRelease(tt.input)
+			assert.Equal(t, tt.group, release.Group)
+			assert.Equal(t, tt.source, release.Source)
+		})
+	}
+}
+
+func TestParseRelease_Tokens(t *testing.T) {
+	t.Parallel()
+
+	release := ParseRelease("Inception.2010.1080p.BluRay.x264-SPARKS.srt")
+	assert.Equal(t, []string{"inception", "2010", "1080p", "bluray", "x264", "sparks"}, release.Tokens)
+}
+
+func TestParseRelease_AudioTokens(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"show", "s01e01", "web", "dl", "ddp", "5.1", "h", "264", "ntb"}, ParseRelease("Show.S01E01.WEB-DL.DDP5.1.H.264-NTb.mkv").Tokens)
+	assert.Equal(t, []string{"show", "s01e01", "web", "dl", "ddp", "5.1", "h", "264", "ntb"}, ParseRelease("Show.S01E01.WEB-DL.EAC3.5.1.H.264-NTb.mkv").Tokens, "aliases of the same codec match")
+	assert.Equal(t, []string{"movie", "2010", "bluray", "aac", "2.0", "x264"}, ParseRelease("Movie.2010.BluRay.AAC2.0.x264").Tokens)
+}
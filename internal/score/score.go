@@ -0,0 +1,201 @@
+package score
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+const (
+	preferredGroupBonus  = 25
+	avoidedGroupPenalty  = 50
+	preferredSourceBonus = 10
+	avoidedSourcePenalty = 50
+
+	fpsTolerance = 0.01
+)
+
+const (
+	FactorHash            = "hash"
+	FactorRelease         = "release"
+	FactorGroup           = "group"
+	FactorTrusted         = "trusted"
+	FactorDownloads       = "downloads"
+	FactorRating          = "rating"
+	FactorFPS             = "fps"
+	FactorPreferredGroup  = "preferred group"
+	FactorAvoidedGroup    = "avoided group"
+	FactorPreferredSource = "preferred source"
+	FactorAvoidedSource   = "avoided source"
+)
+
+type Weights struct {
+	Hash      int `yaml:"hash"`
+	Release   int `yaml:"release"`
+	Group     int `yaml:"group"`
+	Trusted   int `yaml:"trusted"`
+	Downloads int `yaml:"downloads"`
+	Rating    int `yaml:"rating"`
+	FPS       int `yaml:"fps"`
+}
+
+func DefaultWeights() Weights {
+	return Weights{
+		Hash:      100,
+		Release:   40,
+		Group:     30,
+		Trusted:   10,
+		Downloads: 5,
+		Rating:    10,
+		FPS:       20,
+	}
+}
+
+func (w Weights) Named() map[string]int {
+	return map[string]int{
+		FactorHash:      w.Hash,
+		FactorRelease:   w.Release,
+		FactorGroup:     w.Group,
+		FactorTrusted:   w.Trusted,
+		FactorDownloads: w.Downloads,
+		FactorRating:    w.Rating,
+		FactorFPS:       w.FPS,
+	}
+}
+
+type Preferences struct {
+	PreferGroups  []string
+	AvoidGroups   []string
+	PreferSources []string
+	AvoidSources  []string
+}
+
+type Local struct {
+	Release string
+	FPS     float64
+}
+
+type Factor struct {
+	Name   string `json:"name"`
+	Points int    `json:"points"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type Breakdown struct {
+	Total         int      `json:"total"`
+	Factors       []Factor `json:"factors"`
+	MatchedTokens []string `json:"matched_tokens,omitempty"`
+}
+
+func (b *Breakdown) add(name string, points int, detail string) {
+	if points == 0 {
+		return
+	}
+	b.Total += points
+	b.Factors = append(b.Factors, Factor{Name: name, Points: points, Detail: detail})
+}
+
+type Scorer struct {
+	weights Weights
+	prefs   Preferences
+	local   Release
+	fps     float64
+}
+
+func New(weights Weights, prefs Preferences, local Local) *Scorer {
+	scorer := &Scorer{weights: weights, prefs: prefs, fps: local.FPS}
+	if local.Release != "" {
+		scorer.local = ParseRelease(local.Release)
+	}
+	return scorer
+}
+
+func (s *Scorer) Score(subtitle *models.Subtitle) int {
+	return s.Explain(subtitle).Total
+}
+
+func (s *Scorer) Explain(subtitle *models.Subtitle) Breakdown {
+	release := ParseRelease(subtitle.ReleaseName)
+
+	var b Breakdown
+	if subtitle.MovieHashMatch {
+		b.add(FactorHash, s.weights.Hash, "")
+	}
+	if matched, total := s.releaseMatch(release); total > 0 {
+		b.MatchedTokens = matched
+		b.add(FactorRelease, scaled(s.weights.Release, float64(len(matched))/float64(total)), fmt.Sprintf("%d/%d tokens", len(matched), total))
+	}
+	if release.Group != "" {
+		if strings.EqualFold(release.Group, s.local.Group) {
+			b.add(FactorGroup, s.weights.Group, release.Group)
+		}
+		if containsFold(s.prefs.PreferGroups, release.Group) {
+			b.add(FactorPreferredGroup, preferredGroupBonus, release.Group)
+		}
+		if containsFold(s.prefs.AvoidGroups, release.Group) {
+			b.add(FactorAvoidedGroup, -avoidedGroupPenalty, release.Group)
+		}
+	}
+	if release.Source != "" {
+		if slices.Contains(s.prefs.PreferSources, release.Source) {
+			b.add(FactorPreferredSource, preferredSourceBonus, release.Source)
+		}
+		if slices.Contains(s.prefs.AvoidSources, release.Source) {
+			b.add(FactorAvoidedSource, -avoidedSourcePenalty, release.Source)
+		}
+	}
+	if subtitle.FromTrusted {
+		b.add(FactorTrusted, s.weights.Trusted, subtitle.Uploader)
+	}
+	if subtitle.Downloads > 0 {
+		b.add(FactorDownloads, scaled(s.weights.Downloads, math.Log10(float64(subtitle.Downloads)+1)), strconv.Itoa(subtitle.Downloads))
+	}
+	b.add(FactorRating, scaled(s.weights.Rating, subtitle.Rating/10), strconv.FormatFloat(subtitle.Rating, 'f', 1, 64))
+	if s.fps != 0 && subtitle.FPS != 0 && math.Abs(s.fps-subtitle.FPS) <= fpsTolerance {
+		b.add(FactorFPS, s.weights.FPS, strconv.FormatFloat(subtitle.FPS, 'f', 3, 64))
+	}
+	return b
+}
+
+func (s *Scorer) releaseMatch(release Release) ([]string, int) {
+	var wanted []string
+	for _, token := range s.local.Tokens {
+		if !strings.EqualFold(token, s.local.Group) {
+			wanted = append(wanted, token)
+		}
+	}
+
+	var matched []string
+	for _, token := range wanted {
+		if slices.Contains(release.Tokens, token) {
+			matched = append(matched, token)
+		}
+	}
+	return matched, len(wanted)
+}
+
+func (s *Scorer) Rank(subtitles []*models.Subtitle) []*models.Subtitle {
+	scores := make(map[*models.Subtitle]int, len(subtitles))
+	for _, subtitle := range subtitles {
+		scores[subtitle] = s.Score(subtitle)
+	}
+
+	ranked := slices.Clone(subtitles)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return scores[ranked[i]] > scores[ranked[j]]
+	})
+	return ranked
+}
+
+func scaled(weight int, ratio float64) int {
+	return int(math.Round(float64(weight) * ratio))
+}
+
+func containsFold(values []string, value string) bool {
+	return slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, value) })
+}
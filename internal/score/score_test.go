@@ -0,0 +1,105 @@
+package score
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+func TestScorer_Preferences(t *testing.T) {
+	t.Parallel()
+
+	prefs := Preferences{
+		PreferGroups:  []string{"sparks"},
+		AvoidGroups:   []string{"YIFY"},
+		PreferSources: []string{SourceBluRay},
+		AvoidSources:  []string{SourceCam, SourceTelesync},
+	}
+	scorer := New(Weights{Group: 30}, prefs, Local{Release: "Inception.2010.1080p.BluRay.x264-AMIABLE.mkv"})
+
+	tests := []struct {
+		release string
+		want    int
+	}{
+		{"Inception.2010.1080p.BluRay.x264-AMIABLE", 30 + preferredSourceBonus},
+		{"Inception.2010.1080p.BluRay.x264-SPARKS", preferredGroupBonus + preferredSourceBonus},
+		{"Inception.2010.720p.BluRay.x264-YIFY", preferredSourceBonus - avoidedGroupPenalty},
+		{"Inception.2010.HDCAM.x264-GRP", -avoidedSourcePenalty},
+		{"Inception 2010", 0},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, scorer.Score(&models.Subtitle{ReleaseName: tt.release}), tt.release)
+	}
+}
+
+func TestScorer_Explain(t *testing.T) {
+	t.Parallel()
+
+	scorer := New(DefaultWeights(), Preferences{}, Local{Release: "Movie.2023.1080p.WEB-DL.x264-NTb.mkv", FPS: 23.976})
+
+	breakdown := scorer.Explain(&models.Subtitle{
+		ReleaseName:    "Movie.2023.720p.WEB-DL.x264-NTb",
+		MovieHashMatch: true,
+		FromTrusted:    true,
+		Downloads:      999,
+		Rating:         8,
+		FPS:            23.976,
+	})
+	assert.Equal(t, []Factor{
+		{Name: FactorHash, Points: 100},
+		{Name: FactorRelease, Points: 33, Detail: "5/6 tokens"},
+		{Name: FactorGroup, Points: 30, Detail: "NTb"},
+		{Name: FactorTrusted, Points: 10},
+		{Name: FactorDownloads, Points: 15, Detail: "999"},
+		{Name: FactorRating, Points: 8, Detail: "8.0"},
+		{Name: FactorFPS, Points: 20, Detail: "23.976"},
+	}, breakdown.Factors)
+	assert.Equal(t, []string{"movie", "2023", "web", "dl", "x264"}, breakdown.MatchedTokens)
+	assert.Equal(t, 216, breakdown.Total)
+
+	breakdown = scorer.Explain(&models.Subtitle{ReleaseName: "Other.Show", FPS: 25})
+	assert.Empty(t, breakdown.Factors)
+	assert.Zero(t, breakdown.Total)
+}
+
+func TestScorer_Weights(t *testing.T) {
+	t.Parallel()
+
+	subtitle := &models.Subtitle{ReleaseName: "Movie.2023.1080p.WEB-DL", MovieHashMatch: true, Downloads: 99}
+	local := Local{Release: "Movie.2023.1080p.WEB-DL.mkv"}
+
+	assert.Equal(t, 150, New(DefaultWeights(), Preferences{}, local).Score(subtitle))
+	assert.Equal(t, 40, New(Weights{Release: 40}, Preferences{}, local).Score(subtitle))
+	assert.Equal(t, 20, New(Weights{Downloads: 10}, Preferences{}, local).Score(subtitle))
+}
+
+func TestScorer_Rank(t *testing.T) {
+	t.Parallel()
+
+	subtitles := []*models.Subtitle{
+		{ID: "cam", ReleaseName: "Movie.2023.CAM.x264-FAST"},
+		{ID: "plain", ReleaseName: "Movie.2023.1080p.WEB-DL"},
+		{ID: "other", ReleaseName: "Movie.2023.1080p.WEB-DL.x264-OTHER"},
+		{ID: "same", ReleaseName: "Movie.2023.1080p.WEB-DL.x264-NTb"},
+	}
+
+	scorer := New(DefaultWeights(), Preferences{AvoidSources: []string{SourceCam}}, Local{Release: "Movie.2023.1080p.WEB-DL.x264-NTb.mkv"})
+	ranked := scorer.Rank(subtitles)
+
+	var ids []string
+	for _, subtitle := range ranked {
+		ids = append(ids, subtitle.ID)
+	}
+	assert.Equal(t, []string{"same", "other", "plain", "cam"}, ids)
+	assert.Equal(t, "cam", subtitles[0].ID)
+}
+
+func TestScorer_NoLocalRelease(t *testing.T) {
+	t.Parallel()
+
+	scorer := New(DefaultWeights(), Preferences{}, Local{})
+	assert.Zero(t, scorer.Score(&models.Subtitle{ReleaseName: "Movie.2023.BluRay-GROUP", FPS: 25}))
+}
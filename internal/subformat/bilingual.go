@@ -0,0 +1,138 @@
+package subformat
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const SecondaryColor = "#ffd75f"
+
+var htmlTag = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+
+type timedCue struct {
+	start time.Duration
+	end   time.Duration
+	text  string
+}
+
+func Bilingual(primary, secondary []byte, format string) ([]byte, error) {
+	primaryCues, err := timedSRTCues(primary)
+	if err != nil {
+		return nil, fmt.Errorf("primary subtitle: %w", err)
+	}
+	secondaryCues, err := timedSRTCues(secondary)
+	if err != nil {
+		return nil, fmt.Errorf("secondary subtitle: %w", err)
+	}
+
+	switch format {
+	case "", SRT:
+		return bilingualSRT(primaryCues, secondaryCues), nil
+	case ASS:
+		return bilingualASS(primaryCues, secondaryCues), nil
+	}
+	return nil, fmt.Errorf("unsupported bilingual format %q (use srt or ass)", format)
+}
+
+func timedSRTCues(content []byte) ([]timedCue, error) {
+	if format := Detect(content); format != SRT {
+		return nil, fmt.Errorf("not SubRip (detected %q)", format)
+	}
+
+	var cues []timedCue
+	for _, cue := range Cues(content, SRT, 0) {
+		start, err := ParseSRTTime(cue.Start)
+		if err != nil {
+			return nil, err
+		}
+		end, err := ParseSRTTime(cue.End)
+		if err != nil {
+			return nil, err
+		}
+		cues = append(cues, timedCue{start: start, end: end, text: strings.TrimSpace(cue.Text)})
+	}
+	return cues, nil
+}
+
+func bilingualSRT(primary, secondary []timedCue) []byte {
+	merged := make([]timedCue, 0, len(primary)+len(secondary))
+	extra := make([][]string, len(primary))
+	for _, cue := range secondary {
+		if i := bestOverlap(primary, cue); i >= 0 {
+			extra[i] = append(extra[i], cue.text)
+			continue
+		}
+		merged = append(merged, timedCue{start: cue.start, end: cue.end, text: secondaryText(cue.text)})
+	}
+	for i, cue := range primary {
+		text := cue.text
+		if len(extra[i]) > 0 {
+			text += "\n" + secondaryText(strings.Join(extra[i], "\n"))
+		}
+		merged = append(merged, timedCue{start: cue.start, end: cue.end, text: text})
+	}
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].start < merged[j].start })
+
+	var buf bytes.Buffer
+	for i, cue := range merged {
+		fmt.Fprintf(&buf, "%d\n%s --> %s\n%s\n\n", i+1, FormatSRTTime(cue.start), FormatSRTTime(cue.end), cue.text)
+	}
+	return buf.Bytes()
+}
+
+func bestOverlap(cues []timedCue, target timedCue) int {
+	best, bestOverlap := -1, time.Duration(0)
+	for i, cue := range cues {
+		overlap := min(cue.end, target.end) - max(cue.start, target.start)
+		if overlap > bestOverlap {
+			best, bestOverlap = i, overlap
+		}
+	}
+	return best
+}
+
+func secondaryText(text string) string {
+	return fmt.Sprintf(`<font color="%s"><i>%s</i></font>`, SecondaryColor, htmlTag.ReplaceAllString(text, ""))
+}
+
+const assHeader = `[Script Info]
+ScriptType: v4.00+
+PlayResX: 1920
+PlayResY: 1080
+WrapStyle: 0
+
+[V4+ Styles]
+Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, Bold, Italic, Underline, StrikeOut, ScaleX, ScaleY, Spacing, Angle, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, Encoding
+Style: Primary,Arial,64,&H00FFFFFF,&H000000FF,&H00000000,&H80000000,0,0,0,0,100,100,0,0,1,3,1,2,60,60,110,1
+Style: Secondary,Arial,52,&H005FD7FF,&H000000FF,&H00000000,&H80000000,0,1,0,0,100,100,0,0,1,3,1,2,60,60,40,1
+
+[Events]
+Format: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text
+`
+
+func bilingualASS(primary, secondary []timedCue) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(assHeader)
+	for _, cue := range primary {
+		writeASSDialogue(&buf, cue, "Primary")
+	}
+	for _, cue := range secondary {
+		writeASSDialogue(&buf, cue, "Secondary")
+	}
+	return buf.Bytes()
+}
+
+func writeASSDialogue(buf *bytes.Buffer, cue timedCue, style string) {
+	text := htmlTag.ReplaceAllString(cue.text, "")
+	text = strings.ReplaceAll(text, "\n", `\N`)
+	fmt.Fprintf(buf, "Dialogue: 0,%s,%s,%s,,0,0,0,,%s\n", formatASSTime(cue.start), formatASSTime(cue.end), style, text)
+}
+
+func formatASSTime(d time.Duration) string {
+	cs := d.Milliseconds() / 10
+	return fmt.Sprintf("%d:%02d:%02d.%02d", cs/360000, cs/6000%60, cs/100%60, cs%100)
+}
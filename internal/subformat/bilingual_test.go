@@ -0,0 +1,46 @@
+package subformat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBilingual(t *testing.T) {
+	t.Parallel()
+
+	primary := []byte("1\n00:00:01,000 --> 00:00:03,000\nOlá!\n\n2\n00:00:05,000 --> 00:00:07,000\n<i>Tudo bem?</i>\n")
+	secondary := []byte("1\n00:00:01,200 --> 00:00:02,900\nHello!\n\n2\n00:00:04,000 --> 00:00:04,500\n[music]\n\n3\n00:00:05,100 --> 00:00:06,000\n<i>How are</i>\n\n4\n00:00:06,000 --> 00:00:07,000\nyou?\n")
+
+	t.Run("srt", func(t *testing.T) {
+		t.Parallel()
+
+		merged, err := Bilingual(primary, secondary, SRT)
+		require.NoError(t, err)
+		assert.Equal(t, "1\n00:00:01,000 --> 00:00:03,000\nOlá!\n<font color=\"#ffd75f\"><i>Hello!</i></font>\n\n"+
+			"2\n00:00:04,000 --> 00:00:04,500\n<font color=\"#ffd75f\"><i>[music]</i></font>\n\n"+
+			"3\n00:00:05,000 --> 00:00:07,000\n<i>Tudo bem?</i>\n<font color=\"#ffd75f\"><i>How are\nyou?</i></font>\n\n", string(merged))
+		assert.Equal(t, SRT, Detect(merged))
+	})
+
+	t.Run("ass", func(t *testing.T) {
+		t.Parallel()
+
+		merged, err := Bilingual(primary, secondary, ASS)
+		require.NoError(t, err)
+		assert.Equal(t, ASS, Detect(merged))
+		assert.Contains(t, string(merged), "Dialogue: 0,0:00:05.00,0:00:07.00,Primary,,0,0,0,,Tudo bem?\n")
+		assert.Contains(t, string(merged), "Dialogue: 0,0:00:04.00,0:00:04.50,Secondary,,0,0,0,,[music]\n")
+	})
+
+	t.Run("rejects_other_formats", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Bilingual(primary, []byte("WEBVTT\n\n00:00.000 --> 00:01.000\nHi\n"), SRT)
+		assert.EqualError(t, err, `secondary subtitle: not SubRip (detected "vtt")`)
+
+		_, err = Bilingual(primary, secondary, VTT)
+		assert.ErrorContains(t, err, "unsupported bilingual format")
+	})
+}
@@ -0,0 +1,105 @@
+package subformat
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+type Cue struct {
+	Start string
+	End   string
+	Text  string
+}
+
+var (
+	assOverride  = regexp.MustCompile(`\{[^}]*\}`)
+	microDVDLine = regexp.MustCompile(`^\{(\d+)\}\{(\d*)\}(.*)$`)
+)
+
+func Cues(content []byte, format string, limit int) []Cue {
+	text := string(bytes.TrimPrefix(content, utf8BOM))
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	var cues []Cue
+	switch format {
+	case SRT, VTT:
+		cues = timedBlockCues(text, limit)
+	case ASS, SSA:
+		cues = dialogueCues(text, limit)
+	case SUB:
+		cues = microDVDCues(text, limit)
+	}
+	return cues
+}
+
+func timedBlockCues(text string, limit int) []Cue {
+	var cues []Cue
+	for _, block := range strings.Split(text, "\n\n") {
+		lines := strings.Split(strings.TrimSpace(block), "\n")
+		for i, line := range lines {
+			start, rest, ok := strings.Cut(line, "-->")
+			if !ok {
+				continue
+			}
+			end := strings.Fields(rest)
+			if len(end) == 0 {
+				break
+			}
+			cues = append(cues, Cue{
+				Start: strings.TrimSpace(start),
+				End:   end[0],
+				Text:  strings.Join(lines[i+1:], "\n"),
+			})
+			break
+		}
+		if limit > 0 && len(cues) >= limit {
+			break
+		}
+	}
+	return cues
+}
+
+func dialogueCues(text string, limit int) []Cue {
+	var cues []Cue
+	for _, line := range strings.Split(text, "\n") {
+		rest, ok := strings.CutPrefix(strings.TrimSpace(line), "Dialogue:")
+		if !ok {
+			continue
+		}
+		fields := strings.SplitN(rest, ",", 10)
+		if len(fields) < 10 {
+			continue
+		}
+		dialogue := assOverride.ReplaceAllString(fields[9], "")
+		dialogue = strings.NewReplacer(`\N`, "\n", `\n`, "\n", `\h`, " ").Replace(dialogue)
+		cues = append(cues, Cue{
+			Start: strings.TrimSpace(fields[1]),
+			End:   strings.TrimSpace(fields[2]),
+			Text:  strings.TrimSpace(dialogue),
+		})
+		if limit > 0 && len(cues) >= limit {
+			break
+		}
+	}
+	return cues
+}
+
+func microDVDCues(text string, limit int) []Cue {
+	var cues []Cue
+	for _, line := range strings.Split(text, "\n") {
+		match := microDVDLine.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		cues = append(cues, Cue{
+			Start: "frame " + match[1],
+			End:   "frame " + match[2],
+			Text:  strings.ReplaceAll(match[3], "|", "\n"),
+		})
+		if limit > 0 && len(cues) >= limit {
+			break
+		}
+	}
+	return cues
+}
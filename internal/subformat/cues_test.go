@@ -0,0 +1,70 @@
+package subformat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCues(t *testing.T) {
+	t.Parallel()
+
+	t.Run("srt", func(t *testing.T) {
+		t.Parallel()
+
+		content := "\xEF\xBB\xBF1\r\n00:00:01,000 --> 00:00:02,500\r\nHello\r\nthere\r\n\r\n2\r\n00:00:03,000 --> 00:00:04,000\r\nWorld\r\n"
+
+		cues := Cues([]byte(content), SRT, 0)
+
+		require.Len(t, cues, 2)
+		assert.Equal(t, Cue{Start: "00:00:01,000", End: "00:00:02,500", Text: "Hello\nthere"}, cues[0])
+		assert.Equal(t, "World", cues[1].Text)
+	})
+
+	t.Run("vtt with settings", func(t *testing.T) {
+		t.Parallel()
+
+		content := "WEBVTT\n\nintro\n00:00:01.000 --> 00:00:02.500 align:start\nHello\n"
+
+		cues := Cues([]byte(content), VTT, 0)
+
+		require.Len(t, cues, 1)
+		assert.Equal(t, Cue{Start: "00:00:01.000", End: "00:00:02.500", Text: "Hello"}, cues[0])
+	})
+
+	t.Run("ass", func(t *testing.T) {
+		t.Parallel()
+
+		content := "[Events]\nFormat: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text\n" +
+			"Dialogue: 0,0:00:01.00,0:00:02.50,Default,,0,0,0,,{\\i1}Hello,{\\i0}\\Nthere\n"
+
+		cues := Cues([]byte(content), ASS, 0)
+
+		require.Len(t, cues, 1)
+		assert.Equal(t, Cue{Start: "0:00:01.00", End: "0:00:02.50", Text: "Hello,\nthere"}, cues[0])
+	})
+
+	t.Run("microdvd", func(t *testing.T) {
+		t.Parallel()
+
+		cues := Cues([]byte("{100}{200}Hello|World\n"), SUB, 0)
+
+		require.Len(t, cues, 1)
+		assert.Equal(t, Cue{Start: "frame 100", End: "frame 200", Text: "Hello\nWorld"}, cues[0])
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		t.Parallel()
+
+		content := "1\n00:00:01,000 --> 00:00:02,000\nA\n\n2\n00:00:03,000 --> 00:00:04,000\nB\n\n3\n00:00:05,000 --> 00:00:06,000\nC\n"
+
+		assert.Len(t, Cues([]byte(content), SRT, 2), 2)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Empty(t, Cues([]byte("<SAMI></SAMI>"), SAMI, 0))
+	})
+}
@@ -0,0 +1,138 @@
+package subformat
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	DefaultMaxCPS        = 21
+	DefaultMaxLineLength = 42
+
+	minReadingTime = 100 * time.Millisecond
+)
+
+const (
+	IssueTimecode   = "timecode"
+	IssueEmpty      = "empty"
+	IssueOverlap    = "overlap"
+	IssueSpeed      = "speed"
+	IssueLineLength = "line-length"
+)
+
+type LintOptions struct {
+	MaxCPS        float64
+	MaxLineLength int
+}
+
+type Issue struct {
+	Cue     int
+	Start   string
+	Kind    string
+	Message string
+	Fixable bool
+}
+
+type lintCue struct {
+	Cue
+	start, end time.Duration
+	valid      bool
+}
+
+func Lint(content []byte, opts LintOptions) []Issue {
+	cues := lintCues(content)
+
+	var issues []Issue
+	add := func(i int, kind string, fixable bool, format string, args ...any) {
+		issues = append(issues, Issue{Cue: i + 1, Start: cues[i].Start, Kind: kind, Message: fmt.Sprintf(format, args...), Fixable: fixable})
+	}
+
+	previous := -1
+	for i, cue := range cues {
+		text := plainText(cue.Text)
+		if strings.TrimSpace(text) == "" {
+			add(i, IssueEmpty, true, "empty cue")
+			continue
+		}
+		if !cue.valid {
+			add(i, IssueTimecode, false, "invalid timecode '%s --> %s'", cue.Start, cue.End)
+			continue
+		}
+		if cue.end <= cue.start {
+			add(i, IssueTimecode, false, "ends at %s, before it starts", cue.End)
+			continue
+		}
+
+		if previous >= 0 && cues[previous].end > cue.start {
+			add(previous, IssueOverlap, cue.start > cues[previous].start, "overlaps cue %d by %s", i+1, cues[previous].end-cue.start)
+		}
+		previous = i
+
+		if opts.MaxCPS > 0 {
+			duration := max(cue.end-cue.start, minReadingTime)
+			chars := utf8.RuneCountInString(strings.ReplaceAll(text, "\n", ""))
+			if cps := float64(chars) / duration.Seconds(); cps > opts.MaxCPS {
+				add(i, IssueSpeed, false, "reading speed %.1f characters per second (max %g)", cps, opts.MaxCPS)
+			}
+		}
+		if opts.MaxLineLength > 0 {
+			for _, line := range strings.Split(text, "\n") {
+				if n := utf8.RuneCountInString(line); n > opts.MaxLineLength {
+					add(i, IssueLineLength, false, "line is %d characters long (max %d)", n, opts.MaxLineLength)
+					break
+				}
+			}
+		}
+	}
+	return issues
+}
+
+func FixSRT(content []byte) []byte {
+	cues := lintCues(content)
+
+	kept := cues[:0]
+	for _, cue := range cues {
+		if strings.TrimSpace(plainText(cue.Text)) != "" {
+			kept = append(kept, cue)
+		}
+	}
+
+	previous := -1
+	for i := range kept {
+		cue := &kept[i]
+		if !cue.valid || cue.end <= cue.start {
+			continue
+		}
+		if previous >= 0 && kept[previous].end > cue.start && cue.start > kept[previous].start {
+			kept[previous].end = cue.start
+			kept[previous].End = FormatSRTTime(cue.start)
+		}
+		previous = i
+	}
+
+	var buf bytes.Buffer
+	if bytes.HasPrefix(content, utf8BOM) {
+		buf.Write(utf8BOM)
+	}
+	for i, cue := range kept {
+		fmt.Fprintf(&buf, "%d\n%s --> %s\n%s\n\n", i+1, cue.Start, cue.End, cue.Text)
+	}
+	return buf.Bytes()
+}
+
+func lintCues(content []byte) []lintCue {
+	var cues []lintCue
+	for _, cue := range Cues(content, SRT, 0) {
+		start, startErr := ParseSRTTime(cue.Start)
+		end, endErr := ParseSRTTime(cue.End)
+		cues = append(cues, lintCue{Cue: cue, start: start, end: end, valid: startErr == nil && endErr == nil})
+	}
+	return cues
+}
+
+func plainText(text string) string {
+	return assOverride.ReplaceAllString(htmlTag.ReplaceAllString(text, ""), "")
+}
@@ -0,0 +1,73 @@
+package subformat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const lintSample = `1
+00:00:01,000 --> 00:00:03,500
+Hello there
+
+2
+00:00:03,000 --> 00:00:05,000
+<i>Overlapping</i>
+
+3
+00:00:06,000 --> 00:00:06,000
+
+4
+00:00:07,000 --> 00:00:07,500
+This line is far too long to be read in half a second
+
+5
+00:00:09,x --> 00:00:10,000
+Broken
+
+6
+00:00:12,000 --> 00:00:11,000
+Backwards
+`
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+
+	issues := Lint([]byte(lintSample), LintOptions{MaxCPS: DefaultMaxCPS, MaxLineLength: DefaultMaxLineLength})
+
+	kinds := make([]string, len(issues))
+	for i, issue := range issues {
+		kinds[i] = issue.Kind
+	}
+	assert.Equal(t, []string{IssueOverlap, IssueEmpty, IssueSpeed, IssueLineLength, IssueTimecode, IssueTimecode}, kinds)
+
+	assert.Equal(t, Issue{Cue: 1, Start: "00:00:01,000", Kind: IssueOverlap, Message: "overlaps cue 2 by 500ms", Fixable: true}, issues[0])
+	assert.Equal(t, 3, issues[1].Cue)
+	assert.True(t, issues[1].Fixable)
+	assert.Equal(t, "reading speed 106.0 characters per second (max 21)", issues[2].Message)
+	assert.Equal(t, "line is 53 characters long (max 42)", issues[3].Message)
+	assert.Equal(t, "invalid timecode '00:00:09,x --> 00:00:10,000'", issues[4].Message)
+	assert.Equal(t, "ends at 00:00:11,000, before it starts", issues[5].Message)
+
+	assert.Empty(t, Lint([]byte("1\n00:00:01,000 --> 00:00:03,000\nHi\n"), LintOptions{MaxCPS: DefaultMaxCPS, MaxLineLength: DefaultMaxLineLength}))
+}
+
+func TestFixSRT(t *testing.T) {
+	t.Parallel()
+
+	fixed := FixSRT([]byte("\ufeff" + lintSample))
+	expected := "\ufeff1\n00:00:01,000 --> 00:00:03,000\nHello there\n\n" +
+		"2\n00:00:03,000 --> 00:00:05,000\n<i>Overlapping</i>\n\n" +
+		"3\n00:00:07,000 --> 00:00:07,500\nThis line is far too long to be read in half a second\n\n" +
+		"4\n00:00:09,x --> 00:00:10,000\nBroken\n\n" +
+		"5\n00:00:12,000 --> 00:00:11,000\nBackwards\n\n"
+	assert.Equal(t, expected, string(fixed))
+
+	var fixable int
+	for _, issue := range Lint(fixed, LintOptions{}) {
+		if issue.Fixable {
+			fixable++
+		}
+	}
+	assert.Zero(t, fixable)
+}
@@ -0,0 +1,89 @@
+package subformat
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var srtTimestamp = regexp.MustCompile(`^(\d{1,2}):(\d{2}):(\d{2})[,.](\d{1,3})$`)
+
+func ParseSRTTime(value string) (time.Duration, error) {
+	match := srtTimestamp.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return 0, fmt.Errorf("invalid SRT timestamp %q", value)
+	}
+
+	hours, _ := strconv.Atoi(match[1])
+	minutes, _ := strconv.Atoi(match[2])
+	seconds, _ := strconv.Atoi(match[3])
+	millis, _ := strconv.Atoi((match[4] + "00")[:3])
+
+	return time.Duration(hours)*time.Hour +
+		time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second +
+		time.Duration(millis)*time.Millisecond, nil
+}
+
+func FormatSRTTime(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d,%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
+
+func SRTEnd(content []byte) (time.Duration, error) {
+	var end time.Duration
+	for _, cue := range Cues(content, SRT, 0) {
+		t, err := ParseSRTTime(cue.End)
+		if err != nil {
+			return 0, err
+		}
+		if t > end {
+			end = t
+		}
+	}
+	return end, nil
+}
+
+func MergeSRT(parts [][]byte, durations []time.Duration) ([]byte, error) {
+	var buf bytes.Buffer
+	var offset time.Duration
+	index := 0
+
+	for i, part := range parts {
+		if format := Detect(part); format != SRT {
+			return nil, fmt.Errorf("part %d is not SubRip (detected %q)", i+1, format)
+		}
+
+		for _, cue := range Cues(part, SRT, 0) {
+			start, err := ParseSRTTime(cue.Start)
+			if err != nil {
+				return nil, fmt.Errorf("part %d: %w", i+1, err)
+			}
+			end, err := ParseSRTTime(cue.End)
+			if err != nil {
+				return nil, fmt.Errorf("part %d: %w", i+1, err)
+			}
+
+			index++
+			fmt.Fprintf(&buf, "%d\n%s --> %s\n%s\n\n", index, FormatSRTTime(start+offset), FormatSRTTime(end+offset), cue.Text)
+		}
+
+		if i < len(durations) && durations[i] > 0 {
+			offset += durations[i]
+			continue
+		}
+		end, err := SRTEnd(part)
+		if err != nil {
+			return nil, fmt.Errorf("part %d: %w", i+1, err)
+		}
+		offset += end
+	}
+
+	return buf.Bytes(), nil
+}
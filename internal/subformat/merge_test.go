@@ -0,0 +1,63 @@
+package subformat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSRTTime(t *testing.T) {
+	t.Parallel()
+
+	d, err := ParseSRTTime("01:02:03,456")
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour+2*time.Minute+3*time.Second+456*time.Millisecond, d)
+
+	d, err = ParseSRTTime("0:00:01.5")
+	require.NoError(t, err)
+	assert.Equal(t, 1500*time.Millisecond, d)
+
+	_, err = ParseSRTTime("1:2:3")
+	assert.Error(t, err)
+
+	assert.Equal(t, "01:02:03,456", FormatSRTTime(time.Hour+2*time.Minute+3*time.Second+456*time.Millisecond))
+	assert.Equal(t, "00:00:00,000", FormatSRTTime(-time.Second))
+}
+
+func TestMergeSRT(t *testing.T) {
+	t.Parallel()
+
+	cd1 := []byte("1\r\n00:00:01,000 --> 00:00:02,000\r\nFirst\r\n\r\n2\r\n00:45:00,000 --> 00:45:30,500\r\nLast of CD1\r\n")
+	cd2 := []byte("1\n00:00:05,000 --> 00:00:06,000\nSecond part\nline two\n")
+
+	t.Run("offsets_from_last_cue", func(t *testing.T) {
+		t.Parallel()
+
+		merged, err := MergeSRT([][]byte{cd1, cd2}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "1\n00:00:01,000 --> 00:00:02,000\nFirst\n\n"+
+			"2\n00:45:00,000 --> 00:45:30,500\nLast of CD1\n\n"+
+			"3\n00:45:35,500 --> 00:45:36,500\nSecond part\nline two\n\n", string(merged))
+	})
+
+	t.Run("explicit_durations", func(t *testing.T) {
+		t.Parallel()
+
+		merged, err := MergeSRT([][]byte{cd1, cd2}, []time.Duration{50 * time.Minute})
+		require.NoError(t, err)
+		assert.Contains(t, string(merged), "3\n00:50:05,000 --> 00:50:06,000\n")
+	})
+
+	t.Run("rejects_other_formats", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := MergeSRT([][]byte{cd1, []byte("WEBVTT\n\n00:00.000 --> 00:01.000\nHi\n")}, nil)
+		assert.ErrorContains(t, err, "part 2 is not SubRip")
+	})
+
+	end, err := SRTEnd(cd1)
+	require.NoError(t, err)
+	assert.Equal(t, 45*time.Minute+30500*time.Millisecond, end)
+}
@@ -0,0 +1,102 @@
+package subformat
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultMicroDVDDuration = 2 * time.Second
+
+var microDVDTag = regexp.MustCompile(`\{([a-zA-Z]):([^}]*)\}`)
+
+func IsMicroDVD(content []byte) bool {
+	content = bytes.TrimPrefix(content, utf8BOM)
+	if len(content) > sniffLength {
+		content = content[:sniffLength]
+	}
+	return microDVD.Match(content)
+}
+
+func MicroDVDFPS(content []byte) float64 {
+	cues := Cues(content, SUB, 1)
+	if len(cues) == 0 || !isFPSHeader(cues[0]) {
+		return 0
+	}
+	fps, _ := strconv.ParseFloat(strings.TrimSpace(cues[0].Text), 64)
+	return fps
+}
+
+func MicroDVDToSRT(content []byte, fps float64) ([]byte, error) {
+	if header := MicroDVDFPS(content); header > 0 {
+		fps = header
+	}
+	if fps <= 0 {
+		return nil, fmt.Errorf("MicroDVD subtitles need a frame rate to be converted")
+	}
+
+	var buf bytes.Buffer
+	index := 0
+	for _, cue := range Cues(content, SUB, 0) {
+		if isFPSHeader(cue) {
+			continue
+		}
+		startFrame, err := strconv.Atoi(strings.TrimPrefix(cue.Start, "frame "))
+		if err != nil {
+			continue
+		}
+		start := frameTime(startFrame, fps)
+		end := start + defaultMicroDVDDuration
+		if endFrame, err := strconv.Atoi(strings.TrimPrefix(cue.End, "frame ")); err == nil && endFrame > startFrame {
+			end = frameTime(endFrame, fps)
+		}
+
+		index++
+		fmt.Fprintf(&buf, "%d\n%s --> %s\n%s\n\n", index, FormatSRTTime(start), FormatSRTTime(end), microDVDText(cue.Text))
+	}
+	if index == 0 {
+		return nil, fmt.Errorf("no MicroDVD cues found")
+	}
+	return buf.Bytes(), nil
+}
+
+func isFPSHeader(cue Cue) bool {
+	if cue.Start != "frame 0" && cue.Start != "frame 1" {
+		return false
+	}
+	fps, err := strconv.ParseFloat(strings.TrimSpace(cue.Text), 64)
+	return err == nil && fps > 0 && fps < 200
+}
+
+func frameTime(frame int, fps float64) time.Duration {
+	return time.Duration(float64(frame) / fps * float64(time.Second))
+}
+
+func microDVDText(text string) string {
+	italicAll := false
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		italic := false
+		for _, tag := range microDVDTag.FindAllStringSubmatch(line, -1) {
+			if strings.EqualFold(tag[1], "y") && strings.Contains(strings.ToLower(tag[2]), "i") {
+				if tag[1] == "Y" {
+					italicAll = true
+				} else {
+					italic = true
+				}
+			}
+		}
+		line = strings.TrimSpace(microDVDTag.ReplaceAllString(line, ""))
+		if rest, ok := strings.CutPrefix(line, "/"); ok {
+			line, italic = strings.TrimSpace(rest), true
+		}
+		if italic || italicAll {
+			line = "<i>" + line + "</i>"
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
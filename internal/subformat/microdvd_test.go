@@ -0,0 +1,39 @@
+package subformat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMicroDVDToSRT(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("{0}{25}Hello|{y:i}world\n{50}{}Next\n{100}{125}{Y:i}All|italic\n{150}{175}/Thinking\n")
+
+	srt, err := MicroDVDToSRT(content, 25)
+	require.NoError(t, err)
+	assert.Equal(t, "1\n00:00:00,000 --> 00:00:01,000\nHello\n<i>world</i>\n\n"+
+		"2\n00:00:02,000 --> 00:00:04,000\nNext\n\n"+
+		"3\n00:00:04,000 --> 00:00:05,000\n<i>All</i>\n<i>italic</i>\n\n"+
+		"4\n00:00:06,000 --> 00:00:07,000\n<i>Thinking</i>\n\n", string(srt))
+	assert.Equal(t, SRT, Detect(srt))
+
+	_, err = MicroDVDToSRT(content, 0)
+	assert.ErrorContains(t, err, "need a frame rate")
+}
+
+func TestMicroDVDFPS(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("{1}{1}23.976\n{24}{48}One second\n")
+	assert.Equal(t, 23.976, MicroDVDFPS(content))
+	assert.True(t, IsMicroDVD(content))
+	assert.False(t, IsMicroDVD([]byte("1\n00:00:01,000 --> 00:00:02,000\nHi\n")))
+	assert.Zero(t, MicroDVDFPS([]byte("{24}{48}One second\n")))
+
+	srt, err := MicroDVDToSRT(content, 25)
+	require.NoError(t, err)
+	assert.Equal(t, "1\n00:00:01,001 --> 00:00:02,002\nOne second\n\n", string(srt), "the header frame rate wins")
+}
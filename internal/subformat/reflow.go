@@ -0,0 +1,165 @@
+package subformat
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type ReflowOptions struct {
+	MaxLineLength int
+	MaxCPS        float64
+}
+
+func Reflow(content []byte, opts ReflowOptions) []byte {
+	if Detect(content) != SRT {
+		return content
+	}
+	cues := lintCues(content)
+	if len(cues) == 0 {
+		return content
+	}
+
+	var out []lintCue
+	for _, cue := range cues {
+		if !cue.valid || cue.end <= cue.start || opts.MaxLineLength <= 0 {
+			out = append(out, cue)
+			continue
+		}
+		out = append(out, reflowCue(cue, opts.MaxLineLength)...)
+	}
+
+	if opts.MaxCPS > 0 {
+		for i := range out {
+			cue := &out[i]
+			if !cue.valid || cue.end <= cue.start {
+				continue
+			}
+			chars := textWidth(strings.ReplaceAll(cue.Text, "\n", ""))
+			end := cue.start + time.Duration(float64(chars)/opts.MaxCPS*float64(time.Second))
+			if i+1 < len(out) && out[i+1].valid && out[i+1].start > cue.start {
+				end = min(end, out[i+1].start)
+			}
+			if end > cue.end {
+				cue.end = end
+				cue.End = FormatSRTTime(end)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if bytes.HasPrefix(content, utf8BOM) {
+		buf.Write(utf8BOM)
+	}
+	for i, cue := range out {
+		fmt.Fprintf(&buf, "%d\n%s --> %s\n%s\n\n", i+1, cue.Start, cue.End, cue.Text)
+	}
+	return buf.Bytes()
+}
+
+func reflowCue(cue lintCue, maxLength int) []lintCue {
+	lines := strings.Split(cue.Text, "\n")
+	if isDialogue(lines) || !needsReflow(lines, maxLength) {
+		return []lintCue{cue}
+	}
+
+	words := strings.Fields(strings.Join(lines, " "))
+	total := textWidth(strings.Join(words, " "))
+	wrapped := wrapWords(words, max((total+maxLength-1)/maxLength, 2))
+	if len(wrapped) <= 2 || strings.ContainsAny(cue.Text, "<{") {
+		cue.Text = strings.Join(wrapped, "\n")
+		return []lintCue{cue}
+	}
+
+	var chunks []string
+	for i := 0; i < len(wrapped); i += 2 {
+		chunks = append(chunks, strings.Join(wrapped[i:min(i+2, len(wrapped))], "\n"))
+	}
+
+	duration := cue.end - cue.start
+	cues := make([]lintCue, 0, len(chunks))
+	done := 0
+	for i, chunk := range chunks {
+		start := cue.start + duration*time.Duration(done)/time.Duration(total)
+		done += textWidth(strings.ReplaceAll(chunk, "\n", " ")) + 1
+		end := cue.end
+		if i < len(chunks)-1 {
+			end = cue.start + duration*time.Duration(done)/time.Duration(total)
+		}
+		cues = append(cues, lintCue{
+			Cue:   Cue{Start: FormatSRTTime(start), End: FormatSRTTime(end), Text: chunk},
+			start: start,
+			end:   end,
+			valid: true,
+		})
+	}
+	return cues
+}
+
+func needsReflow(lines []string, maxLength int) bool {
+	for _, line := range lines {
+		if textWidth(line) > maxLength {
+			return true
+		}
+	}
+	if len(lines) != 2 {
+		return false
+	}
+	first, second := textWidth(lines[0]), textWidth(lines[1])
+	return first+second+1 > maxLength && (first > 2*second || second > 2*first)
+}
+
+func isDialogue(lines []string) bool {
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(plainText(line)), "-") {
+			return true
+		}
+	}
+	return false
+}
+
+func wrapWords(words []string, lines int) []string {
+	widest := 0
+	for _, word := range words {
+		widest = max(widest, textWidth(word))
+	}
+
+	low, high := widest, textWidth(strings.Join(words, " "))
+	for low < high {
+		mid := (low + high) / 2
+		if len(fillWords(words, mid)) <= lines {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+	return fillWords(words, low)
+}
+
+func fillWords(words []string, width int) []string {
+	var lines []string
+	line, length := "", 0
+	for _, word := range words {
+		w := textWidth(word)
+		if line != "" && length+1+w > width {
+			lines = append(lines, line)
+			line, length = "", 0
+		}
+		if line != "" {
+			line += " "
+			length++
+		}
+		line += word
+		length += w
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func textWidth(text string) int {
+	return utf8.RuneCountInString(plainText(text))
+}
@@ -0,0 +1,66 @@
+package subformat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReflow(t *testing.T) {
+	t.Parallel()
+
+	opts := ReflowOptions{MaxLineLength: 20, MaxCPS: 10}
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "long line is split into balanced lines",
+			content:  "1\n00:00:01,000 --> 00:00:05,000\nI never said she stole my money\n",
+			expected: "1\n00:00:01,000 --> 00:00:05,000\nI never said she\nstole my money\n\n",
+		},
+		{
+			name:     "unbalanced two-line cue is rebalanced",
+			content:  "1\n00:00:01,000 --> 00:00:05,000\nWe should go back to the\ncar\n",
+			expected: "1\n00:00:01,000 --> 00:00:05,000\nWe should go\nback to the car\n\n",
+		},
+		{
+			name:     "short lines are kept",
+			content:  "1\n00:00:01,000 --> 00:00:03,000\nHello\nthere\n",
+			expected: "1\n00:00:01,000 --> 00:00:03,000\nHello\nthere\n\n",
+		},
+		{
+			name:     "dialogue is kept",
+			content:  "1\n00:00:01,000 --> 00:00:05,000\n- Are you coming with us tonight?\n- No.\n",
+			expected: "1\n00:00:01,000 --> 00:00:05,000\n- Are you coming with us tonight?\n- No.\n\n",
+		},
+		{
+			name:     "text needing more than two lines is split into cues",
+			content:  "1\n00:00:00,000 --> 00:00:08,000\nThis is a very long sentence that will never fit into only two lines\n",
+			expected: "1\n00:00:00,000 --> 00:00:04,588\nThis is a very long\nsentence that will\n\n2\n00:00:04,588 --> 00:00:08,000\nnever fit into only\ntwo lines\n\n",
+		},
+		{
+			name:     "fast cue is extended into the gap",
+			content:  "1\n00:00:01,000 --> 00:00:01,500\nHello world\n\n2\n00:00:10,000 --> 00:00:11,000\nBye\n",
+			expected: "1\n00:00:01,000 --> 00:00:02,100\nHello world\n\n2\n00:00:10,000 --> 00:00:11,000\nBye\n\n",
+		},
+		{
+			name:     "fast cue stops at the next cue",
+			content:  "1\n00:00:01,000 --> 00:00:01,500\nHello world\n\n2\n00:00:01,800 --> 00:00:03,000\nBye\n",
+			expected: "1\n00:00:01,000 --> 00:00:01,800\nHello world\n\n2\n00:00:01,800 --> 00:00:03,000\nBye\n\n",
+		},
+		{
+			name:     "not subrip",
+			content:  "[Script Info]\nTitle: test\n",
+			expected: "[Script Info]\nTitle: test\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, string(Reflow([]byte(tt.content), opts)))
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package subformat
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	cueTimestamp = regexp.MustCompile(`(?:(\d{1,2}):)?(\d{2}):(\d{2})([,.])(\d{1,3})`)
+	assTimestamp = regexp.MustCompile(`^(\d+):(\d{2}):(\d{2})\.(\d{2})$`)
+)
+
+type Timing struct {
+	Shift time.Duration
+	Ratio float64
+}
+
+func (t Timing) apply(d time.Duration) time.Duration {
+	if t.Ratio > 0 {
+		d = time.Duration(float64(d) * t.Ratio)
+	}
+	return max(d+t.Shift, 0)
+}
+
+func Retime(content []byte, format string, timing Timing) ([]byte, error) {
+	switch format {
+	case SRT, VTT:
+		return retimeCues(content, timing), nil
+	case ASS, SSA:
+		return retimeDialogue(content, timing)
+	}
+	return nil, fmt.Errorf("cannot change the timing of %q subtitles (supported: srt, vtt, ass, ssa)", format)
+}
+
+func retimeCues(content []byte, timing Timing) []byte {
+	lines := bytes.SplitAfter(content, []byte("\n"))
+	for i, line := range lines {
+		if !bytes.Contains(line, []byte("-->")) {
+			continue
+		}
+		lines[i] = cueTimestamp.ReplaceAllFunc(line, func(match []byte) []byte {
+			m := cueTimestamp.FindSubmatch(match)
+			hours, _ := strconv.Atoi(string(m[1]))
+			minutes, _ := strconv.Atoi(string(m[2]))
+			seconds, _ := strconv.Atoi(string(m[3]))
+			millis, _ := strconv.Atoi((string(m[5]) + "00")[:3])
+			d := timing.apply(time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute +
+				time.Duration(seconds)*time.Second + time.Duration(millis)*time.Millisecond)
+
+			ms := d.Milliseconds()
+			if len(m[1]) == 0 && ms < 3600000 {
+				return fmt.Appendf(nil, "%02d:%02d%s%03d", ms/60000, ms/1000%60, m[4], ms%1000)
+			}
+			return fmt.Appendf(nil, "%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, m[4], ms%1000)
+		})
+	}
+	return bytes.Join(lines, nil)
+}
+
+func retimeDialogue(content []byte, timing Timing) ([]byte, error) {
+	lines := bytes.SplitAfter(content, []byte("\n"))
+	for i, line := range lines {
+		if !bytes.HasPrefix(line, []byte("Dialogue:")) && !bytes.HasPrefix(line, []byte("Comment:")) {
+			continue
+		}
+		fields := bytes.SplitN(line, []byte(","), 4)
+		if len(fields) < 4 {
+			continue
+		}
+		for _, j := range []int{1, 2} {
+			value := strings.TrimSpace(string(fields[j]))
+			m := assTimestamp.FindStringSubmatch(value)
+			if m == nil {
+				return nil, fmt.Errorf("line %d: invalid timestamp %q", i+1, value)
+			}
+			hours, _ := strconv.Atoi(m[1])
+			minutes, _ := strconv.Atoi(m[2])
+			seconds, _ := strconv.Atoi(m[3])
+			centis, _ := strconv.Atoi(m[4])
+			d := timing.apply(time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute +
+				time.Duration(seconds)*time.Second + time.Duration(centis)*10*time.Millisecond)
+
+			cs := d.Milliseconds() / 10
+			fields[j] = fmt.Appendf(nil, "%d:%02d:%02d.%02d", cs/360000, cs/6000%60, cs/100%60, cs%100)
+		}
+		lines[i] = bytes.Join(fields, []byte(","))
+	}
+	return bytes.Join(lines, nil), nil
+}
+
+func ParseFPSChange(value string) (float64, error) {
+	for _, sep := range []string{"→", "->", ":", "/"} {
+		from, to, ok := strings.Cut(value, sep)
+		if !ok {
+			continue
+		}
+		fromFPS, err := strconv.ParseFloat(strings.TrimSpace(from), 64)
+		if err != nil || fromFPS <= 0 {
+			return 0, fmt.Errorf("invalid frame rate %q", from)
+		}
+		toFPS, err := strconv.ParseFloat(strings.TrimSpace(to), 64)
+		if err != nil || toFPS <= 0 {
+			return 0, fmt.Errorf("invalid frame rate %q", to)
+		}
+		return fromFPS / toFPS, nil
+	}
+	return 0, fmt.Errorf("expected FROM->TO frame rates like 25->23.976, got %q", value)
+}
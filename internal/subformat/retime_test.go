@@ -0,0 +1,87 @@
+package subformat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetime(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		content  string
+		format   string
+		timing   Timing
+		expected string
+	}{
+		{
+			name:     "srt shift",
+			content:  "1\r\n00:00:01,000 --> 00:00:02,500\r\n00:00:01,000 is text\r\n",
+			format:   SRT,
+			timing:   Timing{Shift: 2 * time.Second},
+			expected: "1\r\n00:00:03,000 --> 00:00:04,500\r\n00:00:01,000 is text\r\n",
+		},
+		{
+			name:     "srt shift clamps at zero",
+			content:  "1\n00:00:01,000 --> 00:00:02,500\nHi\n",
+			format:   SRT,
+			timing:   Timing{Shift: -1500 * time.Millisecond},
+			expected: "1\n00:00:00,000 --> 00:00:01,000\nHi\n",
+		},
+		{
+			name:     "srt frame rate",
+			content:  "1\n01:00:00,000 --> 01:00:02,000\nHi\n",
+			format:   SRT,
+			timing:   Timing{Ratio: 25 / 23.976},
+			expected: "1\n01:02:33,753 --> 01:02:35,839\nHi\n",
+		},
+		{
+			name:     "vtt keeps short timestamps and settings",
+			content:  "WEBVTT\n\n00:59.000 --> 01:01.000 line:0\nHi\n",
+			format:   VTT,
+			timing:   Timing{Shift: time.Hour},
+			expected: "WEBVTT\n\n01:00:59.000 --> 01:01:01.000 line:0\nHi\n",
+		},
+		{
+			name:     "ass",
+			content:  "[Events]\nDialogue: 0,0:00:01.00,0:00:02.50,Default,,0,0,0,,Hello, world\nComment: 0,0:00:03.00,0:00:04.00,Default,,0,0,0,,note\n",
+			format:   ASS,
+			timing:   Timing{Shift: -500 * time.Millisecond},
+			expected: "[Events]\nDialogue: 0,0:00:00.50,0:00:02.00,Default,,0,0,0,,Hello, world\nComment: 0,0:00:02.50,0:00:03.50,Default,,0,0,0,,note\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			retimed, err := Retime([]byte(tt.content), tt.format, tt.timing)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(retimed))
+		})
+	}
+
+	_, err := Retime([]byte("{1}{2}Hi\n"), SUB, Timing{Shift: time.Second})
+	assert.ErrorContains(t, err, "cannot change the timing")
+
+	_, err = Retime([]byte("Dialogue: 0,bad,0:00:02.00,Default,,0,0,0,,Hi\n"), ASS, Timing{Shift: time.Second})
+	assert.ErrorContains(t, err, `line 1: invalid timestamp "bad"`)
+}
+
+func TestParseFPSChange(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"25→23.976", "25->23.976", "25:23.976", "25/23.976", " 25 -> 23.976 "} {
+		ratio, err := ParseFPSChange(value)
+		require.NoError(t, err, value)
+		assert.InDelta(t, 25/23.976, ratio, 1e-9, value)
+	}
+
+	_, err := ParseFPSChange("25")
+	assert.ErrorContains(t, err, "expected FROM->TO")
+	_, err = ParseFPSChange("25->0")
+	assert.ErrorContains(t, err, `invalid frame rate "0"`)
+}
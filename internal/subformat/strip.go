@@ -0,0 +1,65 @@
+package subformat
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+var (
+	assOverrideTag = regexp.MustCompile(`\{\\[^}]*\}`)
+	vttTimestamp   = regexp.MustCompile(`<\d[^>]*>`)
+)
+
+func StripTags(content []byte, format string) []byte {
+	switch format {
+	case SRT, VTT:
+	case ASS, SSA:
+		return stripDialogueTags(content)
+	default:
+		return content
+	}
+
+	hasBOM := bytes.HasPrefix(content, utf8BOM)
+	text := string(bytes.TrimPrefix(content, utf8BOM))
+	newline := "\n"
+	if strings.Contains(text, "\r\n") {
+		newline = "\r\n"
+		text = strings.ReplaceAll(text, "\r\n", "\n")
+	}
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if start, rest, ok := strings.Cut(line, "-->"); ok {
+			if end := strings.Fields(rest); len(end) > 0 {
+				lines[i] = strings.TrimSpace(start) + " --> " + end[0]
+			}
+			continue
+		}
+		line = htmlTag.ReplaceAllString(line, "")
+		line = vttTimestamp.ReplaceAllString(line, "")
+		lines[i] = assOverrideTag.ReplaceAllString(line, "")
+	}
+
+	stripped := strings.ReplaceAll(strings.Join(lines, "\n"), "\n", newline)
+	if hasBOM {
+		return append(append([]byte{}, utf8BOM...), stripped...)
+	}
+	return []byte(stripped)
+}
+
+func stripDialogueTags(content []byte) []byte {
+	lines := bytes.SplitAfter(content, []byte("\n"))
+	for i, line := range lines {
+		if !bytes.HasPrefix(line, []byte("Dialogue:")) {
+			continue
+		}
+		fields := bytes.SplitN(line, []byte(","), 10)
+		if len(fields) < 10 {
+			continue
+		}
+		fields[9] = assOverride.ReplaceAll(fields[9], nil)
+		lines[i] = bytes.Join(fields, []byte(","))
+	}
+	return bytes.Join(lines, nil)
+}
@@ -0,0 +1,56 @@
+package subformat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		content  string
+		format   string
+		expected string
+	}{
+		{
+			name:     "srt",
+			content:  "1\n00:00:01,000 --> 00:00:02,000 X1:100 X2:600 Y1:50 Y2:80\n{\\an8}<i>Hello</i> <font color=\"#ffff00\">there</font>\n",
+			format:   SRT,
+			expected: "1\n00:00:01,000 --> 00:00:02,000\nHello there\n",
+		},
+		{
+			name:     "srt with crlf and bom",
+			content:  "\ufeff1\r\n00:00:01,000 --> 00:00:02,000\r\n<b>Bold</b>\r\n",
+			format:   SRT,
+			expected: "\ufeff1\r\n00:00:01,000 --> 00:00:02,000\r\nBold\r\n",
+		},
+		{
+			name:     "vtt",
+			content:  "WEBVTT\n\n00:00:01.000 --> 00:00:02.000 line:0 position:20% align:start\n<v Bob><c.yellow>Hi</c> <00:00:01.500>there\n",
+			format:   VTT,
+			expected: "WEBVTT\n\n00:00:01.000 --> 00:00:02.000\nHi there\n",
+		},
+		{
+			name:     "ass",
+			content:  "[Events]\nFormat: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text\nDialogue: 0,0:00:01.00,0:00:02.00,Default,,0,0,0,,{\\pos(10,20)\\k20}Hello, {\\i1}world{\\i0}\n",
+			format:   ASS,
+			expected: "[Events]\nFormat: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text\nDialogue: 0,0:00:01.00,0:00:02.00,Default,,0,0,0,,Hello, world\n",
+		},
+		{
+			name:     "microdvd untouched",
+			content:  "{25}{50}{y:i}Hello\n",
+			format:   SUB,
+			expected: "{25}{50}{y:i}Hello\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, string(StripTags([]byte(tt.content), tt.format)))
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package subformat
+
+import (
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	SRT  = "srt"
+	ASS  = "ass"
+	SSA  = "ssa"
+	VTT  = "vtt"
+	SUB  = "sub"
+	SAMI = "smi"
+	IDX  = "idx"
+)
+
+var extensions = map[string]string{
+	".srt":  SRT,
+	".ass":  ASS,
+	".ssa":  SSA,
+	".vtt":  VTT,
+	".sub":  SUB,
+	".smi":  SAMI,
+	".sami": SAMI,
+	".idx":  IDX,
+}
+
+var (
+	srtTiming   = regexp.MustCompile(`(?m)^\d+\s*\r?\n\d{1,2}:\d{2}:\d{2}[,.]\d{1,3}\s*-->\s*\d{1,2}:\d{2}:\d{2}[,.]\d{1,3}`)
+	microDVD    = regexp.MustCompile(`(?m)^\{\d+\}\{\d*\}`)
+	subViewer   = regexp.MustCompile(`(?m)^\d{2}:\d{2}:\d{2}\.\d{2},\d{2}:\d{2}:\d{2}\.\d{2}\s*$`)
+	utf8BOM     = []byte{0xEF, 0xBB, 0xBF}
+	sniffLength = 4096
+)
+
+func FromFileName(name string) string {
+	return extensions[strings.ToLower(filepath.Ext(name))]
+}
+
+func Detect(content []byte) string {
+	switch {
+	case IsVobSub(content):
+		return SUB
+	case bytes.HasPrefix(content, zipHeader):
+		if _, _, ok := VobSubPair(content); ok {
+			return IDX
+		}
+		return ""
+	}
+
+	content = bytes.TrimPrefix(content, utf8BOM)
+	if len(content) > sniffLength {
+		content = content[:sniffLength]
+	}
+	head := bytes.TrimLeft(content, " \t\r\n")
+
+	switch {
+	case bytes.HasPrefix(head, vobSubIndex):
+		return IDX
+	case bytes.HasPrefix(head, []byte("WEBVTT")):
+		return VTT
+	case bytes.HasPrefix(head, []byte("[Script Info]")):
+		if bytes.Contains(bytes.ToLower(content), []byte("scripttype: v4.00+")) {
+			return ASS
+		}
+		return SSA
+	case bytes.Contains(bytes.ToUpper(head[:min(len(head), 256)]), []byte("<SAMI")):
+		return SAMI
+	case srtTiming.Match(content):
+		return SRT
+	case microDVD.Match(content), subViewer.Match(content):
+		return SUB
+	}
+
+	return ""
+}
+
+func Resolve(content []byte, fileName, fallback string) string {
+	if format := Detect(content); format != "" {
+		return format
+	}
+	if format := FromFileName(fileName); format != "" {
+		return format
+	}
+	if fallback != "" {
+		return fallback
+	}
+	return SRT
+}
@@ -0,0 +1,122 @@
+package subformat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromFileName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"The.Office.S03E07.srt", SRT},
+		{"movie.ASS", ASS},
+		{"movie.ssa", SSA},
+		{"movie.en.vtt", VTT},
+		{"movie.sub", SUB},
+		{"movie.sami", SAMI},
+		{"movie.idx", IDX},
+		{"movie.txt", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, FromFileName(tt.name))
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "srt",
+			content:  "1\n00:00:01,000 --> 00:00:02,500\nHello\n\n2\n00:00:03,000 --> 00:00:04,000\nWorld\n",
+			expected: SRT,
+		},
+		{
+			name:     "srt with bom and crlf",
+			content:  "\xEF\xBB\xBF1\r\n00:00:01,000 --> 00:00:02,500\r\nHello\r\n",
+			expected: SRT,
+		},
+		{
+			name:     "vobsub index",
+			content:  "# VobSub index file, v7 (do not modify this line!)\nsize: 720x480\n",
+			expected: IDX,
+		},
+		{
+			name:     "vobsub stream",
+			content:  "\x00\x00\x01\xba\x44\x00",
+			expected: SUB,
+		},
+		{
+			name:     "webvtt",
+			content:  "WEBVTT\n\n00:00:01.000 --> 00:00:02.500\nHello\n",
+			expected: VTT,
+		},
+		{
+			name:     "advanced substation alpha",
+			content:  "[Script Info]\nTitle: Test\nScriptType: v4.00+\n\n[V4+ Styles]\n",
+			expected: ASS,
+		},
+		{
+			name:     "substation alpha",
+			content:  "[Script Info]\nScriptType: v4.00\n\n[V4 Styles]\n",
+			expected: SSA,
+		},
+		{
+			name:     "microdvd",
+			content:  "{1}{1}23.976\n{100}{200}Hello|World\n",
+			expected: SUB,
+		},
+		{
+			name:     "subviewer",
+			content:  "[INFORMATION]\n00:00:01.00,00:00:02.50\nHello\n",
+			expected: SUB,
+		},
+		{
+			name:     "sami",
+			content:  "<SAMI>\n<BODY>\n<SYNC Start=1000><P>Hello\n</BODY>\n</SAMI>\n",
+			expected: SAMI,
+		},
+		{
+			name:     "unknown",
+			content:  "just some text",
+			expected: "",
+		},
+		{
+			name:     "empty",
+			content:  "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, Detect([]byte(tt.content)))
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	vtt := []byte("WEBVTT\n\n00:00:01.000 --> 00:00:02.500\nHello\n")
+
+	assert.Equal(t, VTT, Resolve(vtt, "movie.srt", SRT), "content wins over file name")
+	assert.Equal(t, ASS, Resolve([]byte("garbage"), "movie.ass", SRT), "file name used when content is unknown")
+	assert.Equal(t, SSA, Resolve(nil, "", SSA), "fallback used when nothing else matches")
+	assert.Equal(t, SRT, Resolve(nil, "", ""), "srt is the last resort")
+}
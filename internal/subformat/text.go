@@ -0,0 +1,42 @@
+package subformat
+
+import "bytes"
+
+const (
+	LF   = "lf"
+	CRLF = "crlf"
+
+	AddBOM    = "add"
+	RemoveBOM = "remove"
+)
+
+func IsText(content []byte) bool {
+	return !bytes.HasPrefix(content, zipHeader) && !IsVobSub(content) && bytes.IndexByte(content, 0) < 0
+}
+
+func Normalize(content []byte, lineEndings, bom string) []byte {
+	if !IsText(content) || (lineEndings == "" && bom == "") {
+		return content
+	}
+
+	hasBOM := bytes.HasPrefix(content, utf8BOM)
+	content = bytes.TrimPrefix(content, utf8BOM)
+	switch lineEndings {
+	case LF:
+		content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	case CRLF:
+		content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+		content = bytes.ReplaceAll(content, []byte("\n"), []byte("\r\n"))
+	}
+
+	switch bom {
+	case AddBOM:
+		hasBOM = true
+	case RemoveBOM:
+		hasBOM = false
+	}
+	if hasBOM {
+		return append(append([]byte{}, utf8BOM...), content...)
+	}
+	return content
+}
@@ -0,0 +1,35 @@
+package subformat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	mixed := []byte("\ufeff1\r\n00:00:01,000 --> 00:00:02,000\nHello\r\n")
+	tests := []struct {
+		name        string
+		content     []byte
+		lineEndings string
+		bom         string
+		expected    string
+	}{
+		{"keep", mixed, "", "", "\ufeff1\r\n00:00:01,000 --> 00:00:02,000\nHello\r\n"},
+		{"lf", mixed, LF, "", "\ufeff1\n00:00:01,000 --> 00:00:02,000\nHello\n"},
+		{"crlf without bom", mixed, CRLF, RemoveBOM, "1\r\n00:00:01,000 --> 00:00:02,000\r\nHello\r\n"},
+		{"add bom", []byte("1\n00:00:01,000 --> 00:00:02,000\nHi\n"), "", AddBOM, "\ufeff1\n00:00:01,000 --> 00:00:02,000\nHi\n"},
+		{"add bom once", mixed, LF, AddBOM, "\ufeff1\n00:00:01,000 --> 00:00:02,000\nHello\n"},
+		{"vobsub untouched", []byte{0x00, 0x00, 0x01, 0xBA, '\n'}, CRLF, AddBOM, "\x00\x00\x01\xba\n"},
+		{"zip untouched", []byte("PK\x03\x04\n"), CRLF, AddBOM, "PK\x03\x04\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, string(Normalize(tt.content, tt.lineEndings, tt.bom)))
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package subformat
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"path"
+	"strings"
+)
+
+var (
+	vobSubIndex  = []byte("# VobSub index file")
+	mpegPSHeader = []byte{0x00, 0x00, 0x01, 0xBA}
+	zipHeader    = []byte("PK\x03\x04")
+)
+
+func IsVobSub(content []byte) bool {
+	return bytes.HasPrefix(content, mpegPSHeader)
+}
+
+func VobSubPair(content []byte) (idx, sub []byte, ok bool) {
+	if !bytes.HasPrefix(content, zipHeader) {
+		return nil, nil, false
+	}
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, nil, false
+	}
+
+	var keys []string
+	files := make(map[string]map[string]*zip.File)
+	for _, file := range archive.File {
+		name := path.Base(file.Name)
+		ext := strings.ToLower(path.Ext(name))
+		if file.FileInfo().IsDir() || (ext != ".idx" && ext != ".sub") {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSuffix(name, path.Ext(name)))
+		if files[key] == nil {
+			keys = append(keys, key)
+			files[key] = make(map[string]*zip.File)
+		}
+		files[key][ext] = file
+	}
+
+	for _, key := range keys {
+		pair := files[key]
+		if pair[".idx"] == nil || pair[".sub"] == nil {
+			continue
+		}
+		if idx, err = readZipFile(pair[".idx"]); err != nil {
+			return nil, nil, false
+		}
+		if sub, err = readZipFile(pair[".sub"]); err != nil || !IsVobSub(sub) {
+			return nil, nil, false
+		}
+		return idx, sub, true
+	}
+	return nil, nil, false
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
@@ -0,0 +1,44 @@
+package subformat
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVobSubPair(t *testing.T) {
+	t.Parallel()
+
+	archive := func(files ...string) []byte {
+		var buf bytes.Buffer
+		w := zip.NewWriter(&buf)
+		for i := 0; i < len(files); i += 2 {
+			f, err := w.Create(files[i])
+			require.NoError(t, err)
+			_, err = f.Write([]byte(files[i+1]))
+			require.NoError(t, err)
+		}
+		require.NoError(t, w.Close())
+		return buf.Bytes()
+	}
+
+	index := "# VobSub index file, v7\n"
+	stream := "\x00\x00\x01\xbadata"
+
+	idx, sub, ok := VobSubPair(archive("readme.txt", "x", "Movie/Movie.IDX", index, "Movie/movie.sub", stream))
+	require.True(t, ok)
+	assert.Equal(t, index, string(idx))
+	assert.Equal(t, stream, string(sub))
+	assert.Equal(t, IDX, Detect(archive("a.idx", index, "a.sub", stream)))
+
+	_, _, ok = VobSubPair(archive("a.idx", index))
+	assert.False(t, ok, "an index without its stream is not a pair")
+	_, _, ok = VobSubPair(archive("a.idx", index, "a.sub", "{1}{2}MicroDVD"))
+	assert.False(t, ok, "a text .sub is not a VobSub stream")
+	_, _, ok = VobSubPair([]byte(index))
+	assert.False(t, ok)
+	assert.Empty(t, Detect(archive("a.srt", "1")))
+}
@@ -0,0 +1,161 @@
+package textnorm
+
+var decompositions = map[rune][2]rune{
+	'À': {'A', '\u0300'}, 'Á': {'A', '\u0301'}, 'Â': {'A', '\u0302'}, 'Ã': {'A', '\u0303'},
+	'Ä': {'A', '\u0308'}, 'Å': {'A', '\u030a'}, 'Ç': {'C', '\u0327'}, 'È': {'E', '\u0300'},
+	'É': {'E', '\u0301'}, 'Ê': {'E', '\u0302'}, 'Ë': {'E', '\u0308'}, 'Ì': {'I', '\u0300'},
+	'Í': {'I', '\u0301'}, 'Î': {'I', '\u0302'}, 'Ï': {'I', '\u0308'}, 'Ñ': {'N', '\u0303'},
+	'Ò': {'O', '\u0300'}, 'Ó': {'O', '\u0301'}, 'Ô': {'O', '\u0302'}, 'Õ': {'O', '\u0303'},
+	'Ö': {'O', '\u0308'}, 'Ù': {'U', '\u0300'}, 'Ú': {'U', '\u0301'}, 'Û': {'U', '\u0302'},
+	'Ü': {'U', '\u0308'}, 'Ý': {'Y', '\u0301'}, 'à': {'a', '\u0300'}, 'á': {'a', '\u0301'},
+	'â': {'a', '\u0302'}, 'ã': {'a', '\u0303'}, 'ä': {'a', '\u0308'}, 'å': {'a', '\u030a'},
+	'ç': {'c', '\u0327'}, 'è': {'e', '\u0300'}, 'é': {'e', '\u0301'}, 'ê': {'e', '\u0302'},
+	'ë': {'e', '\u0308'}, 'ì': {'i', '\u0300'}, 'í': {'i', '\u0301'}, 'î': {'i', '\u0302'},
+	'ï': {'i', '\u0308'}, 'ñ': {'n', '\u0303'}, 'ò': {'o', '\u0300'}, 'ó': {'o', '\u0301'},
+	'ô': {'o', '\u0302'}, 'õ': {'o', '\u0303'}, 'ö': {'o', '\u0308'}, 'ù': {'u', '\u0300'},
+	'ú': {'u', '\u0301'}, 'û': {'u', '\u0302'}, 'ü': {'u', '\u0308'}, 'ý': {'y', '\u0301'},
+	'ÿ': {'y', '\u0308'}, 'Ā': {'A', '\u0304'}, 'ā': {'a', '\u0304'}, 'Ă': {'A', '\u0306'},
+	'ă': {'a', '\u0306'}, 'Ą': {'A', '\u0328'}, 'ą': {'a', '\u0328'}, 'Ć': {'C', '\u0301'},
+	'ć': {'c', '\u0301'}, 'Ĉ': {'C', '\u0302'}, 'ĉ': {'c', '\u0302'}, 'Ċ': {'C', '\u0307'},
+	'ċ': {'c', '\u0307'}, 'Č': {'C', '\u030c'}, 'č': {'c', '\u030c'}, 'Ď': {'D', '\u030c'},
+	'ď': {'d', '\u030c'}, 'Ē': {'E', '\u0304'}, 'ē': {'e', '\u0304'}, 'Ĕ': {'E', '\u0306'},
+	'ĕ': {'e', '\u0306'}, 'Ė': {'E', '\u0307'}, 'ė': {'e', '\u0307'}, 'Ę': {'E', '\u0328'},
+	'ę': {'e', '\u0328'}, 'Ě': {'E', '\u030c'}, 'ě': {'e', '\u030c'}, 'Ĝ': {'G', '\u0302'},
+	'ĝ': {'g', '\u0302'}, 'Ğ': {'G', '\u0306'}, 'ğ': {'g', '\u0306'}, 'Ġ': {'G', '\u0307'},
+	'ġ': {'g', '\u0307'}, 'Ģ': {'G', '\u0327'}, 'ģ': {'g', '\u0327'}, 'Ĥ': {'H', '\u0302'},
+	'ĥ': {'h', '\u0302'}, 'Ĩ': {'I', '\u0303'}, 'ĩ': {'i', '\u0303'}, 'Ī': {'I', '\u0304'},
+	'ī': {'i', '\u0304'}, 'Ĭ': {'I', '\u0306'}, 'ĭ': {'i', '\u0306'}, 'Į': {'I', '\u0328'},
+	'į': {'i', '\u0328'}, 'İ': {'I', '\u0307'}, 'Ĵ': {'J', '\u0302'}, 'ĵ': {'j', '\u0302'},
+	'Ķ': {'K', '\u0327'}, 'ķ': {'k', '\u0327'}, 'Ĺ': {'L', '\u0301'}, 'ĺ': {'l', '\u0301'},
+	'Ļ': {'L', '\u0327'}, 'ļ': {'l', '\u0327'}, 'Ľ': {'L', '\u030c'}, 'ľ': {'l', '\u030c'},
+	'Ń': {'N', '\u0301'}, 'ń': {'n', '\u0301'}, 'Ņ': {'N', '\u0327'}, 'ņ': {'n', '\u0327'},
+	'Ň': {'N', '\u030c'}, 'ň': {'n', '\u030c'}, 'Ō': {'O', '\u0304'}, 'ō': {'o', '\u0304'},
+	'Ŏ': {'O', '\u0306'}, 'ŏ': {'o', '\u0306'}, 'Ő': {'O', '\u030b'}, 'ő': {'o', '\u030b'},
+	'Ŕ': {'R', '\u0301'}, 'ŕ': {'r', '\u0301'}, 'Ŗ': {'R', '\u0327'}, 'ŗ': {'r', '\u0327'},
+	'Ř': {'R', '\u030c'}, 'ř': {'r', '\u030c'}, 'Ś': {'S', '\u0301'}, 'ś': {'s', '\u0301'},
+	'Ŝ': {'S', '\u0302'}, 'ŝ': {'s', '\u0302'}, 'Ş': {'S', '\u0327'}, 'ş': {'s', '\u0327'},
+	'Š': {'S', '\u030c'}, 'š': {'s', '\u030c'}, 'Ţ': {'T', '\u0327'}, 'ţ': {'t', '\u0327'},
+	'Ť': {'T', '\u030c'}, 'ť': {'t', '\u030c'}, 'Ũ': {'U', '\u0303'}, 'ũ': {'u', '\u0303'},
+	'Ū': {'U', '\u0304'}, 'ū': {'u', '\u0304'}, 'Ŭ': {'U', '\u0306'}, 'ŭ': {'u', '\u0306'},
+	'Ů': {'U', '\u030a'}, 'ů': {'u', '\u030a'}, 'Ű': {'U', '\u030b'}, 'ű': {'u', '\u030b'},
+	'Ų': {'U', '\u0328'}, 'ų': {'u', '\u0328'}, 'Ŵ': {'W', '\u0302'}, 'ŵ': {'w', '\u0302'},
+	'Ŷ': {'Y', '\u0302'}, 'ŷ': {'y', '\u0302'}, 'Ÿ': {'Y', '\u0308'}, 'Ź': {'Z', '\u0301'},
+	'ź': {'z', '\u0301'}, 'Ż': {'Z', '\u0307'}, 'ż': {'z', '\u0307'}, 'Ž': {'Z', '\u030c'},
+	'ž': {'z', '\u030c'}, 'Ơ': {'O', '\u031b'}, 'ơ': {'o', '\u031b'}, 'Ư': {'U', '\u031b'},
+	'ư': {'u', '\u031b'}, 'Ǎ': {'A', '\u030c'}, 'ǎ': {'a', '\u030c'}, 'Ǐ': {'I', '\u030c'},
+	'ǐ': {'i', '\u030c'}, 'Ǒ': {'O', '\u030c'}, 'ǒ': {'o', '\u030c'}, 'Ǔ': {'U', '\u030c'},
+	'ǔ': {'u', '\u030c'}, 'Ǖ': {'Ü', '\u0304'}, 'ǖ': {'ü', '\u0304'}, 'Ǘ': {'Ü', '\u0301'},
+	'ǘ': {'ü', '\u0301'}, 'Ǚ': {'Ü', '\u030c'}, 'ǚ': {'ü', '\u030c'}, 'Ǜ': {'Ü', '\u0300'},
+	'ǜ': {'ü', '\u0300'}, 'Ǟ': {'Ä', '\u0304'}, 'ǟ': {'ä', '\u0304'}, 'Ǡ': {'Ȧ', '\u0304'},
+	'ǡ': {'ȧ', '\u0304'}, 'Ǣ': {'Æ', '\u0304'}, 'ǣ': {'æ', '\u0304'}, 'Ǧ': {'G', '\u030c'},
+	'ǧ': {'g', '\u030c'}, 'Ǩ': {'K', '\u030c'}, 'ǩ': {'k', '\u030c'}, 'Ǫ': {'O', '\u0328'},
+	'ǫ': {'o', '\u0328'}, 'Ǭ': {'Ǫ', '\u0304'}, 'ǭ': {'ǫ', '\u0304'}, 'Ǯ': {'Ʒ', '\u030c'},
+	'ǯ': {'ʒ', '\u030c'}, 'ǰ': {'j', '\u030c'}, 'Ǵ': {'G', '\u0301'}, 'ǵ': {'g', '\u0301'},
+	'Ǹ': {'N', '\u0300'}, 'ǹ': {'n', '\u0300'}, 'Ǻ': {'Å', '\u0301'}, 'ǻ': {'å', '\u0301'},
+	'Ǽ': {'Æ', '\u0301'}, 'ǽ': {'æ', '\u0301'}, 'Ǿ': {'Ø', '\u0301'}, 'ǿ': {'ø', '\u0301'},
+	'Ȁ': {'A', '\u030f'}, 'ȁ': {'a', '\u030f'}, 'Ȃ': {'A', '\u0311'}, 'ȃ': {'a', '\u0311'},
+	'Ȅ': {'E', '\u030f'}, 'ȅ': {'e', '\u030f'}, 'Ȇ': {'E', '\u0311'}, 'ȇ': {'e', '\u0311'},
+	'Ȉ': {'I', '\u030f'}, 'ȉ': {'i', '\u030f'}, 'Ȋ': {'I', '\u0311'}, 'ȋ': {'i', '\u0311'},
+	'Ȍ': {'O', '\u030f'}, 'ȍ': {'o', '\u030f'}, 'Ȏ': {'O', '\u0311'}, 'ȏ': {'o', '\u0311'},
+	'Ȑ': {'R', '\u030f'}, 'ȑ': {'r', '\u030f'}, 'Ȓ': {'R', '\u0311'}, 'ȓ': {'r', '\u0311'},
+	'Ȕ': {'U', '\u030f'}, 'ȕ': {'u', '\u030f'}, 'Ȗ': {'U', '\u0311'}, 'ȗ': {'u', '\u0311'},
+	'Ș': {'S', '\u0326'}, 'ș': {'s', '\u0326'}, 'Ț': {'T', '\u0326'}, 'ț': {'t', '\u0326'},
+	'Ȟ': {'H', '\u030c'}, 'ȟ': {'h', '\u030c'}, 'Ȧ': {'A', '\u0307'}, 'ȧ': {'a', '\u0307'},
+	'Ȩ': {'E', '\u0327'}, 'ȩ': {'e', '\u0327'}, 'Ȫ': {'Ö', '\u0304'}, 'ȫ': {'ö', '\u0304'},
+	'Ȭ': {'Õ', '\u0304'}, 'ȭ': {'õ', '\u0304'}, 'Ȯ': {'O', '\u0307'}, 'ȯ': {'o', '\u0307'},
+	'Ȱ': {'Ȯ', '\u0304'}, 'ȱ': {'ȯ', '\u0304'}, 'Ȳ': {'Y', '\u0304'}, 'ȳ': {'y', '\u0304'},
+	'Ḁ': {'A', '\u0325'}, 'ḁ': {'a', '\u0325'}, 'Ḃ': {'B', '\u0307'}, 'ḃ': {'b', '\u0307'},
+	'Ḅ': {'B', '\u0323'}, 'ḅ': {'b', '\u0323'}, 'Ḇ': {'B', '\u0331'}, 'ḇ': {'b', '\u0331'},
+	'Ḉ': {'Ç', '\u0301'}, 'ḉ': {'ç', '\u0301'}, 'Ḋ': {'D', '\u0307'}, 'ḋ': {'d', '\u0307'},
+	'Ḍ': {'D', '\u0323'}, 'ḍ': {'d', '\u0323'}, 'Ḏ': {'D', '\u0331'}, 'ḏ': {'d', '\u0331'},
+	'Ḑ': {'D', '\u0327'}, 'ḑ': {'d', '\u0327'}, 'Ḓ': {'D', '\u032d'}, 'ḓ': {'d', '\u032d'},
+	'Ḕ': {'Ē', '\u0300'}, 'ḕ': {'ē', '\u0300'}, 'Ḗ': {'Ē', '\u0301'}, 'ḗ': {'ē', '\u0301'},
+	'Ḙ': {'E', '\u032d'}, 'ḙ': {'e', '\u032d'}, 'Ḛ': {'E', '\u0330'}, 'ḛ': {'e', '\u0330'},
+	'Ḝ': {'Ȩ', '\u0306'}, 'ḝ': {'ȩ', '\u0306'}, 'Ḟ': {'F', '\u0307'}, 'ḟ': {'f', '\u0307'},
+	'Ḡ': {'G', '\u0304'}, 'ḡ': {'g', '\u0304'}, 'Ḣ': {'H', '\u0307'}, 'ḣ': {'h', '\u0307'},
+	'Ḥ': {'H', '\u0323'}, 'ḥ': {'h', '\u0323'}, 'Ḧ': {'H', '\u0308'}, 'ḧ': {'h', '\u0308'},
+	'Ḩ': {'H', '\u0327'}, 'ḩ': {'h', '\u0327'}, 'Ḫ': {'H', '\u032e'}, 'ḫ': {'h', '\u032e'},
+	'Ḭ': {'I', '\u0330'}, 'ḭ': {'i', '\u0330'}, 'Ḯ': {'Ï', '\u0301'}, 'ḯ': {'ï', '\u0301'},
+	'Ḱ': {'K', '\u0301'}, 'ḱ': {'k', '\u0301'}, 'Ḳ': {'K', '\u0323'}, 'ḳ': {'k', '\u0323'},
+	'Ḵ': {'K', '\u0331'}, 'ḵ': {'k', '\u0331'}, 'Ḷ': {'L', '\u0323'}, 'ḷ': {'l', '\u0323'},
+	'Ḹ': {'Ḷ', '\u0304'}, 'ḹ': {'ḷ', '\u0304'}, 'Ḻ': {'L', '\u0331'}, 'ḻ': {'l', '\u0331'},
+	'Ḽ': {'L', '\u032d'}, 'ḽ': {'l', '\u032d'}, 'Ḿ': {'M', '\u0301'}, 'ḿ': {'m', '\u0301'},
+	'Ṁ': {'M', '\u0307'}, 'ṁ': {'m', '\u0307'}, 'Ṃ': {'M', '\u0323'}, 'ṃ': {'m', '\u0323'},
+	'Ṅ': {'N', '\u0307'}, 'ṅ': {'n', '\u0307'}, 'Ṇ': {'N', '\u0323'}, 'ṇ': {'n', '\u0323'},
+	'Ṉ': {'N', '\u0331'}, 'ṉ': {'n', '\u0331'}, 'Ṋ': {'N', '\u032d'}, 'ṋ': {'n', '\u032d'},
+	'Ṍ': {'Õ', '\u0301'}, 'ṍ': {'õ', '\u0301'}, 'Ṏ': {'Õ', '\u0308'}, 'ṏ': {'õ', '\u0308'},
+	'Ṑ': {'Ō', '\u0300'}, 'ṑ': {'ō', '\u0300'}, 'Ṓ': {'Ō', '\u0301'}, 'ṓ': {'ō', '\u0301'},
+	'Ṕ': {'P', '\u0301'}, 'ṕ': {'p', '\u0301'}, 'Ṗ': {'P', '\u0307'}, 'ṗ': {'p', '\u0307'},
+	'Ṙ': {'R', '\u0307'}, 'ṙ': {'r', '\u0307'}, 'Ṛ': {'R', '\u0323'}, 'ṛ': {'r', '\u0323'},
+	'Ṝ': {'Ṛ', '\u0304'}, 'ṝ': {'ṛ', '\u0304'}, 'Ṟ': {'R', '\u0331'}, 'ṟ': {'r', '\u0331'},
+	'Ṡ': {'S', '\u0307'}, 'ṡ': {'s', '\u0307'}, 'Ṣ': {'S', '\u0323'}, 'ṣ': {'s', '\u0323'},
+	'Ṥ': {'Ś', '\u0307'}, 'ṥ': {'ś', '\u0307'}, 'Ṧ': {'Š', '\u0307'}, 'ṧ': {'š', '\u0307'},
+	'Ṩ': {'Ṣ', '\u0307'}, 'ṩ': {'ṣ', '\u0307'}, 'Ṫ': {'T', '\u0307'}, 'ṫ': {'t', '\u0307'},
+	'Ṭ': {'T', '\u0323'}, 'ṭ': {'t', '\u0323'}, 'Ṯ': {'T', '\u0331'}, 'ṯ': {'t', '\u0331'},
+	'Ṱ': {'T', '\u032d'}, 'ṱ': {'t', '\u032d'}, 'Ṳ': {'U', '\u0324'}, 'ṳ': {'u', '\u0324'},
+	'Ṵ': {'U', '\u0330'}, 'ṵ': {'u', '\u0330'}, 'Ṷ': {'U', '\u032d'}, 'ṷ': {'u', '\u032d'},
+	'Ṹ': {'Ũ', '\u0301'}, 'ṹ': {'ũ', '\u0301'}, 'Ṻ': {'Ū', '\u0308'}, 'ṻ': {'ū', '\u0308'},
+	'Ṽ': {'V', '\u0303'}, 'ṽ': {'v', '\u0303'}, 'Ṿ': {'V', '\u0323'}, 'ṿ': {'v', '\u0323'},
+	'Ẁ': {'W', '\u0300'}, 'ẁ': {'w', '\u0300'}, 'Ẃ': {'W', '\u0301'}, 'ẃ': {'w', '\u0301'},
+	'Ẅ': {'W', '\u0308'}, 'ẅ': {'w', '\u0308'}, 'Ẇ': {'W', '\u0307'}, 'ẇ': {'w', '\u0307'},
+	'Ẉ': {'W', '\u0323'}, 'ẉ': {'w', '\u0323'}, 'Ẋ': {'X', '\u0307'}, 'ẋ': {'x', '\u0307'},
+	'Ẍ': {'X', '\u0308'}, 'ẍ': {'x', '\u0308'}, 'Ẏ': {'Y', '\u0307'}, 'ẏ': {'y', '\u0307'},
+	'Ẑ': {'Z', '\u0302'}, 'ẑ': {'z', '\u0302'}, 'Ẓ': {'Z', '\u0323'}, 'ẓ': {'z', '\u0323'},
+	'Ẕ': {'Z', '\u0331'}, 'ẕ': {'z', '\u0331'}, 'ẖ': {'h', '\u0331'}, 'ẗ': {'t', '\u0308'},
+	'ẘ': {'w', '\u030a'}, 'ẙ': {'y', '\u030a'}, 'ẛ': {'ſ', '\u0307'}, 'Ạ': {'A', '\u0323'},
+	'ạ': {'a', '\u0323'}, 'Ả': {'A', '\u0309'}, 'ả': {'a', '\u0309'}, 'Ấ': {'Â', '\u0301'},
+	'ấ': {'â', '\u0301'}, 'Ầ': {'Â', '\u0300'}, 'ầ': {'â', '\u0300'}, 'Ẩ': {'Â', '\u0309'},
+	'ẩ': {'â', '\u0309'}, 'Ẫ': {'Â', '\u0303'}, 'ẫ': {'â', '\u0303'}, 'Ậ': {'Ạ', '\u0302'},
+	'ậ': {'ạ', '\u0302'}, 'Ắ': {'Ă', '\u0301'}, 'ắ': {'ă', '\u0301'}, 'Ằ': {'Ă', '\u0300'},
+	'ằ': {'ă', '\u0300'}, 'Ẳ': {'Ă', '\u0309'}, 'ẳ': {'ă', '\u0309'}, 'Ẵ': {'Ă', '\u0303'},
+	'ẵ': {'ă', '\u0303'}, 'Ặ': {'Ạ', '\u0306'}, 'ặ': {'ạ', '\u0306'}, 'Ẹ': {'E', '\u0323'},
+	'ẹ': {'e', '\u0323'}, 'Ẻ': {'E', '\u0309'}, 'ẻ': {'e', '\u0309'}, 'Ẽ': {'E', '\u0303'},
+	'ẽ': {'e', '\u0303'}, 'Ế': {'Ê', '\u0301'}, 'ế': {'ê', '\u0301'}, 'Ề': {'Ê', '\u0300'},
+	'ề': {'ê', '\u0300'}, 'Ể': {'Ê', '\u0309'}, 'ể': {'ê', '\u0309'}, 'Ễ': {'Ê', '\u0303'},
+	'ễ': {'ê', '\u0303'}, 'Ệ': {'Ẹ', '\u0302'}, 'ệ': {'ẹ', '\u0302'}, 'Ỉ': {'I', '\u0309'},
+	'ỉ': {'i', '\u0309'}, 'Ị': {'I', '\u0323'}, 'ị': {'i', '\u0323'}, 'Ọ': {'O', '\u0323'},
+	'ọ': {'o', '\u0323'}, 'Ỏ': {'O', '\u0309'}, 'ỏ': {'o', '\u0309'}, 'Ố': {'Ô', '\u0301'},
+	'ố': {'ô', '\u0301'}, 'Ồ': {'Ô', '\u0300'}, 'ồ': {'ô', '\u0300'}, 'Ổ': {'Ô', '\u0309'},
+	'ổ': {'ô', '\u0309'}, 'Ỗ': {'Ô', '\u0303'}, 'ỗ': {'ô', '\u0303'}, 'Ộ': {'Ọ', '\u0302'},
+	'ộ': {'ọ', '\u0302'}, 'Ớ': {'Ơ', '\u0301'}, 'ớ': {'ơ', '\u0301'}, 'Ờ': {'Ơ', '\u0300'},
+	'ờ': {'ơ', '\u0300'}, 'Ở': {'Ơ', '\u0309'}, 'ở': {'ơ', '\u0309'}, 'Ỡ': {'Ơ', '\u0303'},
+	'ỡ': {'ơ', '\u0303'}, 'Ợ': {'Ơ', '\u0323'}, 'ợ': {'ơ', '\u0323'}, 'Ụ': {'U', '\u0323'},
+	'ụ': {'u', '\u0323'}, 'Ủ': {'U', '\u0309'}, 'ủ': {'u', '\u0309'}, 'Ứ': {'Ư', '\u0301'},
+	'ứ': {'ư', '\u0301'}, 'Ừ': {'Ư', '\u0300'}, 'ừ': {'ư', '\u0300'}, 'Ử': {'Ư', '\u0309'},
+	'ử': {'ư', '\u0309'}, 'Ữ': {'Ư', '\u0303'}, 'ữ': {'ư', '\u0303'}, 'Ự': {'Ư', '\u0323'},
+	'ự': {'ư', '\u0323'}, 'Ỳ': {'Y', '\u0300'}, 'ỳ': {'y', '\u0300'}, 'Ỵ': {'Y', '\u0323'},
+	'ỵ': {'y', '\u0323'}, 'Ỷ': {'Y', '\u0309'}, 'ỷ': {'y', '\u0309'}, 'Ỹ': {'Y', '\u0303'},
+	'ỹ': {'y', '\u0303'}, 'が': {'か', '\u3099'}, 'ぎ': {'き', '\u3099'}, 'ぐ': {'く', '\u3099'},
+	'げ': {'け', '\u3099'}, 'ご': {'こ', '\u3099'}, 'ざ': {'さ', '\u3099'}, 'じ': {'し', '\u3099'},
+	'ず': {'す', '\u3099'}, 'ぜ': {'せ', '\u3099'}, 'ぞ': {'そ', '\u3099'}, 'だ': {'た', '\u3099'},
+	'ぢ': {'ち', '\u3099'}, 'づ': {'つ', '\u3099'}, 'で': {'て', '\u3099'}, 'ど': {'と', '\u3099'},
+	'ば': {'は', '\u3099'}, 'ぱ': {'は', '\u309a'}, 'び': {'ひ', '\u3099'}, 'ぴ': {'ひ', '\u309a'},
+	'ぶ': {'ふ', '\u3099'}, 'ぷ': {'ふ', '\u309a'}, 'べ': {'へ', '\u3099'}, 'ぺ': {'へ', '\u309a'},
+	'ぼ': {'ほ', '\u3099'}, 'ぽ': {'ほ', '\u309a'}, 'ゔ': {'う', '\u3099'}, 'ゞ': {'ゝ', '\u3099'},
+	'ガ': {'カ', '\u3099'}, 'ギ': {'キ', '\u3099'}, 'グ': {'ク', '\u3099'}, 'ゲ': {'ケ', '\u3099'},
+	'ゴ': {'コ', '\u3099'}, 'ザ': {'サ', '\u3099'}, 'ジ': {'シ', '\u3099'}, 'ズ': {'ス', '\u3099'},
+	'ゼ': {'セ', '\u3099'}, 'ゾ': {'ソ', '\u3099'}, 'ダ': {'タ', '\u3099'}, 'ヂ': {'チ', '\u3099'},
+	'ヅ': {'ツ', '\u3099'}, 'デ': {'テ', '\u3099'}, 'ド': {'ト', '\u3099'}, 'バ': {'ハ', '\u3099'},
+	'パ': {'ハ', '\u309a'}, 'ビ': {'ヒ', '\u3099'}, 'ピ': {'ヒ', '\u309a'}, 'ブ': {'フ', '\u3099'},
+	'プ': {'フ', '\u309a'}, 'ベ': {'ヘ', '\u3099'}, 'ペ': {'ヘ', '\u309a'}, 'ボ': {'ホ', '\u3099'},
+	'ポ': {'ホ', '\u309a'}, 'ヴ': {'ウ', '\u3099'}, 'ヷ': {'ワ', '\u3099'}, 'ヸ': {'ヰ', '\u3099'},
+	'ヹ': {'ヱ', '\u3099'}, 'ヺ': {'ヲ', '\u3099'}, 'ヾ': {'ヽ', '\u3099'},
+}
+
+var combiningClasses = map[rune]uint8{
+	'\u0300': 230, '\u0301': 230, '\u0302': 230, '\u0303': 230, '\u0304': 230, '\u0305': 230, '\u0306': 230, '\u0307': 230,
+	'\u0308': 230, '\u0309': 230, '\u030a': 230, '\u030b': 230, '\u030c': 230, '\u030d': 230, '\u030e': 230, '\u030f': 230,
+	'\u0310': 230, '\u0311': 230, '\u0312': 230, '\u0313': 230, '\u0314': 230, '\u0315': 232, '\u0316': 220, '\u0317': 220,
+	'\u0318': 220, '\u0319': 220, '\u031a': 232, '\u031b': 216, '\u031c': 220, '\u031d': 220, '\u031e': 220, '\u031f': 220,
+	'\u0320': 220, '\u0321': 202, '\u0322': 202, '\u0323': 220, '\u0324': 220, '\u0325': 220, '\u0326': 220, '\u0327': 202,
+	'\u0328': 202, '\u0329': 220, '\u032a': 220, '\u032b': 220, '\u032c': 220, '\u032d': 220, '\u032e': 220, '\u032f': 220,
+	'\u0330': 220, '\u0331': 220, '\u0332': 220, '\u0333': 220, '\u0334': 1, '\u0335': 1, '\u0336': 1, '\u0337': 1,
+	'\u0338': 1, '\u0339': 220, '\u033a': 220, '\u033b': 220, '\u033c': 220, '\u033d': 230, '\u033e': 230, '\u033f': 230,
+	'\u0340': 230, '\u0341': 230, '\u0342': 230, '\u0343': 230, '\u0344': 230, '\u0345': 240, '\u0346': 230, '\u0347': 220,
+	'\u0348': 220, '\u0349': 220, '\u034a': 230, '\u034b': 230, '\u034c': 230, '\u034d': 220, '\u034e': 220, '\u0350': 230,
+	'\u0351': 230, '\u0352': 230, '\u0353': 220, '\u0354': 220, '\u0355': 220, '\u0356': 220, '\u0357': 230, '\u0358': 232,
+	'\u0359': 220, '\u035a': 220, '\u035b': 230, '\u035c': 233, '\u035d': 234, '\u035e': 234, '\u035f': 233, '\u0360': 234,
+	'\u0361': 234, '\u0362': 233, '\u0363': 230, '\u0364': 230, '\u0365': 230, '\u0366': 230, '\u0367': 230, '\u0368': 230,
+	'\u0369': 230, '\u036a': 230, '\u036b': 230, '\u036c': 230, '\u036d': 230, '\u036e': 230, '\u036f': 230, '\u3099': 8,
+	'\u309a': 8,
+}
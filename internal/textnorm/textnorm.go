@@ -0,0 +1,155 @@
+package textnorm
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	hangulBase  = 0xAC00
+	hangulLBase = 0x1100
+	hangulVBase = 0x1161
+	hangulTBase = 0x11A7
+	hangulLEnd  = hangulLBase + 19
+	hangulVEnd  = hangulVBase + 21
+	hangulTEnd  = hangulTBase + 28
+	hangulEnd   = hangulBase + 19*21*28
+)
+
+var (
+	compositions = make(map[[2]rune]rune, len(decompositions))
+
+	transliterations = map[rune]string{
+		'ß': "ss", 'ẞ': "SS", 'æ': "ae", 'Æ': "AE", 'œ': "oe", 'Œ': "OE",
+		'ø': "o", 'Ø': "O", 'đ': "d", 'Đ': "D", 'ð': "d", 'Ð': "D",
+		'ł': "l", 'Ł': "L", 'þ': "th", 'Þ': "Th", 'ı': "i", 'ħ': "h", 'Ħ': "H",
+		'‘': "'", '’': "'", '‚': "'", '“': "\"", '”': "\"", '„': "\"",
+		'–': "-", '—': "-", '…': "...", '·': ".",
+	}
+)
+
+func init() {
+	for composed, pair := range decompositions {
+		compositions[pair] = composed
+	}
+}
+
+// Compose is a small stand-in for Unicode NFC: it decomposes, canonically
+// orders combining marks and recomposes using only the Latin, Vietnamese,
+// kana and Hangul characters in tables.go. Other scripts pass through as is.
+func Compose(s string) string {
+	if isASCII(s) {
+		return s
+	}
+
+	runes := make([]rune, 0, len(s))
+	for _, r := range s {
+		runes = decompose(runes, r)
+	}
+	reorder(runes)
+
+	out := runes[:0]
+	starter, lastClass := -1, uint8(0)
+	for _, r := range runes {
+		class := combiningClasses[r]
+		if starter >= 0 {
+			blocked := len(out)-1 != starter && (lastClass == 0 || lastClass >= class)
+			if composed, ok := compose(out[starter], r); ok && !blocked {
+				out[starter] = composed
+				continue
+			}
+		}
+		if class == 0 {
+			starter = len(out)
+		}
+		lastClass = class
+		out = append(out, r)
+	}
+	return string(out)
+}
+
+func decompose(out []rune, r rune) []rune {
+	pair, ok := decompositions[r]
+	if !ok {
+		return append(out, r)
+	}
+	return append(decompose(out, pair[0]), pair[1])
+}
+
+func reorder(runes []rune) {
+	for i := 1; i < len(runes); i++ {
+		class := combiningClasses[runes[i]]
+		if class == 0 {
+			continue
+		}
+		for j := i; j > 0; j-- {
+			previous := combiningClasses[runes[j-1]]
+			if previous <= class {
+				break
+			}
+			runes[j-1], runes[j] = runes[j], runes[j-1]
+		}
+	}
+}
+
+func ASCII(s string) (string, bool) {
+	s = Compose(s)
+	if isASCII(s) {
+		return s, true
+	}
+
+	var b strings.Builder
+	ok := true
+	for _, r := range s {
+		switch {
+		case r < utf8.RuneSelf:
+			b.WriteRune(r)
+		case unicode.Is(unicode.Mn, r):
+		case unicode.IsSpace(r):
+			b.WriteByte(' ')
+		default:
+			if t, found := transliterations[r]; found {
+				b.WriteString(t)
+			} else if base := baseLetter(r); base < utf8.RuneSelf {
+				b.WriteRune(base)
+			} else {
+				ok = false
+				b.WriteRune(r)
+			}
+		}
+	}
+	return b.String(), ok
+}
+
+func compose(first, second rune) (rune, bool) {
+	if composed, ok := compositions[[2]rune{first, second}]; ok {
+		return composed, true
+	}
+	switch {
+	case first >= hangulLBase && first < hangulLEnd && second >= hangulVBase && second < hangulVEnd:
+		return hangulBase + ((first-hangulLBase)*21+(second-hangulVBase))*28, true
+	case first >= hangulBase && first < hangulEnd && (first-hangulBase)%28 == 0 && second > hangulTBase && second < hangulTEnd:
+		return first + second - hangulTBase, true
+	}
+	return 0, false
+}
+
+func baseLetter(r rune) rune {
+	for {
+		pair, ok := decompositions[r]
+		if !ok {
+			return r
+		}
+		r = pair[0]
+	}
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,55 @@
+package textnorm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompose(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"Ame\u0301lie":         "Amélie",
+		"Amélie":               "Amélie",
+		"Vie\u0323\u0302t Nam": "Việt Nam",
+		"Vie\u0302\u0323t Nam": "Việt Nam",
+		"Vi\u1ec7t Nam":        "Việt Nam",
+		"Tie\u0302\u0301ng":    "Tiếng",
+		"Ti\u00ea\u0301ng":     "Tiếng",
+		"Tho\u031b\u0300i":     "Thời",
+		"a\u0301\u0301":        "á\u0301",
+		"a\u0327\u0301":        "\u00e1\u0327",
+		"か\u3099っこう":           "がっこう",
+		"\u1112\u1161\u11ab":   "한",
+		"千と千尋の神隠し":             "千と千尋の神隠し",
+		"The Office":           "The Office",
+	}
+
+	for in, want := range tests {
+		assert.Equal(t, want, Compose(in), in)
+	}
+}
+
+func TestASCII(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"Amélie", "Amelie", true},
+		{"Amélie", "Amelie", true},
+		{"Mañana, Straße, Ærø", "Manana, Strasse, AEro", true},
+		{"Don’t Look Up", "Don't Look Up", true},
+		{"Việt Nam", "Viet Nam", true},
+		{"千と千尋の神隠し", "千と千尋の神隠し", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ASCII(tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+		assert.Equal(t, tt.ok, ok, tt.in)
+	}
+}
@@ -0,0 +1,63 @@
+package translate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	deepLURL     = "https://api.deepl.com"
+	deepLFreeURL = "https://api-free.deepl.com"
+)
+
+type deepL struct {
+	opts Options
+}
+
+func newDeepL(opts Options) *deepL {
+	if opts.URL == "" {
+		opts.URL = deepLURL
+		if strings.HasSuffix(opts.APIKey, ":fx") {
+			opts.URL = deepLFreeURL
+		}
+	}
+	return &deepL{opts: opts}
+}
+
+func (d *deepL) Name() string {
+	return BackendDeepL
+}
+
+func (d *deepL) Translate(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	var result struct {
+		Translations []struct {
+			Text string `json:"text"`
+		} `json:"translations"`
+	}
+
+	source, _, _ := strings.Cut(from, "-")
+	resp, err := newClient(d.opts.URL, d.opts.Timeout).R().
+		SetContext(ctx).
+		SetHeader("Authorization", "DeepL-Auth-Key "+d.opts.APIKey).
+		SetBody(map[string]interface{}{
+			"text":         texts,
+			"source_lang":  strings.ToUpper(source),
+			"target_lang":  strings.ToUpper(to),
+			"tag_handling": "html",
+		}).
+		SetResult(&result).
+		Post("/v2/translate")
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode(), resp.String())
+	}
+
+	translated := make([]string, 0, len(result.Translations))
+	for _, translation := range result.Translations {
+		translated = append(translated, translation.Text)
+	}
+	return translated, nil
+}
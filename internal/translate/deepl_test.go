@@ -0,0 +1,38 @@
+package translate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeepLTranslate(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/translate", r.URL.Path)
+		assert.Equal(t, "DeepL-Auth-Key secret", r.Header.Get("Authorization"))
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "EN", body["source_lang"])
+		assert.Equal(t, "PT-BR", body["target_lang"])
+		assert.Equal(t, []interface{}{"Hello", "<i>Bye</i>"}, body["text"])
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"translations": []map[string]string{{"text": "Olá"}, {"text": "<i>Tchau</i>"}}})
+	}))
+	t.Cleanup(server.Close)
+
+	translator, err := New(Options{Backend: BackendDeepL, APIKey: "secret", URL: server.URL})
+	require.NoError(t, err)
+
+	translated, err := translator.Translate(context.Background(), []string{"Hello", "<i>Bye</i>"}, "en-US", "pt-BR")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Olá", "<i>Tchau</i>"}, translated)
+}
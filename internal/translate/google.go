@@ -0,0 +1,58 @@
+package translate
+
+import (
+	"context"
+	"fmt"
+	"html"
+)
+
+const googleURL = "https://translation.googleapis.com"
+
+type google struct {
+	opts Options
+}
+
+func newGoogle(opts Options) *google {
+	if opts.URL == "" {
+		opts.URL = googleURL
+	}
+	return &google{opts: opts}
+}
+
+func (g *google) Name() string {
+	return BackendGoogle
+}
+
+func (g *google) Translate(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	var result struct {
+		Data struct {
+			Translations []struct {
+				TranslatedText string `json:"translatedText"`
+			} `json:"translations"`
+		} `json:"data"`
+	}
+
+	resp, err := newClient(g.opts.URL, g.opts.Timeout).R().
+		SetContext(ctx).
+		SetQueryParam("key", g.opts.APIKey).
+		SetBody(map[string]interface{}{
+			"q":      texts,
+			"source": from,
+			"target": to,
+			"format": "text",
+		}).
+		SetResult(&result).
+		Post("/language/translate/v2")
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode(), resp.String())
+	}
+
+	translated := make([]string, 0, len(result.Data.Translations))
+	for _, translation := range result.Data.Translations {
+		translated = append(translated, html.UnescapeString(translation.TranslatedText))
+	}
+	return translated, nil
+}
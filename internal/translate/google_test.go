@@ -0,0 +1,37 @@
+package translate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoogleTranslate(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/language/translate/v2", r.URL.Path)
+		assert.Equal(t, "secret", r.URL.Query().Get("key"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("key") != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+			"translations": []map[string]string{{"translatedText": "Tom &amp; Jerry"}},
+		}})
+	}))
+	t.Cleanup(server.Close)
+
+	translator, err := New(Options{Backend: BackendGoogle, APIKey: "secret", URL: server.URL})
+	require.NoError(t, err)
+	translated, err := translator.Translate(context.Background(), []string{"Tom & Jerry"}, "en", "pt-BR")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Tom & Jerry"}, translated)
+}
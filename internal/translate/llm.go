@@ -0,0 +1,84 @@
+package translate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/internal/language"
+)
+
+const llmURL = "http://localhost:11434/v1"
+
+type llm struct {
+	opts Options
+}
+
+func newLLM(opts Options) *llm {
+	if opts.URL == "" {
+		opts.URL = llmURL
+	}
+	return &llm{opts: opts}
+}
+
+func (l *llm) Name() string {
+	return BackendLLM
+}
+
+func (l *llm) Translate(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	input, err := json.Marshal(texts)
+	if err != nil {
+		return nil, err
+	}
+
+	prompt := fmt.Sprintf("Translate these movie subtitle lines from %s to %s. "+
+		"Keep line breaks and formatting tags. Reply with only a JSON array of %d strings, one per input line, in the same order.\n\n%s",
+		language.DisplayName(from), language.DisplayName(to), len(texts), input)
+
+	var result struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+
+	req := newClient(l.opts.URL, l.opts.Timeout).R().
+		SetContext(ctx).
+		SetBody(map[string]interface{}{
+			"model":       l.opts.Model,
+			"temperature": 0,
+			"messages": []map[string]string{
+				{"role": "system", "content": "You are a professional subtitle translator."},
+				{"role": "user", "content": prompt},
+			},
+		}).
+		SetResult(&result)
+	if l.opts.APIKey != "" {
+		req.SetAuthToken(l.opts.APIKey)
+	}
+
+	resp, err := req.Post("/chat/completions")
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode(), resp.String())
+	}
+	if len(result.Choices) == 0 {
+		return nil, fmt.Errorf("response contained no choices")
+	}
+
+	content := strings.TrimSpace(result.Choices[0].Message.Content)
+	start, end := strings.Index(content, "["), strings.LastIndex(content, "]")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("response is not a JSON array")
+	}
+
+	var translated []string
+	if err := json.Unmarshal([]byte(content[start:end+1]), &translated); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return translated, nil
+}
@@ -0,0 +1,46 @@
+package translate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLLMTranslate(t *testing.T) {
+	t.Parallel()
+
+	reply := "Here you go:\n```json\n[\"Olá\", \"Tchau\\namigo\"]\n```"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/chat/completions", r.URL.Path)
+
+		var body struct {
+			Model    string `json:"model"`
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "llama3", body.Model)
+		assert.Contains(t, body.Messages[1].Content, "from English to Portuguese (Brazil)")
+		assert.Contains(t, body.Messages[1].Content, `["Hello","Bye\nfriend"]`)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"choices": []map[string]interface{}{{"message": map[string]string{"content": reply}}}})
+	}))
+	t.Cleanup(server.Close)
+
+	translator, err := New(Options{Backend: BackendLLM, Model: "llama3", URL: server.URL})
+	require.NoError(t, err)
+	translated, err := translator.Translate(context.Background(), []string{"Hello", "Bye\nfriend"}, "en", "pt-BR")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Olá", "Tchau\namigo"}, translated)
+
+	reply = "Sorry, I can't."
+	_, err = translator.Translate(context.Background(), []string{"Hello", "Bye\nfriend"}, "en", "pt-BR")
+	assert.EqualError(t, err, "response is not a JSON array")
+}
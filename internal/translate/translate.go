@@ -0,0 +1,103 @@
+package translate
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/carlosarraes/subs-cli/internal/subformat"
+)
+
+const (
+	BackendDeepL  = "deepl"
+	BackendGoogle = "google"
+	BackendLLM    = "llm"
+
+	DefaultTimeout = 60 * time.Second
+
+	batchSize = 50
+)
+
+var Backends = []string{BackendDeepL, BackendGoogle, BackendLLM}
+
+type Translator interface {
+	Name() string
+	Translate(ctx context.Context, texts []string, from, to string) ([]string, error)
+}
+
+type Options struct {
+	Backend string
+	APIKey  string
+	URL     string
+	Model   string
+	Timeout time.Duration
+}
+
+func New(opts Options) (Translator, error) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+
+	switch opts.Backend {
+	case BackendDeepL:
+		if opts.APIKey == "" {
+			return nil, fmt.Errorf("the deepl backend needs translate.api_key")
+		}
+		return newDeepL(opts), nil
+	case BackendGoogle:
+		if opts.APIKey == "" {
+			return nil, fmt.Errorf("the google backend needs translate.api_key")
+		}
+		return newGoogle(opts), nil
+	case BackendLLM:
+		if opts.Model == "" {
+			return nil, fmt.Errorf("the llm backend needs translate.model")
+		}
+		return newLLM(opts), nil
+	case "":
+		return nil, fmt.Errorf("no translation backend configured; set translate.backend in the config file")
+	}
+	return nil, fmt.Errorf("unknown translation backend '%s'", opts.Backend)
+}
+
+func newClient(baseURL string, timeout time.Duration) *resty.Client {
+	return resty.New().
+		SetBaseURL(baseURL).
+		SetTimeout(timeout).
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Accept", "application/json")
+}
+
+func SRT(ctx context.Context, translator Translator, content []byte, from, to string) ([]byte, error) {
+	if format := subformat.Detect(content); format != subformat.SRT {
+		return nil, fmt.Errorf("only SubRip subtitles can be translated (detected %q)", format)
+	}
+
+	cues := subformat.Cues(content, subformat.SRT, 0)
+	texts := make([]string, len(cues))
+	for i, cue := range cues {
+		texts[i] = cue.Text
+	}
+
+	translated := make([]string, 0, len(texts))
+	for start := 0; start < len(texts); start += batchSize {
+		batch := texts[start:min(start+batchSize, len(texts))]
+		result, err := translator.Translate(ctx, batch, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("%s translation failed: %w", translator.Name(), err)
+		}
+		if len(result) != len(batch) {
+			return nil, fmt.Errorf("%s translation returned %d lines for %d subtitles", translator.Name(), len(result), len(batch))
+		}
+		translated = append(translated, result...)
+	}
+
+	var buf bytes.Buffer
+	for i, cue := range cues {
+		fmt.Fprintf(&buf, "%d\n%s --> %s\n%s\n\n", i+1, cue.Start, cue.End, translated[i])
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,85 @@
+package translate
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type upperTranslator struct {
+	batches [][]string
+	drop    bool
+}
+
+func (u *upperTranslator) Name() string {
+	return "upper"
+}
+
+func (u *upperTranslator) Translate(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	u.batches = append(u.batches, texts)
+	translated := make([]string, 0, len(texts))
+	for _, text := range texts {
+		translated = append(translated, strings.ToUpper(text))
+	}
+	if u.drop {
+		translated = translated[1:]
+	}
+	return translated, nil
+}
+
+func TestSRT(t *testing.T) {
+	t.Parallel()
+
+	var content strings.Builder
+	for i := 1; i <= 60; i++ {
+		content.WriteString("1\r\n00:00:01,000 --> 00:00:02,000\r\nline one\r\nline two\r\n\r\n")
+	}
+
+	translator := &upperTranslator{}
+	translated, err := SRT(context.Background(), translator, []byte(content.String()), "en", "pt-BR")
+	require.NoError(t, err)
+	assert.Len(t, translator.batches, 2)
+	assert.Len(t, translator.batches[1], 10)
+	assert.True(t, strings.HasPrefix(string(translated), "1\n00:00:01,000 --> 00:00:02,000\nLINE ONE\nLINE TWO\n\n2\n"))
+	assert.Contains(t, string(translated), "60\n00:00:01,000 --> 00:00:02,000\nLINE ONE\nLINE TWO\n\n")
+
+	_, err = SRT(context.Background(), &upperTranslator{drop: true}, []byte(content.String()), "en", "pt-BR")
+	assert.EqualError(t, err, "upper translation returned 49 lines for 50 subtitles")
+
+	_, err = SRT(context.Background(), translator, []byte("WEBVTT\n\n00:00.000 --> 00:01.000\nHi\n"), "en", "pt-BR")
+	assert.ErrorContains(t, err, "only SubRip subtitles can be translated")
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		opts     Options
+		name     string
+		errorMsg string
+	}{
+		{opts: Options{Backend: BackendDeepL, APIKey: "key"}, name: BackendDeepL},
+		{opts: Options{Backend: BackendGoogle, APIKey: "key"}, name: BackendGoogle},
+		{opts: Options{Backend: BackendLLM, Model: "llama3"}, name: BackendLLM},
+		{opts: Options{Backend: BackendDeepL}, errorMsg: "the deepl backend needs translate.api_key"},
+		{opts: Options{Backend: BackendLLM}, errorMsg: "the llm backend needs translate.model"},
+		{opts: Options{}, errorMsg: "no translation backend configured"},
+		{opts: Options{Backend: "babelfish"}, errorMsg: "unknown translation backend 'babelfish'"},
+	}
+
+	for _, tt := range tests {
+		translator, err := New(tt.opts)
+		if tt.errorMsg != "" {
+			assert.ErrorContains(t, err, tt.errorMsg)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, tt.name, translator.Name())
+	}
+
+	assert.Equal(t, deepLFreeURL, newDeepL(Options{APIKey: "abc:fx"}).opts.URL)
+	assert.Equal(t, deepLURL, newDeepL(Options{APIKey: "abc"}).opts.URL)
+}
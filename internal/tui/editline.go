@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+type lineEditor struct {
+	prompt string
+	runes  []rune
+	cursor int
+}
+
+func newLineEditor(prompt, initial string) *lineEditor {
+	runes := []rune(initial)
+	return &lineEditor{prompt: prompt, runes: runes, cursor: len(runes)}
+}
+
+func (e *lineEditor) handle(key string) (done, cancelled bool) {
+	switch key {
+	case "enter":
+		return true, false
+	case "esc", "ctrl+c":
+		return true, true
+	case "left":
+		if e.cursor > 0 {
+			e.cursor--
+		}
+	case "right":
+		if e.cursor < len(e.runes) {
+			e.cursor++
+		}
+	case "home":
+		e.cursor = 0
+	case "end":
+		e.cursor = len(e.runes)
+	case "backspace":
+		if e.cursor > 0 {
+			e.runes = append(e.runes[:e.cursor-1], e.runes[e.cursor:]...)
+			e.cursor--
+		}
+	case "delete":
+		if e.cursor < len(e.runes) {
+			e.runes = append(e.runes[:e.cursor], e.runes[e.cursor+1:]...)
+		}
+	case "space":
+		e.insert(' ')
+	default:
+		if r := []rune(key); len(r) == 1 {
+			e.insert(r[0])
+		}
+	}
+	return false, false
+}
+
+func (e *lineEditor) insert(r rune) {
+	e.runes = append(e.runes[:e.cursor], append([]rune{r}, e.runes[e.cursor:]...)...)
+	e.cursor++
+}
+
+func (e *lineEditor) value() string {
+	return string(e.runes)
+}
+
+func (e *lineEditor) render() string {
+	var b strings.Builder
+	b.WriteString("\r\x1b[K")
+	b.WriteString(e.prompt)
+	b.WriteString(string(e.runes))
+	if back := len(e.runes) - e.cursor; back > 0 {
+		fmt.Fprintf(&b, "\x1b[%dD", back)
+	}
+	return b.String()
+}
+
+func EditLine(in *os.File, out io.Writer, prompt, initial string) (string, bool, error) {
+	restore, err := enterRawMode(in)
+	if err != nil {
+		return "", false, err
+	}
+	defer restore()
+
+	editor := newLineEditor(prompt, initial)
+	fmt.Fprint(out, editor.render())
+
+	buf := make([]byte, 64)
+	for {
+		n, err := in.Read(buf)
+		if err != nil {
+			fmt.Fprint(out, "\r\n")
+			return "", false, err
+		}
+		for _, key := range ParseKeys(buf[:n]) {
+			done, cancelled := editor.handle(key.Key)
+			if done {
+				fmt.Fprint(out, "\r\n")
+				return editor.value(), !cancelled, nil
+			}
+		}
+		fmt.Fprint(out, editor.render())
+	}
+}
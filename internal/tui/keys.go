@@ -0,0 +1,68 @@
+package tui
+
+import "unicode/utf8"
+
+var escapeSequences = map[string]string{
+	"[A":  "up",
+	"[B":  "down",
+	"[C":  "right",
+	"[D":  "left",
+	"[H":  "home",
+	"[F":  "end",
+	"[Z":  "shift+tab",
+	"[5~": "pgup",
+	"[6~": "pgdown",
+	"[3~": "delete",
+	"OA":  "up",
+	"OB":  "down",
+	"OC":  "right",
+	"OD":  "left",
+}
+
+func ParseKeys(b []byte) []KeyMsg {
+	var keys []KeyMsg
+	for len(b) > 0 {
+		if b[0] == 0x1b {
+			if len(b) == 1 {
+				keys = append(keys, KeyMsg{Key: "esc"})
+				break
+			}
+			matched := false
+			for seq, name := range escapeSequences {
+				if len(b) > len(seq) && string(b[1:1+len(seq)]) == seq {
+					keys = append(keys, KeyMsg{Key: name})
+					b = b[1+len(seq):]
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				keys = append(keys, KeyMsg{Key: "esc"})
+				b = b[1:]
+			}
+			continue
+		}
+
+		switch b[0] {
+		case 0x03:
+			keys = append(keys, KeyMsg{Key: "ctrl+c"})
+		case '\r', '\n':
+			keys = append(keys, KeyMsg{Key: "enter"})
+		case '\t':
+			keys = append(keys, KeyMsg{Key: "tab"})
+		case 0x7f, 0x08:
+			keys = append(keys, KeyMsg{Key: "backspace"})
+		case ' ':
+			keys = append(keys, KeyMsg{Key: "space"})
+		default:
+			r, size := utf8.DecodeRune(b)
+			if r >= 0x20 && r != utf8.RuneError {
+				keys = append(keys, KeyMsg{Key: string(r)})
+			}
+			b = b[size:]
+			continue
+		}
+		b = b[1:]
+	}
+	return keys
+}
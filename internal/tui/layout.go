@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+func Fit(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	count := utf8.RuneCountInString(s)
+	if count <= width {
+		return s + strings.Repeat(" ", width-count)
+	}
+	if width == 1 {
+		return "…"
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + "…"
+}
+
+func Box(title string, lines []string, width, height int, focused bool) []string {
+	if width < 4 || height < 2 {
+		return nil
+	}
+
+	horizontal, vertical := "─", "│"
+	topLeft, topRight, bottomLeft, bottomRight := "┌", "┐", "└", "┘"
+	if focused {
+		horizontal, vertical = "━", "┃"
+		topLeft, topRight, bottomLeft, bottomRight = "┏", "┓", "┗", "┛"
+	}
+
+	inner := width - 2
+	header := " " + title + " "
+	if utf8.RuneCountInString(header) > inner {
+		header = Fit(header, inner)
+	}
+	header += strings.Repeat(horizontal, inner-utf8.RuneCountInString(header))
+
+	box := make([]string, 0, height)
+	box = append(box, topLeft+header+topRight)
+	for i := 0; i < height-2; i++ {
+		line := ""
+		if i < len(lines) {
+			line = lines[i]
+		}
+		box = append(box, vertical+Fit(line, inner)+vertical)
+	}
+	box = append(box, bottomLeft+strings.Repeat(horizontal, inner)+bottomRight)
+	return box
+}
+
+func JoinHorizontal(left, right []string) []string {
+	height := len(left)
+	if len(right) > height {
+		height = len(right)
+	}
+
+	leftWidth := 0
+	if len(left) > 0 {
+		leftWidth = utf8.RuneCountInString(left[0])
+	}
+
+	joined := make([]string, height)
+	for i := range joined {
+		l := strings.Repeat(" ", leftWidth)
+		if i < len(left) {
+			l = left[i]
+		}
+		r := ""
+		if i < len(right) {
+			r = right[i]
+		}
+		joined[i] = l + r
+	}
+	return joined
+}
+
+func Window(total, cursor, height int) (int, int) {
+	if height <= 0 || total <= height {
+		return 0, total
+	}
+	start := cursor - height/2
+	if start < 0 {
+		start = 0
+	}
+	if start+height > total {
+		start = total - height
+	}
+	return start, start + height
+}
@@ -0,0 +1,12 @@
+package tui
+
+import "errors"
+
+const (
+	defaultWidth  = 100
+	defaultHeight = 30
+)
+
+// ErrNoTerminal is returned when the input cannot be switched to raw mode,
+// for example when it is not a terminal or the platform has no stty.
+var ErrNoTerminal = errors.New("the TUI needs an interactive terminal")
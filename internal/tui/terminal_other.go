@@ -0,0 +1,16 @@
+//go:build !unix
+
+package tui
+
+import (
+	"fmt"
+	"os"
+)
+
+func enterRawMode(in *os.File) (func(), error) {
+	return nil, fmt.Errorf("%w: raw mode is only available on Unix terminals", ErrNoTerminal)
+}
+
+func terminalSize(in *os.File) (int, int) {
+	return defaultWidth, defaultHeight
+}
@@ -0,0 +1,57 @@
+//go:build unix
+
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+func stty(in *os.File, args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = in
+	out, err := cmd.Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+func enterRawMode(in *os.File) (func(), error) {
+	if _, err := exec.LookPath("stty"); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoTerminal, err)
+	}
+
+	saved, err := stty(in, "-g")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s is not a terminal", ErrNoTerminal, in.Name())
+	}
+
+	if _, err := stty(in, "raw", "-echo"); err != nil {
+		return nil, fmt.Errorf("failed to switch terminal to raw mode: %w", err)
+	}
+
+	return func() {
+		stty(in, saved)
+	}, nil
+}
+
+func terminalSize(in *os.File) (int, int) {
+	out, err := stty(in, "size")
+	if err != nil {
+		return defaultWidth, defaultHeight
+	}
+
+	fields := strings.Fields(out)
+	if len(fields) != 2 {
+		return defaultWidth, defaultHeight
+	}
+
+	rows, err1 := strconv.Atoi(fields[0])
+	cols, err2 := strconv.Atoi(fields[1])
+	if err1 != nil || err2 != nil || rows <= 0 || cols <= 0 {
+		return defaultWidth, defaultHeight
+	}
+
+	return cols, rows
+}
@@ -0,0 +1,177 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Msg interface{}
+
+type Cmd func() Msg
+
+type KeyMsg struct {
+	Key string
+}
+
+type ResizeMsg struct {
+	Width  int
+	Height int
+}
+
+type quitMsg struct{}
+
+type BatchMsg []Cmd
+
+type Model interface {
+	Init() Cmd
+	Update(msg Msg) (Model, Cmd)
+	View(width, height int) string
+}
+
+func Quit() Msg {
+	return quitMsg{}
+}
+
+func Batch(cmds ...Cmd) Cmd {
+	var valid []Cmd
+	for _, cmd := range cmds {
+		if cmd != nil {
+			valid = append(valid, cmd)
+		}
+	}
+	if len(valid) == 0 {
+		return nil
+	}
+	return func() Msg {
+		return BatchMsg(valid)
+	}
+}
+
+type Program struct {
+	model  Model
+	in     *os.File
+	out    io.Writer
+	msgs   chan Msg
+	width  int
+	height int
+	done   chan struct{}
+	once   sync.Once
+}
+
+func NewProgram(model Model) *Program {
+	return &Program{
+		model: model,
+		in:    os.Stdin,
+		out:   os.Stdout,
+		msgs:  make(chan Msg, 64),
+		done:  make(chan struct{}),
+	}
+}
+
+func (p *Program) Run() (Model, error) {
+	restore, err := enterRawMode(p.in)
+	if err != nil {
+		return p.model, err
+	}
+	defer restore()
+
+	fmt.Fprint(p.out, "\x1b[?1049h\x1b[?25l")
+	defer fmt.Fprint(p.out, "\x1b[?25h\x1b[?1049l")
+	defer p.once.Do(func() { close(p.done) })
+
+	p.width, p.height = terminalSize(p.in)
+	go p.readKeys()
+	go p.watchSize()
+
+	p.exec(p.model.Init())
+	p.render()
+
+	for msg := range p.msgs {
+		switch msg := msg.(type) {
+		case quitMsg:
+			return p.model, nil
+		case BatchMsg:
+			for _, cmd := range msg {
+				p.exec(cmd)
+			}
+			continue
+		case ResizeMsg:
+			p.width, p.height = msg.Width, msg.Height
+		}
+
+		var cmd Cmd
+		p.model, cmd = p.model.Update(msg)
+		p.exec(cmd)
+		p.render()
+	}
+
+	return p.model, nil
+}
+
+func (p *Program) exec(cmd Cmd) {
+	if cmd == nil {
+		return
+	}
+	go func() {
+		msg := cmd()
+		if msg == nil {
+			return
+		}
+		select {
+		case p.msgs <- msg:
+		case <-p.done:
+		}
+	}()
+}
+
+func (p *Program) send(msg Msg) {
+	select {
+	case p.msgs <- msg:
+	case <-p.done:
+	}
+}
+
+func (p *Program) readKeys() {
+	buf := make([]byte, 256)
+	for {
+		n, err := p.in.Read(buf)
+		if err != nil {
+			p.send(quitMsg{})
+			return
+		}
+		for _, key := range ParseKeys(buf[:n]) {
+			p.send(key)
+		}
+	}
+}
+
+func (p *Program) watchSize() {
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+	width, height := p.width, p.height
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			w, h := terminalSize(p.in)
+			if w != width || h != height {
+				width, height = w, h
+				p.send(ResizeMsg{Width: w, Height: h})
+			}
+		}
+	}
+}
+
+func (p *Program) render() {
+	view := p.model.View(p.width, p.height)
+	lines := strings.Split(view, "\n")
+	if len(lines) > p.height {
+		lines = lines[:p.height]
+	}
+	fmt.Fprint(p.out, "\x1b[H"+strings.Join(lines, "\x1b[K\r\n")+"\x1b[K\x1b[J")
+}
@@ -0,0 +1,181 @@
+package tui
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func keyNames(keys []KeyMsg) []string {
+	names := []string{}
+	for _, key := range keys {
+		names = append(names, key.Key)
+	}
+	return names
+}
+
+func TestParseKeys(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{name: "arrows", input: "\x1b[A\x1b[B\x1b[C\x1b[D", expected: []string{"up", "down", "right", "left"}},
+		{name: "application mode arrows", input: "\x1bOA\x1bOB", expected: []string{"up", "down"}},
+		{name: "control keys", input: "\r\t\x7f\x03 ", expected: []string{"enter", "tab", "backspace", "ctrl+c", "space"}},
+		{name: "shift tab and paging", input: "\x1b[Z\x1b[5~\x1b[6~", expected: []string{"shift+tab", "pgup", "pgdown"}},
+		{name: "lone escape", input: "\x1b", expected: []string{"esc"}},
+		{name: "unknown sequence", input: "\x1b[99x", expected: []string{"esc", "[", "9", "9", "x"}},
+		{name: "runes", input: "qé日", expected: []string{"q", "é", "日"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, keyNames(ParseKeys([]byte(tt.input))))
+		})
+	}
+}
+
+func TestFit(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "abc  ", Fit("abc", 5))
+	assert.Equal(t, "abcd…", Fit("abcdefgh", 5))
+	assert.Equal(t, "日本…", Fit("日本語です", 3))
+	assert.Equal(t, "", Fit("abc", 0))
+}
+
+func TestBox(t *testing.T) {
+	t.Parallel()
+
+	box := Box("Files", []string{"one", "two", "three"}, 12, 4, false)
+
+	require.Len(t, box, 4)
+	assert.Equal(t, "┌ Files ───┐", box[0])
+	assert.Equal(t, "│one       │", box[1])
+	assert.Equal(t, "│two       │", box[2])
+	assert.Equal(t, "└──────────┘", box[3])
+	for _, line := range box {
+		assert.Equal(t, 12, utf8.RuneCountInString(line))
+	}
+
+	focused := Box("Files", nil, 12, 3, true)
+	assert.True(t, strings.HasPrefix(focused[0], "┏"))
+
+	assert.Nil(t, Box("x", nil, 3, 3, false))
+}
+
+func TestJoinHorizontal(t *testing.T) {
+	t.Parallel()
+
+	joined := JoinHorizontal([]string{"ab", "cd", "ef"}, []string{"1", "2"})
+
+	assert.Equal(t, []string{"ab1", "cd2", "ef"}, joined)
+	assert.Equal(t, []string{"ab1", "  2"}, JoinHorizontal([]string{"ab"}, []string{"1", "2"}))
+}
+
+func TestWindow(t *testing.T) {
+	t.Parallel()
+
+	start, end := Window(5, 2, 10)
+	assert.Equal(t, []int{0, 5}, []int{start, end})
+
+	start, end = Window(100, 50, 10)
+	assert.Equal(t, []int{45, 55}, []int{start, end})
+
+	start, end = Window(100, 99, 10)
+	assert.Equal(t, []int{90, 100}, []int{start, end})
+
+	start, end = Window(100, 1, 10)
+	assert.Equal(t, []int{0, 10}, []int{start, end})
+}
+
+func TestBatch(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, Batch(nil, nil))
+
+	cmd := Batch(func() Msg { return "a" }, nil, func() Msg { return "b" })
+	require.NotNil(t, cmd)
+
+	batch, ok := cmd().(BatchMsg)
+	require.True(t, ok)
+	assert.Len(t, batch, 2)
+}
+
+func TestLineEditor(t *testing.T) {
+	t.Parallel()
+
+	press := func(editor *lineEditor, keys ...string) (bool, bool) {
+		var done, cancelled bool
+		for _, key := range keys {
+			done, cancelled = editor.handle(key)
+			if done {
+				break
+			}
+		}
+		return done, cancelled
+	}
+
+	t.Run("starts with initial text and appends", func(t *testing.T) {
+		t.Parallel()
+
+		editor := newLineEditor("Query: ", "Dark Mater")
+		done, cancelled := press(editor, "left", "left", "t", "end", "space", "2", "0", "2", "4", "enter")
+
+		assert.True(t, done)
+		assert.False(t, cancelled)
+		assert.Equal(t, "Dark Matter 2024", editor.value())
+	})
+
+	t.Run("deletes around the cursor", func(t *testing.T) {
+		t.Parallel()
+
+		editor := newLineEditor("", "abcd")
+		press(editor, "backspace", "home", "delete", "right", "backspace")
+
+		assert.Equal(t, "c", editor.value())
+	})
+
+	t.Run("escape cancels", func(t *testing.T) {
+		t.Parallel()
+
+		editor := newLineEditor("", "abc")
+		done, cancelled := press(editor, "x", "esc")
+
+		assert.True(t, done)
+		assert.True(t, cancelled)
+	})
+
+	t.Run("render places the cursor", func(t *testing.T) {
+		t.Parallel()
+
+		editor := newLineEditor("> ", "abc")
+		assert.Equal(t, "\r\x1b[K> abc", editor.render())
+
+		press(editor, "left", "left")
+		assert.Equal(t, "\r\x1b[K> abc\x1b[2D", editor.render())
+	})
+}
+
+func TestRunWithoutTerminal(t *testing.T) {
+	t.Parallel()
+
+	in, err := os.Open(os.DevNull)
+	require.NoError(t, err)
+	defer in.Close()
+
+	var out strings.Builder
+	program := NewProgram(nil)
+	program.in, program.out = in, &out
+	_, err = program.Run()
+	assert.ErrorIs(t, err, ErrNoTerminal)
+	assert.Empty(t, out.String(), "nothing is drawn before raw mode is on")
+}
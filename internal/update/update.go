@@ -0,0 +1,144 @@
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/carlosarraes/subs-cli/internal/fsutil"
+)
+
+const (
+	DefaultBaseURL = "https://api.github.com"
+	Repository     = "carlosarraes/subs-cli"
+
+	CheckInterval  = 24 * time.Hour
+	DefaultTimeout = 3 * time.Second
+)
+
+type Release struct {
+	Version string `json:"tag_name"`
+	URL     string `json:"html_url"`
+}
+
+type Client struct {
+	client *resty.Client
+}
+
+func NewClient(baseURL string, timeout time.Duration) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	client := resty.New()
+	client.SetBaseURL(baseURL)
+	client.SetTimeout(timeout)
+	client.SetHeader("Accept", "application/vnd.github+json")
+	client.SetHeader("User-Agent", "subs-cli")
+	return &Client{client: client}
+}
+
+func (c *Client) Latest(ctx context.Context) (*Release, error) {
+	var release Release
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetResult(&release).
+		Get("/repos/" + Repository + "/releases/latest")
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for updates: %w", err)
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to check for updates: %s", resp.Status())
+	}
+	if release.Version == "" {
+		return nil, fmt.Errorf("failed to check for updates: release has no tag")
+	}
+	return &release, nil
+}
+
+func Newer(current, latest string) bool {
+	cur, ok := parseVersion(current)
+	if !ok {
+		return false
+	}
+	next, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	for i := range cur {
+		if next[i] != cur[i] {
+			return next[i] > cur[i]
+		}
+	}
+	return false
+}
+
+func parseVersion(version string) ([3]int, bool) {
+	var parts [3]int
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+
+	fields := strings.Split(version, ".")
+	if len(fields) == 0 || len(fields) > 3 {
+		return parts, false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
+
+type State struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Latest    string    `json:"latest,omitempty"`
+	URL       string    `json:"url,omitempty"`
+}
+
+func (s State) Due(now time.Time) bool {
+	return now.Sub(s.CheckedAt) >= CheckInterval || now.Before(s.CheckedAt)
+}
+
+func LoadState(path string) (State, error) {
+	var state State
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("failed to read update state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return State{}, fmt.Errorf("invalid update state %s: %w", path, err)
+	}
+	return state, nil
+}
+
+func SaveState(path string, state State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode update state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create update state directory: %w", err)
+	}
+	if err := fsutil.WriteFile(path, data, fsutil.WriteOptions{Perm: 0600}); err != nil {
+		return fmt.Errorf("failed to write update state: %w", err)
+	}
+	return nil
+}
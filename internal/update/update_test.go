@@ -0,0 +1,78 @@
+package update
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		current, latest string
+		want            bool
+	}{
+		{"v1.2.0", "v1.3.0", true},
+		{"1.2.0", "v1.2.1", true},
+		{"v1.2.9", "v1.10.0", true},
+		{"v1.2.0", "v1.2.0", false},
+		{"v1.3.0", "v1.2.5", false},
+		{"v1.2.0-rc1", "v1.2.0", false},
+		{"v2", "v1.9.9", false},
+		{"dev", "v1.0.0", false},
+		{"v1.0.0", "nightly", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Newer(tt.current, tt.latest), "%s -> %s", tt.current, tt.latest)
+	}
+}
+
+func TestClientLatest(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/"+Repository+"/releases/latest", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"tag_name": "v1.4.0", "html_url": "https://github.com/carlosarraes/subs-cli/releases/tag/v1.4.0"}`))
+	}))
+	defer server.Close()
+
+	release, err := NewClient(server.URL, time.Second).Latest(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "v1.4.0", release.Version)
+	assert.Equal(t, "https://github.com/carlosarraes/subs-cli/releases/tag/v1.4.0", release.URL)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer failing.Close()
+
+	_, err = NewClient(failing.URL, time.Second).Latest(context.Background())
+	assert.ErrorContains(t, err, "403")
+}
+
+func TestState(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "state", "update.json")
+	state, err := LoadState(path)
+	require.NoError(t, err)
+	assert.True(t, state.Due(time.Now()))
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, SaveState(path, State{CheckedAt: now, Latest: "v1.4.0"}))
+
+	state, err = LoadState(path)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.4.0", state.Latest)
+	assert.False(t, state.Due(now.Add(23*time.Hour)))
+	assert.True(t, state.Due(now.Add(24*time.Hour)))
+	assert.True(t, state.Due(now.Add(-time.Hour)), "a clock that went backwards checks again")
+}
@@ -0,0 +1,10 @@
+package models
+
+import "errors"
+
+var (
+	ErrNotFound            = errors.New("not found")
+	ErrQuotaExceeded       = errors.New("quota exceeded")
+	ErrAuthFailed          = errors.New("authentication failed")
+	ErrProviderUnavailable = errors.New("provider unavailable")
+)
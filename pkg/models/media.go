@@ -10,34 +10,88 @@ type MediaInfo struct {
 	Quality  string `json:"quality,omitempty"`
 	Source   string `json:"source,omitempty"`
 	Codec    string `json:"codec,omitempty"`
+	Audio    string `json:"audio,omitempty"`
+	Channels string `json:"channels,omitempty"`
 	Language string `json:"language,omitempty"`
+	Checksum string `json:"checksum,omitempty"`
 	Type     string `json:"type"`
 }
 
 type SearchParams struct {
-	Query     string `json:"query"`
-	Language  string `json:"language"`
-	Season    int    `json:"season,omitempty"`
-	Episode   int    `json:"episode,omitempty"`
-	Year      int    `json:"year,omitempty"`
-	Type      string `json:"type"`
-	MovieHash string `json:"movie_hash,omitempty"`
+	Query         string `json:"query"`
+	Language      string `json:"language"`
+	Season        int    `json:"season,omitempty"`
+	Episode       int    `json:"episode,omitempty"`
+	Year          int    `json:"year,omitempty"`
+	Type          string `json:"type"`
+	MovieHash     string `json:"movie_hash,omitempty"`
+	MovieByteSize int64  `json:"movie_byte_size,omitempty"`
+	MediaPath     string `json:"media_path,omitempty"`
+	OrderBy       string `json:"order_by,omitempty"`
+	TrustedOnly   bool   `json:"trusted_only,omitempty"`
+	Page          int    `json:"page,omitempty"`
+
+	Languages []string `json:"languages,omitempty"`
 }
 
 type Subtitle struct {
-	ID          string    `json:"id"`
-	Language    string    `json:"language"`
-	ReleaseName string    `json:"release_name"`
-	FileName    string    `json:"file_name"`
-	FileID      string    `json:"file_id"`
-	Uploader    string    `json:"uploader"`
-	Rating      float64   `json:"rating"`
-	Downloads   int       `json:"download_count"`
-	UploadDate  time.Time `json:"upload_date"`
-	MovieHash   string    `json:"movie_hash"`
-	FPS         float64   `json:"fps"`
-	Duration    int       `json:"duration"`
-	SubFormat   string    `json:"sub_format"`
+	ID                string         `json:"id"`
+	Provider          string         `json:"provider,omitempty"`
+	Language          string         `json:"language"`
+	ReleaseName       string         `json:"release_name"`
+	FileName          string         `json:"file_name"`
+	FileID            string         `json:"file_id"`
+	Uploader          string         `json:"uploader"`
+	UploaderRank      string         `json:"uploader_rank,omitempty"`
+	Rating            float64        `json:"rating"`
+	Votes             int            `json:"votes"`
+	Downloads         int            `json:"download_count"`
+	UploadDate        time.Time      `json:"upload_date"`
+	MovieHash         string         `json:"movie_hash"`
+	FPS               float64        `json:"fps"`
+	Duration          int            `json:"duration"`
+	SubFormat         string         `json:"sub_format"`
+	HD                bool           `json:"hd"`
+	HearingImpaired   bool           `json:"hearing_impaired"`
+	FromTrusted       bool           `json:"from_trusted"`
+	ForeignPartsOnly  bool           `json:"foreign_parts_only"`
+	AITranslated      bool           `json:"ai_translated"`
+	MachineTranslated bool           `json:"machine_translated"`
+	MovieHashMatch    bool           `json:"moviehash_match,omitempty"`
+	Comments          string         `json:"comments,omitempty"`
+	URL               string         `json:"url,omitempty"`
+	FeatureTitle      string         `json:"feature_title,omitempty"`
+	FeatureType       string         `json:"feature_type,omitempty"`
+	FeatureYear       int            `json:"feature_year,omitempty"`
+	IMDBID            int            `json:"imdb_id,omitempty"`
+	TMDBID            int            `json:"tmdb_id,omitempty"`
+	Season            int            `json:"season,omitempty"`
+	Episode           int            `json:"episode,omitempty"`
+	Parts             []SubtitlePart `json:"parts,omitempty"`
+}
+
+type SubtitlePart struct {
+	FileID   string `json:"file_id"`
+	FileName string `json:"file_name"`
+	CD       int    `json:"cd_number"`
+}
+
+func (s *Subtitle) IsMultiPart() bool {
+	return len(s.Parts) > 1
+}
+
+func (s *Subtitle) Part(index int) *Subtitle {
+	part := *s
+	part.FileID = s.Parts[index].FileID
+	part.FileName = s.Parts[index].FileName
+	part.Parts = nil
+	return &part
+}
+
+type SupportedLanguage struct {
+	Code   string `json:"code"`
+	Name   string `json:"name"`
+	Native string `json:"native,omitempty"`
 }
 
 func (m *MediaInfo) IsEpisode() bool {
@@ -0,0 +1,11 @@
+package models
+
+type Capabilities struct {
+	TitleSearch bool     `json:"title_search"`
+	HashSearch  bool     `json:"hash_search,omitempty"`
+	IMDBSearch  bool     `json:"imdb_search,omitempty"`
+	NeedsAuth   bool     `json:"needs_auth,omitempty"`
+	Languages   []string `json:"languages,omitempty"`
+	RateLimit   string   `json:"rate_limit,omitempty"`
+	ASCIIQuery  bool     `json:"ascii_query,omitempty"`
+}
@@ -0,0 +1,153 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+const namePrefix = "subs-provider-"
+
+type Client struct {
+	Name string
+	Path string
+
+	capabilities *models.Capabilities
+
+	cmd *exec.Cmd
+	rpc *rpc.Client
+}
+
+func Discover(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin directory: %w", err)
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() || !isExecutable(entry.Name(), info.Mode()) {
+			continue
+		}
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func Load(ctx context.Context, path string) (*Client, error) {
+	cmd := exec.Command(path)
+	cmd.Env = append(os.Environ(), MagicCookieKey+"="+MagicCookieValue)
+	cmd.Stderr = os.Stderr
+
+	replies, requests, err := pipes(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to plugin %s: %w", path, err)
+	}
+	err = cmd.Start()
+	for _, file := range cmd.ExtraFiles {
+		file.Close()
+	}
+	if err != nil {
+		replies.Close()
+		requests.Close()
+		return nil, fmt.Errorf("failed to start plugin %s: %w", path, err)
+	}
+
+	client := &Client{
+		Path: path,
+		cmd:  cmd,
+		rpc:  rpc.NewClientWithCodec(jsonrpc.NewClientCodec(stdio{Reader: replies, Writer: requests, closers: []io.Closer{requests, replies}})),
+	}
+
+	var handshake HandshakeReply
+	if err := client.call(ctx, "Handshake", ProtocolVersion, &handshake); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("plugin %s %w: %w", path, ErrHandshake, err)
+	}
+	if handshake.ProtocolVersion != ProtocolVersion {
+		client.Close()
+		return nil, fmt.Errorf("plugin %s %w (%d), subs-cli needs %d", path, ErrProtocol, handshake.ProtocolVersion, ProtocolVersion)
+	}
+
+	client.Name = handshake.Name
+	client.capabilities = handshake.Capabilities
+	if client.Name == "" {
+		client.Name = strings.TrimPrefix(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)), namePrefix)
+	}
+	return client, nil
+}
+
+func (c *Client) call(ctx context.Context, method string, args, reply any) error {
+	call := c.rpc.Go(serviceName+"."+method, args, reply, make(chan *rpc.Call, 1))
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case done := <-call.Done:
+		var remote rpc.ServerError
+		switch {
+		case errors.Is(done.Error, rpc.ErrShutdown):
+			return fmt.Errorf("plugin %s %w", c.Path, ErrExited)
+		case errors.As(done.Error, &remote):
+			return decodeError(c.Name, string(remote))
+		}
+		return done.Error
+	}
+}
+
+func (c *Client) Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	var subtitles []*models.Subtitle
+	if err := c.call(ctx, "Search", params, &subtitles); err != nil {
+		return nil, err
+	}
+	for _, subtitle := range subtitles {
+		subtitle.Provider = c.Name
+	}
+	return subtitles, nil
+}
+
+func (c *Client) Download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error) {
+	var content []byte
+	if err := c.call(ctx, "Download", subtitle, &content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+func (c *Client) Authenticate(ctx context.Context) error {
+	return nil
+}
+
+func (c *Client) Capabilities() models.Capabilities {
+	if c.capabilities == nil {
+		return models.Capabilities{TitleSearch: true}
+	}
+	return *c.capabilities
+}
+
+func (c *Client) Close() error {
+	c.rpc.Close()
+	if c.cmd.Process != nil {
+		c.cmd.Process.Kill()
+	}
+	c.cmd.Wait()
+	return nil
+}
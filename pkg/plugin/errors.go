@@ -0,0 +1,60 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+var (
+	ErrHandshake = errors.New("did not answer the handshake")
+	ErrProtocol  = errors.New("speaks another protocol version")
+	ErrExited    = errors.New("exited")
+)
+
+var errorKinds = []struct {
+	code string
+	kind error
+}{
+	{"not_found", models.ErrNotFound},
+	{"quota_exceeded", models.ErrQuotaExceeded},
+	{"auth_failed", models.ErrAuthFailed},
+	{"unavailable", models.ErrProviderUnavailable},
+}
+
+type Error struct {
+	Plugin  string
+	Kind    error
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+func encodeError(err error) error {
+	if err == nil {
+		return nil
+	}
+	for _, k := range errorKinds {
+		if errors.Is(err, k.kind) {
+			return fmt.Errorf("[%s] %s", k.code, err)
+		}
+	}
+	return err
+}
+
+func decodeError(plugin, message string) error {
+	for _, k := range errorKinds {
+		if rest, ok := strings.CutPrefix(message, "["+k.code+"] "); ok {
+			return &Error{Plugin: plugin, Kind: k.kind, Message: rest}
+		}
+	}
+	return &Error{Plugin: plugin, Message: message}
+}
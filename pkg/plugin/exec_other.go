@@ -0,0 +1,27 @@
+//go:build !unix
+
+package plugin
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+func isExecutable(name string, mode os.FileMode) bool {
+	return strings.EqualFold(filepath.Ext(name), ".exe")
+}
+
+func pipes(cmd *exec.Cmd) (io.ReadCloser, io.WriteCloser, error) {
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, nil, err
+	}
+	return stdout, stdin, nil
+}
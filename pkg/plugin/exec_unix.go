@@ -0,0 +1,31 @@
+//go:build unix
+
+package plugin
+
+import (
+	"io"
+	"os"
+	"os/exec"
+)
+
+func isExecutable(name string, mode os.FileMode) bool {
+	return mode&0111 != 0
+}
+
+func pipes(cmd *exec.Cmd) (io.ReadCloser, io.WriteCloser, error) {
+	requests, toPlugin, err := os.Pipe()
+	if err != nil {
+		return nil, nil, err
+	}
+	fromPlugin, replies, err := os.Pipe()
+	if err != nil {
+		requests.Close()
+		toPlugin.Close()
+		return nil, nil, err
+	}
+
+	cmd.ExtraFiles = []*os.File{requests, replies}
+	cmd.Env = append(cmd.Env, pipesKey+"=3,4")
+	cmd.Stdout = os.Stderr
+	return fromPlugin, toPlugin, nil
+}
@@ -0,0 +1,44 @@
+//go:build unix
+
+package plugin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadNotAPlugin(t *testing.T) {
+	script := filepath.Join(t.TempDir(), "subs-provider-broken")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\nexit 0\n"), 0755))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := Load(ctx, script)
+	assert.ErrorIs(t, err, ErrHandshake)
+	assert.ErrorContains(t, err, "did not answer the handshake")
+}
+
+func TestDiscover(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "subs-provider-b"), []byte("#!/bin/sh\n"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "subs-provider-a"), []byte("#!/bin/sh\n"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.txt"), []byte("notes"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".hidden"), []byte("#!/bin/sh\n"), 0755))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0755))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "subs-provider-a"), filepath.Join(dir, "subs-provider-c")))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "missing"), filepath.Join(dir, "subs-provider-d")))
+
+	paths, err := Discover(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "subs-provider-a"), filepath.Join(dir, "subs-provider-b"), filepath.Join(dir, "subs-provider-c")}, paths)
+
+	paths, err = Discover(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, paths)
+}
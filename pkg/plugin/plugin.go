@@ -0,0 +1,121 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+const (
+	ProtocolVersion = 1
+
+	MagicCookieKey   = "SUBS_CLI_PLUGIN"
+	MagicCookieValue = "d1f0c6a2-subs-cli-provider"
+
+	pipesKey    = "SUBS_CLI_PLUGIN_PIPES"
+	serviceName = "Plugin"
+)
+
+type Provider interface {
+	Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error)
+	Download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error)
+}
+
+type Capable interface {
+	Capabilities() models.Capabilities
+}
+
+type HandshakeReply struct {
+	Name            string               `json:"name"`
+	ProtocolVersion int                  `json:"protocol_version"`
+	Capabilities    *models.Capabilities `json:"capabilities,omitempty"`
+}
+
+type stdio struct {
+	io.Reader
+	io.Writer
+	closers []io.Closer
+}
+
+func (s stdio) Close() error {
+	var first error
+	for _, c := range s.closers {
+		if err := c.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+func Serve(name string, provider Provider) {
+	if os.Getenv(MagicCookieKey) != MagicCookieValue {
+		fmt.Fprintln(os.Stderr, "This program is a subs-cli provider plugin. Copy it to ~/.subs-cli/plugins/ instead of running it directly.")
+		os.Exit(1)
+	}
+
+	server := rpc.NewServer()
+	if err := server.RegisterName(serviceName, &rpcServer{name: name, provider: provider}); err != nil {
+		fmt.Fprintf(os.Stderr, "plugin %s: %v\n", name, err)
+		os.Exit(1)
+	}
+	conn, err := serverConn()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "plugin %s: %v\n", name, err)
+		os.Exit(1)
+	}
+	server.ServeCodec(jsonrpc.NewServerCodec(conn))
+}
+
+func serverConn() (io.ReadWriteCloser, error) {
+	fds := os.Getenv(pipesKey)
+	if fds == "" {
+		conn := stdio{Reader: os.Stdin, Writer: os.Stdout}
+		os.Stdout = os.Stderr
+		return conn, nil
+	}
+
+	in, out, _ := strings.Cut(fds, ",")
+	inFD, err := strconv.Atoi(in)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q", pipesKey, fds)
+	}
+	outFD, err := strconv.Atoi(out)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q", pipesKey, fds)
+	}
+	requests, replies := os.NewFile(uintptr(inFD), "requests"), os.NewFile(uintptr(outFD), "replies")
+	return stdio{Reader: requests, Writer: replies, closers: []io.Closer{requests, replies}}, nil
+}
+
+type rpcServer struct {
+	name     string
+	provider Provider
+}
+
+func (s *rpcServer) Handshake(version int, reply *HandshakeReply) error {
+	*reply = HandshakeReply{Name: s.name, ProtocolVersion: ProtocolVersion}
+	if capable, ok := s.provider.(Capable); ok {
+		caps := capable.Capabilities()
+		reply.Capabilities = &caps
+	}
+	return nil
+}
+
+func (s *rpcServer) Search(params *models.SearchParams, reply *[]*models.Subtitle) error {
+	subtitles, err := s.provider.Search(context.Background(), params)
+	*reply = subtitles
+	return encodeError(err)
+}
+
+func (s *rpcServer) Download(subtitle *models.Subtitle, reply *[]byte) error {
+	content, err := s.provider.Download(context.Background(), subtitle)
+	*reply = content
+	return encodeError(err)
+}
@@ -0,0 +1,85 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+type fakeProvider struct{}
+
+func (fakeProvider) Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	fmt.Println("stray output that must not reach subs-cli")
+	switch params.Query {
+	case "fail":
+		return nil, errors.New("tracker is down")
+	case "quota":
+		return nil, fmt.Errorf("daily limit reached: %w", models.ErrQuotaExceeded)
+	}
+	if params.Query == "hang" {
+		time.Sleep(time.Minute)
+	}
+	return []*models.Subtitle{{ID: "42", FileID: "42", Language: params.Language, ReleaseName: params.Query + ".1080p"}}, nil
+}
+
+func (fakeProvider) Download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error) {
+	return []byte("1\n00:00:01,000 --> 00:00:02,000\nfrom plugin " + subtitle.ID + "\n"), nil
+}
+
+func (fakeProvider) Capabilities() models.Capabilities {
+	return models.Capabilities{TitleSearch: true, Languages: []string{"pt-BR"}}
+}
+
+func TestMain(m *testing.M) {
+	if os.Getenv(MagicCookieKey) == MagicCookieValue {
+		Serve("fake", fakeProvider{})
+		return
+	}
+	os.Exit(m.Run())
+}
+
+func TestClient(t *testing.T) {
+	executable, err := os.Executable()
+	require.NoError(t, err)
+
+	client, err := Load(context.Background(), executable)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	assert.Equal(t, "fake", client.Name)
+	assert.NoError(t, client.Authenticate(context.Background()))
+	assert.Equal(t, models.Capabilities{TitleSearch: true, Languages: []string{"pt-BR"}}, client.Capabilities())
+	assert.Equal(t, models.Capabilities{TitleSearch: true}, (&Client{}).Capabilities(), "plugins that declare nothing search by title")
+
+	subtitles, err := client.Search(context.Background(), &models.SearchParams{Query: "Movie", Language: "pt-BR"})
+	require.NoError(t, err)
+	require.Len(t, subtitles, 1)
+	assert.Equal(t, &models.Subtitle{ID: "42", FileID: "42", Provider: "fake", Language: "pt-BR", ReleaseName: "Movie.1080p"}, subtitles[0])
+
+	content, err := client.Download(context.Background(), subtitles[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "from plugin 42")
+
+	_, err = client.Search(context.Background(), &models.SearchParams{Query: "fail"})
+	assert.EqualError(t, err, "tracker is down")
+	var pluginErr *Error
+	require.ErrorAs(t, err, &pluginErr)
+	assert.Equal(t, "fake", pluginErr.Plugin)
+	assert.Nil(t, pluginErr.Kind)
+
+	_, err = client.Search(context.Background(), &models.SearchParams{Query: "quota"})
+	assert.EqualError(t, err, "daily limit reached: quota exceeded")
+	assert.ErrorIs(t, err, models.ErrQuotaExceeded, "error kinds survive the trip")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = client.Search(ctx, &models.SearchParams{Query: "hang"})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
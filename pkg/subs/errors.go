@@ -0,0 +1,14 @@
+package subs
+
+import (
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/parser"
+)
+
+var (
+	ErrNotFound            = api.ErrNotFound
+	ErrQuotaExceeded       = api.ErrQuotaExceeded
+	ErrAuthFailed          = api.ErrAuthFailed
+	ErrProviderUnavailable = api.ErrProviderUnavailable
+	ErrUnparseableFilename = parser.ErrUnparseableFilename
+)
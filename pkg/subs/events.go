@@ -0,0 +1,97 @@
+package subs
+
+import "github.com/carlosarraes/subs-cli/pkg/models"
+
+type Event interface {
+	event()
+}
+
+type FileStarted struct {
+	Path string
+}
+
+type MediaParsed struct {
+	Path  string
+	Media *models.MediaInfo
+}
+
+type SearchCompleted struct {
+	Path     string
+	Language string
+	Found    int
+	Filtered int
+	Err      error
+}
+
+type DownloadProgress struct {
+	Path       string
+	Target     string
+	Downloaded int64
+	Total      int64
+	Done       bool
+}
+
+type FallbackStarted struct {
+	Path string
+	Err  error
+}
+
+type FallbackFailed struct {
+	Path     string
+	Subtitle *models.Subtitle
+	Err      error
+}
+
+type FallbackExhausted struct {
+	Path string
+}
+
+type LengthMismatch struct {
+	Path string
+	Err  error
+}
+
+type MergeFailed struct {
+	Path string
+	Err  error
+}
+
+type DownloadFailed struct {
+	Path     string
+	Language string
+	Err      error
+}
+
+type FileFinished struct {
+	Path   string
+	Result *Result
+	Err    error
+}
+
+func (FileStarted) event()       {}
+func (MediaParsed) event()       {}
+func (SearchCompleted) event()   {}
+func (DownloadProgress) event()  {}
+func (FallbackStarted) event()   {}
+func (FallbackFailed) event()    {}
+func (FallbackExhausted) event() {}
+func (LengthMismatch) event()    {}
+func (MergeFailed) event()       {}
+func (DownloadFailed) event()    {}
+func (FileFinished) event()      {}
+
+type Observer interface {
+	Observe(event Event)
+}
+
+type ObserverFunc func(event Event)
+
+func (f ObserverFunc) Observe(event Event) {
+	f(event)
+}
+
+func Channel(events chan<- Event) Observer {
+	return ObserverFunc(func(event Event) {
+		events <- event
+	})
+}
@@ -0,0 +1,65 @@
+package subs
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessEvents(t *testing.T) {
+	server := newTestServer(t)
+	video := filepath.Join(t.TempDir(), "The.Matrix.1999.mkv")
+
+	var events []Event
+	engine, err := New(Options{
+		Languages: []string{"en", "es"},
+		APIKey:    "key",
+		BaseURL:   server.URL,
+		Observer:  ObserverFunc(func(event Event) { events = append(events, event) }),
+	})
+	require.NoError(t, err)
+
+	result, err := engine.Process(context.Background(), video)
+	require.NoError(t, err)
+
+	require.GreaterOrEqual(t, len(events), 6)
+	assert.Equal(t, FileStarted{Path: video}, events[0])
+	parsed, ok := events[1].(MediaParsed)
+	require.True(t, ok)
+	assert.Equal(t, "The Matrix", parsed.Media.Title)
+	assert.Equal(t, SearchCompleted{Path: video, Language: "en", Found: 3}, events[2])
+	assert.Equal(t, SearchCompleted{Path: video, Language: "es"}, events[3])
+
+	target := filepath.Join(filepath.Dir(video), "The.Matrix.1999.en.srt")
+	progress, ok := events[4].(DownloadProgress)
+	require.True(t, ok)
+	assert.Equal(t, target, progress.Target)
+	assert.Equal(t, int64(len(testSRT)), progress.Downloaded)
+	assert.Equal(t, DownloadProgress{Path: video, Target: target, Done: true}, events[len(events)-2])
+	assert.Equal(t, FileFinished{Path: video, Result: result}, events[len(events)-1])
+}
+
+func TestProcessEventsOnFailure(t *testing.T) {
+	var finished *FileFinished
+	engine, err := New(Options{Observer: ObserverFunc(func(event Event) {
+		if e, ok := event.(FileFinished); ok {
+			finished = &e
+		}
+	})})
+	require.NoError(t, err)
+
+	_, err = engine.Process(context.Background(), "random.mkv")
+	require.Error(t, err)
+	require.NotNil(t, finished)
+	assert.Equal(t, err, finished.Err)
+	assert.Nil(t, finished.Result)
+}
+
+func TestChannel(t *testing.T) {
+	events := make(chan Event, 1)
+	Channel(events).Observe(FileStarted{Path: "movie.mkv"})
+	assert.Equal(t, FileStarted{Path: "movie.mkv"}, <-events)
+}
@@ -0,0 +1,595 @@
+package subs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/internal/filter"
+	"github.com/carlosarraes/subs-cli/internal/fsutil"
+	"github.com/carlosarraes/subs-cli/internal/language"
+	"github.com/carlosarraes/subs-cli/internal/parser"
+	"github.com/carlosarraes/subs-cli/internal/score"
+	"github.com/carlosarraes/subs-cli/internal/subformat"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+const (
+	OrderDownloads = filter.OrderDownloads
+	OrderRating    = filter.OrderRating
+	OrderDate      = filter.OrderDate
+
+	DefaultPerm = 0644
+
+	maxFallbacks = 3
+)
+
+type Options struct {
+	Languages []string
+
+	APIKey    string
+	Username  string
+	Password  string
+	BaseURL   string
+	UserAgent string
+	Proxy     string
+	Timeout   time.Duration
+
+	MinRating    float64
+	MinDownloads int
+	Uploader     string
+	TrustedOnly  bool
+	OrderBy      string
+
+	MaxPerLanguage int
+	PlainNames     bool
+	Backup         bool
+	Perm           os.FileMode
+	MergeCDs       bool
+	CDDurations    []time.Duration
+
+	// Fallback tries up to three lower ranked candidates when Check
+	// rejects a download.
+	Fallback bool
+
+	Observer Observer
+
+	// Client replaces the OpenSubtitles client built from the credentials
+	// above for searches and downloads.
+	Client api.Client
+
+	// The hooks below replace a single step of the pipeline; nil keeps the
+	// engine's own behaviour. Rank orders the filtered results of one
+	// language, Select lets the user choose a subtitle (content already
+	// fetched for a preview is reused) and Confirm is asked before each
+	// download, stopping the file when it returns an error. Fetch downloads
+	// a single file, Check reports content that does not fit the media,
+	// Keep skips a target that should not be replaced and Save writes the
+	// subtitle, returning the path written or "" when nothing was.
+	Rank    func(mediaPath, language string, subtitles []*models.Subtitle) []*models.Subtitle
+	Select  func(language string) (*models.Subtitle, []byte)
+	Confirm func(language string, subtitle *models.Subtitle) (bool, error)
+	Fetch   func(ctx context.Context, subtitle *models.Subtitle, target string) ([]byte, error)
+	Check   func(content []byte) error
+	Keep    func(target, mediaPath string, subtitle *models.Subtitle) bool
+	Save    func(content []byte, subtitle *models.Subtitle, mediaPath, label string, withLanguage bool) (string, error)
+}
+
+type Engine struct {
+	opts      Options
+	filters   filter.Options
+	parser    *parser.Parser
+	searcher  searcher
+	retriever retriever
+}
+
+type searcher interface {
+	Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error)
+}
+
+type retriever interface {
+	Download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error)
+}
+
+type progressRetriever interface {
+	DownloadWithProgress(ctx context.Context, subtitle *models.Subtitle, onProgress api.ProgressFunc) ([]byte, error)
+}
+
+type Saved struct {
+	Language string
+	Subtitle *models.Subtitle
+	Path     string
+}
+
+type Found struct {
+	All        []*models.Subtitle
+	Results    map[string][]*models.Subtitle
+	Candidates map[string][]*models.Subtitle
+	Errs       map[string]error
+}
+
+// Request is a single subtitle to save next to a media file. Content holds
+// data already downloaded (the first CD of a multi-part subtitle) and
+// Spares the lower ranked candidates Fallback may use instead.
+type Request struct {
+	MediaPath    string
+	Language     string
+	Label        string
+	WithLanguage bool
+	Subtitle     *models.Subtitle
+	Content      []byte
+	Spares       *[]*models.Subtitle
+}
+
+type Result struct {
+	Path    string
+	Media   *models.MediaInfo
+	Saved   []Saved
+	Missing []string
+	Failed  map[string]error
+}
+
+func New(opts Options) (*Engine, error) {
+	if len(opts.Languages) == 0 {
+		opts.Languages = []string{"en"}
+	}
+	languages := make([]string, 0, len(opts.Languages))
+	for _, code := range opts.Languages {
+		normalized, err := language.Normalize(code)
+		if err != nil {
+			return nil, err
+		}
+		languages = append(languages, normalized)
+	}
+	opts.Languages = languages
+
+	if opts.MaxPerLanguage < 1 {
+		opts.MaxPerLanguage = 1
+	}
+	if opts.Perm == 0 {
+		opts.Perm = DefaultPerm
+	}
+
+	filters := filter.Options{
+		MinRating:    opts.MinRating,
+		MinDownloads: opts.MinDownloads,
+		Uploader:     opts.Uploader,
+		TrustedOnly:  opts.TrustedOnly,
+		OrderBy:      opts.OrderBy,
+	}
+	if err := filters.Validate(); err != nil {
+		return nil, err
+	}
+
+	client := opts.Client
+	if client == nil {
+		client = api.NewOpenSubtitlesClient(&api.Config{
+			APIKey:    opts.APIKey,
+			Username:  opts.Username,
+			Password:  opts.Password,
+			BaseURL:   opts.BaseURL,
+			UserAgent: opts.UserAgent,
+			Proxy:     opts.Proxy,
+			Timeout:   opts.Timeout,
+		})
+	}
+
+	return &Engine{
+		opts:      opts,
+		filters:   filters,
+		parser:    parser.New(),
+		searcher:  client,
+		retriever: client,
+	}, nil
+}
+
+func (e *Engine) Parse(mediaPath string) (*models.MediaInfo, error) {
+	return e.parser.Parse(filepath.Base(mediaPath))
+}
+
+func (e *Engine) notify(event Event) {
+	if e.opts.Observer != nil {
+		e.opts.Observer.Observe(event)
+	}
+}
+
+func (e *Engine) Search(ctx context.Context, info *models.MediaInfo) (map[string][]*models.Subtitle, error) {
+	return e.search(ctx, "", info)
+}
+
+func (e *Engine) search(ctx context.Context, mediaPath string, info *models.MediaInfo) (map[string][]*models.Subtitle, error) {
+	found := e.SearchLanguages(ctx, mediaPath, SearchParams(info, e.opts.OrderBy, e.opts.TrustedOnly), e.opts.Languages)
+	if len(found.Errs) < len(e.opts.Languages) {
+		return found.Candidates, nil
+	}
+
+	errs := make([]error, 0, len(e.opts.Languages))
+	for _, code := range e.opts.Languages {
+		errs = append(errs, fmt.Errorf("%s: %w", code, found.Errs[code]))
+	}
+	return nil, errors.Join(errs...)
+}
+
+// SearchLanguages looks up every language with params, letting the client
+// batch them into a single request, and filters and ranks the results.
+// A language whose providers answered only in part keeps what was found.
+func (e *Engine) SearchLanguages(ctx context.Context, mediaPath string, params *models.SearchParams, languages []string) *Found {
+	found := &Found{
+		All:        make([]*models.Subtitle, 0),
+		Results:    make(map[string][]*models.Subtitle),
+		Candidates: make(map[string][]*models.Subtitle),
+		Errs:       make(map[string]error),
+	}
+	for _, code := range languages {
+		params.Language = code
+		query := *params
+		if len(languages) > 1 {
+			query.Languages = languages
+		}
+		subtitles, err := e.searcher.Search(ctx, &query)
+		var partial *api.PartialResultsError
+		if err != nil && !(errors.As(err, &partial) && len(subtitles) > 0) {
+			e.notify(SearchCompleted{Path: mediaPath, Language: code, Err: err})
+			found.Errs[code] = err
+			continue
+		}
+
+		subtitles = filter.Dedupe(subtitles)
+		matches := filter.Apply(subtitles, e.filters)
+		e.notify(SearchCompleted{Path: mediaPath, Language: code, Found: len(matches), Filtered: len(subtitles) - len(matches), Err: err})
+		found.All = append(found.All, matches...)
+		found.Results[code] = matches
+		found.Candidates[code] = e.rank(mediaPath, code, matches)
+	}
+	return found
+}
+
+func (e *Engine) rank(mediaPath, code string, subtitles []*models.Subtitle) []*models.Subtitle {
+	if e.opts.Rank != nil {
+		return e.opts.Rank(mediaPath, code, subtitles)
+	}
+
+	ranked := Rank(subtitles, e.opts.OrderBy)
+	if e.opts.OrderBy != "" {
+		return ranked
+	}
+	var local score.Local
+	if mediaPath != "" {
+		local.Release = filepath.Base(mediaPath)
+	}
+	return score.New(score.DefaultWeights(), score.Preferences{}, local).Rank(ranked)
+}
+
+func (e *Engine) Download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error) {
+	if !subtitle.IsMultiPart() {
+		return e.fetch(ctx, subtitle, "", "")
+	}
+
+	parts := make([][]byte, len(subtitle.Parts))
+	for i := range subtitle.Parts {
+		content, err := e.fetch(ctx, subtitle.Part(i), "", "")
+		if err != nil {
+			return nil, fmt.Errorf("CD %d: %w", subtitle.Parts[i].CD, err)
+		}
+		parts[i] = content
+	}
+	return subformat.MergeSRT(parts, e.opts.CDDurations)
+}
+
+func (e *Engine) fetch(ctx context.Context, subtitle *models.Subtitle, mediaPath, target string) ([]byte, error) {
+	if e.opts.Fetch != nil {
+		return e.opts.Fetch(ctx, subtitle, target)
+	}
+
+	progress, ok := e.retriever.(progressRetriever)
+	if !ok || e.opts.Observer == nil {
+		return e.retriever.Download(ctx, subtitle)
+	}
+
+	content, err := progress.DownloadWithProgress(ctx, subtitle, func(downloaded, total int64) {
+		e.notify(DownloadProgress{Path: mediaPath, Target: target, Downloaded: downloaded, Total: total})
+	})
+	e.notify(DownloadProgress{Path: mediaPath, Target: target, Done: true})
+	return content, err
+}
+
+func (e *Engine) Save(content []byte, subtitle *models.Subtitle, mediaPath, label string, withLanguage bool) (string, error) {
+	subtitle.SubFormat = subformat.Resolve(content, subtitle.FileName, subtitle.SubFormat)
+	target := SubtitlePath(mediaPath, label, subtitle.SubFormat, withLanguage)
+
+	if err := fsutil.WriteFile(target, content, fsutil.WriteOptions{Perm: e.opts.Perm, Backup: e.opts.Backup}); err != nil {
+		return "", fmt.Errorf("failed to write subtitle file: %w", err)
+	}
+	return target, nil
+}
+
+func (e *Engine) save(request Request, content []byte, subtitle *models.Subtitle, label string, withLanguage bool) ([]Saved, error) {
+	save := e.Save
+	if e.opts.Save != nil {
+		save = e.opts.Save
+	}
+
+	target, err := save(content, subtitle, request.MediaPath, label, withLanguage)
+	if err != nil || target == "" {
+		return nil, err
+	}
+	return []Saved{{Language: request.Language, Subtitle: subtitle, Path: target}}, nil
+}
+
+func (e *Engine) check(content []byte) error {
+	if e.opts.Check == nil {
+		return nil
+	}
+	return e.opts.Check(content)
+}
+
+func (e *Engine) keep(target, mediaPath string, subtitle *models.Subtitle) bool {
+	return e.opts.Keep != nil && e.opts.Keep(target, mediaPath, subtitle)
+}
+
+// Get downloads and saves one subtitle. Multi-part subtitles are merged
+// when MergeCDs is set and saved one file per CD otherwise.
+func (e *Engine) Get(ctx context.Context, request Request) ([]Saved, error) {
+	subtitle := request.Subtitle
+	if subtitle.IsMultiPart() {
+		return e.getParts(ctx, request)
+	}
+
+	content := request.Content
+	if content == nil {
+		target := SubtitlePath(request.MediaPath, request.Label, subtitle.SubFormat, request.WithLanguage)
+		if e.keep(target, request.MediaPath, subtitle) {
+			return nil, nil
+		}
+		var err error
+		if content, err = e.fetch(ctx, subtitle, request.MediaPath, target); err != nil {
+			return nil, err
+		}
+	}
+
+	problem := e.check(content)
+	if problem != nil && request.Content == nil && request.Spares != nil && e.opts.Fallback {
+		subtitle, content, problem = e.fallback(ctx, request, subtitle, content, problem)
+	}
+
+	saved, err := e.save(request, content, subtitle, request.Label, request.WithLanguage)
+	if err != nil {
+		return nil, err
+	}
+	if problem != nil {
+		e.notify(LengthMismatch{Path: request.MediaPath, Err: problem})
+	}
+	return saved, nil
+}
+
+func (e *Engine) fallback(ctx context.Context, request Request, subtitle *models.Subtitle, content []byte, problem error) (*models.Subtitle, []byte, error) {
+	e.notify(FallbackStarted{Path: request.MediaPath, Err: problem})
+	spares := request.Spares
+	for tries := 0; len(*spares) > 0 && tries < maxFallbacks; tries++ {
+		alternate := (*spares)[0]
+		*spares = (*spares)[1:]
+		if alternate.IsMultiPart() {
+			continue
+		}
+
+		alternateContent, err := e.fetch(ctx, alternate, request.MediaPath, SubtitlePath(request.MediaPath, request.Label, alternate.SubFormat, request.WithLanguage))
+		if err != nil {
+			e.notify(FallbackFailed{Path: request.MediaPath, Subtitle: alternate, Err: err})
+			continue
+		}
+		if e.check(alternateContent) == nil {
+			return alternate, alternateContent, nil
+		}
+	}
+
+	e.notify(FallbackExhausted{Path: request.MediaPath})
+	return subtitle, content, problem
+}
+
+func (e *Engine) getParts(ctx context.Context, request Request) ([]Saved, error) {
+	subtitle := request.Subtitle
+	contents := make([][]byte, len(subtitle.Parts))
+	for i := range subtitle.Parts {
+		if i == 0 && request.Content != nil {
+			contents[i] = request.Content
+			continue
+		}
+
+		part := subtitle.Part(i)
+		target := SubtitlePath(request.MediaPath, PartLabel(request.Label, subtitle.Parts[i].CD, request.WithLanguage), part.SubFormat, true)
+		content, err := e.fetch(ctx, part, request.MediaPath, target)
+		if err != nil {
+			return nil, fmt.Errorf("CD %d: %w", subtitle.Parts[i].CD, err)
+		}
+		contents[i] = content
+	}
+
+	if e.opts.MergeCDs {
+		merged, err := subformat.MergeSRT(contents, e.opts.CDDurations)
+		if err == nil {
+			saved, err := e.save(request, merged, subtitle, request.Label, request.WithLanguage)
+			if err != nil {
+				return nil, err
+			}
+			if problem := e.check(merged); problem != nil {
+				e.notify(LengthMismatch{Path: request.MediaPath, Err: problem})
+			}
+			return saved, nil
+		}
+		e.notify(MergeFailed{Path: request.MediaPath, Err: err})
+	}
+
+	var saved []Saved
+	for i, content := range contents {
+		part, err := e.save(request, content, subtitle.Part(i), PartLabel(request.Label, subtitle.Parts[i].CD, request.WithLanguage), true)
+		if err != nil {
+			return saved, err
+		}
+		saved = append(saved, part...)
+	}
+	return saved, nil
+}
+
+// Deliver saves up to MaxPerLanguage of the ranked candidates of each
+// language, in order. It stops early when the download quota is used up
+// or Confirm returns an error.
+func (e *Engine) Deliver(ctx context.Context, mediaPath string, languages []string, candidates map[string][]*models.Subtitle) *Result {
+	result := &Result{Path: mediaPath, Failed: make(map[string]error)}
+	for i, code := range languages {
+		ranked := candidates[code]
+		picked, content := ranked, []byte(nil)
+		if len(picked) > e.opts.MaxPerLanguage {
+			picked = picked[:e.opts.MaxPerLanguage]
+		}
+		spares := append([]*models.Subtitle{}, ranked[len(picked):]...)
+		if e.opts.Select != nil {
+			subtitle, fetched := e.opts.Select(code)
+			picked, content = nil, fetched
+			if subtitle != nil {
+				picked = []*models.Subtitle{subtitle}
+			}
+		}
+		if len(picked) == 0 {
+			result.Missing = append(result.Missing, code)
+			continue
+		}
+
+		for k, subtitle := range picked {
+			if e.opts.Select == nil && e.opts.Confirm != nil {
+				ok, err := e.opts.Confirm(code, subtitle)
+				if err != nil {
+					return result
+				}
+				if !ok {
+					continue
+				}
+			}
+
+			saved, err := e.Get(ctx, Request{
+				MediaPath:    mediaPath,
+				Language:     code,
+				Label:        IndexedLanguage(code, k),
+				WithLanguage: k > 0 || i > 0 || !e.opts.PlainNames,
+				Subtitle:     subtitle,
+				Content:      content,
+				Spares:       &spares,
+			})
+			result.Saved = append(result.Saved, saved...)
+			if err != nil {
+				result.Failed[code] = errors.Join(result.Failed[code], err)
+				e.notify(DownloadFailed{Path: mediaPath, Language: code, Err: err})
+				if errors.Is(err, ErrQuotaExceeded) {
+					return result
+				}
+			}
+		}
+	}
+	return result
+}
+
+func (e *Engine) Process(ctx context.Context, mediaPath string) (*Result, error) {
+	e.notify(FileStarted{Path: mediaPath})
+	result, err := e.process(ctx, mediaPath)
+	e.notify(FileFinished{Path: mediaPath, Result: result, Err: err})
+	return result, err
+}
+
+func (e *Engine) process(ctx context.Context, mediaPath string) (*Result, error) {
+	info, err := e.Parse(mediaPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse filename: %w", err)
+	}
+	e.notify(MediaParsed{Path: mediaPath, Media: info})
+
+	candidates, err := e.search(ctx, mediaPath, info)
+	if err != nil {
+		return nil, fmt.Errorf("subtitle search failed: %w", err)
+	}
+
+	result := e.Deliver(ctx, mediaPath, e.opts.Languages, candidates)
+	result.Media = info
+	return result, nil
+}
+
+func SearchParams(info *models.MediaInfo, orderBy string, trustedOnly bool) *models.SearchParams {
+	params := &models.SearchParams{
+		Query:       info.Title,
+		Type:        "movie",
+		OrderBy:     orderBy,
+		TrustedOnly: trustedOnly,
+	}
+
+	if info.IsEpisode() {
+		params.Type = "episode"
+		params.Season = info.Season
+		params.Episode = info.Episode
+	}
+
+	if info.Year != "" {
+		if year, err := strconv.Atoi(info.Year); err == nil {
+			params.Year = year
+		}
+	}
+
+	return params
+}
+
+func Rank(subtitles []*models.Subtitle, orderBy string) []*models.Subtitle {
+	ranked := make([]*models.Subtitle, 0, len(subtitles))
+	for _, subtitle := range subtitles {
+		if subtitle.FileID != "" {
+			ranked = append(ranked, subtitle)
+		}
+	}
+	if orderBy == "" {
+		filter.Sort(ranked, filter.OrderDownloads)
+	}
+	return ranked
+}
+
+func Best(subtitles []*models.Subtitle, orderBy string) *models.Subtitle {
+	var best *models.Subtitle
+	for _, subtitle := range subtitles {
+		if subtitle.FileID == "" {
+			continue
+		}
+		if orderBy != "" {
+			return subtitle
+		}
+		if best == nil || subtitle.Downloads > best.Downloads {
+			best = subtitle
+		}
+	}
+	return best
+}
+
+func IndexedLanguage(code string, index int) string {
+	if index == 0 {
+		return code
+	}
+	return fmt.Sprintf("%s.%d", code, index+1)
+}
+
+func PartLabel(label string, cd int, withLanguage bool) string {
+	if !withLanguage {
+		return fmt.Sprintf("cd%d", cd)
+	}
+	return fmt.Sprintf("%s.cd%d", label, cd)
+}
+
+func SubtitlePath(mediaPath, label, format string, withLanguage bool) string {
+	if format == "" {
+		format = subformat.SRT
+	}
+	base := strings.TrimSuffix(mediaPath, filepath.Ext(mediaPath))
+	if !withLanguage {
+		return fmt.Sprintf("%s.%s", base, format)
+	}
+	return fmt.Sprintf("%s.%s.%s", base, label, format)
+}
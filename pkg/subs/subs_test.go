@@ -0,0 +1,235 @@
+package subs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/subs-cli/internal/api"
+	"github.com/carlosarraes/subs-cli/pkg/models"
+)
+
+const testSRT = "1\n00:00:01,000 --> 00:00:02,000\nHello\n\n"
+
+func newTestServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/subtitles":
+			assert.Equal(t, "The Matrix", r.URL.Query().Get("query"))
+			assert.Equal(t, "1999", r.URL.Query().Get("year"))
+			if !slices.Contains(strings.Split(r.URL.Query().Get("languages"), ","), "en") {
+				json.NewEncoder(w).Encode(map[string]any{"data": []any{}})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"data": []any{
+				map[string]any{"id": "1", "attributes": map[string]any{"language": "en", "release": "Matrix.Low", "download_count": 10, "files": []any{map[string]any{"file_id": 11, "file_name": "low.srt"}}}},
+				map[string]any{"id": "2", "attributes": map[string]any{"language": "en", "release": "Matrix.High", "download_count": 500, "files": []any{map[string]any{"file_id": 22, "file_name": "high.srt"}}}},
+				map[string]any{"id": "3", "attributes": map[string]any{"language": "en", "release": "Matrix.NoFile", "download_count": 900}},
+			}})
+		case "/download":
+			var request api.DownloadRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			json.NewEncoder(w).Encode(api.DownloadResponse{Link: "http://" + r.Host + "/file"})
+		case "/file":
+			w.Write([]byte(testSRT))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNew(t *testing.T) {
+	engine, err := New(Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"en"}, engine.opts.Languages)
+	assert.Equal(t, 1, engine.opts.MaxPerLanguage)
+	assert.Equal(t, os.FileMode(DefaultPerm), engine.opts.Perm)
+
+	engine, err = New(Options{Languages: []string{"pt_br", "EN"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pt-BR", "en"}, engine.opts.Languages)
+
+	_, err = New(Options{Languages: []string{"xx-invalid"}})
+	assert.Error(t, err)
+
+	_, err = New(Options{OrderBy: "size"})
+	assert.ErrorContains(t, err, "must be one of")
+}
+
+func TestProcess(t *testing.T) {
+	server := newTestServer(t)
+	dir := t.TempDir()
+	video := filepath.Join(dir, "The.Matrix.1999.1080p.BluRay.x264.mkv")
+
+	engine, err := New(Options{Languages: []string{"en", "es"}, APIKey: "key", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	result, err := engine.Process(context.Background(), video)
+	require.NoError(t, err)
+
+	assert.Equal(t, "The Matrix", result.Media.Title)
+	assert.Equal(t, []string{"es"}, result.Missing)
+	assert.Empty(t, result.Failed)
+	require.Len(t, result.Saved, 1)
+	assert.Equal(t, "Matrix.High", result.Saved[0].Subtitle.ReleaseName)
+	assert.Equal(t, filepath.Join(dir, "The.Matrix.1999.1080p.BluRay.x264.en.srt"), result.Saved[0].Path)
+
+	content, err := os.ReadFile(result.Saved[0].Path)
+	require.NoError(t, err)
+	assert.Equal(t, testSRT, string(content))
+}
+
+func TestProcessUnparseable(t *testing.T) {
+	engine, err := New(Options{})
+	require.NoError(t, err)
+
+	_, err = engine.Process(context.Background(), "random.mkv")
+	assert.ErrorIs(t, err, ErrUnparseableFilename)
+}
+
+func TestProcessPlainNames(t *testing.T) {
+	server := newTestServer(t)
+	dir := t.TempDir()
+	video := filepath.Join(dir, "The.Matrix.1999.mkv")
+
+	engine, err := New(Options{APIKey: "key", BaseURL: server.URL, PlainNames: true, MaxPerLanguage: 5})
+	require.NoError(t, err)
+
+	result, err := engine.Process(context.Background(), video)
+	require.NoError(t, err)
+	require.Len(t, result.Saved, 2)
+	assert.Equal(t, filepath.Join(dir, "The.Matrix.1999.srt"), result.Saved[0].Path)
+	assert.Equal(t, filepath.Join(dir, "The.Matrix.1999.en.2.srt"), result.Saved[1].Path)
+}
+
+func TestSearchFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	engine, err := New(Options{APIKey: "key", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	_, err = engine.Process(context.Background(), "The.Matrix.1999.mkv")
+	assert.ErrorContains(t, err, "subtitle search failed: en: search failed with status 500")
+	assert.ErrorIs(t, err, ErrProviderUnavailable)
+}
+
+func TestSearchParams(t *testing.T) {
+	params := SearchParams(&models.MediaInfo{Title: "Show", Type: "episode", Season: 2, Episode: 5, Year: "2020"}, OrderRating, true)
+	assert.Equal(t, &models.SearchParams{Query: "Show", Type: "episode", Season: 2, Episode: 5, Year: 2020, OrderBy: OrderRating, TrustedOnly: true}, params)
+
+	params = SearchParams(&models.MediaInfo{Title: "Movie", Type: "movie", Year: "n/a"}, "", false)
+	assert.Equal(t, &models.SearchParams{Query: "Movie", Type: "movie"}, params)
+}
+
+func TestRankAndBest(t *testing.T) {
+	subtitles := []*models.Subtitle{
+		{ID: "a", FileID: "1", Downloads: 5},
+		{ID: "b", Downloads: 100},
+		{ID: "c", FileID: "3", Downloads: 50},
+	}
+
+	ranked := Rank(subtitles, "")
+	require.Len(t, ranked, 2)
+	assert.Equal(t, "c", ranked[0].ID)
+	assert.Equal(t, "a", ranked[1].ID)
+	assert.Equal(t, "a", Rank(subtitles, OrderRating)[0].ID)
+
+	assert.Equal(t, "c", Best(subtitles, "").ID)
+	assert.Equal(t, "a", Best(subtitles, OrderDate).ID)
+	assert.Nil(t, Best(nil, ""))
+}
+
+func TestSubtitlePath(t *testing.T) {
+	assert.Equal(t, "/m/movie.en.srt", SubtitlePath("/m/movie.mkv", "en", "", true))
+	assert.Equal(t, "/m/movie.ass", SubtitlePath("/m/movie.mkv", "en", "ass", false))
+	assert.Equal(t, "/m/movie.en.3.srt", SubtitlePath("/m/movie.mkv", IndexedLanguage("en", 2), "srt", true))
+}
+
+type fakeClient struct {
+	searches [][]string
+	files    map[string]string
+}
+
+func (f *fakeClient) Search(ctx context.Context, params *models.SearchParams) ([]*models.Subtitle, error) {
+	f.searches = append(f.searches, params.Languages)
+	return nil, nil
+}
+
+func (f *fakeClient) Download(ctx context.Context, subtitle *models.Subtitle) ([]byte, error) {
+	content, ok := f.files[subtitle.FileID]
+	if !ok {
+		return nil, fmt.Errorf("file %s: %w", subtitle.FileID, ErrNotFound)
+	}
+	return []byte(content), nil
+}
+
+func (f *fakeClient) Authenticate(ctx context.Context) error {
+	return nil
+}
+
+func TestSearchLanguagesBatches(t *testing.T) {
+	client := &fakeClient{}
+	engine, err := New(Options{Client: client})
+	require.NoError(t, err)
+
+	found := engine.SearchLanguages(context.Background(), "", &models.SearchParams{Query: "Movie"}, []string{"en", "es"})
+	assert.Empty(t, found.Errs)
+	assert.Equal(t, [][]string{{"en", "es"}, {"en", "es"}}, client.searches)
+}
+
+func TestDeliverKeepsEveryFailure(t *testing.T) {
+	engine, err := New(Options{Client: &fakeClient{}, MaxPerLanguage: 2})
+	require.NoError(t, err)
+
+	candidates := map[string][]*models.Subtitle{"en": {{FileID: "1"}, {FileID: "2"}}}
+	result := engine.Deliver(context.Background(), filepath.Join(t.TempDir(), "Movie.mkv"), []string{"en"}, candidates)
+	assert.Empty(t, result.Saved)
+	assert.ErrorContains(t, result.Failed["en"], "file 1")
+	assert.ErrorContains(t, result.Failed["en"], "file 2")
+}
+
+func TestGetFallback(t *testing.T) {
+	client := &fakeClient{files: map[string]string{"1": "long", "3": testSRT}}
+	var events []Event
+	engine, err := New(Options{
+		Client:   client,
+		Fallback: true,
+		Observer: ObserverFunc(func(event Event) { events = append(events, event) }),
+		Check: func(content []byte) error {
+			if string(content) == "long" {
+				return errors.New("too long")
+			}
+			return nil
+		},
+	})
+	require.NoError(t, err)
+
+	media := filepath.Join(t.TempDir(), "Movie.mkv")
+	spares := []*models.Subtitle{{ID: "b", FileID: "2"}, {ID: "c", FileID: "3"}}
+	saved, err := engine.Get(context.Background(), Request{MediaPath: media, Language: "en", Label: "en", WithLanguage: true, Subtitle: &models.Subtitle{ID: "a", FileID: "1"}, Spares: &spares})
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Equal(t, "c", saved[0].Subtitle.ID)
+	assert.Empty(t, spares)
+
+	assert.Contains(t, events, Event(FallbackStarted{Path: media, Err: errors.New("too long")}))
+	assert.NotContains(t, events, Event(FallbackExhausted{Path: media}))
+	content, err := os.ReadFile(saved[0].Path)
+	require.NoError(t, err)
+	assert.Equal(t, testSRT, string(content))
+}